@@ -0,0 +1,226 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-json"
+)
+
+const (
+	// backupInfix separates a settings file's name from its backup timestamp
+	backupInfix = ".backup-"
+	// backupTimeFormat is the timestamp of a backup's name
+	backupTimeFormat = "20060102-150405"
+	// checksumSuffix names the file holding a backup's SHA-256, in sha256sum format
+	checksumSuffix = ".sha256"
+	// defaultKeepBackups is how many backups of each settings file are kept
+	defaultKeepBackups = 5
+)
+
+// errNoChecksum is returned for backups made before checksums were recorded
+var errNoChecksum = errors.New("backup has no checksum")
+
+// settingsBackup is a backup of a settings file
+type settingsBackup struct {
+	Path     string
+	Settings string
+	Created  time.Time
+}
+
+// createBackup copies settingsPath to a timestamped backup with a checksum
+// file, then removes the oldest backups beyond keep. keep 0 keeps them all.
+func createBackup(settingsPath string, keep int) (string, error) {
+	data, err := os.ReadFile(settingsPath)
+	if err != nil {
+		return "", err
+	}
+	backupPath := settingsPath + backupInfix + time.Now().Format(backupTimeFormat)
+	if err := os.WriteFile(backupPath, data, 0600); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(backupPath+checksumSuffix, []byte(checksumLine(data, backupPath)), 0600); err != nil {
+		return "", err
+	}
+	if _, err := pruneBackups(settingsPath, keep); err != nil {
+		return backupPath, fmt.Errorf("failed to remove old backups: %w", err)
+	}
+	return backupPath, nil
+}
+
+// checksumLine formats the SHA-256 of data as sha256sum prints it for path
+func checksumLine(data []byte, path string) string {
+	sum := sha256.Sum256(data)
+	return fmt.Sprintf("%s  %s\n", hex.EncodeToString(sum[:]), filepath.Base(path))
+}
+
+// listBackups returns the backups of settingsPath, newest first
+func listBackups(settingsPath string) ([]settingsBackup, error) {
+	entries, err := os.ReadDir(filepath.Dir(settingsPath))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := filepath.Base(settingsPath) + backupInfix
+	var backups []settingsBackup
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		created, err := time.ParseInLocation(backupTimeFormat, strings.TrimPrefix(name, prefix), time.Local)
+		if err != nil {
+			continue // checksum files and unrelated names
+		}
+		backups = append(backups, settingsBackup{
+			Path:     filepath.Join(filepath.Dir(settingsPath), name),
+			Settings: settingsPath,
+			Created:  created,
+		})
+	}
+	sort.Slice(backups, func(i, j int) bool { return backups[i].Created.After(backups[j].Created) })
+	return backups, nil
+}
+
+// pruneBackups removes the backups of settingsPath beyond the newest keep and
+// returns the removed paths. keep 0 keeps them all.
+func pruneBackups(settingsPath string, keep int) ([]string, error) {
+	if keep <= 0 {
+		return nil, nil
+	}
+	backups, err := listBackups(settingsPath)
+	if err != nil || len(backups) <= keep {
+		return nil, err
+	}
+	var removed []string
+	for _, backup := range backups[keep:] {
+		if err := os.Remove(backup.Path); err != nil {
+			return removed, err
+		}
+		_ = os.Remove(backup.Path + checksumSuffix)
+		removed = append(removed, backup.Path)
+	}
+	return removed, nil
+}
+
+// verifyBackup checks the backup against its recorded checksum and that it
+// holds valid JSON, returning its content
+func verifyBackup(backupPath string) ([]byte, error) {
+	data, err := os.ReadFile(backupPath)
+	if err != nil {
+		return nil, err
+	}
+	recorded, err := os.ReadFile(backupPath + checksumSuffix)
+	if os.IsNotExist(err) {
+		return data, errNoChecksum
+	}
+	if err != nil {
+		return nil, err
+	}
+	fields := strings.Fields(string(recorded))
+	sum := sha256.Sum256(data)
+	if len(fields) == 0 || !strings.EqualFold(fields[0], hex.EncodeToString(sum[:])) {
+		return nil, fmt.Errorf("%s does not match its checksum; it was modified or corrupted", backupPath)
+	}
+	if !json.Valid(data) {
+		return nil, fmt.Errorf("%s is not valid JSON", backupPath)
+	}
+	return data, nil
+}
+
+// runRestore lists the backups of settingsPaths, or with a selection restores
+// one: its number in the list or its path. The settings file is backed up
+// before being replaced, so a restore can be undone. Backups without a
+// checksum are restored only with force.
+func runRestore(w io.Writer, settingsPaths []string, selection string, force bool, keep int) error {
+	var backups []settingsBackup
+	for _, settingsPath := range settingsPaths {
+		found, err := listBackups(settingsPath)
+		if err != nil {
+			return fmt.Errorf("failed to list backups of %s: %w", settingsPath, err)
+		}
+		backups = append(backups, found...)
+	}
+
+	if selection == "" {
+		if len(backups) == 0 {
+			fmt.Fprintf(w, "No backups found of %s\n", strings.Join(settingsPaths, " or "))
+			return nil
+		}
+		fmt.Fprintln(w, "Backups, newest first:")
+		for i, backup := range backups {
+			status := "✓ checksum ok"
+			if _, err := verifyBackup(backup.Path); errors.Is(err, errNoChecksum) {
+				status = "? no checksum"
+			} else if err != nil {
+				status = "✗ " + err.Error()
+			}
+			fmt.Fprintf(w, "  %d. %s  %s  %s\n", i+1, backup.Path, backup.Created.Format("2006-01-02 15:04:05"), status)
+		}
+		fmt.Fprintln(w, "\nRestore one with: gismo init --restore <number|path>")
+		return nil
+	}
+
+	backup, err := selectBackup(backups, selection)
+	if err != nil {
+		return err
+	}
+	data, err := verifyBackup(backup.Path)
+	if errors.Is(err, errNoChecksum) && force {
+		if !json.Valid(data) {
+			return fmt.Errorf("%s is not valid JSON", backup.Path)
+		}
+		err = nil
+	}
+	if errors.Is(err, errNoChecksum) {
+		return fmt.Errorf("%s has no checksum to verify; use --force to restore it anyway", backup.Path)
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(backup.Settings); err == nil {
+		current, err := createBackup(backup.Settings, keep)
+		if err != nil {
+			return fmt.Errorf("failed to back up current settings: %w", err)
+		}
+		fmt.Fprintf(w, "✓ Backed up current settings: %s\n", current)
+	}
+	if err := os.WriteFile(backup.Settings, data, 0600); err != nil {
+		return fmt.Errorf("failed to restore settings: %w", err)
+	}
+	fmt.Fprintf(w, "✓ Restored %s from %s\n", backup.Settings, backup.Path)
+	return nil
+}
+
+// selectBackup finds a backup by its number in the list or its path
+func selectBackup(backups []settingsBackup, selection string) (settingsBackup, error) {
+	if n, err := strconv.Atoi(selection); err == nil {
+		if n < 1 || n > len(backups) {
+			return settingsBackup{}, fmt.Errorf("no backup number %d; run gismo init --restore to list them", n)
+		}
+		return backups[n-1], nil
+	}
+	abs, err := filepath.Abs(selection)
+	if err != nil {
+		return settingsBackup{}, err
+	}
+	for _, backup := range backups {
+		if path, err := filepath.Abs(backup.Path); err == nil && path == abs {
+			return backup, nil
+		}
+	}
+	return settingsBackup{}, fmt.Errorf("%s is not a backup of the selected settings files", selection)
+}
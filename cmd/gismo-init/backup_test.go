@@ -0,0 +1,150 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeBackup writes a backup of settingsPath made at created, with a
+// checksum file unless legacy
+func writeBackup(t *testing.T, settingsPath string, created time.Time, content string, legacy bool) string {
+	t.Helper()
+	path := settingsPath + backupInfix + created.Format(backupTimeFormat)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if !legacy {
+		if err := os.WriteFile(path+checksumSuffix, []byte(checksumLine([]byte(content), path)), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return path
+}
+
+func TestCreateBackup_Retention(t *testing.T) {
+	settingsPath := filepath.Join(t.TempDir(), "settings.json")
+	if err := os.WriteFile(settingsPath, []byte(`{"hooks":{}}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now().Add(-time.Hour)
+	var old []string
+	for i := range 4 {
+		old = append(old, writeBackup(t, settingsPath, start.Add(time.Duration(i)*time.Minute), "{}", false))
+	}
+
+	backupPath, err := createBackup(settingsPath, 3)
+	if err != nil {
+		t.Fatalf("createBackup() error = %v", err)
+	}
+	if _, err := verifyBackup(backupPath); err != nil {
+		t.Errorf("new backup fails verification: %v", err)
+	}
+
+	backups, err := listBackups(settingsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{backupPath, old[3], old[2]}
+	if len(backups) != len(want) {
+		t.Fatalf("kept %d backups, want %d", len(backups), len(want))
+	}
+	for i, backup := range backups {
+		if backup.Path != want[i] {
+			t.Errorf("backups[%d] = %s, want %s", i, backup.Path, want[i])
+		}
+	}
+	for _, removed := range old[:2] {
+		for _, path := range []string{removed, removed + checksumSuffix} {
+			if _, err := os.Stat(path); !os.IsNotExist(err) {
+				t.Errorf("%s not removed", path)
+			}
+		}
+	}
+}
+
+func TestVerifyBackup(t *testing.T) {
+	settingsPath := filepath.Join(t.TempDir(), "settings.json")
+	created := time.Now().Add(-time.Hour)
+
+	valid := writeBackup(t, settingsPath, created, `{"a":1}`, false)
+	if _, err := verifyBackup(valid); err != nil {
+		t.Errorf("valid backup: %v", err)
+	}
+
+	tampered := writeBackup(t, settingsPath, created.Add(time.Minute), `{"a":1}`, false)
+	if err := os.WriteFile(tampered, []byte(`{"a":2}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := verifyBackup(tampered); err == nil || !strings.Contains(err.Error(), "checksum") {
+		t.Errorf("tampered backup: error = %v, want a checksum mismatch", err)
+	}
+
+	invalid := writeBackup(t, settingsPath, created.Add(2*time.Minute), `{"a":`, false)
+	if _, err := verifyBackup(invalid); err == nil || !strings.Contains(err.Error(), "not valid JSON") {
+		t.Errorf("invalid backup: error = %v, want invalid JSON", err)
+	}
+
+	legacy := writeBackup(t, settingsPath, created.Add(3*time.Minute), `{"a":1}`, true)
+	if _, err := verifyBackup(legacy); err != errNoChecksum {
+		t.Errorf("legacy backup: error = %v, want errNoChecksum", err)
+	}
+}
+
+func TestRunRestore(t *testing.T) {
+	dir := t.TempDir()
+	settingsPath := filepath.Join(dir, "settings.json")
+	if err := os.WriteFile(settingsPath, []byte(`{"current":true}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	created := time.Now().Add(-time.Hour)
+	legacy := writeBackup(t, settingsPath, created, `{"legacy":true}`, true)
+	backup := writeBackup(t, settingsPath, created.Add(time.Minute), `{"restored":true}`, false)
+
+	var out strings.Builder
+	if err := runRestore(&out, []string{settingsPath}, "", false, defaultKeepBackups); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"1. " + backup, "checksum ok", "2. " + legacy, "no checksum"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("listing missing %q:\n%s", want, out.String())
+		}
+	}
+
+	if err := runRestore(&out, []string{settingsPath}, "3", false, defaultKeepBackups); err == nil {
+		t.Error("restoring a backup number past the list succeeded")
+	}
+	if err := runRestore(&out, []string{settingsPath}, legacy, false, defaultKeepBackups); err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Errorf("restoring a legacy backup without force: error = %v", err)
+	}
+
+	if err := runRestore(&out, []string{settingsPath}, "1", false, defaultKeepBackups); err != nil {
+		t.Fatalf("runRestore() error = %v", err)
+	}
+	data, err := os.ReadFile(settingsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"restored":true}` {
+		t.Errorf("settings = %s after restore", data)
+	}
+
+	// The replaced settings are backed up so the restore can be undone
+	backups, err := listBackups(settingsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backups) != 3 {
+		t.Fatalf("got %d backups after restore, want 3", len(backups))
+	}
+	previous, err := verifyBackup(backups[0].Path)
+	if err != nil || string(previous) != `{"current":true}` {
+		t.Errorf("newest backup = %s, %v, want the replaced settings", previous, err)
+	}
+
+	if err := runRestore(&out, []string{settingsPath}, legacy, true, defaultKeepBackups); err != nil {
+		t.Errorf("restoring a legacy backup with force: %v", err)
+	}
+}
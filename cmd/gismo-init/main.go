@@ -7,7 +7,6 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
-	"time"
 
 	"github.com/goccy/go-json"
 )
@@ -40,50 +39,107 @@ type ClaudeHookConfig struct {
 	ContinueOnError bool   `json:"continueOnError,omitempty"`
 }
 
+// Exit codes, so scripts can tell what init did
+const (
+	// exitChanged means settings were updated
+	exitChanged = 0
+	// exitError means settings couldn't be read or written
+	exitError = 1
+	// exitConfigured means every settings file already had the gismo hook
+	exitConfigured = 3
+	// exitSkipped means changes are needed but weren't applied: a dry run, a
+	// declined prompt, or a run without a terminal and without --yes
+	exitSkipped = 4
+)
+
+// settingsOutcome is what init did to a settings file, in increasing precedence
+// when several files are processed
+type settingsOutcome int
+
+const (
+	outcomeConfigured settingsOutcome = iota
+	outcomeSkipped
+	outcomeChanged
+)
+
+// exitCode returns the process exit code for the outcome
+func (o settingsOutcome) exitCode() int {
+	switch o {
+	case outcomeChanged:
+		return exitChanged
+	case outcomeSkipped:
+		return exitSkipped
+	default:
+		return exitConfigured
+	}
+}
+
 func main() {
 	// Define flags
 	globalOnly := flag.Bool("global", false, "Only update global settings (~/.claude/settings.json)")
 	projectOnly := flag.Bool("project", false, "Only update project settings (.claude/settings.json)")
 	dryRun := flag.Bool("dry-run", false, "Show what would be changed without applying")
 	force := flag.Bool("force", false, "Apply changes without confirmation")
+	yes := flag.Bool("yes", false, "Apply changes without confirmation, for scripts and provisioning")
 	matcher := flag.String("matcher", "", "Tool matcher pattern (empty string matches all tools)")
+	keepBackups := flag.Int("keep-backups", defaultKeepBackups, "Number of settings backups to keep per file (0 keeps all)")
+	restore := flag.Bool("restore", false, "List settings backups, or restore the one given by number or path")
 
 	flag.Usage = func() {
-		fmt.Fprintf(os.Stderr, "Usage: gismo-init [options]\n\n")
+		fmt.Fprintf(os.Stderr, "Usage: gismo-init [options]\n")
+		fmt.Fprintf(os.Stderr, "       gismo-init -restore [number|path]\n\n")
 		fmt.Fprintf(os.Stderr, "Initialize gismo hooks in Claude Code settings\n\n")
 		fmt.Fprintf(os.Stderr, "Options:\n")
 		flag.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\nExit codes: %d changed, %d error, %d already configured, %d skipped\n",
+			exitChanged, exitError, exitConfigured, exitSkipped)
 	}
 
 	flag.Parse()
 
+	if *restore {
+		settingsPaths, err := settingsFiles(*globalOnly, *projectOnly)
+		if err == nil {
+			err = runRestore(os.Stdout, settingsPaths, flag.Arg(0), *force, *keepBackups)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Set default matcher if not specified
 	if *matcher == "" {
 		*matcher = "Write|Edit|MultiEdit"
 	}
 
+	// Without a terminal nobody can answer the prompt, so only show the changes
+	if !*dryRun && !*force && !*yes && !isInteractive() {
+		fmt.Fprintf(os.Stderr, "Note: stdin is not a terminal, showing changes without applying them; use --yes to apply\n\n")
+		*dryRun = true
+	}
+
 	// Run init command
-	if err := runInit(*globalOnly, *projectOnly, *dryRun, *force, *matcher); err != nil {
+	outcome, err := runInit(*globalOnly, *projectOnly, *dryRun, *force || *yes, *matcher, *keepBackups)
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitError)
 	}
+	os.Exit(outcome.exitCode())
 }
 
-func runInit(globalOnly, projectOnly, dryRun, force bool, matcher string) error {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return fmt.Errorf("failed to get home directory: %w", err)
-	}
+// isInteractive reports whether stdin is a terminal that can answer prompts
+func isInteractive() bool {
+	info, err := os.Stdin.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
 
+func runInit(globalOnly, projectOnly, dryRun, force bool, matcher string, keepBackups int) (settingsOutcome, error) {
 	// Determine which settings files to update
-	var settingsPaths []string
-	if !projectOnly {
-		globalPath := filepath.Join(homeDir, ".claude", "settings.json")
-		settingsPaths = append(settingsPaths, globalPath)
-	}
-	if !globalOnly {
-		projectPath := filepath.Join(".claude", "settings.json")
-		settingsPaths = append(settingsPaths, projectPath)
+	settingsPaths, err := settingsFiles(globalOnly, projectOnly)
+	if err != nil {
+		return outcomeConfigured, err
 	}
 
 	// Check if gismo is in PATH
@@ -92,43 +148,52 @@ func runInit(globalOnly, projectOnly, dryRun, force bool, matcher string) error
 		fmt.Fprintf(os.Stderr, "Make sure gismo is installed and available in your PATH\n\n")
 	}
 
-	// Track if any changes were made
-	changesMade := false
+	// The outcome with the highest precedence across the files
+	outcome := outcomeConfigured
 	applyToAll := false
 
 	// Process each settings file
-	for i, settingsPath := range settingsPaths {
+	for _, settingsPath := range settingsPaths {
 		fmt.Printf("Processing: %s\n", settingsPath)
 
-		// If user selected "apply to all" on previous file, set force flag
-		forceThis := force || applyToAll
-
-		wasModified, err := processSettingsFile(settingsPath, matcher, dryRun, forceThis)
+		// If user selected "apply to all" on a previous file, don't ask again
+		result, all, err := processSettingsFile(settingsPath, matcher, dryRun, force || applyToAll, keepBackups)
 		if err != nil {
-			return fmt.Errorf("failed to process %s: %w", settingsPath, err)
-		}
-
-		// Check if user selected "apply to all"
-		if wasModified && !force && i == 0 && len(settingsPaths) > 1 {
-			applyToAll = true
-		}
-
-		if wasModified {
-			changesMade = true
+			return outcome, fmt.Errorf("failed to process %s: %w", settingsPath, err)
 		}
+		applyToAll = applyToAll || all
+		outcome = max(outcome, result)
 		fmt.Println()
 	}
 
 	// Show next steps only if changes were actually made
-	if !dryRun && changesMade {
+	if outcome == outcomeChanged {
 		showNextSteps()
 	}
 
-	return nil
+	return outcome, nil
+}
+
+// settingsFiles returns the Claude Code settings files selected by the flags
+func settingsFiles(globalOnly, projectOnly bool) ([]string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get home directory: %w", err)
+	}
+
+	var settingsPaths []string
+	if !projectOnly {
+		settingsPaths = append(settingsPaths, filepath.Join(homeDir, ".claude", "settings.json"))
+	}
+	if !globalOnly {
+		settingsPaths = append(settingsPaths, filepath.Join(".claude", "settings.json"))
+	}
+	return settingsPaths, nil
 }
 
-// processSettingsFile handles a single settings file
-func processSettingsFile(settingsPath, matcher string, dryRun, force bool) (bool, error) {
+// processSettingsFile handles a single settings file. applyToAll reports that
+// the user chose to apply the changes to the remaining files too.
+func processSettingsFile(settingsPath, matcher string, dryRun, force bool, keepBackups int) (outcome settingsOutcome, applyToAll bool, err error) {
 	// ANSI color codes
 	const (
 		red    = "\033[31m"
@@ -151,7 +216,7 @@ func processSettingsFile(settingsPath, matcher string, dryRun, force bool) (bool
 	// Read existing settings
 	settings, extraFields, err := readClaudeSettings(settingsPath)
 	if err != nil && !os.IsNotExist(err) {
-		return false, fmt.Errorf("failed to read settings: %w", err)
+		return outcomeConfigured, false, fmt.Errorf("failed to read settings: %w", err)
 	}
 
 	// Store original for comparison
@@ -163,13 +228,13 @@ func processSettingsFile(settingsPath, matcher string, dryRun, force bool) (bool
 	// Marshal the modified settings
 	modifiedJSON, err := marshalClaudeSettings(modified, extraFields)
 	if err != nil {
-		return false, fmt.Errorf("failed to marshal settings: %w", err)
+		return outcomeConfigured, false, fmt.Errorf("failed to marshal settings: %w", err)
 	}
 
 	// Check if anything changed
 	if string(originalJSON) == string(modifiedJSON) {
 		fmt.Printf("%s✓ CCFeedback hook is already configured correctly%s\n", green, reset)
-		return false, nil
+		return outcomeConfigured, false, nil
 	}
 
 	// Display changes with clear indication of scope
@@ -179,7 +244,7 @@ func processSettingsFile(settingsPath, matcher string, dryRun, force bool) (bool
 
 	if dryRun {
 		fmt.Println("\n(Dry run - no changes were made)")
-		return false, nil
+		return outcomeSkipped, false, nil
 	}
 
 	// Ask for confirmation unless forced
@@ -198,32 +263,36 @@ func processSettingsFile(settingsPath, matcher string, dryRun, force bool) (bool
 			// Continue with just this file
 		case "a", "all":
 			// Apply to this file and signal to apply to all remaining files
-			if err := applySettingsChanges(settingsPath, modifiedJSON); err != nil {
-				return false, err
+			if err := applySettingsChanges(settingsPath, modifiedJSON, keepBackups); err != nil {
+				return outcomeConfigured, false, err
 			}
-			return true, nil
+			return outcomeChanged, true, nil
 		default:
 			fmt.Println("Skipped - no changes made")
-			return false, nil
+			return outcomeSkipped, false, nil
 		}
 	}
 
 	// Apply the changes
-	if err := applySettingsChanges(settingsPath, modifiedJSON); err != nil {
-		return false, err
+	if err := applySettingsChanges(settingsPath, modifiedJSON, keepBackups); err != nil {
+		return outcomeConfigured, false, err
 	}
-	return false, nil
+	return outcomeChanged, false, nil
 }
 
-// applySettingsChanges applies the settings changes to the file
-func applySettingsChanges(settingsPath string, modifiedJSON []byte) error {
+// applySettingsChanges applies the settings changes to the file, keeping
+// keepBackups backups of it
+func applySettingsChanges(settingsPath string, modifiedJSON []byte, keepBackups int) error {
 	// Backup existing file if it exists
 	if _, err := os.Stat(settingsPath); err == nil {
-		backupPath := fmt.Sprintf("%s.backup-%s", settingsPath, time.Now().Format("20060102-150405"))
-		if err := copyFile(settingsPath, backupPath); err != nil {
+		backupPath, err := createBackup(settingsPath, keepBackups)
+		if backupPath == "" {
 			return fmt.Errorf("failed to backup existing settings: %w", err)
 		}
 		fmt.Printf("✓ Created backup: %s\n", backupPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
 	}
 
 	// Ensure directory exists
@@ -529,12 +598,3 @@ func isGismoAvailable() bool {
 	}
 	return false
 }
-
-// copyFile copies a file from src to dst
-func copyFile(src, dst string) error {
-	input, err := os.ReadFile(src)
-	if err != nil {
-		return err
-	}
-	return os.WriteFile(dst, input, 0600)
-}
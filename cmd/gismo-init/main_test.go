@@ -0,0 +1,63 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestProcessSettingsFile_Outcomes(t *testing.T) {
+	settingsPath := filepath.Join(t.TempDir(), ".claude", "settings.json")
+
+	// A dry run reports the needed changes without applying them
+	outcome, _, err := processSettingsFile(settingsPath, "Write|Edit|MultiEdit", true, false, defaultKeepBackups)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if outcome != outcomeSkipped {
+		t.Errorf("dry run outcome = %v, want skipped", outcome)
+	}
+	if _, err := os.Stat(settingsPath); !os.IsNotExist(err) {
+		t.Error("dry run wrote the settings file")
+	}
+
+	outcome, _, err = processSettingsFile(settingsPath, "Write|Edit|MultiEdit", false, true, defaultKeepBackups)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if outcome != outcomeChanged {
+		t.Errorf("forced outcome = %v, want changed", outcome)
+	}
+
+	outcome, _, err = processSettingsFile(settingsPath, "Write|Edit|MultiEdit", false, true, defaultKeepBackups)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if outcome != outcomeConfigured {
+		t.Errorf("second run outcome = %v, want already configured", outcome)
+	}
+
+	if err := os.WriteFile(settingsPath, []byte("{"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := processSettingsFile(settingsPath, "Write", false, true, defaultKeepBackups); err == nil {
+		t.Error("invalid settings: want an error")
+	}
+}
+
+func TestSettingsOutcome_ExitCode(t *testing.T) {
+	tests := map[settingsOutcome]int{
+		outcomeChanged:    exitChanged,
+		outcomeConfigured: exitConfigured,
+		outcomeSkipped:    exitSkipped,
+	}
+	for outcome, want := range tests {
+		if got := outcome.exitCode(); got != want {
+			t.Errorf("%v.exitCode() = %d, want %d", outcome, got, want)
+		}
+	}
+	// A file changed takes precedence over one skipped or already configured
+	if got := max(outcomeConfigured, outcomeChanged, outcomeSkipped); got != outcomeChanged {
+		t.Errorf("combined outcome = %v, want changed", got)
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/jrossi/gismo"
+)
+
+// runShowLinters handles `gismo show linters [--schema] [name...]`
+func runShowLinters(w io.Writer, args []string, ruleEngine *gismo.LintingRuleEngine) error {
+	fs := flag.NewFlagSet("linters", flag.ContinueOnError)
+	fs.SetOutput(w)
+	schema := fs.Bool("schema", false, "Print the JSON Schema of each linter's config block")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	names := fs.Args()
+	if len(names) == 0 {
+		names = ruleEngine.LinterNames()
+	}
+	schemas := ruleEngine.LinterSchemas()
+	known := make(map[string]bool)
+	for _, name := range ruleEngine.LinterNames() {
+		known[name] = true
+	}
+	for _, name := range names {
+		if !known[name] {
+			return fmt.Errorf("unknown linter %q", name)
+		}
+	}
+
+	if *schema {
+		return showLinterSchemas(w, names, schemas)
+	}
+
+	appConfig := ruleEngine.GetAppConfig()
+	fmt.Fprintf(w, "=== Linters ===\n")
+	for _, name := range names {
+		status := "enabled"
+		if !appConfig.IsLinterEnabled(name) {
+			status = "disabled"
+		}
+		configSchema := "no config schema"
+		if _, ok := schemas[name]; ok {
+			configSchema = "config schema available"
+		}
+		fmt.Fprintf(w, "  %-12s %-9s %s\n", name, status, configSchema)
+	}
+	fmt.Fprintf(w, "\nRun `gismo show linters --schema [name]` to print config schemas\n")
+	return nil
+}
+
+// showLinterSchemas prints the schema of a single linter as is, or a JSON object
+// mapping each linter name to its schema
+func showLinterSchemas(w io.Writer, names []string, schemas map[string]json.RawMessage) error {
+	if len(names) == 1 {
+		schema, ok := schemas[names[0]]
+		if !ok {
+			return fmt.Errorf("linter %q has no config schema", names[0])
+		}
+		_, err := fmt.Fprintf(w, "%s\n", schema)
+		return err
+	}
+
+	selected := make(map[string]json.RawMessage, len(names))
+	for _, name := range names {
+		if schema, ok := schemas[name]; ok {
+			selected[name] = schema
+		}
+	}
+	out, err := json.MarshalIndent(selected, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s\n", out)
+	return err
+}
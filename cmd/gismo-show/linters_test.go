@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/jrossi/gismo"
+)
+
+func TestRunShowLinters(t *testing.T) {
+	engine := gismo.NewLintingRuleEngine()
+
+	var out bytes.Buffer
+	if err := runShowLinters(&out, nil, engine); err != nil {
+		t.Fatalf("runShowLinters() error = %v", err)
+	}
+	for _, want := range []string{"=== Linters ===", "markdown", "security     disabled"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+
+	out.Reset()
+	if err := runShowLinters(&out, []string{"--schema", "markdown"}, engine); err != nil {
+		t.Fatalf("runShowLinters(--schema markdown) error = %v", err)
+	}
+	var schema struct {
+		Properties map[string]json.RawMessage `json:"properties"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &schema); err != nil {
+		t.Fatalf("schema output is not JSON: %v\n%s", err, out.String())
+	}
+	if _, ok := schema.Properties["maxLineLength"]; !ok {
+		t.Errorf("markdown schema missing maxLineLength: %s", out.String())
+	}
+
+	out.Reset()
+	if err := runShowLinters(&out, []string{"--schema"}, engine); err != nil {
+		t.Fatalf("runShowLinters(--schema) error = %v", err)
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(out.Bytes(), &all); err != nil {
+		t.Fatalf("schema output is not JSON: %v", err)
+	}
+	if len(all) != len(engine.LinterNames()) {
+		t.Errorf("got %d schemas, want one per linter", len(all))
+	}
+
+	if err := runShowLinters(&out, []string{"ruby"}, engine); err == nil {
+		t.Error("expected error for unknown linter")
+	}
+}
@@ -15,9 +15,12 @@ func main() {
 	// Define global flags
 	debug := flag.Bool("debug", false, "Enable debug output")
 	configFile := flag.String("config", "", "Path to configuration file")
+	project := flag.Bool("project", false, "Show detected sub-projects, active linters and missing tools")
 
 	flag.Usage = func() {
-		fmt.Fprintf(os.Stderr, "Usage: gismo-show [options] <file>...\n\n")
+		fmt.Fprintf(os.Stderr, "Usage: gismo-show [options] <file>...\n")
+		fmt.Fprintf(os.Stderr, "       gismo-show [options] --project\n")
+		fmt.Fprintf(os.Stderr, "       gismo-show [options] linters [--schema] [name...]\n\n")
 		fmt.Fprintf(os.Stderr, "Show which configuration rules would apply to the given files\n\n")
 		fmt.Fprintf(os.Stderr, "Options:\n")
 		flag.PrintDefaults()
@@ -26,7 +29,7 @@ func main() {
 	flag.Parse()
 
 	// Check for required arguments
-	if flag.NArg() < 1 {
+	if flag.NArg() < 1 && !*project {
 		fmt.Fprintf(os.Stderr, "Error: show-actions requires at least one file path\n")
 		flag.Usage()
 		os.Exit(1)
@@ -82,6 +85,26 @@ func main() {
 		ruleEngine.SetAppConfig(appConfig)
 	}
 
+	if *project {
+		root, err := os.Getwd()
+		if err == nil {
+			err = showProjects(os.Stdout, root, ruleEngine)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if flag.Arg(0) == "linters" {
+		if err := runShowLinters(os.Stdout, flag.Args()[1:], ruleEngine); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Process the file argument
 	filePath := flag.Args()[0]
 	if err := showFilter(filePath, ruleEngine, configLoader, *configFile, *debug); err != nil {
@@ -113,9 +136,9 @@ func showConfig(appConfig *gismo.AppConfig, debug bool) error {
 	if debug {
 		fmt.Println("\n--- Configuration Sources ---")
 		fmt.Println("Configuration files are loaded in this order (later files override earlier):")
-		fmt.Println("1. ~/.claude/gismo.json (global)")
-		fmt.Println("2. .claude/gismo.json (project)")
-		fmt.Println("3. .claude/gismo.local.json (local overrides)")
+		fmt.Println("1. ~/.config/gismo/gismo.json and ~/.claude/gismo.json (global)")
+		fmt.Println("2. gismo.json, .config/gismo/gismo.json and .claude/gismo.json (project)")
+		fmt.Println("3. gismo.local.json in the same places (local overrides)")
 		fmt.Println("4. --config flag (if specified)")
 	}
 
@@ -129,31 +152,21 @@ type ConfigPath struct {
 	desc string
 }
 
-// getConfigPaths returns the configuration paths that would be loaded
-func getConfigPaths(customConfigFile string, configLoader *gismo.ConfigLoader) []ConfigPath {
-	var paths []ConfigPath
-
+// loadConfigLayers returns the config files that were loaded, unmerged, so
+// settings can be traced to the file that set them
+func loadConfigLayers(customConfigFile string, configLoader *gismo.ConfigLoader) []gismo.ConfigLayer {
+	if configLoader == nil {
+		return nil
+	}
+	paths := configLoader.GetConfigPaths()
 	if customConfigFile != "" {
-		paths = append(paths, ConfigPath{customConfigFile, "custom config"})
-	} else if configLoader != nil {
-		homeDir, _ := os.UserHomeDir()
-		cwd, _ := os.Getwd()
-
-		// Only include paths that actually exist
-		potentialPaths := []ConfigPath{
-			{filepath.Join(homeDir, ".claude", "gismo.json"), "global config"},
-			{filepath.Join(cwd, ".claude", "gismo.json"), "project config"},
-			{filepath.Join(cwd, ".claude", "gismo.local.json"), "local overrides"},
-		}
-
-		for _, cp := range potentialPaths {
-			if _, err := os.Stat(cp.path); err == nil {
-				paths = append(paths, cp)
-			}
-		}
+		paths = []string{customConfigFile}
 	}
-
-	return paths
+	layers, err := configLoader.LoadConfigLayers(paths)
+	if err != nil {
+		return nil
+	}
+	return layers
 }
 
 // showConfigSources displays which configuration files were loaded
@@ -170,13 +183,18 @@ func showConfigSources(customConfigFile string, configLoader *gismo.ConfigLoader
 		}
 	} else if configLoader != nil {
 		// Show standard config hierarchy
-		homeDir, _ := os.UserHomeDir()
 		cwd, _ := os.Getwd()
 
-		configPaths := []ConfigPath{
-			{filepath.Join(homeDir, ".claude", "gismo.json"), "global config"},
-			{filepath.Join(cwd, ".claude", "gismo.json"), "project config"},
-			{filepath.Join(cwd, ".claude", "gismo.local.json"), "local overrides"},
+		var configPaths []ConfigPath
+		for _, path := range configLoader.GetConfigPaths() {
+			desc := "project config"
+			switch {
+			case filepath.Base(path) == "gismo.local.json":
+				desc = "local overrides"
+			case !strings.HasPrefix(path, cwd+string(filepath.Separator)):
+				desc = "global config"
+			}
+			configPaths = append(configPaths, ConfigPath{path, desc})
 		}
 
 		fmt.Printf("Configuration files (in order of precedence):\n")
@@ -225,19 +243,34 @@ func showFilter(filePath string, ruleEngine *gismo.LintingRuleEngine, configLoad
 
 	// Check all possible linters
 	linterMap := map[string][]string{
-		".go":       {"golang"},
-		".md":       {"markdown"},
-		".markdown": {"markdown"},
-		".js":       {"javascript"},
-		".jsx":      {"javascript"},
-		".ts":       {"javascript"},
-		".tsx":      {"javascript"},
-		".py":       {"python"},
-		".rs":       {"rust"},
-		".proto":    {"protobuf"},
-		".json":     {"json"},
-		".jsonc":    {"json"},
-		".json5":    {"json"},
+		".go":         {"go"},
+		".md":         {"markdown"},
+		".markdown":   {"markdown"},
+		".js":         {"javascript"},
+		".jsx":        {"javascript"},
+		".ts":         {"javascript"},
+		".tsx":        {"javascript"},
+		".py":         {"python"},
+		".rs":         {"rust"},
+		".proto":      {"protobuf"},
+		".json":       {"json"},
+		".jsonc":      {"json"},
+		".json5":      {"json"},
+		".yml":        {"yaml"},
+		".yaml":       {"yaml"},
+		".sh":         {"shell"},
+		".bash":       {"shell"},
+		".zsh":        {"shell"},
+		".toml":       {"toml"},
+		".dockerfile": {"dockerfile"},
+		".css":        {"css"},
+		".scss":       {"css"},
+		".less":       {"css"},
+	}
+
+	// Dockerfiles are named rather than given an extension
+	if base := strings.ToLower(filepath.Base(filePath)); base == "dockerfile" || strings.HasPrefix(base, "dockerfile.") {
+		ext = ".dockerfile"
 	}
 
 	if linters, ok := linterMap[ext]; ok {
@@ -279,9 +312,8 @@ func showFilter(filePath string, ruleEngine *gismo.LintingRuleEngine, configLoad
 	fmt.Printf("\n--- Rule Hierarchy ---\n")
 	fmt.Printf("Rules are applied in order. Later rules override earlier ones.\n")
 
-	// Try to determine which config file rules come from based on their position
-	// This is a heuristic since we don't track sources during merge
-	configPaths := getConfigPaths(customConfigFile, configLoader)
+	layers := loadConfigLayers(customConfigFile, configLoader)
+	ruleSources := gismo.RuleSources(layers)
 
 	fmt.Printf("\n")
 
@@ -303,11 +335,8 @@ func showFilter(filePath string, ruleEngine *gismo.LintingRuleEngine, configLoad
 				fmt.Printf(" (applies to %s linter)", rule.Linter)
 			}
 
-			// Try to indicate which config file this likely came from
-			// This is a heuristic based on rule order
-			if len(configPaths) > 0 {
-				configIndex := min(i/max(1, len(appConfig.Rules)/len(configPaths)), len(configPaths)-1)
-				fmt.Printf(" [likely from: %s]", configPaths[configIndex].desc)
+			if i < len(ruleSources) {
+				fmt.Printf(" [from: %s]", ruleSources[i])
 			}
 			fmt.Printf("\n")
 
@@ -327,36 +356,38 @@ func showFilter(filePath string, ruleEngine *gismo.LintingRuleEngine, configLoad
 		fmt.Printf("   Base linter configuration will be used.\n")
 	}
 
-	// Show the final merged configuration for each linter
+	// Report rules that are shadowed, duplicated or never apply
+	if problems := appConfig.AnalyzeRules(ruleEngine.LinterNames()); len(problems) > 0 {
+		fmt.Printf("\n--- Rule Conflicts ---\n")
+		for _, problem := range problems {
+			icon := "⚠️ "
+			if problem.Severity == "error" {
+				icon = "❌"
+			}
+			fmt.Printf("%s %s\n", icon, problem.Message)
+		}
+		fmt.Printf("Run 'gismo config validate' for a suggested rule order.\n")
+	}
+
+	// Show the final merged configuration for each linter, and what set each setting
+	settings, err := ruleEngine.EffectiveConfig(layers, absPath)
+	if err != nil {
+		return err
+	}
 	for _, linterName := range applicableLinters {
 		fmt.Printf("\n--- Final Configuration for %s ---\n", linterName)
 		fmt.Printf("(After applying all matching rules)\n")
 
-		// Get all overrides that would apply
-		overrides := appConfig.GetRuleOverrides(absPath, linterName)
-
-		// Start with base config
-		finalConfig := make(map[string]interface{})
-		if baseConfig, exists := appConfig.GetLinterConfig(linterName); exists {
-			_ = json.Unmarshal(baseConfig, &finalConfig)
-		}
-
-		// Apply each override in order
-		for _, override := range overrides {
-			var overrideMap map[string]interface{}
-			if err := json.Unmarshal(override, &overrideMap); err == nil {
-				for k, v := range overrideMap {
-					finalConfig[k] = v
-				}
+		prefix := "linters." + linterName + ".config."
+		shown := false
+		for _, setting := range settings {
+			if key, ok := strings.CutPrefix(setting.Key, prefix); ok {
+				value, _ := json.Marshal(setting.Value)
+				fmt.Printf("  %s: %s  [from: %s]\n", key, value, setting.Source)
+				shown = true
 			}
 		}
-
-		// Display final config
-		if len(finalConfig) > 0 {
-			for key, value := range finalConfig {
-				fmt.Printf("  %s: %v\n", key, value)
-			}
-		} else {
+		if !shown {
 			fmt.Printf("  (default configuration)\n")
 		}
 	}
@@ -444,7 +475,7 @@ func showExecutionTree(filePath string, applicableLinters []string, appConfig *g
 			fmt.Printf("%s%s%s%s %s%s linter%s", green, connector, horizontal, horizontal, cyan, linterName, reset)
 
 			// Show specific checks for golang
-			if linterName == "golang" {
+			if linterName == "go" {
 				fmt.Printf(" %s(pre-lint content)%s\n", dim, reset)
 				if !isLast {
 					fmt.Printf("%s%s   %s%s%s Syntax validation%s\n", green, vertical, dim, branch, horizontal, reset)
@@ -490,7 +521,7 @@ func showExecutionTree(filePath string, applicableLinters []string, appConfig *g
 			fmt.Printf("%s%s%s%s %s%s linter%s", blue, connector, horizontal, horizontal, cyan, linterName, reset)
 
 			// Show specific checks based on linter type
-			if linterName == "golang" {
+			if linterName == "go" {
 				fmt.Printf(" %s(full analysis)%s\n", dim, reset)
 
 				// Get linter config to show specific checks
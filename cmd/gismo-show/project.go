@@ -0,0 +1,159 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jrossi/gismo"
+	"github.com/jrossi/gismo/toolcache"
+)
+
+// toolRequirement is a set of interchangeable tools a linter needs
+type toolRequirement struct {
+	tools    []string // any one of these satisfies the requirement
+	optional bool     // the linter falls back to built-in checks without it
+}
+
+// projectToolRequirements lists the external tools each project type relies on
+var projectToolRequirements = map[string][]toolRequirement{
+	"go": {
+		{tools: []string{"go"}},
+		{tools: []string{"golangci-lint"}, optional: true},
+	},
+	"javascript": {
+		{tools: []string{"biome", "oxlint", "eslint"}},
+	},
+	"python": {
+		{tools: []string{"ruff"}},
+	},
+	"rust": {
+		{tools: []string{"cargo"}},
+		{tools: []string{"cargo-clippy"}, optional: true},
+	},
+}
+
+// showProjects prints a coverage map of the repository rooted at root: detected
+// sub-projects, the linters that activate in each and the tools they are missing
+func showProjects(w io.Writer, root string, ruleEngine *gismo.LintingRuleEngine) error {
+	projects, err := toolcache.DiscoverProjects(root)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "=== Project Coverage ===\n")
+	fmt.Fprintf(w, "Root: %s\n", root)
+	appConfig := ruleEngine.GetAppConfig()
+	switch {
+	case appConfig.IsLinterScopingEnabled():
+		fmt.Fprintf(w, "Project detection: enabled, language linters are scoped to their sub-projects\n")
+	case appConfig.IsProjectDetectionEnabled():
+		fmt.Fprintf(w, "Project detection: enabled, linter scoping disabled\n")
+	default:
+		fmt.Fprintf(w, "Project detection: disabled (set \"projects\": {\"detect\": true} to scope linters per sub-project)\n")
+	}
+
+	if len(projects) == 0 {
+		fmt.Fprintf(w, "\nℹ️  No projects found (no go.mod, package.json, pyproject.toml, setup.py or Cargo.toml)\n")
+		return nil
+	}
+
+	dirs := make([]string, 0, len(projects))
+	for dir := range projects {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+
+	for _, dir := range dirs {
+		project := projects[dir]
+		fmt.Fprintf(w, "\n%s [%s]", dir, strings.Join(project.ProjectType, ", "))
+		if len(project.SubProjects) > 0 {
+			fmt.Fprintf(w, " (workspace root, %d sub-project(s))", len(project.SubProjects))
+		}
+		fmt.Fprintf(w, "\n")
+
+		if len(project.PackageFiles) > 0 {
+			fmt.Fprintf(w, "   Manifests: %s\n", strings.Join(sortedKeys(project.PackageFiles), ", "))
+		}
+		if len(project.ConfigFiles) > 0 {
+			var configs []string
+			for _, tool := range sortedKeys(project.ConfigFiles) {
+				configs = append(configs, fmt.Sprintf("%s (%s)", tool, filepath.Base(project.ConfigFiles[tool])))
+			}
+			fmt.Fprintf(w, "   Tool configs: %s\n", strings.Join(configs, ", "))
+		}
+
+		// A placeholder file in the directory stands in for any file of the project
+		placeholder := filepath.Join(root, filepath.FromSlash(dir), "file")
+		fmt.Fprintf(w, "   Linters: %s\n", joinOrNone(ruleEngine.ActiveLinterNames(placeholder)))
+
+		projectDir := filepath.Join(root, filepath.FromSlash(dir))
+		for _, projectType := range project.ProjectType {
+			for _, requirement := range projectToolRequirements[projectType] {
+				fmt.Fprintf(w, "   %s\n", describeRequirement(projectDir, requirement))
+			}
+		}
+	}
+
+	return nil
+}
+
+// describeRequirement reports where a required tool was found, or that it is missing
+func describeRequirement(projectDir string, requirement toolRequirement) string {
+	for _, tool := range requirement.tools {
+		if path := findProjectTool(projectDir, tool); path != "" {
+			return fmt.Sprintf("✅ %s (%s)", tool, path)
+		}
+	}
+	names := strings.Join(requirement.tools, " or ")
+	if requirement.optional {
+		return fmt.Sprintf("⚠️  %s not found (optional, built-in checks are used instead)", names)
+	}
+	return fmt.Sprintf("❌ %s not found", names)
+}
+
+// findProjectTool looks for a tool installed in the project (node_modules/.bin,
+// virtual environments) before $HOME/go/bin and PATH
+func findProjectTool(projectDir, tool string) string {
+	candidates := []string{
+		filepath.Join(projectDir, "node_modules", ".bin", tool),
+		filepath.Join(projectDir, ".venv", "bin", tool),
+		filepath.Join(projectDir, "venv", "bin", tool),
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		candidates = append(candidates, filepath.Join(home, "go", "bin", tool))
+	}
+	for _, candidate := range candidates {
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate
+		}
+	}
+	if path, err := exec.LookPath(tool); err == nil {
+		return path
+	}
+	return ""
+}
+
+// sortedKeys returns the keys of m in order
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// joinOrNone joins names, or returns "(none)" when there are none
+func joinOrNone(names []string) string {
+	if len(names) == 0 {
+		return "(none)"
+	}
+	sorted := append([]string(nil), names...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ", ")
+}
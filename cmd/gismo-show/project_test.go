@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jrossi/gismo"
+)
+
+func TestShowProjects(t *testing.T) {
+	root := t.TempDir()
+	for _, file := range []string{".claude/.keep", "api/go.mod", "api/.golangci.yml", "web/package.json", "web/node_modules/.bin/biome"} {
+		path := filepath.Join(root, filepath.FromSlash(file))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	detect := true
+	config := gismo.NewAppConfig()
+	config.Projects = &gismo.ProjectsConfig{Detect: &detect}
+	engine := gismo.NewLintingRuleEngineWithConfig(gismo.LintingConfig{ProjectRoot: root})
+	engine.SetAppConfig(config)
+
+	var out bytes.Buffer
+	if err := showProjects(&out, root, engine); err != nil {
+		t.Fatalf("showProjects() error = %v", err)
+	}
+	output := out.String()
+
+	for _, want := range []string{
+		"scoped to their sub-projects",
+		". [mixed] (workspace root, 2 sub-project(s))",
+		"api [go]",
+		"Tool configs: golangci-lint (.golangci.yml)",
+		"web [javascript]",
+		"✅ biome (" + filepath.Join(root, "web", "node_modules", ".bin", "biome") + ")",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+
+	// Scoping leaves only the JavaScript linter among the language linters in web
+	webSection := output[strings.Index(output, "web [javascript]"):]
+	linters := webSection[strings.Index(webSection, "Linters:"):]
+	linters = linters[:strings.Index(linters, "\n")]
+	if strings.Contains(linters, "go,") || !strings.Contains(linters, "javascript") {
+		t.Errorf("web linters = %q, want javascript without go", linters)
+	}
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jrossi/gismo"
+)
+
+// allSessions is the -session value for overrides that apply to every session
+const allSessions = "all"
+
+// runAllowCommand handles `gismo allow`, which relaxes rules for the current
+// session when a person decides a block is a false positive. Errors of the rules
+// become warnings until the override expires.
+func runAllowCommand(w io.Writer, args []string, projectDir string, store *gismo.SessionStore) int {
+	fs := flag.NewFlagSet("allow", flag.ContinueOnError)
+	fs.SetOutput(w)
+	duration := fs.Duration("for", 30*time.Minute, "How long the rules stay relaxed")
+	paths := fs.String("path", "", "Comma-separated file patterns to relax the rules for (default every file)")
+	session := fs.String("session", "", "Session to relax the rules for, or \"all\" (default the most recently active session)")
+	reason := fs.String("reason", "", "Why the block is a false positive, recorded in the override log")
+	list := fs.Bool("list", false, "List the active overrides")
+	fs.Usage = func() {
+		fmt.Fprintf(w, "Usage: gismo allow <rule>... [-for duration] [-path patterns] [-session id] [-reason text]\n")
+		fmt.Fprintf(w, "       gismo allow -list\n\n")
+		fmt.Fprintf(w, "Relaxes rules so their errors stop blocking Claude's edits until the override expires.\n")
+		fmt.Fprintf(w, "Overrides are written to %s and logged in %s.\n\n",
+			gismo.RelaxationPath("."), gismo.RelaxationLogPath("."))
+		fs.PrintDefaults()
+	}
+
+	// Rules may come before or after the flags, as in gismo allow errcheck -for 1h
+	var rules []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return 1
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		rules = append(rules, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+
+	if *list {
+		return listRuleRelaxations(w, projectDir)
+	}
+	if len(rules) == 0 {
+		fs.Usage()
+		return 1
+	}
+	if *duration <= 0 {
+		fmt.Fprintf(w, "Invalid duration %s, expected a positive duration such as 30m\n", *duration)
+		return 1
+	}
+
+	sessionID := *session
+	switch sessionID {
+	case allSessions:
+		sessionID = ""
+	case "":
+		latest, err := latestSession(store)
+		if err != nil {
+			fmt.Fprintf(w, "Error: %v\n", err)
+			return 1
+		}
+		if latest == "" {
+			fmt.Fprintf(w, "No active session found; use -session <id> or -session all\n")
+			return 1
+		}
+		sessionID = latest
+	}
+
+	now := time.Now()
+	override := gismo.RuleRelaxation{
+		Rules:   rules,
+		Paths:   splitList(*paths),
+		Session: sessionID,
+		Expires: now.Add(*duration),
+		Reason:  *reason,
+		By:      currentUser(),
+		Created: now,
+	}
+	if err := gismo.AddRuleRelaxation(projectDir, override); err != nil {
+		fmt.Fprintf(w, "Error: %v\n", err)
+		return 1
+	}
+	fmt.Fprintf(w, "Relaxed %s %s until %s\n", strings.Join(rules, ", "), overrideScope(override), override.Expires.Format(time.Kitchen))
+	return 0
+}
+
+// listRuleRelaxations prints the rule overrides of the project that haven't expired
+func listRuleRelaxations(w io.Writer, projectDir string) int {
+	overrides, err := gismo.LoadRuleRelaxations(projectDir)
+	if err != nil {
+		fmt.Fprintf(w, "Error: %v\n", err)
+		return 1
+	}
+	now := time.Now()
+	active := overrides.Active(now)
+	if len(active) == 0 {
+		fmt.Fprintf(w, "No active rule overrides\n")
+		return 0
+	}
+	for _, override := range active {
+		fmt.Fprintf(w, "%s %s, %s left", strings.Join(override.Rules, ", "), overrideScope(override), override.Expires.Sub(now).Round(time.Second))
+		if override.By != "" {
+			fmt.Fprintf(w, ", by %s", override.By)
+		}
+		if override.Reason != "" {
+			fmt.Fprintf(w, ": %s", override.Reason)
+		}
+		fmt.Fprintln(w)
+	}
+	return 0
+}
+
+// overrideScope describes the files and session an override applies to
+func overrideScope(override gismo.RuleRelaxation) string {
+	scope := "in every file"
+	if len(override.Paths) > 0 {
+		scope = "in " + strings.Join(override.Paths, ", ")
+	}
+	if override.Session == "" {
+		return scope + " for all sessions"
+	}
+	return scope + " for session " + override.Session
+}
+
+// latestSession returns the session whose hooks ran most recently, or "" if
+// there is none
+func latestSession(store *gismo.SessionStore) (string, error) {
+	states, err := store.LoadAll()
+	if err != nil {
+		return "", err
+	}
+	latest := ""
+	var latestAt time.Time
+	for id, state := range states {
+		if state.UpdatedAt.After(latestAt) {
+			latest, latestAt = id, state.UpdatedAt
+		}
+	}
+	return latest, nil
+}
+
+// currentUser names the person creating an override, for the override log
+func currentUser() string {
+	for _, name := range []string{"USER", "USERNAME"} {
+		if user := os.Getenv(name); user != "" {
+			return user
+		}
+	}
+	return ""
+}
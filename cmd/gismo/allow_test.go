@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jrossi/gismo"
+)
+
+func TestRunAllowCommand(t *testing.T) {
+	projectDir := t.TempDir()
+	store := gismo.NewSessionStore(t.TempDir())
+
+	var out bytes.Buffer
+	if code := runAllowCommand(&out, []string{"errcheck"}, projectDir, store); code != 1 || !strings.Contains(out.String(), "No active session found") {
+		t.Fatalf("no sessions: code %d, output:\n%s", code, out.String())
+	}
+
+	for _, id := range []string{"older", "latest"} {
+		state, _ := store.Load(id)
+		if err := store.Save(id, state); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	out.Reset()
+	args := []string{"errcheck", "-for", "1h", "govet", "-path", "/internal/", "-reason", "false positive"}
+	if code := runAllowCommand(&out, args, projectDir, store); code != 0 {
+		t.Fatalf("allow: code %d, output:\n%s", code, out.String())
+	}
+	if !strings.Contains(out.String(), "Relaxed errcheck, govet in /internal/ for session latest until") {
+		t.Errorf("allow output:\n%s", out.String())
+	}
+
+	relaxations, err := gismo.LoadRuleRelaxations(projectDir)
+	if err != nil || len(relaxations.Overrides) != 1 {
+		t.Fatalf("overrides = %+v, %v", relaxations, err)
+	}
+	relaxation := relaxations.Overrides[0]
+	if relaxation.Session != "latest" || relaxation.Reason != "false positive" || time.Until(relaxation.Expires) < 59*time.Minute {
+		t.Errorf("override = %+v", relaxation)
+	}
+
+	out.Reset()
+	if code := runAllowCommand(&out, []string{"MD013", "-session", "all"}, projectDir, store); code != 0 || !strings.Contains(out.String(), "for all sessions") {
+		t.Errorf("all sessions: code %d, output:\n%s", code, out.String())
+	}
+
+	out.Reset()
+	if code := runAllowCommand(&out, []string{"-list"}, projectDir, store); code != 0 {
+		t.Fatalf("list: code %d", code)
+	}
+	if lines := strings.Split(strings.TrimSpace(out.String()), "\n"); len(lines) != 2 || !strings.Contains(lines[0], "left") || !strings.Contains(lines[0], ": false positive") {
+		t.Errorf("list output:\n%s", out.String())
+	}
+
+	out.Reset()
+	if code := runAllowCommand(&out, []string{"errcheck", "-for", "0s"}, projectDir, store); code != 1 || !strings.Contains(out.String(), "Invalid duration") {
+		t.Errorf("zero duration: code %d, output:\n%s", code, out.String())
+	}
+}
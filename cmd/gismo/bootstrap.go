@@ -0,0 +1,279 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jrossi/gismo"
+	"github.com/jrossi/gismo/toolcache"
+)
+
+// Exit codes of gismo-init, see cmd/gismo-init
+const (
+	initExitChanged    = 0
+	initExitConfigured = 3
+	initExitSkipped    = 4
+)
+
+// toolInstallTimeout bounds one tool installation
+const toolInstallTimeout = 5 * time.Minute
+
+// toolInstallers are the commands bootstrap -install runs for missing tools.
+// Tools without one, such as shellcheck, come from the system package manager.
+var toolInstallers = map[string][]string{
+	"golangci-lint": {"go", "install", "github.com/golangci/golangci-lint/v2/cmd/golangci-lint@latest"},
+	"gofumpt":       {"go", "install", "mvdan.cc/gofumpt@latest"},
+	"gci":           {"go", "install", "github.com/daixiang0/gci@latest"},
+	"govulncheck":   {"go", "install", "golang.org/x/vuln/cmd/govulncheck@latest"},
+	"staticcheck":   {"go", "install", "honnef.co/go/tools/cmd/staticcheck@latest"},
+	"protolint":     {"go", "install", "github.com/yoheimuta/protolint/cmd/protolint@latest"},
+	"buf":           {"go", "install", "github.com/bufbuild/buf/cmd/buf@latest"},
+	"biome":         {"npm", "install", "--global", "@biomejs/biome"},
+	"eslint":        {"npm", "install", "--global", "eslint"},
+	"oxlint":        {"npm", "install", "--global", "oxlint"},
+	"stylelint":     {"npm", "install", "--global", "stylelint"},
+	"yamllint":      {"python3", "-m", "pip", "install", "--user", "yamllint"},
+	"uv":            {"python3", "-m", "pip", "install", "--user", "uv"},
+}
+
+// runInitCommand runs gismo-init with args, writing its output to out, and
+// returns its exit code. Tests replace it.
+var runInitCommand = func(args []string, out io.Writer) (int, error) {
+	cmd := exec.Command(subcommandPath("gismo-init"), args...) // #nosec G204 - subcommand is controlled
+	cmd.Stdout = out
+	cmd.Stderr = out
+	cmd.Env = os.Environ()
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode(), nil
+	}
+	return 0, err
+}
+
+// installTool runs a tool's installer, writing its output to out. Tests replace it.
+var installTool = func(ctx context.Context, command []string, out io.Writer) error {
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...) // #nosec G204 - commands from toolInstallers
+	cmd.Stdout = out
+	cmd.Stderr = out
+	return cmd.Run()
+}
+
+// bootstrapSummary is the machine-readable result of gismo bootstrap
+type bootstrapSummary struct {
+	// Init is what gismo init did: "changed", "configured", "skipped" or "failed"
+	Init string `json:"init"`
+	// Config is the project config file, and ConfigCreated whether bootstrap wrote it
+	Config        string `json:"config"`
+	ConfigCreated bool   `json:"configCreated"`
+	// Linters are the enabled linters that check files in the project
+	Linters []string        `json:"linters"`
+	Tools   []bootstrapTool `json:"tools"`
+	Errors  []string        `json:"errors,omitempty"`
+}
+
+// bootstrapTool is an external tool of a linter the project uses
+type bootstrapTool struct {
+	Name      string `json:"name"`
+	Linter    string `json:"linter"`
+	Available bool   `json:"available"`
+	Path      string `json:"path,omitempty"`
+	Version   string `json:"version,omitempty"`
+	// Installed is set when bootstrap installed the tool
+	Installed bool `json:"installed,omitempty"`
+	// Install is the command that installs a missing tool, when one is known
+	Install string `json:"install,omitempty"`
+}
+
+// runBootstrap handles `gismo bootstrap`, the one-shot setup for devcontainers
+// and Codespaces: it adds the gismo hooks without prompting, writes a starter
+// project config, warms the tool cache for the linters the project needs and
+// optionally installs their missing tools. The summary goes to w as JSON and
+// progress to progress. It exits with 1 if any step failed.
+func runBootstrap(w, progress io.Writer, args []string, ruleEngine *gismo.LintingRuleEngine, projectDir string) int {
+	flags := flag.NewFlagSet("bootstrap", flag.ContinueOnError)
+	flags.SetOutput(progress)
+	install := flags.Bool("install", false, "Install missing tools with go, npm or pip")
+	global := flags.Bool("global", false, "Only add the hooks to the global settings (~/.claude/settings.json)")
+	project := flags.Bool("project", false, "Only add the hooks to the project settings (.claude/settings.json)")
+	flags.Usage = func() {
+		fmt.Fprintf(progress, "Usage: gismo bootstrap [-install] [-global|-project]\n\n")
+		fmt.Fprintf(progress, "Sets up gismo non-interactively, for example from a devcontainer postCreateCommand.\n")
+		fmt.Fprintf(progress, "Prints a JSON summary and exits with 1 if any step failed.\n\n")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	summary := bootstrapSummary{Linters: []string{}, Tools: []bootstrapTool{}}
+	fail := func(format string, args ...interface{}) {
+		message := fmt.Sprintf(format, args...)
+		summary.Errors = append(summary.Errors, message)
+		fmt.Fprintf(progress, "Error: %s\n", message)
+	}
+
+	// Hooks
+	initArgs := []string{"--yes"}
+	if *global {
+		initArgs = append(initArgs, "--global")
+	}
+	if *project {
+		initArgs = append(initArgs, "--project")
+	}
+	code, err := runInitCommand(initArgs, progress)
+	switch {
+	case err != nil:
+		summary.Init = "failed"
+		fail("init: %v", err)
+	case code == initExitChanged:
+		summary.Init = "changed"
+	case code == initExitConfigured:
+		summary.Init = "configured"
+	case code == initExitSkipped:
+		summary.Init = "skipped"
+	default:
+		summary.Init = "failed"
+		fail("init exited with %d", code)
+	}
+
+	// Linters the project's files need
+	summary.Linters = projectLinters(ruleEngine, projectDir)
+
+	// Starter config
+	summary.Config = gismo.ProjectConfigPath(projectDir)
+	if _, err := os.Stat(summary.Config); os.IsNotExist(err) {
+		if err := writeStarterConfig(summary.Config, summary.Linters); err != nil {
+			fail("config: %v", err)
+		} else {
+			summary.ConfigCreated = true
+			fmt.Fprintf(progress, "✓ Created %s\n", summary.Config)
+		}
+	}
+
+	// Tool cache
+	var cache toolcache.ToolCache
+	if manager, err := toolcache.NewCacheManager(projectDir); err != nil {
+		fail("tool cache: %v", err)
+	} else {
+		cache = manager
+	}
+	capabilities := ruleEngine.LinterCapabilities()
+	for _, linter := range summary.Linters {
+		for _, name := range capabilities[linter].Tools {
+			tool := bootstrapTool{Name: name, Linter: linter}
+			if installer, ok := toolInstallers[name]; ok {
+				tool.Install = strings.Join(installer, " ")
+			}
+			discoverBootstrapTool(cache, &tool)
+			if !tool.Available && *install && tool.Install != "" {
+				if err := installBootstrapTool(cache, &tool, progress); err != nil {
+					fail("install %s: %v", name, err)
+				}
+			}
+			summary.Tools = append(summary.Tools, tool)
+		}
+	}
+
+	if code := writeJSON(w, summary); code != 0 {
+		return code
+	}
+	if len(summary.Errors) > 0 {
+		return 1
+	}
+	return 0
+}
+
+// projectLinters returns the sorted names of the enabled linters that check
+// any file in the project
+func projectLinters(ruleEngine *gismo.LintingRuleEngine, projectDir string) []string {
+	files, err := lintFiles([]string{projectDir})
+	if err != nil {
+		return []string{}
+	}
+	seen := make(map[string]bool)
+	for _, file := range files {
+		for _, name := range ruleEngine.HandlingLinterNames(file) {
+			seen[name] = true
+		}
+	}
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// writeStarterConfig writes a project config enabling the given linters
+func writeStarterConfig(path string, linterNames []string) error {
+	config := gismo.AppConfig{Linters: make(map[string]gismo.LinterConfig)}
+	enabled := true
+	for _, name := range linterNames {
+		config.Linters[name] = gismo.LinterConfig{Enabled: &enabled}
+	}
+	data, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0600)
+}
+
+// discoverBootstrapTool looks the tool up through the tool cache, recording it
+// there for the hooks
+func discoverBootstrapTool(cache toolcache.ToolCache, tool *bootstrapTool) {
+	var info *toolcache.ToolInfo
+	if cache != nil {
+		info, _ = cache.DiscoverTool(tool.Linter, tool.Name)
+	}
+	if info == nil {
+		// Without a tool cache the tool is looked up directly
+		path, err := exec.LookPath(tool.Name)
+		info = &toolcache.ToolInfo{Path: path, Available: err == nil}
+		if err == nil {
+			info.Version = toolcache.DetectVersion(path)
+		}
+	}
+	tool.Available = info.Available
+	tool.Path = info.Path
+	tool.Version = info.Version
+}
+
+// installBootstrapTool installs a missing tool and discovers it again
+func installBootstrapTool(cache toolcache.ToolCache, tool *bootstrapTool, progress io.Writer) error {
+	installer := toolInstallers[tool.Name]
+	if _, err := exec.LookPath(installer[0]); err != nil {
+		return fmt.Errorf("%s is needed to install it", installer[0])
+	}
+	fmt.Fprintf(progress, "Installing %s: %s\n", tool.Name, tool.Install)
+	ctx, cancel := context.WithTimeout(context.Background(), toolInstallTimeout)
+	defer cancel()
+	if err := installTool(ctx, installer, progress); err != nil {
+		return err
+	}
+
+	// The cache remembers the tool as missing until it is checked again
+	if cache != nil {
+		_ = cache.UpdateTool(tool.Linter, tool.Name, &toolcache.ToolInfo{})
+	}
+	discoverBootstrapTool(cache, tool)
+	if !tool.Available {
+		return fmt.Errorf("installed, but %s is not on PATH", tool.Name)
+	}
+	tool.Installed = true
+	fmt.Fprintf(progress, "✓ Installed %s\n", tool.Name)
+	return nil
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/jrossi/gismo"
+	"github.com/jrossi/gismo/linters"
+)
+
+// fakeToolLinter checks .fake files with an external tool
+type fakeToolLinter struct{}
+
+func (fakeToolLinter) Name() string               { return "fake" }
+func (fakeToolLinter) CanHandle(path string) bool { return strings.HasSuffix(path, ".fake") }
+func (fakeToolLinter) Lint(context.Context, string, []byte) (*linters.LintResult, error) {
+	return &linters.LintResult{Success: true}, nil
+}
+func (fakeToolLinter) Capabilities() linters.Capabilities {
+	return linters.Capabilities{Tools: []string{"gismo-fake-tool"}}
+}
+
+func TestRunBootstrap(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, ".claude"), 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "data.fake"), []byte("x"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// The tool installs into a directory on PATH
+	binDir := t.TempDir()
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	toolInstallers["gismo-fake-tool"] = []string{"go", "install", "example.com/gismo-fake-tool@latest"}
+	defer delete(toolInstallers, "gismo-fake-tool")
+
+	var initArgs []string
+	restoreInit, restoreInstall := runInitCommand, installTool
+	defer func() { runInitCommand, installTool = restoreInit, restoreInstall }()
+	runInitCommand = func(args []string, out io.Writer) (int, error) {
+		initArgs = args
+		return initExitConfigured, nil
+	}
+	var installed []string
+	installTool = func(ctx context.Context, command []string, out io.Writer) error {
+		installed = command
+		return os.WriteFile(filepath.Join(binDir, "gismo-fake-tool"), []byte("#!/bin/sh\necho gismo-fake-tool 1.2.3\n"), 0700) // #nosec G306 - test executable
+	}
+
+	run := func(args ...string) bootstrapSummary {
+		t.Helper()
+		engine := gismo.NewLintingRuleEngine()
+		engine.AddLinter(fakeToolLinter{})
+		var out, progress bytes.Buffer
+		if code := runBootstrap(&out, &progress, args, engine, dir); code != 0 {
+			t.Fatalf("exit code = %d\n%s%s", code, out.String(), progress.String())
+		}
+		var summary bootstrapSummary
+		if err := json.Unmarshal(out.Bytes(), &summary); err != nil {
+			t.Fatalf("summary is not JSON: %v\n%s", err, out.String())
+		}
+		return summary
+	}
+
+	summary := run("-project")
+	if !slices.Equal(initArgs, []string{"--yes", "--project"}) {
+		t.Errorf("init args = %v", initArgs)
+	}
+	if summary.Init != "configured" {
+		t.Errorf("init = %q, want configured", summary.Init)
+	}
+	if !summary.ConfigCreated || summary.Config != filepath.Join(dir, ".claude", "gismo.json") {
+		t.Errorf("config = %q, created %v", summary.Config, summary.ConfigCreated)
+	}
+	if !slices.Contains(summary.Linters, "fake") {
+		t.Errorf("linters = %v, want fake", summary.Linters)
+	}
+	var config gismo.AppConfig
+	data, err := os.ReadFile(summary.Config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, &config); err != nil || config.Linters["fake"].Enabled == nil {
+		t.Errorf("starter config = %s, %v", data, err)
+	}
+	if len(summary.Tools) != 1 || summary.Tools[0].Available || summary.Tools[0].Install == "" {
+		t.Errorf("tools = %+v, want gismo-fake-tool missing with an installer", summary.Tools)
+	}
+	if installed != nil {
+		t.Error("tool installed without -install")
+	}
+
+	summary = run("-install")
+	if summary.ConfigCreated {
+		t.Error("existing config overwritten")
+	}
+	if len(summary.Tools) != 1 || !summary.Tools[0].Available || !summary.Tools[0].Installed {
+		t.Errorf("tools = %+v, want gismo-fake-tool installed", summary.Tools)
+	}
+	if len(installed) == 0 || installed[0] != "go" {
+		t.Errorf("installer = %v", installed)
+	}
+}
+
+func TestRunBootstrap_InitFailure(t *testing.T) {
+	restore := runInitCommand
+	defer func() { runInitCommand = restore }()
+	runInitCommand = func(args []string, out io.Writer) (int, error) { return 1, nil }
+
+	var out, progress bytes.Buffer
+	if code := runBootstrap(&out, &progress, nil, gismo.NewLintingRuleEngine(), t.TempDir()); code != 1 {
+		t.Errorf("exit code = %d, want 1", code)
+	}
+	var summary bootstrapSummary
+	if err := json.Unmarshal(out.Bytes(), &summary); err != nil {
+		t.Fatal(err)
+	}
+	if summary.Init != "failed" || len(summary.Errors) == 0 {
+		t.Errorf("summary = %+v, want the init failure", summary)
+	}
+}
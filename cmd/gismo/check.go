@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/jrossi/gismo"
+)
+
+// runCheck handles `gismo check`: it runs the PreToolUse pipeline on content as
+// if Claude were about to write it to -path, prints the hook response as JSON and
+// exits with the code the hook would have used
+func runCheck(w io.Writer, stdin io.Reader, args []string, ruleEngine *gismo.LintingRuleEngine) int {
+	fs := flag.NewFlagSet("check", flag.ContinueOnError)
+	fs.SetOutput(w)
+	path := fs.String("path", "", "Path the content would be written to; selects linters and rules")
+	fromStdin := fs.Bool("stdin", false, "Read the content from stdin instead of the file at -path")
+	// check and lint are the commands for linting files from the shell, so strict
+	// mode is offered here for CI without editing the configuration Claude sees
+	strict := fs.Bool("strict", false, "Treat warnings as blocking errors")
+	fs.Usage = func() {
+		fmt.Fprintf(w, "Usage: gismo check -path file [-stdin] [-strict]\n\n")
+		fmt.Fprintf(w, "Lints content as if it were about to be written to the path and prints the hook response.\n")
+		fmt.Fprintf(w, "Exits with 2 if the write would be blocked. Lint details go to stderr.\n\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 1
+	}
+	if *path == "" {
+		fs.Usage()
+		return 1
+	}
+	if *strict {
+		ruleEngine.SetStrict(true)
+	}
+
+	filePath, err := filepath.Abs(*path)
+	if err != nil {
+		fmt.Fprintf(w, "Error: %v\n", err)
+		return 1
+	}
+
+	var content []byte
+	if *fromStdin {
+		content, err = io.ReadAll(stdin)
+	} else {
+		content, err = os.ReadFile(filePath) // #nosec G304 - path given on the command line
+	}
+	if err != nil {
+		fmt.Fprintf(w, "Error: failed to read content: %v\n", err)
+		return 1
+	}
+
+	filePathJSON, _ := json.Marshal(filePath)
+	contentJSON, _ := json.Marshal(string(content))
+	msg := &gismo.PreToolUseMessage{
+		BaseHookMessage: gismo.BaseHookMessage{HookEventName: gismo.PreToolUseEvent},
+		ToolName:        "Write",
+		ToolInput: map[string]json.RawMessage{
+			"file_path": filePathJSON,
+			"content":   contentJSON,
+		},
+	}
+
+	response, err := ruleEngine.EvaluatePreToolUse(context.Background(), msg)
+	if err != nil {
+		fmt.Fprintf(w, "Error: %v\n", err)
+		return 1
+	}
+	if response == nil {
+		response = &gismo.HookResponse{Decision: "approve"}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(response); err != nil {
+		fmt.Fprintf(w, "Error: %v\n", err)
+		return 1
+	}
+	if response.Decision == "block" {
+		return int(gismo.ExitBlocking)
+	}
+	return int(gismo.ExitSuccess)
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jrossi/gismo"
+)
+
+func TestRunCheck(t *testing.T) {
+	dir := t.TempDir()
+	onDisk := filepath.Join(dir, "disk.json")
+	if err := os.WriteFile(onDisk, []byte(`{"valid": true}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	engine := gismo.NewLintingRuleEngine()
+
+	tests := []struct {
+		name     string
+		args     []string
+		stdin    string
+		wantCode int
+		want     string
+	}{
+		{name: "valid stdin", args: []string{"-stdin", "-path", filepath.Join(dir, "new.json")}, stdin: `{"a": 1}`, want: `"decision": "approve"`},
+		{name: "invalid stdin", args: []string{"-stdin", "-path", filepath.Join(dir, "new.json")}, stdin: `{"a": }`, wantCode: 2, want: `"decision": "block"`},
+		{name: "file on disk", args: []string{"-path", onDisk}, want: `"decision": "approve"`},
+		{name: "missing file", args: []string{"-path", filepath.Join(dir, "missing.json")}, wantCode: 1, want: "failed to read content"},
+		{name: "no path", args: []string{"-stdin"}, wantCode: 1, want: "Usage: gismo check"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			if code := runCheck(&out, strings.NewReader(tt.stdin), tt.args, engine); code != tt.wantCode {
+				t.Errorf("exit code = %d, want %d", code, tt.wantCode)
+			}
+			if !strings.Contains(out.String(), tt.want) {
+				t.Errorf("output missing %q:\n%s", tt.want, out.String())
+			}
+		})
+	}
+}
+
+func TestRunCheck_Strict(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notes.md")
+	// Unformatted markdown only warns
+	content := "# Notes\n*  item\n"
+
+	var out bytes.Buffer
+	if code := runCheck(&out, strings.NewReader(content), []string{"-stdin", "-path", path}, gismo.NewLintingRuleEngine()); code != 0 {
+		t.Fatalf("exit code = %d, want 0\n%s", code, out.String())
+	}
+
+	out.Reset()
+	if code := runCheck(&out, strings.NewReader(content), []string{"-stdin", "-strict", "-path", path}, gismo.NewLintingRuleEngine()); code != 2 {
+		t.Errorf("strict exit code = %d, want 2\n%s", code, out.String())
+	}
+	if !strings.Contains(out.String(), `"decision": "block"`) {
+		t.Errorf("strict output missing block decision:\n%s", out.String())
+	}
+}
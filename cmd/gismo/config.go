@@ -0,0 +1,247 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/jrossi/gismo"
+)
+
+// configUsage lists the config subcommands
+const configUsage = "Usage: gismo config validate | show [-effective -for file] [-json] | diff <a> <b>\n"
+
+// runConfigCommand handles `gismo config <subcommand>` and returns the exit code.
+// layers are the config files appConfig was merged from, for show.
+func runConfigCommand(w io.Writer, args []string, appConfig *gismo.AppConfig, linterNames []string, schemas map[string]json.RawMessage, layers []gismo.ConfigLayer, ruleEngine *gismo.LintingRuleEngine) int {
+	if len(args) == 0 {
+		fmt.Fprint(w, configUsage)
+		return 1
+	}
+
+	switch args[0] {
+	case "validate":
+		configCode := validateLinterConfigs(w, appConfig, schemas)
+		if code := validateConfig(w, appConfig, linterNames); code != 0 {
+			return code
+		}
+		return configCode
+	case "show":
+		return showConfig(w, args[1:], layers, ruleEngine)
+	case "diff":
+		return diffConfigs(w, args[1:])
+	default:
+		fmt.Fprintf(w, "Unknown config command: %s\n", args[0])
+		fmt.Fprint(w, configUsage)
+		return 1
+	}
+}
+
+// showConfig prints the merged config and the files it came from, or with
+// -effective the settings that apply to one file and where each was set
+func showConfig(w io.Writer, args []string, layers []gismo.ConfigLayer, ruleEngine *gismo.LintingRuleEngine) int {
+	fs := flag.NewFlagSet("config show", flag.ContinueOnError)
+	fs.SetOutput(w)
+	effective := fs.Bool("effective", false, "Show the settings that apply to the -for file, with the file or rule that set each")
+	forPath := fs.String("for", "", "File to show the effective settings of")
+	asJSON := fs.Bool("json", false, "Print JSON")
+	if err := fs.Parse(args); err != nil {
+		return 1
+	}
+	if *effective != (*forPath != "") {
+		fmt.Fprintf(w, "-effective and -for go together\n")
+		return 1
+	}
+
+	if !*effective {
+		merged := gismo.MergeConfigLayers(layers)
+		if *asJSON {
+			return writeJSON(w, merged)
+		}
+		fmt.Fprintf(w, "Config files, lowest precedence first:\n")
+		if len(layers) == 0 {
+			fmt.Fprintf(w, "  (none)\n")
+		}
+		for i, layer := range layers {
+			fmt.Fprintf(w, "  %d. %s\n", i+1, layer.Source)
+		}
+		fmt.Fprintf(w, "\nMerged configuration:\n")
+		return writeJSON(w, merged)
+	}
+
+	filePath, err := filepath.Abs(*forPath)
+	if err != nil {
+		fmt.Fprintf(w, "Error: %v\n", err)
+		return 1
+	}
+	settings, err := ruleEngine.EffectiveConfig(layers, filePath)
+	if err != nil {
+		fmt.Fprintf(w, "Error: %v\n", err)
+		return 1
+	}
+	if *asJSON {
+		return writeJSON(w, settings)
+	}
+
+	fmt.Fprintf(w, "Effective configuration for %s\n", filePath)
+	if len(settings) == 0 {
+		fmt.Fprintf(w, "  (all defaults)\n")
+		return 0
+	}
+	fmt.Fprintln(w)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "KEY\tVALUE\tSET BY")
+	for _, setting := range settings {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", setting.Key, formatSetting(setting.Value), setting.Source)
+	}
+	if err := tw.Flush(); err != nil {
+		return 1
+	}
+	return 0
+}
+
+// diffConfigs compares two config states, each a config file or a project
+// directory whose project config files are merged. It returns 1 if they differ.
+func diffConfigs(w io.Writer, args []string) int {
+	if len(args) != 2 {
+		fmt.Fprintf(w, "Usage: gismo config diff <a> <b>\n\nEach of a and b is a config file or a project directory.\n")
+		return 1
+	}
+
+	loader, err := gismo.NewConfigLoader()
+	if err != nil {
+		fmt.Fprintf(w, "Error: %v\n", err)
+		return 1
+	}
+	configs := make([]*gismo.AppConfig, 2)
+	for i, arg := range args {
+		paths, err := configStatePaths(arg)
+		if err != nil {
+			fmt.Fprintf(w, "Error: %v\n", err)
+			return 1
+		}
+		layers, err := loader.LoadConfigLayers(paths)
+		if err != nil {
+			fmt.Fprintf(w, "Error: %v\n", err)
+			return 1
+		}
+		configs[i] = gismo.MergeConfigLayers(layers)
+	}
+
+	changes, err := gismo.DiffConfigs(configs[0], configs[1])
+	if err != nil {
+		fmt.Fprintf(w, "Error: %v\n", err)
+		return 1
+	}
+	if len(changes) == 0 {
+		fmt.Fprintf(w, "No differences\n")
+		return 0
+	}
+	for _, change := range changes {
+		switch {
+		case change.Old == nil:
+			fmt.Fprintf(w, "+ %s: %s\n", change.Key, formatSetting(change.New))
+		case change.New == nil:
+			fmt.Fprintf(w, "- %s: %s\n", change.Key, formatSetting(change.Old))
+		default:
+			fmt.Fprintf(w, "~ %s: %s -> %s\n", change.Key, formatSetting(change.Old), formatSetting(change.New))
+		}
+	}
+	return 1
+}
+
+// configStatePaths returns the config files of a config state: the file itself,
+// or a project directory's project config files
+func configStatePaths(arg string) ([]string, error) {
+	info, err := os.Stat(arg)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{arg}, nil
+	}
+	return gismo.ProjectConfigPaths(arg), nil
+}
+
+// formatSetting formats a setting value as compact JSON
+func formatSetting(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+// writeJSON writes value as indented JSON
+func writeJSON(w io.Writer, value interface{}) int {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(value); err != nil {
+		fmt.Fprintf(w, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// validateConfig reports rule problems and returns 1 if any rule can never apply
+func validateConfig(w io.Writer, appConfig *gismo.AppConfig, linterNames []string) int {
+	if appConfig == nil || len(appConfig.Rules) == 0 {
+		fmt.Fprintf(w, "✅ No rules configured\n")
+		return 0
+	}
+
+	problems := appConfig.AnalyzeRules(linterNames)
+	if len(problems) == 0 {
+		fmt.Fprintf(w, "✅ %d rule(s) checked, no conflicts found\n", len(appConfig.Rules))
+		return 0
+	}
+
+	errors, shadowed := 0, false
+	fmt.Fprintf(w, "Checked %d rule(s):\n", len(appConfig.Rules))
+	for _, problem := range problems {
+		icon := "⚠️ "
+		if problem.Severity == "error" {
+			icon = "❌"
+			errors++
+		}
+		if problem.Kind == gismo.RuleShadowed {
+			shadowed = true
+		}
+		fmt.Fprintf(w, "  %s [%s] %s\n", icon, problem.Kind, problem.Message)
+	}
+
+	// Broad rules first, specific rules last avoids shadowing
+	if shadowed {
+		fmt.Fprintf(w, "\nSuggested rule order (broadest first):\n")
+		for i, rule := range gismo.SortRulesBySpecificity(appConfig.Rules) {
+			fmt.Fprintf(w, "  %d. pattern %q, linter %q\n", i+1, rule.Pattern, rule.Linter)
+		}
+	}
+
+	if errors > 0 {
+		return 1
+	}
+	return 0
+}
+
+// validateLinterConfigs reports linter config blocks and rule settings that don't
+// match the linter's schema, such as typoed keys, and returns 1 if there are any
+func validateLinterConfigs(w io.Writer, appConfig *gismo.AppConfig, schemas map[string]json.RawMessage) int {
+	problems := appConfig.ValidateLinterConfigs(schemas)
+	if len(problems) == 0 {
+		if appConfig != nil && len(appConfig.Linters) > 0 {
+			fmt.Fprintf(w, "✅ %d linter config(s) match their schemas\n", len(appConfig.Linters))
+		}
+		return 0
+	}
+
+	fmt.Fprintf(w, "Linter configuration problems:\n")
+	for _, problem := range problems {
+		fmt.Fprintf(w, "  ❌ [%s] %s: %s\n", problem.Linter, problem.Source, problem.Message)
+	}
+	return 1
+}
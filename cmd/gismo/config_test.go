@@ -0,0 +1,163 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jrossi/gismo"
+)
+
+func TestRunConfigCommand_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		rules    []gismo.RuleOverride
+		wantCode int
+		wantOut  []string
+	}{
+		{
+			name:     "no rules",
+			wantCode: 0,
+			wantOut:  []string{"No rules configured"},
+		},
+		{
+			name: "shadowed rule warns and suggests an order",
+			rules: []gismo.RuleOverride{
+				{Pattern: "*_test.go", Linter: "go", Rules: json.RawMessage(`{"maxLineLength": 200}`)},
+				{Pattern: "*", Linter: "*", Rules: json.RawMessage(`{"maxLineLength": 120}`)},
+			},
+			wantCode: 0,
+			wantOut:  []string{"[shadowed]", "Suggested rule order", `1. pattern "*", linter "*"`},
+		},
+		{
+			name: "unknown linter fails",
+			rules: []gismo.RuleOverride{
+				{Pattern: "*.rb", Linter: "ruby", Rules: json.RawMessage(`{}`)},
+			},
+			wantCode: 1,
+			wantOut:  []string{"[unknown-linter]"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			config := &gismo.AppConfig{Rules: tt.rules}
+			code := runConfigCommand(&out, []string{"validate"}, config, []string{"go", "python"}, nil, nil, nil)
+			if code != tt.wantCode {
+				t.Errorf("exit code = %d, want %d\n%s", code, tt.wantCode, out.String())
+			}
+			for _, want := range tt.wantOut {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("output missing %q:\n%s", want, out.String())
+				}
+			}
+		})
+	}
+
+	var out bytes.Buffer
+	if code := runConfigCommand(&out, []string{"bogus"}, nil, nil, nil, nil, nil); code != 1 {
+		t.Errorf("unknown subcommand exit code = %d, want 1", code)
+	}
+}
+
+func TestRunConfigCommand_ValidateLinterConfigs(t *testing.T) {
+	schemas := map[string]json.RawMessage{
+		"go": json.RawMessage(`{"type": "object", "properties": {"gofumpt": {"type": "boolean"}}, "additionalProperties": false}`),
+	}
+	config := &gismo.AppConfig{Linters: map[string]gismo.LinterConfig{
+		"go": {Config: json.RawMessage(`{"gofumt": true}`)},
+	}}
+
+	var out bytes.Buffer
+	if code := runConfigCommand(&out, []string{"validate"}, config, []string{"go"}, schemas, nil, nil); code != 1 {
+		t.Errorf("exit code = %d, want 1\n%s", code, out.String())
+	}
+	if !strings.Contains(out.String(), `[go] linters.go.config: unknown key "gofumt" (known: gofumpt)`) {
+		t.Errorf("output missing unknown key problem:\n%s", out.String())
+	}
+
+	out.Reset()
+	config.Linters["go"] = gismo.LinterConfig{Config: json.RawMessage(`{"gofumpt": true}`)}
+	if code := runConfigCommand(&out, []string{"validate"}, config, []string{"go"}, schemas, nil, nil); code != 0 {
+		t.Errorf("exit code = %d, want 0\n%s", code, out.String())
+	}
+	if !strings.Contains(out.String(), "1 linter config(s) match their schemas") {
+		t.Errorf("output missing success message:\n%s", out.String())
+	}
+}
+
+func TestRunConfigCommand_ShowEffective(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "gismo.json")
+	content := `{"strict": true, "rules": [{"pattern": "*.md", "linter": "markdown", "rules": {"maxLineLength": 200}}]}`
+	if err := os.WriteFile(configPath, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	loader, err := gismo.NewConfigLoader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	layers, err := loader.LoadConfigLayers([]string{configPath})
+	if err != nil {
+		t.Fatal(err)
+	}
+	appConfig := gismo.MergeConfigLayers(layers)
+	engine := gismo.NewLintingRuleEngine()
+	engine.SetAppConfig(appConfig)
+
+	var out bytes.Buffer
+	args := []string{"show", "-effective", "-for", filepath.Join(dir, "README.md")}
+	if code := runConfigCommand(&out, args, appConfig, nil, nil, layers, engine); code != 0 {
+		t.Fatalf("exit code = %d\n%s", code, out.String())
+	}
+	// Compare with the column padding collapsed
+	output := strings.Join(strings.Fields(out.String()), " ")
+	for _, want := range []string{
+		"linters.markdown.config.maxLineLength 200 " + configPath + ` rules[0] "*.md"`,
+		"strict true " + configPath,
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+
+	out.Reset()
+	if code := runConfigCommand(&out, []string{"show", "-for", "README.md"}, appConfig, nil, nil, layers, engine); code != 1 {
+		t.Errorf("-for without -effective exit code = %d, want 1", code)
+	}
+}
+
+func TestRunConfigCommand_Diff(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a.json")
+	b := filepath.Join(dir, "b.json")
+	if err := os.WriteFile(a, []byte(`{"strict": true, "feedback": {"language": "ja"}}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(b, []byte(`{"strict": false, "timeout": "30s"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if code := runConfigCommand(&out, []string{"diff", a, b}, nil, nil, nil, nil, nil); code != 1 {
+		t.Errorf("exit code = %d, want 1 for differences", code)
+	}
+	want := "- feedback.language: \"ja\"\n~ strict: true -> false\n+ timeout: \"30s\"\n"
+	if out.String() != want {
+		t.Errorf("diff output = %q, want %q", out.String(), want)
+	}
+
+	out.Reset()
+	if code := runConfigCommand(&out, []string{"diff", a, a}, nil, nil, nil, nil, nil); code != 0 || !strings.Contains(out.String(), "No differences") {
+		t.Errorf("diff of a file with itself = %d, %q", code, out.String())
+	}
+
+	out.Reset()
+	if code := runConfigCommand(&out, []string{"diff", a, filepath.Join(dir, "missing.json")}, nil, nil, nil, nil, nil); code != 1 || !strings.Contains(out.String(), "missing.json") {
+		t.Errorf("diff with a missing file = %d, %q", code, out.String())
+	}
+}
@@ -0,0 +1,251 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/jrossi/gismo"
+	"github.com/jrossi/gismo/internal/statedir"
+)
+
+// noDaemonEnv names the environment variable that stops hooks forwarding to a daemon
+const noDaemonEnv = "GISMO_NO_DAEMON"
+
+// defaultDaemonIdle is how long the daemon waits for a hook before exiting
+const defaultDaemonIdle = 30 * time.Minute
+
+// daemonPollInterval is how often the daemon checks its idle time and config files
+const daemonPollInterval = 2 * time.Second
+
+// defaultDaemonSocket returns the socket of the daemon serving hooks run in dir.
+// Config files are found relative to the working directory, so each directory
+// gets its own daemon. Sockets live in the user's runtime directory, where
+// other users can't plant one of their own.
+func defaultDaemonSocket(dir string) string {
+	sum := sha256.Sum256([]byte(dir))
+	return statedir.RuntimeDir("daemon", hex.EncodeToString(sum[:8])+".sock")
+}
+
+// daemonTokenPath returns the file holding the bearer token of the daemon on socket
+func daemonTokenPath(socket string) string {
+	return socket + ".token"
+}
+
+// runDaemon handles `gismo daemon`: it processes hook messages forwarded by hook
+// processes over a unix socket, keeping linters, tool caches and warmed tools in
+// memory between hooks. It exits when interrupted, after idle without a hook, or
+// when one of configPaths changes, since its config would be stale. outputMode
+// is the configured output mode of the hooks it serves.
+func runDaemon(w io.Writer, args []string, ruleEngine gismo.RuleEngine, timeout time.Duration, outputMode string, dir string, configPaths []string) int {
+	fs := flag.NewFlagSet("daemon", flag.ContinueOnError)
+	fs.SetOutput(w)
+	socket := fs.String("socket", defaultDaemonSocket(dir), "Unix socket hooks forward messages to")
+	idle := fs.Duration("idle", defaultDaemonIdle, "Exit after this long without a hook (0 never exits)")
+	if err := fs.Parse(args); err != nil {
+		return 1
+	}
+
+	if err := statedir.Ensure(filepath.Dir(*socket)); err != nil {
+		fmt.Fprintf(w, "Error: failed to create socket directory: %v\n", err)
+		return 1
+	}
+	if conn, err := net.DialTimeout("unix", *socket, time.Second); err == nil {
+		conn.Close()
+		fmt.Fprintf(w, "Error: a daemon is already listening on %s\n", *socket)
+		return 1
+	}
+	// A socket left by a daemon that didn't shut down cleanly blocks Listen
+	_ = os.Remove(*socket)
+	listener, err := net.Listen("unix", *socket)
+	if err != nil {
+		fmt.Fprintf(w, "Error: failed to listen: %v\n", err)
+		return 1
+	}
+	defer os.Remove(*socket)
+	if err := os.Chmod(*socket, 0600); err != nil {
+		listener.Close()
+		fmt.Fprintf(w, "Error: failed to restrict socket: %v\n", err)
+		return 1
+	}
+
+	token, err := generateServeToken()
+	if err != nil {
+		listener.Close()
+		fmt.Fprintf(w, "Error: %v\n", err)
+		return 1
+	}
+	tokenPath := daemonTokenPath(*socket)
+	// WriteFile keeps the mode of an existing file, so replace any stale token
+	_ = os.Remove(tokenPath)
+	if err := os.WriteFile(tokenPath, []byte(token), 0600); err != nil {
+		listener.Close()
+		fmt.Fprintf(w, "Error: failed to write token: %v\n", err)
+		return 1
+	}
+	defer os.Remove(tokenPath)
+
+	hooks := gismo.NewHookServer(ruleEngine, token)
+	hooks.SetTimeout(timeout)
+	hooks.SetOutputMode(outputMode)
+	var lastHook atomic.Int64
+	lastHook.Store(time.Now().UnixNano())
+	handler := hooks.Handler()
+	server := &http.Server{
+		Handler: http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			lastHook.Store(time.Now().UnixNano())
+			handler.ServeHTTP(rw, r)
+		}),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	fmt.Fprintf(w, "gismo daemon on %s\n", *socket)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	shutdown := make(chan struct{})
+	go func() {
+		defer close(shutdown)
+		configs := configModTimes(configPaths)
+		ticker := time.NewTicker(daemonPollInterval)
+		defer ticker.Stop()
+	wait:
+		for {
+			select {
+			case <-ctx.Done():
+				break wait
+			case <-ticker.C:
+				if *idle > 0 && time.Since(time.Unix(0, lastHook.Load())) > *idle {
+					fmt.Fprintf(w, "No hooks for %s, exiting\n", *idle)
+					break wait
+				}
+				if !equalModTimes(configs, configModTimes(configPaths)) {
+					fmt.Fprintf(w, "Configuration changed, exiting\n")
+					break wait
+				}
+			}
+		}
+		// Give in-flight hooks as long as a hook may take
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		fmt.Fprintf(w, "Error: %v\n", err)
+		return 1
+	}
+	<-shutdown
+	return 0
+}
+
+// configModTimes returns the modification time of each config file, zero for
+// missing ones
+func configModTimes(paths []string) []time.Time {
+	times := make([]time.Time, len(paths))
+	for i, path := range paths {
+		if info, err := os.Stat(path); err == nil {
+			times[i] = info.ModTime()
+		}
+	}
+	return times
+}
+
+// equalModTimes reports whether two snapshots of config modification times match
+func equalModTimes(a, b []time.Time) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// daemonClient forwards hook messages to a running daemon
+type daemonClient struct {
+	client *http.Client
+	token  string
+}
+
+// dialDaemon returns a client for the daemon on socket, or nil if none is running.
+// Hooks send file contents to the daemon and act on its answers, so the socket
+// and token must belong to the current user and be private to them; otherwise
+// the hook runs locally. Requests have no client timeout: the daemon applies
+// the hook timeout itself.
+func dialDaemon(socket string) *daemonClient {
+	tokenPath := daemonTokenPath(socket)
+	if statedir.CheckPrivate(socket) != nil || statedir.CheckPrivate(tokenPath) != nil {
+		return nil
+	}
+	token, err := os.ReadFile(tokenPath)
+	if err != nil {
+		return nil
+	}
+	conn, err := net.DialTimeout("unix", socket, time.Second)
+	if err != nil {
+		return nil
+	}
+	conn.Close()
+	dialer := &net.Dialer{Timeout: time.Second}
+	return &daemonClient{
+		client: &http.Client{
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					return dialer.DialContext(ctx, "unix", socket)
+				},
+			},
+		},
+		token: strings.TrimSpace(string(token)),
+	}
+}
+
+// forward sends data to the daemon and writes what the hook would have written
+// to stdout and stderr. ok is false if the daemon couldn't process the hook, so
+// the caller can process it locally instead.
+func (c *daemonClient) forward(data []byte, stdout, stderr io.Writer) (exitCode int, ok bool) {
+	req, err := http.NewRequest(http.MethodPost, "http://gismo/hook", bytes.NewReader(data))
+	if err != nil {
+		return 0, false
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, false
+	}
+	defer resp.Body.Close()
+	// Invalid messages are reported as the command line reports them; any other
+	// failure is the daemon's, not the hook's
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusBadRequest {
+		return 0, false
+	}
+	var result gismo.HookServerResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return 0, false
+	}
+
+	fmt.Fprint(stdout, result.Output)
+	fmt.Fprint(stderr, result.Feedback)
+	if result.Error != "" {
+		fmt.Fprintf(stderr, "\n> Hook execution error:\n")
+		fmt.Fprintf(stderr, "  - [gismo]: ❌ %s\n", result.Error)
+	}
+	return result.ExitCode, true
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jrossi/gismo"
+)
+
+func TestDaemon_ForwardsHooks(t *testing.T) {
+	// Unix socket paths are limited to about 100 bytes, so avoid t.TempDir
+	dir, err := os.MkdirTemp("", "gismo-daemon")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	socket := filepath.Join(dir, "d.sock")
+	config := filepath.Join(dir, "gismo.json")
+
+	if dialDaemon(socket) != nil {
+		t.Fatal("dialDaemon() found a daemon before one started")
+	}
+
+	var log bytes.Buffer
+	done := make(chan int)
+	go func() {
+		done <- runDaemon(&log, []string{"-socket", socket}, gismo.NewLintingRuleEngine(), 10*time.Second, gismo.OutputModeExitCode, dir, []string{config})
+	}()
+
+	var daemon *daemonClient
+	for i := 0; i < 100 && daemon == nil; i++ {
+		time.Sleep(20 * time.Millisecond)
+		daemon = dialDaemon(socket)
+	}
+	if daemon == nil {
+		t.Fatal("daemon did not start")
+	}
+
+	// A token other users can read, or a socket reached through a symlink,
+	// may not be the daemon's
+	token := daemonTokenPath(socket)
+	if err := os.Chmod(token, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if dialDaemon(socket) != nil {
+		t.Error("dialDaemon() trusted a token readable by others")
+	}
+	if err := os.Chmod(token, 0600); err != nil {
+		t.Fatal(err)
+	}
+	link := filepath.Join(dir, "link.sock")
+	if err := os.Symlink(socket, link); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(token, daemonTokenPath(link)); err != nil {
+		t.Fatal(err)
+	}
+	if dialDaemon(link) != nil {
+		t.Error("dialDaemon() followed a symlinked socket")
+	}
+
+	tests := []struct {
+		name       string
+		message    string
+		wantCode   int
+		wantStderr string
+	}{
+		{name: "stop", message: `{"hook_event_name":"Stop","session_id":"s"}`, wantCode: 0},
+		{name: "post tool use", message: `{"hook_event_name":"PostToolUse","session_id":"s","tool_name":"Read"}`, wantCode: 2},
+		{name: "invalid message", message: `{"hook_event_name":"PreToolUse"}`, wantCode: 1, wantStderr: "Hook execution error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code, ok := daemon.forward([]byte(tt.message), &stdout, &stderr)
+			if !ok {
+				t.Fatal("forward() failed")
+			}
+			if code != tt.wantCode {
+				t.Errorf("exit code = %d, want %d (stderr %q)", code, tt.wantCode, stderr.String())
+			}
+			if !strings.Contains(stderr.String(), tt.wantStderr) {
+				t.Errorf("stderr = %q, want %q", stderr.String(), tt.wantStderr)
+			}
+		})
+	}
+
+	// A second daemon on the same socket is refused
+	var second bytes.Buffer
+	if code := runDaemon(&second, []string{"-socket", socket}, gismo.NewLintingRuleEngine(), time.Second, gismo.OutputModeExitCode, dir, nil); code != 1 {
+		t.Errorf("second daemon exit code = %d, want 1", code)
+	}
+
+	// Changing the config stops the daemon, since its config is stale
+	if err := os.WriteFile(config, []byte("{}"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case code := <-done:
+		if code != 0 {
+			t.Errorf("daemon exit code = %d, want 0", code)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("daemon did not exit after the config changed")
+	}
+	if !strings.Contains(log.String(), "Configuration changed") {
+		t.Errorf("daemon log = %q", log.String())
+	}
+	if _, err := os.Stat(socket); !os.IsNotExist(err) {
+		t.Errorf("socket left behind: %v", err)
+	}
+	if dialDaemon(socket) != nil {
+		t.Error("dialDaemon() found a stopped daemon")
+	}
+}
+
+func TestDaemonClient_Unavailable(t *testing.T) {
+	dir, err := os.MkdirTemp("", "gismo-daemon")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	socket := filepath.Join(dir, "d.sock")
+
+	// A token left by a crashed daemon without a listening socket isn't a daemon
+	if err := os.WriteFile(daemonTokenPath(socket), []byte("t"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if dialDaemon(socket) != nil {
+		t.Error("dialDaemon() = client for a socket nobody listens on")
+	}
+
+	if defaultDaemonSocket("/a") == defaultDaemonSocket("/b") {
+		t.Error("defaultDaemonSocket() is the same for different directories")
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jrossi/gismo"
+	"github.com/jrossi/gismo/toolcache"
+)
+
+// blockingPlugin stands in for a WASM plugin that blocks every tool use
+type blockingPlugin struct {
+	*gismo.BaseRuleEngine
+	calls int
+}
+
+func (p *blockingPlugin) EvaluatePreToolUse(ctx context.Context, msg *gismo.PreToolUseMessage) (*gismo.HookResponse, error) {
+	p.calls++
+	return &gismo.HookResponse{Decision: "block", Reason: "blocked by plugin"}, nil
+}
+
+func TestNewHookEngine_PluginsWithDecisionCache(t *testing.T) {
+	ruleEngine := gismo.NewLintingRuleEngineWithConfig(gismo.LintingConfig{ToolCache: toolcache.NewMemoryCache()})
+	plugin := &blockingPlugin{BaseRuleEngine: gismo.NewBaseRuleEngine()}
+	store := gismo.NewSessionStore(t.TempDir())
+
+	// The decision cache is on by default
+	hookEngine, feedbackEngine := newHookEngine(ruleEngine, []gismo.RuleEngine{plugin}, nil, store, "")
+	if _, ok := hookEngine.(*gismo.CachingRuleEngine); !ok {
+		t.Fatalf("hook engine = %T, want the decision cache", hookEngine)
+	}
+	feedbackEngine.SetFeedbackWriter(io.Discard)
+
+	content, _ := json.Marshal("hello\n")
+	path, _ := json.Marshal(filepath.Join(t.TempDir(), "notes.txt"))
+	msg := &gismo.PreToolUseMessage{
+		BaseHookMessage: gismo.BaseHookMessage{SessionID: "s1"},
+		ToolName:        "Write",
+		ToolInput:       map[string]json.RawMessage{"file_path": path, "content": content},
+	}
+	for i := 0; i < 2; i++ {
+		response, err := hookEngine.EvaluatePreToolUse(context.Background(), msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// A replayed block keeps the plugin's reason after the retry notice
+		if response == nil || response.Decision != "block" || !strings.HasSuffix(response.Reason, "blocked by plugin") {
+			t.Fatalf("attempt %d: response = %+v, want the plugin's block", i+1, response)
+		}
+	}
+	// The retry is answered from the cache
+	if plugin.calls != 1 {
+		t.Errorf("plugin ran %d times, want 1", plugin.calls)
+	}
+}
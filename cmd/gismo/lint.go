@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jrossi/gismo"
+	"github.com/jrossi/gismo/linters"
+	"github.com/jrossi/gismo/report"
+)
+
+// lintSkipDirs are directories gismo lint doesn't descend into when walking a
+// directory; hidden directories are skipped too
+var lintSkipDirs = map[string]bool{
+	"node_modules": true,
+	"vendor":       true,
+}
+
+// runLint handles `gismo lint`: it runs the configured linters on files and
+// directories from the shell, prints the issues in the chosen format and exits
+// with 2 if any is an error. With -diff-base, it lints the files changed since
+// a git ref and reports only the issues introduced since then, as a PR gate.
+func runLint(w io.Writer, args []string, ruleEngine *gismo.LintingRuleEngine) int {
+	flags := flag.NewFlagSet("lint", flag.ContinueOnError)
+	flags.SetOutput(w)
+	format := flags.String("format", report.FormatText, "Output format: "+strings.Join(report.Formats(), ", "))
+	// lint is run from the shell like check, so it offers strict mode as well
+	strict := flags.Bool("strict", false, "Treat warnings as errors")
+	contextLines := flags.Int("context", 0, "Source lines to include either side of each issue (default: from the config)")
+	diffBase := flags.String("diff-base", "", "Lint only files changed between this git ref and HEAD, reporting issues introduced since the ref")
+	flags.Usage = func() {
+		fmt.Fprintf(w, "Usage: gismo lint [-format text|json|sarif] [-strict] [-context n] [-diff-base ref] [paths...]\n\n")
+		fmt.Fprintf(w, "Lints files, and the files in directories, with the configured linters.\n")
+		fmt.Fprintf(w, "Lints the current directory without paths. Exits with 2 if any issue is an error.\n\n")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+	if *strict {
+		ruleEngine.SetStrict(true)
+	}
+	ruleEngine.SetContextLines(*contextLines)
+
+	root, err := os.Getwd()
+	if err != nil {
+		fmt.Fprintf(w, "Error: %v\n", err)
+		return 1
+	}
+	reporter, err := report.New(*format, root)
+	if err != nil {
+		fmt.Fprintf(w, "Error: %v\n", err)
+		return 1
+	}
+
+	paths := flags.Args()
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+	var files []lintTarget
+	if *diffBase != "" {
+		files, err = changedFiles(*diffBase, paths)
+	} else {
+		var found []string
+		found, err = lintFiles(paths)
+		for _, path := range found {
+			files = append(files, lintTarget{path: path})
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(w, "Error: %v\n", err)
+		return 1
+	}
+
+	issues := []linters.Issue{}
+	blocking := false
+	for _, file := range files {
+		content, err := os.ReadFile(file.path) // #nosec G304 - path given on the command line
+		if err != nil {
+			fmt.Fprintf(w, "Error: failed to read %s: %v\n", file.path, err)
+			return 1
+		}
+		fileIssues, err := lintFileIssues(ruleEngine, file.path, content)
+		if err != nil {
+			fmt.Fprintf(w, "Error: %s: %v\n", file.path, err)
+			return 1
+		}
+		// Issues the file already had at the diff base aren't reported. The base
+		// content is linted under the current path so fingerprints match.
+		if file.hasBase {
+			baseIssues, err := lintFileIssues(ruleEngine, file.path, file.base)
+			if err != nil {
+				fmt.Fprintf(w, "Error: %s at %s: %v\n", file.path, *diffBase, err)
+				return 1
+			}
+			fileIssues, _ = linters.SplitByBaseline(fileIssues, baseIssues)
+		}
+		for _, issue := range fileIssues {
+			issues = append(issues, issue)
+			blocking = blocking || issue.Severity == "error"
+		}
+	}
+
+	if err := reporter.Report(w, issues); err != nil {
+		fmt.Fprintf(w, "Error: %v\n", err)
+		return 1
+	}
+	if blocking {
+		return int(gismo.ExitBlocking)
+	}
+	return int(gismo.ExitSuccess)
+}
+
+// lintFileIssues lints content as filePath and returns the issues found
+func lintFileIssues(ruleEngine *gismo.LintingRuleEngine, filePath string, content []byte) ([]linters.Issue, error) {
+	diagnostics, err := ruleEngine.LintFile(context.Background(), filePath, content)
+	if err != nil {
+		return nil, err
+	}
+	issues := make([]linters.Issue, 0, len(diagnostics))
+	for _, diagnostic := range diagnostics {
+		issues = append(issues, diagnostic.Issue)
+	}
+	return issues, nil
+}
+
+// lintFiles returns the absolute paths of the files named by paths, walking
+// directories. Files named directly are always included.
+func lintFiles(paths []string) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return nil, err
+		}
+		info, err := os.Stat(abs)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, abs)
+			continue
+		}
+		err = filepath.WalkDir(abs, func(file string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() {
+				name := entry.Name()
+				if file != abs && (lintSkipDirs[name] || strings.HasPrefix(name, ".")) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if entry.Type().IsRegular() {
+				files = append(files, file)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
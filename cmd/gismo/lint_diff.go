@@ -0,0 +1,134 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// lintTarget is a file gismo lint checks, with the content it had at the diff
+// base when there is one
+type lintTarget struct {
+	path string
+	// hasBase is set when the file existed at the diff base, with that content in base
+	hasBase bool
+	base    []byte
+}
+
+// changedFiles returns the files under paths that changed between the merge
+// base of ref and HEAD, with their content at the merge base. Deleted files are
+// left out, and renamed files keep the content they had under their old name.
+// Files under the directories lint skips when walking are left out as well.
+func changedFiles(ref string, paths []string) ([]lintTarget, error) {
+	if ref == "" || strings.HasPrefix(ref, "-") {
+		return nil, fmt.Errorf("invalid diff base %q", ref)
+	}
+	roots := make([]string, 0, len(paths))
+	for _, path := range paths {
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return nil, err
+		}
+		// git reports the repository with symlinks resolved
+		if resolved, err := filepath.EvalSymlinks(abs); err == nil {
+			abs = resolved
+		}
+		roots = append(roots, abs)
+	}
+	dir := roots[0]
+	if info, err := os.Stat(dir); err != nil {
+		return nil, err
+	} else if !info.IsDir() {
+		dir = filepath.Dir(dir)
+	}
+
+	top, err := git(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, err
+	}
+	repo := strings.TrimSpace(string(top))
+	mergeBase, err := git(repo, "merge-base", ref, "HEAD")
+	if err != nil {
+		return nil, err
+	}
+	base := strings.TrimSpace(string(mergeBase))
+	out, err := git(repo, "diff", "--name-status", "-z", "-M", "--diff-filter=ACMR", base, "HEAD")
+	if err != nil {
+		return nil, err
+	}
+
+	var files []lintTarget
+	fields := strings.Split(strings.TrimSuffix(string(out), "\x00"), "\x00")
+	for i := 0; i+1 < len(fields); {
+		status, oldPath, newPath := fields[i], fields[i+1], fields[i+1]
+		i += 2
+		// Renames and copies list the old path, then the new one
+		if strings.HasPrefix(status, "R") || strings.HasPrefix(status, "C") {
+			if i >= len(fields) {
+				break
+			}
+			newPath = fields[i]
+			i++
+		}
+
+		path := filepath.Join(repo, filepath.FromSlash(newPath))
+		if !underLintRoots(path, roots) {
+			continue
+		}
+		if info, err := os.Stat(path); err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		file := lintTarget{path: path, hasBase: status != "A"}
+		if file.hasBase {
+			content, err := git(repo, "show", base+":"+oldPath)
+			if err != nil {
+				return nil, err
+			}
+			file.base = content
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// underLintRoots reports whether path is one of roots, or inside one of them
+// and not in a directory lint skips when walking
+func underLintRoots(path string, roots []string) bool {
+	for _, root := range roots {
+		if path == root {
+			return true
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		skipped := false
+		dirs := strings.Split(filepath.Dir(rel), string(filepath.Separator))
+		for _, name := range dirs {
+			if name != "." && (lintSkipDirs[name] || strings.HasPrefix(name, ".")) {
+				skipped = true
+				break
+			}
+		}
+		if !skipped {
+			return true
+		}
+	}
+	return false
+}
+
+// git runs git in dir and returns its output
+func git(dir string, args ...string) ([]byte, error) {
+	cmd := exec.Command("git", args...) // #nosec G204 - fixed subcommands; refs are checked not to be flags
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
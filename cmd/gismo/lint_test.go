@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jrossi/gismo"
+	"github.com/jrossi/gismo/linters"
+)
+
+func TestRunLint(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"valid.json":              `{"valid": true}`,
+		"sub/invalid.json":        `{"a": }`,
+		"node_modules/bad.json":   `{"a": }`,
+		".hidden/bad.json":        `{"a": }`,
+		"sub/deeper/another.json": `[1, 2]`,
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantCode int
+		want     string
+		wantNot  string
+	}{
+		{name: "valid file", args: []string{filepath.Join(dir, "valid.json")}},
+		{name: "directory", args: []string{dir}, wantCode: 2, want: "invalid.json:1:7: error", wantNot: "bad.json"},
+		{name: "skipped directory named directly", args: []string{filepath.Join(dir, "node_modules")}, wantCode: 2, want: "bad.json"},
+		{name: "sarif", args: []string{"-format", "sarif", filepath.Join(dir, "sub")}, wantCode: 2, want: `"version": "2.1.0"`},
+		{name: "unknown format", args: []string{"-format", "xml", dir}, wantCode: 1, want: "unknown report format"},
+		{name: "missing path", args: []string{filepath.Join(dir, "missing.json")}, wantCode: 1, want: "Error:"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			if code := runLint(&out, tt.args, gismo.NewLintingRuleEngine()); code != tt.wantCode {
+				t.Errorf("exit code = %d, want %d\n%s", code, tt.wantCode, out.String())
+			}
+			if !strings.Contains(out.String(), tt.want) {
+				t.Errorf("output missing %q:\n%s", tt.want, out.String())
+			}
+			if tt.wantNot != "" && strings.Contains(out.String(), tt.wantNot) {
+				t.Errorf("output contains %q:\n%s", tt.wantNot, out.String())
+			}
+		})
+	}
+}
+
+func TestRunLint_JSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bad.json")
+	if err := os.WriteFile(path, []byte(`{"a": }`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if code := runLint(&out, []string{"-format", "json", path}, gismo.NewLintingRuleEngine()); code != 2 {
+		t.Fatalf("exit code = %d, want 2\n%s", code, out.String())
+	}
+	var issues []linters.Issue
+	if err := json.Unmarshal(out.Bytes(), &issues); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out.String())
+	}
+	if len(issues) == 0 || issues[0].Severity != "error" {
+		t.Errorf("issues = %+v, want a syntax error", issues)
+	}
+}
+
+func TestRunLint_DiffBase(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	gitRun := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	gitRun("init", "-q")
+	write("old.json", "{\n\"a\": }\n")
+	write("untouched.json", `{"a": }`)
+	write("renamed.json", "[1, 2]\n")
+	gitRun("add", ".")
+	gitRun("commit", "-q", "-m", "base")
+
+	// old.json keeps its error, new.json and moved.json introduce one
+	write("old.json", "{\n\"a\": }\n\n")
+	write("new.json", `{"b": }`)
+	gitRun("mv", "renamed.json", "moved.json")
+	write("moved.json", "[1, 2,]\n")
+	gitRun("add", ".")
+	gitRun("commit", "-q", "-m", "change")
+
+	var out bytes.Buffer
+	code := runLint(&out, []string{"-format", "json", "-diff-base", "HEAD~1", dir}, gismo.NewLintingRuleEngine())
+	if code != 2 {
+		t.Fatalf("exit code = %d, want 2\n%s", code, out.String())
+	}
+	var issues []linters.Issue
+	if err := json.Unmarshal(out.Bytes(), &issues); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out.String())
+	}
+	files := make(map[string]bool)
+	for _, issue := range issues {
+		files[filepath.Base(issue.File)] = true
+	}
+	if !files["new.json"] || !files["moved.json"] || files["old.json"] || files["untouched.json"] {
+		t.Errorf("issues in %v, want only those introduced since the base", files)
+	}
+
+	out.Reset()
+	if code := runLint(&out, []string{"-diff-base", "HEAD", dir}, gismo.NewLintingRuleEngine()); code != 0 {
+		t.Errorf("no changes: exit code = %d\n%s", code, out.String())
+	}
+	out.Reset()
+	if code := runLint(&out, []string{"-diff-base", "--output=x", dir}, gismo.NewLintingRuleEngine()); code != 1 {
+		t.Errorf("flag as ref: exit code = %d\n%s", code, out.String())
+	}
+}
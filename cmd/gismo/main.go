@@ -1,9 +1,11 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -26,6 +28,10 @@ func main() {
 		showVersion = flag.Bool("version", false, "Show version information")
 		debug       = flag.Bool("debug", false, "Enable debug output")
 		configFile  = flag.String("config", "", "Path to configuration file")
+		eventStream = flag.String("event-stream", "", "Write JSONL lifecycle events to a file or unix:<socket>")
+		traceExec   = flag.String("trace-exec", "", "Log every external command to a file, unix:<socket> or - for stderr")
+		printSchema = flag.Bool("print-schema", false, "Print the JSON Schema of each hook event's message and exit")
+		jsonVersion = flag.Bool("json", false, "With -version, print the build information as JSON")
 	)
 
 	flag.Usage = func() {
@@ -33,7 +39,23 @@ func main() {
 		fmt.Fprintf(os.Stderr, "Usage: %s [flags] [command] [arguments]\n\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "Commands:\n")
 		fmt.Fprintf(os.Stderr, "  init                    Set up gismo in Claude Code settings\n")
+		fmt.Fprintf(os.Stderr, "  bootstrap [-install]    Set up hooks, config and tools non-interactively, for devcontainers\n")
 		fmt.Fprintf(os.Stderr, "  show <command>          Show various information (config, filter, setup, linters)\n")
+		fmt.Fprintf(os.Stderr, "  config validate         Check linter configs and rules for conflicts and mistakes\n")
+		fmt.Fprintf(os.Stderr, "  config show [-effective -for file] Show the merged config, or the settings for a file and who set them\n")
+		fmt.Fprintf(os.Stderr, "  config diff <a> <b>     Compare the settings of two config files or project directories\n")
+		fmt.Fprintf(os.Stderr, "  rules install <url|path> Install a shared rule pack into the project config\n")
+		fmt.Fprintf(os.Stderr, "  rules list              List installed rule packs\n")
+		fmt.Fprintf(os.Stderr, "  tune [flags]            Replay recent blocks against a proposed policy change\n")
+		fmt.Fprintf(os.Stderr, "  allow <rule>... [flags] Relax rules for the current session, e.g. allow errcheck -for 30m\n")
+		fmt.Fprintf(os.Stderr, "  status-server [flags]   Serve live diagnostics for editor integrations\n")
+		fmt.Fprintf(os.Stderr, "  serve [flags]           Process hook messages posted over HTTP\n")
+		fmt.Fprintf(os.Stderr, "  daemon [flags]          Keep linters warm and process hooks forwarded over a unix socket\n")
+		fmt.Fprintf(os.Stderr, "  check -path file [-stdin] Lint content as if it were about to be written to file\n")
+		fmt.Fprintf(os.Stderr, "  lint [flags] [paths...]  Lint files and directories and report the issues\n")
+		fmt.Fprintf(os.Stderr, "  mcp                     Serve lint tools over the Model Context Protocol on stdio\n")
+		fmt.Fprintf(os.Stderr, "  version [-capabilities|-json] Show version information and the built-in checks of each linter\n")
+		fmt.Fprintf(os.Stderr, "  self-update [-check]    Update gismo to the latest release (checksum-checked; releases are unsigned)\n")
 		fmt.Fprintf(os.Stderr, "\nFlags:\n")
 		flag.PrintDefaults()
 		fmt.Fprintf(os.Stderr, "\nDefault behavior (no command):\n")
@@ -47,17 +69,56 @@ func main() {
 	flag.Parse()
 
 	if *showVersion {
-		fmt.Printf("gismo version %s\n", version)
-		if commit != "none" {
-			fmt.Printf("  commit: %s\n", commit)
+		if *jsonVersion {
+			os.Exit(writeJSON(os.Stdout, newVersionInfo(gismo.NewLintingRuleEngine())))
 		}
-		if date != "unknown" {
-			fmt.Printf("  built at: %s\n", date)
+		printVersion(os.Stdout)
+		os.Exit(0)
+	}
+
+	// The version command reports on the binary, so it needs no configuration
+	if args := flag.Args(); len(args) > 0 && args[0] == "version" {
+		os.Exit(runVersion(os.Stdout, args[1:], gismo.NewLintingRuleEngine()))
+	}
+	if args := flag.Args(); len(args) > 0 && args[0] == "self-update" {
+		os.Exit(runSelfUpdate(os.Stdout, args[1:]))
+	}
+
+	if *printSchema {
+		os.Exit(printHookSchemas(os.Stdout, flag.Args()))
+	}
+
+	// Installing rule packs must work even when the config lists a missing pack
+	if args := flag.Args(); len(args) > 0 && args[0] == "rules" {
+		projectDir, err := os.Getwd()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
 		}
-		if builtBy != "" {
-			fmt.Printf("  built by: %s\n", builtBy)
+		os.Exit(runRulesCommand(os.Stdout, args[1:], projectDir))
+	}
+
+	// A running daemon already has the config, linters and tool caches loaded, so
+	// hooks hand their messages over instead of starting cold. Flags that change
+	// how this process handles the hook keep it local.
+	var hookInput []byte
+	if len(flag.Args()) == 0 && *configFile == "" && *eventStream == "" && *traceExec == "" && os.Getenv(noDaemonEnv) == "" {
+		if dir, err := os.Getwd(); err == nil {
+			if daemon := dialDaemon(defaultDaemonSocket(dir)); daemon != nil {
+				data, err := io.ReadAll(os.Stdin)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error: failed to read stdin: %v\n", err)
+					os.Exit(1)
+				}
+				if exitCode, ok := daemon.forward(data, os.Stdout, os.Stderr); ok {
+					os.Exit(exitCode)
+				}
+				if *debug {
+					fmt.Fprintf(os.Stderr, "Daemon unavailable, processing the hook locally\n")
+				}
+				hookInput = data
+			}
 		}
-		os.Exit(0)
 	}
 
 	// Load configuration
@@ -89,8 +150,24 @@ func main() {
 		}
 	}
 
+	// Session state lets hooks in the same Claude session share decisions and block streaks
+	sessionStore := gismo.NewSessionStore(gismo.DefaultSessionDir())
+	_ = sessionStore.Prune(gismo.DefaultSessionMaxAge)
+
 	// Create linting config from app config
-	lintingConfig := gismo.LintingConfig{}
+	lintingConfig := gismo.LintingConfig{SessionStore: sessionStore}
+	// Rule overrides from gismo allow, CODEOWNERS and sub-projects are found at
+	// the project root, not in the directory a hook runs in
+	if configLoader != nil {
+		if root, err := configLoader.FindProjectRoot(); err == nil {
+			lintingConfig.ProjectRoot = root
+		}
+	}
+	if appConfig.IsResultCacheEnabled() && appConfig.GetResultCacheTTL() > 0 {
+		resultCache := gismo.NewResultCache(gismo.DefaultResultCacheDir(), appConfig.GetResultCacheTTL())
+		_ = resultCache.Prune()
+		lintingConfig.ResultCache = resultCache
+	}
 	if appConfig != nil {
 		if appConfig.Parallel != nil {
 			if appConfig.Parallel.MaxWorkers != nil {
@@ -99,6 +176,16 @@ func main() {
 			if appConfig.Parallel.DisableParallel != nil {
 				lintingConfig.DisableParallel = *appConfig.Parallel.DisableParallel
 			}
+			lintingConfig.ToolLimits = appConfig.Parallel.ToolLimits
+		}
+		if limits, err := appConfig.GetResourceLimits(); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: resource limits disabled: %v\n", err)
+		} else {
+			lintingConfig.ResourceLimits = &limits
+		}
+		if lintingConfig.ProjectRoot != "" {
+			cacheDirs := appConfig.GetCacheDirs(lintingConfig.ProjectRoot)
+			lintingConfig.CacheDirs = &cacheDirs
 		}
 		// Override timeout if specified in config
 		if appConfig.Timeout != nil {
@@ -106,6 +193,30 @@ func main() {
 		}
 	}
 
+	// Lifecycle events let dashboards and IDE plugins follow gismo live; a missing
+	// subscriber must never break the hook, so failures only warn
+	var eventSink *gismo.JSONLEventSink
+	if *eventStream != "" {
+		sink, err := gismo.OpenEventStream(*eventStream)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: event stream disabled: %v\n", err)
+		} else {
+			eventSink = sink
+			lintingConfig.EventSink = sink
+		}
+	}
+
+	// The exec audit log records every external command the hook runs
+	if *traceExec != "" {
+		if *traceExec == "-" {
+			gismo.TraceExec(gismo.NewJSONLEventSink(os.Stderr))
+		} else if sink, err := gismo.OpenEventStream(*traceExec); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: exec trace disabled: %v\n", err)
+		} else {
+			gismo.TraceExec(sink)
+		}
+	}
+
 	// Create rule engine with linting capabilities
 	ruleEngine := gismo.NewLintingRuleEngineWithConfig(lintingConfig)
 
@@ -118,17 +229,7 @@ func main() {
 	args := flag.Args()
 	if len(args) > 0 && args[0] == "init" {
 		// Dispatch to gismo-init binary
-		subcommand := "gismo-init"
-
-		// Try to find the subcommand in the same directory as the main binary
-		execPath, err := os.Executable()
-		if err == nil {
-			dir := filepath.Dir(execPath)
-			localSubcommand := filepath.Join(dir, subcommand)
-			if _, err := os.Stat(localSubcommand); err == nil {
-				subcommand = localSubcommand
-			}
-		}
+		subcommand := subcommandPath("gismo-init")
 
 		cmd := exec.Command(subcommand, args[1:]...) // #nosec G204 - subcommand is controlled
 		cmd.Stdin = os.Stdin
@@ -146,17 +247,7 @@ func main() {
 		os.Exit(0)
 	} else if len(args) > 0 && (args[0] == "show" || args[0] == "show-actions") {
 		// Dispatch to gismo-show binary
-		subcommand := "gismo-show"
-
-		// Try to find the subcommand in the same directory as the main binary
-		execPath, err := os.Executable()
-		if err == nil {
-			dir := filepath.Dir(execPath)
-			localSubcommand := filepath.Join(dir, subcommand)
-			if _, err := os.Stat(localSubcommand); err == nil {
-				subcommand = localSubcommand
-			}
-		}
+		subcommand := subcommandPath("gismo-show")
 
 		// Build arguments for show command
 		var showArgs []string
@@ -193,18 +284,110 @@ func main() {
 			os.Exit(1)
 		}
 		os.Exit(0)
+	} else if len(args) > 0 && args[0] == "config" {
+		// show attributes settings to the files that set them, so it needs them unmerged
+		var layers []gismo.ConfigLayer
+		if configLoader != nil {
+			paths := configLoader.GetConfigPaths()
+			if *configFile != "" {
+				paths = []string{*configFile}
+			}
+			if layers, err = configLoader.LoadConfigLayers(paths); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to load configuration: %v\n", err)
+				os.Exit(1)
+			}
+		}
+		os.Exit(runConfigCommand(os.Stdout, args[1:], appConfig, ruleEngine.LinterNames(), ruleEngine.LinterSchemas(), layers, ruleEngine))
+	} else if len(args) > 0 && args[0] == "bootstrap" {
+		dir, err := os.Getwd()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(runBootstrap(os.Stdout, os.Stderr, args[1:], ruleEngine, dir))
+	} else if len(args) > 0 && args[0] == "tune" {
+		os.Exit(runTuneCommand(os.Stdout, args[1:], sessionStore))
+	} else if len(args) > 0 && args[0] == "allow" {
+		projectDir, err := os.Getwd()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if configLoader != nil {
+			if root, err := configLoader.FindProjectRoot(); err == nil {
+				projectDir = root
+			}
+		}
+		os.Exit(runAllowCommand(os.Stdout, args[1:], projectDir, sessionStore))
+	} else if len(args) > 0 && args[0] == "status-server" {
+		os.Exit(runStatusServer(os.Stdout, args[1:]))
+	} else if len(args) > 0 && args[0] == "check" {
+		os.Exit(runCheck(os.Stdout, os.Stdin, args[1:], ruleEngine))
+	} else if len(args) > 0 && args[0] == "lint" {
+		os.Exit(runLint(os.Stdout, args[1:], ruleEngine))
+	} else if len(args) > 0 && args[0] == "mcp" {
+		os.Exit(runMCP(os.Stdin, os.Stdout, os.Stderr, ruleEngine))
+	}
+
+	// Default behavior: process hook from stdin; serve shares the same engine
+	var plugins []gismo.RuleEngine
+	if configs := appConfig.GetPlugins(); len(configs) > 0 {
+		loaded, err := gismo.LoadPlugins(context.Background(), configs)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: plugins disabled: %v\n", err)
+		}
+		for _, plugin := range loaded {
+			plugins = append(plugins, plugin)
+		}
+	}
+	hookEngine, feedbackEngine := newHookEngine(ruleEngine, plugins, appConfig, sessionStore, lintingConfig.ProjectRoot)
+
+	if eventSink != nil {
+		hookEngine = gismo.NewEventRuleEngine(hookEngine, eventSink)
+	}
+
+	if len(args) > 0 && args[0] == "serve" {
+		os.Exit(runServe(os.Stdout, args[1:], hookEngine, *timeout, appConfig.GetOutputMode()))
+	} else if len(args) > 0 && args[0] == "daemon" {
+		dir, err := os.Getwd()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		var configPaths []string
+		if configLoader != nil {
+			configPaths = configLoader.GetConfigPaths()
+		}
+		os.Exit(runDaemon(os.Stdout, args[1:], hookEngine, *timeout, appConfig.GetOutputMode(), dir, configPaths))
+	}
+
+	if appConfig.IsUpdateCheckEnabled() {
+		warnIfOutdated(os.Stderr, appConfig.GetUpdateCheckAfter())
 	}
 
-	// Default behavior: process hook from stdin
 	// Create executor
-	executor := gismo.NewExecutor(ruleEngine)
+	executor := gismo.NewExecutor(hookEngine)
 	executor.SetTimeout(*timeout)
+	if *debug {
+		executor.SetDebugOutput(os.Stderr)
+	}
+	if appConfig.GetOutputMode() == gismo.OutputModeJSON {
+		// The feedback goes into the JSON output, as Claude ignores stderr then
+		var feedback bytes.Buffer
+		feedbackEngine.SetFeedbackWriter(&feedback)
+		executor.SetJSONOutput(&feedback)
+	}
 
 	// Create context
 	ctx := context.Background()
 
-	// Execute
-	exitCode, err := executor.ExecuteWithExitCode(ctx)
+	// Execute; input already read for an unavailable daemon is processed here
+	var exitCode int
+	if hookInput != nil {
+		exitCode, err = executor.ExecuteDataWithExitCode(ctx, hookInput, os.Stdout)
+	} else {
+		exitCode, err = executor.ExecuteWithExitCode(ctx)
+	}
 
 	// Always flush both stdout and stderr before exiting
 	os.Stdout.Sync()
@@ -231,3 +414,45 @@ func main() {
 	// Exit with the proper code
 	os.Exit(exitCode)
 }
+
+// newHookEngine builds the engine hooks run on: ruleEngine, then the WASM
+// plugins, with decisions reused when Claude retries an identical tool input
+// under the same config and rule overrides of the project at root. It also
+// returns the engine whose feedback writer to set, which reaches ruleEngine
+// through the wrappers.
+func newHookEngine(ruleEngine *gismo.LintingRuleEngine, plugins []gismo.RuleEngine, appConfig *gismo.AppConfig, store *gismo.SessionStore, root string) (gismo.RuleEngine, gismo.FeedbackAware) {
+	var hookEngine gismo.RuleEngine = ruleEngine
+	var feedbackEngine gismo.FeedbackAware = ruleEngine
+	if len(plugins) > 0 {
+		composite := gismo.NewCompositeRuleEngine(ruleEngine)
+		for _, plugin := range plugins {
+			composite.AddEngine(plugin)
+		}
+		hookEngine, feedbackEngine = composite, composite
+	}
+	if appConfig.IsDecisionCacheEnabled() {
+		var cacheConfig *gismo.DecisionCacheConfig
+		if appConfig != nil {
+			cacheConfig = appConfig.DecisionCache
+		}
+		caching := gismo.NewCachingRuleEngineWithConfig(hookEngine, store, cacheConfig)
+		caching.SetAppConfig(appConfig)
+		if root != "" {
+			caching.SetProjectRoot(root)
+		}
+		hookEngine, feedbackEngine = caching, caching
+	}
+	return hookEngine, feedbackEngine
+}
+
+// subcommandPath returns the path of a subcommand binary, preferring the one
+// in the same directory as the main binary
+func subcommandPath(name string) string {
+	if execPath, err := os.Executable(); err == nil {
+		localSubcommand := filepath.Join(filepath.Dir(execPath), name)
+		if _, err := os.Stat(localSubcommand); err == nil {
+			return localSubcommand
+		}
+	}
+	return name
+}
@@ -202,7 +202,7 @@ func TestDefaultBehavior_ValidInput(t *testing.T) {
 	// Run gismo with input on stdin
 	cmd := exec.Command(gismoPath)
 	cmd.Stdin = bytes.NewReader(inputJSON)
-	cmd.Env = append(os.Environ(), "CCFEEDBACK_NO_DAEMON=1")
+	cmd.Env = append(os.Environ(), noDaemonEnv+"=1")
 	output, err := cmd.CombinedOutput()
 
 	// PostToolUse always exits with code 2 (blocking) to ensure output is visible
@@ -281,8 +281,8 @@ func Test() {}
 	}
 
 	// Set env var to disable daemon mode for this test
-	os.Setenv("CCFEEDBACK_NO_DAEMON", "1")
-	defer os.Unsetenv("CCFEEDBACK_NO_DAEMON")
+	os.Setenv(noDaemonEnv, "1")
+	defer os.Unsetenv(noDaemonEnv)
 
 	// First build the show binary if needed
 	showBinaryPath := filepath.Join(filepath.Dir(gismoPath), "gismo-show")
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/jrossi/gismo"
+)
+
+// runMCP handles `gismo mcp`: it serves the Model Context Protocol on stdin and
+// stdout until the client closes stdin
+func runMCP(r io.Reader, w, errOut io.Writer, ruleEngine *gismo.LintingRuleEngine) int {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := gismo.NewMCPServer(ruleEngine, version).Serve(ctx, r, w); err != nil && ctx.Err() == nil {
+		fmt.Fprintf(errOut, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunRulesCommand(t *testing.T) {
+	projectDir := t.TempDir()
+	packPath := filepath.Join(t.TempDir(), "gismo-pack.json")
+	if err := os.WriteFile(packPath, []byte(`{"name": "team-go", "version": "1.0.0", "description": "Team Go policy"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/team-go.json" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{"name": "team-go", "version": "2.0.0"}`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantCode int
+		want     string
+	}{
+		{name: "empty list", args: []string{"list"}, want: "No rule packs installed"},
+		{name: "install path", args: []string{"install", packPath}, want: "Installed rule pack team-go 1.0.0"},
+		{name: "list", args: []string{"list"}, want: "team-go 1.0.0 - Team Go policy (from " + packPath + ")"},
+		{name: "update from URL", args: []string{"install", server.URL + "/team-go.json"}, want: "Updated rule pack team-go from 1.0.0 to 2.0.0"},
+		{name: "URL not found", args: []string{"install", server.URL + "/missing.json"}, wantCode: 1, want: "404"},
+		{name: "no source", args: []string{"install"}, wantCode: 1, want: "Usage: gismo rules install"},
+		{name: "unknown", args: []string{"remove"}, wantCode: 1, want: "Unknown rules command"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			if code := runRulesCommand(&out, tt.args, projectDir); code != tt.wantCode {
+				t.Errorf("exit code = %d, want %d", code, tt.wantCode)
+			}
+			if !strings.Contains(out.String(), tt.want) {
+				t.Errorf("output missing %q:\n%s", tt.want, out.String())
+			}
+		})
+	}
+
+	config, err := os.ReadFile(filepath.Join(projectDir, ".claude", "gismo.json"))
+	if err != nil || !strings.Contains(string(config), `"team-go"`) {
+		t.Errorf("project config = %s, %v", config, err)
+	}
+}
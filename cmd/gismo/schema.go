@@ -0,0 +1,35 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/jrossi/gismo"
+)
+
+// printHookSchemas handles `gismo -print-schema [event]`: the schema of one hook
+// event's message, or an object mapping every event to its schema
+func printHookSchemas(w io.Writer, events []string) int {
+	if len(events) == 1 {
+		schema, err := gismo.HookMessageSchema(gismo.HookEventName(events[0]))
+		if err != nil {
+			fmt.Fprintf(w, "Error: %v\n", err)
+			return 1
+		}
+		fmt.Fprintf(w, "%s\n", schema)
+		return 0
+	}
+	if len(events) > 1 {
+		fmt.Fprintf(w, "Usage: gismo -print-schema [event]\n")
+		return 1
+	}
+
+	out, err := json.MarshalIndent(gismo.HookMessageSchemas(), "", "  ")
+	if err != nil {
+		fmt.Fprintf(w, "Error: %v\n", err)
+		return 1
+	}
+	fmt.Fprintf(w, "%s\n", out)
+	return 0
+}
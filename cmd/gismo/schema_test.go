@@ -0,0 +1,43 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestPrintHookSchemas(t *testing.T) {
+	var out bytes.Buffer
+	if code := printHookSchemas(&out, nil); code != 0 {
+		t.Fatalf("exit code = %d\n%s", code, out.String())
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(out.Bytes(), &all); err != nil {
+		t.Fatalf("output is not JSON: %v", err)
+	}
+	for _, event := range []string{"PreToolUse", "PostToolUse", "Notification", "Stop", "SubagentStop", "PreCompact"} {
+		if _, ok := all[event]; !ok {
+			t.Errorf("missing schema for %s", event)
+		}
+	}
+
+	out.Reset()
+	if code := printHookSchemas(&out, []string{"PreToolUse"}); code != 0 {
+		t.Fatalf("exit code = %d\n%s", code, out.String())
+	}
+	var schema struct {
+		Required []string `json:"required"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &schema); err != nil {
+		t.Fatalf("output is not JSON: %v", err)
+	}
+	if strings.Join(schema.Required, ",") != "hook_event_name,tool_name" {
+		t.Errorf("required = %v, want hook_event_name and tool_name", schema.Required)
+	}
+
+	out.Reset()
+	if code := printHookSchemas(&out, []string{"Bogus"}); code != 1 || !strings.Contains(out.String(), "unknown hook event type") {
+		t.Errorf("unknown event: exit code = %d, output %q", code, out.String())
+	}
+}
@@ -0,0 +1,474 @@
+package main
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jrossi/gismo/internal/statedir"
+)
+
+// Exit codes of gismo self-update -check
+const (
+	updateExitCurrent   = 0
+	updateExitAvailable = 2
+)
+
+// releaseCheckInterval is how long hooks reuse the latest release they looked up
+const releaseCheckInterval = 24 * time.Hour
+
+// releaseCheckTimeout bounds the release lookup of a hook, which must stay fast
+const releaseCheckTimeout = 2 * time.Second
+
+// maxReleaseDownload bounds a release archive download
+const maxReleaseDownload = 256 << 20
+
+// latestReleaseURL is the GitHub API endpoint of the latest release. Tests replace it.
+var latestReleaseURL = "https://api.github.com/repos/jrossi/gismo/releases/latest"
+
+// executablePath returns the path of the running binary. Tests replace it.
+var executablePath = os.Executable
+
+// releaseBinaries are the binaries a release archive holds
+var releaseBinaries = []string{"gismo", "gismo-init", "gismo-show"}
+
+// githubRelease is the part of a GitHub release gismo uses
+type githubRelease struct {
+	TagName     string        `json:"tag_name"`
+	PublishedAt time.Time     `json:"published_at"`
+	Assets      []githubAsset `json:"assets"`
+}
+
+// githubAsset is a file attached to a release
+type githubAsset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+}
+
+// version returns the release's version without the leading v
+func (r *githubRelease) version() string {
+	return strings.TrimPrefix(r.TagName, "v")
+}
+
+// asset returns the download URL of the named asset
+func (r *githubRelease) asset(name string) (string, bool) {
+	for _, asset := range r.Assets {
+		if asset.Name == name {
+			return asset.URL, true
+		}
+	}
+	return "", false
+}
+
+// runSelfUpdate handles `gismo self-update`: it replaces the gismo binaries with
+// the latest release after checking the archive against the release checksums.
+// The checksums come from the same release and releases aren't signed yet, so
+// this catches corrupt downloads, not a tampered release; signature checks
+// need signed releases first. Binaries installed by Homebrew or Scoop are left
+// to the package manager. -check only reports whether an update is available,
+// exiting with 2 if it is.
+func runSelfUpdate(w io.Writer, args []string) int {
+	fs := flag.NewFlagSet("self-update", flag.ContinueOnError)
+	fs.SetOutput(w)
+	check := fs.Bool("check", false, "Only check for a newer release; exit with 2 if there is one")
+	fs.Usage = func() {
+		fmt.Fprintf(w, "Usage: gismo self-update [-check]\n\n")
+		fmt.Fprintf(w, "Replaces gismo with the latest GitHub release. The archive is checked against the\n")
+		fmt.Fprintf(w, "release's checksums.txt, which detects corrupt downloads but not a tampered release:\n")
+		fmt.Fprintf(w, "releases aren't signed yet. Build from a tagged commit if you need that assurance.\n\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 1
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	release, err := fetchLatestRelease(ctx)
+	if err != nil {
+		fmt.Fprintf(w, "Error: %v\n", err)
+		return 1
+	}
+	latest := release.version()
+
+	if version == "dev" {
+		fmt.Fprintf(w, "gismo is a development build; the latest release is %s\n", latest)
+		return updateExitCurrent
+	}
+	if compareVersions(version, latest) >= 0 {
+		fmt.Fprintf(w, "gismo %s is up to date\n", version)
+		return updateExitCurrent
+	}
+	fmt.Fprintf(w, "gismo %s is available (installed: %s)\n", latest, version)
+
+	exe, err := executablePath()
+	if err == nil {
+		exe, err = filepath.EvalSymlinks(exe)
+	}
+	if err != nil {
+		fmt.Fprintf(w, "Error: failed to locate the gismo binary: %v\n", err)
+		return 1
+	}
+	if manager, command := packageManager(exe); manager != "" {
+		fmt.Fprintf(w, "gismo was installed with %s; update it with: %s\n", manager, command)
+		if *check {
+			return updateExitAvailable
+		}
+		return 0
+	}
+	if *check {
+		fmt.Fprintf(w, "Run gismo self-update to update\n")
+		return updateExitAvailable
+	}
+
+	if err := installRelease(ctx, w, release, filepath.Dir(exe)); err != nil {
+		fmt.Fprintf(w, "Error: %v\n", err)
+		return 1
+	}
+	fmt.Fprintf(w, "✓ Updated gismo to %s\n", latest)
+	return 0
+}
+
+// installRelease downloads the release archive for this platform, checks it
+// against the release checksums and replaces the binaries in dir that the archive holds
+func installRelease(ctx context.Context, w io.Writer, release *githubRelease, dir string) error {
+	name := releaseArchiveName(release.version(), runtime.GOOS, runtime.GOARCH)
+	archiveURL, ok := release.asset(name)
+	if !ok {
+		return fmt.Errorf("release %s has no archive for %s/%s", release.TagName, runtime.GOOS, runtime.GOARCH)
+	}
+	checksumsURL, ok := release.asset("checksums.txt")
+	if !ok {
+		return fmt.Errorf("release %s has no checksums.txt, so %s can't be verified", release.TagName, name)
+	}
+
+	fmt.Fprintf(w, "Downloading %s\n", name)
+	archive, err := download(ctx, archiveURL)
+	if err != nil {
+		return err
+	}
+	checksums, err := download(ctx, checksumsURL)
+	if err != nil {
+		return err
+	}
+	if err := verifyReleaseChecksum(checksums, name, archive); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "Checksum of %s matches checksums.txt (the release itself is not signed)\n", name)
+
+	binaries, err := extractBinaries(archive, strings.HasSuffix(name, ".zip"))
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	if _, ok := binaries[binaryName("gismo")]; !ok {
+		return fmt.Errorf("%s has no gismo binary", name)
+	}
+	for file, data := range binaries {
+		target := filepath.Join(dir, file)
+		// gismo-init and gismo-show are only updated where they are installed
+		if file != binaryName("gismo") {
+			if _, err := os.Stat(target); err != nil {
+				continue
+			}
+		}
+		if err := replaceBinary(target, data); err != nil {
+			return fmt.Errorf("failed to replace %s: %w", target, err)
+		}
+	}
+	return nil
+}
+
+// fetchLatestRelease looks up the latest release with the GitHub API
+func fetchLatestRelease(ctx context.Context) (*githubRelease, error) {
+	data, err := download(ctx, latestReleaseURL)
+	if err != nil {
+		return nil, err
+	}
+	var release githubRelease
+	if err := json.Unmarshal(data, &release); err != nil {
+		return nil, fmt.Errorf("invalid release information: %w", err)
+	}
+	if release.TagName == "" {
+		return nil, errors.New("invalid release information: no tag")
+	}
+	return &release, nil
+}
+
+// download fetches url
+func download(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "gismo/"+version)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxReleaseDownload+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxReleaseDownload {
+		return nil, fmt.Errorf("GET %s: response too large", url)
+	}
+	return data, nil
+}
+
+// releaseArchiveName returns the name of the release archive for a platform,
+// following the archive name template in .goreleaser.yml
+func releaseArchiveName(version, goos, goarch string) string {
+	arch := goarch
+	if goarch == "amd64" {
+		arch = "x86_64"
+	}
+	ext := ".tar.gz"
+	if goos == "windows" {
+		ext = ".zip"
+	}
+	return fmt.Sprintf("gismo_%s_%s_%s%s", version, strings.ToUpper(goos[:1])+goos[1:], arch, ext)
+}
+
+// verifyReleaseChecksum checks data against its entry in a sha256sum-format
+// checksums file
+func verifyReleaseChecksum(checksums []byte, name string, data []byte) error {
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 || strings.TrimPrefix(fields[1], "*") != name {
+			continue
+		}
+		sum := sha256.Sum256(data)
+		if !strings.EqualFold(fields[0], hex.EncodeToString(sum[:])) {
+			return fmt.Errorf("checksum mismatch for %s", name)
+		}
+		return nil
+	}
+	return fmt.Errorf("checksums.txt has no entry for %s", name)
+}
+
+// extractBinaries returns the release binaries in a tar.gz or zip archive,
+// keyed by file name
+func extractBinaries(archive []byte, isZip bool) (map[string][]byte, error) {
+	wanted := make(map[string]bool)
+	for _, name := range releaseBinaries {
+		wanted[binaryName(name)] = true
+	}
+	binaries := make(map[string][]byte)
+
+	if isZip {
+		reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range reader.File {
+			name := path.Base(file.Name)
+			if !wanted[name] || file.FileInfo().IsDir() {
+				continue
+			}
+			rc, err := file.Open()
+			if err != nil {
+				return nil, err
+			}
+			data, err := io.ReadAll(io.LimitReader(rc, maxReleaseDownload))
+			rc.Close()
+			if err != nil {
+				return nil, err
+			}
+			binaries[name] = data
+		}
+		return binaries, nil
+	}
+
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+	reader := tar.NewReader(gz)
+	for {
+		header, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return binaries, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		name := path.Base(header.Name)
+		if !wanted[name] || header.Typeflag != tar.TypeReg {
+			continue
+		}
+		data, err := io.ReadAll(io.LimitReader(reader, maxReleaseDownload))
+		if err != nil {
+			return nil, err
+		}
+		binaries[name] = data
+	}
+}
+
+// binaryName returns the file name of a binary on this platform
+func binaryName(name string) string {
+	if runtime.GOOS == "windows" {
+		return name + ".exe"
+	}
+	return name
+}
+
+// replaceBinary replaces the binary at target with data. The new binary is
+// written next to it and renamed into place, so a failed update leaves the old
+// one working. Windows can't replace a running binary, so it is moved aside first.
+func replaceBinary(target string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(target), "."+filepath.Base(target)+".new-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0755); err != nil { // #nosec G302 - executable
+		return err
+	}
+	if runtime.GOOS == "windows" {
+		old := target + ".old"
+		_ = os.Remove(old)
+		if err := os.Rename(target, old); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return os.Rename(tmp.Name(), target)
+}
+
+// packageManager returns the package manager that installed the binary at exe
+// and the command that updates it, or empty strings for a manual install
+func packageManager(exe string) (string, string) {
+	slashed := filepath.ToSlash(exe)
+	switch {
+	case strings.Contains(slashed, "/Cellar/") || strings.Contains(slashed, "/homebrew/") || strings.Contains(slashed, "/linuxbrew/"):
+		return "Homebrew", "brew upgrade gismo"
+	case strings.Contains(strings.ToLower(slashed), "/scoop/apps/"):
+		return "Scoop", "scoop update gismo"
+	}
+	return "", ""
+}
+
+// compareVersions compares two semantic versions, with or without a leading v,
+// returning -1, 0 or 1. A pre-release sorts before its release.
+func compareVersions(a, b string) int {
+	coreA, preA, _ := strings.Cut(strings.TrimPrefix(a, "v"), "-")
+	coreB, preB, _ := strings.Cut(strings.TrimPrefix(b, "v"), "-")
+	partsA, partsB := strings.Split(coreA, "."), strings.Split(coreB, ".")
+	for i := range max(len(partsA), len(partsB)) {
+		var x, y int
+		if i < len(partsA) {
+			x, _ = strconv.Atoi(partsA[i])
+		}
+		if i < len(partsB) {
+			y, _ = strconv.Atoi(partsB[i])
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case preA == preB:
+		return 0
+	case preA == "":
+		return 1
+	case preB == "":
+		return -1
+	}
+	return strings.Compare(preA, preB)
+}
+
+// releaseCheckPath is the file caching the latest release for hooks
+func releaseCheckPath() string {
+	return statedir.CacheDir("latest-release.json")
+}
+
+// cachedLatestRelease returns the latest release, looking it up at most once
+// per releaseCheckInterval. A failed lookup is cached too, so an offline
+// machine doesn't slow down every hook.
+func cachedLatestRelease(cachePath string) (*githubRelease, error) {
+	private := statedir.Check(filepath.Dir(cachePath)) == nil
+	if info, err := os.Stat(cachePath); private && err == nil && time.Since(info.ModTime()) < releaseCheckInterval {
+		var release githubRelease
+		data, err := os.ReadFile(cachePath)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &release); err != nil || release.TagName == "" {
+			return nil, errors.New("no release information")
+		}
+		return &release, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), releaseCheckTimeout)
+	defer cancel()
+	release, lookupErr := fetchLatestRelease(ctx)
+	data := []byte("{}")
+	if lookupErr == nil {
+		data, _ = json.Marshal(githubRelease{TagName: release.TagName, PublishedAt: release.PublishedAt})
+	}
+	if err := statedir.Ensure(filepath.Dir(cachePath)); err == nil {
+		_ = os.WriteFile(cachePath, data, 0600)
+	}
+	return release, lookupErr
+}
+
+// warnIfOutdated writes a warning to w when a release newer than this binary
+// has been out for at least after. Lookup failures are silent, since the
+// warning must never get in the way of a hook.
+func warnIfOutdated(w io.Writer, after time.Duration) {
+	if version == "dev" {
+		return
+	}
+	release, err := cachedLatestRelease(releaseCheckPath())
+	if err != nil || compareVersions(version, release.version()) >= 0 {
+		return
+	}
+	age := time.Since(release.PublishedAt)
+	if age < after {
+		return
+	}
+	command := "gismo self-update"
+	if exe, err := executablePath(); err == nil {
+		if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+			exe = resolved
+		}
+		if _, managed := packageManager(exe); managed != "" {
+			command = managed
+		}
+	}
+	fmt.Fprintf(w, "Warning: gismo %s is out of date; %s was released %d days ago. Update with: %s\n",
+		version, release.version(), int(age.Hours()/24), command)
+}
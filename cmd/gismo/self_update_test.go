@@ -0,0 +1,175 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"0.3.0", "v0.3.0", 0},
+		{"0.3.0", "0.10.0", -1},
+		{"1.0.0", "0.9.9", 1},
+		{"1.0.0-rc1", "1.0.0", -1},
+		{"1.0", "1.0.1", -1},
+	}
+	for _, tt := range tests {
+		if got := compareVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("compareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestPackageManager(t *testing.T) {
+	tests := map[string]string{
+		"/opt/homebrew/Cellar/gismo/0.3.0/bin/gismo": "Homebrew",
+		"/home/linuxbrew/.linuxbrew/bin/gismo":       "Homebrew",
+		`C:/Users/me/scoop/apps/gismo/current/gismo`: "Scoop",
+		"/usr/local/bin/gismo":                       "",
+	}
+	for exe, want := range tests {
+		if got, _ := packageManager(exe); got != want {
+			t.Errorf("packageManager(%q) = %q, want %q", exe, got, want)
+		}
+	}
+}
+
+// releaseServer serves a release of version 0.9.0 whose archive holds a gismo
+// binary with the given content
+func releaseServer(t *testing.T, content string, published time.Time) *httptest.Server {
+	t.Helper()
+	var archive bytes.Buffer
+	gz := gzip.NewWriter(&archive)
+	tw := tar.NewWriter(gz)
+	for _, file := range []string{binaryName("gismo"), "README.md"} {
+		if err := tw.WriteHeader(&tar.Header{Name: file, Mode: 0755, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	name := releaseArchiveName("0.9.0", runtime.GOOS, runtime.GOARCH)
+	if strings.HasSuffix(name, ".zip") {
+		t.Skip("release archive test uses tar.gz")
+	}
+	sum := sha256.Sum256(archive.Bytes())
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	mux.HandleFunc("/latest", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(githubRelease{
+			TagName:     "v0.9.0",
+			PublishedAt: published,
+			Assets: []githubAsset{
+				{Name: name, URL: server.URL + "/archive"},
+				{Name: "checksums.txt", URL: server.URL + "/checksums"},
+			},
+		})
+	})
+	mux.HandleFunc("/archive", func(w http.ResponseWriter, r *http.Request) { _, _ = w.Write(archive.Bytes()) })
+	mux.HandleFunc("/checksums", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "%s  %s\n", hex.EncodeToString(sum[:]), name)
+	})
+	return server
+}
+
+// useRelease points the self-update at server and a gismo binary in a temporary
+// directory, returning the binary's path
+func useRelease(t *testing.T, server *httptest.Server, installed string) string {
+	t.Helper()
+	exe := filepath.Join(t.TempDir(), binaryName("gismo"))
+	if err := os.WriteFile(exe, []byte("old"), 0700); err != nil { // #nosec G306 - test executable
+		t.Fatal(err)
+	}
+	restoreURL, restoreExe, restoreVersion := latestReleaseURL, executablePath, version
+	t.Cleanup(func() { latestReleaseURL, executablePath, version = restoreURL, restoreExe, restoreVersion })
+	latestReleaseURL = server.URL + "/latest"
+	executablePath = func() (string, error) { return exe, nil }
+	version = installed
+	return exe
+}
+
+func TestRunSelfUpdate(t *testing.T) {
+	exe := useRelease(t, releaseServer(t, "new", time.Now()), "0.3.0")
+
+	var out bytes.Buffer
+	if code := runSelfUpdate(&out, []string{"-check"}); code != updateExitAvailable {
+		t.Errorf("check exit code = %d, want %d\n%s", code, updateExitAvailable, out.String())
+	}
+	if data, _ := os.ReadFile(exe); string(data) != "old" {
+		t.Error("check replaced the binary")
+	}
+
+	out.Reset()
+	if code := runSelfUpdate(&out, nil); code != 0 {
+		t.Fatalf("exit code = %d\n%s", code, out.String())
+	}
+	if data, _ := os.ReadFile(exe); string(data) != "new" {
+		t.Errorf("binary = %q, want the release binary", data)
+	}
+	// Only the checksum is checked, and the output mustn't claim more
+	if !strings.Contains(out.String(), "not signed") {
+		t.Errorf("output doesn't say the release is unsigned:\n%s", out.String())
+	}
+
+	version = "0.9.0"
+	out.Reset()
+	if code := runSelfUpdate(&out, []string{"-check"}); code != updateExitCurrent || !strings.Contains(out.String(), "up to date") {
+		t.Errorf("current version: exit code %d\n%s", code, out.String())
+	}
+}
+
+func TestVerifyReleaseChecksum(t *testing.T) {
+	sum := sha256.Sum256([]byte("archive"))
+	checksums := []byte(hex.EncodeToString(sum[:]) + "  gismo_0.9.0_Linux_x86_64.tar.gz\n")
+	if err := verifyReleaseChecksum(checksums, "gismo_0.9.0_Linux_x86_64.tar.gz", []byte("archive")); err != nil {
+		t.Errorf("valid checksum: %v", err)
+	}
+	if err := verifyReleaseChecksum(checksums, "gismo_0.9.0_Linux_x86_64.tar.gz", []byte("tampered")); err == nil {
+		t.Error("tampered archive: want an error")
+	}
+	if err := verifyReleaseChecksum(checksums, "gismo_0.9.0_Darwin_arm64.tar.gz", []byte("archive")); err == nil {
+		t.Error("missing entry: want an error")
+	}
+}
+
+func TestWarnIfOutdated(t *testing.T) {
+	t.Setenv("TMPDIR", t.TempDir())
+	useRelease(t, releaseServer(t, "new", time.Now().Add(-60*24*time.Hour)), "0.3.0")
+
+	var out bytes.Buffer
+	warnIfOutdated(&out, 90*24*time.Hour)
+	if out.Len() != 0 {
+		t.Errorf("release newer than the threshold: %s", out.String())
+	}
+	// The second check reuses the cached release
+	latestReleaseURL = "http://127.0.0.1:0/unreachable"
+	warnIfOutdated(&out, 30*24*time.Hour)
+	if !strings.Contains(out.String(), "gismo 0.3.0 is out of date; 0.9.0 was released 60 days ago") {
+		t.Errorf("warning = %q", out.String())
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/jrossi/gismo"
+)
+
+// defaultServeListen is the localhost address the hook server listens on
+const defaultServeListen = "127.0.0.1:8377"
+
+// serveTokenEnv names the environment variable holding the hook server's auth token
+const serveTokenEnv = "GISMO_SERVE_TOKEN"
+
+// runServe handles `gismo serve`: it processes hook messages posted over HTTP
+// until interrupted, letting in-flight requests finish before exiting. Responses
+// carry the output of hooks in outputMode.
+func runServe(w io.Writer, args []string, ruleEngine gismo.RuleEngine, timeout time.Duration, outputMode string) int {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	fs.SetOutput(w)
+	listen := fs.String("listen", defaultServeListen, "Address for the HTTP hook endpoint")
+	token := fs.String("token", os.Getenv(serveTokenEnv), "Bearer token required on requests (default $"+serveTokenEnv+")")
+	if err := fs.Parse(args); err != nil {
+		return 1
+	}
+
+	// Hook messages carry file contents and can run tests, so every request is
+	// authenticated. Without a token, one is generated for this loopback server.
+	if *token == "" {
+		if err := checkLoopback(*listen); err != nil {
+			fmt.Fprintf(w, "Error: %v without -token or $%s\n", err, serveTokenEnv)
+			return 1
+		}
+		generated, err := generateServeToken()
+		if err != nil {
+			fmt.Fprintf(w, "Error: %v\n", err)
+			return 1
+		}
+		*token = generated
+		fmt.Fprintf(w, "Token: %s\n", *token)
+	}
+
+	hooks := gismo.NewHookServer(ruleEngine, *token)
+	hooks.SetTimeout(timeout)
+	hooks.SetOutputMode(outputMode)
+
+	server := &http.Server{Addr: *listen, Handler: hooks.Handler(), ReadHeaderTimeout: 5 * time.Second}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	shutdown := make(chan struct{})
+	go func() {
+		defer close(shutdown)
+		<-ctx.Done()
+		// Give in-flight hooks as long as a hook may take
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Fprintf(w, "gismo hook server on http://%s/hook\n", *listen)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		fmt.Fprintf(w, "Error: %v\n", err)
+		return 1
+	}
+	<-shutdown
+	return 0
+}
+
+// generateServeToken returns a random bearer token
+func generateServeToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
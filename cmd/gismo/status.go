@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/jrossi/gismo"
+	"github.com/jrossi/gismo/internal/statedir"
+)
+
+// defaultStatusListen is the localhost address the status server listens on
+const defaultStatusListen = "127.0.0.1:8378"
+
+// defaultEventSocket is the socket hooks stream events to for the status server.
+// It lives in the user's runtime directory so other users can't feed it events.
+func defaultEventSocket() string {
+	return statedir.RuntimeDir("events.sock")
+}
+
+// runStatusServer handles `gismo status-server`: it receives event streams from
+// hooks on a unix socket and serves current diagnostics over localhost HTTP
+func runStatusServer(w io.Writer, args []string) int {
+	fs := flag.NewFlagSet("status-server", flag.ContinueOnError)
+	fs.SetOutput(w)
+	listen := fs.String("listen", defaultStatusListen, "Localhost address for the HTTP status endpoint")
+	socket := fs.String("events", defaultEventSocket(), "Unix socket hooks stream events to")
+	if err := fs.Parse(args); err != nil {
+		return 1
+	}
+
+	if err := checkLoopback(*listen); err != nil {
+		fmt.Fprintf(w, "Error: %v\n", err)
+		return 1
+	}
+
+	if err := statedir.Ensure(filepath.Dir(*socket)); err != nil {
+		fmt.Fprintf(w, "Error: failed to create socket directory: %v\n", err)
+		return 1
+	}
+	// A socket left by a previous server that didn't shut down cleanly blocks Listen
+	_ = os.Remove(*socket)
+	events, err := net.Listen("unix", *socket)
+	if err != nil {
+		fmt.Fprintf(w, "Error: failed to listen for events: %v\n", err)
+		return 1
+	}
+	defer events.Close()
+	if err := os.Chmod(*socket, 0600); err != nil {
+		fmt.Fprintf(w, "Error: failed to restrict event socket: %v\n", err)
+		return 1
+	}
+
+	status := gismo.NewStatusServer(version)
+	go func() { _ = status.ServeEvents(events) }()
+
+	server := &http.Server{Addr: *listen, Handler: status.Handler(), ReadHeaderTimeout: 5 * time.Second}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Fprintf(w, "gismo status server on http://%s (events: %s)\n", *listen, *socket)
+	fmt.Fprintf(w, "Run hooks with: gismo -event-stream unix:%s\n", *socket)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		fmt.Fprintf(w, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// checkLoopback rejects listen addresses reachable from other machines, since
+// diagnostics include file paths and source messages
+func checkLoopback(address string) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("invalid listen address %q: %w", address, err)
+	}
+	if host == "localhost" {
+		return nil
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return nil
+	}
+	return fmt.Errorf("listen address %q must be on localhost", address)
+}
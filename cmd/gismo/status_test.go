@@ -0,0 +1,23 @@
+package main
+
+import "testing"
+
+func TestCheckLoopback(t *testing.T) {
+	tests := []struct {
+		address string
+		wantErr bool
+	}{
+		{"127.0.0.1:8378", false},
+		{"localhost:8378", false},
+		{"[::1]:8378", false},
+		{":8378", true},
+		{"0.0.0.0:8378", true},
+		{"192.168.1.10:8378", true},
+		{"8378", true},
+	}
+	for _, tt := range tests {
+		if err := checkLoopback(tt.address); (err != nil) != tt.wantErr {
+			t.Errorf("checkLoopback(%q) error = %v, wantErr %v", tt.address, err, tt.wantErr)
+		}
+	}
+}
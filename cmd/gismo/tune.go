@@ -0,0 +1,114 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/jrossi/gismo"
+)
+
+// maxTuneRules caps the rules listed in tune output
+const maxTuneRules = 10
+
+// runTuneCommand handles `gismo tune`, replaying recorded blocks against a proposed
+// policy change and reporting how many would have been avoided
+func runTuneCommand(w io.Writer, args []string, store *gismo.SessionStore) int {
+	fs := flag.NewFlagSet("tune", flag.ContinueOnError)
+	fs.SetOutput(w)
+	disable := fs.String("disable", "", "Comma-separated rules to disable")
+	severity := fs.String("severity", "", "Comma-separated rule=severity changes (e.g. MD013=warning)")
+	ignore := fs.String("ignore", "", "Comma-separated file patterns to ignore")
+	since := fs.Duration("since", 7*24*time.Hour, "Only replay blocks newer than this (0 for all)")
+	fs.Usage = func() {
+		fmt.Fprintf(w, "Usage: gismo tune [-disable rules] [-severity rule=level,...] [-ignore patterns] [-since duration]\n\n")
+		fmt.Fprintf(w, "Replays blocks recorded in recent sessions against a proposed policy change.\n")
+		fmt.Fprintf(w, "Without a proposal, lists the rules that block most often.\n\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 1
+	}
+
+	proposal := gismo.TuneProposal{
+		DisableRules:   splitList(*disable),
+		IgnorePatterns: splitList(*ignore),
+	}
+	for _, change := range splitList(*severity) {
+		rule, level, ok := strings.Cut(change, "=")
+		if !ok || rule == "" || level == "" {
+			fmt.Fprintf(w, "Invalid severity change %q, expected rule=severity\n", change)
+			return 1
+		}
+		if proposal.Severities == nil {
+			proposal.Severities = make(map[string]string)
+		}
+		proposal.Severities[rule] = level
+	}
+
+	states, err := store.LoadAll()
+	if err != nil {
+		fmt.Fprintf(w, "Error: %v\n", err)
+		return 1
+	}
+
+	var cutoff time.Time
+	if *since > 0 {
+		cutoff = time.Now().Add(-*since)
+	}
+
+	baseline := gismo.ReplayBlocks(states, cutoff, gismo.TuneProposal{})
+	if baseline.Blocks == 0 {
+		fmt.Fprintf(w, "No recorded blocks to replay. Blocks are recorded per session as gismo runs.\n")
+		return 0
+	}
+	fmt.Fprintf(w, "Replaying %d block(s) from %d session(s)\n\n", baseline.Blocks, baseline.Sessions)
+
+	if len(proposal.DisableRules) == 0 && len(proposal.Severities) == 0 && len(proposal.IgnorePatterns) == 0 {
+		fmt.Fprintf(w, "Most frequent blocking rules:\n")
+		for i, count := range baseline.Remaining {
+			if i == maxTuneRules {
+				break
+			}
+			alone := gismo.ReplayBlocks(states, cutoff, gismo.TuneProposal{DisableRules: []string{count.Rule}})
+			fmt.Fprintf(w, "  %-24s %4d block(s); disabling it alone avoids %d\n", ruleLabel(count.Rule), count.Blocks, alone.Avoided)
+		}
+		fmt.Fprintf(w, "\nTry a change with: gismo tune -disable <rule> or -severity <rule>=warning\n")
+		return 0
+	}
+
+	result := gismo.ReplayBlocks(states, cutoff, proposal)
+	fmt.Fprintf(w, "With the proposed change, %d of %d block(s) (%.0f%%) would have been avoided.\n",
+		result.Avoided, result.Blocks, 100*float64(result.Avoided)/float64(result.Blocks))
+	if len(result.Remaining) > 0 {
+		fmt.Fprintf(w, "\nRules that would still block:\n")
+		for i, count := range result.Remaining {
+			if i == maxTuneRules {
+				break
+			}
+			fmt.Fprintf(w, "  %-24s %4d block(s)\n", ruleLabel(count.Rule), count.Blocks)
+		}
+	}
+	return 0
+}
+
+// splitList splits a comma-separated flag value, dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// ruleLabel names issues reported without a rule
+func ruleLabel(rule string) string {
+	if rule == "" {
+		return "(unnamed)"
+	}
+	return rule
+}
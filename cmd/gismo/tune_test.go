@@ -0,0 +1,52 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jrossi/gismo"
+	"github.com/jrossi/gismo/linters"
+)
+
+func TestRunTuneCommand(t *testing.T) {
+	store := gismo.NewSessionStore(t.TempDir())
+
+	var out bytes.Buffer
+	if code := runTuneCommand(&out, nil, store); code != 0 || !strings.Contains(out.String(), "No recorded blocks") {
+		t.Fatalf("empty store: code %d, output:\n%s", code, out.String())
+	}
+
+	state, _ := store.Load("session")
+	state.Blocks = []gismo.BlockRecord{
+		{File: "a.md", At: time.Now(), Issues: []linters.Issue{{Severity: "error", Rule: "MD013"}}},
+		{File: "b.go", At: time.Now(), Issues: []linters.Issue{{Severity: "error", Rule: "errcheck"}}},
+	}
+	if err := store.Save("session", state); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantCode int
+		want     string
+	}{
+		{name: "ranking", want: "disabling it alone avoids 1"},
+		{name: "disable", args: []string{"-disable", "MD013"}, want: "1 of 2 block(s) (50%)"},
+		{name: "severity", args: []string{"-severity", "MD013=warning,errcheck=info"}, want: "2 of 2 block(s) (100%)"},
+		{name: "bad severity", args: []string{"-severity", "MD013"}, wantCode: 1, want: "expected rule=severity"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			if code := runTuneCommand(&out, tt.args, store); code != tt.wantCode {
+				t.Errorf("exit code = %d, want %d", code, tt.wantCode)
+			}
+			if !strings.Contains(out.String(), tt.want) {
+				t.Errorf("output missing %q:\n%s", tt.want, out.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os/exec"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/jrossi/gismo"
+)
+
+// printVersion writes the version and build information
+func printVersion(w io.Writer) {
+	fmt.Fprintf(w, "gismo version %s\n", version)
+	if commit != "none" {
+		fmt.Fprintf(w, "  commit: %s\n", commit)
+	}
+	if date != "unknown" {
+		fmt.Fprintf(w, "  built at: %s\n", date)
+	}
+	if builtBy != "" {
+		fmt.Fprintf(w, "  built by: %s\n", builtBy)
+	}
+}
+
+// versionInfo is the machine-readable version of a gismo binary, for support
+// requests and reproducible builds
+type versionInfo struct {
+	Version string `json:"version"`
+	Commit  string `json:"commit,omitempty"`
+	Date    string `json:"date,omitempty"`
+	BuiltBy string `json:"builtBy,omitempty"`
+
+	// Module is the main module as recorded by the Go toolchain; its version is
+	// "(devel)" for builds from a checkout
+	Module *moduleInfo `json:"module,omitempty"`
+	// VCS is the revision the binary was built from, when built in a checkout
+	VCS       *vcsInfo `json:"vcs,omitempty"`
+	GoVersion string   `json:"goVersion"`
+	Platform  string   `json:"platform"`
+	// BuildTags are the tags the binary was built with, such as noembed
+	BuildTags    []string     `json:"buildTags"`
+	Dependencies []moduleInfo `json:"dependencies"`
+	// Linters are the registered linters with their embedded checks and tools
+	Linters []linterInfo `json:"linters"`
+}
+
+// moduleInfo is a Go module built into the binary
+type moduleInfo struct {
+	Path    string `json:"path"`
+	Version string `json:"version"`
+	Sum     string `json:"sum,omitempty"`
+	// Replace is the module that replaced this one, as path@version
+	Replace string `json:"replace,omitempty"`
+}
+
+// vcsInfo is the version control state of a build
+type vcsInfo struct {
+	System   string `json:"system"`
+	Revision string `json:"revision"`
+	Time     string `json:"time,omitempty"`
+	Modified bool   `json:"modified"`
+}
+
+// linterInfo is a registered linter's entry in the version information
+type linterInfo struct {
+	Name     string   `json:"name"`
+	Embedded []string `json:"embedded"`
+	Tools    []string `json:"tools"`
+}
+
+// newVersionInfo collects the version information of this binary
+func newVersionInfo(ruleEngine *gismo.LintingRuleEngine) versionInfo {
+	info := versionInfo{
+		Version:      version,
+		BuiltBy:      builtBy,
+		GoVersion:    runtime.Version(),
+		Platform:     runtime.GOOS + "/" + runtime.GOARCH,
+		BuildTags:    []string{},
+		Dependencies: []moduleInfo{},
+		Linters:      []linterInfo{},
+	}
+	if commit != "none" {
+		info.Commit = commit
+	}
+	if date != "unknown" {
+		info.Date = date
+	}
+
+	if build, ok := debug.ReadBuildInfo(); ok {
+		info.GoVersion = build.GoVersion
+		info.Module = &moduleInfo{Path: build.Main.Path, Version: build.Main.Version, Sum: build.Main.Sum}
+		vcs := &vcsInfo{}
+		for _, setting := range build.Settings {
+			switch setting.Key {
+			case "-tags":
+				for _, tag := range strings.Split(setting.Value, ",") {
+					if tag = strings.TrimSpace(tag); tag != "" {
+						info.BuildTags = append(info.BuildTags, tag)
+					}
+				}
+			case "vcs":
+				vcs.System = setting.Value
+			case "vcs.revision":
+				vcs.Revision = setting.Value
+			case "vcs.time":
+				vcs.Time = setting.Value
+			case "vcs.modified":
+				vcs.Modified = setting.Value == "true"
+			}
+		}
+		if vcs.Revision != "" {
+			info.VCS = vcs
+			if info.Commit == "" {
+				info.Commit = vcs.Revision
+			}
+		}
+		for _, dep := range build.Deps {
+			module := moduleInfo{Path: dep.Path, Version: dep.Version, Sum: dep.Sum}
+			if dep.Replace != nil {
+				module.Replace = dep.Replace.Path + "@" + dep.Replace.Version
+			}
+			info.Dependencies = append(info.Dependencies, module)
+		}
+	}
+
+	matrix := ruleEngine.LinterCapabilities()
+	for _, name := range ruleEngine.LinterNames() {
+		linter := linterInfo{Name: name, Embedded: matrix[name].Embedded, Tools: matrix[name].Tools}
+		if linter.Embedded == nil {
+			linter.Embedded = []string{}
+		}
+		if linter.Tools == nil {
+			linter.Tools = []string{}
+		}
+		info.Linters = append(info.Linters, linter)
+	}
+	sort.Slice(info.Linters, func(i, j int) bool { return info.Linters[i].Name < info.Linters[j].Name })
+	return info
+}
+
+// runVersion handles `gismo version`, optionally followed by the capability
+// matrix: the checks each linter runs with no tools installed, and the tools it
+// uses when they are. -json prints the full build information as JSON instead.
+func runVersion(w io.Writer, args []string, ruleEngine *gismo.LintingRuleEngine) int {
+	fs := flag.NewFlagSet("version", flag.ContinueOnError)
+	fs.SetOutput(w)
+	capabilities := fs.Bool("capabilities", false, "List the built-in checks and external tools of each linter")
+	asJSON := fs.Bool("json", false, "Print the version, build information, dependencies and linters as JSON")
+	if err := fs.Parse(args); err != nil {
+		return 1
+	}
+
+	if *asJSON {
+		return writeJSON(w, newVersionInfo(ruleEngine))
+	}
+	printVersion(w)
+	if !*capabilities {
+		return 0
+	}
+
+	matrix := ruleEngine.LinterCapabilities()
+	names := make([]string, 0, len(matrix))
+	for name := range matrix {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintf(w, "\nEmbedded checks run with no external tools installed.\n\n")
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "LINTER\tEMBEDDED\tTOOLS")
+	for _, name := range names {
+		embedded := strings.Join(matrix[name].Embedded, ", ")
+		if embedded == "" {
+			embedded = "-"
+		}
+		tools := make([]string, len(matrix[name].Tools))
+		for i, tool := range matrix[name].Tools {
+			tools[i] = tool
+			if _, err := exec.LookPath(tool); err != nil {
+				tools[i] += " (not found)"
+			}
+		}
+		toolList := strings.Join(tools, ", ")
+		if toolList == "" {
+			toolList = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", name, embedded, toolList)
+	}
+	if err := tw.Flush(); err != nil {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/jrossi/gismo"
+)
+
+func TestRunVersion(t *testing.T) {
+	engine := gismo.NewLintingRuleEngine()
+
+	var out bytes.Buffer
+	if code := runVersion(&out, nil, engine); code != 0 {
+		t.Fatalf("exit code = %d", code)
+	}
+	if got := out.String(); !strings.HasPrefix(got, "gismo version ") || strings.Contains(got, "LINTER") {
+		t.Errorf("version output = %q", got)
+	}
+
+	out.Reset()
+	t.Setenv("PATH", t.TempDir())
+	if code := runVersion(&out, []string{"-capabilities"}, engine); code != 0 {
+		t.Fatalf("exit code = %d", code)
+	}
+	for _, want := range []string{
+		"LINTER",
+		"hadolint (not found)",
+		"syntax, structure, JSON Schema validation",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("capabilities output missing %q:\n%s", want, out.String())
+		}
+	}
+	for _, name := range engine.LinterNames() {
+		if !strings.Contains(out.String(), "\n"+name+" ") {
+			t.Errorf("capabilities output missing linter %q", name)
+		}
+	}
+}
+
+func TestRunVersion_JSON(t *testing.T) {
+	engine := gismo.NewLintingRuleEngine()
+
+	var out bytes.Buffer
+	if code := runVersion(&out, []string{"-json"}, engine); code != 0 {
+		t.Fatalf("exit code = %d", code)
+	}
+	var info versionInfo
+	if err := json.Unmarshal(out.Bytes(), &info); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out.String())
+	}
+	if info.Version != version || !strings.HasPrefix(info.GoVersion, "go") || info.Platform == "" {
+		t.Errorf("version info = %+v", info)
+	}
+	if len(info.Linters) != len(engine.LinterNames()) {
+		t.Errorf("linters = %d, want %d", len(info.Linters), len(engine.LinterNames()))
+	}
+	if info.BuildTags == nil || info.Dependencies == nil {
+		t.Error("build tags and dependencies must be lists, even when empty")
+	}
+}
@@ -0,0 +1,265 @@
+package gismo
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// codeownersLocations are the paths GitHub reads CODEOWNERS from, in order
+var codeownersLocations = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// Ownership policies for edits to files owned by another team
+const (
+	// OwnershipInform names the owning team in block messages
+	OwnershipInform = "inform"
+	// OwnershipWarn also approves cross-team edits with a warning naming the owners
+	OwnershipWarn = "warn"
+	// OwnershipAcknowledge blocks the first cross-team edit of each file in a session;
+	// retrying the edit acknowledges it
+	OwnershipAcknowledge = "acknowledge"
+)
+
+// OwnershipConfig controls CODEOWNERS-aware feedback
+type OwnershipConfig struct {
+	// Enabled turns on CODEOWNERS lookups, default false
+	Enabled *bool `json:"enabled,omitempty"`
+	// Owners are the teams or users the session works for, e.g. "@org/platform".
+	// Files owned by none of them are cross-team edits.
+	Owners []string `json:"owners,omitempty"`
+	// Policy is "inform" (default), "warn" or "acknowledge"
+	Policy *string `json:"policy,omitempty"`
+	// File overrides the CODEOWNERS location, relative to the repository root
+	File *string `json:"file,omitempty"`
+}
+
+// IsOwnershipEnabled checks if CODEOWNERS-aware feedback is enabled
+func (c *AppConfig) IsOwnershipEnabled() bool {
+	if c == nil || c.Ownership == nil || c.Ownership.Enabled == nil {
+		return false
+	}
+	return *c.Ownership.Enabled
+}
+
+// GetOwnershipPolicy returns the policy for cross-team edits
+func (c *AppConfig) GetOwnershipPolicy() string {
+	if c == nil || c.Ownership == nil || c.Ownership.Policy == nil {
+		return OwnershipInform
+	}
+	return *c.Ownership.Policy
+}
+
+// CodeOwners holds parsed CODEOWNERS rules
+type CodeOwners struct {
+	rules []codeownersRule
+}
+
+// codeownersRule is a single CODEOWNERS line
+type codeownersRule struct {
+	pattern string
+	re      *regexp.Regexp
+	owners  []string
+}
+
+// ParseCodeOwners parses CODEOWNERS content. Invalid patterns are skipped.
+func ParseCodeOwners(data []byte) *CodeOwners {
+	owners := &CodeOwners{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		re, err := codeownersPattern(fields[0])
+		if err != nil {
+			continue
+		}
+		owners.rules = append(owners.rules, codeownersRule{pattern: fields[0], re: re, owners: fields[1:]})
+	}
+	return owners
+}
+
+// LoadCodeOwners reads CODEOWNERS from root, using file when set or the
+// standard GitHub locations otherwise. It returns nil if none exists.
+func LoadCodeOwners(root, file string) (*CodeOwners, error) {
+	locations := codeownersLocations
+	if file != "" {
+		locations = []string{file}
+	}
+	for _, location := range locations {
+		data, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(location)))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", location, err)
+		}
+		return ParseCodeOwners(data), nil
+	}
+	return nil, nil
+}
+
+// Owners returns the owners of relPath, a slash-separated path relative to the
+// repository root. The last matching rule wins, as on GitHub.
+func (o *CodeOwners) Owners(relPath string) []string {
+	if o == nil {
+		return nil
+	}
+	for i := len(o.rules) - 1; i >= 0; i-- {
+		if o.rules[i].re.MatchString(relPath) {
+			return o.rules[i].owners
+		}
+	}
+	return nil
+}
+
+// codeownersPattern compiles a CODEOWNERS pattern. Patterns follow gitignore
+// rules: a leading or inner slash anchors the pattern to the root, a trailing
+// slash matches everything in a directory, and a pattern matching a directory
+// matches all files below it, except "dir/*" which only matches direct children.
+func codeownersPattern(pattern string) (*regexp.Regexp, error) {
+	anchored := strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+	dirOnly := strings.HasSuffix(pattern, "/")
+	trimmed := strings.Trim(pattern, "/")
+
+	var b strings.Builder
+	if anchored || strings.HasPrefix(trimmed, "**") {
+		b.WriteString("^")
+	} else {
+		b.WriteString("^(?:.*/)?")
+	}
+
+	for i := 0; i < len(trimmed); i++ {
+		switch {
+		case strings.HasPrefix(trimmed[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(trimmed[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case trimmed[i] == '*':
+			b.WriteString("[^/]*")
+		case trimmed[i] == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(trimmed[i])))
+		}
+	}
+
+	switch {
+	case dirOnly:
+		b.WriteString("/.*$")
+	case strings.HasSuffix(trimmed, "/*"):
+		b.WriteString("$")
+	default:
+		b.WriteString("(?:/.*)?$")
+	}
+	return regexp.Compile(b.String())
+}
+
+// codeOwners returns the CODEOWNERS of the repository, loaded once per engine
+func (e *LintingRuleEngine) codeOwners() *CodeOwners {
+	e.codeownersOnce.Do(func() {
+		file := ""
+		if e.config.Ownership.File != nil {
+			file = *e.config.Ownership.File
+		}
+		owners, err := LoadCodeOwners(e.projectRoot(), file)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+		e.codeowners = owners
+	})
+	return e.codeowners
+}
+
+// fileOwners returns the owners of filePath and whether editing it is a
+// cross-team edit, i.e. it has owners and none of them is a configured owner
+func (e *LintingRuleEngine) fileOwners(filePath string) (owners []string, crossTeam bool) {
+	if !e.config.IsOwnershipEnabled() {
+		return nil, false
+	}
+	rel, ok := e.projectRelPath(filePath)
+	if !ok {
+		return nil, false
+	}
+	owners = e.codeOwners().Owners(rel)
+	if len(owners) == 0 {
+		return nil, false
+	}
+	for _, owner := range owners {
+		for _, mine := range e.config.Ownership.Owners {
+			if strings.EqualFold(owner, mine) {
+				return owners, false
+			}
+		}
+	}
+	return owners, true
+}
+
+// crossTeamOwners returns the owners of filePath joined for messages, or "" when
+// editing it is not a cross-team edit
+func (e *LintingRuleEngine) crossTeamOwners(filePath string) string {
+	owners, crossTeam := e.fileOwners(filePath)
+	if !crossTeam {
+		return ""
+	}
+	return strings.Join(owners, ", ")
+}
+
+// checkCrossTeamEdit applies the ownership policy to an edit that passed linting.
+// It returns a blocking response when the edit must be acknowledged, or a warning
+// message to include in the approval.
+func (e *LintingRuleEngine) checkCrossTeamEdit(sessionID, filePath string) (*HookResponse, string) {
+	owners := e.crossTeamOwners(filePath)
+	if owners == "" {
+		return nil, ""
+	}
+	note := e.messages.Sprintf("ownership.owned_by", filePath, owners)
+
+	switch e.config.GetOwnershipPolicy() {
+	case OwnershipWarn:
+		return nil, e.messages.Sprintf("ownership.warn", note)
+	case OwnershipAcknowledge:
+		if e.acknowledgeCrossTeamEdit(sessionID, filePath) {
+			return nil, e.messages.Sprintf("ownership.acknowledged", note)
+		}
+		return &HookResponse{
+			Decision: "block",
+			Reason:   e.messages.Sprintf("ownership.block", note),
+			NoCache:  true,
+		}, ""
+	default:
+		return nil, ""
+	}
+}
+
+// acknowledgeCrossTeamEdit records a cross-team edit in session state and
+// reports whether it was already acknowledged. Without session state every
+// edit counts as acknowledged, since a retry couldn't be recognized.
+func (e *LintingRuleEngine) acknowledgeCrossTeamEdit(sessionID, filePath string) bool {
+	if e.sessions == nil || sessionID == "" {
+		return true
+	}
+	acknowledged := true
+	_ = e.sessions.Update(sessionID, func(state *SessionState) error {
+		if state.Acknowledged[filePath] {
+			return errNoSessionChange
+		}
+		if state.Acknowledged == nil {
+			state.Acknowledged = make(map[string]bool)
+		}
+		state.Acknowledged[filePath] = true
+		acknowledged = false
+		return nil
+	})
+	return acknowledged
+}
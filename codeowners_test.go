@@ -0,0 +1,185 @@
+package gismo
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+func TestCodeOwners_Owners(t *testing.T) {
+	owners := ParseCodeOwners([]byte(`# Default owners
+*                 @org/core
+*.md              @org/docs   # inline comment
+/build/           @org/infra
+docs/*            @org/writers
+apps/**/config    @org/config
+services/payments @org/payments @alice
+`))
+
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{"main.go", []string{"@org/core"}},
+		{"README.md", []string{"@org/docs"}},
+		{"nested/dir/NOTES.md", []string{"@org/docs"}},
+		{"build/ci/run.sh", []string{"@org/infra"}},
+		{"src/build/file.go", []string{"@org/core"}},
+		{"docs/intro.txt", []string{"@org/writers"}},
+		{"docs/guide/intro.txt", []string{"@org/core"}},
+		{"apps/web/config", []string{"@org/config"}},
+		{"apps/config/app.json", []string{"@org/config"}},
+		{"services/payments/api.go", []string{"@org/payments", "@alice"}},
+		{"services/paymentsx/api.go", []string{"@org/core"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := owners.Owners(tt.path); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Owners(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+
+	var none *CodeOwners
+	if got := none.Owners("main.go"); got != nil {
+		t.Errorf("nil CodeOwners should have no owners, got %v", got)
+	}
+}
+
+func TestLoadCodeOwners(t *testing.T) {
+	root := t.TempDir()
+	if owners, err := LoadCodeOwners(root, ""); err != nil || owners != nil {
+		t.Fatalf("LoadCodeOwners() without file = %v, %v; want nil, nil", owners, err)
+	}
+
+	if err := os.MkdirAll(filepath.Join(root, ".github"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, ".github", "CODEOWNERS"), []byte("* @org/github\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "OWNERS"), []byte("* @org/custom\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	owners, err := LoadCodeOwners(root, "")
+	if err != nil {
+		t.Fatalf("LoadCodeOwners() error = %v", err)
+	}
+	if got := owners.Owners("a.go"); !reflect.DeepEqual(got, []string{"@org/github"}) {
+		t.Errorf("expected .github/CODEOWNERS owners, got %v", got)
+	}
+
+	owners, err = LoadCodeOwners(root, "OWNERS")
+	if err != nil {
+		t.Fatalf("LoadCodeOwners() error = %v", err)
+	}
+	if got := owners.Owners("a.go"); !reflect.DeepEqual(got, []string{"@org/custom"}) {
+		t.Errorf("expected custom file owners, got %v", got)
+	}
+}
+
+func TestLintingRuleEngine_Ownership(t *testing.T) {
+	tests := []struct {
+		name         string
+		policy       string
+		file         string
+		content      string
+		wantDecision []string
+		wantText     string
+	}{
+		{"inform names owners on block", OwnershipInform, "billing/pay.go", "BROKEN", []string{"block"}, "owned by @org/billing"},
+		{"inform is silent on clean edit", OwnershipInform, "billing/pay.go", "fine", []string{"approve"}, ""},
+		{"warn approves with warning", OwnershipWarn, "billing/pay.go", "fine", []string{"approve"}, "Cross-team edit"},
+		{"acknowledge blocks first edit", OwnershipAcknowledge, "billing/pay.go", "fine", []string{"block", "approve"}, "acknowledged"},
+		{"own files are not cross-team", OwnershipAcknowledge, "platform/api.go", "fine", []string{"approve"}, ""},
+		{"unowned files are not cross-team", OwnershipAcknowledge, "misc/notes.txt", "fine", []string{"approve"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			codeowners := "billing/ @org/billing\nplatform/ @org/platform\n"
+			if err := os.WriteFile(filepath.Join(root, "CODEOWNERS"), []byte(codeowners), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			engine := NewLintingRuleEngineWithConfig(LintingConfig{
+				FileSystem:   linters.NewMemFileSystem(),
+				SessionStore: NewSessionStore(t.TempDir()),
+				ProjectRoot:  root,
+			})
+			engine.linters = []linters.Linter{&syntaxLinter{MockLinter{name: "syntax", canHandle: true}}}
+
+			enabled, policy := true, tt.policy
+			config := NewAppConfig()
+			config.Ownership = &OwnershipConfig{Enabled: &enabled, Owners: []string{"@org/platform"}, Policy: &policy}
+			engine.SetAppConfig(config)
+
+			var last *HookResponse
+			for i, want := range tt.wantDecision {
+				msg := writeMessage("session", map[string]interface{}{
+					"file_path": filepath.Join(root, tt.file),
+					"content":   tt.content,
+				})
+				resp, err := engine.EvaluatePreToolUse(context.Background(), msg)
+				if err != nil {
+					t.Fatalf("EvaluatePreToolUse() error = %v", err)
+				}
+				if resp.Decision != want {
+					t.Errorf("attempt %d decision = %q, want %q (%s)", i+1, resp.Decision, want, resp.Reason)
+				}
+				last = resp
+			}
+
+			text := last.Reason + last.Message
+			if tt.wantText == "" && text != "" {
+				t.Errorf("expected no ownership feedback, got %q", text)
+			}
+			if !strings.Contains(text, tt.wantText) {
+				t.Errorf("expected %q in feedback, got %q", tt.wantText, text)
+			}
+		})
+	}
+}
+
+func TestCachingRuleEngine_SkipsAcknowledgmentBlock(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "CODEOWNERS"), []byte("* @org/billing\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	store := NewSessionStore(t.TempDir())
+	engine := NewLintingRuleEngineWithConfig(LintingConfig{
+		FileSystem:   linters.NewMemFileSystem(),
+		SessionStore: store,
+		ProjectRoot:  root,
+	})
+	engine.linters = []linters.Linter{&syntaxLinter{MockLinter{name: "syntax", canHandle: true}}}
+
+	enabled, policy := true, OwnershipAcknowledge
+	config := NewAppConfig()
+	config.Ownership = &OwnershipConfig{Enabled: &enabled, Policy: &policy}
+	engine.SetAppConfig(config)
+
+	cached := NewCachingRuleEngine(engine, store)
+	msg := writeMessage("session", map[string]interface{}{
+		"file_path": filepath.Join(root, "pay.go"),
+		"content":   "fine",
+	})
+
+	for i, want := range []string{"block", "approve"} {
+		resp, err := cached.EvaluatePreToolUse(context.Background(), msg)
+		if err != nil {
+			t.Fatalf("EvaluatePreToolUse() error = %v", err)
+		}
+		if resp.Decision != want {
+			t.Errorf("attempt %d decision = %q, want %q", i+1, resp.Decision, want)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package gismo
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// CommandPolicyConfig controls which Bash commands the agent may run. Commands
+// are split into the segments separated by &&, ||, ; and newlines, and a
+// segment matching a deny rule is blocked unless an allow pattern matches it too.
+type CommandPolicyConfig struct {
+	// Enabled checks Bash commands before they run, default false
+	Enabled *bool `json:"enabled,omitempty"`
+	// Builtins includes gismo's deny rules for destructive commands, default true
+	Builtins *bool `json:"builtins,omitempty"`
+	// Deny lists the rules of commands to block
+	Deny []CommandRule `json:"deny,omitempty"`
+	// Allow lists regular expressions of commands exempt from the deny rules
+	Allow []string `json:"allow,omitempty"`
+}
+
+// CommandRule blocks commands matching a regular expression
+type CommandRule struct {
+	Pattern string `json:"pattern"`
+	// Reason tells the agent why the command is blocked
+	Reason string `json:"reason,omitempty"`
+}
+
+// BuiltinCommandRules are denied when the command policy is enabled, unless
+// builtins is false
+var BuiltinCommandRules = []CommandRule{
+	{
+		Pattern: `\brm\s+(-\S*\s+)*(-[a-zA-Z]*[rR][a-zA-Z]*|--recursive)\s+(-\S*\s+)*(/|~|\$HOME)/?\*?(\s|$)`,
+		Reason:  "recursively deletes the root or home directory",
+	},
+	{
+		Pattern: `\bgit\b.*\spush\b.*\s(--force|-[a-zA-Z]*f[a-zA-Z]*)(\s|$)`,
+		Reason:  "force pushing rewrites remote history; use --force-with-lease if it is really needed",
+	},
+	{
+		Pattern: `\b(curl|wget)\b.*\|\s*(sudo\s+)?(ba|z|da|k)?sh\b`,
+		Reason:  "pipes a downloaded script straight into a shell; download and review it first",
+	},
+}
+
+// commandSeparators splits a command into the commands it runs in sequence.
+// Pipes are kept, so a rule can match a whole pipeline.
+var commandSeparators = regexp.MustCompile(`&&|\|\||;|\n`)
+
+// IsCommandPolicyEnabled checks if Bash commands are checked against the command policy
+func (c *AppConfig) IsCommandPolicyEnabled() bool {
+	if c == nil || c.CommandPolicy == nil || c.CommandPolicy.Enabled == nil {
+		return false
+	}
+	return *c.CommandPolicy.Enabled
+}
+
+// GetCommandPolicy returns the deny rules, built-in rules first, and the allow patterns
+func (c *AppConfig) GetCommandPolicy() ([]CommandRule, []string) {
+	if c == nil || c.CommandPolicy == nil {
+		return BuiltinCommandRules, nil
+	}
+	var deny []CommandRule
+	if c.CommandPolicy.Builtins == nil || *c.CommandPolicy.Builtins {
+		deny = append(deny, BuiltinCommandRules...)
+	}
+	return append(deny, c.CommandPolicy.Deny...), c.CommandPolicy.Allow
+}
+
+// merge merges other into the policy; rules and patterns are added to those
+// already configured
+func (p *CommandPolicyConfig) merge(other *CommandPolicyConfig) {
+	if other.Enabled != nil {
+		p.Enabled = other.Enabled
+	}
+	if other.Builtins != nil {
+		p.Builtins = other.Builtins
+	}
+	for _, rule := range other.Deny {
+		if !slices.ContainsFunc(p.Deny, func(existing CommandRule) bool { return existing.Pattern == rule.Pattern }) {
+			p.Deny = append(p.Deny, rule)
+		}
+	}
+	for _, pattern := range other.Allow {
+		if !slices.Contains(p.Allow, pattern) {
+			p.Allow = append(p.Allow, pattern)
+		}
+	}
+}
+
+// CommandViolation is a command segment a deny rule blocks
+type CommandViolation struct {
+	Rule    CommandRule
+	Segment string
+}
+
+// CheckCommand returns the first segment of command a deny rule blocks, or
+// nil if the command may run. An invalid pattern is an error, so a mistyped
+// rule doesn't silently allow what it was meant to block.
+func CheckCommand(command string, deny []CommandRule, allow []string) (*CommandViolation, error) {
+	for _, segment := range commandSeparators.Split(command, -1) {
+		segment = strings.TrimSpace(segment)
+		if segment == "" {
+			continue
+		}
+		for _, rule := range deny {
+			re, err := compilePattern(rule.Pattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid deny pattern %q: %w", rule.Pattern, err)
+			}
+			if !re.MatchString(segment) {
+				continue
+			}
+			allowed, err := matchesAny(allow, segment)
+			if err != nil {
+				return nil, err
+			}
+			if !allowed {
+				return &CommandViolation{Rule: rule, Segment: segment}, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// matchesAny reports whether any allow pattern matches the segment
+func matchesAny(patterns []string, segment string) (bool, error) {
+	for _, pattern := range patterns {
+		re, err := compilePattern(pattern)
+		if err != nil {
+			return false, fmt.Errorf("invalid allow pattern %q: %w", pattern, err)
+		}
+		if re.MatchString(segment) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// evaluateCommand checks a Bash tool invocation against the command policy
+func (e *LintingRuleEngine) evaluateCommand(msg *PreToolUseMessage) *HookResponse {
+	if !e.config.IsCommandPolicyEnabled() {
+		return &HookResponse{Decision: "approve"}
+	}
+	input, err := ParseToolInput(msg.ToolName, msg.ToolInput)
+	bash, ok := input.(BashToolInput)
+	if err != nil || !ok || bash.Command == "" {
+		return &HookResponse{Decision: "approve"}
+	}
+
+	deny, allow := e.config.GetCommandPolicy()
+	violation, err := CheckCommand(bash.Command, deny, allow)
+	var reason string
+	switch {
+	case err != nil:
+		reason = e.messages.Sprintf("reason.command_policy_invalid", err)
+	case violation != nil:
+		why := violation.Rule.Reason
+		if why == "" {
+			why = e.messages.Sprintf("reason.command_pattern", violation.Rule.Pattern)
+		}
+		reason = e.messages.Sprintf("reason.command_denied", violation.Segment, why)
+	default:
+		return &HookResponse{Decision: "approve"}
+	}
+	fmt.Fprintf(e.feedback, "\n> %s:\n  - [gismo]: %s\n", e.messages.Sprintf("feedback.operation", msg.ToolName), reason)
+	return &HookResponse{Decision: "block", Reason: reason}
+}
@@ -1,66 +1,157 @@
 package gismo
 
 import (
+	"bytes"
 	"encoding/json"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
 	"time"
+
+	"github.com/jrossi/gismo/linters"
+	"github.com/jrossi/gismo/linters/custom"
+	"github.com/jrossi/gismo/types"
 )
 
 // AppConfig represents the complete configuration for gismo
 type AppConfig struct {
 	// Global settings
 	Parallel *ParallelConfig `json:"parallel,omitempty"`
-	Timeout  *Duration       `json:"timeout,omitempty"`
+	Timeout  *types.Duration `json:"timeout,omitempty"`
+
+	// Strict treats warnings as blocking errors
+	Strict *bool `json:"strict,omitempty"`
+	// WarningsAsInfo reports warnings as info; strict takes precedence
+	WarningsAsInfo *bool `json:"warningsAsInfo,omitempty"`
+	// InlineConfig honors "gismo:config" directives at the top of files, default true
+	InlineConfig *bool `json:"inlineConfig,omitempty"`
+	// OutputMode is how hook results reach Claude: "exit-code" (default) or "json"
+	OutputMode *string `json:"outputMode,omitempty"`
+	// SeverityMap overrides how external tools' severity labels map to error,
+	// warning and info, keyed by tool name and label
+	SeverityMap map[string]linters.SeverityMap `json:"severityMap,omitempty"`
 
 	// Linter configurations keyed by linter name
 	Linters map[string]LinterConfig `json:"linters,omitempty"`
 
+	// External commands wrapped as linters, keyed by linter name
+	CustomLinters map[string]custom.Config `json:"customLinters,omitempty"`
+
 	// Rule overrides by file pattern
 	Rules []RuleOverride `json:"rules,omitempty"`
+
+	// Feedback output settings
+	Feedback *FeedbackConfig `json:"feedback,omitempty"`
+
+	// Caching of PreToolUse decisions for repeated identical tool inputs
+	DecisionCache *DecisionCacheConfig `json:"decisionCache,omitempty"`
+
+	// Lint result caching for unchanged file content
+	ResultCache *ResultCacheConfig `json:"resultCache,omitempty"`
+
+	// Escalation policy after repeated blocks on the same file and rule
+	Escalation *EscalationConfig `json:"escalation,omitempty"`
+
+	// Monorepo sub-project detection and per-project linter settings
+	Projects *ProjectsConfig `json:"projects,omitempty"`
+
+	// CODEOWNERS-aware feedback for edits to files owned by other teams
+	Ownership *OwnershipConfig `json:"ownership,omitempty"`
+
+	// Refactor guard for single edits too large to review
+	RefactorGuard *RefactorGuardConfig `json:"refactorGuard,omitempty"`
+
+	// Directory conventions for where new files may be created
+	FilePolicy *FilePolicyConfig `json:"filePolicy,omitempty"`
+
+	// "Code generated ... DO NOT EDIT." headers on new and edited generated files
+	GeneratedCode *GeneratedCodeConfig `json:"generatedCode,omitempty"`
+
+	// CPU, I/O and memory limits for spawned linter processes
+	Resources *ResourcesConfig `json:"resources,omitempty"`
+
+	// Stable locations for tool-internal caches
+	ToolCaches *ToolCachesConfig `json:"toolCaches,omitempty"`
+
+	// Rule packs installed in the gismo-packs directory next to the config file.
+	// A pack's settings apply before the settings of the file that lists it.
+	Packs []string `json:"packs,omitempty"`
+
+	// WASM rule engines run after the built-in linting, in order
+	Plugins []PluginConfig `json:"plugins,omitempty"`
+
+	// Checks run when the agent stops
+	StopHook *StopHookConfig `json:"stopHook,omitempty"`
+
+	// Deny and allow rules for the Bash commands the agent runs
+	CommandPolicy *CommandPolicyConfig `json:"commandPolicy,omitempty"`
+
+	// Warning in hook output when gismo is out of date
+	UpdateCheck *UpdateCheckConfig `json:"updateCheck,omitempty"`
+}
+
+// FeedbackConfig controls how lint feedback is presented
+type FeedbackConfig struct {
+	// MaxIssuesPerFile caps issues shown per file in multi-file summaries (0 = no cap)
+	MaxIssuesPerFile *int `json:"maxIssuesPerFile,omitempty"`
+	// FixPayload embeds known fixes in feedback: "none" (default), "content" or "patch"
+	FixPayload *string `json:"fixPayload,omitempty"`
+	// Language selects the message catalog, such as "ja" (default: from LANG, else "en")
+	Language *string `json:"language,omitempty"`
+	// ContextLines shows the source lines around each issue, this many either
+	// side of the issue line (default 0: no source is shown)
+	ContextLines *int `json:"contextLines,omitempty"`
+	// ForbidWhitespaceEdits reports edits that only change whitespace, default
+	// false. Edits that change nothing at all are always reported.
+	ForbidWhitespaceEdits *bool `json:"forbidWhitespaceEdits,omitempty"`
+	// DependencySummary lists the dependencies an edit to a package.json,
+	// go.mod, Cargo.toml, pyproject.toml or requirements file adds, changes and
+	// removes, default true
+	DependencySummary *bool `json:"dependencySummary,omitempty"`
 }
 
 // ParallelConfig controls parallel execution settings
 type ParallelConfig struct {
 	MaxWorkers      *int  `json:"maxWorkers,omitempty"`
 	DisableParallel *bool `json:"disableParallel,omitempty"`
+	// ToolLimits caps concurrent processes per external tool, keyed by binary name
+	ToolLimits map[string]int `json:"toolLimits,omitempty"`
 }
 
 // LinterConfig represents configuration for a specific linter
 type LinterConfig struct {
 	Enabled *bool           `json:"enabled,omitempty"`
 	Config  json.RawMessage `json:"config,omitempty"`
+	// Env sets environment variables for the linter's subprocesses
+	Env map[string]string `json:"env,omitempty"`
+	// Path lists directories prepended to PATH for the linter's subprocesses
+	Path []string `json:"path,omitempty"`
+	// Timeout bounds one run of the linter; a linter that runs out of time
+	// reports a timeout issue instead of failing the hook
+	Timeout *types.Duration `json:"timeout,omitempty"`
 }
 
-// RuleOverride applies linter-specific rules based on file patterns
+// RuleOverride applies linter-specific rules based on file patterns and content
 type RuleOverride struct {
-	Pattern string          `json:"pattern"` // glob pattern for files
-	Linter  string          `json:"linter"`  // which linter this applies to
-	Rules   json.RawMessage `json:"rules"`   // linter-specific rule configuration
-}
+	Pattern string          `json:"pattern"`         // glob pattern for files
+	Linter  string          `json:"linter"`          // which linter this applies to
+	Rules   json.RawMessage `json:"rules,omitempty"` // linter-specific rule configuration
 
-// Duration is a wrapper around time.Duration for JSON unmarshaling
-type Duration struct {
-	time.Duration
+	// FirstLine is a regular expression the file's first line must match
+	FirstLine string `json:"firstLine,omitempty"`
+	// Contains is text the file's content must include
+	Contains string `json:"contains,omitempty"`
+	// Route has the linter check matching files even if it doesn't handle
+	// their file type, such as an extensionless script with a bash shebang
+	Route bool `json:"route,omitempty"`
 }
 
-// UnmarshalJSON implements json.Unmarshaler for Duration
-func (d *Duration) UnmarshalJSON(b []byte) error {
-	var s string
-	if err := json.Unmarshal(b, &s); err != nil {
-		return err
-	}
-	duration, err := time.ParseDuration(s)
-	if err != nil {
-		return err
-	}
-	d.Duration = duration
-	return nil
-}
-
-// MarshalJSON implements json.Marshaler for Duration
-func (d Duration) MarshalJSON() ([]byte, error) {
-	return json.Marshal(d.Duration.String())
-}
+// Duration is kept for compatibility.
+//
+// Deprecated: use types.Duration.
+type Duration = types.Duration
 
 // MarkdownConfig represents markdown linter specific configuration
 type MarkdownConfig struct {
@@ -74,9 +165,9 @@ type MarkdownConfig struct {
 
 // GolangConfig represents golang linter specific configuration
 type GolangConfig struct {
-	GolangciConfig *string   `json:"golangciConfig,omitempty"` // path to golangci.yml
-	DisabledChecks []string  `json:"disabledChecks,omitempty"`
-	TestTimeout    *Duration `json:"testTimeout,omitempty"`
+	GolangciConfig *string         `json:"golangciConfig,omitempty"` // path to golangci.yml
+	DisabledChecks []string        `json:"disabledChecks,omitempty"`
+	TestTimeout    *types.Duration `json:"testTimeout,omitempty"`
 }
 
 // NewAppConfig creates a new AppConfig with default values
@@ -104,6 +195,12 @@ func (c *AppConfig) Merge(other *AppConfig) {
 		if other.Parallel.DisableParallel != nil {
 			c.Parallel.DisableParallel = other.Parallel.DisableParallel
 		}
+		for tool, limit := range other.Parallel.ToolLimits {
+			if c.Parallel.ToolLimits == nil {
+				c.Parallel.ToolLimits = make(map[string]int)
+			}
+			c.Parallel.ToolLimits[tool] = limit
+		}
 	}
 
 	// Merge timeout
@@ -111,6 +208,31 @@ func (c *AppConfig) Merge(other *AppConfig) {
 		c.Timeout = other.Timeout
 	}
 
+	// Merge severity settings
+	if other.Strict != nil {
+		c.Strict = other.Strict
+	}
+	if other.WarningsAsInfo != nil {
+		c.WarningsAsInfo = other.WarningsAsInfo
+	}
+	if other.InlineConfig != nil {
+		c.InlineConfig = other.InlineConfig
+	}
+	if other.OutputMode != nil {
+		c.OutputMode = other.OutputMode
+	}
+	for tool, labels := range other.SeverityMap {
+		if c.SeverityMap == nil {
+			c.SeverityMap = make(map[string]linters.SeverityMap)
+		}
+		if c.SeverityMap[tool] == nil {
+			c.SeverityMap[tool] = make(linters.SeverityMap)
+		}
+		for label, severity := range labels {
+			c.SeverityMap[tool][label] = severity
+		}
+	}
+
 	// Merge linters
 	if c.Linters == nil {
 		c.Linters = make(map[string]LinterConfig)
@@ -125,14 +247,379 @@ func (c *AppConfig) Merge(other *AppConfig) {
 				existing.Enabled = linterConfig.Enabled
 			}
 			if linterConfig.Config != nil {
-				existing.Config = linterConfig.Config
+				// Later files change only the settings they set
+				merged := linterConfig.Config
+				if len(existing.Config) > 0 {
+					if deep, err := MergeLinterConfigs(existing.Config, linterConfig.Config); err == nil {
+						merged = deep
+					}
+				}
+				existing.Config = merged
+			}
+			if linterConfig.Env != nil {
+				env := make(map[string]string, len(existing.Env)+len(linterConfig.Env))
+				for key, value := range existing.Env {
+					env[key] = value
+				}
+				for key, value := range linterConfig.Env {
+					env[key] = value
+				}
+				existing.Env = env
+			}
+			if linterConfig.Path != nil {
+				existing.Path = linterConfig.Path
+			}
+			if linterConfig.Timeout != nil {
+				existing.Timeout = linterConfig.Timeout
 			}
 			c.Linters[name] = existing
 		}
 	}
 
+	// Custom linters are replaced whole, so a later file can redefine one
+	for name, customConfig := range other.CustomLinters {
+		if c.CustomLinters == nil {
+			c.CustomLinters = make(map[string]custom.Config)
+		}
+		c.CustomLinters[name] = customConfig
+	}
+
 	// Append rules (don't merge, later rules take precedence)
 	c.Rules = append(c.Rules, other.Rules...)
+
+	// Collect pack names, each once
+	for _, pack := range other.Packs {
+		if !slices.Contains(c.Packs, pack) {
+			c.Packs = append(c.Packs, pack)
+		}
+	}
+
+	// Collect plugins, each module once, in the order they were first declared
+	for _, plugin := range other.Plugins {
+		if !slices.ContainsFunc(c.Plugins, func(p PluginConfig) bool { return p.Path == plugin.Path }) {
+			c.Plugins = append(c.Plugins, plugin)
+		}
+	}
+
+	// Merge feedback config
+	if other.Feedback != nil {
+		if c.Feedback == nil {
+			c.Feedback = &FeedbackConfig{}
+		}
+		if other.Feedback.MaxIssuesPerFile != nil {
+			c.Feedback.MaxIssuesPerFile = other.Feedback.MaxIssuesPerFile
+		}
+		if other.Feedback.FixPayload != nil {
+			c.Feedback.FixPayload = other.Feedback.FixPayload
+		}
+		if other.Feedback.Language != nil {
+			c.Feedback.Language = other.Feedback.Language
+		}
+		if other.Feedback.ContextLines != nil {
+			c.Feedback.ContextLines = other.Feedback.ContextLines
+		}
+		if other.Feedback.ForbidWhitespaceEdits != nil {
+			c.Feedback.ForbidWhitespaceEdits = other.Feedback.ForbidWhitespaceEdits
+		}
+		if other.Feedback.DependencySummary != nil {
+			c.Feedback.DependencySummary = other.Feedback.DependencySummary
+		}
+	}
+
+	// Merge decision cache config
+	if other.DecisionCache != nil {
+		if c.DecisionCache == nil {
+			c.DecisionCache = &DecisionCacheConfig{}
+		}
+		if other.DecisionCache.Enabled != nil {
+			c.DecisionCache.Enabled = other.DecisionCache.Enabled
+		}
+		if other.DecisionCache.TTL != nil {
+			c.DecisionCache.TTL = other.DecisionCache.TTL
+		}
+		if other.DecisionCache.Escalate != nil {
+			c.DecisionCache.Escalate = other.DecisionCache.Escalate
+		}
+	}
+
+	// Merge result cache config
+	if other.ResultCache != nil {
+		if c.ResultCache == nil {
+			c.ResultCache = &ResultCacheConfig{}
+		}
+		if other.ResultCache.Enabled != nil {
+			c.ResultCache.Enabled = other.ResultCache.Enabled
+		}
+		if other.ResultCache.TTL != nil {
+			c.ResultCache.TTL = other.ResultCache.TTL
+		}
+	}
+
+	// Merge escalation config
+	if other.Escalation != nil {
+		if c.Escalation == nil {
+			c.Escalation = &EscalationConfig{}
+		}
+		if other.Escalation.After != nil {
+			c.Escalation.After = other.Escalation.After
+		}
+		if other.Escalation.Action != nil {
+			c.Escalation.Action = other.Escalation.Action
+		}
+	}
+
+	// Merge ownership config
+	if other.Ownership != nil {
+		if c.Ownership == nil {
+			c.Ownership = &OwnershipConfig{}
+		}
+		if other.Ownership.Enabled != nil {
+			c.Ownership.Enabled = other.Ownership.Enabled
+		}
+		if other.Ownership.Owners != nil {
+			c.Ownership.Owners = other.Ownership.Owners
+		}
+		if other.Ownership.Policy != nil {
+			c.Ownership.Policy = other.Ownership.Policy
+		}
+		if other.Ownership.File != nil {
+			c.Ownership.File = other.Ownership.File
+		}
+	}
+
+	// Merge refactor guard config
+	if other.RefactorGuard != nil {
+		if c.RefactorGuard == nil {
+			c.RefactorGuard = &RefactorGuardConfig{}
+		}
+		if other.RefactorGuard.Enabled != nil {
+			c.RefactorGuard.Enabled = other.RefactorGuard.Enabled
+		}
+		if other.RefactorGuard.MaxChangedLines != nil {
+			c.RefactorGuard.MaxChangedLines = other.RefactorGuard.MaxChangedLines
+		}
+		if other.RefactorGuard.MaxEdits != nil {
+			c.RefactorGuard.MaxEdits = other.RefactorGuard.MaxEdits
+		}
+		if other.RefactorGuard.Action != nil {
+			c.RefactorGuard.Action = other.RefactorGuard.Action
+		}
+	}
+
+	// Merge file policy
+	if other.FilePolicy != nil {
+		if c.FilePolicy == nil {
+			c.FilePolicy = &FilePolicyConfig{}
+		}
+		c.FilePolicy.merge(other.FilePolicy)
+	}
+
+	// Merge generated code config
+	if other.GeneratedCode != nil {
+		if c.GeneratedCode == nil {
+			c.GeneratedCode = &GeneratedCodeConfig{}
+		}
+		c.GeneratedCode.merge(other.GeneratedCode)
+	}
+
+	// Merge resources config
+	if other.Resources != nil {
+		if c.Resources == nil {
+			c.Resources = &ResourcesConfig{}
+		}
+		if other.Resources.Nice != nil {
+			c.Resources.Nice = other.Resources.Nice
+		}
+		if other.Resources.IOClass != nil {
+			c.Resources.IOClass = other.Resources.IOClass
+		}
+		if other.Resources.MaxMemory != nil {
+			c.Resources.MaxMemory = other.Resources.MaxMemory
+		}
+		if other.Resources.MaxCPUTime != nil {
+			c.Resources.MaxCPUTime = other.Resources.MaxCPUTime
+		}
+	}
+
+	// Merge tool caches config
+	if other.ToolCaches != nil {
+		if c.ToolCaches == nil {
+			c.ToolCaches = &ToolCachesConfig{}
+		}
+		if other.ToolCaches.Enabled != nil {
+			c.ToolCaches.Enabled = other.ToolCaches.Enabled
+		}
+		if other.ToolCaches.Dir != nil {
+			c.ToolCaches.Dir = other.ToolCaches.Dir
+		}
+		if other.ToolCaches.CargoTargetDir != nil {
+			c.ToolCaches.CargoTargetDir = other.ToolCaches.CargoTargetDir
+		}
+	}
+
+	// Merge stop hook config
+	if other.StopHook != nil && other.StopHook.ProjectScan != nil {
+		if c.StopHook == nil {
+			c.StopHook = &StopHookConfig{}
+		}
+		if c.StopHook.ProjectScan == nil {
+			c.StopHook.ProjectScan = &ProjectScanConfig{}
+		}
+		scan := other.StopHook.ProjectScan
+		if scan.Enabled != nil {
+			c.StopHook.ProjectScan.Enabled = scan.Enabled
+		}
+		if scan.Budget != nil {
+			c.StopHook.ProjectScan.Budget = scan.Budget
+		}
+		for _, pattern := range scan.Ignore {
+			if !slices.Contains(c.StopHook.ProjectScan.Ignore, pattern) {
+				c.StopHook.ProjectScan.Ignore = append(c.StopHook.ProjectScan.Ignore, pattern)
+			}
+		}
+	}
+
+	// Merge command policy
+	if other.CommandPolicy != nil {
+		if c.CommandPolicy == nil {
+			c.CommandPolicy = &CommandPolicyConfig{}
+		}
+		c.CommandPolicy.merge(other.CommandPolicy)
+	}
+
+	// Merge update check
+	if other.UpdateCheck != nil {
+		if c.UpdateCheck == nil {
+			c.UpdateCheck = &UpdateCheckConfig{}
+		}
+		if other.UpdateCheck.Enabled != nil {
+			c.UpdateCheck.Enabled = other.UpdateCheck.Enabled
+		}
+		if other.UpdateCheck.After != nil {
+			c.UpdateCheck.After = other.UpdateCheck.After
+		}
+	}
+
+	// Merge projects config
+	if other.Projects != nil {
+		if c.Projects == nil {
+			c.Projects = &ProjectsConfig{}
+		}
+		if other.Projects.Detect != nil {
+			c.Projects.Detect = other.Projects.Detect
+		}
+		if other.Projects.ScopeLinters != nil {
+			c.Projects.ScopeLinters = other.Projects.ScopeLinters
+		}
+		for dir, linterConfigs := range other.Projects.Linters {
+			if c.Projects.Linters == nil {
+				c.Projects.Linters = make(map[string]map[string]LinterConfig)
+			}
+			if c.Projects.Linters[dir] == nil {
+				c.Projects.Linters[dir] = make(map[string]LinterConfig)
+			}
+			for name, linterConfig := range linterConfigs {
+				c.Projects.Linters[dir][name] = linterConfig
+			}
+		}
+	}
+}
+
+// IsDecisionCacheEnabled checks if PreToolUse decision caching is enabled
+func (c *AppConfig) IsDecisionCacheEnabled() bool {
+	if c == nil || c.DecisionCache == nil || c.DecisionCache.Enabled == nil {
+		return true // default to enabled
+	}
+	return *c.DecisionCache.Enabled
+}
+
+// IsResultCacheEnabled checks if lint results are reused for unchanged content
+func (c *AppConfig) IsResultCacheEnabled() bool {
+	return c == nil || c.ResultCache == nil || c.ResultCache.Enabled == nil || *c.ResultCache.Enabled
+}
+
+// GetResultCacheTTL returns how long lint results are reused
+func (c *AppConfig) GetResultCacheTTL() time.Duration {
+	if c == nil || c.ResultCache == nil || c.ResultCache.TTL == nil {
+		return DefaultResultCacheTTL
+	}
+	return c.ResultCache.TTL.Duration
+}
+
+// IsInlineConfigEnabled checks if files may override settings with a gismo:config directive
+func (c *AppConfig) IsInlineConfigEnabled() bool {
+	return c == nil || c.InlineConfig == nil || *c.InlineConfig
+}
+
+// GetSeverityMap returns the configured tool severity overrides with labels
+// lower-cased, leaving out entries that don't map to error, warning or info
+func (c *AppConfig) GetSeverityMap() map[string]linters.SeverityMap {
+	if c == nil || len(c.SeverityMap) == 0 {
+		return nil
+	}
+	severities := make(map[string]linters.SeverityMap, len(c.SeverityMap))
+	for tool, labels := range c.SeverityMap {
+		severities[tool] = make(linters.SeverityMap, len(labels))
+		for label, severity := range labels {
+			if linters.IsSeverity(severity) {
+				severities[tool][strings.ToLower(label)] = severity
+			}
+		}
+	}
+	return severities
+}
+
+// IsStrict checks if warnings are treated as blocking errors
+func (c *AppConfig) IsStrict() bool {
+	return c != nil && c.Strict != nil && *c.Strict
+}
+
+// IsWarningsAsInfo checks if warnings are reported as info
+func (c *AppConfig) IsWarningsAsInfo() bool {
+	return c != nil && c.WarningsAsInfo != nil && *c.WarningsAsInfo
+}
+
+// GetMaxIssuesPerFile returns the per-file issue cap for multi-file summaries
+func (c *AppConfig) GetMaxIssuesPerFile() int {
+	if c == nil || c.Feedback == nil || c.Feedback.MaxIssuesPerFile == nil {
+		return DefaultMaxIssuesPerFile
+	}
+	return *c.Feedback.MaxIssuesPerFile
+}
+
+// GetFixPayload returns how known fixes are embedded in feedback
+func (c *AppConfig) GetFixPayload() string {
+	if c == nil || c.Feedback == nil || c.Feedback.FixPayload == nil {
+		return FixPayloadNone
+	}
+	return *c.Feedback.FixPayload
+}
+
+// GetContextLines returns how many lines of source to show either side of an
+// issue, or -1 to show none
+func (c *AppConfig) GetContextLines() int {
+	if c == nil || c.Feedback == nil || c.Feedback.ContextLines == nil || *c.Feedback.ContextLines <= 0 {
+		return -1
+	}
+	return *c.Feedback.ContextLines
+}
+
+// IsWhitespaceEditForbidden checks if edits that only change whitespace are reported
+func (c *AppConfig) IsWhitespaceEditForbidden() bool {
+	return c != nil && c.Feedback != nil && c.Feedback.ForbidWhitespaceEdits != nil && *c.Feedback.ForbidWhitespaceEdits
+}
+
+// IsDependencySummaryEnabled checks if edits to dependency manifests are summarized
+func (c *AppConfig) IsDependencySummaryEnabled() bool {
+	return c == nil || c.Feedback == nil || c.Feedback.DependencySummary == nil || *c.Feedback.DependencySummary
+}
+
+// GetLanguage returns the configured feedback language, or "" to follow the locale
+func (c *AppConfig) GetLanguage() string {
+	if c == nil || c.Feedback == nil || c.Feedback.Language == nil {
+		return ""
+	}
+	return *c.Feedback.Language
 }
 
 // GetLinterConfig returns the configuration for a specific linter
@@ -147,20 +634,86 @@ func (c *AppConfig) GetLinterConfig(name string) (json.RawMessage, bool) {
 	return linterConfig.Config, true
 }
 
+// GetLinterTimeouts returns the configured timeout of each linter that has one
+func (c *AppConfig) GetLinterTimeouts() map[string]time.Duration {
+	if c == nil {
+		return nil
+	}
+	timeouts := make(map[string]time.Duration)
+	for name, linterConfig := range c.Linters {
+		if linterConfig.Timeout != nil && linterConfig.Timeout.Duration > 0 {
+			timeouts[name] = linterConfig.Timeout.Duration
+		}
+	}
+	return timeouts
+}
+
+// linterAliases map other names config files may use to the linter's name
+var linterAliases = map[string]string{
+	"golang": "go",
+}
+
+// normalizeLinterNames renames linter entries and rules that use an alias, so
+// a "golang" block configures the go linter. An entry under the linter's own
+// name takes precedence over one under an alias.
+func (c *AppConfig) normalizeLinterNames() {
+	for alias, name := range linterAliases {
+		if linterConfig, ok := c.Linters[alias]; ok {
+			if _, exists := c.Linters[name]; !exists {
+				c.Linters[name] = linterConfig
+			}
+			delete(c.Linters, alias)
+		}
+	}
+	for i := range c.Rules {
+		if name, ok := linterAliases[c.Rules[i].Linter]; ok {
+			c.Rules[i].Linter = name
+		}
+	}
+}
+
+// optionalLinters are disabled unless enabled explicitly in the config
+var optionalLinters = map[string]bool{
+	"security": true,
+}
+
+// GetPlugins returns the declared WASM plugins, in order
+func (c *AppConfig) GetPlugins() []PluginConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Plugins
+}
+
+// GetCustomLinters returns the declared custom linters, keyed by name
+func (c *AppConfig) GetCustomLinters() map[string]custom.Config {
+	if c == nil {
+		return nil
+	}
+	return c.CustomLinters
+}
+
 // IsLinterEnabled checks if a linter is enabled
 func (c *AppConfig) IsLinterEnabled(name string) bool {
-	if c.Linters == nil {
-		return true // default to enabled
+	if c == nil || c.Linters == nil {
+		return !optionalLinters[name] // default to enabled
 	}
 	linterConfig, ok := c.Linters[name]
 	if !ok || linterConfig.Enabled == nil {
-		return true // default to enabled
+		return !optionalLinters[name] // default to enabled
 	}
 	return *linterConfig.Enabled
 }
 
-// GetRuleOverrides returns all rule overrides that match the given file path for a specific linter
+// GetRuleOverrides returns all rule overrides that match the given file path for
+// a specific linter. Rules with content predicates need the content and never match.
 func (c *AppConfig) GetRuleOverrides(filePath, linterName string) []json.RawMessage {
+	return c.GetContentRuleOverrides(filePath, nil, linterName)
+}
+
+// GetContentRuleOverrides returns all rule overrides that match the given file
+// path and content for a specific linter
+func (c *AppConfig) GetContentRuleOverrides(filePath string, content []byte, linterName string) []json.RawMessage {
 	if len(c.Rules) == 0 {
 		return nil
 	}
@@ -172,22 +725,111 @@ func (c *AppConfig) GetRuleOverrides(filePath, linterName string) []json.RawMess
 			continue
 		}
 
-		// Check if the pattern matches the file path
-		matched, err := filepath.Match(rule.Pattern, filePath)
-		if err != nil {
-			// Invalid pattern, skip
-			continue
+		if len(rule.Rules) > 0 && rule.matches(filePath, content) {
+			overrides = append(overrides, rule.Rules)
 		}
+	}
 
-		if !matched {
-			// Also check against just the filename
-			matched, _ = filepath.Match(rule.Pattern, filepath.Base(filePath))
+	return overrides
+}
+
+// IsRouted reports whether a rule routes the file to a linter that doesn't
+// handle its file type
+func (c *AppConfig) IsRouted(filePath string, content []byte, linterName string) bool {
+	if c == nil {
+		return false
+	}
+	for _, rule := range c.Rules {
+		if rule.Route && rule.Linter == linterName && rule.matches(filePath, content) {
+			return true
 		}
+	}
+	return false
+}
 
-		if matched {
-			overrides = append(overrides, rule.Rules)
+// hasRoutes reports whether any rule routes files to a linter
+func (c *AppConfig) hasRoutes() bool {
+	return c != nil && slices.ContainsFunc(c.Rules, func(rule RuleOverride) bool { return rule.Route })
+}
+
+// hasContentPredicate reports whether the rule matches on file content
+func (r RuleOverride) hasContentPredicate() bool {
+	return r.FirstLine != "" || r.Contains != ""
+}
+
+// matches reports whether the rule applies to the file. A rule needs a pattern
+// or a content predicate, and matches when all of those it has match; content
+// predicates never match nil content.
+func (r RuleOverride) matches(filePath string, content []byte) bool {
+	if r.Pattern == "" && !r.hasContentPredicate() {
+		return false
+	}
+	if r.Pattern != "" && !matchRulePattern(r.Pattern, filePath) {
+		return false
+	}
+	if !r.hasContentPredicate() {
+		return true
+	}
+	if content == nil {
+		return false
+	}
+	if r.Contains != "" && !bytes.Contains(content, []byte(r.Contains)) {
+		return false
+	}
+	if r.FirstLine != "" {
+		re, err := compilePattern(r.FirstLine)
+		if err != nil {
+			return false
+		}
+		line, _, _ := bytes.Cut(content, []byte("\n"))
+		if !re.Match(bytes.TrimSuffix(line, []byte("\r"))) {
+			return false
 		}
 	}
+	return true
+}
+
+// compiledPatterns caches compiled configuration expressions, such as rules'
+// firstLine, as they are matched against every file or command checked
+var compiledPatterns sync.Map
+
+// compilePattern compiles a configured regular expression once
+func compilePattern(expr string) (*regexp.Regexp, error) {
+	if re, ok := compiledPatterns.Load(expr); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	compiledPatterns.Store(expr, re)
+	return re, nil
+}
 
-	return overrides
+// matchRulePattern reports whether a rule pattern matches the file path or its
+// file name. Invalid patterns never match.
+func matchRulePattern(pattern, filePath string) bool {
+	matched, err := filepath.Match(pattern, filePath)
+	if err != nil {
+		return false
+	}
+	if !matched {
+		matched, _ = filepath.Match(pattern, filepath.Base(filePath))
+	}
+	return matched
+}
+
+// GetEscalation returns the escalation threshold and action
+func (c *AppConfig) GetEscalation() (int, string) {
+	after, action := DefaultEscalationThreshold, EscalationRemediate
+	if c == nil || c.Escalation == nil {
+		return after, action
+	}
+	if c.Escalation.After != nil {
+		after = *c.Escalation.After
+	}
+	if c.Escalation.Action != nil {
+		action = *c.Escalation.Action
+	}
+	return after, action
 }
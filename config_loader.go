@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 
 	"github.com/goccy/go-json"
 )
@@ -12,8 +13,15 @@ import (
 type ConfigLoader struct {
 	projectDir string
 	homeDir    string
+	// configHome is the XDG base directory for user config, ~/.config if empty
+	configHome string
 }
 
+// projectConfigDirs are the directories of a project searched for gismo.json
+// and gismo.local.json, lowest precedence first. The project root and
+// .config/gismo serve layouts that use gismo without Claude Code.
+var projectConfigDirs = []string{".", filepath.Join(".config", "gismo"), ".claude"}
+
 // NewConfigLoader creates a new configuration loader
 func NewConfigLoader() (*ConfigLoader, error) {
 	homeDir, err := os.UserHomeDir()
@@ -26,9 +34,16 @@ func NewConfigLoader() (*ConfigLoader, error) {
 		return nil, fmt.Errorf("failed to get working directory: %w", err)
 	}
 
+	// XDG requires an absolute path; a relative one is ignored
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if !filepath.IsAbs(configHome) {
+		configHome = ""
+	}
+
 	return &ConfigLoader{
 		projectDir: projectDir,
 		homeDir:    homeDir,
+		configHome: configHome,
 	}, nil
 }
 
@@ -36,14 +51,7 @@ func NewConfigLoader() (*ConfigLoader, error) {
 func (cl *ConfigLoader) LoadConfig() (*AppConfig, error) {
 	config := NewAppConfig()
 
-	// Configuration files in order of precedence (lowest to highest)
-	configPaths := []string{
-		filepath.Join(cl.homeDir, ".claude", "gismo.json"),          // user global
-		filepath.Join(cl.projectDir, ".claude", "gismo.json"),       // project-specific
-		filepath.Join(cl.projectDir, ".claude", "gismo.local.json"), // local overrides
-	}
-
-	for _, path := range configPaths {
+	for _, path := range cl.GetConfigPaths() {
 		if err := cl.loadAndMergeConfig(config, path); err != nil {
 			return nil, err
 		}
@@ -67,28 +75,67 @@ func (cl *ConfigLoader) LoadConfigWithPaths(paths []string) (*AppConfig, error)
 
 // loadAndMergeConfig loads a single config file and merges it
 func (cl *ConfigLoader) loadAndMergeConfig(config *AppConfig, path string) error {
+	layers, err := readConfigLayers(path)
+	if err != nil {
+		return err
+	}
+	for _, layer := range layers {
+		config.Merge(layer.Config)
+	}
+	return nil
+}
+
+// LoadConfigLayers reads the config files at paths, skipping missing ones, and
+// returns their layers in merge order, without merging them
+func (cl *ConfigLoader) LoadConfigLayers(paths []string) ([]ConfigLayer, error) {
+	var layers []ConfigLayer
+	for _, path := range paths {
+		fileLayers, err := readConfigLayers(path)
+		if err != nil {
+			return nil, err
+		}
+		layers = append(layers, fileLayers...)
+	}
+	return layers, nil
+}
+
+// readConfigLayers reads a config file as the layers it contributes: each rule
+// pack it lists, then the file itself. A missing file has no layers.
+func readConfigLayers(path string) ([]ConfigLayer, error) {
 	// Check if file exists
 	if _, err := os.Stat(path); os.IsNotExist(err) {
 		// File doesn't exist, skip silently
-		return nil
+		return nil, nil
 	}
 
 	// Read the file
 	data, err := os.ReadFile(path)
 	if err != nil {
-		return fmt.Errorf("failed to read config file %s: %w", path, err)
+		return nil, fmt.Errorf("failed to read config file %s: %w", path, err)
 	}
 
 	// Parse the JSON
 	var fileConfig AppConfig
 	if err := json.Unmarshal(data, &fileConfig); err != nil {
-		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	fileConfig.normalizeLinterNames()
+
+	// Packs the file lists apply first, so the file's own settings win
+	var layers []ConfigLayer
+	for _, name := range fileConfig.Packs {
+		packPath := filepath.Join(filepath.Dir(path), RulePackDir, name+".json")
+		pack, err := loadRulePack(packPath)
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("config file %s uses rule pack %q, which is not installed in %s", path, name, filepath.Dir(packPath))
+		}
+		if err != nil {
+			return nil, err
+		}
+		layers = append(layers, ConfigLayer{Source: fmt.Sprintf("%s (pack %s)", path, name), Config: pack.Config()})
 	}
 
-	// Merge into main config
-	config.Merge(&fileConfig)
-
-	return nil
+	return append(layers, ConfigLayer{Source: path, Config: &fileConfig}), nil
 }
 
 // FindProjectRoot finds the project root by looking for .git directory
@@ -114,13 +161,54 @@ func (cl *ConfigLoader) FindProjectRoot() (string, error) {
 	return cl.projectDir, nil
 }
 
-// GetConfigPaths returns the paths where config files will be searched
+// GetConfigPaths returns the paths where config files will be searched, in
+// order of precedence (lowest to highest): the user's XDG config, then
+// ~/.claude, then the project's files and its local overrides
 func (cl *ConfigLoader) GetConfigPaths() []string {
-	return []string{
+	configHome := cl.configHome
+	if configHome == "" {
+		configHome = filepath.Join(cl.homeDir, ".config")
+	}
+	paths := []string{
+		filepath.Join(configHome, "gismo", "gismo.json"),
 		filepath.Join(cl.homeDir, ".claude", "gismo.json"),
-		filepath.Join(cl.projectDir, ".claude", "gismo.json"),
-		filepath.Join(cl.projectDir, ".claude", "gismo.local.json"),
 	}
+	paths = append(paths, ProjectConfigPaths(cl.projectDir)...)
+
+	// Running from the home directory finds its files twice
+	var unique []string
+	for _, path := range paths {
+		if !slices.Contains(unique, path) {
+			unique = append(unique, path)
+		}
+	}
+	return unique
+}
+
+// ProjectConfigPaths returns the config files of the project in dir, in order
+// of precedence: gismo.json at the root, in .config/gismo and in .claude, then
+// gismo.local.json in the same places
+func ProjectConfigPaths(dir string) []string {
+	var paths []string
+	for _, name := range []string{"gismo.json", "gismo.local.json"} {
+		for _, configDir := range projectConfigDirs {
+			paths = append(paths, filepath.Join(dir, configDir, name))
+		}
+	}
+	return paths
+}
+
+// ProjectConfigPath returns the project config file that commands writing the
+// config, such as gismo rules install, update: the existing gismo.json of the
+// project in dir with the highest precedence, else .claude/gismo.json
+func ProjectConfigPath(dir string) string {
+	for i := len(projectConfigDirs) - 1; i >= 0; i-- {
+		path := filepath.Join(dir, projectConfigDirs[i], "gismo.json")
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return filepath.Join(dir, ".claude", "gismo.json")
 }
 
 // ConfigExists checks if any configuration files exist
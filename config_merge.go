@@ -0,0 +1,59 @@
+package gismo
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// appendSuffix marks an override key whose array is appended to the base array
+// instead of replacing it, e.g. "disabledChecks+": ["lll"]
+const appendSuffix = "+"
+
+// MergeLinterConfigs deep-merges linter config layers, later layers taking
+// precedence. Objects are merged key by key at every level, so overriding one
+// nested key keeps its siblings. Arrays and scalars replace the base value,
+// unless the key ends in "+", which appends the array to the base array. A null
+// value removes the key. Empty layers are skipped; the result is always an object.
+func MergeLinterConfigs(layers ...json.RawMessage) (json.RawMessage, error) {
+	merged := make(map[string]interface{})
+	for i, layer := range layers {
+		if len(layer) == 0 {
+			continue
+		}
+		var overlay map[string]interface{}
+		if err := json.Unmarshal(layer, &overlay); err != nil {
+			return nil, fmt.Errorf("config layer %d is not a JSON object: %w", i, err)
+		}
+		mergeObjects(merged, overlay)
+	}
+	return json.Marshal(merged)
+}
+
+// mergeObjects merges overlay into base in place
+func mergeObjects(base, overlay map[string]interface{}) {
+	for key, value := range overlay {
+		if name, ok := strings.CutSuffix(key, appendSuffix); ok && name != "" {
+			if items, isArray := value.([]interface{}); isArray {
+				existing, _ := base[name].([]interface{})
+				base[name] = append(append([]interface{}{}, existing...), items...)
+				continue
+			}
+			key = name
+		}
+
+		switch value := value.(type) {
+		case nil:
+			delete(base, key)
+		case map[string]interface{}:
+			existing, ok := base[key].(map[string]interface{})
+			if !ok {
+				existing = make(map[string]interface{})
+			}
+			mergeObjects(existing, value)
+			base[key] = existing
+		default:
+			base[key] = value
+		}
+	}
+}
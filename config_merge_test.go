@@ -0,0 +1,192 @@
+package gismo
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+func TestMergeLinterConfigs(t *testing.T) {
+	tests := []struct {
+		name    string
+		layers  []string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "override keeps other base keys",
+			layers: []string{`{"maxLineLength": 120, "disabledRules": ["MD013"]}`, `{"maxLineLength": 200}`},
+			want:   `{"disabledRules":["MD013"],"maxLineLength":200}`,
+		},
+		{
+			name:   "nested objects merge key by key",
+			layers: []string{`{"jsonSchema": {"type": "object", "required": ["id"]}}`, `{"jsonSchema": {"type": "array"}}`},
+			want:   `{"jsonSchema":{"required":["id"],"type":"array"}}`,
+		},
+		{
+			name:   "arrays replace by default",
+			layers: []string{`{"disabledChecks": ["lll", "gocyclo"]}`, `{"disabledChecks": ["errcheck"]}`},
+			want:   `{"disabledChecks":["errcheck"]}`,
+		},
+		{
+			name:   "plus suffix appends arrays",
+			layers: []string{`{"disabledChecks": ["lll"]}`, `{"disabledChecks+": ["errcheck"]}`, `{"disabledChecks+": ["gocyclo"]}`},
+			want:   `{"disabledChecks":["lll","errcheck","gocyclo"]}`,
+		},
+		{
+			name:   "plus suffix without base array",
+			layers: []string{`{}`, `{"features+": ["serde"]}`},
+			want:   `{"features":["serde"]}`,
+		},
+		{
+			name:   "plus suffix on a scalar sets the key",
+			layers: []string{`{"verbose": false}`, `{"verbose+": true}`},
+			want:   `{"verbose":true}`,
+		},
+		{
+			name:   "null removes a key",
+			layers: []string{`{"golangciConfig": ".golangci.yml", "gofumpt": true}`, `{"golangciConfig": null}`},
+			want:   `{"gofumpt":true}`,
+		},
+		{
+			name:   "object replaces scalar",
+			layers: []string{`{"jsonSchema": "schema.json"}`, `{"jsonSchema": {"type": "object"}}`},
+			want:   `{"jsonSchema":{"type":"object"}}`,
+		},
+		{
+			name:   "empty layers are skipped",
+			layers: []string{``, `{"verbose": true}`, ``},
+			want:   `{"verbose":true}`,
+		},
+		{
+			name: "no layers",
+			want: `{}`,
+		},
+		{
+			name:    "non-object layer",
+			layers:  []string{`{"verbose": true}`, `["verbose"]`},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			layers := make([]json.RawMessage, len(tt.layers))
+			for i, layer := range tt.layers {
+				layers[i] = json.RawMessage(layer)
+			}
+			got, err := MergeLinterConfigs(layers...)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error, got %s", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("MergeLinterConfigs() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("MergeLinterConfigs() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeLinterConfigs_DoesNotModifyLayers(t *testing.T) {
+	base := json.RawMessage(`{"disabledChecks": ["lll"]}`)
+	if _, err := MergeLinterConfigs(base, json.RawMessage(`{"disabledChecks+": ["errcheck"]}`)); err != nil {
+		t.Fatal(err)
+	}
+	if string(base) != `{"disabledChecks": ["lll"]}` {
+		t.Errorf("base layer modified: %s", base)
+	}
+}
+
+func TestAppConfig_MergeLinterConfigDeep(t *testing.T) {
+	base := NewAppConfig()
+	base.Merge(&AppConfig{Linters: map[string]LinterConfig{
+		"markdown": {Config: json.RawMessage(`{"maxLineLength": 120, "disabledRules": ["MD013"]}`)},
+	}})
+	base.Merge(&AppConfig{Linters: map[string]LinterConfig{
+		"markdown": {Config: json.RawMessage(`{"maxLineLength": 100, "disabledRules+": ["MD041"]}`)},
+	}})
+
+	want := `{"disabledRules":["MD013","MD041"],"maxLineLength":100}`
+	if got := string(base.Linters["markdown"].Config); got != want {
+		t.Errorf("merged config = %s, want %s", got, want)
+	}
+}
+
+func TestLintingRuleEngine_RuleOverridesLayerOnBaseConfig(t *testing.T) {
+	linter := &configRecordingLinter{MockLinter: MockLinter{name: "go", canHandle: true}}
+	engine := NewLintingRuleEngine()
+	engine.linters = []linters.Linter{linter}
+
+	config := NewAppConfig()
+	config.Linters["go"] = LinterConfig{Config: json.RawMessage(`{"gofumpt": true, "disabledChecks": ["lll"]}`)}
+	config.Rules = []RuleOverride{
+		{Pattern: "*_test.go", Linter: "go", Rules: json.RawMessage(`{"testTimeout": "1m", "disabledChecks+": ["funlen"]}`)},
+	}
+	engine.SetAppConfig(config)
+
+	engine.applyRuleOverrides(filepath.Join("pkg", "handler_test.go"))
+	if want := `{"disabledChecks":["lll","funlen"],"gofumpt":true,"testTimeout":"1m"}`; linter.config != want {
+		t.Errorf("test file config = %s, want %s", linter.config, want)
+	}
+
+	// The next file without overrides gets the base config back
+	engine.applyRuleOverrides(filepath.Join("pkg", "handler.go"))
+	if want := `{"disabledChecks":["lll"],"gofumpt":true}`; linter.config != want {
+		t.Errorf("regular file config = %s, want %s", linter.config, want)
+	}
+
+	linter.config = ""
+	engine.applyRuleOverrides(filepath.Join("pkg", "other.go"))
+	if linter.config != "" {
+		t.Errorf("config reapplied without overrides: %s", linter.config)
+	}
+}
+
+func FuzzAppConfig_Merge(f *testing.F) {
+	f.Add([]byte(`{"timeout":"30s","linters":{"go":{"enabled":true,"config":{"gofumpt":true}}}}`), []byte(`{"linters":{"go":{"enabled":false}},"rules":[{"pattern":"*.go","linter":"go","rules":{"x":1}}]}`))
+	f.Add([]byte(`{"strict":true,"severityMap":{"golangci-lint":{"":"error"}},"escalation":{"after":0}}`), []byte(`{"resultCache":{"ttl":"-1s"},"contextLines":-5}`))
+	f.Add([]byte(`{"parallel":{"maxWorkers":-1,"toolLimits":{"cargo":0}}}`), []byte(`{"resources":{"memory":"lots"}}`))
+	f.Add([]byte(`{}`), []byte(`null`))
+	f.Fuzz(func(t *testing.T, base, override []byte) {
+		var a, b AppConfig
+		if json.Unmarshal(base, &a) != nil || json.Unmarshal(override, &b) != nil {
+			return
+		}
+		config := NewAppConfig()
+		config.Merge(&a)
+		config.Merge(&b)
+
+		// Every getter must cope with whatever the files set
+		config.GetEscalation()
+		config.GetContextLines()
+		config.GetResultCacheTTL()
+		config.GetMaxIssuesPerFile()
+		config.GetSeverityMap()
+		_, _ = config.GetResourceLimits()
+		config.GetLinterConfig("go")
+		config.IsLinterEnabled("go")
+		config.GetRuleOverrides("/proj/main.go", "go")
+		config.AnalyzeRules([]string{"go", "markdown"})
+		config.GetCacheDirs("/proj")
+
+		// A merged config must be saveable and load back to the same settings
+		saved, err := json.Marshal(config)
+		if err != nil {
+			t.Fatalf("merged config does not marshal: %v", err)
+		}
+		var loaded AppConfig
+		if err := json.Unmarshal(saved, &loaded); err != nil {
+			t.Fatalf("merged config %s does not load back: %v", saved, err)
+		}
+		if resaved, _ := json.Marshal(&loaded); string(resaved) != string(saved) {
+			t.Fatalf("merged config changed on reload:\n%s\n%s", saved, resaved)
+		}
+	})
+}
@@ -0,0 +1,254 @@
+package gismo
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ConfigLayer is one source of settings. Layers are merged in order, later
+// layers taking precedence.
+type ConfigLayer struct {
+	// Source is the config file, or the rule pack and the file that lists it
+	Source string
+	Config *AppConfig
+}
+
+// MergeConfigLayers merges layers into one config, as LoadConfig does
+func MergeConfigLayers(layers []ConfigLayer) *AppConfig {
+	config := NewAppConfig()
+	for _, layer := range layers {
+		config.Merge(layer.Config)
+	}
+	return config
+}
+
+// RuleSources returns the source of each rule of the merged layers, in the
+// order of the merged config's Rules
+func RuleSources(layers []ConfigLayer) []string {
+	var sources []string
+	for _, layer := range layers {
+		for i, rule := range layer.Config.Rules {
+			sources = append(sources, ruleSource(layer.Source, i, rule))
+		}
+	}
+	return sources
+}
+
+// ruleSource names rule i of a layer
+func ruleSource(source string, i int, rule RuleOverride) string {
+	return fmt.Sprintf("%s rules[%d] %q", source, i, rule.Pattern)
+}
+
+// ConfigSetting is one effective setting and the layer that set it
+type ConfigSetting struct {
+	// Key is the dotted path of the setting, such as "linters.go.config.disabledChecks"
+	Key    string      `json:"key"`
+	Value  interface{} `json:"value"`
+	Source string      `json:"source"`
+}
+
+// configStep is a layer's own settings, or one of its rules: the unit settings
+// are attributed to
+type configStep struct {
+	source string
+	config *AppConfig
+}
+
+// configSteps splits layers into steps, each layer's rules after its settings
+func configSteps(layers []ConfigLayer) []configStep {
+	var steps []configStep
+	for _, layer := range layers {
+		settings := *layer.Config
+		settings.Rules = nil
+		steps = append(steps, configStep{source: layer.Source, config: &settings})
+		for i, rule := range layer.Config.Rules {
+			steps = append(steps, configStep{
+				source: ruleSource(layer.Source, i, rule),
+				config: &AppConfig{Rules: []RuleOverride{rule}},
+			})
+		}
+	}
+	return steps
+}
+
+// EffectiveConfig returns the settings that apply to filePath once layers are
+// merged, sorted by key, each with the layer or rule that set it last. Linter
+// settings include sub-project settings and matching rule overrides, and are
+// given for the linters that would check filePath under the engine's config.
+// Settings left unset use their defaults and are not listed.
+func (e *LintingRuleEngine) EffectiveConfig(layers []ConfigLayer, filePath string) ([]ConfigSetting, error) {
+	var names []string
+	for _, linter := range e.lintersFor(filePath) {
+		if linter.CanHandle(filePath) {
+			names = append(names, linter.Name())
+		}
+	}
+
+	// Merge one step at a time; a setting belongs to the last step that changed it
+	sources := make(map[string]string)
+	var current map[string]interface{}
+	config := NewAppConfig()
+	for _, step := range configSteps(layers) {
+		config.Merge(step.config)
+		doc, err := e.effectiveDocument(config, filePath, names)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", step.source, err)
+		}
+		next := flattenSettings(doc)
+		for key, value := range next {
+			if old, ok := current[key]; !ok || !reflect.DeepEqual(old, value) {
+				sources[key] = step.source
+			}
+		}
+		current = next
+	}
+
+	settings := make([]ConfigSetting, 0, len(current))
+	for key, value := range current {
+		settings = append(settings, ConfigSetting{Key: key, Value: value, Source: sources[key]})
+	}
+	sort.Slice(settings, func(i, j int) bool { return settings[i].Key < settings[j].Key })
+	return settings, nil
+}
+
+// effectiveDocument returns config as it applies to filePath: the global
+// settings, and the merged settings of the named linters
+func (e *LintingRuleEngine) effectiveDocument(config *AppConfig, filePath string, names []string) (map[string]interface{}, error) {
+	doc, err := configDocument(config)
+	if err != nil {
+		return nil, err
+	}
+	delete(doc, "linters")
+	delete(doc, "rules")
+	delete(doc, "packs")
+	if projects, ok := doc["projects"].(map[string]interface{}); ok {
+		delete(projects, "linters")
+	}
+
+	linterDocs := make(map[string]interface{})
+	for _, name := range names {
+		linterConfig := config.Linters[name]
+		layers := append([]json.RawMessage{linterConfig.Config}, e.configOverrides(config, filePath, nil, name)...)
+		merged, err := MergeLinterConfigs(layers...)
+		if err != nil {
+			return nil, fmt.Errorf("%s linter config: %w", name, err)
+		}
+		linterConfig.Config = merged
+		entry, err := toDocument(linterConfig)
+		if err != nil {
+			return nil, err
+		}
+		linterDocs[name] = entry
+	}
+	doc["linters"] = linterDocs
+	return doc, nil
+}
+
+// ConfigChange is a setting that differs between two configs. Old or New is
+// nil when the setting is absent from that config.
+type ConfigChange struct {
+	Key string      `json:"key"`
+	Old interface{} `json:"old,omitempty"`
+	New interface{} `json:"new,omitempty"`
+}
+
+// DiffConfigs returns the settings that differ between a and b, sorted by key
+func DiffConfigs(a, b *AppConfig) ([]ConfigChange, error) {
+	docA, err := configDocument(a)
+	if err != nil {
+		return nil, err
+	}
+	docB, err := configDocument(b)
+	if err != nil {
+		return nil, err
+	}
+	oldSettings, newSettings := flattenSettings(docA), flattenSettings(docB)
+
+	var changes []ConfigChange
+	for key, old := range oldSettings {
+		if value, ok := newSettings[key]; !ok || !reflect.DeepEqual(old, value) {
+			changes = append(changes, ConfigChange{Key: key, Old: old, New: newSettings[key]})
+		}
+	}
+	for key, value := range newSettings {
+		if _, ok := oldSettings[key]; !ok {
+			changes = append(changes, ConfigChange{Key: key, New: value})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+	return changes, nil
+}
+
+// configDocument returns config as generic JSON values
+func configDocument(config *AppConfig) (map[string]interface{}, error) {
+	if config == nil {
+		config = NewAppConfig()
+	}
+	return toDocument(config)
+}
+
+// toDocument converts a value to generic JSON values through its JSON encoding
+func toDocument(value interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	doc := make(map[string]interface{})
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// flattenSettings maps the dotted path of each setting in doc to its value.
+// Objects are walked, and arrays of objects are walked by index, e.g.
+// "rules[0].pattern"; other arrays and scalars are values. Empty objects
+// have no settings.
+func flattenSettings(doc map[string]interface{}) map[string]interface{} {
+	settings := make(map[string]interface{})
+	var walk func(prefix string, value interface{})
+	walk = func(prefix string, value interface{}) {
+		switch value := value.(type) {
+		case map[string]interface{}:
+			for key, child := range value {
+				walk(joinSettingKey(prefix, key), child)
+			}
+		case []interface{}:
+			if !allObjects(value) {
+				settings[prefix] = value
+				return
+			}
+			for i, child := range value {
+				walk(fmt.Sprintf("%s[%d]", prefix, i), child)
+			}
+		default:
+			settings[prefix] = value
+		}
+	}
+	walk("", doc)
+	return settings
+}
+
+// joinSettingKey appends key to a dotted settings path
+func joinSettingKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	if strings.ContainsAny(key, ".[]") {
+		key = fmt.Sprintf("%q", key)
+	}
+	return prefix + "." + key
+}
+
+// allObjects reports whether items is a non-empty array of objects
+func allObjects(items []interface{}) bool {
+	for _, item := range items {
+		if _, ok := item.(map[string]interface{}); !ok {
+			return false
+		}
+	}
+	return len(items) > 0
+}
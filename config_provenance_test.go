@@ -0,0 +1,139 @@
+package gismo
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeConfigFile writes a config file into dir and returns its path
+func writeConfigFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// settingsByKey indexes settings by key
+func settingsByKey(settings []ConfigSetting) map[string]ConfigSetting {
+	byKey := make(map[string]ConfigSetting, len(settings))
+	for _, setting := range settings {
+		byKey[setting.Key] = setting
+	}
+	return byKey
+}
+
+func TestLintingRuleEngine_EffectiveConfig(t *testing.T) {
+	dir := t.TempDir()
+	global := writeConfigFile(t, dir, "global.json", `{
+		"strict": true,
+		"feedback": {"language": "ja"},
+		"linters": {"go": {"config": {"disabledChecks": ["lll"], "gofumpt": true}}}
+	}`)
+	project := writeConfigFile(t, dir, "project.json", `{
+		"feedback": {"language": "en"},
+		"linters": {
+			"go": {"config": {"gofumpt": false, "disabledChecks+": ["dupl"]}},
+			"markdown": {"config": {"maxLineLength": 100}}
+		},
+		"rules": [
+			{"pattern": "*.md", "linter": "markdown", "rules": {"maxLineLength": 200}},
+			{"pattern": "*_test.go", "linter": "go", "rules": {"testTimeout": "2m"}}
+		]
+	}`)
+
+	loader := &ConfigLoader{projectDir: dir, homeDir: dir}
+	layers, err := loader.LoadConfigLayers([]string{global, filepath.Join(dir, "missing.json"), project})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(layers) != 2 {
+		t.Fatalf("layers = %+v, want the two existing files", layers)
+	}
+	engine := NewLintingRuleEngineWithConfig(LintingConfig{ProjectRoot: dir})
+	engine.SetAppConfig(MergeConfigLayers(layers))
+
+	settings, err := engine.EffectiveConfig(layers, filepath.Join(dir, "pkg", "x_test.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	byKey := settingsByKey(settings)
+	testRule := project + ` rules[1] "*_test.go"`
+	for key, want := range map[string]string{
+		"strict":                           global,
+		"feedback.language":                project,
+		"linters.go.config.gofumpt":        project,
+		"linters.go.config.disabledChecks": project,
+		"linters.go.config.testTimeout":    testRule,
+	} {
+		if got := byKey[key].Source; got != want {
+			t.Errorf("source of %s = %q, want %q", key, got, want)
+		}
+	}
+	if checks, _ := byKey["linters.go.config.disabledChecks"].Value.([]interface{}); len(checks) != 2 {
+		t.Errorf("disabledChecks = %v, want the appended list", byKey["linters.go.config.disabledChecks"].Value)
+	}
+	if _, ok := byKey["linters.markdown.config.maxLineLength"]; ok {
+		t.Error("settings include the markdown linter, which doesn't check .go files")
+	}
+	for i := 1; i < len(settings); i++ {
+		if settings[i-1].Key >= settings[i].Key {
+			t.Errorf("settings not sorted: %s before %s", settings[i-1].Key, settings[i].Key)
+		}
+	}
+
+	// A rule that matches sets the value; the base value it replaces isn't listed
+	settings, err = engine.EffectiveConfig(layers, filepath.Join(dir, "README.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	setting := settingsByKey(settings)["linters.markdown.config.maxLineLength"]
+	if setting.Value != float64(200) || setting.Source != project+` rules[0] "*.md"` {
+		t.Errorf("markdown maxLineLength = %+v", setting)
+	}
+}
+
+func TestDiffConfigs(t *testing.T) {
+	dir := t.TempDir()
+	loader := &ConfigLoader{projectDir: dir, homeDir: dir}
+	load := func(content string) *AppConfig {
+		t.Helper()
+		config, err := loader.LoadConfigWithPaths([]string{writeConfigFile(t, dir, "c.json", content)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return config
+	}
+	a := load(`{"strict": true, "linters": {"go": {"config": {"gofumpt": true}}}, "rules": [{"pattern": "*.md", "linter": "markdown", "rules": {"maxLineLength": 100}}]}`)
+	b := load(`{"linters": {"go": {"config": {"gofumpt": false}}}, "rules": [{"pattern": "*.md", "linter": "markdown", "rules": {"maxLineLength": 120}}], "timeout": "30s"}`)
+
+	changes, err := DiffConfigs(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var keys []string
+	for _, change := range changes {
+		keys = append(keys, change.Key)
+	}
+	want := []string{"linters.go.config.gofumpt", "rules[0].rules.maxLineLength", "strict", "timeout"}
+	if len(keys) != len(want) {
+		t.Fatalf("changed keys = %v, want %v", keys, want)
+	}
+	for i := range want {
+		if keys[i] != want[i] {
+			t.Fatalf("changed keys = %v, want %v", keys, want)
+		}
+	}
+	if changes[2].Old != true || changes[2].New != nil {
+		t.Errorf("strict change = %+v, want removed", changes[2])
+	}
+	if changes[3].Old != nil || changes[3].New != "30s" {
+		t.Errorf("timeout change = %+v, want added", changes[3])
+	}
+
+	if changes, err := DiffConfigs(a, a); err != nil || len(changes) != 0 {
+		t.Errorf("DiffConfigs(a, a) = %v, %v", changes, err)
+	}
+}
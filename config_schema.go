@@ -0,0 +1,176 @@
+package gismo
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kaptinlin/jsonschema"
+)
+
+// SchemaLinter is implemented by configurable linters that publish a JSON Schema
+// for their config block
+type SchemaLinter interface {
+	ConfigSchema() json.RawMessage
+}
+
+// LinterSchemas returns the config schema of each registered linter that publishes one
+func (e *LintingRuleEngine) LinterSchemas() map[string]json.RawMessage {
+	schemas := make(map[string]json.RawMessage)
+	for _, linter := range e.linters {
+		if provider, ok := linter.(SchemaLinter); ok {
+			schemas[linter.Name()] = provider.ConfigSchema()
+		}
+	}
+	return schemas
+}
+
+// LinterConfigProblem describes a linter config block that doesn't match the
+// linter's schema
+type LinterConfigProblem struct {
+	Linter  string `json:"linter"`
+	Source  string `json:"source"` // "linters.<name>.config" or "rules[<index>]"
+	Message string `json:"message"`
+}
+
+// ValidateLinterConfigs checks each linter's config block and each rule override
+// against the linter's schema, so typoed keys are reported instead of silently
+// ignored. Rules for "*" apply to every linter, so their keys only need to be
+// known to one of them. Linters without a schema are not checked.
+func (c *AppConfig) ValidateLinterConfigs(schemas map[string]json.RawMessage) []LinterConfigProblem {
+	if c == nil {
+		return nil
+	}
+
+	compiled := make(map[string]*jsonschema.Schema, len(schemas))
+	compiler := jsonschema.NewCompiler()
+	for name, schema := range schemas {
+		if s, err := compiler.Compile(schema); err == nil {
+			compiled[name] = s
+		}
+	}
+
+	var problems []LinterConfigProblem
+	check := func(linter, source string, config json.RawMessage) {
+		schema, ok := compiled[linter]
+		if !ok || len(config) == 0 {
+			return
+		}
+		// Merging onto nothing resolves "key+" appends and null removals
+		normalized, err := MergeLinterConfigs(config)
+		var value interface{}
+		if err == nil {
+			err = json.Unmarshal(normalized, &value)
+		}
+		if err != nil {
+			problems = append(problems, LinterConfigProblem{Linter: linter, Source: source, Message: "config must be a JSON object"})
+			return
+		}
+		for _, message := range schemaErrors(schema.Validate(value), schemas[linter]) {
+			problems = append(problems, LinterConfigProblem{Linter: linter, Source: source, Message: message})
+		}
+	}
+
+	for _, name := range sortedKeys(c.Linters) {
+		check(name, fmt.Sprintf("linters.%s.config", name), c.Linters[name].Config)
+	}
+
+	for i, rule := range c.Rules {
+		source := fmt.Sprintf("rules[%d]", i)
+		if rule.Linter != "*" {
+			check(rule.Linter, source, rule.Rules)
+			continue
+		}
+		if len(schemas) == 0 {
+			continue
+		}
+		var settings map[string]json.RawMessage
+		if json.Unmarshal(rule.Rules, &settings) != nil {
+			continue // reported by AnalyzeRules
+		}
+		for _, key := range sortedKeys(settings) {
+			if !anySchemaHasProperty(schemas, strings.TrimSuffix(key, appendSuffix)) {
+				problems = append(problems, LinterConfigProblem{
+					Linter: "*", Source: source,
+					Message: fmt.Sprintf("unknown key %q, no linter accepts it", key),
+				})
+			}
+		}
+	}
+	return problems
+}
+
+// schemaErrors flattens a validation result into one message per failing value.
+// Unknown keys are reported with the keys the schema does accept.
+func schemaErrors(result *jsonschema.EvaluationResult, schema json.RawMessage) []string {
+	if result == nil || result.IsValid() {
+		return nil
+	}
+
+	var messages []string
+	var walk func(list jsonschema.List)
+	walk = func(list jsonschema.List) {
+		nested := false
+		for _, detail := range list.Details {
+			if !detail.Valid {
+				nested = true
+				walk(detail)
+			}
+		}
+		if nested || len(list.Errors) == 0 || list.EvaluationPath == "" {
+			return
+		}
+		location := strings.TrimPrefix(list.InstanceLocation, "/")
+		if strings.HasPrefix(list.EvaluationPath, "/additionalProperties/") {
+			messages = append(messages, fmt.Sprintf("unknown key %q (known: %s)",
+				location, strings.Join(schemaProperties(schema), ", ")))
+			return
+		}
+		for _, keyword := range sortedKeys(list.Errors) {
+			messages = append(messages, fmt.Sprintf("%s: %s", location, list.Errors[keyword]))
+		}
+	}
+	walk(*result.ToList())
+
+	if len(messages) == 0 {
+		for _, keyword := range sortedKeys(result.Errors) {
+			messages = append(messages, result.Errors[keyword].Error())
+		}
+	}
+	sort.Strings(messages)
+	return messages
+}
+
+// schemaProperties returns the top-level property names a schema accepts
+func schemaProperties(schema json.RawMessage) []string {
+	var parsed struct {
+		Properties map[string]json.RawMessage `json:"properties"`
+	}
+	if err := json.Unmarshal(schema, &parsed); err != nil {
+		return nil
+	}
+	return sortedKeys(parsed.Properties)
+}
+
+// anySchemaHasProperty reports whether any schema accepts the top-level key
+func anySchemaHasProperty(schemas map[string]json.RawMessage, key string) bool {
+	for _, schema := range schemas {
+		for _, property := range schemaProperties(schema) {
+			if property == key {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// sortedKeys returns the keys of m in sorted order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,115 @@
+package gismo
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/kaptinlin/jsonschema"
+)
+
+func TestLintingRuleEngine_LinterSchemas(t *testing.T) {
+	engine := NewLintingRuleEngine()
+	schemas := engine.LinterSchemas()
+
+	for _, name := range engine.LinterNames() {
+		schema, ok := schemas[name]
+		if !ok {
+			t.Errorf("linter %q has no config schema", name)
+			continue
+		}
+		if _, err := jsonschema.NewCompiler().Compile(schema); err != nil {
+			t.Errorf("linter %q schema does not compile: %v", name, err)
+		}
+	}
+}
+
+func TestAppConfig_ValidateLinterConfigs(t *testing.T) {
+	schemas := NewLintingRuleEngine().LinterSchemas()
+
+	tests := []struct {
+		name    string
+		config  *AppConfig
+		want    []string
+		wantNil bool
+	}{
+		{
+			name:    "nil config",
+			wantNil: true,
+		},
+		{
+			name: "valid linter configs",
+			config: &AppConfig{Linters: map[string]LinterConfig{
+				"go":       {Config: json.RawMessage(`{"disabledChecks": ["lll"], "testTimeout": "5m"}`)},
+				"markdown": {Config: json.RawMessage(`{"maxLineLength": 100}`)},
+				"python":   {Enabled: boolPtr(false)},
+			}},
+			wantNil: true,
+		},
+		{
+			name: "typoed key",
+			config: &AppConfig{Linters: map[string]LinterConfig{
+				"markdown": {Config: json.RawMessage(`{"maxLinLength": 100}`)},
+			}},
+			want: []string{`linters.markdown.config: unknown key "maxLinLength"`, "maxLineLength"},
+		},
+		{
+			name: "wrong value type",
+			config: &AppConfig{Linters: map[string]LinterConfig{
+				"json": {Config: json.RawMessage(`{"validationLevel": "strict"}`)},
+			}},
+			want: []string{"linters.json.config: validationLevel:"},
+		},
+		{
+			name: "rule override for one linter",
+			config: &AppConfig{Rules: []RuleOverride{
+				{Pattern: "*.go", Linter: "go", Rules: json.RawMessage(`{"gofumpt": true}`)},
+				{Pattern: "*_test.go", Linter: "go", Rules: json.RawMessage(`{"testTimout": "1m"}`)},
+			}},
+			want: []string{`rules[1]: unknown key "testTimout"`},
+		},
+		{
+			name: "rule override for all linters",
+			config: &AppConfig{Rules: []RuleOverride{
+				{Pattern: "*", Linter: "*", Rules: json.RawMessage(`{"maxLineLength": 120, "maxLength": 120}`)},
+			}},
+			want: []string{`rules[0]: unknown key "maxLength", no linter accepts it`},
+		},
+		{
+			name: "append keys are checked without the suffix",
+			config: &AppConfig{Rules: []RuleOverride{
+				{Pattern: "*_test.go", Linter: "go", Rules: json.RawMessage(`{"disabledChecks+": ["funlen"]}`)},
+				{Pattern: "*", Linter: "*", Rules: json.RawMessage(`{"disabledChecks+": ["lll"], "golangciConfig": null}`)},
+			}},
+			wantNil: true,
+		},
+		{
+			name: "linter without schema is not checked",
+			config: &AppConfig{Linters: map[string]LinterConfig{
+				"custom": {Config: json.RawMessage(`{"anything": true}`)},
+			}},
+			wantNil: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problems := tt.config.ValidateLinterConfigs(schemas)
+			if tt.wantNil {
+				if len(problems) != 0 {
+					t.Fatalf("expected no problems, got %+v", problems)
+				}
+				return
+			}
+			if len(problems) != 1 {
+				t.Fatalf("expected one problem, got %+v", problems)
+			}
+			got := problems[0].Source + ": " + problems[0].Message
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("problem %q missing %q", got, want)
+				}
+			}
+		})
+	}
+}
@@ -2,8 +2,15 @@ package gismo
 
 import (
 	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/jrossi/gismo/linters"
+	"github.com/jrossi/gismo/linters/custom"
+	"github.com/jrossi/gismo/types"
 )
 
 func TestAppConfig_Merge(t *testing.T) {
@@ -123,6 +130,117 @@ func TestAppConfig_Merge(t *testing.T) {
 	}
 }
 
+func TestAppConfig_MergeLinterEnv(t *testing.T) {
+	base := NewAppConfig()
+	base.Merge(&AppConfig{Linters: map[string]LinterConfig{
+		"javascript": {Env: map[string]string{"NODE_OPTIONS": "--max-old-space-size=2048", "CI": "1"}, Path: []string{"node_modules/.bin"}},
+	}})
+	shared := base.Linters["javascript"].Env
+
+	base.Merge(&AppConfig{Linters: map[string]LinterConfig{
+		"javascript": {Env: map[string]string{"NODE_OPTIONS": "--max-old-space-size=4096"}},
+	}})
+
+	got := base.Linters["javascript"]
+	if got.Env["NODE_OPTIONS"] != "--max-old-space-size=4096" || got.Env["CI"] != "1" {
+		t.Errorf("expected env merged key by key, got %v", got.Env)
+	}
+	if len(got.Path) != 1 || got.Path[0] != "node_modules/.bin" {
+		t.Errorf("expected PATH entries kept, got %v", got.Path)
+	}
+	if shared["NODE_OPTIONS"] != "--max-old-space-size=2048" {
+		t.Error("merge must not modify the env map of an earlier config")
+	}
+}
+
+func TestAppConfig_GetLinterTimeouts(t *testing.T) {
+	base := NewAppConfig()
+	base.Merge(&AppConfig{Linters: map[string]LinterConfig{
+		"go":       {Timeout: types.NewDuration(2 * time.Minute)},
+		"markdown": {Timeout: types.NewDuration(10 * time.Second)},
+	}})
+	base.Merge(&AppConfig{Linters: map[string]LinterConfig{
+		"markdown": {Timeout: types.NewDuration(5 * time.Second)},
+		"json":     {Enabled: boolPtr(true)},
+	}})
+
+	timeouts := base.GetLinterTimeouts()
+	if len(timeouts) != 2 || timeouts["go"] != 2*time.Minute || timeouts["markdown"] != 5*time.Second {
+		t.Errorf("GetLinterTimeouts() = %v", timeouts)
+	}
+}
+
+func TestAppConfig_MergeSeverityMap(t *testing.T) {
+	first := map[string]linters.SeverityMap{"eslint": {"1": "error"}, "clippy": {"note": "warning"}}
+	base := NewAppConfig()
+	base.Merge(&AppConfig{SeverityMap: first})
+	base.Merge(&AppConfig{SeverityMap: map[string]linters.SeverityMap{"eslint": {"2": "warning"}, "biome": {"Hint": "bogus"}}})
+
+	got := base.GetSeverityMap()
+	if got["eslint"]["1"] != "error" || got["eslint"]["2"] != "warning" || got["clippy"]["note"] != "warning" {
+		t.Errorf("expected severity maps merged label by label, got %v", got)
+	}
+	if _, ok := got["biome"]["hint"]; ok {
+		t.Errorf("invalid severity kept: %v", got["biome"])
+	}
+	if len(first["eslint"]) != 1 {
+		t.Error("merge must not modify the severity map of an earlier config")
+	}
+}
+
+func TestAppConfig_MergeCustomLinters(t *testing.T) {
+	base := NewAppConfig()
+	base.Merge(&AppConfig{CustomLinters: map[string]custom.Config{
+		"xyz":  {Command: "xyzlint {file}", Patterns: []string{"*.xyz"}, OutputFormat: "json"},
+		"todo": {Command: "todo-lint {file}", Patterns: []string{"*"}},
+	}})
+	base.Merge(&AppConfig{CustomLinters: map[string]custom.Config{
+		"xyz": {Command: "xyzlint --strict {file}", Patterns: []string{"*.xyz"}},
+	}})
+
+	got := base.GetCustomLinters()
+	if len(got) != 2 || got["todo"].Command != "todo-lint {file}" {
+		t.Errorf("custom linters = %+v, want both declarations", got)
+	}
+	// A later declaration replaces the whole linter, format included
+	if got["xyz"].Command != "xyzlint --strict {file}" || got["xyz"].OutputFormat != "" {
+		t.Errorf("xyz = %+v, want the later declaration", got["xyz"])
+	}
+}
+
+func TestAppConfig_MergePlugins(t *testing.T) {
+	base := NewAppConfig()
+	base.Merge(&AppConfig{Plugins: []PluginConfig{{Path: "plugins/policy.wasm"}}})
+	base.Merge(&AppConfig{Plugins: []PluginConfig{{Path: "plugins/naming.wasm"}, {Path: "plugins/policy.wasm"}}})
+
+	got := base.GetPlugins()
+	if len(got) != 2 || got[0].Path != "plugins/policy.wasm" || got[1].Path != "plugins/naming.wasm" {
+		t.Errorf("plugins = %+v, want each module once in declaration order", got)
+	}
+	var none *AppConfig
+	if none.GetPlugins() != nil {
+		t.Error("nil config has plugins")
+	}
+}
+
+func TestAppConfig_GetContextLines(t *testing.T) {
+	config := NewAppConfig()
+	if got := config.GetContextLines(); got != -1 {
+		t.Errorf("default = %d, want -1", got)
+	}
+
+	lines := 3
+	config.Merge(&AppConfig{Feedback: &FeedbackConfig{ContextLines: &lines}})
+	if got := config.GetContextLines(); got != 3 {
+		t.Errorf("merged = %d, want 3", got)
+	}
+
+	var nilConfig *AppConfig
+	if got := nilConfig.GetContextLines(); got != -1 {
+		t.Errorf("nil config = %d, want -1", got)
+	}
+}
+
 func TestAppConfig_GetLinterConfig(t *testing.T) {
 	config := &AppConfig{
 		Linters: map[string]LinterConfig{
@@ -218,6 +336,11 @@ func TestAppConfig_IsLinterEnabled(t *testing.T) {
 			linterName: "rust",
 			want:       true, // defaults to enabled
 		},
+		{
+			name:       "optional_linter",
+			linterName: "security",
+			want:       false, // opt-in only
+		},
 	}
 
 	for _, tt := range tests {
@@ -257,8 +380,13 @@ func TestDuration_UnmarshalJSON(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name:    "not_string",
-			input:   `123`,
+			name:  "integer_milliseconds",
+			input: `123`,
+			want:  123 * time.Millisecond,
+		},
+		{
+			name:    "not_string_or_number",
+			input:   `true`,
 			wantErr: true,
 		},
 	}
@@ -483,3 +611,147 @@ func TestAppConfig_GetRuleOverrides(t *testing.T) {
 		})
 	}
 }
+
+func TestAppConfig_ContentRules(t *testing.T) {
+	config := &AppConfig{
+		Rules: []RuleOverride{
+			{FirstLine: `^#!\s*/usr/bin/env\s+bash`, Linter: "shell", Route: true},
+			{Pattern: "*.yaml", Contains: "apiVersion:", Linter: "kubernetes", Route: true},
+			{Pattern: "*.yaml", Contains: "apiVersion:", Linter: "yaml", Rules: json.RawMessage(`{"maxLineLength": 200}`)},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		filePath   string
+		content    string
+		linterName string
+		wantRouted bool
+	}{
+		{"shebang routes to shell", "scripts/deploy", "#!/usr/bin/env bash\necho hi\n", "shell", true},
+		{"CRLF first line", "scripts/deploy", "#!/usr/bin/env bash\r\necho hi\r\n", "shell", true},
+		{"shebang on a later line", "notes", "notes\n#!/usr/bin/env bash\n", "shell", false},
+		{"contains and pattern", "deploy/app.yaml", "kind: Deployment\napiVersion: apps/v1\n", "kubernetes", true},
+		{"contains without pattern", "deploy/app.json", `{"apiVersion:": 1}`, "kubernetes", false},
+		{"pattern without contains", "ci.yaml", "jobs: {}\n", "kubernetes", false},
+		{"other linter", "scripts/deploy", "#!/usr/bin/env bash\n", "python", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := config.IsRouted(tt.filePath, []byte(tt.content), tt.linterName); got != tt.wantRouted {
+				t.Errorf("IsRouted() = %v, want %v", got, tt.wantRouted)
+			}
+		})
+	}
+
+	kubernetes := []byte("apiVersion: v1\n")
+	if got := config.GetContentRuleOverrides("app.yaml", kubernetes, "yaml"); len(got) != 1 {
+		t.Errorf("GetContentRuleOverrides() = %d overrides, want 1", len(got))
+	}
+	// Without the content, content rules never match
+	if got := config.GetRuleOverrides("app.yaml", "yaml"); len(got) != 0 {
+		t.Errorf("GetRuleOverrides() = %d overrides, want 0", len(got))
+	}
+	if config.IsRouted("app.yaml", nil, "kubernetes") {
+		t.Error("IsRouted() with nil content = true")
+	}
+}
+
+func FuzzMatchRulePattern(f *testing.F) {
+	f.Add("*.go", "/proj/internal/main.go")
+	f.Add("internal/*_test.go", "internal/server_test.go")
+	f.Add("[a-", "a.go")
+	f.Add("\\", "x")
+	f.Add("", "")
+	f.Fuzz(func(t *testing.T, pattern, filePath string) {
+		matched := matchRulePattern(pattern, filePath)
+		// A pattern without glob syntax matches the path it names
+		if !strings.ContainsAny(pattern, `*?[\`) && pattern == filePath && !matched {
+			t.Fatalf("matchRulePattern(%q, %q) = false for a literal path", pattern, filePath)
+		}
+		// An invalid pattern never matches
+		if _, err := filepath.Match(pattern, ""); err != nil && matched {
+			t.Fatalf("invalid pattern %q matched %q", pattern, filePath)
+		}
+	})
+}
+
+func TestConfigLoader_SearchOrder(t *testing.T) {
+	home, project := t.TempDir(), t.TempDir()
+	for _, dir := range []string{
+		filepath.Join(home, ".config", "gismo"),
+		filepath.Join(home, ".claude"),
+		filepath.Join(project, ".config", "gismo"),
+		filepath.Join(project, ".claude"),
+	} {
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Each file sets the language, and all but the last also set a key of their own
+	writeConfigFile(t, home, ".config/gismo/gismo.json", `{"strict": true, "feedback": {"language": "xdg"}}`)
+	writeConfigFile(t, home, ".claude/gismo.json", `{"feedback": {"language": "global"}}`)
+	writeConfigFile(t, project, "gismo.json", `{"warningsAsInfo": true, "feedback": {"language": "root"}}`)
+	writeConfigFile(t, project, ".config/gismo/gismo.json", `{"feedback": {"language": "config", "maxIssuesPerFile": 3}}`)
+	writeConfigFile(t, project, "gismo.local.json", `{"feedback": {"language": "root-local"}}`)
+
+	loader := &ConfigLoader{projectDir: project, homeDir: home}
+	config, err := loader.LoadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !config.IsStrict() || !config.IsWarningsAsInfo() || config.GetMaxIssuesPerFile() != 3 {
+		t.Errorf("settings from every location should merge: %+v", config)
+	}
+	if got := config.GetLanguage(); got != "root-local" {
+		t.Errorf("language = %q, want the local override", got)
+	}
+
+	// .claude/gismo.json has precedence over the other project locations
+	writeConfigFile(t, project, ".claude/gismo.json", `{"feedback": {"language": "claude"}}`)
+	if got := ProjectConfigPath(project); got != filepath.Join(project, ".claude", "gismo.json") {
+		t.Errorf("ProjectConfigPath() = %q", got)
+	}
+	paths := loader.GetConfigPaths()
+	if paths[0] != filepath.Join(home, ".config", "gismo", "gismo.json") || paths[len(paths)-1] != filepath.Join(project, ".claude", "gismo.local.json") {
+		t.Errorf("GetConfigPaths() = %v", paths)
+	}
+
+	// XDG_CONFIG_HOME replaces ~/.config, and a home directory used as the
+	// project is searched once
+	loader = &ConfigLoader{projectDir: home, homeDir: home, configHome: filepath.Join(project, ".config")}
+	paths = loader.GetConfigPaths()
+	if paths[0] != filepath.Join(project, ".config", "gismo", "gismo.json") {
+		t.Errorf("XDG path = %q", paths[0])
+	}
+	seen := make(map[string]bool)
+	for _, path := range paths {
+		if seen[path] {
+			t.Errorf("%s searched twice", path)
+		}
+		seen[path] = true
+	}
+}
+
+func TestConfigLoader_LinterAliases(t *testing.T) {
+	project := t.TempDir()
+	writeConfigFile(t, project, "gismo.json", `{
+		"linters": {"golang": {"config": {"buildTags": ["integration"]}}},
+		"rules": [{"pattern": "*_test.go", "linter": "golang", "rules": {"runTests": false}}]
+	}`)
+
+	loader := &ConfigLoader{projectDir: project, homeDir: t.TempDir()}
+	config, err := loader.LoadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := config.Linters["golang"]; ok {
+		t.Error("golang entry kept under its alias")
+	}
+	if linterConfig, ok := config.GetLinterConfig("go"); !ok || !strings.Contains(string(linterConfig), "integration") {
+		t.Errorf("go linter config = %s, want the golang block", linterConfig)
+	}
+	if len(config.Rules) != 1 || config.Rules[0].Linter != "go" {
+		t.Errorf("rules = %+v, want the rule for the go linter", config.Rules)
+	}
+}
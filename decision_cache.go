@@ -0,0 +1,243 @@
+package gismo
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/jrossi/gismo/linters"
+	"github.com/jrossi/gismo/types"
+)
+
+// DefaultDecisionCacheTTL is how long a PreToolUse decision is reused by default
+const DefaultDecisionCacheTTL = time.Minute
+
+// DecisionCacheConfig controls caching of PreToolUse decisions for identical tool inputs
+type DecisionCacheConfig struct {
+	Enabled  *bool           `json:"enabled,omitempty"`  // default true
+	TTL      *types.Duration `json:"ttl,omitempty"`      // default 1m
+	Escalate *bool           `json:"escalate,omitempty"` // note repeated blocks in the reason, default true
+}
+
+// CachingRuleEngine wraps a RuleEngine and reuses PreToolUse decisions when Claude
+// retries an identical tool input within the TTL. Decisions are keyed by the tool
+// name, tool input, the current content of the target file, the configuration and
+// the project's rule overrides, so retrying the same Edit against a file that has
+// since changed, or after the config or overrides change, is evaluated again.
+type CachingRuleEngine struct {
+	RuleEngine
+	store    *SessionStore
+	fs       linters.FileSystem
+	ttl      time.Duration
+	escalate bool
+	feedback io.Writer
+	now      func() time.Time
+	// root is the project whose rule overrides are part of the key, the
+	// working directory if empty
+	root string
+	// configHash identifies the configuration decisions were made under
+	configHash []byte
+	configErr  error
+}
+
+// NewCachingRuleEngine wraps engine with a decision cache persisted in store
+func NewCachingRuleEngine(engine RuleEngine, store *SessionStore) *CachingRuleEngine {
+	return &CachingRuleEngine{
+		RuleEngine: engine,
+		store:      store,
+		fs:         linters.OSFileSystem{},
+		ttl:        DefaultDecisionCacheTTL,
+		escalate:   true,
+		feedback:   os.Stderr,
+		now:        time.Now,
+	}
+}
+
+// NewCachingRuleEngineWithConfig wraps engine with a decision cache using config settings
+func NewCachingRuleEngineWithConfig(engine RuleEngine, store *SessionStore, config *DecisionCacheConfig) *CachingRuleEngine {
+	c := NewCachingRuleEngine(engine, store)
+	if config != nil {
+		if config.TTL != nil {
+			c.ttl = config.TTL.Duration
+		}
+		if config.Escalate != nil {
+			c.escalate = *config.Escalate
+		}
+	}
+	return c
+}
+
+// SetFileSystem sets the filesystem used to read target files when computing cache keys
+func (c *CachingRuleEngine) SetFileSystem(fsys linters.FileSystem) {
+	c.fs = fsys
+}
+
+// SetProjectRoot sets the project whose rule overrides, written there by gismo
+// allow, are part of the cache key
+func (c *CachingRuleEngine) SetProjectRoot(root string) {
+	c.root = root
+}
+
+// SetAppConfig makes the configuration part of the cache key, so blocks made
+// under a different config, such as before a rule was turned off, aren't reused
+func (c *CachingRuleEngine) SetAppConfig(config *AppConfig) {
+	c.configHash, c.configErr = nil, nil
+	if config == nil {
+		return
+	}
+	data, err := json.Marshal(config)
+	if err != nil {
+		// Without a key for the config no decision can be reused safely
+		c.configErr = fmt.Errorf("failed to hash config: %w", err)
+		return
+	}
+	sum := sha256.Sum256(data)
+	c.configHash = sum[:]
+}
+
+// SetFeedbackWriter redirects feedback for this engine and the wrapped one
+func (c *CachingRuleEngine) SetFeedbackWriter(w io.Writer) {
+	c.feedback = w
+	if aware, ok := c.RuleEngine.(FeedbackAware); ok {
+		aware.SetFeedbackWriter(w)
+	}
+}
+
+// EvaluatePreToolUse returns a cached decision for a repeated tool input, or
+// evaluates the wrapped engine and caches its decision
+func (c *CachingRuleEngine) EvaluatePreToolUse(ctx context.Context, msg *PreToolUseMessage) (*HookResponse, error) {
+	sessionID := msg.SessionID
+	if sessionID == "" || c.store == nil || c.ttl <= 0 {
+		return c.RuleEngine.EvaluatePreToolUse(ctx, msg)
+	}
+
+	key, err := c.decisionKey(msg)
+	if err != nil {
+		return c.RuleEngine.EvaluatePreToolUse(ctx, msg)
+	}
+
+	now := c.now()
+	var cached CachedDecision
+	hit := false
+	err = c.store.Update(sessionID, func(state *SessionState) error {
+		c.pruneExpired(state, now)
+		decision, ok := state.Decisions[key]
+		if !ok {
+			return nil
+		}
+		decision.Hits++
+		cached, hit = *decision, true
+		return nil
+	})
+	if err != nil {
+		return c.RuleEngine.EvaluatePreToolUse(ctx, msg)
+	}
+	if hit {
+		return c.replay(msg, sessionID, &cached), nil
+	}
+
+	started := time.Now()
+	response, err := c.RuleEngine.EvaluatePreToolUse(ctx, msg)
+	if err != nil || response == nil || response.NoCache || response.Decision != "block" {
+		return response, err
+	}
+
+	// Only blocks are cached: an approve may have side effects such as formatting
+	// that must happen on every attempt
+	_ = c.store.Update(sessionID, func(state *SessionState) error {
+		decision := &CachedDecision{Response: *response, CreatedAt: now}
+		// The wrapped engine records the block it just made in the session history
+		if n := len(state.Blocks); n > 0 && !state.Blocks[n-1].At.Before(started) {
+			decision.File = state.Blocks[n-1].File
+			decision.Issues = state.Blocks[n-1].Issues
+		}
+		state.Decisions[key] = decision
+		return nil
+	})
+	return response, nil
+}
+
+// replay returns a cached block, counting it towards escalation like a fresh block
+func (c *CachingRuleEngine) replay(msg *PreToolUseMessage, sessionID string, cached *CachedDecision) *HookResponse {
+	response := cached.Response
+	if c.escalate {
+		response.Reason = fmt.Sprintf("Same error as before (identical %s attempted %d times): %s",
+			msg.ToolName, cached.Hits+1, cached.Response.Reason)
+		fmt.Fprintf(c.feedback, "\n> %s operation feedback:\n  - [gismo]: 🔁 This exact %s was already blocked. Fix the errors reported earlier instead of retrying it unchanged.\n",
+			msg.ToolName, msg.ToolName)
+	}
+	if tracker, ok := c.RuleEngine.(BlockTracker); ok && cached.File != "" {
+		return tracker.TrackBlock(sessionID, cached.File, cached.Issues, &response)
+	}
+	return &response
+}
+
+// pruneExpired drops cached decisions older than the TTL
+func (c *CachingRuleEngine) pruneExpired(state *SessionState, now time.Time) {
+	for key, decision := range state.Decisions {
+		if now.Sub(decision.CreatedAt) > c.ttl {
+			delete(state.Decisions, key)
+		}
+	}
+}
+
+// decisionKey hashes the tool name, tool input, current target file content,
+// the configuration and the project's rule overrides
+func (c *CachingRuleEngine) decisionKey(msg *PreToolUseMessage) (string, error) {
+	if c.configErr != nil {
+		return "", c.configErr
+	}
+	h := sha256.New()
+	h.Write(c.configHash)
+	h.Write([]byte{0})
+	h.Write([]byte(msg.ToolName))
+	h.Write([]byte{0})
+
+	// Hash input fields in sorted order so key order in the JSON doesn't matter
+	names := make([]string, 0, len(msg.ToolInput))
+	for name := range msg.ToolInput {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		var value interface{}
+		if err := json.Unmarshal(msg.ToolInput[name], &value); err != nil {
+			return "", err
+		}
+		canonical, err := json.Marshal(value)
+		if err != nil {
+			return "", err
+		}
+		h.Write([]byte(name))
+		h.Write([]byte{0})
+		h.Write(canonical)
+		h.Write([]byte{0})
+	}
+
+	// Include the target file so decisions depending on it are invalidated when it changes
+	if raw, ok := msg.ToolInput["file_path"]; ok {
+		var filePath string
+		if err := json.Unmarshal(raw, &filePath); err == nil && filePath != "" {
+			if content, err := c.fs.ReadFile(filePath); err == nil {
+				h.Write(content)
+			}
+		}
+	}
+
+	// Include the rule overrides, so a block is evaluated again once gismo allow relaxes it
+	root := c.root
+	if root == "" {
+		root, _ = os.Getwd()
+	}
+	if overrides, err := c.fs.ReadFile(RelaxationPath(root)); err == nil {
+		h.Write(overrides)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
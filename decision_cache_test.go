@@ -0,0 +1,240 @@
+package gismo
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+// countingRuleEngine blocks every PreToolUse and counts evaluations
+type countingRuleEngine struct {
+	BaseRuleEngine
+	calls int
+}
+
+func (c *countingRuleEngine) EvaluatePreToolUse(ctx context.Context, msg *PreToolUseMessage) (*HookResponse, error) {
+	c.calls++
+	return &HookResponse{Decision: "block", Reason: "Found 1 error(s) in main.go"}, nil
+}
+
+func newTestCachingEngine(t *testing.T) (*CachingRuleEngine, *countingRuleEngine, *linters.MemFileSystem, *bytes.Buffer) {
+	t.Helper()
+	inner := &countingRuleEngine{}
+	engine := NewCachingRuleEngine(inner, NewSessionStore(t.TempDir()))
+	fsys := linters.NewMemFileSystem()
+	engine.SetFileSystem(fsys)
+	var feedback bytes.Buffer
+	engine.feedback = &feedback
+	return engine, inner, fsys, &feedback
+}
+
+func writeMessage(sessionID string, input map[string]interface{}) *PreToolUseMessage {
+	return &PreToolUseMessage{
+		BaseHookMessage: BaseHookMessage{SessionID: sessionID, HookEventName: PreToolUseEvent},
+		ToolName:        "Write",
+		ToolInput:       testConvertToRawMessage(input),
+	}
+}
+
+func TestCachingRuleEngine_ReusesDecision(t *testing.T) {
+	engine, inner, _, feedback := newTestCachingEngine(t)
+	ctx := context.Background()
+	input := map[string]interface{}{"file_path": "/proj/main.go", "content": "package main"}
+
+	first, err := engine.EvaluatePreToolUse(ctx, writeMessage("s1", input))
+	if err != nil {
+		t.Fatalf("EvaluatePreToolUse() error = %v", err)
+	}
+	second, err := engine.EvaluatePreToolUse(ctx, writeMessage("s1", input))
+	if err != nil {
+		t.Fatalf("EvaluatePreToolUse() error = %v", err)
+	}
+
+	if inner.calls != 1 {
+		t.Errorf("inner engine called %d times, want 1", inner.calls)
+	}
+	if second.Decision != "block" {
+		t.Errorf("cached decision = %q, want block", second.Decision)
+	}
+	if !strings.HasPrefix(second.Reason, "Same error as before") || !strings.Contains(second.Reason, first.Reason) {
+		t.Errorf("expected escalated reason, got %q", second.Reason)
+	}
+	if !strings.Contains(feedback.String(), "already blocked") {
+		t.Errorf("expected escalation feedback, got %q", feedback.String())
+	}
+
+	// A different session evaluates again
+	if _, err := engine.EvaluatePreToolUse(ctx, writeMessage("s2", input)); err != nil {
+		t.Fatal(err)
+	}
+	if inner.calls != 2 {
+		t.Errorf("inner engine called %d times, want 2 after new session", inner.calls)
+	}
+}
+
+func TestCachingRuleEngine_KeyChanges(t *testing.T) {
+	engine, inner, fsys, _ := newTestCachingEngine(t)
+	ctx := context.Background()
+	input := map[string]interface{}{"file_path": "/proj/main.go", "old_string": "a", "new_string": "b"}
+
+	_, _ = engine.EvaluatePreToolUse(ctx, writeMessage("s1", input))
+
+	// Different input is evaluated
+	changed := map[string]interface{}{"file_path": "/proj/main.go", "old_string": "a", "new_string": "c"}
+	_, _ = engine.EvaluatePreToolUse(ctx, writeMessage("s1", changed))
+	if inner.calls != 2 {
+		t.Fatalf("inner engine called %d times, want 2", inner.calls)
+	}
+
+	// Same input against a changed target file is evaluated
+	_ = fsys.WriteFile("/proj/main.go", []byte("a"), 0644)
+	_, _ = engine.EvaluatePreToolUse(ctx, writeMessage("s1", input))
+	if inner.calls != 3 {
+		t.Errorf("inner engine called %d times, want 3 after target file changed", inner.calls)
+	}
+
+	// A different config is evaluated again
+	config := NewAppConfig()
+	engine.SetAppConfig(config)
+	_, _ = engine.EvaluatePreToolUse(ctx, writeMessage("s1", input))
+	action := EscalationNone
+	changedConfig := NewAppConfig()
+	changedConfig.Escalation = &EscalationConfig{Action: &action}
+	engine.SetAppConfig(changedConfig)
+	_, _ = engine.EvaluatePreToolUse(ctx, writeMessage("s1", input))
+	if inner.calls != 5 {
+		t.Errorf("inner engine called %d times, want 5 after the config changed", inner.calls)
+	}
+
+	// Overrides gismo allow writes at the project root are evaluated again,
+	// wherever the hook runs
+	engine.SetProjectRoot("/proj")
+	_ = fsys.WriteFile(RelaxationPath("/proj"), []byte(`{"overrides":[{"rules":["errcheck"]}]}`), 0644)
+	_, _ = engine.EvaluatePreToolUse(ctx, writeMessage("s1", input))
+	if inner.calls != 6 {
+		t.Errorf("inner engine called %d times, want 6 after the project's overrides changed", inner.calls)
+	}
+}
+
+func TestCachingRuleEngine_TTLAndDisabledCases(t *testing.T) {
+	engine, inner, _, _ := newTestCachingEngine(t)
+	ctx := context.Background()
+	input := map[string]interface{}{"file_path": "/proj/main.go", "content": "x"}
+
+	now := time.Now()
+	engine.now = func() time.Time { return now }
+	_, _ = engine.EvaluatePreToolUse(ctx, writeMessage("s1", input))
+
+	// Expired entries are re-evaluated
+	engine.now = func() time.Time { return now.Add(2 * DefaultDecisionCacheTTL) }
+	_, _ = engine.EvaluatePreToolUse(ctx, writeMessage("s1", input))
+	if inner.calls != 2 {
+		t.Errorf("inner engine called %d times, want 2 after TTL expiry", inner.calls)
+	}
+
+	// Without a session ID nothing is cached
+	_, _ = engine.EvaluatePreToolUse(ctx, writeMessage("", input))
+	_, _ = engine.EvaluatePreToolUse(ctx, writeMessage("", input))
+	if inner.calls != 4 {
+		t.Errorf("inner engine called %d times, want 4 without session ID", inner.calls)
+	}
+}
+
+func TestNewCachingRuleEngineWithConfig(t *testing.T) {
+	escalate := false
+	engine := NewCachingRuleEngineWithConfig(&countingRuleEngine{}, NewSessionStore(t.TempDir()), &DecisionCacheConfig{
+		TTL:      &Duration{Duration: 5 * time.Second},
+		Escalate: &escalate,
+	})
+	if engine.ttl != 5*time.Second || engine.escalate {
+		t.Errorf("config not applied: ttl=%v escalate=%v", engine.ttl, engine.escalate)
+	}
+
+	config := NewAppConfig()
+	if !config.IsDecisionCacheEnabled() {
+		t.Error("expected decision cache to be enabled by default")
+	}
+	disabled := false
+	config.Merge(&AppConfig{DecisionCache: &DecisionCacheConfig{Enabled: &disabled}})
+	if config.IsDecisionCacheEnabled() {
+		t.Error("expected decision cache to be disabled after merge")
+	}
+}
+
+// approvingRuleEngine approves every PreToolUse and counts evaluations
+type approvingRuleEngine struct {
+	BaseRuleEngine
+	calls int
+}
+
+func (a *approvingRuleEngine) EvaluatePreToolUse(ctx context.Context, msg *PreToolUseMessage) (*HookResponse, error) {
+	a.calls++
+	return &HookResponse{Decision: "approve"}, nil
+}
+
+func TestCachingRuleEngine_ApprovesNotCached(t *testing.T) {
+	inner := &approvingRuleEngine{}
+	engine := NewCachingRuleEngine(inner, NewSessionStore(t.TempDir()))
+	engine.SetFileSystem(linters.NewMemFileSystem())
+	ctx := context.Background()
+	input := map[string]interface{}{"file_path": "/proj/main.go", "content": "package main"}
+
+	for i := 0; i < 2; i++ {
+		if _, err := engine.EvaluatePreToolUse(ctx, writeMessage("s1", input)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if inner.calls != 2 {
+		t.Errorf("inner engine called %d times, want 2 for approvals", inner.calls)
+	}
+}
+
+// trackingRuleEngine blocks like countingRuleEngine and records the block in session state
+type trackingRuleEngine struct {
+	countingRuleEngine
+	store   *SessionStore
+	tracked int
+}
+
+func (t *trackingRuleEngine) EvaluatePreToolUse(ctx context.Context, msg *PreToolUseMessage) (*HookResponse, error) {
+	issues := []linters.Issue{{File: "/proj/main.go", Line: 1, Severity: "error", Rule: "syntax"}}
+	_ = t.store.Update(msg.SessionID, func(state *SessionState) error {
+		recordBlockHistory(state, "/proj/main.go", issues)
+		return nil
+	})
+	return t.countingRuleEngine.EvaluatePreToolUse(ctx, msg)
+}
+
+func (t *trackingRuleEngine) TrackBlock(sessionID, filePath string, errorIssues []linters.Issue, response *HookResponse) *HookResponse {
+	t.tracked++
+	if filePath != "/proj/main.go" || len(errorIssues) != 1 {
+		return response
+	}
+	return &HookResponse{Decision: "approve", Message: "escalated"}
+}
+
+func TestCachingRuleEngine_HitsTrackBlocks(t *testing.T) {
+	store := NewSessionStore(t.TempDir())
+	inner := &trackingRuleEngine{store: store}
+	engine := NewCachingRuleEngine(inner, store)
+	engine.SetFileSystem(linters.NewMemFileSystem())
+	engine.feedback = &bytes.Buffer{}
+	ctx := context.Background()
+	input := map[string]interface{}{"file_path": "/proj/main.go", "content": "package main"}
+
+	_, _ = engine.EvaluatePreToolUse(ctx, writeMessage("s1", input))
+	second, err := engine.EvaluatePreToolUse(ctx, writeMessage("s1", input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inner.calls != 1 || inner.tracked != 1 {
+		t.Errorf("calls = %d, tracked = %d, want 1 and 1", inner.calls, inner.tracked)
+	}
+	if second.Decision != "approve" {
+		t.Errorf("expected escalation policy to apply to cached block, got %+v", second)
+	}
+}
@@ -0,0 +1,379 @@
+package gismo
+
+import (
+	"bufio"
+	"encoding/json"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+// maxDependencyChanges caps the changes listed in one summary
+const maxDependencyChanges = 10
+
+// requirementName matches the package name and the rest of a PEP 508
+// requirement, skipping any extras
+var requirementName = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)\s*(?:\[[^\]]*\])?\s*(.*)$`)
+
+// pythonNameSeparators are the runs of characters PEP 503 normalizes to "-"
+var pythonNameSeparators = regexp.MustCompile(`[-_.]+`)
+
+// dependency is a dependency a manifest declares
+type dependency struct {
+	Name    string
+	Version string // as written, such as "^4.17.21", "v0.25.0" or ">=2.31"
+	Section string // the table or field listing it, "" for regular dependencies
+}
+
+// key identifies the dependency within its manifest
+func (d dependency) key() string {
+	return d.Section + "\x00" + strings.ToLower(d.Name)
+}
+
+// String formats the dependency as name@version, or with its specifier for
+// constraints such as ">=2.31", followed by a non-default section
+func (d dependency) String() string {
+	s := d.Name
+	switch {
+	case d.Version == "":
+	case strings.ContainsAny(d.Version[:1], "<>=!") || strings.HasPrefix(d.Version, "~="):
+		s += d.Version
+	default:
+		s += "@" + d.Version
+	}
+	if d.Section != "" {
+		s += " (" + d.Section + ")"
+	}
+	return s
+}
+
+// manifestDependencies returns the dependencies declared by a package.json,
+// go.mod, Cargo.toml, pyproject.toml or requirements file. ok is false for
+// other files and for content that doesn't parse.
+func manifestDependencies(filePath string, content []byte) (dependencies []dependency, ok bool) {
+	base := filepath.Base(filePath)
+	switch {
+	case base == "package.json":
+		return packageJSONDependencies(content)
+	case base == "go.mod":
+		return goModDependencies(content), true
+	case base == "Cargo.toml":
+		return cargoDependencies(content)
+	case base == "pyproject.toml":
+		return pyprojectDependencies(content)
+	case strings.HasSuffix(base, ".txt") &&
+		(strings.HasPrefix(base, "requirements") || filepath.Base(filepath.Dir(filePath)) == "requirements"):
+		return requirementsDependencies(strings.Split(string(content), "\n"), ""), true
+	}
+	return nil, false
+}
+
+// packageJSONDependencies reads the dependencies of a package.json
+func packageJSONDependencies(content []byte) ([]dependency, bool) {
+	var manifest map[string]json.RawMessage
+	if len(content) == 0 {
+		return nil, true
+	}
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return nil, false
+	}
+	var dependencies []dependency
+	for _, field := range []string{"dependencies", "devDependencies", "peerDependencies", "optionalDependencies"} {
+		var versions map[string]string
+		if err := json.Unmarshal(manifest[field], &versions); err != nil {
+			continue
+		}
+		section := field
+		if field == "dependencies" {
+			section = ""
+		}
+		for name, version := range versions {
+			dependencies = append(dependencies, dependency{Name: name, Version: version, Section: section})
+		}
+	}
+	return dependencies, true
+}
+
+// goModDependencies reads the require directives of a go.mod
+func goModDependencies(content []byte) []dependency {
+	var dependencies []dependency
+	inBlock := false
+	scanner := bufio.NewScanner(strings.NewReader(string(content)))
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "//")
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) == 0:
+			continue
+		case inBlock && fields[0] == ")":
+			inBlock = false
+			continue
+		case !inBlock && fields[0] == "require":
+			if len(fields) == 2 && fields[1] == "(" {
+				inBlock = true
+				continue
+			}
+			fields = fields[1:]
+		case !inBlock:
+			continue
+		}
+		if len(fields) == 2 {
+			dependencies = append(dependencies, dependency{Name: fields[0], Version: fields[1]})
+		}
+	}
+	return dependencies
+}
+
+// cargoDependencies reads the dependency tables of a Cargo.toml, including
+// target-specific and workspace dependencies
+func cargoDependencies(content []byte) ([]dependency, bool) {
+	var manifest map[string]interface{}
+	if _, err := toml.Decode(string(content), &manifest); err != nil {
+		return nil, false
+	}
+	var dependencies []dependency
+	addTables := func(tables map[string]interface{}, prefix string) {
+		for _, table := range []string{"dependencies", "dev-dependencies", "build-dependencies"} {
+			crates, _ := tables[table].(map[string]interface{})
+			section := prefix + table
+			if section == "dependencies" {
+				section = ""
+			}
+			for name, spec := range crates {
+				dependencies = append(dependencies, dependency{Name: name, Version: cargoVersion(spec), Section: section})
+			}
+		}
+	}
+	addTables(manifest, "")
+	if targets, ok := manifest["target"].(map[string]interface{}); ok {
+		for target, tables := range targets {
+			if tables, ok := tables.(map[string]interface{}); ok {
+				addTables(tables, "target."+target+".")
+			}
+		}
+	}
+	if workspace, ok := manifest["workspace"].(map[string]interface{}); ok {
+		crates, _ := workspace["dependencies"].(map[string]interface{})
+		for name, spec := range crates {
+			dependencies = append(dependencies, dependency{Name: name, Version: cargoVersion(spec), Section: "workspace.dependencies"})
+		}
+	}
+	return dependencies, true
+}
+
+// cargoVersion returns the version of a Cargo dependency given as a string or
+// a table, or where a table without a version takes the crate from
+func cargoVersion(spec interface{}) string {
+	switch spec := spec.(type) {
+	case string:
+		return spec
+	case map[string]interface{}:
+		for _, key := range []string{"version", "git", "path"} {
+			if value, ok := spec[key].(string); ok {
+				return value
+			}
+		}
+		if workspace, ok := spec["workspace"].(bool); ok && workspace {
+			return "workspace"
+		}
+	}
+	return ""
+}
+
+// pyprojectDependencies reads the dependencies, optional dependencies and
+// dependency groups of a pyproject.toml, and its poetry dependencies
+func pyprojectDependencies(content []byte) ([]dependency, bool) {
+	var manifest struct {
+		Project struct {
+			Dependencies         []string            `toml:"dependencies"`
+			OptionalDependencies map[string][]string `toml:"optional-dependencies"`
+		} `toml:"project"`
+		DependencyGroups map[string][]interface{} `toml:"dependency-groups"`
+		Tool             struct {
+			Poetry struct {
+				Dependencies map[string]interface{} `toml:"dependencies"`
+				Group        map[string]struct {
+					Dependencies map[string]interface{} `toml:"dependencies"`
+				} `toml:"group"`
+			} `toml:"poetry"`
+		} `toml:"tool"`
+	}
+	if _, err := toml.Decode(string(content), &manifest); err != nil {
+		return nil, false
+	}
+
+	dependencies := requirementsDependencies(manifest.Project.Dependencies, "")
+	for extra, requirements := range manifest.Project.OptionalDependencies {
+		dependencies = append(dependencies, requirementsDependencies(requirements, "optional-dependencies."+extra)...)
+	}
+	for group, entries := range manifest.DependencyGroups {
+		var requirements []string
+		for _, entry := range entries {
+			// {include-group = "..."} entries name other groups
+			if requirement, ok := entry.(string); ok {
+				requirements = append(requirements, requirement)
+			}
+		}
+		dependencies = append(dependencies, requirementsDependencies(requirements, "dependency-groups."+group)...)
+	}
+	addPoetry := func(specs map[string]interface{}, section string) {
+		for name, spec := range specs {
+			if name != "python" {
+				dependencies = append(dependencies, dependency{Name: name, Version: cargoVersion(spec), Section: section})
+			}
+		}
+	}
+	addPoetry(manifest.Tool.Poetry.Dependencies, "")
+	for group, table := range manifest.Tool.Poetry.Group {
+		addPoetry(table.Dependencies, "group."+group)
+	}
+	return dependencies, true
+}
+
+// requirementsDependencies reads PEP 508 requirements, one per line, leaving
+// out comments, options and bare URLs or paths
+func requirementsDependencies(lines []string, section string) []dependency {
+	var dependencies []dependency
+	for _, line := range lines {
+		if index := strings.Index(line, "#"); index >= 0 {
+			line = line[:index]
+		}
+		if index := strings.Index(line, " --"); index >= 0 {
+			line = line[:index]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "-") || strings.Contains(strings.SplitN(line, "@", 2)[0], "/") {
+			continue
+		}
+		match := requirementName.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		spec, _, _ := strings.Cut(match[2], ";")
+		spec = strings.Join(strings.Fields(spec), "")
+		// An exact pin reads as a version
+		if version, ok := strings.CutPrefix(spec, "=="); ok && !strings.ContainsAny(version, ",*") {
+			spec = version
+		}
+		name := strings.ToLower(pythonNameSeparators.ReplaceAllString(match[1], "-"))
+		dependencies = append(dependencies, dependency{Name: name, Version: spec, Section: section})
+	}
+	return dependencies
+}
+
+// dependencyChanges lists the dependencies added, changed and removed between
+// two versions of a manifest, such as "added lodash@4.17.21" and "bumped axios
+// 1.4 → 1.7", sorted by section and name
+func (e *LintingRuleEngine) dependencyChanges(before, after []dependency) []string {
+	old := make(map[string]dependency, len(before))
+	for _, dep := range before {
+		old[dep.key()] = dep
+	}
+	current := make(map[string]bool, len(after))
+
+	type change struct{ name, text string }
+	var changes []change
+	for _, dep := range after {
+		current[dep.key()] = true
+		previous, found := old[dep.key()]
+		switch {
+		case !found:
+			changes = append(changes, change{dep.key(), e.messages.Sprintf("deps.added", dep)})
+		case previous.Version != dep.Version:
+			key := "deps.changed"
+			from, fromOK := linters.ParseToolVersion(previous.Version)
+			to, toOK := linters.ParseToolVersion(dep.Version)
+			if fromOK && toOK {
+				switch from.Compare(to) {
+				case -1:
+					key = "deps.bumped"
+				case 1:
+					key = "deps.downgraded"
+				}
+			}
+			name := dependency{Name: dep.Name, Section: dep.Section}
+			changes = append(changes, change{dep.key(), e.messages.Sprintf(key, name, dependencyVersion(previous.Version), dependencyVersion(dep.Version))})
+		}
+	}
+	for _, dep := range before {
+		if !current[dep.key()] {
+			changes = append(changes, change{dep.key(), e.messages.Sprintf("deps.removed", dep)})
+		}
+	}
+
+	slices.SortFunc(changes, func(a, b change) int { return strings.Compare(a.name, b.name) })
+	texts := make([]string, 0, len(changes))
+	for _, change := range changes {
+		texts = append(texts, change.text)
+	}
+	return texts
+}
+
+// dependencyChangeNote summarizes how an edit to a dependency manifest changed
+// its dependencies, so the dependency impact of the edit is visible in the
+// transcript. It returns "" for other files, edits that leave the dependencies
+// alone, and edits whose original content isn't known.
+func (e *LintingRuleEngine) dependencyChangeNote(msg *PostToolUseMessage, filePath string, content []byte) string {
+	if !e.config.IsDependencySummaryEnabled() {
+		return ""
+	}
+	if _, ok := manifestDependencies(filePath, nil); !ok {
+		return ""
+	}
+	original, ok := originalContent(msg)
+	if !ok {
+		return ""
+	}
+	before, ok := manifestDependencies(filePath, []byte(original))
+	if !ok {
+		return ""
+	}
+	after, ok := manifestDependencies(filePath, content)
+	if !ok {
+		return ""
+	}
+
+	changes := e.dependencyChanges(before, after)
+	if len(changes) == 0 {
+		return ""
+	}
+	if len(changes) > maxDependencyChanges {
+		more := len(changes) - maxDependencyChanges
+		changes = append(changes[:maxDependencyChanges], e.messages.Sprintf("deps.more", more))
+	}
+	return e.messages.Sprintf("deps.summary", filePath, strings.Join(changes, ", "))
+}
+
+// originalContent returns the file's content before a Write, Edit or MultiEdit,
+// from the original file Claude Code reports in the tool response. A Write
+// creating the file had no content.
+func originalContent(msg *PostToolUseMessage) (string, bool) {
+	response := msg.ToolResponse
+	if len(response) == 0 {
+		response = msg.ToolOutput
+	}
+	var write writeToolResponse
+	if err := json.Unmarshal(response, &write); err != nil {
+		return "", false
+	}
+	if write.OriginalFile != nil {
+		return *write.OriginalFile, true
+	}
+	if write.Type == "create" {
+		return "", true
+	}
+	return "", false
+}
+
+// dependencyVersion is the version shown for a dependency, "any" if it has none
+func dependencyVersion(version string) string {
+	if version == "" {
+		return "any"
+	}
+	return version
+}
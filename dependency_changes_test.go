@@ -0,0 +1,154 @@
+package gismo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+func TestDependencyChangeNote(t *testing.T) {
+	tests := []struct {
+		name   string
+		file   string
+		before string
+		after  string
+		want   string
+	}{
+		{
+			name:   "package.json",
+			file:   "package.json",
+			before: `{"dependencies": {"axios": "^1.4.0", "leftpad": "1.0.0"}, "devDependencies": {"jest": "^29.0.0"}}`,
+			after:  `{"dependencies": {"axios": "^1.7.2", "lodash": "4.17.21"}, "devDependencies": {"jest": "^28.1.0"}}`,
+			want:   "bumped axios ^1.4.0 → ^1.7.2, removed leftpad@1.0.0, added lodash@4.17.21, downgraded jest (devDependencies) ^29.0.0 → ^28.1.0",
+		},
+		{
+			name:   "go.mod",
+			file:   "go.mod",
+			before: "module m\n\ngo 1.23\n\nrequire golang.org/x/text v0.24.0\n",
+			after:  "module m\n\ngo 1.23\n\nrequire (\n\tgolang.org/x/text v0.25.0\n\tgolang.org/x/mod v0.20.0 // indirect\n)\n",
+			want:   "added golang.org/x/mod@v0.20.0, bumped golang.org/x/text v0.24.0 → v0.25.0",
+		},
+		{
+			name:   "Cargo.toml",
+			file:   "Cargo.toml",
+			before: "[package]\nname = \"app\"\n\n[dependencies]\nserde = \"1.0.190\"\n",
+			after:  "[package]\nname = \"app\"\n\n[dependencies]\nserde = { version = \"1.0.210\", features = [\"derive\"] }\n\n[dev-dependencies]\ntempfile = \"3\"\n",
+			want:   "bumped serde 1.0.190 → 1.0.210, added tempfile@3 (dev-dependencies)",
+		},
+		{
+			name:   "requirements file",
+			file:   "requirements-dev.txt",
+			before: "Requests==2.31.0\nflask>=2.0\n",
+			after:  "requests==2.32.3\nflask\n",
+			want:   "changed flask >=2.0 → any, bumped requests 2.31.0 → 2.32.3",
+		},
+		{
+			name:   "pyproject.toml",
+			file:   "pyproject.toml",
+			before: "[project]\nname = \"app\"\ndependencies = [\"httpx>=0.27\"]\n",
+			after:  "[project]\nname = \"app\"\ndependencies = [\"httpx>=0.27\"]\n\n[dependency-groups]\ndev = [\"pytest>=8\"]\n",
+			want:   "added pytest>=8 (dependency-groups.dev)",
+		},
+		{
+			name:   "edit leaving dependencies alone",
+			file:   "package.json",
+			before: `{"name": "app", "dependencies": {"lodash": "4.17.21"}}`,
+			after:  `{"name": "app", "version": "1.0.1", "dependencies": {"lodash": "4.17.21"}}`,
+		},
+		{
+			name:   "manifest that doesn't parse",
+			file:   "package.json",
+			before: `{"dependencies": {}}`,
+			after:  `{"dependencies": {"lodash": `,
+		},
+		{
+			name:   "other file",
+			file:   "main.go",
+			before: "package main\n",
+			after:  "package main\n\nimport _ \"embed\"\n",
+		},
+	}
+
+	engine := NewLintingRuleEngine()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, _ := json.Marshal(map[string]string{"type": "update", "originalFile": tt.before})
+			msg := &PostToolUseMessage{ToolName: "Write", ToolResponse: response}
+			got := engine.dependencyChangeNote(msg, tt.file, []byte(tt.after))
+			if tt.want == "" {
+				if got != "" {
+					t.Errorf("dependencyChangeNote() = %q, want no note", got)
+				}
+				return
+			}
+			if !strings.HasSuffix(got, ": "+tt.want) {
+				t.Errorf("dependencyChangeNote() = %q, want it to list %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDependencyChangeNote_OriginalContent(t *testing.T) {
+	engine := NewLintingRuleEngine()
+	after := []byte(`{"dependencies": {"lodash": "4.17.21"}}`)
+
+	// A created file had no dependencies
+	created := &PostToolUseMessage{ToolName: "Write", ToolResponse: json.RawMessage(`{"type": "create"}`)}
+	if got := engine.dependencyChangeNote(created, "package.json", after); !strings.Contains(got, "added lodash@4.17.21") {
+		t.Errorf("created manifest note = %q", got)
+	}
+
+	// Without the original content there is nothing to compare
+	unknown := &PostToolUseMessage{ToolName: "Edit"}
+	if got := engine.dependencyChangeNote(unknown, "package.json", after); got != "" {
+		t.Errorf("note without original content = %q", got)
+	}
+
+	var lines []string
+	for i := range 12 {
+		lines = append(lines, "pkg"+string(rune('a'+i))+"==1.0")
+	}
+	requirements := &PostToolUseMessage{ToolName: "Write", ToolResponse: json.RawMessage(`{"type": "create"}`)}
+	if got := engine.dependencyChangeNote(requirements, "requirements.txt", []byte(strings.Join(lines, "\n"))); !strings.HasSuffix(got, "and 2 more") {
+		t.Errorf("long note = %q, want it capped", got)
+	}
+
+	disabled := false
+	engine.SetAppConfig(&AppConfig{Feedback: &FeedbackConfig{DependencySummary: &disabled}})
+	if got := engine.dependencyChangeNote(created, "package.json", after); got != "" {
+		t.Errorf("note with dependencySummary off = %q", got)
+	}
+}
+
+func TestLintingRuleEngine_DependencyChangeFeedback(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "package.json")
+	original := `{"dependencies": {"axios": "^1.4.0"}}`
+	edited := `{"dependencies": {"axios": "^1.7.0"}}`
+	if err := os.WriteFile(filePath, []byte(edited), 0600); err != nil {
+		t.Fatal(err)
+	}
+	engine := NewLintingRuleEngine()
+	engine.linters = []linters.Linter{&MockLinter{canHandle: true, result: &linters.LintResult{Success: true}}}
+	var feedback bytes.Buffer
+	engine.SetFeedbackWriter(&feedback)
+
+	response, _ := json.Marshal(map[string]string{"filePath": filePath, "originalFile": original})
+	msg := &PostToolUseMessage{
+		BaseHookMessage: BaseHookMessage{HookEventName: PostToolUseEvent},
+		ToolName:        "Edit",
+		ToolInput:       testConvertToRawMessage(map[string]interface{}{"file_path": filePath, "old_string": "^1.4.0", "new_string": "^1.7.0"}),
+		ToolResponse:    response,
+	}
+	if _, err := engine.EvaluatePostToolUse(context.Background(), msg); err != nil {
+		t.Fatal(err)
+	}
+	if got := feedback.String(); !strings.Contains(got, "Dependency changes in "+filePath+": bumped axios ^1.4.0 → ^1.7.0") || !strings.Contains(got, "Style clean") {
+		t.Errorf("feedback:\n%s", got)
+	}
+}
@@ -0,0 +1,197 @@
+package gismo
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jrossi/gismo/i18n"
+	"github.com/jrossi/gismo/linters"
+	"github.com/jrossi/gismo/linters/conflicts"
+	"github.com/jrossi/gismo/linters/secrets"
+	"github.com/jrossi/gismo/linters/security"
+	"github.com/jrossi/gismo/linters/unicodecheck"
+)
+
+// Escalation actions applied after repeated blocks
+const (
+	// EscalationNone keeps blocking with the normal message
+	EscalationNone = "none"
+	// EscalationDowngrade approves the operation with a strong note instead of blocking again
+	EscalationDowngrade = "downgrade"
+	// EscalationRemediate keeps blocking but adds detailed remediation, including
+	// formatter output Claude can write verbatim when it is available
+	EscalationRemediate = "remediate"
+)
+
+// undowngradableRules are the rules of the checks guarding against leaked
+// secrets, hidden characters, merge conflicts and risky security changes.
+// Retrying doesn't make them safe, so they are remediated instead of downgraded.
+var undowngradableRules = ruleNames(
+	conflicts.NewConflictLinter(),
+	secrets.NewSecretsLinter(),
+	security.NewSecurityLinter(),
+	unicodecheck.NewUnicodeLinter(),
+)
+
+// ruleNames returns the set of rules defined by describers
+func ruleNames(describers ...linters.RuleDescriber) map[string]bool {
+	names := make(map[string]bool)
+	for _, describer := range describers {
+		for rule := range describer.Rules() {
+			names[rule] = true
+		}
+	}
+	return names
+}
+
+// downgradable reports whether an operation blocked by errorIssues may be
+// approved by the downgrade action
+func downgradable(errorIssues []linters.Issue) bool {
+	for _, issue := range errorIssues {
+		if undowngradableRules[issue.Rule] {
+			return false
+		}
+	}
+	return true
+}
+
+// DefaultEscalationThreshold is the number of consecutive blocks before escalating
+const DefaultEscalationThreshold = 3
+
+// EscalationConfig controls what happens after repeated blocks on the same file and rule
+type EscalationConfig struct {
+	After  *int    `json:"after,omitempty"`  // consecutive blocks before escalating, default 3
+	Action *string `json:"action,omitempty"` // "none", "downgrade" or "remediate" (default)
+}
+
+// blockStreakKey identifies a file/rule pair in session state
+func blockStreakKey(filePath, rule string) string {
+	return filePath + "\x00" + rule
+}
+
+// recordBlockStreaks updates consecutive block counts for filePath and returns the
+// longest current streak among the blocking rules. Rules that no longer block are reset.
+func recordBlockStreaks(state *SessionState, filePath string, errorIssues []linters.Issue) int {
+	if state.BlockStreaks == nil {
+		state.BlockStreaks = make(map[string]int)
+	}
+
+	blocking := make(map[string]bool)
+	for _, issue := range errorIssues {
+		blocking[blockStreakKey(filePath, issue.Rule)] = true
+	}
+
+	prefix := filePath + "\x00"
+	for key := range state.BlockStreaks {
+		if strings.HasPrefix(key, prefix) && !blocking[key] {
+			delete(state.BlockStreaks, key)
+		}
+	}
+
+	longest := 0
+	for key := range blocking {
+		state.BlockStreaks[key]++
+		if state.BlockStreaks[key] > longest {
+			longest = state.BlockStreaks[key]
+		}
+	}
+	return longest
+}
+
+// trackBlock records a block decision in session state and applies the configured
+// escalation policy once the same file/rule has blocked too many times in a row
+func (e *LintingRuleEngine) trackBlock(sessionID, filePath string, errorIssues []linters.Issue, formatted []byte, response *HookResponse) *HookResponse {
+	if e.sessions == nil || sessionID == "" {
+		return response
+	}
+	var streak int
+	err := e.sessions.Update(sessionID, func(state *SessionState) error {
+		streak = recordBlockStreaks(state, filePath, errorIssues)
+		recordBlockHistory(state, filePath, errorIssues)
+		return nil
+	})
+	if err != nil {
+		return response
+	}
+
+	after, action := e.config.GetEscalation()
+	if after <= 0 || streak < after {
+		return response
+	}
+	if action == EscalationDowngrade && !downgradable(errorIssues) {
+		action = EscalationRemediate
+	}
+
+	switch action {
+	case EscalationDowngrade:
+		note := e.messages.Sprintf("escalation.downgrade", filePath, streak)
+		fmt.Fprintf(e.feedback, "  - [gismo]: %s\n", note)
+		return &HookResponse{Decision: "approve", Message: note}
+	case EscalationRemediate:
+		remediation := formatRemediation(e.messages, filePath, streak, errorIssues, formatted)
+		fmt.Fprintf(e.feedback, "\n%s\n", remediation)
+		response.Reason = response.Reason + "\n\n" + remediation
+		return response
+	default:
+		return response
+	}
+}
+
+// BlockTracker is implemented by rule engines that track repeated blocks for
+// escalation. Decision caches use it to record blocks they replay.
+type BlockTracker interface {
+	TrackBlock(sessionID, filePath string, errorIssues []linters.Issue, response *HookResponse) *HookResponse
+}
+
+// TrackBlock records a block replayed from the decision cache and applies the
+// escalation policy as if the block had been evaluated again
+func (e *LintingRuleEngine) TrackBlock(sessionID, filePath string, errorIssues []linters.Issue, response *HookResponse) *HookResponse {
+	return e.trackBlock(sessionID, filePath, errorIssues, nil, response)
+}
+
+// trackApprove clears block streaks for a file once an operation on it is approved
+func (e *LintingRuleEngine) trackApprove(sessionID, filePath string) {
+	if e.sessions == nil || sessionID == "" {
+		return
+	}
+	_ = e.sessions.Update(sessionID, func(state *SessionState) error {
+		if len(state.BlockStreaks) == 0 {
+			return errNoSessionChange
+		}
+		recordBlockStreaks(state, filePath, nil)
+		return nil
+	})
+}
+
+// formatRemediation builds a detailed remediation message for repeated blocks
+func formatRemediation(catalog *i18n.Catalog, filePath string, streak int, errorIssues []linters.Issue, formatted []byte) string {
+	var b strings.Builder
+	b.WriteString(catalog.Sprintf("escalation.remediation", streak, filePath) + "\n")
+
+	sorted := append([]linters.Issue(nil), errorIssues...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Line < sorted[j].Line })
+	for _, issue := range sorted {
+		location := filePath
+		if issue.Line > 0 {
+			location = fmt.Sprintf("%s:%d:%d", filePath, issue.Line, issue.Column)
+		}
+		if issue.Rule != "" {
+			b.WriteString(fmt.Sprintf("  - %s [%s] %s\n", location, issue.Rule, issue.Message))
+		} else {
+			b.WriteString(fmt.Sprintf("  - %s %s\n", location, issue.Message))
+		}
+	}
+
+	if len(formatted) > 0 {
+		fence := codeFence(formatted)
+		b.WriteString("\n" + catalog.Sprintf("escalation.formatted") + "\n" + fence + "\n")
+		b.Write(formatted)
+		if !strings.HasSuffix(string(formatted), "\n") {
+			b.WriteString("\n")
+		}
+		b.WriteString(fence)
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
@@ -0,0 +1,150 @@
+package gismo
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/jrossi/gismo/linters"
+	"github.com/jrossi/gismo/linters/secrets"
+)
+
+func TestRecordBlockStreaks(t *testing.T) {
+	state := &SessionState{}
+	syntax := []linters.Issue{{Severity: "error", Rule: "syntax"}}
+	vet := []linters.Issue{{Severity: "error", Rule: "vet"}}
+
+	if got := recordBlockStreaks(state, "a.go", syntax); got != 1 {
+		t.Errorf("first block streak = %d, want 1", got)
+	}
+	if got := recordBlockStreaks(state, "a.go", syntax); got != 2 {
+		t.Errorf("second block streak = %d, want 2", got)
+	}
+	// Other files are tracked independently
+	if got := recordBlockStreaks(state, "b.go", syntax); got != 1 {
+		t.Errorf("other file streak = %d, want 1", got)
+	}
+	// A different rule resets the previous one for this file
+	if got := recordBlockStreaks(state, "a.go", vet); got != 1 {
+		t.Errorf("new rule streak = %d, want 1", got)
+	}
+	if got := recordBlockStreaks(state, "a.go", syntax); got != 1 {
+		t.Errorf("syntax streak after interruption = %d, want 1", got)
+	}
+	// Clearing a file leaves other files alone
+	recordBlockStreaks(state, "a.go", nil)
+	if state.BlockStreaks[blockStreakKey("b.go", "syntax")] != 1 {
+		t.Errorf("clearing a.go should not reset b.go: %v", state.BlockStreaks)
+	}
+}
+
+func TestFormatRemediation(t *testing.T) {
+	issues := []linters.Issue{
+		{Line: 7, Column: 1, Severity: "error", Message: "second", Rule: "vet"},
+		{Line: 2, Column: 3, Severity: "error", Message: "first", Rule: "syntax"},
+	}
+	got := formatRemediation(nil, "main.go", 3, issues, []byte("package main"))
+
+	for _, want := range []string{
+		"blocked 3 times in a row on main.go",
+		"main.go:2:3 [syntax] first",
+		"main.go:7:1 [vet] second",
+		"```\npackage main\n```",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("formatRemediation() missing %q in:\n%s", want, got)
+		}
+	}
+	if strings.Index(got, "first") > strings.Index(got, "second") {
+		t.Error("expected issues ordered by line")
+	}
+}
+
+func TestLintingRuleEngine_Escalation(t *testing.T) {
+	tests := []struct {
+		name         string
+		action       string
+		wantDecision []string
+	}{
+		{"remediate keeps blocking", EscalationRemediate, []string{"block", "block", "block"}},
+		{"downgrade approves on threshold", EscalationDowngrade, []string{"block", "block", "approve"}},
+		{"none keeps blocking", EscalationNone, []string{"block", "block", "block"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsys := linters.NewMemFileSystem()
+			engine := NewLintingRuleEngineWithConfig(LintingConfig{
+				FileSystem:   fsys,
+				SessionStore: NewSessionStore(t.TempDir()),
+			})
+			engine.linters = []linters.Linter{&syntaxLinter{MockLinter{name: "syntax", canHandle: true}}}
+
+			after, action := 3, tt.action
+			config := NewAppConfig()
+			config.Escalation = &EscalationConfig{After: &after, Action: &action}
+			engine.SetAppConfig(config)
+
+			for i, want := range tt.wantDecision {
+				// Each attempt differs but keeps the same blocking rule
+				msg := writeMessage("session", map[string]interface{}{
+					"file_path": "/proj/file.txt",
+					"content":   strings.Repeat("x", i) + "BROKEN",
+				})
+				resp, err := engine.EvaluatePreToolUse(context.Background(), msg)
+				if err != nil {
+					t.Fatalf("EvaluatePreToolUse() error = %v", err)
+				}
+				if resp.Decision != want {
+					t.Errorf("attempt %d decision = %q, want %q", i+1, resp.Decision, want)
+				}
+				if i == 2 && tt.action == EscalationRemediate && !strings.Contains(resp.Reason, "REMEDIATION") {
+					t.Errorf("expected remediation in reason, got %q", resp.Reason)
+				}
+			}
+
+			// An approved write resets the streak
+			clean := writeMessage("session", map[string]interface{}{"file_path": "/proj/file.txt", "content": "fine"})
+			if resp, _ := engine.EvaluatePreToolUse(context.Background(), clean); resp.Decision != "approve" {
+				t.Fatalf("clean write decision = %q, want approve", resp.Decision)
+			}
+			state, _ := engine.sessions.Load("session")
+			if len(state.BlockStreaks) != 0 {
+				t.Errorf("expected streaks cleared after approval, got %v", state.BlockStreaks)
+			}
+			if len(state.Blocks) != 3 || state.Blocks[0].File != "/proj/file.txt" {
+				t.Errorf("expected 3 recorded blocks for gismo tune, got %+v", state.Blocks)
+			}
+		})
+	}
+}
+
+func TestLintingRuleEngine_EscalationNeverDowngradesSecrets(t *testing.T) {
+	engine := NewLintingRuleEngineWithConfig(LintingConfig{
+		FileSystem:   linters.NewMemFileSystem(),
+		SessionStore: NewSessionStore(t.TempDir()),
+	})
+	engine.linters = []linters.Linter{secrets.NewSecretsLinter()}
+
+	after, action := 2, EscalationDowngrade
+	config := NewAppConfig()
+	config.Escalation = &EscalationConfig{After: &after, Action: &action}
+	engine.SetAppConfig(config)
+
+	for i := 0; i < 3; i++ {
+		msg := writeMessage("session", map[string]interface{}{
+			"file_path": "/proj/config.env",
+			"content":   strings.Repeat("#\n", i) + "AWS_ACCESS_KEY_ID=AKIAZ7QX3M2P9LKJ4HWB\n",
+		})
+		resp, err := engine.EvaluatePreToolUse(context.Background(), msg)
+		if err != nil {
+			t.Fatalf("EvaluatePreToolUse() error = %v", err)
+		}
+		if resp.Decision != "block" {
+			t.Fatalf("attempt %d decision = %q, want block", i+1, resp.Decision)
+		}
+		if i > 0 && !strings.Contains(resp.Reason, "REMEDIATION") {
+			t.Errorf("attempt %d reason = %q, want remediation instead of a downgrade", i+1, resp.Reason)
+		}
+	}
+}
@@ -0,0 +1,215 @@
+package gismo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+// Lifecycle event types emitted to an event stream
+const (
+	// EventLintStart is emitted before linters run on a file
+	EventLintStart = "lint-start"
+	// EventIssue is emitted for each issue a linter reports
+	EventIssue = "issue"
+	// EventLintEnd is emitted after all linters for a file have finished
+	EventLintEnd = "lint-end"
+	// EventDecision is emitted with the PreToolUse decision returned to Claude
+	EventDecision = "decision"
+	// EventExec is emitted for each external command gismo runs when tracing executions
+	EventExec = "exec"
+)
+
+// Event is a single lifecycle event. Fields that don't apply to a type are omitted.
+type Event struct {
+	Type       string         `json:"type"`
+	Time       time.Time      `json:"time"`
+	SessionID  string         `json:"sessionId,omitempty"`
+	Hook       string         `json:"hook,omitempty"`
+	Tool       string         `json:"tool,omitempty"`
+	File       string         `json:"file,omitempty"`
+	Linters    []string       `json:"linters,omitempty"`
+	Linter     string         `json:"linter,omitempty"`
+	Issue      *linters.Issue `json:"issue,omitempty"`
+	Errors     int            `json:"errors,omitempty"`
+	Warnings   int            `json:"warnings,omitempty"`
+	DurationMs int64          `json:"durationMs,omitempty"`
+	Decision   string         `json:"decision,omitempty"`
+	Reason     string         `json:"reason,omitempty"`
+	Command    []string       `json:"command,omitempty"`
+	Dir        string         `json:"dir,omitempty"`
+	ExitCode   *int           `json:"exitCode,omitempty"`
+	Error      string         `json:"error,omitempty"`
+}
+
+// EventSink receives lifecycle events. Emit must never fail the hook.
+type EventSink interface {
+	Emit(event Event)
+}
+
+// JSONLEventSink writes events as one JSON object per line
+type JSONLEventSink struct {
+	mu     sync.Mutex
+	w      io.Writer
+	closer io.Closer
+}
+
+// NewJSONLEventSink creates a sink writing JSON lines to w
+func NewJSONLEventSink(w io.Writer) *JSONLEventSink {
+	return &JSONLEventSink{w: w}
+}
+
+// OpenEventStream opens an event stream target: "unix:<path>" connects to a
+// listening Unix socket (for example an IDE plugin or dashboard), anything else
+// is a file that events are appended to
+func OpenEventStream(target string) (*JSONLEventSink, error) {
+	if socket, ok := strings.CutPrefix(target, "unix:"); ok {
+		conn, err := net.DialTimeout("unix", socket, time.Second)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to event socket: %w", err)
+		}
+		return &JSONLEventSink{w: conn, closer: conn}, nil
+	}
+
+	file, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open event stream: %w", err)
+	}
+	return &JSONLEventSink{w: file, closer: file}, nil
+}
+
+// Emit writes the event as a single line. Write errors are ignored so a
+// disconnected subscriber never affects hook processing.
+func (s *JSONLEventSink) Emit(event Event) {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	data, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, _ = s.w.Write(append(data, '\n'))
+}
+
+// Close closes the underlying file or socket, if the sink opened one
+func (s *JSONLEventSink) Close() error {
+	if s.closer == nil {
+		return nil
+	}
+	return s.closer.Close()
+}
+
+// EventRuleEngine wraps a RuleEngine and emits a decision event for every
+// PreToolUse response, including decisions served from the decision cache
+type EventRuleEngine struct {
+	RuleEngine
+	sink EventSink
+}
+
+// NewEventRuleEngine wraps engine, emitting decisions to sink
+func NewEventRuleEngine(engine RuleEngine, sink EventSink) *EventRuleEngine {
+	return &EventRuleEngine{RuleEngine: engine, sink: sink}
+}
+
+// EvaluatePreToolUse evaluates the wrapped engine and emits its decision
+func (r *EventRuleEngine) EvaluatePreToolUse(ctx context.Context, msg *PreToolUseMessage) (*HookResponse, error) {
+	response, err := r.RuleEngine.EvaluatePreToolUse(ctx, msg)
+	if response != nil {
+		r.sink.Emit(Event{
+			Type:      EventDecision,
+			SessionID: msg.SessionID,
+			Hook:      string(PreToolUseEvent),
+			Tool:      msg.ToolName,
+			File:      toolInputFilePath(msg.ToolInput),
+			Decision:  response.Decision,
+			Reason:    response.Reason,
+		})
+	}
+	return response, err
+}
+
+// runLinters executes the applicable linters on a file, emitting lint-start,
+// issue and lint-end events when an event sink is configured
+func (e *LintingRuleEngine) runLinters(ctx context.Context, hook, sessionID, tool, filePath string, content []byte) []linters.LintTaskResult {
+	active := e.routedLinters(filePath, content)
+	if e.events == nil {
+		return e.executeLinters(ctx, active, filePath, content)
+	}
+
+	var names []string
+	for _, linter := range active {
+		if linter.CanHandle(filePath) {
+			names = append(names, linter.Name())
+		}
+	}
+	base := Event{SessionID: sessionID, Hook: hook, Tool: tool, File: filePath}
+
+	start := base
+	start.Type, start.Linters = EventLintStart, names
+	e.events.Emit(start)
+
+	started := time.Now()
+	results := e.executeLinters(ctx, active, filePath, content)
+
+	end := base
+	end.Type = EventLintEnd
+	for _, result := range results {
+		if result.Result == nil {
+			continue
+		}
+		for i := range result.Result.Issues {
+			issue := result.Result.Issues[i]
+			if issue.Severity == "error" {
+				end.Errors++
+			} else {
+				end.Warnings++
+			}
+			event := base
+			event.Type, event.Linter, event.Issue = EventIssue, result.LinterName, &issue
+			e.events.Emit(event)
+		}
+	}
+	end.DurationMs = time.Since(started).Milliseconds()
+	e.events.Emit(end)
+	return results
+}
+
+// toolInputFilePath returns the file_path tool input, if any
+func toolInputFilePath(input map[string]json.RawMessage) string {
+	var filePath string
+	if raw, ok := input["file_path"]; ok {
+		_ = json.Unmarshal(raw, &filePath)
+	}
+	return filePath
+}
+
+// TraceExec emits an exec event to sink for every external command linters run,
+// recording the command line, working directory, duration and exit code
+func TraceExec(sink EventSink) {
+	linters.SetExecObserver(func(record linters.ExecRecord) {
+		exitCode := record.ExitCode
+		event := Event{
+			Type:       EventExec,
+			Time:       record.Start,
+			Command:    record.Args,
+			Dir:        record.Dir,
+			DurationMs: record.Duration.Milliseconds(),
+			ExitCode:   &exitCode,
+		}
+		if record.Err != nil {
+			event.Error = record.Err.Error()
+		}
+		sink.Emit(event)
+	})
+}
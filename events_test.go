@@ -0,0 +1,162 @@
+package gismo
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+// decodeEvents parses a JSONL event stream
+func decodeEvents(t *testing.T, data []byte) []Event {
+	t.Helper()
+	var events []Event
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		var event Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatalf("invalid event line %q: %v", scanner.Text(), err)
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+func TestEventStream_PreToolUseLifecycle(t *testing.T) {
+	var out bytes.Buffer
+	sink := NewJSONLEventSink(&out)
+
+	engine := NewLintingRuleEngineWithConfig(LintingConfig{FileSystem: linters.NewMemFileSystem(), EventSink: sink})
+	engine.linters = []linters.Linter{
+		&syntaxLinter{MockLinter{name: "syntax", canHandle: true}},
+		&MockLinter{name: "other", canHandle: false},
+	}
+	hookEngine := NewEventRuleEngine(engine, sink)
+
+	msg := writeMessage("session", map[string]interface{}{"file_path": "/proj/file.txt", "content": "BROKEN BROKEN"})
+	resp, err := hookEngine.EvaluatePreToolUse(context.Background(), msg)
+	if err != nil || resp.Decision != "block" {
+		t.Fatalf("EvaluatePreToolUse() = %+v, %v; want block", resp, err)
+	}
+
+	events := decodeEvents(t, out.Bytes())
+	var types []string
+	for _, event := range events {
+		types = append(types, event.Type)
+		if event.SessionID != "session" || event.File != "/proj/file.txt" || event.Time.IsZero() {
+			t.Errorf("event missing context: %+v", event)
+		}
+	}
+	if got := strings.Join(types, ","); got != "lint-start,issue,issue,lint-end,decision" {
+		t.Fatalf("event types = %s", got)
+	}
+
+	if linters := events[0].Linters; len(linters) != 1 || linters[0] != "syntax" {
+		t.Errorf("lint-start linters = %v, want [syntax]", linters)
+	}
+	if events[1].Linter != "syntax" || events[1].Issue == nil || events[1].Issue.Message != "syntax error" {
+		t.Errorf("issue event = %+v", events[1])
+	}
+	if events[3].Errors != 2 || events[3].Hook != "PreToolUse" || events[3].Tool != "Write" {
+		t.Errorf("lint-end event = %+v", events[3])
+	}
+	if events[4].Decision != "block" || !strings.Contains(events[4].Reason, "2 error(s)") {
+		t.Errorf("decision event = %+v", events[4])
+	}
+}
+
+func TestOpenEventStream(t *testing.T) {
+	t.Run("file appends", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "events.jsonl")
+		for i := 0; i < 2; i++ {
+			sink, err := OpenEventStream(path)
+			if err != nil {
+				t.Fatalf("OpenEventStream() error = %v", err)
+			}
+			sink.Emit(Event{Type: EventDecision, Decision: "approve"})
+			if err := sink.Close(); err != nil {
+				t.Fatal(err)
+			}
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if events := decodeEvents(t, data); len(events) != 2 {
+			t.Errorf("got %d events, want 2 appended", len(events))
+		}
+	})
+
+	t.Run("unix socket", func(t *testing.T) {
+		// Socket paths are length limited, so avoid long test temp dirs
+		dir, err := os.MkdirTemp("", "gismo-ev")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+		socket := filepath.Join(dir, "s.sock")
+		listener, err := net.Listen("unix", socket)
+		if err != nil {
+			t.Skipf("unix sockets unavailable: %v", err)
+		}
+		defer listener.Close()
+
+		received := make(chan []byte, 1)
+		go func() {
+			conn, err := listener.Accept()
+			if err != nil {
+				received <- nil
+				return
+			}
+			defer conn.Close()
+			line, _ := bufio.NewReader(conn).ReadBytes('\n')
+			received <- line
+		}()
+
+		sink, err := OpenEventStream("unix:" + socket)
+		if err != nil {
+			t.Fatalf("OpenEventStream() error = %v", err)
+		}
+		sink.Emit(Event{Type: EventLintStart, File: "a.go"})
+		_ = sink.Close()
+
+		if events := decodeEvents(t, <-received); len(events) != 1 || events[0].File != "a.go" {
+			t.Errorf("socket received %+v", events)
+		}
+	})
+
+	t.Run("missing socket", func(t *testing.T) {
+		if _, err := OpenEventStream("unix:" + filepath.Join(t.TempDir(), "none.sock")); err == nil {
+			t.Error("expected error connecting to a missing socket")
+		}
+	})
+}
+
+func TestTraceExec(t *testing.T) {
+	var buf bytes.Buffer
+	TraceExec(NewJSONLEventSink(&buf))
+	defer linters.SetExecObserver(nil)
+
+	if err := linters.Run(exec.Command("sh", "-c", "exit 2")); err == nil {
+		t.Fatal("expected exit error")
+	}
+
+	var event Event
+	if err := json.Unmarshal(buf.Bytes(), &event); err != nil {
+		t.Fatalf("invalid exec event %q: %v", buf.String(), err)
+	}
+	if event.Type != EventExec || len(event.Command) != 3 || event.Command[2] != "exit 2" {
+		t.Errorf("unexpected exec event %+v", event)
+	}
+	if event.ExitCode == nil || *event.ExitCode != 2 || event.Error == "" || event.Dir == "" {
+		t.Errorf("expected exit code, error and dir in %s", buf.String())
+	}
+}
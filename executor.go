@@ -44,22 +44,47 @@ func (e *Executor) ExecuteWithExitCode(ctx context.Context) (int, error) {
 		return 1, err
 	}
 
+	return int(hookExitCode(e.handler, response)), nil
+}
+
+// ExecuteDataWithExitCode processes hook messages already read from stdin,
+// writing responses to w, and returns the appropriate exit code
+func (e *Executor) ExecuteDataWithExitCode(ctx context.Context, data []byte, w io.Writer) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, e.timeout)
+	defer cancel()
+
+	response, err := e.handler.ProcessData(ctx, data, w)
+	if err != nil {
+		return 1, err
+	}
+
+	return int(hookExitCode(e.handler, response)), nil
+}
+
+// hookExitCode returns the exit code for the response the handler produced
+func hookExitCode(handler *Handler, response *HookResponse) ExitCode {
+	// The JSON output carries the decision; Claude only reads it after exit code 0
+	if handler.jsonOutput {
+		return ExitSuccess
+	}
+
 	// Check if this is a PostToolUse hook by examining the handler's last processed message
-	if e.handler.IsPostToolUseHook() {
+	if handler.IsPostToolUseHook() {
 		// For PostToolUse hooks, always return exit code 2 to ensure output is visible
 		// This matches smart-lint.sh behavior
-		return int(ExitBlocking), nil
+		return ExitBlocking
 	}
 
 	// Determine exit code based on response
 	if response != nil && response.Decision == "block" {
-		return int(ExitBlocking), nil
+		return ExitBlocking
 	}
 
-	return int(ExitSuccess), nil
+	return ExitSuccess
 }
 
-// ExecuteWithReader processes hook messages from a custom reader
+// ExecuteWithReader processes one hook message, or several newline-delimited
+// ones, from a custom reader
 func (e *Executor) ExecuteWithReader(ctx context.Context, reader io.Reader) error {
 	// Read all data
 	data, err := io.ReadAll(reader)
@@ -67,32 +92,8 @@ func (e *Executor) ExecuteWithReader(ctx context.Context, reader io.Reader) erro
 		return fmt.Errorf("failed to read input: %w", err)
 	}
 
-	// Parse the message
-	msg, err := e.handler.parser.ParseHookMessage(data)
-	if err != nil {
-		return fmt.Errorf("failed to parse message: %w", err)
-	}
-
-	// Process the message
-	response, err := e.handler.ProcessMessage(ctx, msg)
-	if err != nil {
-		return fmt.Errorf("failed to process message: %w", err)
-	}
-
-	// Write response if needed
-	if response != nil {
-		responseData, err := e.handler.parser.MarshalHookResponse(response)
-		if err != nil {
-			return fmt.Errorf("failed to marshal response: %w", err)
-		}
-
-		_, err = os.Stdout.Write(responseData)
-		if err != nil {
-			return fmt.Errorf("failed to write response: %w", err)
-		}
-	}
-
-	return nil
+	_, err = e.handler.ProcessData(ctx, data, os.Stdout)
+	return err
 }
 
 // SetTimeout updates the execution timeout
@@ -100,6 +101,18 @@ func (e *Executor) SetTimeout(timeout time.Duration) {
 	e.timeout = timeout
 }
 
+// SetDebugOutput sets where notes about tolerated hook message oddities, such
+// as unknown fields, are written; nil disables them
+func (e *Executor) SetDebugOutput(w io.Writer) {
+	e.handler.parser.SetDebugOutput(w)
+}
+
+// SetJSONOutput writes Claude Code's JSON hook output to stdout instead of
+// using exit code 2. feedback must be where the rule engine writes its feedback.
+func (e *Executor) SetJSONOutput(feedback *bytes.Buffer) {
+	e.handler.SetJSONOutput(feedback)
+}
+
 // SetRuleEngine updates the rule engine
 func (e *Executor) SetRuleEngine(engine RuleEngine) {
 	e.handler.SetRuleEngine(engine)
@@ -169,6 +182,10 @@ func (r *HookRunner) RunHook(ctx context.Context, hookPath string, input []byte)
 }
 
 // ChainExecutor allows chaining multiple rule engines in sequence
+//
+// Deprecated: each executor reads the hook message from stdin, so only the first
+// one sees it. Combine the rule engines with NewCompositeRuleEngine and run them
+// with a single Executor instead.
 type ChainExecutor struct {
 	executors []*Executor
 }
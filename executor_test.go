@@ -217,7 +217,7 @@ func TestExecutor_ExecuteWithReader_Errors(t *testing.T) {
 		{
 			name:    "invalid_json",
 			reader:  strings.NewReader(`{"invalid": json}`),
-			wantErr: "failed to parse message",
+			wantErr: "failed to parse hook message",
 		},
 	}
 
@@ -0,0 +1,178 @@
+package gismo
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// FilePolicyConfig controls where the agent may create new files. A Write
+// creating a file that doesn't exist yet is blocked when a rule matching its
+// path doesn't allow it there.
+type FilePolicyConfig struct {
+	// Enabled checks new files against the rules, default false
+	Enabled *bool `json:"enabled,omitempty"`
+	// Rules are the project's directory conventions; every rule matching a new
+	// file must allow it
+	Rules []FileRule `json:"rules,omitempty"`
+}
+
+// FileRule restricts where new files matching a pattern may be created.
+// Patterns follow gitignore rules and match the path relative to the project
+// root, as in CODEOWNERS.
+type FileRule struct {
+	// Pattern selects the new files the rule applies to, e.g. "main.go"
+	Pattern string `json:"pattern"`
+	// Allow lists the paths where such files may be created, e.g. "/cmd/*/main.go"
+	Allow []string `json:"allow,omitempty"`
+	// NextTo also allows such files in a directory already holding a file that
+	// matches NextTo but not Pattern, e.g. "*.go" for tests next to their source
+	NextTo string `json:"nextTo,omitempty"`
+	// Reason tells the agent where such files belong
+	Reason string `json:"reason,omitempty"`
+}
+
+// IsFilePolicyEnabled checks if new files are checked against the file policy
+func (c *AppConfig) IsFilePolicyEnabled() bool {
+	if c == nil || c.FilePolicy == nil || c.FilePolicy.Enabled == nil {
+		return false
+	}
+	return *c.FilePolicy.Enabled
+}
+
+// merge merges other into the policy; a rule for a pattern already configured
+// replaces it, and other rules are added
+func (p *FilePolicyConfig) merge(other *FilePolicyConfig) {
+	if other.Enabled != nil {
+		p.Enabled = other.Enabled
+	}
+	// Rules are copied, as the merged config may share them with another config
+	p.Rules = slices.Clone(p.Rules)
+	for _, rule := range other.Rules {
+		if i := slices.IndexFunc(p.Rules, func(existing FileRule) bool { return existing.Pattern == rule.Pattern }); i >= 0 {
+			p.Rules[i] = rule
+		} else {
+			p.Rules = append(p.Rules, rule)
+		}
+	}
+}
+
+// CheckNewFile returns the first rule that doesn't allow creating a file at
+// relPath, a slash-separated path relative to the project root, or nil if it
+// may be created. siblings are the names of the files already in its
+// directory. An invalid pattern is an error, so a mistyped rule doesn't
+// silently allow what it was meant to block.
+func CheckNewFile(relPath string, siblings []string, rules []FileRule) (*FileRule, error) {
+	for i, rule := range rules {
+		re, err := codeownersPattern(rule.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid file pattern %q: %w", rule.Pattern, err)
+		}
+		if !re.MatchString(relPath) {
+			continue
+		}
+		allowed, err := fileAllowed(rule, re.MatchString, relPath, siblings)
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			return &rules[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// fileAllowed reports whether a rule matching relPath allows creating it.
+// matches is the rule's compiled pattern.
+func fileAllowed(rule FileRule, matches func(string) bool, relPath string, siblings []string) (bool, error) {
+	for _, pattern := range rule.Allow {
+		re, err := codeownersPattern(pattern)
+		if err != nil {
+			return false, fmt.Errorf("invalid allow pattern %q: %w", pattern, err)
+		}
+		if re.MatchString(relPath) {
+			return true, nil
+		}
+	}
+	if rule.NextTo == "" {
+		return false, nil
+	}
+	dir := path.Dir(relPath)
+	for _, name := range siblings {
+		sibling := path.Join(dir, name)
+		if sibling == relPath {
+			continue
+		}
+		if matched, err := path.Match(rule.NextTo, name); err != nil {
+			return false, fmt.Errorf("invalid nextTo pattern %q: %w", rule.NextTo, err)
+		} else if !matched {
+			continue
+		}
+		// Files the rule applies to don't count, so a test isn't placed next to another test
+		if !matches(sibling) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// evaluateNewFile checks a Write creating filePath against the file policy. It
+// returns nil when the file may be created.
+func (e *LintingRuleEngine) evaluateNewFile(msg *PreToolUseMessage, filePath string) *HookResponse {
+	if msg.ToolName != "Write" || !e.config.IsFilePolicyEnabled() {
+		return nil
+	}
+	if _, err := e.fs.Stat(filePath); !os.IsNotExist(err) {
+		return nil
+	}
+	rel, ok := e.projectRelPath(filePath)
+	if !ok {
+		return nil
+	}
+
+	var siblings []string
+	if entries, err := os.ReadDir(filepath.Dir(filePath)); err == nil {
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				siblings = append(siblings, entry.Name())
+			}
+		}
+	}
+
+	violation, err := CheckNewFile(rel, siblings, e.config.FilePolicy.Rules)
+	var reason string
+	switch {
+	case err != nil:
+		reason = e.messages.Sprintf("reason.file_policy_invalid", err)
+	case violation != nil:
+		why := violation.Reason
+		if why == "" {
+			why = e.describeFileRule(violation)
+		}
+		reason = e.messages.Sprintf("reason.file_denied", rel, why)
+	default:
+		return nil
+	}
+	fmt.Fprintf(e.feedback, "\n> %s:\n  - [gismo]: %s\n", e.messages.Sprintf("feedback.operation", msg.ToolName), reason)
+	// Not cached: creating a source file next to it can allow the same write later
+	return &HookResponse{Decision: "block", Reason: reason, NoCache: true}
+}
+
+// describeFileRule explains where a rule allows files, for rules without a reason
+func (e *LintingRuleEngine) describeFileRule(rule *FileRule) string {
+	places := make([]string, 0, len(rule.Allow)+1)
+	for _, pattern := range rule.Allow {
+		places = append(places, strconv.Quote(pattern))
+	}
+	if rule.NextTo != "" {
+		places = append(places, e.messages.Sprintf("reason.file_next_to", rule.NextTo))
+	}
+	if len(places) == 0 {
+		return e.messages.Sprintf("reason.file_forbidden", rule.Pattern)
+	}
+	return e.messages.Sprintf("reason.file_allowed", rule.Pattern, strings.Join(places, e.messages.Sprintf("reason.file_or")))
+}
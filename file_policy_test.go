@@ -0,0 +1,130 @@
+package gismo
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+// conventionRules are the directory conventions from the documentation
+var conventionRules = []FileRule{
+	{Pattern: "*_test.go", Allow: []string{"tests/"}, NextTo: "*.go"},
+	{Pattern: "main.go", Allow: []string{"/cmd/*/main.go"}, Reason: "commands live in cmd/<name>/main.go"},
+	{Pattern: "*.orig"},
+}
+
+func TestCheckNewFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		relPath  string
+		siblings []string
+		want     string
+	}{
+		{"test next to source", "pkg/parse/parse_test.go", []string{"parse.go"}, ""},
+		{"test only next to tests", "pkg/parse/more_test.go", []string{"parse_test.go"}, "*_test.go"},
+		{"test in an empty directory", "pkg/new/new_test.go", nil, "*_test.go"},
+		{"test under tests", "tests/integration/api_test.go", nil, ""},
+		{"command in cmd", "cmd/gismo/main.go", nil, ""},
+		{"command elsewhere", "tools/main.go", []string{"util.go"}, "main.go"},
+		{"command nested too deep", "cmd/gismo/sub/main.go", nil, "main.go"},
+		{"forbidden file", "config.json.orig", nil, "*.orig"},
+		{"no matching rule", "docs/guide.md", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violation, err := CheckNewFile(tt.relPath, tt.siblings, conventionRules)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := ""
+			if violation != nil {
+				got = violation.Pattern
+			}
+			if got != tt.want {
+				t.Errorf("CheckNewFile(%q) violates %q, want %q", tt.relPath, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := CheckNewFile("a.go", []string{"b.go"}, []FileRule{{Pattern: "*.go", NextTo: "["}}); err == nil {
+		t.Error("expected an error for an invalid nextTo pattern")
+	}
+}
+
+func TestAppConfig_FilePolicyMerge(t *testing.T) {
+	enabled := true
+	config := &AppConfig{FilePolicy: &FilePolicyConfig{Rules: conventionRules[:2]}}
+	config.Merge(&AppConfig{FilePolicy: &FilePolicyConfig{
+		Enabled: &enabled,
+		Rules:   []FileRule{{Pattern: "main.go", Allow: []string{"/cmd/"}}, conventionRules[2]},
+	}})
+
+	if !config.IsFilePolicyEnabled() {
+		t.Error("file policy should be enabled by the merged config")
+	}
+	if len(config.FilePolicy.Rules) != 3 || config.FilePolicy.Rules[1].Allow[0] != "/cmd/" {
+		t.Errorf("a rule for the same pattern should replace the earlier one: %+v", config.FilePolicy.Rules)
+	}
+}
+
+func TestLintingRuleEngine_FilePolicy(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "pkg"), 0750); err != nil {
+		t.Fatal(err)
+	}
+	existing := filepath.Join(root, "tools", "main.go")
+	if err := os.MkdirAll(filepath.Dir(existing), 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(existing, []byte("package main\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	engine := NewLintingRuleEngineWithConfig(LintingConfig{ProjectRoot: root})
+	engine.SetFeedbackWriter(io.Discard)
+	enabled := true
+	engine.SetAppConfig(&AppConfig{FilePolicy: &FilePolicyConfig{Enabled: &enabled, Rules: conventionRules}})
+	engine.linters = []linters.Linter{&MockLinter{canHandle: true, result: &linters.LintResult{Success: true}}}
+
+	write := func(path string) *HookResponse {
+		t.Helper()
+		response, err := engine.EvaluatePreToolUse(context.Background(), &PreToolUseMessage{
+			ToolName:  "Write",
+			ToolInput: testConvertToRawMessage(map[string]interface{}{"file_path": path, "content": "package pkg\n"}),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return response
+	}
+
+	// A test file can't be created before the source it tests
+	testFile := filepath.Join(root, "pkg", "pkg_test.go")
+	response := write(testFile)
+	if response.Decision != "block" || !response.NoCache ||
+		!strings.Contains(response.Reason, `files matching "*_test.go" may only be created at "tests/" or next to a file matching "*.go"`) {
+		t.Errorf("test without source: %+v", response)
+	}
+	if err := os.WriteFile(filepath.Join(root, "pkg", "pkg.go"), []byte("package pkg\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if response := write(testFile); response.Decision != "approve" {
+		t.Errorf("test next to source: %+v", response)
+	}
+
+	// The rule's reason is given when it has one
+	if response := write(filepath.Join(root, "main.go")); response.Decision != "block" || !strings.Contains(response.Reason, "commands live in cmd/<name>/main.go") {
+		t.Errorf("command outside cmd: %+v", response)
+	}
+
+	// Existing files can always be rewritten
+	if response := write(existing); response.Decision != "approve" {
+		t.Errorf("rewrite of an existing file: %+v", response)
+	}
+}
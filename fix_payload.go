@@ -0,0 +1,85 @@
+package gismo
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/jrossi/gismo/i18n"
+	"github.com/jrossi/gismo/linters"
+)
+
+// Fix payload modes for embedding known fixes in feedback
+const (
+	// FixPayloadNone leaves fixes out of feedback
+	FixPayloadNone = "none"
+	// FixPayloadContent embeds the complete corrected file content
+	FixPayloadContent = "content"
+	// FixPayloadPatch embeds a unified diff from the submitted to the corrected content,
+	// falling back to the complete content when the file is too large to diff
+	FixPayloadPatch = "patch"
+)
+
+// formatFixPayload renders the fix for filePath as a fenced block Claude can apply
+// verbatim. It returns an empty string when there is no fix or mode is "none".
+func formatFixPayload(catalog *i18n.Catalog, mode, filePath string, original, fixed []byte) string {
+	if len(fixed) == 0 || bytes.Equal(original, fixed) {
+		return ""
+	}
+
+	switch mode {
+	case FixPayloadPatch:
+		if diff, ok := linters.UnifiedDiff(filePath, original, fixed); ok {
+			if diff == "" {
+				return ""
+			}
+			fence := codeFence([]byte(diff))
+			return catalog.Sprintf("fix.patch", filePath) + "\n" + fence + "diff\n" + diff + fence
+		}
+		return formatFixContent(catalog, filePath, fixed)
+	case FixPayloadContent:
+		return formatFixContent(catalog, filePath, fixed)
+	default:
+		return ""
+	}
+}
+
+// formatFixContent renders the complete corrected content as a fenced block
+func formatFixContent(catalog *i18n.Catalog, filePath string, fixed []byte) string {
+	var b strings.Builder
+	lang := strings.TrimPrefix(filepath.Ext(filePath), ".")
+	fence := codeFence(fixed)
+	b.WriteString(catalog.Sprintf("fix.content", filePath) + "\n" + fence + lang + "\n")
+	b.Write(fixed)
+	if !bytes.HasSuffix(fixed, []byte("\n")) {
+		b.WriteString("\n")
+	}
+	b.WriteString(fence)
+	return b.String()
+}
+
+// codeFence returns a backtick fence longer than any run of backticks in
+// content, so fenced content such as Markdown with code blocks can't close it
+func codeFence(content []byte) string {
+	longest, run := 0, 0
+	for _, c := range content {
+		if c != '`' {
+			run = 0
+			continue
+		}
+		run++
+		longest = max(longest, run)
+	}
+	return strings.Repeat("`", max(3, longest+1))
+}
+
+// appendFixPayload adds the configured fix payload to a feedback message
+func (e *LintingRuleEngine) appendFixPayload(text, filePath string, original, fixed []byte) string {
+	payload := formatFixPayload(e.messages, e.config.GetFixPayload(), filePath, original, fixed)
+	if payload == "" {
+		return text
+	}
+	fmt.Fprintf(e.feedback, "\n%s\n", payload)
+	return text + "\n\n" + payload
+}
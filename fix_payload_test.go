@@ -0,0 +1,129 @@
+package gismo
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+func TestFormatFixPayload(t *testing.T) {
+	original := []byte("package main\nfunc  main() {}\n")
+	fixed := []byte("package main\n\nfunc main() {}\n")
+
+	tests := []struct {
+		name    string
+		mode    string
+		fixed   []byte
+		want    []string
+		wantNil bool
+	}{
+		{name: "none", mode: FixPayloadNone, fixed: fixed, wantNil: true},
+		{name: "no fix", mode: FixPayloadContent, fixed: nil, wantNil: true},
+		{name: "unchanged", mode: FixPayloadPatch, fixed: original, wantNil: true},
+		{
+			name:  "content",
+			mode:  FixPayloadContent,
+			fixed: fixed,
+			want:  []string{"write exactly this content to main.go", "```go\npackage main\n\nfunc main() {}\n```"},
+		},
+		{
+			name:  "patch",
+			mode:  FixPayloadPatch,
+			fixed: fixed,
+			want:  []string{"apply this patch to main.go", "```diff\n--- a/main.go", "-func  main() {}\n+\n+func main() {}\n"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := formatFixPayload(nil, tt.mode, "main.go", original, tt.fixed)
+			if tt.wantNil {
+				if got != "" {
+					t.Errorf("formatFixPayload() = %q, want empty", got)
+				}
+				return
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("formatFixPayload() missing %q in:\n%s", want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestFormatFixPayload_FencesMarkdownCodeBlocks(t *testing.T) {
+	original := []byte("# Usage\n```go\nfmt.Println(\"hi\")\n```\n")
+	fixed := []byte("# Usage\n\n```go\nfmt.Println(\"hi\")\n```\n")
+
+	for _, mode := range []string{FixPayloadContent, FixPayloadPatch} {
+		got := formatFixPayload(nil, mode, "README.md", original, fixed)
+		// The payload is fenced with four backticks, and the fence only closes
+		// at the end, after the content's own code block
+		if !strings.Contains(got, "\n````") || !strings.HasSuffix(got, "\n````") {
+			t.Errorf("%s payload isn't fenced with four backticks:\n%s", mode, got)
+		}
+		body := got[strings.Index(got, "````")+4 : len(got)-4]
+		if strings.Contains(body, "````") || !strings.Contains(body, "```go") {
+			t.Errorf("%s payload body = %q, want the content's own fences inside", mode, body)
+		}
+	}
+
+	if got := codeFence([]byte("a `b` ``c`` ````` d")); got != "``````" {
+		t.Errorf("codeFence() = %q, want six backticks", got)
+	}
+	if got := codeFence([]byte("plain")); got != "```" {
+		t.Errorf("codeFence() = %q, want three backticks", got)
+	}
+}
+
+func TestLintingRuleEngine_FixPayload(t *testing.T) {
+	tests := []struct {
+		name     string
+		severity string
+		mode     string
+		want     string
+	}{
+		{"block reason carries content", "error", FixPayloadContent, "```txt\nfixed\n```"},
+		{"approve message carries patch", "warning", FixPayloadPatch, "-broken\n+fixed\n"},
+		{"disabled by default", "warning", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := NewLintingRuleEngineWithConfig(LintingConfig{FileSystem: linters.NewMemFileSystem()})
+			engine.linters = []linters.Linter{&MockLinter{
+				name:      "fixer",
+				canHandle: true,
+				result: &linters.LintResult{
+					Issues:    []linters.Issue{{Line: 1, Column: 1, Severity: tt.severity, Message: "needs fixing", Rule: "fix"}},
+					Formatted: []byte("fixed\n"),
+				},
+			}}
+			config := NewAppConfig()
+			if tt.mode != "" {
+				config.Feedback = &FeedbackConfig{FixPayload: &tt.mode}
+			}
+			engine.SetAppConfig(config)
+
+			msg := writeMessage("", map[string]interface{}{"file_path": "/proj/file.txt", "content": "broken\n"})
+			resp, err := engine.EvaluatePreToolUse(context.Background(), msg)
+			if err != nil {
+				t.Fatalf("EvaluatePreToolUse() error = %v", err)
+			}
+
+			text := resp.Message + resp.Reason
+			if tt.want == "" {
+				if strings.Contains(text, "Fix available") {
+					t.Errorf("expected no fix payload, got %q", text)
+				}
+				return
+			}
+			if !strings.Contains(text, tt.want) {
+				t.Errorf("response missing %q in %q", tt.want, text)
+			}
+		})
+	}
+}
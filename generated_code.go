@@ -0,0 +1,122 @@
+package gismo
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+)
+
+// generatedHeader matches the "Code generated ... DO NOT EDIT." line that marks
+// a generated file, written as a comment in the file's language, such as
+// "// Code generated by protoc-gen-go. DO NOT EDIT." or "# Code generated by
+// sqlc. DO NOT EDIT."
+var generatedHeader = regexp.MustCompile(`(?m)^[\t ]*(?://+|#+|--|;+|/\*+|\*|<!--)[\t ]*Code generated .* DO NOT EDIT\.?[\t ]*(?:\*/|-->)?[\t ]*\r?$`)
+
+// GeneratedCodeConfig enforces the "Code generated ... DO NOT EDIT." convention
+// for generated files: new files in generated directories must carry the
+// header, and files carrying it may not be edited by hand
+type GeneratedCodeConfig struct {
+	// Enabled turns on the checks, default false
+	Enabled *bool `json:"enabled,omitempty"`
+	// Paths are where generated files live, e.g. "/internal/gen/" or "*.pb.go".
+	// Patterns follow gitignore rules and match the path relative to the
+	// project root, as in CODEOWNERS.
+	Paths []string `json:"paths,omitempty"`
+	// AllowEdits lists generated files that may be edited anyway, such as
+	// generated code the project has taken over
+	AllowEdits []string `json:"allowEdits,omitempty"`
+}
+
+// IsGeneratedCodeEnabled checks if the generated code convention is enforced
+func (c *AppConfig) IsGeneratedCodeEnabled() bool {
+	return c != nil && c.GeneratedCode != nil && c.GeneratedCode.Enabled != nil && *c.GeneratedCode.Enabled
+}
+
+// merge merges other into the config; patterns are added to those already
+// configured
+func (g *GeneratedCodeConfig) merge(other *GeneratedCodeConfig) {
+	if other.Enabled != nil {
+		g.Enabled = other.Enabled
+	}
+	// Patterns are copied, as the merged config may share them with another config
+	g.Paths = slices.Clone(g.Paths)
+	for _, pattern := range other.Paths {
+		if !slices.Contains(g.Paths, pattern) {
+			g.Paths = append(g.Paths, pattern)
+		}
+	}
+	g.AllowEdits = slices.Clone(g.AllowEdits)
+	for _, pattern := range other.AllowEdits {
+		if !slices.Contains(g.AllowEdits, pattern) {
+			g.AllowEdits = append(g.AllowEdits, pattern)
+		}
+	}
+}
+
+// hasGeneratedHeader reports whether content carries a "Code generated ... DO
+// NOT EDIT." line
+func hasGeneratedHeader(content []byte) bool {
+	return generatedHeader.Match(content)
+}
+
+// matchesPathPattern reports whether relPath, relative to the project root,
+// matches one of patterns. An invalid pattern is an error.
+func matchesPathPattern(relPath string, patterns []string) (bool, error) {
+	for _, pattern := range patterns {
+		re, err := codeownersPattern(pattern)
+		if err != nil {
+			return false, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+		if re.MatchString(relPath) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// evaluateGeneratedCode blocks a Write creating a file in a generated directory
+// without the generated header, and any edit of a file that carries the header
+// and isn't listed in allowEdits. It returns nil when the operation may go ahead.
+func (e *LintingRuleEngine) evaluateGeneratedCode(msg *PreToolUseMessage, filePath string) *HookResponse {
+	if !e.config.IsGeneratedCodeEnabled() {
+		return nil
+	}
+	rel, ok := e.projectRelPath(filePath)
+	if !ok {
+		return nil
+	}
+	config := e.config.GeneratedCode
+
+	var reason string
+	current, err := e.fs.ReadFile(filePath)
+	switch {
+	case os.IsNotExist(err):
+		input, err := ParseToolInput(msg.ToolName, msg.ToolInput)
+		write, isWrite := input.(WriteToolInput)
+		if err != nil || !isWrite {
+			return nil
+		}
+		generated, err := matchesPathPattern(rel, config.Paths)
+		switch {
+		case err != nil:
+			reason = e.messages.Sprintf("reason.generated_invalid", err)
+		case generated && !hasGeneratedHeader([]byte(write.Content)):
+			reason = e.messages.Sprintf("reason.generated_header", rel)
+		}
+	case err == nil && hasGeneratedHeader(current):
+		allowed, err := matchesPathPattern(rel, config.AllowEdits)
+		switch {
+		case err != nil:
+			reason = e.messages.Sprintf("reason.generated_invalid", err)
+		case !allowed:
+			reason = e.messages.Sprintf("reason.generated_edit", rel)
+		}
+	}
+	if reason == "" {
+		return nil
+	}
+	fmt.Fprintf(e.feedback, "\n> %s:\n  - [gismo]: %s\n", e.messages.Sprintf("feedback.operation", msg.ToolName), reason)
+	// Not cached: regenerating the file changes whether the same edit is allowed
+	return &HookResponse{Decision: "block", Reason: reason, NoCache: true}
+}
@@ -0,0 +1,118 @@
+package gismo
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+func TestHasGeneratedHeader(t *testing.T) {
+	tests := []struct {
+		content string
+		want    bool
+	}{
+		{"// Code generated by protoc-gen-go. DO NOT EDIT.\n\npackage pb\n", true},
+		{"// Copyright 2025\n\n// Code generated by mockgen. DO NOT EDIT.\npackage mocks\n", true},
+		{"# Code generated by sqlc. DO NOT EDIT.\nfrom x import y\n", true},
+		{"/* Code generated by ent, DO NOT EDIT. */\n", true},
+		{"-- Code generated by sqlc. DO NOT EDIT.\r\nSELECT 1;\r\n", true},
+		{"package main\n\n// Code generated elsewhere is copied here\n", false},
+		{"const s = \"// Code generated by x. DO NOT EDIT.\"\n", false},
+		{"package main\n", false},
+	}
+	for _, tt := range tests {
+		if got := hasGeneratedHeader([]byte(tt.content)); got != tt.want {
+			t.Errorf("hasGeneratedHeader(%q) = %v, want %v", tt.content, got, tt.want)
+		}
+	}
+}
+
+func TestAppConfig_GeneratedCodeMerge(t *testing.T) {
+	enabled := true
+	config := &AppConfig{GeneratedCode: &GeneratedCodeConfig{Paths: []string{"/gen/"}}}
+	config.Merge(&AppConfig{GeneratedCode: &GeneratedCodeConfig{
+		Enabled:    &enabled,
+		Paths:      []string{"/gen/", "*.pb.go"},
+		AllowEdits: []string{"/gen/custom.go"},
+	}})
+
+	if !config.IsGeneratedCodeEnabled() {
+		t.Error("generated code checks should be enabled by the merged config")
+	}
+	if len(config.GeneratedCode.Paths) != 2 || len(config.GeneratedCode.AllowEdits) != 1 {
+		t.Errorf("merged config = %+v", config.GeneratedCode)
+	}
+}
+
+func TestLintingRuleEngine_GeneratedCode(t *testing.T) {
+	root := t.TempDir()
+	header := "// Code generated by mockgen. DO NOT EDIT.\n\n"
+	for name, content := range map[string]string{
+		"gen/mocks.go":  header + "package gen\n",
+		"gen/custom.go": header + "package gen\n",
+		"pkg/pkg.go":    "package pkg\n",
+	} {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	engine := NewLintingRuleEngineWithConfig(LintingConfig{ProjectRoot: root})
+	engine.SetFeedbackWriter(io.Discard)
+	enabled := true
+	engine.SetAppConfig(&AppConfig{GeneratedCode: &GeneratedCodeConfig{
+		Enabled:    &enabled,
+		Paths:      []string{"/gen/"},
+		AllowEdits: []string{"/gen/custom.go"},
+	}})
+	engine.linters = []linters.Linter{&MockLinter{canHandle: true, result: &linters.LintResult{Success: true}}}
+
+	evaluate := func(tool, name string, input map[string]interface{}) *HookResponse {
+		t.Helper()
+		input["file_path"] = filepath.Join(root, name)
+		response, err := engine.EvaluatePreToolUse(context.Background(), &PreToolUseMessage{
+			ToolName:  tool,
+			ToolInput: testConvertToRawMessage(input),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return response
+	}
+
+	// New files in a generated directory need the header
+	response := evaluate("Write", "gen/client.go", map[string]interface{}{"content": "package gen\n"})
+	if response.Decision != "block" || !response.NoCache || !strings.Contains(response.Reason, "gen/client.go is in a generated code directory") {
+		t.Errorf("new generated file without header: %+v", response)
+	}
+	if response := evaluate("Write", "gen/client.go", map[string]interface{}{"content": header + "package gen\n"}); response.Decision != "approve" {
+		t.Errorf("new generated file with header: %+v", response)
+	}
+	if response := evaluate("Write", "pkg/new.go", map[string]interface{}{"content": "package pkg\n"}); response.Decision != "approve" {
+		t.Errorf("new file elsewhere: %+v", response)
+	}
+
+	// Files with the header may only be edited when allowed
+	response = evaluate("Edit", "gen/mocks.go", map[string]interface{}{"old_string": "package gen", "new_string": "package gen // edited"})
+	if response.Decision != "block" || !strings.Contains(response.Reason, "gen/mocks.go has a \"Code generated ... DO NOT EDIT.\" header") {
+		t.Errorf("edit of a generated file: %+v", response)
+	}
+	if response := evaluate("Write", "gen/mocks.go", map[string]interface{}{"content": header + "package gen\n\nvar x = 1\n"}); response.Decision != "block" {
+		t.Errorf("rewrite of a generated file: %+v", response)
+	}
+	if response := evaluate("Edit", "gen/custom.go", map[string]interface{}{"old_string": "package gen", "new_string": "package gen // edited"}); response.Decision != "approve" {
+		t.Errorf("edit of an allowed generated file: %+v", response)
+	}
+	if response := evaluate("Edit", "pkg/pkg.go", map[string]interface{}{"old_string": "package pkg", "new_string": "package pkg // edited"}); response.Decision != "approve" {
+		t.Errorf("edit of a hand-written file: %+v", response)
+	}
+}
@@ -3,19 +3,20 @@ module github.com/jrossi/gismo
 go 1.23.2
 
 require (
+	github.com/BurntSushi/toml v1.2.1
 	github.com/goccy/go-json v0.10.5
 	github.com/kaptinlin/jsonschema v0.4.6
 	github.com/teekennedy/goldmark-markdown v0.5.1
+	github.com/tetratelabs/wazero v1.9.0
 	github.com/yuin/goldmark v1.7.12
 	go.abhg.dev/goldmark/frontmatter v0.2.0
+	golang.org/x/text v0.25.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
-	github.com/BurntSushi/toml v1.2.1 // indirect
 	github.com/goccy/go-yaml v1.18.0 // indirect
 	github.com/gotnospirit/makeplural v0.0.0-20180622080156-a5f48d94d976 // indirect
 	github.com/gotnospirit/messageformat v0.0.0-20221001023931-dfe49f1eb092 // indirect
 	github.com/kaptinlin/go-i18n v0.1.4 // indirect
-	golang.org/x/text v0.25.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
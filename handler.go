@@ -1,12 +1,15 @@
 package gismo
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
 	"os"
 	"sync"
 	"time"
+
+	json "github.com/goccy/go-json"
 )
 
 // Handler processes hook messages and generates responses
@@ -16,6 +19,11 @@ type Handler struct {
 	ruleEngine      RuleEngine
 	mu              sync.RWMutex
 	lastMessageType HookEventName // Track the type of the last processed message
+
+	// jsonOutput writes Claude Code's JSON hook output instead of the responses,
+	// with the feedback collected in jsonFeedback
+	jsonOutput   bool
+	jsonFeedback *bytes.Buffer
 }
 
 // NewHandler creates a new hook handler
@@ -33,40 +41,126 @@ func (h *Handler) ProcessInput(ctx context.Context) error {
 	return err
 }
 
-// ProcessInputWithResponse reads hook message from stdin, processes it, and returns the response
+// ProcessInputWithResponse reads hook messages from stdin, processes them, and returns the response
 func (h *Handler) ProcessInputWithResponse(ctx context.Context) (*HookResponse, error) {
 	// Read from stdin
 	data, err := io.ReadAll(os.Stdin)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read stdin: %w", err)
 	}
+	return h.ProcessData(ctx, data, os.Stdout)
+}
 
-	// Parse the message
-	msg, err := h.parser.ParseHookMessage(data)
+// ProcessData processes the hook messages in data and writes the responses to w.
+// data holds a single message, or several newline-delimited messages as in
+// replay files; a batch gets exactly one response line per message, with {} for
+// messages that produce no response. The returned response is the first
+// blocking one in the batch, otherwise the last.
+func (h *Handler) ProcessData(ctx context.Context, data []byte, w io.Writer) (*HookResponse, error) {
+	messages, err := splitHookMessages(data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse hook message: %w", err)
 	}
+	batch := len(messages) > 1
 
-	// Process the message
-	response, err := h.ProcessMessage(ctx, msg)
-	if err != nil {
-		return nil, fmt.Errorf("failed to process message: %w", err)
-	}
-
-	// Write response if needed
-	if response != nil {
-		responseData, err := h.parser.MarshalHookResponse(response)
+	var result *HookResponse
+	for i, data := range messages {
+		// Parse the message
+		msg, err := h.parser.ParseHookMessage(data)
 		if err != nil {
-			return nil, fmt.Errorf("failed to marshal response: %w", err)
+			return result, fmt.Errorf("failed to parse hook message%s: %w", batchPosition(batch, i), err)
 		}
 
-		_, err = os.Stdout.Write(responseData)
+		// Process the message
+		response, err := h.ProcessMessage(ctx, msg)
 		if err != nil {
-			return nil, fmt.Errorf("failed to write response: %w", err)
+			return result, fmt.Errorf("failed to process message%s: %w", batchPosition(batch, i), err)
+		}
+
+		if h.jsonOutput {
+			if err := h.writeHookOutput(w, msg.EventName(), response, batch); err != nil {
+				return result, err
+			}
+		} else if response != nil || batch {
+			// Write response if needed
+			written := response
+			if written == nil {
+				written = &HookResponse{}
+			}
+			responseData, err := h.parser.MarshalHookResponse(written)
+			if err != nil {
+				return result, fmt.Errorf("failed to marshal response: %w", err)
+			}
+			if _, err := w.Write(responseData); err != nil {
+				return result, fmt.Errorf("failed to write response: %w", err)
+			}
+		}
+
+		if result == nil || result.Decision != "block" {
+			if response != nil {
+				result = response
+			}
 		}
 	}
 
-	return response, nil
+	return result, nil
+}
+
+// writeHookOutput writes the JSON hook output for a response, taking the
+// feedback collected while the message was processed
+func (h *Handler) writeHookOutput(w io.Writer, event HookEventName, response *HookResponse, batch bool) error {
+	var feedback string
+	if h.jsonFeedback != nil {
+		feedback = h.jsonFeedback.String()
+		h.jsonFeedback.Reset()
+	}
+	if response == nil && feedback == "" && !batch {
+		return nil
+	}
+	data, err := json.Marshal(NewHookOutput(event, response, feedback))
+	if err != nil {
+		return fmt.Errorf("failed to marshal hook output: %w", err)
+	}
+	if _, err := w.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write response: %w", err)
+	}
+	return nil
+}
+
+// SetJSONOutput makes the handler write Claude Code's JSON hook output, with
+// the feedback the rule engine writes to feedback, which may be nil
+func (h *Handler) SetJSONOutput(feedback *bytes.Buffer) {
+	h.jsonOutput = true
+	h.jsonFeedback = feedback
+}
+
+// splitHookMessages splits data into its top-level JSON values. Data that isn't
+// a sequence of JSON values is returned whole, so the parser reports the error.
+func splitHookMessages(data []byte) ([]json.RawMessage, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	var messages []json.RawMessage
+	for decoder.More() {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			if len(messages) == 0 {
+				return []json.RawMessage{data}, nil
+			}
+			return nil, fmt.Errorf("message %d: %w", len(messages)+1, err)
+		}
+		messages = append(messages, raw)
+	}
+	if len(messages) == 0 {
+		return []json.RawMessage{data}, nil
+	}
+	return messages, nil
+}
+
+// batchPosition names a message's position in a batch for error messages
+func batchPosition(batch bool, i int) string {
+	if !batch {
+		return ""
+	}
+	return fmt.Sprintf(" %d", i+1)
 }
 
 // ProcessMessage handles a specific hook message
@@ -1,7 +1,9 @@
 package gismo
 
 import (
+	"bytes"
 	"context"
+	"strings"
 	"testing"
 	"time"
 )
@@ -230,3 +232,67 @@ func TestHandler_SetRuleEngine(t *testing.T) {
 		t.Errorf("expected block decision, got %v", resp2.Decision)
 	}
 }
+
+func TestHandler_ProcessData_Batch(t *testing.T) {
+	engine := &MockRuleEngine{
+		preToolUseResponse: &HookResponse{Decision: "block", Reason: "lint errors"},
+		stopResponse:       &HookResponse{Decision: "approve"},
+	}
+	handler := NewHandler(engine)
+
+	input := `{"hook_event_name":"Notification","session_id":"s","message":"hi"}
+{"hook_event_name":"PreToolUse","session_id":"s","tool_name":"Write"}
+
+{"hook_event_name":"Stop","session_id":"s"}
+`
+	var out bytes.Buffer
+	response, err := handler.ProcessData(context.Background(), []byte(input), &out)
+	if err != nil {
+		t.Fatalf("ProcessData() error = %v", err)
+	}
+
+	want := "{}\n" + `{"decision":"block","reason":"lint errors"}` + "\n" + `{"decision":"approve"}` + "\n"
+	if out.String() != want {
+		t.Errorf("responses =\n%s\nwant one line per message:\n%s", out.String(), want)
+	}
+	if response == nil || response.Decision != "block" {
+		t.Errorf("response = %+v, want the blocking response", response)
+	}
+	if !engine.notificationCalled || !engine.preToolUseCalled || !engine.stopCalled {
+		t.Error("expected every message in the batch to be processed")
+	}
+}
+
+func TestHandler_ProcessData_Single(t *testing.T) {
+	handler := NewHandler(&MockRuleEngine{})
+
+	var out bytes.Buffer
+	response, err := handler.ProcessData(context.Background(), []byte(`{"hook_event_name":"Stop","session_id":"s"}`), &out)
+	if err != nil {
+		t.Fatalf("ProcessData() error = %v", err)
+	}
+	if response != nil || out.Len() != 0 {
+		t.Errorf("a single message without a response writes nothing, got %q", out.String())
+	}
+}
+
+func TestHandler_ProcessData_BatchError(t *testing.T) {
+	handler := NewHandler(&MockRuleEngine{})
+
+	input := `{"hook_event_name":"Stop","session_id":"s"}
+{"hook_event_name":"PreToolUse","session_id":"s"}
+`
+	var out bytes.Buffer
+	_, err := handler.ProcessData(context.Background(), []byte(input), &out)
+	if err == nil || !strings.Contains(err.Error(), "failed to parse hook message 2") {
+		t.Fatalf("ProcessData() error = %v, want the failing message's position", err)
+	}
+	if out.String() != "{}\n" {
+		t.Errorf("responses before the error = %q", out.String())
+	}
+
+	_, err = handler.ProcessData(context.Background(), []byte(`{"hook_event_name":"Stop"} {"hook_`), &out)
+	if err == nil || !strings.Contains(err.Error(), "message 2") {
+		t.Errorf("ProcessData() error = %v, want truncated second message reported", err)
+	}
+}
@@ -0,0 +1,97 @@
+package gismo
+
+import "strings"
+
+// Output modes select how hook responses reach Claude Code
+const (
+	// OutputModeExitCode reports blocks with exit code 2 and the feedback on
+	// stderr, and always exits with 2 after PostToolUse so the feedback is shown
+	OutputModeExitCode = "exit-code"
+	// OutputModeJSON writes Claude Code's JSON hook output to stdout and exits
+	// with 0; the feedback is part of the JSON, as Claude ignores stderr then
+	OutputModeJSON = "json"
+)
+
+// GetOutputMode returns the configured output mode, OutputModeExitCode by default
+func (c *AppConfig) GetOutputMode() string {
+	if c == nil || c.OutputMode == nil {
+		return OutputModeExitCode
+	}
+	return *c.OutputMode
+}
+
+// HookOutput is the JSON a hook writes to stdout for Claude Code to act on
+type HookOutput struct {
+	// Continue false stops Claude after the hook, with StopReason shown to the user
+	Continue   *bool  `json:"continue,omitempty"`
+	StopReason string `json:"stopReason,omitempty"`
+	// SuppressOutput hides the hook's stdout from the transcript
+	SuppressOutput *bool `json:"suppressOutput,omitempty"`
+	// SystemMessage is shown to the user
+	SystemMessage string `json:"systemMessage,omitempty"`
+	// Decision "block" with Reason feeds the reason back to Claude after
+	// PostToolUse, or keeps it working on Stop
+	Decision           string              `json:"decision,omitempty"`
+	Reason             string              `json:"reason,omitempty"`
+	HookSpecificOutput *HookSpecificOutput `json:"hookSpecificOutput,omitempty"`
+}
+
+// HookSpecificOutput holds the fields specific to the hook event
+type HookSpecificOutput struct {
+	HookEventName HookEventName `json:"hookEventName"`
+	// PermissionDecision is "deny" for PreToolUse blocks, with the reason shown
+	// to Claude. Approved operations are left to Claude's permission checks.
+	PermissionDecision       string `json:"permissionDecision,omitempty"`
+	PermissionDecisionReason string `json:"permissionDecisionReason,omitempty"`
+	// AdditionalContext is added to Claude's context after PostToolUse
+	AdditionalContext string `json:"additionalContext,omitempty"`
+}
+
+// NewHookOutput converts a response to the JSON output for event. feedback is
+// the detailed output the rule engine would write to stderr; it is passed to
+// Claude with the reason for a block, and as context after PostToolUse.
+func NewHookOutput(event HookEventName, response *HookResponse, feedback string) *HookOutput {
+	if response == nil {
+		response = &HookResponse{}
+	}
+	output := &HookOutput{
+		Continue:       response.Continue,
+		StopReason:     response.StopReason,
+		SuppressOutput: response.SuppressOutput,
+	}
+	blocked := response.Decision == "block"
+	feedback = strings.TrimSpace(feedback)
+	details := joinFeedback(response.Reason, feedback)
+
+	switch event {
+	case PreToolUseEvent:
+		if blocked {
+			output.HookSpecificOutput = &HookSpecificOutput{
+				HookEventName:            event,
+				PermissionDecision:       "deny",
+				PermissionDecisionReason: details,
+			}
+		} else {
+			output.SystemMessage = response.Message
+		}
+	case PostToolUseEvent:
+		if blocked {
+			output.Decision = "block"
+			output.Reason = joinFeedback(details, response.Message)
+		} else if context := joinFeedback(response.Message, feedback); context != "" {
+			output.HookSpecificOutput = &HookSpecificOutput{
+				HookEventName:     event,
+				AdditionalContext: context,
+			}
+		}
+	case StopEvent, SubagentStopEvent:
+		if blocked {
+			output.Decision = "block"
+			output.Reason = details
+		}
+		output.SystemMessage = response.Message
+	default:
+		output.SystemMessage = joinFeedback(response.Message, response.Reason)
+	}
+	return output
+}
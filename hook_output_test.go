@@ -0,0 +1,113 @@
+package gismo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestNewHookOutput(t *testing.T) {
+	stop := false
+	tests := []struct {
+		name     string
+		event    HookEventName
+		response *HookResponse
+		feedback string
+		want     string
+	}{
+		{
+			name:     "pre tool use block",
+			event:    PreToolUseEvent,
+			response: &HookResponse{Decision: "block", Reason: "command denied"},
+			feedback: "details\n",
+			want:     `{"hookSpecificOutput":{"hookEventName":"PreToolUse","permissionDecision":"deny","permissionDecisionReason":"command denied\n\ndetails"}}`,
+		},
+		{
+			name:     "pre tool use approve leaves permission to Claude",
+			event:    PreToolUseEvent,
+			response: &HookResponse{Decision: "approve"},
+			want:     `{}`,
+		},
+		{
+			name:     "post tool use block",
+			event:    PostToolUseEvent,
+			response: &HookResponse{Decision: "block", Reason: "lint errors"},
+			feedback: "main.go:1: unused variable\n",
+			want:     `{"decision":"block","reason":"lint errors\n\nmain.go:1: unused variable"}`,
+		},
+		{
+			name:     "post tool use feedback as context",
+			event:    PostToolUseEvent,
+			response: &HookResponse{Message: "warnings found"},
+			feedback: "main.go:1: line too long\n",
+			want:     `{"hookSpecificOutput":{"hookEventName":"PostToolUse","additionalContext":"warnings found\n\nmain.go:1: line too long"}}`,
+		},
+		{
+			name:     "stop block",
+			event:    StopEvent,
+			response: &HookResponse{Decision: "block", Reason: "tests fail"},
+			want:     `{"decision":"block","reason":"tests fail"}`,
+		},
+		{
+			name:     "continue and suppress output pass through",
+			event:    PostToolUseEvent,
+			response: &HookResponse{Continue: &stop, StopReason: "halted", SuppressOutput: &stop},
+			want:     `{"continue":false,"stopReason":"halted","suppressOutput":false}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(NewHookOutput(tt.event, tt.response, tt.feedback))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("got  %s\nwant %s", data, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecutor_JSONOutput(t *testing.T) {
+	var feedback bytes.Buffer
+	engine := &MockRuleEngine{postToolUseResponse: &HookResponse{Decision: "block", Reason: "lint errors"}}
+	executor := NewExecutor(engine)
+	executor.SetJSONOutput(&feedback)
+	feedback.WriteString("main.go:1: unused variable\n")
+
+	var output bytes.Buffer
+	input := `{"hook_event_name":"PostToolUse","session_id":"s","tool_name":"Write"}`
+	code, err := executor.ExecuteDataWithExitCode(context.Background(), []byte(input), &output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The decision is in the output, which Claude only reads after exit code 0
+	if code != int(ExitSuccess) {
+		t.Errorf("exit code = %d, want 0", code)
+	}
+	var hookOutput HookOutput
+	if err := json.Unmarshal(output.Bytes(), &hookOutput); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, output.String())
+	}
+	if hookOutput.Decision != "block" || !strings.Contains(hookOutput.Reason, "unused variable") {
+		t.Errorf("output = %+v, want a block with the feedback", hookOutput)
+	}
+	if feedback.Len() != 0 {
+		t.Error("feedback not consumed")
+	}
+}
+
+func TestAppConfig_OutputMode(t *testing.T) {
+	var config *AppConfig
+	if got := config.GetOutputMode(); got != OutputModeExitCode {
+		t.Errorf("nil config output mode = %q", got)
+	}
+	mode := OutputModeJSON
+	config = &AppConfig{}
+	config.Merge(&AppConfig{OutputMode: &mode})
+	if got := config.GetOutputMode(); got != OutputModeJSON {
+		t.Errorf("merged output mode = %q, want json", got)
+	}
+}
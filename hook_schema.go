@@ -0,0 +1,182 @@
+package gismo
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	json "github.com/goccy/go-json"
+)
+
+// hookField describes one top-level field of a hook message
+type hookField struct {
+	Name        string
+	Type        string // JSON Schema type, or "" for any value
+	Required    bool
+	Description string
+}
+
+// baseHookFields are the fields every hook message carries
+var baseHookFields = []hookField{
+	{Name: "hook_event_name", Type: "string", Required: true, Description: "Hook event that fired"},
+	{Name: "session_id", Type: "string", Description: "Claude Code session identifier"},
+	{Name: "transcript_path", Type: "string", Description: "Path to the session transcript"},
+}
+
+// hookEventFields lists the event-specific fields gismo reads for each hook event
+var hookEventFields = map[HookEventName][]hookField{
+	PreToolUseEvent: {
+		{Name: "tool_name", Type: "string", Required: true, Description: "Tool about to run, e.g. Write or Edit"},
+		{Name: "tool_input", Type: "object", Description: "Tool arguments"},
+	},
+	PostToolUseEvent: {
+		{Name: "tool_name", Type: "string", Required: true, Description: "Tool that ran"},
+		{Name: "tool_input", Type: "object", Description: "Tool arguments"},
+		{Name: "tool_output", Description: "Tool result"},
+		{Name: "tool_response", Description: "Tool result as reported by Claude Code, e.g. a written file's original content"},
+		{Name: "tool_error", Type: "string", Description: "Error reported by the tool"},
+	},
+	NotificationEvent: {
+		{Name: "notification_type", Type: "string", Description: "Kind of notification"},
+		{Name: "message", Type: "string", Description: "Notification text"},
+	},
+	StopEvent: {
+		{Name: "reason", Type: "string", Description: "Why the agent stopped"},
+		{Name: "final_message", Type: "string", Description: "Last assistant message"},
+	},
+	SubagentStopEvent: {
+		{Name: "subagent_id", Type: "string", Description: "Subagent identifier"},
+		{Name: "subagent_name", Type: "string", Description: "Subagent name"},
+		{Name: "result", Type: "string", Description: "Subagent result"},
+	},
+	PreCompactEvent: {
+		{Name: "current_tokens", Type: "integer", Description: "Context size before compaction"},
+		{Name: "target_tokens", Type: "integer", Description: "Context size to compact to"},
+	},
+}
+
+// HookEvents returns the hook events gismo understands, in sorted order
+func HookEvents() []HookEventName {
+	events := make([]HookEventName, 0, len(hookEventFields))
+	for event := range hookEventFields {
+		events = append(events, event)
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i] < events[j] })
+	return events
+}
+
+// HookMessageSchema returns the JSON Schema of a hook event's message. Unknown
+// fields are allowed, since Claude Code adds fields over time.
+func HookMessageSchema(event HookEventName) (json.RawMessage, error) {
+	fields, ok := hookEventFields[event]
+	if !ok {
+		return nil, fmt.Errorf("unknown hook event type: %s", event)
+	}
+
+	properties := make(map[string]interface{})
+	required := []string{}
+	for _, field := range append(append([]hookField{}, baseHookFields...), fields...) {
+		property := map[string]interface{}{"description": field.Description}
+		if field.Type != "" {
+			property["type"] = field.Type
+		}
+		if field.Name == "hook_event_name" {
+			property["const"] = string(event)
+		}
+		properties[field.Name] = property
+		if field.Required {
+			required = append(required, field.Name)
+		}
+	}
+
+	return json.MarshalIndent(map[string]interface{}{
+		"$schema":              "https://json-schema.org/draft/2020-12/schema",
+		"title":                string(event) + " hook message",
+		"type":                 "object",
+		"properties":           properties,
+		"required":             required,
+		"additionalProperties": true,
+	}, "", "  ")
+}
+
+// HookMessageSchemas returns the JSON Schema of every hook event's message, keyed by event name
+func HookMessageSchemas() map[HookEventName]json.RawMessage {
+	schemas := make(map[HookEventName]json.RawMessage, len(hookEventFields))
+	for _, event := range HookEvents() {
+		schema, _ := HookMessageSchema(event)
+		schemas[event] = schema
+	}
+	return schemas
+}
+
+// validateHookFields checks the fields of a hook message against its event's
+// fields. It returns an error naming the first missing required field or field
+// of the wrong type, and the unknown fields, which are tolerated.
+func validateHookFields(event HookEventName, raw map[string]json.RawMessage) (unknown []string, err error) {
+	fields := append(append([]hookField{}, baseHookFields...), hookEventFields[event]...)
+	known := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		known[field.Name] = true
+		value, present := raw[field.Name]
+		if !present || jsonType(value) == "null" {
+			if field.Required {
+				return nil, fmt.Errorf("%s message is missing required field %q", event, field.Name)
+			}
+			continue
+		}
+		if got := jsonType(value); field.Type != "" && got != field.Type && (field.Type != "integer" || got != "number") {
+			return nil, fmt.Errorf("%s message field %q must be %s, got %s", event, field.Name, withArticle(field.Type), got)
+		}
+		if field.Type == "integer" && !isJSONInteger(value) {
+			return nil, fmt.Errorf("%s message field %q must be an integer, got %s", event, field.Name, value)
+		}
+	}
+
+	for name := range raw {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown, nil
+}
+
+// jsonType returns the JSON Schema type name of a raw JSON value
+func jsonType(value json.RawMessage) string {
+	trimmed := bytes.TrimSpace(value)
+	if len(trimmed) == 0 {
+		return "null"
+	}
+	switch trimmed[0] {
+	case '"':
+		return "string"
+	case '{':
+		return "object"
+	case '[':
+		return "array"
+	case 't', 'f':
+		return "boolean"
+	case 'n':
+		return "null"
+	default:
+		return "number"
+	}
+}
+
+// isJSONInteger reports whether a raw JSON number has no fractional part
+func isJSONInteger(value json.RawMessage) bool {
+	var n float64
+	if err := json.Unmarshal(value, &n); err != nil {
+		return false
+	}
+	return n == float64(int64(n))
+}
+
+// withArticle prefixes a JSON type name with "a" or "an"
+func withArticle(typeName string) string {
+	switch typeName[0] {
+	case 'a', 'e', 'i', 'o', 'u':
+		return "an " + typeName
+	}
+	return "a " + typeName
+}
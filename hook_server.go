@@ -0,0 +1,171 @@
+package gismo
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxHookRequestBytes bounds the body of a hook request; tool inputs carry whole
+// files, so this is generous
+const maxHookRequestBytes = 32 << 20
+
+// HookServer processes hook messages posted over HTTP, so wrappers can call
+// gismo without starting a process per hook
+type HookServer struct {
+	ruleEngine RuleEngine
+	token      string
+	timeout    time.Duration
+	jsonOutput bool
+
+	// Rule engines reconfigure linters per file, so requests are processed one at a time
+	mu sync.Mutex
+}
+
+// HookServerResponse is the body of a POST /hook response. ExitCode is what the
+// hook would have exited with on the command line. Response is the response that
+// decides the exit code; batches also list one response per message. Output and
+// Feedback hold exactly what the command line writes to stdout and stderr.
+type HookServerResponse struct {
+	ExitCode  int             `json:"exitCode"`
+	Response  *HookResponse   `json:"response,omitempty"`
+	Responses []*HookResponse `json:"responses,omitempty"`
+	Output    string          `json:"output,omitempty"`
+	Feedback  string          `json:"feedback,omitempty"`
+	Error     string          `json:"error,omitempty"`
+}
+
+// FeedbackAware is implemented by rule engines whose human-readable feedback,
+// written to stderr by default, can be redirected
+type FeedbackAware interface {
+	SetFeedbackWriter(w io.Writer)
+}
+
+// NewHookServer creates a hook server. Requests must send token as
+// "Authorization: Bearer <token>"; with an empty token every request is rejected.
+func NewHookServer(ruleEngine RuleEngine, token string) *HookServer {
+	return &HookServer{
+		ruleEngine: ruleEngine,
+		token:      token,
+		timeout:    60 * time.Second,
+	}
+}
+
+// SetTimeout updates the time allowed to process one request
+func (s *HookServer) SetTimeout(timeout time.Duration) {
+	s.timeout = timeout
+}
+
+// SetOutputMode selects how the hook output is written, see OutputModeJSON.
+// In JSON mode Output holds the JSON hook output, including the feedback.
+func (s *HookServer) SetOutputMode(mode string) {
+	s.jsonOutput = mode == OutputModeJSON
+}
+
+// Handler returns the HTTP handler serving POST /hook and GET /healthz
+func (s *HookServer) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/hook", s.serveHook)
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		writeStatusJSON(w, map[string]string{"status": "ok"})
+	})
+	return mux
+}
+
+// serveHook processes the hook messages in the request body, which holds one
+// message or several newline-delimited ones, as on stdin
+func (s *HookServer) serveHook(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeHookError(w, http.StatusMethodNotAllowed, "hook messages must be sent with POST")
+		return
+	}
+	if !s.authorized(r) {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="gismo"`)
+		writeHookError(w, http.StatusUnauthorized, "missing or invalid bearer token")
+		return
+	}
+	// Browsers send an Origin header and can't send application/json cross-site
+	// without a preflight, so this shuts out requests forged by web pages
+	if r.Header.Get("Origin") != "" {
+		writeHookError(w, http.StatusForbidden, "requests from browsers are not accepted")
+		return
+	}
+	if mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err != nil || mediaType != "application/json" {
+		writeHookError(w, http.StatusUnsupportedMediaType, "Content-Type must be application/json")
+		return
+	}
+
+	var body bytes.Buffer
+	if _, err := body.ReadFrom(http.MaxBytesReader(w, r.Body, maxHookRequestBytes)); err != nil {
+		writeHookError(w, http.StatusRequestEntityTooLarge, err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), s.timeout)
+	defer cancel()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Collect the feedback for the client instead of the server's stderr
+	var feedback bytes.Buffer
+	if aware, ok := s.ruleEngine.(FeedbackAware); ok {
+		aware.SetFeedbackWriter(&feedback)
+		defer aware.SetFeedbackWriter(os.Stderr)
+	}
+
+	// Each request gets its own handler, since a handler tracks the last message it processed
+	handler := NewHandler(s.ruleEngine)
+	if s.jsonOutput {
+		handler.SetJSONOutput(&feedback)
+	}
+	var output bytes.Buffer
+	response, err := handler.ProcessData(ctx, body.Bytes(), &output)
+	if err != nil {
+		writeHookError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result := HookServerResponse{
+		ExitCode: int(hookExitCode(handler, response)),
+		Response: response,
+		Output:   output.String(),
+		Feedback: feedback.String(),
+	}
+	if lines := strings.Split(strings.TrimSpace(output.String()), "\n"); len(lines) > 1 && !s.jsonOutput {
+		// Batches write one response line per message
+		for _, line := range lines {
+			var each HookResponse
+			if err := json.Unmarshal([]byte(line), &each); err == nil {
+				result.Responses = append(result.Responses, &each)
+			}
+		}
+	}
+	writeStatusJSON(w, result)
+}
+
+// authorized reports whether the request carries the server's token
+func (s *HookServer) authorized(r *http.Request) bool {
+	if s.token == "" {
+		return false
+	}
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) == 1
+}
+
+// writeHookError writes an error response with a non-blocking exit code, matching
+// how the command line reports hook execution errors
+func writeHookError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(HookServerResponse{ExitCode: 1, Error: message})
+}
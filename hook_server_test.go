@@ -0,0 +1,143 @@
+package gismo
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// postHook posts body to the hook server and decodes the response
+func postHook(t *testing.T, handler http.Handler, body, token string) (int, HookServerResponse) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/hook", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	var result HookServerResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+		t.Fatalf("invalid response body %q: %v", rec.Body.String(), err)
+	}
+	return rec.Code, result
+}
+
+func TestHookServer_Hook(t *testing.T) {
+	engine := &MockRuleEngine{
+		preToolUseResponse: &HookResponse{Decision: "block", Reason: "bad file"},
+	}
+	handler := NewHookServer(engine, "t").Handler()
+
+	preToolUse := `{"hook_event_name":"PreToolUse","session_id":"s","tool_name":"Write"}`
+	code, result := postHook(t, handler, preToolUse, "t")
+	if code != http.StatusOK || result.ExitCode != 2 || result.Response == nil || result.Response.Reason != "bad file" {
+		t.Errorf("PreToolUse = %d %+v", code, result)
+	}
+
+	stop := `{"hook_event_name":"Stop","session_id":"s"}`
+	code, result = postHook(t, handler, stop, "t")
+	if code != http.StatusOK || result.ExitCode != 0 || result.Response != nil {
+		t.Errorf("Stop = %d %+v", code, result)
+	}
+
+	code, result = postHook(t, handler, preToolUse+"\n"+stop+"\n", "t")
+	if code != http.StatusOK || result.ExitCode != 2 || len(result.Responses) != 2 || result.Responses[0].Decision != "block" {
+		t.Errorf("batch = %d %+v", code, result)
+	}
+
+	code, result = postHook(t, handler, `{"hook_event_name":"PreToolUse"}`, "t")
+	if code != http.StatusBadRequest || result.ExitCode != 1 || !strings.Contains(result.Error, "tool_name") {
+		t.Errorf("invalid message = %d %+v", code, result)
+	}
+}
+
+func TestHookServer_Auth(t *testing.T) {
+	handler := NewHookServer(&MockRuleEngine{}, "secret").Handler()
+	stop := `{"hook_event_name":"Stop","session_id":"s"}`
+
+	if code, _ := postHook(t, handler, stop, ""); code != http.StatusUnauthorized {
+		t.Errorf("no token = %d, want %d", code, http.StatusUnauthorized)
+	}
+	if code, _ := postHook(t, handler, stop, "wrong"); code != http.StatusUnauthorized {
+		t.Errorf("wrong token = %d, want %d", code, http.StatusUnauthorized)
+	}
+	if code, _ := postHook(t, handler, stop, "secret"); code != http.StatusOK {
+		t.Errorf("valid token = %d, want %d", code, http.StatusOK)
+	}
+
+	if code, _ := postHook(t, NewHookServer(&MockRuleEngine{}, "").Handler(), stop, ""); code != http.StatusUnauthorized {
+		t.Errorf("server without token = %d, want %d", code, http.StatusUnauthorized)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/hook", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET /hook = %d, want %d", rec.Code, http.StatusMethodNotAllowed)
+	}
+}
+
+func TestHookServer_RejectsBrowserRequests(t *testing.T) {
+	handler := NewHookServer(&MockRuleEngine{}, "secret").Handler()
+	stop := `{"hook_event_name":"Stop","session_id":"s"}`
+
+	tests := []struct {
+		name        string
+		contentType string
+		origin      string
+		want        int
+	}{
+		{name: "cross-site form post", contentType: "text/plain", want: http.StatusUnsupportedMediaType},
+		{name: "missing content type", want: http.StatusUnsupportedMediaType},
+		{name: "origin header", contentType: "application/json", origin: "https://evil.example", want: http.StatusForbidden},
+		{name: "json with charset", contentType: "application/json; charset=utf-8", want: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/hook", strings.NewReader(stop))
+			req.Header.Set("Authorization", "Bearer secret")
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestHookServer_Feedback(t *testing.T) {
+	dir := t.TempDir()
+	engine := NewLintingRuleEngine()
+	handler := NewHookServer(engine, "t").Handler()
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"hook_event_name": "PreToolUse",
+		"session_id":      "s",
+		"tool_name":       "Write",
+		"tool_input": map[string]string{
+			"file_path": filepath.Join(dir, "bad.json"),
+			"content":   `{"a": }`,
+		},
+	})
+	code, result := postHook(t, handler, string(body), "t")
+	if code != http.StatusOK || result.ExitCode != 2 {
+		t.Fatalf("PreToolUse = %d %+v", code, result)
+	}
+	if !strings.Contains(result.Feedback, "bad.json") {
+		t.Errorf("feedback missing lint output: %q", result.Feedback)
+	}
+	if engine.feedback != os.Stderr {
+		t.Error("feedback writer not restored after the request")
+	}
+}
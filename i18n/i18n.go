@@ -0,0 +1,149 @@
+// Package i18n holds the message catalogs for the feedback, CLI output and rule
+// explanations gismo shows to people reading the Claude transcript
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultLanguage is used when no language is configured or the configured one
+// has no catalog. Its catalog holds every message.
+const DefaultLanguage = "en"
+
+//go:embed catalogs/*.json
+var catalogFiles embed.FS
+
+var (
+	catalogsOnce sync.Once
+	catalogs     map[string]*Catalog
+)
+
+// Catalog holds the message templates for one language. Templates are fmt format
+// strings; translations may reorder arguments with explicit indexes such as %[2]s.
+// Messages missing from a catalog fall back to the default language.
+type Catalog struct {
+	language string
+	messages map[string]string
+	fallback *Catalog
+}
+
+// loadCatalogs parses the embedded catalogs, once
+func loadCatalogs() map[string]*Catalog {
+	catalogsOnce.Do(func() {
+		catalogs = make(map[string]*Catalog)
+		entries, err := catalogFiles.ReadDir("catalogs")
+		if err != nil {
+			panic(fmt.Sprintf("i18n: %v", err))
+		}
+		for _, entry := range entries {
+			data, err := catalogFiles.ReadFile("catalogs/" + entry.Name())
+			if err != nil {
+				panic(fmt.Sprintf("i18n: %v", err))
+			}
+			language := strings.TrimSuffix(entry.Name(), path.Ext(entry.Name()))
+			catalog := &Catalog{language: language}
+			if err := json.Unmarshal(data, &catalog.messages); err != nil {
+				panic(fmt.Sprintf("i18n: catalog %s: %v", entry.Name(), err))
+			}
+			catalogs[language] = catalog
+		}
+		for language, catalog := range catalogs {
+			if language != DefaultLanguage {
+				catalog.fallback = catalogs[DefaultLanguage]
+			}
+		}
+	})
+	return catalogs
+}
+
+// Languages returns the languages that have a catalog
+func Languages() []string {
+	var languages []string
+	for language := range loadCatalogs() {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+// Lookup returns the catalog for language, which may be a locale such as
+// "ja_JP.UTF-8". Unknown languages get the default language's catalog.
+func Lookup(language string) *Catalog {
+	all := loadCatalogs()
+	if catalog, ok := all[Normalize(language)]; ok {
+		return catalog
+	}
+	return all[DefaultLanguage]
+}
+
+// Detect returns the language to use: configured when set, otherwise the first
+// of LC_ALL, LC_MESSAGES and LANG that is set, otherwise the default language
+func Detect(configured string) string {
+	if configured != "" {
+		return Normalize(configured)
+	}
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if value := os.Getenv(name); value != "" {
+			return Normalize(value)
+		}
+	}
+	return DefaultLanguage
+}
+
+// Normalize reduces a locale such as "zh_CN.UTF-8" or "ja-JP" to its language
+// code. The C and POSIX locales are the default language.
+func Normalize(locale string) string {
+	language := strings.ToLower(strings.TrimSpace(locale))
+	if i := strings.IndexAny(language, ".@"); i >= 0 {
+		language = language[:i]
+	}
+	if i := strings.IndexAny(language, "_-"); i >= 0 {
+		language = language[:i]
+	}
+	if language == "" || language == "c" || language == "posix" {
+		return DefaultLanguage
+	}
+	return language
+}
+
+// Language returns the catalog's language code
+func (c *Catalog) Language() string {
+	if c == nil {
+		return DefaultLanguage
+	}
+	return c.language
+}
+
+// Text returns the template for key, falling back to the default language
+func (c *Catalog) Text(key string) (string, bool) {
+	if c == nil {
+		c = Lookup(DefaultLanguage)
+	}
+	for catalog := c; catalog != nil; catalog = catalog.fallback {
+		if template, ok := catalog.messages[key]; ok {
+			return template, true
+		}
+	}
+	return "", false
+}
+
+// Sprintf formats the message for key with args. A key with no template in any
+// catalog is returned as is, so a missing message shows up instead of vanishing.
+// A nil catalog uses the default language.
+func (c *Catalog) Sprintf(key string, args ...interface{}) string {
+	template, ok := c.Text(key)
+	if !ok {
+		return key
+	}
+	if len(args) == 0 {
+		return template
+	}
+	return fmt.Sprintf(template, args...)
+}
@@ -0,0 +1,121 @@
+package i18n
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	for locale, want := range map[string]string{
+		"ja_JP.UTF-8": "ja",
+		"zh-CN":       "zh",
+		"ZH_tw@latin": "zh",
+		"en":          "en",
+		"C":           "en",
+		"POSIX":       "en",
+		"":            "en",
+	} {
+		if got := Normalize(locale); got != want {
+			t.Errorf("Normalize(%q) = %q, want %q", locale, got, want)
+		}
+	}
+}
+
+func TestDetect(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "zh_CN.UTF-8")
+	if got := Detect(""); got != "zh" {
+		t.Errorf("Detect() with LANG = %q, want zh", got)
+	}
+	if got := Detect("ja"); got != "ja" {
+		t.Errorf("Detect(ja) = %q, the configured language should win", got)
+	}
+	t.Setenv("LC_ALL", "ja_JP.UTF-8")
+	if got := Detect(""); got != "ja" {
+		t.Errorf("Detect() with LC_ALL = %q, want ja", got)
+	}
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LANG", "")
+	if got := Detect(""); got != DefaultLanguage {
+		t.Errorf("Detect() without a locale = %q, want %q", got, DefaultLanguage)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	if got := Lookup("ja_JP.UTF-8").Language(); got != "ja" {
+		t.Errorf("Lookup(ja_JP.UTF-8) = %q", got)
+	}
+	if got := Lookup("xx").Language(); got != DefaultLanguage {
+		t.Errorf("Lookup(xx) = %q, want the default language", got)
+	}
+	if got := strings.Join(Languages(), ","); got != "en,ja,zh" {
+		t.Errorf("Languages() = %s", got)
+	}
+}
+
+func TestCatalog_Sprintf(t *testing.T) {
+	var nilCatalog *Catalog
+	if got := nilCatalog.Sprintf("reason.errors_found", 2, "a.go"); got != "Found 2 error(s) in a.go" {
+		t.Errorf("nil catalog Sprintf() = %q", got)
+	}
+	if got := Lookup("ja").Sprintf("reason.errors_found", 2, "a.go"); got != "a.go に 2 件のエラーがあります" {
+		t.Errorf("ja Sprintf() = %q", got)
+	}
+	if got := Lookup("zh").Sprintf("no.such.key"); got != "no.such.key" {
+		t.Errorf("missing key = %q, want the key", got)
+	}
+	if _, ok := Lookup("en").Text("rule.yaml.syntax"); ok {
+		t.Error("rule explanations come from the linters in English, not the catalog")
+	}
+	if _, ok := Lookup("ja").Text("rule.yaml.syntax"); !ok {
+		t.Error("ja catalog has no yaml syntax rule explanation")
+	}
+}
+
+// verbPattern matches fmt verbs with an optional explicit argument index
+var verbPattern = regexp.MustCompile(`%(?:\[(\d+)\])?([a-z])`)
+
+// verbs returns the verb each argument of template is formatted with
+func verbs(template string) []string {
+	var found []string
+	next := 1
+	for _, match := range verbPattern.FindAllStringSubmatch(strings.ReplaceAll(template, "%%", ""), -1) {
+		if match[1] != "" {
+			next, _ = strconv.Atoi(match[1])
+		}
+		found = append(found, strconv.Itoa(next)+match[2])
+		next++
+	}
+	sort.Strings(found)
+	return found
+}
+
+// TestCatalogsMatchDefault checks every translation formats the same arguments
+// with the same verbs as the default language
+func TestCatalogsMatchDefault(t *testing.T) {
+	defaults := Lookup(DefaultLanguage).messages
+	for _, language := range Languages() {
+		for key, template := range Lookup(language).messages {
+			if strings.HasPrefix(key, "rule.") {
+				continue
+			}
+			base, ok := defaults[key]
+			if !ok {
+				t.Errorf("%s: %s is not in the %s catalog", language, key, DefaultLanguage)
+				continue
+			}
+			if got, want := strings.Join(verbs(template), ","), strings.Join(verbs(base), ","); got != want {
+				t.Errorf("%s: %s formats %s, want %s", language, key, got, want)
+			}
+		}
+		for key := range defaults {
+			if _, ok := Lookup(language).messages[key]; !ok {
+				t.Errorf("%s: %s is missing", language, key)
+			}
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package gismo
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+// inlineDirective starts a file-scoped configuration comment
+const inlineDirective = "gismo:config"
+
+// InlineConfig holds the settings of a gismo:config directive in a file's first
+// line (or second, after a shebang), such as
+//
+//	// gismo:config maxLineLength=200 disable=line-length
+//
+// It lets vendored or intentionally unusual files relax rules without changing
+// the shared config.
+type InlineConfig struct {
+	// Settings are linter config keys and JSON values. A key prefixed with a
+	// linter name, such as "markdown.maxLineLength", applies to that linter only;
+	// other keys apply to every linter whose config has them.
+	Settings map[string]json.RawMessage
+	// Disabled lists rules whose issues are dropped for the file
+	Disabled map[string]bool
+}
+
+// ParseInlineConfig returns the gismo:config directive of content, or nil if it
+// has none. Fields that aren't key=value pairs are ignored.
+func ParseInlineConfig(content []byte) *InlineConfig {
+	lines := bytes.SplitN(content, []byte("\n"), 3)
+	line := string(lines[0])
+	if strings.HasPrefix(line, "#!") && len(lines) > 1 {
+		line = string(lines[1])
+	}
+	idx := strings.Index(line, inlineDirective)
+	if idx < 0 {
+		return nil
+	}
+
+	// Drop the closing marker of block comments such as /* ... */ or <!-- ... -->
+	rest := strings.TrimSpace(line[idx+len(inlineDirective):])
+	for _, closer := range []string{"*/", "-->", "#}", "--}}"} {
+		rest = strings.TrimSpace(strings.TrimSuffix(rest, closer))
+	}
+
+	inline := &InlineConfig{Settings: make(map[string]json.RawMessage), Disabled: make(map[string]bool)}
+	for _, field := range strings.Fields(rest) {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok || key == "" {
+			continue
+		}
+		if key == "disable" {
+			for _, rule := range strings.Split(value, ",") {
+				if rule != "" {
+					inline.Disabled[rule] = true
+				}
+			}
+			continue
+		}
+		inline.Settings[key] = inlineValue(value)
+	}
+	return inline
+}
+
+// inlineValue converts a directive value to JSON: valid JSON is kept, comma
+// separated values become a list of strings and anything else a string
+func inlineValue(value string) json.RawMessage {
+	if json.Valid([]byte(value)) {
+		return json.RawMessage(value)
+	}
+	var encoded []byte
+	if strings.Contains(value, ",") {
+		encoded, _ = json.Marshal(strings.Split(value, ","))
+	} else {
+		encoded, _ = json.Marshal(value)
+	}
+	return encoded
+}
+
+// layer returns the config layer the directive applies to a linter, or nil if
+// none of its settings apply. schemaKeys are the linter's known config keys.
+func (c *InlineConfig) layer(linterName string, schemaKeys map[string]bool) json.RawMessage {
+	if c == nil || len(c.Settings) == 0 {
+		return nil
+	}
+	settings := make(map[string]json.RawMessage)
+	for key, value := range c.Settings {
+		if name, scoped, ok := strings.Cut(key, "."); ok {
+			if name == linterName {
+				settings[scoped] = value
+			}
+			continue
+		}
+		if schemaKeys[key] {
+			settings[key] = value
+		}
+	}
+	if len(settings) == 0 {
+		return nil
+	}
+	layer, err := json.Marshal(settings)
+	if err != nil {
+		return nil
+	}
+	return layer
+}
+
+// unknownKeys returns the settings no linter accepts, sorted
+func (c *InlineConfig) unknownKeys(linterKeys map[string]map[string]bool) []string {
+	if c == nil {
+		return nil
+	}
+	var unknown []string
+	for key := range c.Settings {
+		if name, _, ok := strings.Cut(key, "."); ok {
+			if _, exists := linterKeys[name]; !exists {
+				unknown = append(unknown, key)
+			}
+			continue
+		}
+		known := false
+		for _, keys := range linterKeys {
+			if keys[key] {
+				known = true
+				break
+			}
+		}
+		if !known {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+// disables reports whether the directive disables rule
+func (c *InlineConfig) disables(rule string) bool {
+	return c != nil && c.Disabled[rule]
+}
+
+// filter drops issues for rules the directive disables
+func (c *InlineConfig) filter(issues []linters.Issue) []linters.Issue {
+	if c == nil || len(c.Disabled) == 0 {
+		return issues
+	}
+	kept := make([]linters.Issue, 0, len(issues))
+	for _, issue := range issues {
+		if !c.disables(issue.Rule) {
+			kept = append(kept, issue)
+		}
+	}
+	return kept
+}
+
+// schemaKeys returns the top-level config keys declared in a linter's schema
+func schemaKeys(linter linters.Linter) map[string]bool {
+	provider, ok := linter.(SchemaLinter)
+	if !ok {
+		return nil
+	}
+	var schema struct {
+		Properties map[string]json.RawMessage `json:"properties"`
+	}
+	if err := json.Unmarshal(provider.ConfigSchema(), &schema); err != nil {
+		return nil
+	}
+	keys := make(map[string]bool, len(schema.Properties))
+	for key := range schema.Properties {
+		keys[key] = true
+	}
+	return keys
+}
@@ -0,0 +1,167 @@
+package gismo
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+func TestParseInlineConfig(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      string
+		wantNil      bool
+		wantSettings map[string]string
+		wantDisabled []string
+	}{
+		{
+			name:         "line comment",
+			content:      "// gismo:config maxLineLength=200 disable=line-length\npackage main\n",
+			wantSettings: map[string]string{"maxLineLength": "200"},
+			wantDisabled: []string{"line-length"},
+		},
+		{
+			name:         "html comment",
+			content:      "<!-- gismo:config markdown.maxLineLength=200 disable=MD013,MD041 -->\n# Title\n",
+			wantSettings: map[string]string{"markdown.maxLineLength": "200"},
+			wantDisabled: []string{"MD013", "MD041"},
+		},
+		{
+			name:         "after shebang",
+			content:      "#!/usr/bin/env python3\n# gismo:config ignore=E501,W291 strict=false\n",
+			wantSettings: map[string]string{"ignore": `["E501","W291"]`, "strict": "false"},
+		},
+		{
+			name:         "block comment and string value",
+			content:      "/* gismo:config style=airbnb */\n",
+			wantSettings: map[string]string{"style": `"airbnb"`},
+		},
+		{
+			name:    "directive past the first line",
+			content: "package main\n// gismo:config maxLineLength=200\n",
+			wantNil: true,
+		},
+		{
+			name:    "no directive",
+			content: "package main\n",
+			wantNil: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inline := ParseInlineConfig([]byte(tt.content))
+			if tt.wantNil {
+				if inline != nil {
+					t.Fatalf("ParseInlineConfig() = %+v, want nil", inline)
+				}
+				return
+			}
+			if inline == nil {
+				t.Fatal("ParseInlineConfig() = nil, want a directive")
+			}
+			if len(inline.Settings) != len(tt.wantSettings) {
+				t.Errorf("settings = %v, want %v", inline.Settings, tt.wantSettings)
+			}
+			for key, want := range tt.wantSettings {
+				if got := string(inline.Settings[key]); got != want {
+					t.Errorf("setting %s = %s, want %s", key, got, want)
+				}
+			}
+			if len(inline.Disabled) != len(tt.wantDisabled) {
+				t.Errorf("disabled = %v, want %v", inline.Disabled, tt.wantDisabled)
+			}
+			for _, rule := range tt.wantDisabled {
+				if !inline.Disabled[rule] {
+					t.Errorf("rule %s not disabled", rule)
+				}
+			}
+		})
+	}
+}
+
+func TestInlineConfig_Layer(t *testing.T) {
+	inline := ParseInlineConfig([]byte("// gismo:config maxLineLength=200 markdown.disabledRules=MD013,MD041 python.ignore=E501 bogus=1\n"))
+	keys := map[string]bool{"maxLineLength": true, "disabledRules": true}
+
+	if got, want := string(inline.layer("markdown", keys)), `{"disabledRules":["MD013","MD041"],"maxLineLength":200}`; got != want {
+		t.Errorf("markdown layer = %s, want %s", got, want)
+	}
+	if got := inline.layer("go", map[string]bool{"gofumpt": true}); got != nil {
+		t.Errorf("go layer = %s, want nil", got)
+	}
+
+	linterKeys := map[string]map[string]bool{"markdown": keys}
+	if got := strings.Join(inline.unknownKeys(linterKeys), ","); got != "bogus,python.ignore" {
+		t.Errorf("unknownKeys() = %s, want bogus,python.ignore", got)
+	}
+}
+
+func TestInlineConfig_Filter(t *testing.T) {
+	issues := []linters.Issue{{Rule: "line-length"}, {Rule: "syntax"}}
+
+	var none *InlineConfig
+	if got := none.filter(issues); len(got) != 2 {
+		t.Errorf("nil directive filtered issues: %+v", got)
+	}
+	inline := ParseInlineConfig([]byte("# gismo:config disable=line-length\n"))
+	if got := inline.filter(issues); len(got) != 1 || got[0].Rule != "syntax" {
+		t.Errorf("filter() = %+v, want only syntax", got)
+	}
+}
+
+// schemaRecordingLinter records its config and declares a schema, so inline
+// settings can be matched to it
+type schemaRecordingLinter struct {
+	configRecordingLinter
+}
+
+func (l *schemaRecordingLinter) ConfigSchema() json.RawMessage {
+	return json.RawMessage(`{"type": "object", "properties": {"maxLineLength": {"type": "integer"}}}`)
+}
+
+func TestLintingRuleEngine_InlineConfig(t *testing.T) {
+	linter := &schemaRecordingLinter{configRecordingLinter{MockLinter: MockLinter{
+		name:      "markdown",
+		canHandle: true,
+		result: &linters.LintResult{Issues: []linters.Issue{
+			{Severity: "error", Message: "line too long", Rule: "MD013"},
+			{Severity: "error", Message: "bad heading", Rule: "MD041"},
+		}},
+	}}}
+	engine := NewLintingRuleEngine()
+	engine.linters = []linters.Linter{linter}
+
+	content := []byte("<!-- gismo:config maxLineLength=200 disable=MD013 -->\n# Title\n")
+	diagnostics, err := engine.LintFile(context.Background(), "README.md", content)
+	if err != nil {
+		t.Fatalf("LintFile() error = %v", err)
+	}
+	if linter.config != `{"maxLineLength":200}` {
+		t.Errorf("linter config = %s, want the inline setting", linter.config)
+	}
+	if len(diagnostics) != 1 || diagnostics[0].Rule != "MD041" {
+		t.Errorf("diagnostics = %+v, want only MD041", diagnostics)
+	}
+
+	// The next file without a directive gets the default config back
+	if _, err := engine.LintFile(context.Background(), "OTHER.md", []byte("# Other\n")); err != nil {
+		t.Fatalf("LintFile() error = %v", err)
+	}
+	if linter.config != `{}` {
+		t.Errorf("linter config = %s, want the default", linter.config)
+	}
+
+	// Disabling inline config ignores the directive
+	disabled := false
+	config := NewAppConfig()
+	config.InlineConfig = &disabled
+	engine.SetAppConfig(config)
+	diagnostics, _ = engine.LintFile(context.Background(), "README.md", content)
+	if len(diagnostics) != 2 {
+		t.Errorf("diagnostics = %+v, want both issues with inline config disabled", diagnostics)
+	}
+}
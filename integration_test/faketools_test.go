@@ -0,0 +1,214 @@
+package integration_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jrossi/gismo"
+	"github.com/jrossi/gismo/internal/faketools"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files with the current output")
+
+func TestHookRuns_FakeTools(t *testing.T) {
+	tests := []struct {
+		name  string
+		hook  gismo.HookEventName
+		file  string
+		files map[string]string
+		tools map[string]faketools.Tool
+		// wantArgs must appear in the arguments of a call to the named tool
+		wantTool string
+		wantArgs []string
+	}{
+		{
+			name: "golangci-lint",
+			hook: gismo.PostToolUseEvent,
+			file: "main.go",
+			files: map[string]string{
+				"go.mod":  "module example.com/app\n\ngo 1.23\n",
+				"main.go": "package main\n\nimport \"os\"\n\nfunc main() {\n\tos.Remove(\"tmp\")\n}\n",
+			},
+			tools: map[string]faketools.Tool{
+				"golangci-lint": {Responses: []faketools.Response{{
+					Args:     []string{"run"},
+					Stdout:   `{"Issues":[{"FromLinter":"errcheck","Text":"Error return value of ` + "`os.Remove`" + ` is not checked","Severity":"","Pos":{"Filename":"main.go","Line":6,"Column":11}}]}`,
+					ExitCode: 1,
+				}}},
+			},
+			wantTool: "golangci-lint",
+			wantArgs: []string{"run", "--out-format=json"},
+		},
+		{
+			name: "ruff",
+			hook: gismo.PostToolUseEvent,
+			file: "app.py",
+			files: map[string]string{
+				"app.py": "import os\n\n\ndef main():\n    return 1\n",
+			},
+			tools: map[string]faketools.Tool{
+				"uv": {Responses: []faketools.Response{{
+					Args:     []string{"check", "--output-format"},
+					Stdout:   `[{"code":"F401","message":"` + "`os`" + ` imported but unused","location":{"row":1,"column":8},"end_location":{"row":1,"column":10},"filename":"app.py"}]`,
+					ExitCode: 1,
+				}}},
+			},
+			wantTool: "uv",
+			wantArgs: []string{"ruff", "check", "--output-format", "json"},
+		},
+		{
+			name: "eslint",
+			hook: gismo.PreToolUseEvent,
+			file: "index.js",
+			files: map[string]string{
+				"index.js": "const unused = 1;\n",
+			},
+			tools: map[string]faketools.Tool{
+				"eslint": {Category: "javascript", Responses: []faketools.Response{{
+					Stdout:   `[{"filePath":"index.js","messages":[{"ruleId":"no-unused-vars","severity":2,"message":"'unused' is assigned a value but never used.","line":1,"column":7}],"errorCount":1,"warningCount":0}]`,
+					ExitCode: 1,
+				}}},
+			},
+			wantTool: "eslint",
+			wantArgs: []string{"--format=json"},
+		},
+		{
+			name: "buf",
+			hook: gismo.PostToolUseEvent,
+			file: "api/v1/service.proto",
+			files: map[string]string{
+				"buf.yaml":             "version: v2\n",
+				"api/v1/service.proto": "syntax = \"proto3\";\n\npackage api.v1;\n\nmessage get_request {}\n",
+			},
+			tools: map[string]faketools.Tool{
+				"buf": {Responses: []faketools.Response{{
+					Args:     []string{"lint"},
+					Stdout:   `{"path":"api/v1/service.proto","start_line":5,"start_column":9,"end_line":5,"end_column":20,"type":"MESSAGE_PASCAL_CASE","message":"Message name \"get_request\" should be PascalCase, such as \"GetRequest\"."}` + "\n",
+					ExitCode: 100,
+				}}},
+			},
+			wantTool: "buf",
+			wantArgs: []string{"lint", "--format=json"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("LC_ALL", "")
+			t.Setenv("LC_MESSAGES", "")
+			t.Setenv("LANG", "en_US.UTF-8")
+
+			root := t.TempDir()
+			for name, content := range tt.files {
+				path := filepath.Join(root, name)
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			tools := faketools.Install(t, tt.tools)
+
+			var feedback bytes.Buffer
+			engine := gismo.NewLintingRuleEngineWithConfig(gismo.LintingConfig{ToolCache: tools.ToolCache(), ProjectRoot: root})
+			engine.SetFeedbackWriter(&feedback)
+
+			filePath := filepath.Join(root, tt.file)
+			message, _ := json.Marshal(map[string]interface{}{
+				"session_id":      "golden",
+				"hook_event_name": tt.hook,
+				"tool_name":       "Write",
+				"tool_input":      map[string]string{"file_path": filePath, "content": tt.files[tt.file]},
+			})
+			response, err := gismo.NewWithRuleEngine(engine).ProcessMessage(context.Background(), message)
+			if err != nil {
+				t.Fatalf("ProcessMessage() error = %v", err)
+			}
+
+			var got bytes.Buffer
+			encoded, _ := json.MarshalIndent(response, "", "  ")
+			got.WriteString("# response\n")
+			got.Write(encoded)
+			got.WriteString("\n# feedback\n")
+			got.WriteString(feedback.String())
+			output := strings.ReplaceAll(got.String(), root, "$ROOT")
+			faketools.Golden(t, filepath.Join("..", "testdata", "golden", tt.name+".golden"), []byte(output), *update)
+
+			if !calledWith(tools.Calls(tt.wantTool), tt.wantArgs) {
+				t.Errorf("%s calls = %q, want a call with %q", tt.wantTool, tools.Calls(tt.wantTool), tt.wantArgs)
+			}
+		})
+	}
+}
+
+// calledWith reports whether one of the calls has every argument in args
+func calledWith(calls [][]string, args []string) bool {
+	for _, call := range calls {
+		found := 0
+		for _, want := range args {
+			for _, arg := range call {
+				if arg == want {
+					found++
+					break
+				}
+			}
+		}
+		if found == len(args) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestFakeTools_UnsupportedVersion(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"go.mod":  "module example.com/app\n\ngo 1.23\n",
+		"main.go": "package main\n\nfunc main() {}\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tools := faketools.Install(t, map[string]faketools.Tool{
+		"golangci-lint": {Responses: []faketools.Response{
+			{Args: []string{"--version"}, Stdout: "golangci-lint has version 3.0.0 built with go1.26.0\n"},
+			{Args: []string{"run"}, Stdout: "main.go:3:1: something new\n", ExitCode: 1},
+		}},
+	})
+
+	engine := gismo.NewLintingRuleEngineWithConfig(gismo.LintingConfig{ToolCache: tools.ToolCache(), ProjectRoot: root})
+	filePath := filepath.Join(root, "main.go")
+	diagnostics, err := engine.LintFile(context.Background(), filePath, []byte(files["main.go"]))
+	if err != nil {
+		t.Fatalf("LintFile() error = %v", err)
+	}
+
+	var found bool
+	for _, diagnostic := range diagnostics {
+		if diagnostic.Rule == "unsupported-tool-version" {
+			found = true
+			if !strings.Contains(diagnostic.Message, "golangci-lint 3.0.0 is not supported") {
+				t.Errorf("message = %q, want the tool and its version", diagnostic.Message)
+			}
+		}
+		if diagnostic.Rule == "parse-error" {
+			t.Errorf("got a generic parse error: %+v", diagnostic)
+		}
+	}
+	if !found {
+		t.Errorf("diagnostics = %+v, want an unsupported-tool-version warning", diagnostics)
+	}
+	// Version 2 flags are the newest known, so they are tried on later versions
+	if !calledWith(tools.Calls("golangci-lint"), []string{"run", "--output.json.path=stdout"}) {
+		t.Errorf("golangci-lint calls = %q, want the version 2 flags", tools.Calls("golangci-lint"))
+	}
+}
@@ -0,0 +1,84 @@
+// Command faketool stands in for an external tool in tests. It is installed
+// under the tool's name next to a <name>.json spec written by faketools.Install,
+// logs its arguments to <name>.calls and answers with the first matching
+// canned response.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+type response struct {
+	Args     []string `json:"args"`
+	Stdout   string   `json:"stdout"`
+	Stderr   string   `json:"stderr"`
+	ExitCode int      `json:"exitCode"`
+}
+
+type spec struct {
+	Responses []response `json:"responses"`
+}
+
+func main() {
+	executable, err := os.Executable()
+	if err != nil {
+		fail(err)
+	}
+	base := filepath.Join(filepath.Dir(executable), filepath.Base(executable))
+	args := os.Args[1:]
+
+	if err := logCall(base+".calls", args); err != nil {
+		fail(err)
+	}
+
+	content, err := os.ReadFile(base + ".json") // #nosec G304 - spec next to the binary
+	if err != nil {
+		fail(err)
+	}
+	var s spec
+	if err := json.Unmarshal(content, &s); err != nil {
+		fail(err)
+	}
+
+	for _, r := range s.Responses {
+		if matches(args, r.Args) {
+			_, _ = os.Stdout.WriteString(r.Stdout)
+			_, _ = os.Stderr.WriteString(r.Stderr)
+			os.Exit(r.ExitCode)
+		}
+	}
+}
+
+// matches reports whether every wanted argument appears in args
+func matches(args, want []string) bool {
+	for _, arg := range want {
+		if !slices.Contains(args, arg) {
+			return false
+		}
+	}
+	return true
+}
+
+// logCall appends the arguments of this call to the call log as a JSON line
+func logCall(path string, args []string) error {
+	line, err := json.Marshal(args)
+	if err != nil {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600) // #nosec G304 - log next to the binary
+	if err != nil {
+		return err
+	}
+	defer func() { _ = file.Close() }()
+	_, err = file.Write(append(line, '\n'))
+	return err
+}
+
+func fail(err error) {
+	fmt.Fprintf(os.Stderr, "faketool: %v\n", err)
+	os.Exit(125)
+}
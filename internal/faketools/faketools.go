@@ -0,0 +1,176 @@
+// Package faketools installs fake external tools, such as golangci-lint, ruff,
+// eslint and buf, that answer with canned output. Linter integrations can then
+// be tested end to end without the real tools installed, and with the same
+// output on every machine.
+package faketools
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/jrossi/gismo/toolcache"
+)
+
+// Response is a canned answer of a fake tool
+type Response struct {
+	// Args must all appear in a call's arguments for the response to be used;
+	// a response without Args answers every call
+	Args     []string `json:"args,omitempty"`
+	Stdout   string   `json:"stdout,omitempty"`
+	Stderr   string   `json:"stderr,omitempty"`
+	ExitCode int      `json:"exitCode,omitempty"`
+}
+
+// Tool is a fake external tool. A call is answered by the first response that
+// matches it; calls no response matches exit 0 with no output.
+type Tool struct {
+	// Category registers the tool in ToolCache under this category, such as
+	// "javascript", for linters that discover tools through the cache
+	Category  string     `json:"-"`
+	Responses []Response `json:"responses"`
+}
+
+// Tools are fake tools installed in a directory that is the whole PATH
+type Tools struct {
+	// Dir holds the fake binaries, their specs and their call logs
+	Dir   string
+	tools map[string]Tool
+}
+
+var (
+	buildOnce sync.Once
+	binary    string
+	buildErr  error
+)
+
+// build compiles the fake tool binary once per test process
+func build() (string, error) {
+	buildOnce.Do(func() {
+		goTool, err := exec.LookPath("go")
+		if err != nil {
+			buildErr = err
+			return
+		}
+		dir, err := os.MkdirTemp("", "gismo-faketools-")
+		if err != nil {
+			buildErr = err
+			return
+		}
+		binary = filepath.Join(dir, "faketool")
+		cmd := exec.Command(goTool, "build", "-o", binary, "github.com/jrossi/gismo/internal/faketools/cmd/faketool") // #nosec G204 - fixed arguments
+		if output, err := cmd.CombinedOutput(); err != nil {
+			buildErr = fmt.Errorf("building fake tool: %v\n%s", err, output)
+		}
+	})
+	return binary, buildErr
+}
+
+// Install installs the fake tools under their names and makes their directory
+// the whole PATH for the rest of the test, so real tools can't be found. HOME is
+// pointed at an empty directory too, as some linters look for tools there.
+// The test is skipped if the go command needed to build the fakes is missing.
+func Install(t testing.TB, tools map[string]Tool) *Tools {
+	t.Helper()
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go command not found, fake tools can't be built")
+	}
+	source, err := build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, err := os.ReadFile(source) // #nosec G304 - built above
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	for name, tool := range tools {
+		// Copies rather than links, so each fake finds its spec by its own path
+		if err := os.WriteFile(filepath.Join(dir, name), content, 0755); err != nil { // #nosec G306 - executable
+			t.Fatal(err)
+		}
+		spec, err := json.Marshal(tool)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name+".json"), spec, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Setenv("PATH", dir)
+	t.Setenv("HOME", t.TempDir())
+	return &Tools{Dir: dir, tools: tools}
+}
+
+// Path returns the path of the fake tool name
+func (f *Tools) Path(name string) string {
+	return filepath.Join(f.Dir, name)
+}
+
+// Calls returns the arguments of each call to the fake tool name, in order
+func (f *Tools) Calls(name string) [][]string {
+	file, err := os.Open(filepath.Join(f.Dir, name+".calls")) // #nosec G304 - under the fake tools directory
+	if err != nil {
+		return nil
+	}
+	defer func() { _ = file.Close() }()
+
+	var calls [][]string
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		var args []string
+		if err := json.Unmarshal(scanner.Bytes(), &args); err == nil {
+			calls = append(calls, args)
+		}
+	}
+	return calls
+}
+
+// ToolCache returns a tool cache holding the fake tools that have a category,
+// for linters that discover tools through a cache rather than PATH
+func (f *Tools) ToolCache() *toolcache.MemoryCache {
+	cache := toolcache.NewMemoryCache()
+	names := make([]string, 0, len(f.tools))
+	for name := range f.tools {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if category := f.tools[name].Category; category != "" {
+			cache.AddTool(category, name, f.Path(name))
+		}
+	}
+	return cache
+}
+
+// Golden compares got with the golden file at path, or rewrites the file when
+// update is set, as with a -update test flag
+func Golden(t testing.TB, path string, got []byte, update bool) {
+	t.Helper()
+	if update {
+		if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, got, 0600); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path) // #nosec G304 - golden file of the test
+	if err != nil {
+		t.Fatalf("reading golden file (run with -update to create it): %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("output differs from %s (run with -update to accept it):\n--- got\n%s\n--- want\n%s", path, got, want)
+	}
+}
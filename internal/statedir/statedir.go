@@ -0,0 +1,96 @@
+// Package statedir locates the per-user directories gismo keeps state in, such
+// as session state, cached results and daemon sockets. Hooks trust what they
+// read there, so the directories must not be shared with, or creatable by,
+// other local users; Ensure, Check and CheckPrivate verify that before use.
+package statedir
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// CacheDir returns a directory under the user's cache directory, such as
+// ~/.cache/gismo/sessions. Without one it falls back to a directory in the
+// system temp directory named for the user.
+func CacheDir(elem ...string) string {
+	base, err := os.UserCacheDir()
+	if err != nil || !filepath.IsAbs(base) {
+		return filepath.Join(append([]string{fallbackDir()}, elem...)...)
+	}
+	return filepath.Join(append([]string{base, "gismo"}, elem...)...)
+}
+
+// RuntimeDir returns a directory for sockets and other files that only live
+// while a process runs: under $XDG_RUNTIME_DIR when it is set, else under
+// CacheDir
+func RuntimeDir(elem ...string) string {
+	// XDG requires an absolute path; a relative one is ignored
+	if base := os.Getenv("XDG_RUNTIME_DIR"); filepath.IsAbs(base) {
+		return filepath.Join(append([]string{base, "gismo"}, elem...)...)
+	}
+	return CacheDir(elem...)
+}
+
+// fallbackDir is the state directory of users without a cache directory
+func fallbackDir() string {
+	return filepath.Join(os.TempDir(), "gismo-"+strconv.Itoa(os.Getuid()))
+}
+
+// Ensure creates dir if needed and checks it as Check does
+func Ensure(dir string) error {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	return Check(dir)
+}
+
+// Check verifies that no other user can change path, a directory or file: it
+// isn't a symlink, the current user owns it and others can't write to it. Each
+// directory above it must belong to the user or root, and those of the user
+// must not be writable by others either, so no one else can swap path out.
+func Check(path string) error {
+	return check(path, 0o022)
+}
+
+// CheckPrivate verifies path as Check does, and that other users can't read it
+// either, as for files holding secrets
+func CheckPrivate(path string) error {
+	return check(path, 0o077)
+}
+
+// check verifies path and its directories, where others have none of the
+// permissions in others on path itself
+func check(path string, others os.FileMode) error {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	info, err := os.Lstat(path)
+	if err != nil {
+		return err
+	}
+	if info.Mode()&os.ModeSymlink != 0 {
+		return fmt.Errorf("%s is a symlink: %w", path, errNotPrivate)
+	}
+	if err := checkOwner(path, info, false, others); err != nil {
+		return err
+	}
+	for dir := filepath.Dir(path); ; dir = filepath.Dir(dir) {
+		info, err := os.Stat(dir)
+		if err != nil {
+			return err
+		}
+		if err := checkOwner(dir, info, true, 0o022); err != nil {
+			return err
+		}
+		if parent := filepath.Dir(dir); parent == dir {
+			return nil
+		}
+	}
+}
+
+// errNotPrivate is wrapped by the errors of Check and CheckPrivate
+var errNotPrivate = errors.New("not private to the current user")
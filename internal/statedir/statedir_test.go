@@ -0,0 +1,114 @@
+//go:build unix
+
+package statedir
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestCacheDir(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", "/home/alex/.cache")
+	t.Setenv("HOME", "/home/alex")
+	want := "/home/alex/.cache/gismo/sessions"
+	if runtime.GOOS == "darwin" {
+		want = "/home/alex/Library/Caches/gismo/sessions"
+	}
+	if got := CacheDir("sessions"); got != want {
+		t.Errorf("CacheDir() = %q, want %q", got, want)
+	}
+
+	t.Setenv("XDG_RUNTIME_DIR", "/run/user/1000")
+	if got, want := RuntimeDir("daemon"), "/run/user/1000/gismo/daemon"; got != want {
+		t.Errorf("RuntimeDir() = %q, want %q", got, want)
+	}
+	t.Setenv("XDG_RUNTIME_DIR", "relative")
+	if got := RuntimeDir("daemon"); got != CacheDir("daemon") {
+		t.Errorf("RuntimeDir() with a relative XDG_RUNTIME_DIR = %q, want the cache directory", got)
+	}
+}
+
+func TestEnsure(t *testing.T) {
+	root := t.TempDir()
+
+	dir := filepath.Join(root, "gismo", "sessions")
+	if err := Ensure(dir); err != nil {
+		t.Fatalf("Ensure() error = %v", err)
+	}
+	if info, err := os.Stat(dir); err != nil || info.Mode().Perm() != 0700 {
+		t.Errorf("created %v, %v, want mode 0700", info.Mode(), err)
+	}
+
+	shared := filepath.Join(root, "shared")
+	if err := os.Mkdir(shared, 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(shared, 0775); err != nil {
+		t.Fatal(err)
+	}
+	if err := Ensure(shared); !errors.Is(err, errNotPrivate) {
+		t.Errorf("Ensure() on a group-writable directory error = %v, want not private", err)
+	}
+	// Directories others can only read, as made under a umask of 022, are fine
+	if err := os.Chmod(shared, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := Ensure(shared); err != nil {
+		t.Errorf("Ensure() on a readable directory error = %v", err)
+	}
+
+	link := filepath.Join(root, "link")
+	if err := os.Symlink(dir, link); err != nil {
+		t.Fatal(err)
+	}
+	if err := Ensure(link); err == nil {
+		t.Error("Ensure() on a symlink error = nil")
+	}
+
+	token := filepath.Join(dir, "daemon.token")
+	if err := os.WriteFile(token, []byte("secret"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := Check(token); err != nil {
+		t.Errorf("Check() on a readable file error = %v", err)
+	}
+	if err := CheckPrivate(token); !errors.Is(err, errNotPrivate) {
+		t.Errorf("CheckPrivate() on a readable file error = %v, want not private", err)
+	}
+	if err := os.Chmod(token, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := CheckPrivate(token); err != nil {
+		t.Errorf("CheckPrivate() on a private file error = %v", err)
+	}
+}
+
+func TestCheck_Owners(t *testing.T) {
+	// Files of other users can only be made as root, and as root every
+	// ancestor belongs to the current user
+	if os.Getuid() != 0 {
+		t.Skip("changing owners requires root")
+	}
+	dir := filepath.Join(t.TempDir(), "gismo")
+	if err := os.Mkdir(dir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chown(dir, 65534, 65534); err != nil {
+		t.Skipf("chown: %v", err)
+	}
+	if err := Check(dir); !errors.Is(err, errNotPrivate) {
+		t.Errorf("Check() on another user's directory error = %v, want not private", err)
+	}
+
+	// A directory pre-created by another user can't hold the current user's state
+	state := filepath.Join(dir, "sessions")
+	if err := os.Mkdir(state, 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := Check(state); !errors.Is(err, errNotPrivate) {
+		t.Errorf("Check() under another user's directory error = %v, want not private", err)
+	}
+}
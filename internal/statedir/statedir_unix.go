@@ -0,0 +1,26 @@
+//go:build unix
+
+package statedir
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// checkOwner reports an error unless the current user owns path, or root does
+// when rootOK is set, and other users have none of the permissions in others.
+// Directories owned by root, such as /tmp, are trusted as they are.
+func checkOwner(path string, info os.FileInfo, rootOK bool, others os.FileMode) error {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok || (rootOK && stat.Uid == 0) {
+		return nil
+	}
+	if int(stat.Uid) != os.Getuid() {
+		return fmt.Errorf("%s is owned by uid %d: %w", path, stat.Uid, errNotPrivate)
+	}
+	if perm := info.Mode().Perm(); perm&others != 0 {
+		return fmt.Errorf("%s has mode %#o: %w", path, perm, errNotPrivate)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package linters
+
+import (
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+)
+
+// ExecRecord describes one external command gismo ran
+type ExecRecord struct {
+	// Args is the command line as executed, including resource wrappers
+	Args []string
+	// Dir is the working directory of the command
+	Dir string
+	// Start is when the command started
+	Start time.Time
+	// Duration is how long the command ran
+	Duration time.Duration
+	// ExitCode is the exit status, -1 if the command didn't start or was killed
+	ExitCode int
+	// Err is the error from running the command, if any
+	Err error
+}
+
+var (
+	execObserverMu sync.RWMutex
+	execObserver   func(ExecRecord)
+)
+
+// SetExecObserver registers fn to receive a record of every command run through
+// Run; nil stops auditing. fn may be called from several goroutines at once.
+func SetExecObserver(fn func(ExecRecord)) {
+	execObserverMu.Lock()
+	defer execObserverMu.Unlock()
+	execObserver = fn
+}
+
+// Run runs cmd and reports it to the exec observer. Linters run every external
+// command through it so users get a precise record of what the hook executed.
+func Run(cmd *exec.Cmd) error {
+	start := time.Now()
+	err := cmd.Run()
+
+	execObserverMu.RLock()
+	observe := execObserver
+	execObserverMu.RUnlock()
+	if observe == nil {
+		return err
+	}
+
+	dir := cmd.Dir
+	if dir == "" {
+		dir, _ = os.Getwd()
+	}
+	exitCode := -1
+	if cmd.ProcessState != nil {
+		exitCode = cmd.ProcessState.ExitCode()
+	}
+	args := []string{cmd.Path}
+	if len(cmd.Args) > 1 {
+		args = append(args, cmd.Args[1:]...)
+	}
+	observe(ExecRecord{
+		Args:     args,
+		Dir:      dir,
+		Start:    start,
+		Duration: time.Since(start),
+		ExitCode: exitCode,
+		Err:      err,
+	})
+	return err
+}
@@ -0,0 +1,60 @@
+package linters
+
+import (
+	"os/exec"
+	"reflect"
+	"testing"
+)
+
+func TestRun_ReportsExecRecord(t *testing.T) {
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("sh not installed")
+	}
+	var records []ExecRecord
+	SetExecObserver(func(record ExecRecord) { records = append(records, record) })
+	defer SetExecObserver(nil)
+
+	dir := t.TempDir()
+	tests := []struct {
+		name     string
+		script   string
+		wantCode int
+		wantErr  bool
+	}{
+		{"success", "exit 0", 0, false},
+		{"failure", "exit 3", 3, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records = nil
+			cmd := exec.Command("sh", "-c", tt.script)
+			cmd.Dir = dir
+			if err := Run(cmd); (err != nil) != tt.wantErr {
+				t.Fatalf("Run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(records) != 1 {
+				t.Fatalf("expected 1 exec record, got %d", len(records))
+			}
+			record := records[0]
+			if !reflect.DeepEqual(record.Args, []string{sh, "-c", tt.script}) {
+				t.Errorf("Args = %v", record.Args)
+			}
+			if record.Dir != dir || record.ExitCode != tt.wantCode || (record.Err != nil) != tt.wantErr {
+				t.Errorf("record = %+v, want dir %s exit %d", record, dir, tt.wantCode)
+			}
+			if record.Start.IsZero() {
+				t.Error("expected start time")
+			}
+		})
+	}
+
+	// Commands that can't start are recorded too
+	records = nil
+	if err := Run(exec.Command("/nonexistent/gismo-tool")); err == nil {
+		t.Fatal("expected error for missing binary")
+	}
+	if len(records) != 1 || records[0].ExitCode != -1 {
+		t.Errorf("expected failed start recorded with exit code -1, got %+v", records)
+	}
+}
@@ -0,0 +1,256 @@
+package linters
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// cacheEnvVars maps environment variables that relocate tool-internal caches to
+// the subdirectory of the cache root they point at
+var cacheEnvVars = map[string]string{
+	"GOLANGCI_LINT_CACHE": "golangci-lint",
+	"RUFF_CACHE_DIR":      "ruff",
+}
+
+// CacheDirs pins tool-internal caches to stable locations so they survive across
+// hook invocations
+type CacheDirs struct {
+	// Root holds one subdirectory per tool, empty leaves caches where tools put them
+	Root string
+	// CargoTarget also points CARGO_TARGET_DIR into Root, sharing build output
+	// between hook runs at the cost of not reusing the project's own target dir
+	CargoTarget bool
+}
+
+// CommandRunner builds and throttles the external tool commands linters run. It
+// holds the per-tool concurrency limits, resource limits, cache locations and
+// per-linter environments, so each engine carries its own settings. A nil
+// runner starts tools unthrottled with the inherited environment.
+type CommandRunner struct {
+	limiter   *ToolLimiter
+	resources ResourceLimits
+	cacheDirs CacheDirs
+
+	mu   sync.RWMutex
+	envs map[string]CommandEnv
+}
+
+// RunnerConfig configures a CommandRunner
+type RunnerConfig struct {
+	// ToolLimits caps concurrent processes per tool on top of DefaultToolLimits;
+	// a limit of 0 removes a default
+	ToolLimits map[string]int
+	// ResourceLimits sets nice, ionice and rlimits for spawned tools
+	ResourceLimits ResourceLimits
+	// CacheDirs pins tool-internal caches
+	CacheDirs CacheDirs
+}
+
+// CommandRunnerAware is implemented by linters that run external tools, so an
+// engine can share one runner between them
+type CommandRunnerAware interface {
+	SetCommandRunner(runner *CommandRunner)
+}
+
+// NewCommandRunner creates a runner with the given settings
+func NewCommandRunner(config RunnerConfig) *CommandRunner {
+	return &CommandRunner{
+		limiter:   NewToolLimiter(withDefaultToolLimits(config.ToolLimits)),
+		resources: config.ResourceLimits,
+		cacheDirs: config.CacheDirs,
+		envs:      make(map[string]CommandEnv),
+	}
+}
+
+// Acquire waits for a slot for tool, given as a name or path to its binary, and
+// returns a function releasing it. Linters call it right before starting the
+// process Command built.
+func (r *CommandRunner) Acquire(ctx context.Context, tool string) (func(), error) {
+	if r == nil {
+		return func() {}, nil
+	}
+	return r.limiter.Acquire(ctx, tool)
+}
+
+// CacheLocation returns the cache directory for tool, creating it, or "" when
+// caches aren't pinned. Linters use it for tools configured by flags rather than
+// environment variables.
+func (r *CommandRunner) CacheLocation(tool string) string {
+	if r == nil {
+		return ""
+	}
+	root := r.cacheDirs.Root
+	if root == "" {
+		return ""
+	}
+	if err := ensureCacheRoot(root); err != nil {
+		return ""
+	}
+	dir := filepath.Join(root, tool)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return ""
+	}
+	return dir
+}
+
+// ensureCacheRoot creates the cache root with a .gitignore so caches inside a
+// repository never show up as changes
+func ensureCacheRoot(root string) error {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return err
+	}
+	ignore := filepath.Join(root, ".gitignore")
+	if _, err := os.Stat(ignore); os.IsNotExist(err) {
+		return os.WriteFile(ignore, []byte("*\n"), 0o644) // #nosec G306 - not sensitive
+	}
+	return nil
+}
+
+// CommandEnv holds environment settings for one linter's subprocesses
+type CommandEnv struct {
+	// Env sets variables such as NODE_OPTIONS or GOFLAGS, expanding $VAR references
+	Env map[string]string
+	// Path lists directories prepended to PATH, relative ones resolved against
+	// the working directory
+	Path []string
+}
+
+// SetCommandEnv sets the environment for subprocesses of the named linter
+func (r *CommandRunner) SetCommandEnv(linter string, env CommandEnv) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(env.Env) == 0 && len(env.Path) == 0 {
+		delete(r.envs, linter)
+		return
+	}
+	r.envs[linter] = env
+}
+
+// Command builds the command for an external tool run on behalf of linter. It is
+// the shared command builder all linters use: it resolves name against the
+// linter's PATH entries, applies its environment and cache locations, and wraps
+// the tool with the configured resource limits.
+func (r *CommandRunner) Command(ctx context.Context, linter, name string, args ...string) *exec.Cmd {
+	if r == nil {
+		return exec.CommandContext(ctx, name, args...) // #nosec G204 - linters pass resolved tool paths
+	}
+	r.mu.RLock()
+	linterEnv := r.envs[linter]
+	r.mu.RUnlock()
+
+	vars := r.cacheEnv()
+	pathDirs := linterEnv.pathDirs()
+	if len(pathDirs) > 0 {
+		name = lookPathIn(name, pathDirs)
+		vars = append(vars, "PATH="+strings.Join(append(pathDirs, os.Getenv("PATH")), string(os.PathListSeparator)))
+	}
+	for key, value := range linterEnv.Env {
+		vars = append(vars, key+"="+os.ExpandEnv(value))
+	}
+
+	cmd := exec.CommandContext(ctx, name, args...) // #nosec G204 - linters pass resolved tool paths
+	cmd.Env = withEnv(nil, vars)
+	r.resources.Apply(cmd)
+	return cmd
+}
+
+// ExistingDir returns the directory of filePath, or its nearest existing parent
+// when a pending write creates new directories, for use as a tool's working directory
+func ExistingDir(filePath string) string {
+	dir := filepath.Dir(filePath)
+	for {
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// pathDirs returns the absolute PATH entries to prepend
+func (c CommandEnv) pathDirs() []string {
+	dirs := make([]string, 0, len(c.Path))
+	for _, dir := range c.Path {
+		dir = os.ExpandEnv(dir)
+		if abs, err := filepath.Abs(dir); err == nil {
+			dir = abs
+		}
+		dirs = append(dirs, dir)
+	}
+	return dirs
+}
+
+// lookPathIn returns the first executable called name in dirs, or name itself
+// so exec falls back to the regular PATH lookup
+func lookPathIn(name string, dirs []string) string {
+	if strings.ContainsRune(name, filepath.Separator) {
+		return name
+	}
+	for _, dir := range dirs {
+		path := filepath.Join(dir, name)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() && info.Mode()&0o111 != 0 {
+			return path
+		}
+	}
+	return name
+}
+
+// cacheEnv returns the cache variables to set, leaving ones the user set alone
+func (r *CommandRunner) cacheEnv() []string {
+	dirs := r.cacheDirs
+	if dirs.Root == "" || ensureCacheRoot(dirs.Root) != nil {
+		return nil
+	}
+
+	vars := make(map[string]string, len(cacheEnvVars)+1)
+	for name, sub := range cacheEnvVars {
+		vars[name] = sub
+	}
+	if dirs.CargoTarget {
+		vars["CARGO_TARGET_DIR"] = "cargo-target"
+	}
+
+	var env []string
+	for name, sub := range vars {
+		if _, set := os.LookupEnv(name); set {
+			continue
+		}
+		env = append(env, name+"="+filepath.Join(dirs.Root, sub))
+	}
+	return env
+}
+
+// withEnv returns base, or the current environment when base is nil, with vars
+// added. Existing entries for the same names are replaced.
+func withEnv(base, vars []string) []string {
+	if len(vars) == 0 {
+		return base
+	}
+	if base == nil {
+		base = os.Environ()
+	}
+	names := make(map[string]bool, len(vars))
+	for _, kv := range vars {
+		names[envName(kv)] = true
+	}
+	env := make([]string, 0, len(base)+len(vars))
+	for _, kv := range base {
+		if !names[envName(kv)] {
+			env = append(env, kv)
+		}
+	}
+	return append(env, vars...)
+}
+
+// envName returns the name part of a NAME=value entry
+func envName(kv string) string {
+	name, _, _ := strings.Cut(kv, "=")
+	return name
+}
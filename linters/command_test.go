@@ -0,0 +1,171 @@
+package linters
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCommand_CacheEnv(t *testing.T) {
+	t.Setenv("RUFF_CACHE_DIR", "/user/ruff")
+	root := filepath.Join(t.TempDir(), "gismo-cache")
+
+	tests := []struct {
+		name    string
+		dirs    CacheDirs
+		want    []string
+		notWant []string
+	}{
+		{"caches not pinned", CacheDirs{}, nil, []string{"GOLANGCI_LINT_CACHE="}},
+		{
+			"pinned caches",
+			CacheDirs{Root: root},
+			[]string{"GOLANGCI_LINT_CACHE=" + filepath.Join(root, "golangci-lint"), "RUFF_CACHE_DIR=/user/ruff"},
+			[]string{"CARGO_TARGET_DIR=" + filepath.Join(root, "cargo-target")},
+		},
+		{
+			"cargo target dir",
+			CacheDirs{Root: root, CargoTarget: true},
+			[]string{"CARGO_TARGET_DIR=" + filepath.Join(root, "cargo-target")},
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := NewCommandRunner(RunnerConfig{CacheDirs: tt.dirs})
+			cmd := runner.Command(context.Background(), "test", "true")
+
+			env := strings.Join(cmd.Env, "\n") + "\n"
+			for _, want := range tt.want {
+				if !strings.Contains(env, want+"\n") {
+					t.Errorf("expected %q in command environment", want)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(env, notWant) {
+					t.Errorf("unexpected %q in command environment", notWant)
+				}
+			}
+		})
+	}
+
+	if data, err := os.ReadFile(filepath.Join(root, ".gitignore")); err != nil || string(data) != "*\n" {
+		t.Errorf("expected cache root .gitignore, got %q, %v", data, err)
+	}
+}
+
+func TestCommand_LinterEnv(t *testing.T) {
+	t.Setenv("GISMO_TEST_HOME", "/home/test")
+	bin := t.TempDir()
+	tool := filepath.Join(bin, "fake-tool")
+	if err := os.WriteFile(tool, []byte("#!/bin/sh\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	runner := NewCommandRunner(RunnerConfig{})
+	runner.SetCommandEnv("test", CommandEnv{
+		Env:  map[string]string{"NODE_OPTIONS": "--max-old-space-size=4096", "GOPATH": "$GISMO_TEST_HOME/go"},
+		Path: []string{bin},
+	})
+
+	cmd := runner.Command(context.Background(), "test", "fake-tool", "--check")
+	if cmd.Path != tool {
+		t.Errorf("expected tool resolved from linter PATH, got %q", cmd.Path)
+	}
+	if !reflect.DeepEqual(cmd.Args[1:], []string{"--check"}) {
+		t.Errorf("unexpected args %v", cmd.Args)
+	}
+
+	env := strings.Join(cmd.Env, "\n") + "\n"
+	for _, want := range []string{
+		"NODE_OPTIONS=--max-old-space-size=4096\n",
+		"GOPATH=/home/test/go\n",
+		"PATH=" + bin + string(os.PathListSeparator),
+	} {
+		if !strings.Contains(env, want) {
+			t.Errorf("expected %q in command environment", want)
+		}
+	}
+
+	// Other linters are unaffected
+	other := runner.Command(context.Background(), "other", "fake-tool")
+	if other.Path == tool || other.Env != nil {
+		t.Errorf("linter env leaked to another linter: %q %v", other.Path, other.Env)
+	}
+}
+
+func TestCommandRunner_Acquire(t *testing.T) {
+	runner := NewCommandRunner(RunnerConfig{ToolLimits: map[string]int{"ruff": 1, "golangci-lint": 0}})
+	release, err := runner.Acquire(context.Background(), "ruff")
+	if err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := runner.Acquire(ctx, "ruff"); err == nil {
+		t.Error("expected the ruff limit to hold")
+	}
+	// Runners don't share slots, so engines with different settings don't interfere
+	if other, err := NewCommandRunner(RunnerConfig{ToolLimits: map[string]int{"ruff": 1}}).Acquire(context.Background(), "ruff"); err != nil {
+		t.Errorf("separate runner blocked: %v", err)
+	} else {
+		other()
+	}
+
+	// Defaults apply unless removed
+	cargo, err := runner.Acquire(context.Background(), "cargo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cargo()
+	if _, err := runner.Acquire(ctx, "cargo"); err == nil {
+		t.Error("expected the default cargo limit to hold")
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := runner.Acquire(ctx, "golangci-lint"); err != nil {
+			t.Errorf("removed default limit still applies: %v", err)
+		}
+	}
+}
+
+func TestCommandRunner_CacheLocation(t *testing.T) {
+	if got := NewCommandRunner(RunnerConfig{}).CacheLocation("eslint"); got != "" {
+		t.Errorf("CacheLocation() without root = %q, want empty", got)
+	}
+
+	root := t.TempDir()
+	got := NewCommandRunner(RunnerConfig{CacheDirs: CacheDirs{Root: root}}).CacheLocation("eslint")
+	if got != filepath.Join(root, "eslint") {
+		t.Fatalf("CacheLocation() = %q", got)
+	}
+	if info, err := os.Stat(got); err != nil || !info.IsDir() {
+		t.Errorf("expected cache directory to be created: %v", err)
+	}
+}
+
+func TestWithEnv(t *testing.T) {
+	got := withEnv([]string{"A=1", "B=2"}, []string{"B=3", "C=4"})
+	want := []string{"A=1", "B=3", "C=4"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("withEnv() = %v, want %v", got, want)
+	}
+	if got := withEnv(nil, nil); got != nil {
+		t.Errorf("withEnv() without vars should keep inheriting the environment, got %v", got)
+	}
+}
+
+func TestExistingDir(t *testing.T) {
+	dir := t.TempDir()
+	if got := ExistingDir(filepath.Join(dir, "a.sh")); got != dir {
+		t.Errorf("ExistingDir() = %q, want %q", got, dir)
+	}
+	if got := ExistingDir(filepath.Join(dir, "new", "nested", "a.sh")); got != dir {
+		t.Errorf("ExistingDir() for new directories = %q, want %q", got, dir)
+	}
+}
@@ -0,0 +1,41 @@
+package conflicts
+
+// ConflictConfig holds configuration for the merge conflict marker linter
+type ConflictConfig struct {
+	// Severity of reported issues, "error" (default) blocks the change
+	Severity *string `json:"severity,omitempty"`
+	// AllowedPaths are glob patterns, matched against the path and its base name,
+	// of files that may hold conflict markers, such as test fixtures
+	AllowedPaths []string `json:"allowedPaths,omitempty"`
+}
+
+// configSchema is the JSON Schema for ConflictConfig
+const configSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "properties": {
+    "severity": {
+      "type": "string",
+      "enum": [
+        "error",
+        "warning",
+        "info"
+      ],
+      "description": "Severity of reported issues, \"error\" by default"
+    },
+    "allowedPaths": {
+      "type": "array",
+      "items": {
+        "type": "string"
+      },
+      "description": "Glob patterns of files that may hold conflict markers, e.g. \"testdata/*.txt\""
+    }
+  },
+  "additionalProperties": false
+}`
+
+// DefaultConflictConfig returns the default configuration for conflict marker checks
+func DefaultConflictConfig() *ConflictConfig {
+	severity := "error"
+	return &ConflictConfig{Severity: &severity}
+}
@@ -0,0 +1,223 @@
+package conflicts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+// RuleConflictMarker is the rule reported for unresolved merge conflicts
+const RuleConflictMarker = "conflict-marker"
+
+// markerKind is the kind of merge conflict marker a line is
+type markerKind int
+
+const (
+	notMarker markerKind = iota
+	openingMarker
+	baseMarker
+	separatorMarker
+	closingMarker
+)
+
+// markdownExtensions are the documentation files whose fenced code blocks may
+// show conflict markers, as in docs about resolving merges
+var markdownExtensions = map[string]bool{".md": true, ".markdown": true, ".mdx": true}
+
+// ConflictLinter blocks writes that leave unresolved merge conflict markers in
+// any text file
+type ConflictLinter struct {
+	mu     sync.RWMutex
+	config *ConflictConfig
+}
+
+// NewConflictLinter creates a new conflict marker linter with default configuration
+func NewConflictLinter() *ConflictLinter {
+	return NewConflictLinterWithConfig(nil)
+}
+
+// NewConflictLinterWithConfig creates a new conflict marker linter with custom configuration
+func NewConflictLinterWithConfig(config *ConflictConfig) *ConflictLinter {
+	if config == nil {
+		config = DefaultConflictConfig()
+	}
+	return &ConflictLinter{config: config}
+}
+
+// Name returns the linter name
+func (l *ConflictLinter) Name() string {
+	return "conflicts"
+}
+
+// Capabilities reports the built-in checks and the external tools used when installed
+func (l *ConflictLinter) Capabilities() linters.Capabilities {
+	return linters.Capabilities{
+		Embedded: []string{"merge conflict markers"},
+	}
+}
+
+// Rules describes the check rules
+func (l *ConflictLinter) Rules() map[string]string {
+	return map[string]string{
+		RuleConflictMarker: "Unresolved merge conflicts, between <<<<<<< and >>>>>>> markers, break the build or ship both sides of the conflict",
+	}
+}
+
+// CanHandle returns true for every file; binary content is skipped in Lint
+func (l *ConflictLinter) CanHandle(filePath string) bool {
+	return true
+}
+
+// SetConfig updates the linter configuration
+func (l *ConflictLinter) SetConfig(config []byte) error {
+	conflictConfig := DefaultConflictConfig()
+	if err := json.Unmarshal(config, conflictConfig); err != nil {
+		return fmt.Errorf("failed to parse conflicts config: %w", err)
+	}
+	for _, pattern := range conflictConfig.AllowedPaths {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid allowed path %q: %w", pattern, err)
+		}
+	}
+	l.mu.Lock()
+	l.config = conflictConfig
+	l.mu.Unlock()
+	return nil
+}
+
+// ConfigSchema returns the JSON Schema for the linter configuration
+func (l *ConflictLinter) ConfigSchema() json.RawMessage {
+	return json.RawMessage(configSchema)
+}
+
+// Lint reports unresolved merge conflicts and markers left over from resolving one
+func (l *ConflictLinter) Lint(ctx context.Context, filePath string, content []byte) (*linters.LintResult, error) {
+	l.mu.RLock()
+	config := l.config
+	l.mu.RUnlock()
+
+	result := &linters.LintResult{Success: true}
+	if bytes.IndexByte(content, 0) >= 0 || allowedPath(filePath, config.AllowedPaths) {
+		return result, nil
+	}
+
+	severity := "error"
+	if config.Severity != nil && *config.Severity != "" {
+		severity = *config.Severity
+	}
+	report := func(line int, message string) {
+		result.Issues = append(result.Issues, linters.Issue{
+			File:     filePath,
+			Line:     line,
+			Column:   1,
+			Severity: severity,
+			Message:  message,
+			Rule:     RuleConflictMarker,
+		})
+		if severity == "error" {
+			result.Success = false
+		}
+	}
+
+	markdown := markdownExtensions[strings.ToLower(filepath.Ext(filePath))]
+	fence := ""
+	// opening is the line of the open conflict's <<<<<<< marker, 0 when none is open
+	opening, separated := 0, false
+	for i, line := range strings.Split(string(content), "\n") {
+		lineNum := i + 1
+		line = strings.TrimSuffix(line, "\r")
+		if markdown {
+			if marker := codeFence(line); marker != "" && (fence == "" || strings.HasPrefix(marker, fence)) {
+				if fence == "" {
+					fence = marker
+				} else {
+					fence = ""
+				}
+				continue
+			}
+			if fence != "" {
+				continue
+			}
+		}
+
+		switch kind, labeled := conflictMarker(line); kind {
+		case openingMarker:
+			if opening > 0 {
+				report(opening, fmt.Sprintf("leftover conflict marker %q; finish resolving the conflict and remove it", "<<<<<<<"))
+			}
+			opening, separated = lineNum, false
+		case baseMarker, separatorMarker:
+			separated = separated || opening > 0
+		case closingMarker:
+			switch {
+			case opening > 0 && separated:
+				report(opening, fmt.Sprintf("unresolved merge conflict on lines %d-%d; keep the right changes and remove the conflict markers", opening, lineNum))
+			case opening > 0 || labeled:
+				report(lineNum, fmt.Sprintf("leftover conflict marker %q; finish resolving the conflict and remove it", ">>>>>>>"))
+			}
+			opening = 0
+		}
+	}
+	if opening > 0 {
+		report(opening, fmt.Sprintf("leftover conflict marker %q; finish resolving the conflict and remove it", "<<<<<<<"))
+	}
+	return result, nil
+}
+
+// conflictMarker returns the kind of conflict marker line is: exactly seven
+// marker characters, followed by a space and a label or nothing. labeled is set
+// when a label follows, as git writes for the opening and closing markers.
+func conflictMarker(line string) (kind markerKind, labeled bool) {
+	if len(line) < 7 {
+		return notMarker, false
+	}
+	switch marker, rest := line[:7], line[7:]; {
+	case rest != "" && rest[0] != ' ':
+		return notMarker, false
+	case marker == "<<<<<<<":
+		return openingMarker, strings.TrimSpace(rest) != ""
+	case marker == "|||||||":
+		return baseMarker, strings.TrimSpace(rest) != ""
+	case marker == "=======" && strings.TrimSpace(rest) == "":
+		return separatorMarker, false
+	case marker == ">>>>>>>":
+		return closingMarker, strings.TrimSpace(rest) != ""
+	}
+	return notMarker, false
+}
+
+// codeFence returns the fence that opens or closes a Markdown code block on
+// line, such as "```", or "" if line isn't one
+func codeFence(line string) string {
+	trimmed := strings.TrimLeft(line, " ")
+	if len(line)-len(trimmed) > 3 {
+		return ""
+	}
+	for _, char := range []string{"`", "~"} {
+		if strings.HasPrefix(trimmed, strings.Repeat(char, 3)) {
+			return trimmed[:len(trimmed)-len(strings.TrimLeft(trimmed, char))]
+		}
+	}
+	return ""
+}
+
+// allowedPath reports whether filePath, or a trailing part of it such as its
+// base name, matches one of patterns
+func allowedPath(filePath string, patterns []string) bool {
+	parts := strings.Split(filepath.ToSlash(filePath), "/")
+	for _, pattern := range patterns {
+		for i := range parts {
+			if matched, _ := path.Match(pattern, strings.Join(parts[i:], "/")); matched {
+				return true
+			}
+		}
+	}
+	return false
+}
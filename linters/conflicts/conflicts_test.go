@@ -0,0 +1,116 @@
+package conflicts
+
+import (
+	"context"
+	"testing"
+)
+
+func TestConflictLinter_Lint(t *testing.T) {
+	tests := []struct {
+		name      string
+		file      string
+		content   string
+		wantLines []int
+	}{
+		{
+			name:      "unresolved conflict",
+			file:      "main.go",
+			content:   "package main\n\n<<<<<<< HEAD\nconst port = 8080\n=======\nconst port = 9090\n>>>>>>> feature\n",
+			wantLines: []int{3},
+		},
+		{
+			name:      "diff3 conflict with CRLF line endings",
+			file:      "app.py",
+			content:   "<<<<<<< ours\r\nx = 1\r\n||||||| base\r\nx = 0\r\n=======\r\nx = 2\r\n>>>>>>> theirs\r\n",
+			wantLines: []int{1},
+		},
+		{
+			name:      "leftover markers from a partial resolution",
+			file:      "config.yaml",
+			content:   "<<<<<<< HEAD\nport: 8080\nhost: example.com\n>>>>>>> feature\nname: app\n>>>>>>> main\n",
+			wantLines: []int{4, 6},
+		},
+		{
+			name:      "unclosed conflict",
+			file:      "index.ts",
+			content:   "<<<<<<< HEAD\nexport const a = 1\n=======\n",
+			wantLines: []int{1},
+		},
+		{
+			name:    "markdown setext heading and code block",
+			file:    "docs/merging.md",
+			content: "Merging\n=======\n\n```text\n<<<<<<< HEAD\nours\n=======\ntheirs\n>>>>>>> branch\n```\n",
+		},
+		{
+			name:      "markdown conflict outside code blocks",
+			file:      "README.md",
+			content:   "# App\n\n<<<<<<< HEAD\nRun make.\n=======\nRun task.\n>>>>>>> main\n",
+			wantLines: []int{3},
+		},
+		{
+			name:    "longer runs are not markers",
+			file:    "notes.txt",
+			content: "<<<<<<<<\n========\n>>>>>>>>\n",
+		},
+		{
+			name:    "binary content is skipped",
+			file:    "image.png",
+			content: "\x89PNG\x00\n<<<<<<< HEAD\n=======\n>>>>>>> main\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewConflictLinter().Lint(context.Background(), tt.file, []byte(tt.content))
+			if err != nil {
+				t.Fatalf("Lint() error = %v", err)
+			}
+			var lines []int
+			for _, issue := range result.Issues {
+				lines = append(lines, issue.Line)
+				if issue.Rule != RuleConflictMarker || issue.Severity != "error" {
+					t.Errorf("issue = %+v, want a blocking %s issue", issue, RuleConflictMarker)
+				}
+			}
+			if len(lines) != len(tt.wantLines) {
+				t.Fatalf("issue lines = %v, want %v", lines, tt.wantLines)
+			}
+			for i := range lines {
+				if lines[i] != tt.wantLines[i] {
+					t.Errorf("issue lines = %v, want %v", lines, tt.wantLines)
+				}
+			}
+			if result.Success != (len(tt.wantLines) == 0) {
+				t.Errorf("Success = %v, want blocking only when issues are found", result.Success)
+			}
+		})
+	}
+}
+
+func TestConflictLinter_SetConfig(t *testing.T) {
+	l := NewConflictLinter()
+	if err := l.SetConfig([]byte(`{"allowedPaths": ["testdata/*.txt"], "severity": "warning"}`)); err != nil {
+		t.Fatalf("SetConfig() error = %v", err)
+	}
+
+	content := []byte("<<<<<<< HEAD\na\n=======\nb\n>>>>>>> main\n")
+	result, err := l.Lint(context.Background(), "/src/app/testdata/conflict.txt", content)
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+	if len(result.Issues) != 0 {
+		t.Errorf("allowed path: issues = %+v", result.Issues)
+	}
+
+	result, err = l.Lint(context.Background(), "/src/app/merge.txt", content)
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+	if len(result.Issues) != 1 || result.Issues[0].Severity != "warning" || !result.Success {
+		t.Errorf("issues = %+v, success = %v, want one warning", result.Issues, result.Success)
+	}
+
+	if err := l.SetConfig([]byte(`{"allowedPaths": ["["]}`)); err == nil {
+		t.Error("invalid pattern: want an error")
+	}
+}
@@ -0,0 +1,56 @@
+package css
+
+// CSSConfig represents CSS, SCSS and Less linter specific configuration
+type CSSConfig struct {
+	// UseStylelint runs stylelint when it is installed (default true)
+	UseStylelint *bool `json:"useStylelint,omitempty"`
+	// MaxImportant is the number of !important declarations a file may have
+	// before the built-in checks warn (default 5)
+	MaxImportant *int `json:"maxImportant,omitempty"`
+	// DisabledRules lists stylelint rules and built-in rule names to skip
+	DisabledRules []string `json:"disabledRules,omitempty"`
+	// MaxFileSize is the maximum file size in bytes to lint (default 1MB)
+	MaxFileSize *int64 `json:"maxFileSize,omitempty"`
+}
+
+// configSchema is the JSON Schema for CSSConfig
+const configSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "properties": {
+    "useStylelint": {
+      "type": "boolean",
+      "description": "Run stylelint when it is installed"
+    },
+    "maxImportant": {
+      "type": "integer",
+      "minimum": 0,
+      "description": "Number of !important declarations allowed before the built-in checks warn"
+    },
+    "disabledRules": {
+      "type": "array",
+      "items": {
+        "type": "string"
+      },
+      "description": "Stylelint rules and built-in rule names to skip, such as color-no-invalid-hex"
+    },
+    "maxFileSize": {
+      "type": "integer",
+      "minimum": 0,
+      "description": "Maximum file size in bytes to lint"
+    }
+  },
+  "additionalProperties": false
+}`
+
+// DefaultCSSConfig returns the default configuration for CSS linting
+func DefaultCSSConfig() *CSSConfig {
+	useStylelint := true
+	maxImportant := 5
+	maxFileSize := int64(1024 * 1024)
+	return &CSSConfig{
+		UseStylelint: &useStylelint,
+		MaxImportant: &maxImportant,
+		MaxFileSize:  &maxFileSize,
+	}
+}
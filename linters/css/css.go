@@ -0,0 +1,440 @@
+package css
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/jrossi/gismo/linters"
+	"github.com/jrossi/gismo/toolcache"
+)
+
+// Rules reported by the built-in checks
+const (
+	RuleSyntax           = "syntax"
+	RuleImportantOveruse = "important-overuse"
+	RuleFileSize         = "file-size"
+)
+
+// stylelintSyntaxError is the rule stylelint gives to files it can't parse
+const stylelintSyntaxError = "CssSyntaxError"
+
+// extensions are the stylesheet extensions handled, and whether the dialect has
+// // line comments
+var extensions = map[string]bool{".css": false, ".scss": true, ".less": true}
+
+// closers maps each closing bracket to its opening one
+var closers = map[byte]byte{'}': '{', ')': '(', ']': '['}
+
+// stylelintResult is the report for one file in stylelint's JSON output
+type stylelintResult struct {
+	Source   string             `json:"source"`
+	Warnings []stylelintWarning `json:"warnings"`
+}
+
+// stylelintWarning is one finding in stylelint's JSON output
+type stylelintWarning struct {
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	Text     string `json:"text"`
+}
+
+// CSSLinter checks CSS, SCSS and Less stylesheets with stylelint, or with the
+// built-in bracket and !important checks when stylelint is unavailable
+type CSSLinter struct {
+	mu     sync.RWMutex
+	config *CSSConfig
+	// Tool cache used to discover stylelint outside node_modules; nil uses the disk-backed cache of the linted file's project
+	cache toolcache.ToolCache
+	// Runs external tools with the engine's limits, caches and environment
+	runner *linters.CommandRunner
+}
+
+// NewCSSLinter creates a new CSS linter with default configuration
+func NewCSSLinter() *CSSLinter {
+	return NewCSSLinterWithConfig(nil)
+}
+
+// NewCSSLinterWithConfig creates a new CSS linter with the given configuration
+func NewCSSLinterWithConfig(config *CSSConfig) *CSSLinter {
+	if config == nil {
+		config = DefaultCSSConfig()
+	}
+	return &CSSLinter{
+		config: config,
+		runner: linters.NewCommandRunner(linters.RunnerConfig{}),
+	}
+}
+
+// NewCSSLinterWithToolCache creates a CSS linter that discovers stylelint with the
+// given tool cache when the project doesn't install it in node_modules. A nil
+// cache falls back to the disk-backed cache rooted at the linted file's project.
+func NewCSSLinterWithToolCache(config *CSSConfig, cache toolcache.ToolCache) *CSSLinter {
+	l := NewCSSLinterWithConfig(config)
+	l.cache = cache
+	return l
+}
+
+// Name returns the linter name
+func (l *CSSLinter) Name() string {
+	return "css"
+}
+
+// SetCommandRunner sets the runner used to start external tools
+func (l *CSSLinter) SetCommandRunner(runner *linters.CommandRunner) {
+	l.runner = runner
+}
+
+// Capabilities reports the built-in checks and the external tools used when installed
+func (l *CSSLinter) Capabilities() linters.Capabilities {
+	return linters.Capabilities{
+		Embedded: []string{"bracket balance", "!important overuse"},
+		Tools:    []string{"stylelint"},
+	}
+}
+
+// CanHandle returns true for .css, .scss and .less files
+func (l *CSSLinter) CanHandle(filePath string) bool {
+	_, ok := extensions[strings.ToLower(filepath.Ext(filePath))]
+	return ok
+}
+
+// SetConfig updates the linter configuration
+func (l *CSSLinter) SetConfig(configData json.RawMessage) error {
+	// Settings not given keep their defaults
+	config := DefaultCSSConfig()
+	if err := json.Unmarshal(configData, config); err != nil {
+		return fmt.Errorf("failed to parse css config: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.config = config
+	return nil
+}
+
+// ConfigSchema returns the JSON Schema for the linter configuration
+func (l *CSSLinter) ConfigSchema() json.RawMessage {
+	return json.RawMessage(configSchema)
+}
+
+// Rules describes the built-in checks. Stylelint's own rules are documented at
+// https://stylelint.io/user-guide/rules
+func (l *CSSLinter) Rules() map[string]string {
+	return map[string]string{
+		RuleSyntax:           "Braces, parentheses and brackets are balanced outside strings and comments",
+		RuleImportantOveruse: "The file has no more !important declarations than the configured maxImportant",
+		RuleFileSize:         "The file is no larger than the configured maxFileSize",
+	}
+}
+
+// Lint checks a stylesheet with stylelint if it is installed and configured for
+// the project, and with the built-in checks otherwise
+func (l *CSSLinter) Lint(ctx context.Context, filePath string, content []byte) (*linters.LintResult, error) {
+	l.mu.RLock()
+	config := l.config
+	l.mu.RUnlock()
+
+	result := &linters.LintResult{
+		Success: true,
+		Issues:  []linters.Issue{},
+	}
+
+	if config.MaxFileSize != nil && int64(len(content)) > *config.MaxFileSize {
+		result.Issues = append(result.Issues, linters.Issue{
+			File:     filePath,
+			Line:     1,
+			Column:   1,
+			Severity: "error",
+			Message:  fmt.Sprintf("File size %d exceeds limit %d", len(content), *config.MaxFileSize),
+			Rule:     RuleFileSize,
+		})
+		result.Success = false
+		return result, nil
+	}
+
+	var issues []linters.Issue
+	ran := false
+	if stylelint := l.stylelintPath(config, filePath); stylelint != "" {
+		var err error
+		issues, ran, err = l.runStylelint(ctx, stylelint, filePath, content)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !ran {
+		issues = builtinChecks(config, filePath, content)
+	}
+
+	for _, issue := range issues {
+		if isDisabled(config, issue.Rule) {
+			continue
+		}
+		if issue.Severity == "error" {
+			result.Success = false
+		}
+		result.Issues = append(result.Issues, issue)
+	}
+	return result, nil
+}
+
+// isDisabled reports whether a rule is disabled by configuration
+func isDisabled(config *CSSConfig, rule string) bool {
+	for _, disabled := range config.DisabledRules {
+		if disabled == rule {
+			return true
+		}
+	}
+	return false
+}
+
+// stylelintPath returns the stylelint binary to run, or "" to use the built-in
+// checks. A project's node_modules/.bin comes first, since stylelint and its
+// plugins are usually installed per project.
+func (l *CSSLinter) stylelintPath(config *CSSConfig, filePath string) string {
+	if config.UseStylelint != nil && !*config.UseStylelint {
+		return ""
+	}
+	bin := "stylelint"
+	if runtime.GOOS == "windows" {
+		bin = "stylelint.cmd"
+	}
+	if absPath, err := filepath.Abs(filePath); err == nil {
+		for dir := filepath.Dir(absPath); ; {
+			path := filepath.Join(dir, "node_modules", ".bin", bin)
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				return path
+			}
+			parent := filepath.Dir(dir)
+			if parent == dir {
+				break
+			}
+			dir = parent
+		}
+	}
+
+	cache := l.cache
+	if cache == nil {
+		manager, err := toolcache.NewCacheManager(filePath)
+		if err != nil {
+			return ""
+		}
+		cache = manager
+	}
+	tool, err := cache.DiscoverTool("css", "stylelint")
+	if err != nil || tool == nil || !tool.Available {
+		return ""
+	}
+	return tool.Path
+}
+
+// runStylelint lints content with stylelint, read from stdin since the content
+// may not be on disk yet. It reports ran as false when the project has no
+// stylelint configuration, so the built-in checks run instead.
+func (l *CSSLinter) runStylelint(ctx context.Context, stylelint, filePath string, content []byte) (issues []linters.Issue, ran bool, err error) {
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		absPath = filePath
+	}
+
+	release, err := l.runner.Acquire(ctx, stylelint)
+	if err != nil {
+		return nil, false, err
+	}
+	defer release()
+
+	// --stdin-filename picks the syntax from the extension and resolves the
+	// project's configuration and .stylelintignore from the path
+	cmd := l.runner.Command(ctx, l.Name(), stylelint, "--formatter", "json", "--stdin-filename", absPath)
+	cmd.Dir = linters.ExistingDir(filePath)
+	cmd.Stdin = bytes.NewReader(content)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	// stylelint exits with 2 when it reports errors, and 78 without a configuration
+	runErr := linters.Run(cmd)
+	if strings.Contains(stderr.String(), "No configuration provided") {
+		return nil, false, nil
+	}
+
+	// stylelint 16 writes the report to stderr when it has findings
+	output := bytes.TrimSpace(stdout.Bytes())
+	if len(output) == 0 {
+		output = bytes.TrimSpace(stderr.Bytes())
+	}
+	var results []stylelintResult
+	if err := json.Unmarshal(output, &results); err != nil {
+		if runErr != nil {
+			return nil, false, fmt.Errorf("stylelint failed: %v\nstderr: %s", runErr, stderr.String())
+		}
+		return nil, false, fmt.Errorf("failed to parse stylelint output: %w", err)
+	}
+
+	for _, result := range results {
+		for _, warning := range result.Warnings {
+			rule := warning.Rule
+			if rule == stylelintSyntaxError {
+				rule = RuleSyntax
+			}
+			issues = append(issues, linters.ToolIssue("stylelint", warning.Severity, linters.Issue{
+				File:    filePath,
+				Line:    max(warning.Line, 1),
+				Column:  max(warning.Column, 1),
+				Message: strings.TrimSuffix(warning.Text, " ("+warning.Rule+")"),
+				Rule:    rule,
+			}))
+		}
+	}
+	return issues, true, nil
+}
+
+// position is a location in the stylesheet
+type position struct {
+	line, column int
+}
+
+// builtinChecks runs the checks used when stylelint is not available
+func builtinChecks(config *CSSConfig, filePath string, content []byte) []linters.Issue {
+	lineComments := extensions[strings.ToLower(filepath.Ext(filePath))]
+	issues, important := scan(filePath, content, lineComments)
+
+	limit := 5
+	if config.MaxImportant != nil {
+		limit = *config.MaxImportant
+	}
+	if len(important) > limit {
+		first := important[limit]
+		issues = append(issues, linters.Issue{
+			File:     filePath,
+			Line:     first.line,
+			Column:   first.column,
+			Severity: "warning",
+			Message: fmt.Sprintf("%d !important declarations, more than the limit of %d; use more specific selectors or cascade layers instead",
+				len(important), limit),
+			Rule: RuleImportantOveruse,
+		})
+	}
+	return issues
+}
+
+// scan walks the stylesheet outside strings and comments, and returns an issue
+// for each unbalanced bracket and the position of each !important
+func scan(filePath string, content []byte, lineComments bool) ([]linters.Issue, []position) {
+	var issues []linters.Issue
+	var important []position
+	type opener struct {
+		char byte
+		at   position
+	}
+	var open []opener
+
+	bracketIssue := func(at position, message string) {
+		issues = append(issues, linters.Issue{
+			File:     filePath,
+			Line:     at.line,
+			Column:   at.column,
+			Severity: "error",
+			Message:  message,
+			Rule:     RuleSyntax,
+		})
+	}
+
+	line, lineStart := 1, 0
+	// advance moves past content[i], counting lines
+	advance := func(i int) {
+		if content[i] == '\n' {
+			line++
+			lineStart = i + 1
+		}
+	}
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+		at := position{line, i - lineStart + 1}
+		switch {
+		case c == '\n':
+			advance(i)
+		case c == '\\':
+			// An escaped character is never a bracket or quote
+			if i+1 < len(content) {
+				i++
+				advance(i)
+			}
+		case c == '/' && i+1 < len(content) && content[i+1] == '*':
+			end := bytes.Index(content[i+2:], []byte("*/"))
+			stop := len(content)
+			if end >= 0 {
+				stop = i + 2 + end + 2
+			} else {
+				bracketIssue(at, "Unclosed comment")
+			}
+			for ; i < stop; i++ {
+				advance(i)
+			}
+			i--
+		case c == '/' && lineComments && i+1 < len(content) && content[i+1] == '/' &&
+			(len(open) == 0 || open[len(open)-1].char != '('):
+			// Line comments, except inside parentheses where // is part of a URL
+			for i < len(content) && content[i] != '\n' {
+				i++
+			}
+			i--
+		case c == '"' || c == '\'':
+			j := i + 1
+			for ; j < len(content) && content[j] != c && content[j] != '\n'; j++ {
+				if content[j] == '\\' {
+					j++
+				}
+			}
+			if j >= len(content) || content[j] != c {
+				bracketIssue(at, "Unclosed string")
+				// Carry on from the end of the line
+				j--
+			}
+			for ; i < j; i++ {
+				advance(i)
+			}
+		case c == '{' || c == '(' || c == '[':
+			open = append(open, opener{c, at})
+		case c == '}' || c == ')' || c == ']':
+			want := closers[c]
+			if len(open) == 0 {
+				bracketIssue(at, fmt.Sprintf("Unexpected %q without a matching %q", c, want))
+				continue
+			}
+			top := open[len(open)-1]
+			if top.char != want {
+				bracketIssue(at, fmt.Sprintf("Unexpected %q, expected the %q opened at line %d to be closed first", c, top.char, top.at.line))
+				// A closing brace also closes the brackets left open inside the block
+				if c != '}' {
+					continue
+				}
+				for len(open) > 0 && open[len(open)-1].char != want {
+					open = open[:len(open)-1]
+				}
+				if len(open) == 0 {
+					continue
+				}
+			}
+			open = open[:len(open)-1]
+		case c == '!':
+			rest := bytes.TrimLeft(content[i+1:], " \t")
+			if len(rest) >= len("important") && bytes.EqualFold(rest[:len("important")], []byte("important")) {
+				important = append(important, at)
+			}
+		}
+	}
+
+	for _, unclosed := range open {
+		bracketIssue(unclosed.at, fmt.Sprintf("Unclosed %q", unclosed.char))
+	}
+	return issues, important
+}
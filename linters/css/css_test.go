@@ -0,0 +1,210 @@
+package css
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/jrossi/gismo/toolcache"
+)
+
+// fakeStylelint writes a stylelint stand-in that records its arguments, prints
+// output to stderr as stylelint 16 does and exits with code, and returns its
+// path and the arguments file
+func fakeStylelint(t *testing.T, output string, code int) (string, string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake stylelint is a shell script")
+	}
+	dir := t.TempDir()
+	args := filepath.Join(dir, "args")
+	script := "#!/bin/sh\n" +
+		"echo \"$@\" > " + args + "\n" +
+		"cat > /dev/null\n" +
+		"cat >&2 <<'EOF'\n" + output + "\nEOF\n" +
+		fmt.Sprintf("exit %d\n", code)
+	path := filepath.Join(dir, "stylelint")
+	if err := os.WriteFile(path, []byte(script), 0700); err != nil {
+		t.Fatal(err)
+	}
+	return path, args
+}
+
+func TestCSSLinter_CanHandle(t *testing.T) {
+	linter := NewCSSLinter()
+	for path, want := range map[string]bool{
+		"styles/site.css":     true,
+		"theme/_vars.SCSS":    true,
+		"legacy/grid.less":    true,
+		"app/styles.css.map":  false,
+		"templates/base.sass": false,
+	} {
+		if got := linter.CanHandle(path); got != want {
+			t.Errorf("CanHandle(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestCSSLinter_BuiltinChecks(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		content string
+		// want lists the expected issues as "line:column rule"
+		want []string
+	}{
+		{
+			name:    "balanced",
+			path:    "site.css",
+			content: "a[href^=\"http\"] {\n  background: url(data:image/png;base64,AA==);\n  content: \"}\";\n}\n/* { */\n",
+		},
+		{
+			name:    "unclosed block",
+			path:    "site.css",
+			content: ".nav {\n  color: red;\n\n.footer {\n  color: blue;\n}\n",
+			want:    []string{"1:6 syntax"},
+		},
+		{
+			name:    "unexpected brace",
+			path:    "site.css",
+			content: "a { color: red; }\n}\n",
+			want:    []string{"2:1 syntax"},
+		},
+		{
+			name:    "unclosed parenthesis inside a block",
+			path:    "site.css",
+			content: "a {\n  width: calc(100% - 2px;\n}\np { margin: 0; }\n",
+			want:    []string{"3:1 syntax"},
+		},
+		{
+			name:    "unclosed string",
+			path:    "site.css",
+			content: "a {\n  content: \"open;\n}\n",
+			want:    []string{"2:12 syntax"},
+		},
+		{
+			name:    "scss line comments and urls",
+			path:    "theme.scss",
+			content: "// a { note\n.logo {\n  background: url(https://example.com/logo.png); // }\n  &:hover { color: #{$accent}; }\n}\n",
+		},
+		{
+			name:    "css has no line comments",
+			path:    "site.css",
+			content: "// a {\nb { color: red; }\n",
+			want:    []string{"1:6 syntax"},
+		},
+		{
+			name:    "important overuse",
+			path:    "site.less",
+			content: "a { color: red !important; }\nb { color: blue ! important; }\n/* !important */\ni { color: green !IMPORTANT; }\n",
+			want:    []string{"4:18 important-overuse"},
+		},
+	}
+
+	linter := NewCSSLinterWithToolCache(nil, toolcache.NewMemoryCache())
+	if err := linter.SetConfig(json.RawMessage(`{"maxImportant": 2}`)); err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := linter.Lint(context.Background(), filepath.Join(t.TempDir(), tt.path), []byte(tt.content))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, issue := range result.Issues {
+				got = append(got, fmt.Sprintf("%d:%d %s", issue.Line, issue.Column, issue.Rule))
+			}
+			if strings.Join(got, ", ") != strings.Join(tt.want, ", ") {
+				t.Errorf("issues = %v, want %v\n%+v", got, tt.want, result.Issues)
+			}
+		})
+	}
+}
+
+func TestCSSLinter_Stylelint(t *testing.T) {
+	stylelint, args := fakeStylelint(t, `[{"source":"/project/site.css","deprecations":[],"invalidOptionWarnings":[],"parseErrors":[],"errored":true,"warnings":[
+  {"line":2,"column":10,"endLine":2,"endColumn":13,"rule":"color-no-invalid-hex","severity":"error","text":"Unexpected invalid hex color \"#ff\" (color-no-invalid-hex)"},
+  {"line":4,"column":3,"rule":"declaration-block-no-duplicate-properties","severity":"warning","text":"Unexpected duplicate \"color\" (declaration-block-no-duplicate-properties)"},
+  {"line":6,"column":1,"rule":"CssSyntaxError","severity":"error","text":"Unclosed block (CssSyntaxError)"}
+]}]`, 2)
+	cache := toolcache.NewMemoryCache()
+	cache.AddTool("css", "stylelint", stylelint)
+	linter := NewCSSLinterWithToolCache(nil, cache)
+	if err := linter.SetConfig(json.RawMessage(`{"disabledRules": ["declaration-block-no-duplicate-properties"]}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	filePath := filepath.Join(t.TempDir(), "site.css")
+	result, err := linter.Lint(context.Background(), filePath, []byte("a {\n  color: #ff;\n"))
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+	if result.Success {
+		t.Error("Success = true, want false for an error-level finding")
+	}
+	if len(result.Issues) != 2 {
+		t.Fatalf("issues = %+v", result.Issues)
+	}
+	if issue := result.Issues[0]; issue.Line != 2 || issue.Column != 10 || issue.Severity != "error" ||
+		issue.Rule != "color-no-invalid-hex" || issue.Message != `Unexpected invalid hex color "#ff"` {
+		t.Errorf("first issue = %+v", issue)
+	}
+	if issue := result.Issues[1]; issue.Rule != RuleSyntax || issue.Message != "Unclosed block" {
+		t.Errorf("syntax issue = %+v", issue)
+	}
+
+	data, err := os.ReadFile(args)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := strings.TrimSpace(string(data)), "--formatter json --stdin-filename "+filePath; got != want {
+		t.Errorf("stylelint args = %q, want %q", got, want)
+	}
+}
+
+func TestCSSLinter_StylelintWithoutConfig(t *testing.T) {
+	stylelint, _ := fakeStylelint(t, "Error: No configuration provided for /project/site.css", 78)
+	cache := toolcache.NewMemoryCache()
+	cache.AddTool("css", "stylelint", stylelint)
+	linter := NewCSSLinterWithToolCache(nil, cache)
+
+	// Without a stylelint configuration the built-in checks run instead
+	result, err := linter.Lint(context.Background(), filepath.Join(t.TempDir(), "site.css"), []byte("a {\n"))
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+	if len(result.Issues) != 1 || result.Issues[0].Rule != RuleSyntax || result.Success {
+		t.Errorf("result = %+v", result)
+	}
+}
+
+func TestCSSLinter_StylelintInNodeModules(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake stylelint is a shell script")
+	}
+	root := t.TempDir()
+	bin := filepath.Join(root, "node_modules", ".bin")
+	if err := os.MkdirAll(bin, 0750); err != nil {
+		t.Fatal(err)
+	}
+	local := filepath.Join(bin, "stylelint")
+	if err := os.WriteFile(local, []byte("#!/bin/sh\necho '[]'\n"), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	cache := toolcache.NewMemoryCache()
+	cache.AddTool("css", "stylelint", "/usr/local/bin/stylelint")
+	linter := NewCSSLinterWithToolCache(nil, cache)
+	if got := linter.stylelintPath(linter.config, filepath.Join(root, "src", "styles", "site.scss")); got != local {
+		t.Errorf("stylelintPath() = %q, want the project's %q", got, local)
+	}
+	if got := linter.stylelintPath(linter.config, filepath.Join(t.TempDir(), "site.css")); got != "/usr/local/bin/stylelint" {
+		t.Errorf("stylelintPath() = %q, want the cached stylelint", got)
+	}
+}
@@ -0,0 +1,283 @@
+// Package custom wraps external commands declared in the config as linters, so
+// niche tools can be used without changes to gismo.
+package custom
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+// Output formats of custom linter commands
+const (
+	// FormatJSONLines is one JSON issue object per line; other lines are ignored
+	FormatJSONLines = "json-lines"
+	// FormatJSON is a JSON array of issue objects
+	FormatJSON = "json"
+	// FormatText is compiler-style "file:line:col: severity: message [rule]" lines,
+	// where the column, severity and rule are optional
+	FormatText = "text"
+)
+
+// textIssue matches one issue in FormatText output
+var textIssue = regexp.MustCompile(`^(?:[A-Za-z]:)?[^:]+:(\d+)(?::(\d+))?:\s*(?:(?i)(error|warning|warn|info|note|hint)\s*:\s*)?(.*?)(?:\s+\[([^\]\s]+)\])?$`)
+
+// Config declares a custom linter in the customLinters section of gismo.json
+type Config struct {
+	// Command runs the tool. {file} is replaced by the path of a temporary file
+	// holding the content being linted, named like the original, and {path} by
+	// the original path, which may not exist yet. The content is also on stdin.
+	Command string `json:"command"`
+	// Patterns are globs matched against the file path or its name, such as "*.xyz"
+	Patterns []string `json:"patterns"`
+	// OutputFormat is "json-lines" (default), "json" or "text"
+	OutputFormat string `json:"outputFormat,omitempty"`
+	// Severity is reported for issues whose output gives none (default "warning")
+	Severity string `json:"severity,omitempty"`
+}
+
+// toolIssue is an issue in JSON output, with the field names of linters.Issue.
+// Severity labels other than error, warning and info can be mapped with the
+// severityMap setting, keyed by the linter's name.
+type toolIssue struct {
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+	Rule     string `json:"rule"`
+}
+
+// Linter runs a configured command and parses the issues it prints
+type Linter struct {
+	name   string
+	config Config
+	argv   []string
+	// Runs external tools with the engine's limits, caches and environment
+	runner *linters.CommandRunner
+}
+
+// New returns the custom linter called name, or an error if its config is invalid
+func New(name string, config Config) (*Linter, error) {
+	argv, err := splitCommand(config.Command)
+	if err != nil {
+		return nil, fmt.Errorf("custom linter %s: %w", name, err)
+	}
+	if len(argv) == 0 {
+		return nil, fmt.Errorf("custom linter %s: command is empty", name)
+	}
+	if len(config.Patterns) == 0 {
+		return nil, fmt.Errorf("custom linter %s: patterns are empty, so it would lint nothing", name)
+	}
+	for _, pattern := range config.Patterns {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("custom linter %s: invalid pattern %q: %w", name, pattern, err)
+		}
+	}
+	switch config.OutputFormat {
+	case "":
+		config.OutputFormat = FormatJSONLines
+	case FormatJSONLines, FormatJSON, FormatText:
+	default:
+		return nil, fmt.Errorf("custom linter %s: unknown output format %q, expected json-lines, json or text", name, config.OutputFormat)
+	}
+	if config.Severity == "" {
+		config.Severity = "warning"
+	} else if !linters.IsSeverity(config.Severity) {
+		return nil, fmt.Errorf("custom linter %s: severity %q must be error, warning or info", name, config.Severity)
+	}
+	return &Linter{
+		name:   name,
+		config: config,
+		argv:   argv,
+		runner: linters.NewCommandRunner(linters.RunnerConfig{}),
+	}, nil
+}
+
+// Name returns the name the linter was declared with
+func (l *Linter) Name() string {
+	return l.name
+}
+
+// SetCommandRunner sets the runner used to start the command
+func (l *Linter) SetCommandRunner(runner *linters.CommandRunner) {
+	l.runner = runner
+}
+
+// Capabilities reports the command's binary as the linter's only tool
+func (l *Linter) Capabilities() linters.Capabilities {
+	return linters.Capabilities{
+		Embedded: []string{},
+		Tools:    []string{filepath.Base(l.argv[0])},
+	}
+}
+
+// CanHandle returns true for files matching one of the patterns
+func (l *Linter) CanHandle(filePath string) bool {
+	for _, pattern := range l.config.Patterns {
+		if matched, _ := filepath.Match(pattern, filePath); matched {
+			return true
+		}
+		if matched, _ := filepath.Match(pattern, filepath.Base(filePath)); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// Lint runs the command on content and parses its output. A command that fails
+// without printing any issue is reported as an error, since it most likely
+// didn't run at all.
+func (l *Linter) Lint(ctx context.Context, filePath string, content []byte) (*linters.LintResult, error) {
+	// The content may not be on disk yet, so the command gets a copy
+	dir, err := os.MkdirTemp("", "gismo-custom-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+	tempFile := filepath.Join(dir, filepath.Base(filePath))
+	if err := os.WriteFile(tempFile, content, 0600); err != nil {
+		return nil, fmt.Errorf("failed to write temporary file: %w", err)
+	}
+
+	replacer := strings.NewReplacer("{file}", tempFile, "{path}", filePath)
+	args := make([]string, len(l.argv)-1)
+	for i, arg := range l.argv[1:] {
+		args[i] = replacer.Replace(arg)
+	}
+
+	release, err := l.runner.Acquire(ctx, l.argv[0])
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	cmd := l.runner.Command(ctx, l.name, l.argv[0], args...)
+	cmd.Dir = linters.ExistingDir(filePath)
+	cmd.Stdin = bytes.NewReader(content)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	// Linters commonly exit non-zero when they report issues
+	runErr := linters.Run(cmd)
+
+	issues, err := l.parse(stdout.Bytes(), filePath)
+	if err != nil && runErr == nil {
+		return nil, err
+	}
+	if len(issues) == 0 && runErr != nil {
+		return nil, fmt.Errorf("%s failed: %v\nstderr: %s", l.argv[0], runErr, stderr.String())
+	}
+
+	result := &linters.LintResult{Success: true, Issues: issues}
+	for _, issue := range issues {
+		if issue.Severity == "error" {
+			result.Success = false
+		}
+	}
+	return result, nil
+}
+
+// parse converts the command's output to issues in filePath
+func (l *Linter) parse(output []byte, filePath string) ([]linters.Issue, error) {
+	var found []toolIssue
+	switch l.config.OutputFormat {
+	case FormatJSON:
+		if len(bytes.TrimSpace(output)) == 0 {
+			break
+		}
+		if err := json.Unmarshal(output, &found); err != nil {
+			return nil, fmt.Errorf("failed to parse %s output: %w", l.name, err)
+		}
+	case FormatJSONLines:
+		for _, line := range bytes.Split(output, []byte("\n")) {
+			var issue toolIssue
+			if err := json.Unmarshal(bytes.TrimSpace(line), &issue); err == nil && issue.Message != "" {
+				found = append(found, issue)
+			}
+		}
+	case FormatText:
+		for _, line := range strings.Split(string(output), "\n") {
+			match := textIssue.FindStringSubmatch(strings.TrimSpace(line))
+			if match == nil {
+				continue
+			}
+			issue := toolIssue{Severity: match[3], Message: match[4], Rule: match[5]}
+			issue.Line, _ = strconv.Atoi(match[1])
+			issue.Column, _ = strconv.Atoi(match[2])
+			found = append(found, issue)
+		}
+	}
+
+	issues := make([]linters.Issue, 0, len(found))
+	for _, each := range found {
+		issues = append(issues, l.issue(each, filePath))
+	}
+	return issues, nil
+}
+
+// issue converts a tool issue, keeping its severity label for severityMap
+func (l *Linter) issue(found toolIssue, filePath string) linters.Issue {
+	level := strings.ToLower(found.Severity)
+	issue := linters.ToolIssue(l.name, level, linters.Issue{
+		File:    filePath,
+		Line:    found.Line,
+		Column:  found.Column,
+		Message: found.Message,
+		Rule:    found.Rule,
+	})
+	switch {
+	case level == "":
+		issue.Severity = l.config.Severity
+	case level == "warn":
+		issue.Severity = "warning"
+	case linters.IsSeverity(level):
+		issue.Severity = level
+	}
+	return issue
+}
+
+// splitCommand splits a command line into arguments at spaces outside single or
+// double quotes, as a shell would without expansions
+func splitCommand(command string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	inArg := false
+	var quote rune
+	for _, r := range command {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote in command %q", quote, command)
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
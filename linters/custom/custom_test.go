@@ -0,0 +1,162 @@
+package custom
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr string
+	}{
+		{name: "valid", config: Config{Command: "mytool --json {file}", Patterns: []string{"*.xyz"}}},
+		{name: "empty command", config: Config{Command: "  ", Patterns: []string{"*.xyz"}}, wantErr: "command is empty"},
+		{name: "unterminated quote", config: Config{Command: `mytool "{file}`, Patterns: []string{"*.xyz"}}, wantErr: "unterminated"},
+		{name: "no patterns", config: Config{Command: "mytool"}, wantErr: "patterns are empty"},
+		{name: "invalid pattern", config: Config{Command: "mytool", Patterns: []string{"[a-"}}, wantErr: "invalid pattern"},
+		{name: "unknown format", config: Config{Command: "mytool", Patterns: []string{"*.xyz"}, OutputFormat: "xml"}, wantErr: "unknown output format"},
+		{name: "invalid severity", config: Config{Command: "mytool", Patterns: []string{"*.xyz"}, Severity: "fatal"}, wantErr: "severity"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New("mylint", tt.config)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("New() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSplitCommand(t *testing.T) {
+	args, err := splitCommand(`mytool --format 'a b' "{file}"  -x`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(args, "|"); got != "mytool|--format|a b|{file}|-x" {
+		t.Errorf("splitCommand() = %s", got)
+	}
+}
+
+func TestLinter_CanHandle(t *testing.T) {
+	linter, err := New("mylint", Config{Command: "mytool", Patterns: []string{"*.xyz", "schemas/*.json"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for path, want := range map[string]bool{
+		"/proj/data/a.xyz":  true,
+		"schemas/user.json": true,
+		"/proj/user.json":   false,
+		"/proj/a.xyz.bak":   false,
+	} {
+		if got := linter.CanHandle(path); got != want {
+			t.Errorf("CanHandle(%s) = %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestLinter_Parse(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		output string
+		want   []string
+	}{
+		{
+			name:   "json lines",
+			format: FormatJSONLines,
+			output: "checking...\n{\"line\":3,\"column\":2,\"severity\":\"error\",\"message\":\"bad token\",\"rule\":\"X1\"}\n{\"line\":5,\"message\":\"odd spacing\"}\n",
+			want:   []string{"3:2 error X1 bad token", "5:0 info  odd spacing"},
+		},
+		{
+			name:   "json array",
+			format: FormatJSON,
+			output: `[{"line":1,"column":1,"severity":"WARN","message":"deprecated","rule":"dep"}]`,
+			want:   []string{"1:1 warning dep deprecated"},
+		},
+		{
+			name:   "text",
+			format: FormatText,
+			output: "/tmp/a.xyz:4:7: error: missing end [syntax]\n/tmp/a.xyz:9: unused value\n2 problems\n",
+			want:   []string{"4:7 error syntax missing end", "9:0 info  unused value"},
+		},
+		{
+			name:   "empty json",
+			format: FormatJSON,
+			output: "\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			linter, err := New("mylint", Config{Command: "mytool", Patterns: []string{"*.xyz"}, OutputFormat: tt.format, Severity: "info"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			issues, err := linter.parse([]byte(tt.output), "/proj/a.xyz")
+			if err != nil {
+				t.Fatalf("parse() error = %v", err)
+			}
+			var got []string
+			for _, issue := range issues {
+				if issue.File != "/proj/a.xyz" || issue.Tool != "mylint" {
+					t.Errorf("issue %+v not attributed to the file and linter", issue)
+				}
+				got = append(got, fmt.Sprintf("%d:%d %s %s %s", issue.Line, issue.Column, issue.Severity, issue.Rule, issue.Message))
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("parse() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+
+	linter, _ := New("mylint", Config{Command: "mytool", Patterns: []string{"*.xyz"}, OutputFormat: FormatJSON})
+	if _, err := linter.parse([]byte("not json"), "a.xyz"); err == nil {
+		t.Error("parse() should reject output that isn't a JSON array")
+	}
+}
+
+func TestLinter_Lint(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+	dir := t.TempDir()
+	// The script reports each TODO line of the file it is given, and checks the
+	// content also arrives on stdin
+	script := filepath.Join(dir, "todo-lint")
+	if err := os.WriteFile(script, []byte(`#!/bin/sh
+cmp -s "$1" - || { echo "stdin differs" >&2; exit 3; }
+grep -n TODO "$1" | while IFS=: read -r n _; do
+  echo "{\"line\":$n,\"column\":1,\"severity\":\"warning\",\"message\":\"TODO in $2\",\"rule\":\"todo\"}"
+done
+`), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	linter, err := New("todo", Config{Command: script + " {file} {path}", Patterns: []string{"*.xyz"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	filePath := filepath.Join(dir, "new", "a.xyz")
+	result, err := linter.Lint(context.Background(), filePath, []byte("one\nTODO two\n"))
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+	if len(result.Issues) != 1 || result.Issues[0].Line != 2 || result.Issues[0].Message != "TODO in "+filePath || !result.Success {
+		t.Errorf("Lint() = %+v", result)
+	}
+
+	// A command that fails without reporting issues is an error
+	failing, _ := New("fail", Config{Command: "sh -c 'exit 4'", Patterns: []string{"*.xyz"}})
+	if _, err := failing.Lint(context.Background(), filePath, []byte("x")); err == nil {
+		t.Error("Lint() should fail when the command fails without output")
+	}
+}
@@ -0,0 +1,193 @@
+package linters
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxDiffCells bounds the LCS table so diffs of very large files fail fast
+const maxDiffCells = 4_000_000
+
+// diffContext is the number of unchanged lines shown around each change
+const diffContext = 3
+
+// UnifiedDiff returns a unified diff turning before into after, labelled with path.
+// It returns an empty string when the inputs are identical, and ok=false when the
+// files are too large to diff cheaply.
+func UnifiedDiff(path string, before, after []byte) (diff string, ok bool) {
+	a := splitLines(string(before))
+	b := splitLines(string(after))
+	if len(a)*len(b) > maxDiffCells {
+		return "", false
+	}
+
+	ops := diffLines(a, b)
+	changed := false
+	for _, op := range ops {
+		if op.kind != ' ' {
+			changed = true
+			break
+		}
+	}
+	if !changed {
+		return "", true
+	}
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "--- a/%s\n+++ b/%s\n", path, path)
+
+	// Group operations into hunks separated by more than 2*diffContext unchanged lines
+	for start := 0; start < len(ops); {
+		// Find the next change
+		for start < len(ops) && ops[start].kind == ' ' {
+			start++
+		}
+		if start == len(ops) {
+			break
+		}
+
+		hunkStart := start - diffContext
+		if hunkStart < 0 {
+			hunkStart = 0
+		}
+		end := start
+		for end < len(ops) {
+			if ops[end].kind != ' ' {
+				end++
+				continue
+			}
+			// Count the run of unchanged lines
+			run := end
+			for run < len(ops) && ops[run].kind == ' ' {
+				run++
+			}
+			if run == len(ops) || run-end > 2*diffContext {
+				break
+			}
+			end = run
+		}
+		hunkEnd := end + diffContext
+		if hunkEnd > len(ops) {
+			hunkEnd = len(ops)
+		}
+
+		writeHunk(&out, ops[hunkStart:hunkEnd])
+		start = hunkEnd
+	}
+
+	return out.String(), true
+}
+
+// ChangedLines counts the lines added and removed turning before into after.
+// Lines shared at the start and end are skipped before diffing, and when the
+// rest is too large to diff cheaply, all of it counts as changed.
+func ChangedLines(before, after []byte) (added, removed int) {
+	a := splitLines(string(before))
+	b := splitLines(string(after))
+	for len(a) > 0 && len(b) > 0 && a[0] == b[0] {
+		a, b = a[1:], b[1:]
+	}
+	for len(a) > 0 && len(b) > 0 && a[len(a)-1] == b[len(b)-1] {
+		a, b = a[:len(a)-1], b[:len(b)-1]
+	}
+	if len(a)*len(b) > maxDiffCells {
+		return len(b), len(a)
+	}
+	for _, op := range diffLines(a, b) {
+		switch op.kind {
+		case '+':
+			added++
+		case '-':
+			removed++
+		}
+	}
+	return added, removed
+}
+
+// diffOp is a single line in an edit script: ' ' keep, '-' delete, '+' insert
+type diffOp struct {
+	kind   byte
+	line   string
+	aIndex int // 1-based line number in before (for ' ' and '-')
+	bIndex int // 1-based line number in after (for ' ' and '+')
+}
+
+// diffLines computes a line edit script using a longest common subsequence table
+func diffLines(a, b []string) []diffOp {
+	n, m := len(a), len(b)
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	ops := make([]diffOp, 0, n+m)
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, diffOp{kind: ' ', line: a[i], aIndex: i + 1, bIndex: j + 1})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, diffOp{kind: '-', line: a[i], aIndex: i + 1, bIndex: j + 1})
+			i++
+		default:
+			ops = append(ops, diffOp{kind: '+', line: b[j], aIndex: i + 1, bIndex: j + 1})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		ops = append(ops, diffOp{kind: '-', line: a[i], aIndex: i + 1, bIndex: m + 1})
+	}
+	for ; j < m; j++ {
+		ops = append(ops, diffOp{kind: '+', line: b[j], aIndex: n + 1, bIndex: j + 1})
+	}
+	return ops
+}
+
+// writeHunk writes one hunk header and its lines
+func writeHunk(out *strings.Builder, ops []diffOp) {
+	aStart, bStart := ops[0].aIndex, ops[0].bIndex
+	aCount, bCount := 0, 0
+	for _, op := range ops {
+		if op.kind != '+' {
+			aCount++
+		}
+		if op.kind != '-' {
+			bCount++
+		}
+	}
+	// Empty ranges start at the line before, per the unified diff format
+	if aCount == 0 {
+		aStart--
+	}
+	if bCount == 0 {
+		bStart--
+	}
+
+	fmt.Fprintf(out, "@@ -%d,%d +%d,%d @@\n", aStart, aCount, bStart, bCount)
+	for _, op := range ops {
+		out.WriteByte(op.kind)
+		out.WriteString(op.line)
+		out.WriteByte('\n')
+	}
+}
+
+// splitLines splits content into lines without their trailing newlines
+func splitLines(content string) []string {
+	if content == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+}
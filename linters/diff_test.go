@@ -0,0 +1,78 @@
+package linters
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name   string
+		before string
+		after  string
+		want   string
+	}{
+		{
+			name:   "identical",
+			before: "a\nb\n",
+			after:  "a\nb\n",
+			want:   "",
+		},
+		{
+			name:   "single change",
+			before: "a\nb\nc\n",
+			after:  "a\nB\nc\n",
+			want:   "--- a/f.go\n+++ b/f.go\n@@ -1,3 +1,3 @@\n a\n-b\n+B\n c\n",
+		},
+		{
+			name:   "insert into empty file",
+			before: "",
+			after:  "x\n",
+			want:   "--- a/f.go\n+++ b/f.go\n@@ -0,0 +1,1 @@\n+x\n",
+		},
+		{
+			name:   "distant changes produce separate hunks",
+			before: "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n",
+			after:  "one\n2\n3\n4\n5\n6\n7\n8\n9\nten\n",
+			want: "--- a/f.go\n+++ b/f.go\n@@ -1,4 +1,4 @@\n-1\n+one\n 2\n 3\n 4\n" +
+				"@@ -7,4 +7,4 @@\n 7\n 8\n 9\n-10\n+ten\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := UnifiedDiff("f.go", []byte(tt.before), []byte(tt.after))
+			if !ok {
+				t.Fatal("UnifiedDiff() ok = false")
+			}
+			if got != tt.want {
+				t.Errorf("UnifiedDiff() =\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnifiedDiff_TooLarge(t *testing.T) {
+	big := []byte(strings.Repeat("line\n", 3000))
+	if _, ok := UnifiedDiff("f.go", big, big); ok {
+		t.Error("expected oversized inputs to be rejected")
+	}
+}
+
+func TestChangedLines(t *testing.T) {
+	tests := []struct {
+		before, after  string
+		added, removed int
+	}{
+		{"a\nb\nc\n", "a\nb\nc\n", 0, 0},
+		{"a\nb\nc\n", "a\nx\ny\nc\n", 2, 1},
+		{"", "a\nb\n", 2, 0},
+		{strings.Repeat("line\n", 3000) + "end\n", strings.Repeat("line\n", 3000) + "new\n", 1, 1},
+	}
+	for _, tt := range tests {
+		added, removed := ChangedLines([]byte(tt.before), []byte(tt.after))
+		if added != tt.added || removed != tt.removed {
+			t.Errorf("ChangedLines(%.20q, %.20q) = +%d -%d, want +%d -%d", tt.before, tt.after, added, removed, tt.added, tt.removed)
+		}
+	}
+}
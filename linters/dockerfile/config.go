@@ -0,0 +1,53 @@
+package dockerfile
+
+// DockerfileConfig represents Dockerfile linter specific configuration
+type DockerfileConfig struct {
+	// UseHadolint runs hadolint when it is installed (default true)
+	UseHadolint *bool `json:"useHadolint,omitempty"`
+	// HadolintConfig is the path to a hadolint config file. If unset, hadolint looks
+	// for .hadolint.yaml in the linted file's directory as usual
+	HadolintConfig *string `json:"hadolintConfig,omitempty"`
+	// DisabledRules lists rules to skip, including hadolint codes such as "DL3008"
+	DisabledRules []string `json:"disabledRules,omitempty"`
+	// MaxFileSize is the maximum file size in bytes to lint (default 1MB)
+	MaxFileSize *int64 `json:"maxFileSize,omitempty"`
+}
+
+// configSchema is the JSON Schema for DockerfileConfig
+const configSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "properties": {
+    "useHadolint": {
+      "type": "boolean",
+      "description": "Run hadolint when it is installed"
+    },
+    "hadolintConfig": {
+      "type": "string",
+      "description": "Path to a hadolint config file"
+    },
+    "disabledRules": {
+      "type": "array",
+      "items": {
+        "type": "string"
+      },
+      "description": "Rules to skip, including hadolint codes such as DL3008"
+    },
+    "maxFileSize": {
+      "type": "integer",
+      "minimum": 0,
+      "description": "Maximum file size in bytes to lint"
+    }
+  },
+  "additionalProperties": false
+}`
+
+// DefaultDockerfileConfig returns the default configuration for Dockerfile linting
+func DefaultDockerfileConfig() *DockerfileConfig {
+	useHadolint := true
+	maxFileSize := int64(1024 * 1024)
+	return &DockerfileConfig{
+		UseHadolint: &useHadolint,
+		MaxFileSize: &maxFileSize,
+	}
+}
@@ -0,0 +1,440 @@
+package dockerfile
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/jrossi/gismo/linters"
+	"github.com/jrossi/gismo/toolcache"
+)
+
+// Rules reported by the built-in checks
+const (
+	RulePinImageTag   = "pin-image-tag"
+	RulePreferCopy    = "prefer-copy"
+	RuleAptGetCleanup = "apt-get-cleanup"
+	RuleFileSize      = "file-size"
+)
+
+// hadolintCode matches the rule codes hadolint accepts for --ignore
+var hadolintCode = regexp.MustCompile(`^(DL|SC)\d+$`)
+
+// escapeDirective matches the parser directive that changes the line continuation character
+var escapeDirective = regexp.MustCompile(`^#\s*escape\s*=\s*(\S)\s*$`)
+
+// heredocStart matches the start of a heredoc such as <<EOF or <<-"EOF"
+var heredocStart = regexp.MustCompile(`<<-?["']?(\w+)["']?`)
+
+// aptGetInstall matches an apt-get install command
+var aptGetInstall = regexp.MustCompile(`\bapt-get\s+(?:-\S+\s+)*install\b`)
+
+// aptListsCleanup matches removal of the apt lists, or a cache mount that keeps them out of the image
+var aptListsCleanup = regexp.MustCompile(`\brm\s+(?:-\S+\s+)*/var/lib/apt/lists|--mount=type=cache\S*target=/var/lib/apt`)
+
+// archiveExtensions are the local sources ADD extracts, which COPY can't replace
+var archiveExtensions = []string{".tar", ".tar.gz", ".tgz", ".tar.bz2", ".tbz2", ".tar.xz", ".txz"}
+
+// hadolintComment is one finding in hadolint's JSON output
+type hadolintComment struct {
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+	Level   string `json:"level"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// instruction is one Dockerfile instruction with its continuation lines joined
+type instruction struct {
+	// Line the instruction starts on
+	line int
+	// Upper-cased instruction keyword, such as FROM
+	keyword string
+	args    string
+}
+
+// DockerfileLinter checks Dockerfiles with hadolint, or with built-in checks for
+// common mistakes when hadolint is unavailable
+type DockerfileLinter struct {
+	mu     sync.RWMutex
+	config *DockerfileConfig
+	// Tool cache used to discover hadolint; nil uses the disk-backed cache of the linted file's project
+	cache toolcache.ToolCache
+	// Runs external tools with the engine's limits, caches and environment
+	runner *linters.CommandRunner
+}
+
+// NewDockerfileLinter creates a new Dockerfile linter with default configuration
+func NewDockerfileLinter() *DockerfileLinter {
+	return NewDockerfileLinterWithConfig(nil)
+}
+
+// NewDockerfileLinterWithConfig creates a new Dockerfile linter with the given configuration
+func NewDockerfileLinterWithConfig(config *DockerfileConfig) *DockerfileLinter {
+	if config == nil {
+		config = DefaultDockerfileConfig()
+	}
+	return &DockerfileLinter{
+		config: config,
+		runner: linters.NewCommandRunner(linters.RunnerConfig{}),
+	}
+}
+
+// NewDockerfileLinterWithToolCache creates a Dockerfile linter that discovers hadolint
+// with the given tool cache. A nil cache falls back to the disk-backed cache rooted at
+// the linted file's project.
+func NewDockerfileLinterWithToolCache(config *DockerfileConfig, cache toolcache.ToolCache) *DockerfileLinter {
+	l := NewDockerfileLinterWithConfig(config)
+	l.cache = cache
+	return l
+}
+
+// Name returns the linter name
+func (l *DockerfileLinter) Name() string {
+	return "dockerfile"
+}
+
+// SetCommandRunner sets the runner used to start external tools
+func (l *DockerfileLinter) SetCommandRunner(runner *linters.CommandRunner) {
+	l.runner = runner
+}
+
+// Capabilities reports the built-in checks and the external tools used when installed
+func (l *DockerfileLinter) Capabilities() linters.Capabilities {
+	return linters.Capabilities{
+		Embedded: []string{"image tag pinning", "COPY over ADD", "apt-get cache cleanup"},
+		Tools:    []string{"hadolint"},
+	}
+}
+
+// CanHandle returns true for Dockerfile, Dockerfile.* and *.dockerfile files
+func (l *DockerfileLinter) CanHandle(filePath string) bool {
+	base := strings.ToLower(filepath.Base(filePath))
+	return base == "dockerfile" || strings.HasPrefix(base, "dockerfile.") || strings.HasSuffix(base, ".dockerfile")
+}
+
+// SetConfig updates the linter configuration
+func (l *DockerfileLinter) SetConfig(configData json.RawMessage) error {
+	// Settings not given keep their defaults
+	config := DefaultDockerfileConfig()
+	if err := json.Unmarshal(configData, config); err != nil {
+		return fmt.Errorf("failed to parse dockerfile config: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.config = config
+	return nil
+}
+
+// ConfigSchema returns the JSON Schema for the linter configuration
+func (l *DockerfileLinter) ConfigSchema() json.RawMessage {
+	return json.RawMessage(configSchema)
+}
+
+// Rules describes the built-in checks. Hadolint's own codes are documented at
+// https://github.com/hadolint/hadolint#rules
+func (l *DockerfileLinter) Rules() map[string]string {
+	return map[string]string{
+		RulePinImageTag:   "FROM names an image tag other than latest, or a digest, so builds are reproducible",
+		RulePreferCopy:    "COPY is used instead of ADD for local files and folders; ADD is for URLs and archives to extract",
+		RuleAptGetCleanup: "A RUN instruction that installs packages with apt-get also removes /var/lib/apt/lists to keep the layer small",
+		RuleFileSize:      "The file is no larger than the configured maxFileSize",
+	}
+}
+
+// Lint checks a Dockerfile with hadolint if it is installed and with the built-in
+// checks otherwise
+func (l *DockerfileLinter) Lint(ctx context.Context, filePath string, content []byte) (*linters.LintResult, error) {
+	l.mu.RLock()
+	config := l.config
+	l.mu.RUnlock()
+
+	result := &linters.LintResult{
+		Success: true,
+		Issues:  []linters.Issue{},
+	}
+
+	if config.MaxFileSize != nil && int64(len(content)) > *config.MaxFileSize {
+		result.Issues = append(result.Issues, linters.Issue{
+			File:     filePath,
+			Line:     1,
+			Column:   1,
+			Severity: "error",
+			Message:  fmt.Sprintf("File size %d exceeds limit %d", len(content), *config.MaxFileSize),
+			Rule:     RuleFileSize,
+		})
+		result.Success = false
+		return result, nil
+	}
+
+	var issues []linters.Issue
+	if hadolint := l.hadolintPath(config, filePath); hadolint != "" {
+		var err error
+		issues, err = l.runHadolint(ctx, config, hadolint, filePath, content)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		issues = builtinChecks(filePath, content)
+	}
+
+	for _, issue := range issues {
+		if isDisabled(config, issue.Rule) {
+			continue
+		}
+		if issue.Severity == "error" {
+			result.Success = false
+		}
+		result.Issues = append(result.Issues, issue)
+	}
+	return result, nil
+}
+
+// parseInstructions splits a Dockerfile into instructions, joining continuation
+// lines and heredoc bodies and skipping comments
+func parseInstructions(content []byte) []instruction {
+	lines := strings.Split(string(content), "\n")
+	escape := `\`
+
+	var instructions []instruction
+	var current *instruction
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], "\r")
+		trimmed := strings.TrimSpace(line)
+
+		// Parser directives are comments before the first instruction
+		if len(instructions) == 0 && current == nil {
+			if match := escapeDirective.FindStringSubmatch(trimmed); match != nil {
+				escape = match[1]
+				continue
+			}
+		}
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		if current == nil {
+			keyword, args, _ := strings.Cut(trimmed, " ")
+			current = &instruction{line: i + 1, keyword: strings.ToUpper(keyword), args: strings.TrimSpace(args)}
+		} else {
+			current.args += " " + trimmed
+		}
+
+		if strings.HasSuffix(current.args, escape) {
+			current.args = strings.TrimSpace(strings.TrimSuffix(current.args, escape))
+			continue
+		}
+
+		// Heredoc bodies belong to the instruction, up to the terminating word
+		if match := heredocStart.FindStringSubmatch(current.args); match != nil {
+			for i+1 < len(lines) {
+				i++
+				body := strings.TrimSpace(strings.TrimRight(lines[i], "\r"))
+				if body == match[1] {
+					break
+				}
+				current.args += "\n" + body
+			}
+		}
+		instructions = append(instructions, *current)
+		current = nil
+	}
+	if current != nil {
+		instructions = append(instructions, *current)
+	}
+	return instructions
+}
+
+// builtinChecks runs the checks used when hadolint is not available
+func builtinChecks(filePath string, content []byte) []linters.Issue {
+	var issues []linters.Issue
+	issue := func(in instruction, rule, message string) {
+		issues = append(issues, linters.Issue{
+			File:     filePath,
+			Line:     in.line,
+			Column:   1,
+			Severity: "warning",
+			Message:  message,
+			Rule:     rule,
+		})
+	}
+
+	stages := make(map[string]bool)
+	for _, in := range parseInstructions(content) {
+		switch in.keyword {
+		case "FROM":
+			image, stage := fromImage(in.args)
+			if message := checkImageTag(image, stages); message != "" {
+				issue(in, RulePinImageTag, message)
+			}
+			if stage != "" {
+				stages[strings.ToLower(stage)] = true
+			}
+		case "ADD":
+			if !addNeeded(in.args) {
+				issue(in, RulePreferCopy, "Use COPY instead of ADD for files and folders")
+			}
+		case "RUN":
+			if aptGetInstall.MatchString(in.args) && !aptListsCleanup.MatchString(in.args) {
+				issue(in, RuleAptGetCleanup, "Delete the apt-get lists after installing packages: rm -rf /var/lib/apt/lists/*")
+			}
+		}
+	}
+	return issues
+}
+
+// fromImage returns the image and optional stage name of FROM's arguments,
+// e.g. "--platform=$BUILDPLATFORM golang:1.22 AS build"
+func fromImage(args string) (image, stage string) {
+	var fields []string
+	for _, field := range strings.Fields(args) {
+		if !strings.HasPrefix(field, "--") {
+			fields = append(fields, field)
+		}
+	}
+	if len(fields) == 0 {
+		return "", ""
+	}
+	if len(fields) >= 3 && strings.EqualFold(fields[1], "AS") {
+		stage = fields[2]
+	}
+	return fields[0], stage
+}
+
+// checkImageTag returns why image isn't pinned, or "" if it is. Images built from
+// build arguments, earlier stages and scratch are not checked.
+func checkImageTag(image string, stages map[string]bool) string {
+	if image == "" || strings.Contains(image, "$") || strings.Contains(image, "@") ||
+		image == "scratch" || stages[strings.ToLower(image)] {
+		return ""
+	}
+	// The tag follows the last path component, registry ports come before it
+	name := image[strings.LastIndex(image, "/")+1:]
+	_, tag, found := strings.Cut(name, ":")
+	switch {
+	case !found:
+		return fmt.Sprintf("Always tag the version of image %q explicitly", image)
+	case tag == "latest":
+		return fmt.Sprintf("Pin a version of image %q instead of latest", image)
+	}
+	return ""
+}
+
+// addNeeded reports whether ADD's arguments fetch a URL or extract an archive,
+// which COPY can't do
+func addNeeded(args string) bool {
+	var fields []string
+	if strings.HasPrefix(args, "[") {
+		if err := json.Unmarshal([]byte(args), &fields); err != nil {
+			return true
+		}
+	} else {
+		for _, field := range strings.Fields(args) {
+			if !strings.HasPrefix(field, "--") {
+				fields = append(fields, field)
+			}
+		}
+	}
+	if len(fields) < 2 {
+		return true
+	}
+	for _, source := range fields[:len(fields)-1] {
+		if strings.Contains(source, "://") || strings.HasPrefix(source, "git@") {
+			return true
+		}
+		for _, ext := range archiveExtensions {
+			if strings.HasSuffix(strings.ToLower(source), ext) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isDisabled reports whether a rule is disabled by configuration
+func isDisabled(config *DockerfileConfig, rule string) bool {
+	for _, disabled := range config.DisabledRules {
+		if disabled == rule {
+			return true
+		}
+	}
+	return false
+}
+
+// hadolintPath returns the hadolint binary to run, or "" to use the built-in checks
+func (l *DockerfileLinter) hadolintPath(config *DockerfileConfig, filePath string) string {
+	if config.UseHadolint != nil && !*config.UseHadolint {
+		return ""
+	}
+	cache := l.cache
+	if cache == nil {
+		manager, err := toolcache.NewCacheManager(filePath)
+		if err != nil {
+			return ""
+		}
+		cache = manager
+	}
+	tool, err := cache.DiscoverTool("dockerfile", "hadolint")
+	if err != nil || tool == nil || !tool.Available {
+		return ""
+	}
+	return tool.Path
+}
+
+// runHadolint lints content with hadolint, read from stdin since the content may
+// not be on disk yet
+func (l *DockerfileLinter) runHadolint(ctx context.Context, config *DockerfileConfig, hadolint, filePath string, content []byte) ([]linters.Issue, error) {
+	args := []string{"--format", "json"}
+	if config.HadolintConfig != nil && *config.HadolintConfig != "" {
+		args = append(args, "--config", *config.HadolintConfig)
+	}
+	for _, rule := range config.DisabledRules {
+		if hadolintCode.MatchString(rule) {
+			args = append(args, "--ignore", rule)
+		}
+	}
+	args = append(args, "-")
+
+	release, err := l.runner.Acquire(ctx, hadolint)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	cmd := l.runner.Command(ctx, l.Name(), hadolint, args...)
+	// hadolint looks for .hadolint.yaml from the working directory
+	cmd.Dir = linters.ExistingDir(filePath)
+	cmd.Stdin = bytes.NewReader(content)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	// hadolint exits with 1 when it reports findings
+	runErr := linters.Run(cmd)
+
+	var comments []hadolintComment
+	if err := json.Unmarshal(bytes.TrimSpace(stdout.Bytes()), &comments); err != nil {
+		if runErr != nil {
+			return nil, fmt.Errorf("hadolint failed: %v\nstderr: %s", runErr, stderr.String())
+		}
+		return nil, fmt.Errorf("failed to parse hadolint output: %w", err)
+	}
+
+	issues := make([]linters.Issue, 0, len(comments))
+	for _, comment := range comments {
+		issues = append(issues, linters.ToolIssue("hadolint", comment.Level, linters.Issue{
+			File:    filePath,
+			Line:    comment.Line,
+			Column:  comment.Column,
+			Message: comment.Message,
+			Rule:    comment.Code,
+		}))
+	}
+	return issues, nil
+}
@@ -0,0 +1,183 @@
+package dockerfile
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/jrossi/gismo/linters"
+	"github.com/jrossi/gismo/toolcache"
+)
+
+// rulesOf returns the rule of each issue
+func rulesOf(issues []linters.Issue) string {
+	rules := []string{}
+	for _, issue := range issues {
+		rules = append(rules, issue.Rule)
+	}
+	return strings.Join(rules, ",")
+}
+
+// builtinLinter returns a linter that can't find hadolint
+func builtinLinter() *DockerfileLinter {
+	return NewDockerfileLinterWithToolCache(nil, toolcache.NewMemoryCache())
+}
+
+func TestDockerfileLinter_CanHandle(t *testing.T) {
+	linter := NewDockerfileLinter()
+	for path, want := range map[string]bool{
+		"Dockerfile":            true,
+		"build/Dockerfile.dev":  true,
+		"dockerfile":            true,
+		"images/api.Dockerfile": true,
+		"Dockerfile-old":        false,
+		"docker-compose.yml":    false,
+		"Dockerfiles/README.md": false,
+	} {
+		if got := linter.CanHandle(path); got != want {
+			t.Errorf("CanHandle(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestDockerfileLinter_BuiltinChecks(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{name: "pinned", content: "FROM golang:1.22 AS build\nFROM gcr.io/distroless/static@sha256:abc\nCOPY --from=build /app /app\n", want: ""},
+		{name: "untagged", content: "FROM ubuntu\n", want: "pin-image-tag"},
+		{name: "latest", content: "FROM --platform=linux/amd64 localhost:5000/base:latest\n", want: "pin-image-tag"},
+		{name: "stages and args", content: "ARG BASE=alpine:3.20\nFROM $BASE AS base\nFROM base\nFROM scratch\n", want: ""},
+		{name: "add local files", content: "FROM alpine:3.20\nADD --chown=app ./src /app/src\n", want: "prefer-copy"},
+		{name: "add url and archive", content: "FROM alpine:3.20\nADD https://example.com/tool /bin/\nADD [\"rootfs.tar.gz\", \"/\"]\n", want: ""},
+		{
+			name:    "apt-get without cleanup",
+			content: "FROM debian:12\nRUN apt-get update && \\\n    apt-get -y install curl\n",
+			want:    "apt-get-cleanup",
+		},
+		{
+			name:    "apt-get with cleanup",
+			content: "FROM debian:12\nRUN apt-get update \\\n    # install tools\n    && apt-get install -y curl \\\n    && rm -rf /var/lib/apt/lists/*\n",
+			want:    "",
+		},
+		{
+			name:    "escape directive",
+			content: "# escape=`\nFROM mcr.microsoft.com/windows/servercore:ltsc2022\nRUN apt-get install -y curl `\n    && rm -rf /var/lib/apt/lists\n",
+			want:    "",
+		},
+		{
+			name:    "heredoc body is not an instruction",
+			content: "FROM debian:12\nRUN <<EOF\napt-get install -y curl\nFROM ubuntu\nEOF\n",
+			want:    "apt-get-cleanup",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := builtinLinter().Lint(context.Background(), "Dockerfile", []byte(tt.content))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := rulesOf(result.Issues); got != tt.want {
+				t.Errorf("rules = %q, want %q (%+v)", got, tt.want, result.Issues)
+			}
+			if !result.Success {
+				t.Error("Success = false, built-in checks only warn")
+			}
+		})
+	}
+}
+
+func TestDockerfileLinter_BuiltinIssueLines(t *testing.T) {
+	content := "FROM node\n\nRUN apt-get update && \\\n    apt-get install -y git\nADD . /app\n"
+	result, err := builtinLinter().Lint(context.Background(), "Dockerfile", []byte(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var lines []int
+	for _, issue := range result.Issues {
+		lines = append(lines, issue.Line)
+	}
+	if len(lines) != 3 || lines[0] != 1 || lines[1] != 3 || lines[2] != 5 {
+		t.Errorf("issue lines = %v, want [1 3 5]", lines)
+	}
+}
+
+func TestDockerfileLinter_DisabledRules(t *testing.T) {
+	linter := builtinLinter()
+	if err := linter.SetConfig(json.RawMessage(`{"disabledRules": ["pin-image-tag"]}`)); err != nil {
+		t.Fatal(err)
+	}
+	result, err := linter.Lint(context.Background(), "Dockerfile", []byte("FROM ubuntu\nADD a b\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := rulesOf(result.Issues); got != "prefer-copy" {
+		t.Errorf("rules = %q, want prefer-copy", got)
+	}
+}
+
+func TestDockerfileLinter_Hadolint(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake hadolint is a shell script")
+	}
+	dir := t.TempDir()
+	args := filepath.Join(dir, "args")
+	script := "#!/bin/sh\n" +
+		"echo \"$@\" > " + args + "\n" +
+		"cat > /dev/null\n" +
+		`echo '[{"code":"DL3006","column":1,"file":"-","level":"warning","line":1,"message":"Always tag the version of an image explicitly"},` +
+		`{"code":"DL3020","column":1,"file":"-","level":"error","line":2,"message":"Use COPY instead of ADD for files and folders"},` +
+		`{"code":"DL3059","column":1,"file":"-","level":"info","line":3,"message":"Multiple consecutive RUN instructions."}]'` + "\n" +
+		"exit 1\n"
+	hadolint := filepath.Join(dir, "hadolint")
+	if err := os.WriteFile(hadolint, []byte(script), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	cache := toolcache.NewMemoryCache()
+	cache.AddTool("dockerfile", "hadolint", hadolint)
+	linter := NewDockerfileLinterWithToolCache(nil, cache)
+	if err := linter.SetConfig(json.RawMessage(`{"disabledRules": ["DL3059", "prefer-copy"]}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := linter.Lint(context.Background(), "/project/Dockerfile", []byte("FROM ubuntu\nADD a b\nRUN true\n"))
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+	// The fake ignores --ignore, so DL3059 is filtered from the output too
+	if got := rulesOf(result.Issues); got != "DL3006,DL3020" {
+		t.Errorf("rules = %s", got)
+	}
+	if result.Success {
+		t.Error("Success = true, want false for an error-level finding")
+	}
+	if issue := result.Issues[1]; issue.Line != 2 || issue.Severity != "error" || issue.File != "/project/Dockerfile" {
+		t.Errorf("second issue = %+v", issue)
+	}
+
+	data, err := os.ReadFile(args)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(string(data)); got != "--format json --ignore DL3059 -" {
+		t.Errorf("hadolint args = %q", got)
+	}
+}
+
+func TestDockerfileLinter_MaxFileSize(t *testing.T) {
+	linter := builtinLinter()
+	if err := linter.SetConfig(json.RawMessage(`{"maxFileSize": 4}`)); err != nil {
+		t.Fatal(err)
+	}
+	result, err := linter.Lint(context.Background(), "Dockerfile", []byte("FROM alpine:3.20\n"))
+	if err != nil || result.Success || len(result.Issues) != 1 {
+		t.Errorf("Lint() = %+v, %v", result, err)
+	}
+}
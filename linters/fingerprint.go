@@ -0,0 +1,102 @@
+package linters
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// SetFingerprints sets the Fingerprint of each issue one linter reported for
+// content at path. A fingerprint hashes the rule, the path and the issue's source
+// line with its whitespace collapsed, so it survives edits that move the line or
+// reindent it. Issues with no line in content use their message instead. Issues
+// sharing a rule and line text are told apart by their order in the file. path
+// should be relative to the project root so fingerprints match across checkouts.
+func SetFingerprints(path string, content []byte, issues []Issue) {
+	path = filepath.ToSlash(filepath.Clean(path))
+	lines := strings.Split(string(content), "\n")
+
+	// Number issues per rule and context in file order
+	order := make([]int, len(issues))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		if issues[order[a]].Line != issues[order[b]].Line {
+			return issues[order[a]].Line < issues[order[b]].Line
+		}
+		return issues[order[a]].Column < issues[order[b]].Column
+	})
+
+	occurrences := make(map[string]int)
+	for _, i := range order {
+		issue := &issues[i]
+		key := path + "\x00" + issue.Rule + "\x00" + issueContext(lines, *issue)
+		sum := sha256.Sum256([]byte(fmt.Sprintf("%s\x00%d", key, occurrences[key])))
+		issue.Fingerprint = hex.EncodeToString(sum[:16])
+		occurrences[key]++
+	}
+}
+
+// issueContext returns the issue's source line with whitespace collapsed, or its
+// message when the line isn't in the content
+func issueContext(lines []string, issue Issue) string {
+	if issue.Line < 1 || issue.Line > len(lines) {
+		return issue.Message
+	}
+	return strings.Join(strings.Fields(lines[issue.Line-1]), " ")
+}
+
+// DeduplicateIssues drops issues whose fingerprint matches an earlier issue's,
+// such as the same finding reported by two linters. Issues without a fingerprint
+// are kept.
+func DeduplicateIssues(issues []Issue) []Issue {
+	seen := make(map[string]bool, len(issues))
+	kept := issues[:0:0]
+	for _, issue := range issues {
+		if issue.Fingerprint != "" {
+			if seen[issue.Fingerprint] {
+				continue
+			}
+			seen[issue.Fingerprint] = true
+		}
+		kept = append(kept, issue)
+	}
+	return kept
+}
+
+// SplitByBaseline separates issues into those introduced since baseline and
+// those already in it. Issues are matched by fingerprint, which doesn't change
+// when an edit moves the offending line, and then by rule and message, as an
+// edit to the offending line itself changes its fingerprint.
+func SplitByBaseline(issues, baseline []Issue) (introduced, existing []Issue) {
+	fingerprints := make(map[string]bool, len(baseline))
+	remaining := make(map[string]int, len(baseline))
+	for _, issue := range baseline {
+		fingerprints[issue.Fingerprint] = true
+		remaining[issue.Rule+"\x00"+issue.Message]++
+	}
+
+	var unmatched []Issue
+	for _, issue := range issues {
+		if issue.Fingerprint != "" && fingerprints[issue.Fingerprint] {
+			remaining[issue.Rule+"\x00"+issue.Message]--
+			existing = append(existing, issue)
+			continue
+		}
+		unmatched = append(unmatched, issue)
+	}
+	for _, issue := range unmatched {
+		key := issue.Rule + "\x00" + issue.Message
+		if remaining[key] > 0 {
+			remaining[key]--
+			existing = append(existing, issue)
+			continue
+		}
+		introduced = append(introduced, issue)
+	}
+	return introduced, existing
+}
@@ -0,0 +1,85 @@
+package linters
+
+import "testing"
+
+func TestSetFingerprints(t *testing.T) {
+	content := []byte("package main\n\nfunc main() {\n\tx := 1\n}\n")
+	issues := []Issue{
+		{Line: 4, Column: 2, Message: "x declared and not used", Rule: "unused"},
+		{Line: 4, Column: 7, Message: "magic number", Rule: "mnd"},
+		{Line: 0, Message: "missing package comment", Rule: "doc"},
+	}
+	SetFingerprints("cmd/main.go", content, issues)
+
+	seen := make(map[string]bool)
+	for i, issue := range issues {
+		if len(issue.Fingerprint) != 32 || seen[issue.Fingerprint] {
+			t.Errorf("issue %d fingerprint %q is malformed or duplicated", i, issue.Fingerprint)
+		}
+		seen[issue.Fingerprint] = true
+	}
+
+	// Moving and reindenting the line keeps the fingerprint
+	moved := []byte("package main\n\nimport \"fmt\"\n\nfunc main() {\n    x   := 1\n\tfmt.Println()\n}\n")
+	movedIssues := []Issue{
+		{Line: 6, Column: 5, Message: "x declared and not used", Rule: "unused"},
+		{Line: 0, Message: "missing package comment", Rule: "doc"},
+	}
+	SetFingerprints("./cmd/../cmd/main.go", moved, movedIssues)
+	if movedIssues[0].Fingerprint != issues[0].Fingerprint {
+		t.Error("fingerprint changed when the line moved")
+	}
+	if movedIssues[1].Fingerprint != issues[2].Fingerprint {
+		t.Error("fingerprint of an issue without a line changed")
+	}
+
+	// Changing the line, the rule or the file does change it
+	for name, tt := range map[string]struct {
+		path    string
+		content string
+		issue   Issue
+	}{
+		"line": {"cmd/main.go", "package main\n\nfunc main() {\n\ty := 1\n}\n", issues[0]},
+		"rule": {"cmd/main.go", string(content), Issue{Line: 4, Rule: "ineffassign"}},
+		"file": {"cmd/other.go", string(content), issues[0]},
+	} {
+		changed := []Issue{tt.issue}
+		SetFingerprints(tt.path, []byte(tt.content), changed)
+		if changed[0].Fingerprint == issues[0].Fingerprint {
+			t.Errorf("fingerprint unchanged after changing the %s", name)
+		}
+	}
+}
+
+func TestSetFingerprints_RepeatedIssues(t *testing.T) {
+	content := []byte("a\nTODO\nb\nTODO\n")
+	issues := []Issue{
+		{Line: 4, Message: "todo", Rule: "todo"},
+		{Line: 2, Message: "todo", Rule: "todo"},
+	}
+	SetFingerprints("notes.md", content, issues)
+	if issues[0].Fingerprint == issues[1].Fingerprint {
+		t.Fatal("issues on identical lines share a fingerprint")
+	}
+
+	// Occurrences are numbered in file order, not report order
+	reordered := []Issue{issues[1], issues[0]}
+	SetFingerprints("notes.md", content, reordered)
+	if reordered[0].Fingerprint != issues[1].Fingerprint || reordered[1].Fingerprint != issues[0].Fingerprint {
+		t.Error("fingerprints depend on report order")
+	}
+}
+
+func TestDeduplicateIssues(t *testing.T) {
+	issues := []Issue{
+		{Message: "a", Fingerprint: "1"},
+		{Message: "b", Fingerprint: "2"},
+		{Message: "a again", Fingerprint: "1"},
+		{Message: "c"},
+		{Message: "d"},
+	}
+	got := DeduplicateIssues(issues)
+	if len(got) != 4 || got[0].Message != "a" || got[1].Message != "b" || got[2].Message != "c" || got[3].Message != "d" {
+		t.Errorf("DeduplicateIssues() = %+v", got)
+	}
+}
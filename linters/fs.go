@@ -0,0 +1,150 @@
+package linters
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FileSystem abstracts the filesystem access linters perform themselves, such as
+// module-root discovery, config lookups and test-file detection. Method signatures
+// match os and io/fs so the OS implementation is a thin wrapper. Names are OS paths.
+type FileSystem interface {
+	Stat(name string) (fs.FileInfo, error)
+	ReadFile(name string) ([]byte, error)
+	WriteFile(name string, data []byte, perm fs.FileMode) error
+}
+
+// FileSystemAware is implemented by linters whose filesystem access can be redirected
+type FileSystemAware interface {
+	SetFileSystem(fsys FileSystem)
+}
+
+// OSFileSystem is the FileSystem backed by the real operating system
+type OSFileSystem struct{}
+
+// Ensure OSFileSystem implements FileSystem
+var _ FileSystem = OSFileSystem{}
+
+// Stat returns file info from the OS
+func (OSFileSystem) Stat(name string) (fs.FileInfo, error) {
+	return os.Stat(name)
+}
+
+// ReadFile reads a file from the OS
+func (OSFileSystem) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(name)
+}
+
+// WriteFile writes a file to the OS
+func (OSFileSystem) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	return os.WriteFile(name, data, perm)
+}
+
+// MemFileSystem is an in-memory FileSystem for hermetic tests and for content that
+// is not on disk yet. Directories exist implicitly when a file exists below them.
+type MemFileSystem struct {
+	mu    sync.RWMutex
+	files map[string]*memFile
+}
+
+// Ensure MemFileSystem implements FileSystem
+var _ FileSystem = (*MemFileSystem)(nil)
+
+type memFile struct {
+	data    []byte
+	mode    fs.FileMode
+	modTime time.Time
+}
+
+// NewMemFileSystem creates an empty in-memory filesystem
+func NewMemFileSystem() *MemFileSystem {
+	return &MemFileSystem{
+		files: make(map[string]*memFile),
+	}
+}
+
+// Stat returns info for a file, or for a directory that contains files
+func (m *MemFileSystem) Stat(name string) (fs.FileInfo, error) {
+	name = filepath.Clean(name)
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if f, ok := m.files[name]; ok {
+		return &memFileInfo{name: filepath.Base(name), size: int64(len(f.data)), mode: f.mode, modTime: f.modTime}, nil
+	}
+
+	prefix := name + string(filepath.Separator)
+	if name == string(filepath.Separator) {
+		prefix = name
+	}
+	for path := range m.files {
+		if strings.HasPrefix(path, prefix) {
+			return &memFileInfo{name: filepath.Base(name), mode: fs.ModeDir | 0755}, nil
+		}
+	}
+
+	return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+}
+
+// ReadFile returns a copy of the file contents
+func (m *MemFileSystem) ReadFile(name string) ([]byte, error) {
+	name = filepath.Clean(name)
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	f, ok := m.files[name]
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return append([]byte(nil), f.data...), nil
+}
+
+// WriteFile stores a copy of data at name, creating parent directories implicitly
+func (m *MemFileSystem) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	name = filepath.Clean(name)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.files[name] = &memFile{
+		data:    append([]byte(nil), data...),
+		mode:    perm,
+		modTime: time.Now(),
+	}
+	return nil
+}
+
+// Files returns the sorted paths of all files in the filesystem
+func (m *MemFileSystem) Files() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	paths := make([]string, 0, len(m.files))
+	for path := range m.files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// memFileInfo implements fs.FileInfo for MemFileSystem entries
+type memFileInfo struct {
+	name    string
+	size    int64
+	mode    fs.FileMode
+	modTime time.Time
+}
+
+func (i *memFileInfo) Name() string       { return i.name }
+func (i *memFileInfo) Size() int64        { return i.size }
+func (i *memFileInfo) Mode() fs.FileMode  { return i.mode }
+func (i *memFileInfo) ModTime() time.Time { return i.modTime }
+func (i *memFileInfo) IsDir() bool        { return i.mode.IsDir() }
+func (i *memFileInfo) Sys() any           { return nil }
@@ -0,0 +1,99 @@
+package linters
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMemFileSystem_ReadWrite(t *testing.T) {
+	fsys := NewMemFileSystem()
+
+	if _, err := fsys.ReadFile("/proj/main.go"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Expected ErrNotExist for missing file, got %v", err)
+	}
+
+	data := []byte("package main\n")
+	if err := fsys.WriteFile("/proj/main.go", data, 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	// Mutating the source slice must not affect stored content
+	data[0] = 'X'
+
+	got, err := fsys.ReadFile("/proj/./main.go")
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if string(got) != "package main\n" {
+		t.Errorf("ReadFile() = %q, want %q", got, "package main\n")
+	}
+}
+
+func TestMemFileSystem_Stat(t *testing.T) {
+	fsys := NewMemFileSystem()
+	if err := fsys.WriteFile("/proj/pkg/util.go", []byte("package pkg\n"), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		wantDir bool
+		wantErr bool
+	}{
+		{"file", "/proj/pkg/util.go", false, false},
+		{"parent directory", "/proj/pkg", true, false},
+		{"ancestor directory", "/proj", true, false},
+		{"root", "/", true, false},
+		{"missing", "/proj/other", false, true},
+		{"prefix is not a directory", "/proj/pk", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := fsys.Stat(tt.path)
+			if tt.wantErr {
+				if !os.IsNotExist(err) {
+					t.Errorf("Stat(%q) error = %v, want not-exist", tt.path, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Stat(%q) failed: %v", tt.path, err)
+			}
+			if info.IsDir() != tt.wantDir {
+				t.Errorf("Stat(%q).IsDir() = %v, want %v", tt.path, info.IsDir(), tt.wantDir)
+			}
+		})
+	}
+}
+
+func TestMemFileSystem_Files(t *testing.T) {
+	fsys := NewMemFileSystem()
+	_ = fsys.WriteFile("/b.txt", nil, 0644)
+	_ = fsys.WriteFile("/a.txt", nil, 0644)
+
+	files := fsys.Files()
+	if len(files) != 2 || files[0] != "/a.txt" || files[1] != "/b.txt" {
+		t.Errorf("Files() = %v, want sorted paths", files)
+	}
+}
+
+func TestOSFileSystem(t *testing.T) {
+	var fsys FileSystem = OSFileSystem{}
+	path := filepath.Join(t.TempDir(), "file.txt")
+
+	if err := fsys.WriteFile(path, []byte("hello"), 0600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	got, err := fsys.ReadFile(path)
+	if err != nil || string(got) != "hello" {
+		t.Errorf("ReadFile() = %q, %v", got, err)
+	}
+	if _, err := fsys.Stat(path); err != nil {
+		t.Errorf("Stat failed: %v", err)
+	}
+}
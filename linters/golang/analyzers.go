@@ -0,0 +1,357 @@
+//go:build !noembed
+
+package golang
+
+import (
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"sync"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+// embeddedAnalyzers lists the analyzers compiled into gismo for the fallback checks
+var embeddedAnalyzers = []string{"unchecked-error", "ineffectual-assignment"}
+
+// runEmbeddedAnalyzers type-checks the file on its own and runs the enabled
+// built-in analyzers over it. They only run when builtinAnalyzers is set: seeing
+// one file, they approximate errcheck and ineffassign rather than replace them.
+func (l *GoLinter) runEmbeddedAnalyzers(filePath string, content []byte) []linters.Issue {
+	if l.config == nil || l.config.BuiltinAnalyzers == nil || !*l.config.BuiltinAnalyzers {
+		return nil
+	}
+	runUnchecked := !l.isCheckDisabled("unchecked-error")
+	runIneffectual := !l.isCheckDisabled("ineffectual-assignment")
+	if !runUnchecked && !runIneffectual {
+		return nil
+	}
+
+	fset, file, info, err := typeCheckFile(filePath, content)
+	if err != nil {
+		return nil
+	}
+	var issues []linters.Issue
+	if runUnchecked {
+		issues = append(issues, checkUncheckedErrors(fset, file, info, filePath)...)
+	}
+	if runIneffectual {
+		issues = append(issues, checkIneffectualAssignments(fset, file, info, filePath)...)
+	}
+	return issues
+}
+
+// uncheckedErrorExcluded lists functions whose errors are conventionally ignored,
+// following errcheck's default exclusions
+var uncheckedErrorExcluded = map[string]bool{
+	"fmt.Print":                      true,
+	"fmt.Printf":                     true,
+	"fmt.Println":                    true,
+	"fmt.Fprint":                     true,
+	"fmt.Fprintf":                    true,
+	"fmt.Fprintln":                   true,
+	"(*bytes.Buffer).Write":          true,
+	"(*bytes.Buffer).WriteByte":      true,
+	"(*bytes.Buffer).WriteRune":      true,
+	"(*bytes.Buffer).WriteString":    true,
+	"(*strings.Builder).Write":       true,
+	"(*strings.Builder).WriteByte":   true,
+	"(*strings.Builder).WriteRune":   true,
+	"(*strings.Builder).WriteString": true,
+	"(hash.Hash).Write":              true,
+	"math/rand.Read":                 true,
+}
+
+// sharedImporter resolves imports for every file type-checked by the built-in
+// analyzers, so each standard library package is only loaded once per process
+var sharedImporter = &lockedImporter{importer: importer.Default()}
+
+// lockedImporter serializes a types.Importer, which files linted concurrently share
+type lockedImporter struct {
+	mu       sync.Mutex
+	importer types.Importer
+}
+
+// Import imports the package at path
+func (i *lockedImporter) Import(path string) (*types.Package, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.importer.Import(path)
+}
+
+// typeCheckFile parses and type-checks a single file. Type errors are ignored so the
+// analyzers still see whatever type information could be resolved: standard library
+// imports resolve, while identifiers from other files or modules stay untyped.
+func typeCheckFile(filePath string, content []byte) (*token.FileSet, *ast.File, *types.Info, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filePath, content, parser.ParseComments)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	info := &types.Info{
+		Types: make(map[ast.Expr]types.TypeAndValue),
+		Defs:  make(map[*ast.Ident]types.Object),
+		Uses:  make(map[*ast.Ident]types.Object),
+	}
+	config := types.Config{
+		Importer: sharedImporter,
+		Error:    func(error) {},
+	}
+	_, _ = config.Check(file.Name.Name, fset, []*ast.File{file}, info)
+	return fset, file, info, nil
+}
+
+// checkUncheckedErrors reports call statements that discard a returned error,
+// a simplified approximation of errcheck. Calls whose types can't be resolved are
+// skipped.
+func checkUncheckedErrors(fset *token.FileSet, file *ast.File, info *types.Info, filePath string) []linters.Issue {
+	errorType := types.Universe.Lookup("error").Type()
+	var issues []linters.Issue
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		stmt, ok := n.(*ast.ExprStmt)
+		if !ok {
+			return true
+		}
+		call, ok := stmt.X.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+
+		tv, ok := info.Types[call]
+		if !ok || tv.Type == nil {
+			return true
+		}
+		var last types.Type
+		switch t := tv.Type.(type) {
+		case *types.Tuple:
+			if t.Len() == 0 {
+				return true
+			}
+			last = t.At(t.Len() - 1).Type()
+		default:
+			last = t
+		}
+		if !types.Identical(last, errorType) {
+			return true
+		}
+
+		if fn := calledFunc(call, info); fn != nil && uncheckedErrorExcluded[fn.FullName()] {
+			return true
+		}
+
+		pos := fset.Position(call.Pos())
+		issues = append(issues, linters.Issue{
+			File:     filePath,
+			Line:     pos.Line,
+			Column:   pos.Column,
+			Severity: "warning",
+			Message:  fmt.Sprintf("Error return value of `%s` is not checked", types.ExprString(call.Fun)),
+			Rule:     "unchecked-error",
+		})
+		return true
+	})
+
+	return issues
+}
+
+// calledFunc returns the function or method a call resolves to, if known
+func calledFunc(call *ast.CallExpr, info *types.Info) *types.Func {
+	var ident *ast.Ident
+	switch fun := call.Fun.(type) {
+	case *ast.Ident:
+		ident = fun
+	case *ast.SelectorExpr:
+		ident = fun.Sel
+	default:
+		return nil
+	}
+	fn, _ := info.Uses[ident].(*types.Func)
+	return fn
+}
+
+// checkIneffectualAssignments reports assignments to local variables that are
+// overwritten by a later assignment in the same block before being read, a
+// simplified approximation of ineffassign. It only follows straight-line code, so
+// it errs on the side of reporting nothing.
+func checkIneffectualAssignments(fset *token.FileSet, file *ast.File, info *types.Info, filePath string) []linters.Issue {
+	var issues []linters.Issue
+
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Body == nil {
+			continue
+		}
+
+		// Variables that may be read indirectly are never reported
+		escaped := escapingVars(fn, info)
+
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			var list []ast.Stmt
+			switch block := n.(type) {
+			case *ast.BlockStmt:
+				list = block.List
+			case *ast.CaseClause:
+				list = block.Body
+			case *ast.CommClause:
+				list = block.Body
+			default:
+				return true
+			}
+
+			for i, stmt := range list {
+				assign, ok := stmt.(*ast.AssignStmt)
+				if !ok || (assign.Tok != token.ASSIGN && assign.Tok != token.DEFINE) {
+					continue
+				}
+				for _, lhs := range assign.Lhs {
+					ident, ok := lhs.(*ast.Ident)
+					if !ok || ident.Name == "_" {
+						continue
+					}
+					v := localVar(ident, info)
+					if v == nil || escaped[v] {
+						continue
+					}
+					if overwrittenBeforeUse(list[i+1:], v, info) {
+						pos := fset.Position(ident.Pos())
+						issues = append(issues, linters.Issue{
+							File:     filePath,
+							Line:     pos.Line,
+							Column:   pos.Column,
+							Severity: "warning",
+							Message:  fmt.Sprintf("ineffectual assignment to %s", ident.Name),
+							Rule:     "ineffectual-assignment",
+						})
+					}
+				}
+			}
+			return true
+		})
+	}
+
+	return issues
+}
+
+// localVar returns the function-local variable an identifier refers to
+func localVar(ident *ast.Ident, info *types.Info) *types.Var {
+	obj := info.Defs[ident]
+	if obj == nil {
+		obj = info.Uses[ident]
+	}
+	v, ok := obj.(*types.Var)
+	if !ok || v.IsField() || v.Pkg() == nil || v.Parent() == nil || v.Parent() == v.Pkg().Scope() {
+		return nil
+	}
+	return v
+}
+
+// escapingVars returns variables whose address is taken, that are used in closures,
+// or that are named results, since they can be read without a visible reference
+func escapingVars(fn *ast.FuncDecl, info *types.Info) map[*types.Var]bool {
+	escaped := make(map[*types.Var]bool)
+	mark := func(ident *ast.Ident) {
+		if v := localVar(ident, info); v != nil {
+			escaped[v] = true
+		}
+	}
+
+	if fn.Type.Results != nil {
+		for _, field := range fn.Type.Results.List {
+			for _, name := range field.Names {
+				mark(name)
+			}
+		}
+	}
+
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.UnaryExpr:
+			if node.Op == token.AND {
+				if ident, ok := node.X.(*ast.Ident); ok {
+					mark(ident)
+				}
+			}
+		case *ast.FuncLit:
+			ast.Inspect(node.Body, func(inner ast.Node) bool {
+				if ident, ok := inner.(*ast.Ident); ok {
+					mark(ident)
+				}
+				return true
+			})
+			return false
+		}
+		return true
+	})
+	return escaped
+}
+
+// overwrittenBeforeUse reports whether the statements assign v again before reading it.
+// It gives up at the first statement that could branch or read v.
+func overwrittenBeforeUse(stmts []ast.Stmt, v *types.Var, info *types.Info) bool {
+	for _, stmt := range stmts {
+		switch s := stmt.(type) {
+		case *ast.AssignStmt:
+			plain := s.Tok == token.ASSIGN || s.Tok == token.DEFINE
+			if !plain {
+				// Compound assignments like x += 1 read the variable
+				if usesVar(s.Lhs, v, info) || usesVar(s.Rhs, v, info) {
+					return false
+				}
+				continue
+			}
+			if !usesVar(s.Rhs, v, info) {
+				for _, lhs := range s.Lhs {
+					if ident, ok := lhs.(*ast.Ident); ok && info.Uses[ident] == v {
+						return true
+					}
+				}
+			}
+			if usesVar(s.Rhs, v, info) || usesVarInLhs(s.Lhs, v, info) {
+				return false
+			}
+		case *ast.ExprStmt, *ast.IncDecStmt, *ast.DeclStmt, *ast.SendStmt:
+			if usesVar([]ast.Node{s}, v, info) {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+// usesVar reports whether any of the nodes reference v
+func usesVar[T ast.Node](nodes []T, v *types.Var, info *types.Info) bool {
+	found := false
+	for _, node := range nodes {
+		ast.Inspect(node, func(n ast.Node) bool {
+			if ident, ok := n.(*ast.Ident); ok && info.Uses[ident] == v {
+				found = true
+			}
+			return !found
+		})
+		if found {
+			return true
+		}
+	}
+	return false
+}
+
+// usesVarInLhs reports whether v is read on the left-hand side of an assignment,
+// as in x.field = ... or s[x] = ..., as opposed to being assigned directly
+func usesVarInLhs(lhs []ast.Expr, v *types.Var, info *types.Info) bool {
+	for _, expr := range lhs {
+		if _, ok := expr.(*ast.Ident); ok {
+			continue
+		}
+		if usesVar([]ast.Expr{expr}, v, info) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,13 @@
+//go:build noembed
+
+package golang
+
+import "github.com/jrossi/gismo/linters"
+
+// embeddedAnalyzers is empty: noembed builds leave out the built-in analyzers
+var embeddedAnalyzers = []string{}
+
+// runEmbeddedAnalyzers reports nothing in noembed builds, which rely on golangci-lint
+func (l *GoLinter) runEmbeddedAnalyzers(filePath string, content []byte) []linters.Issue {
+	return nil
+}
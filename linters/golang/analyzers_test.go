@@ -0,0 +1,85 @@
+//go:build !noembed
+
+package golang
+
+import "testing"
+
+func TestRunEmbeddedAnalyzers_OptIn(t *testing.T) {
+	src := []byte("package main\n\nimport \"os\"\n\nfunc main() {\n\tos.Remove(\"x\")\n}\n")
+
+	l := NewGoLinter()
+	if issues := l.runEmbeddedAnalyzers("main.go", src); len(issues) != 0 {
+		t.Errorf("default config reported %v, want the analyzers off", issues)
+	}
+
+	enabled := true
+	l = NewGoLinterWithConfig(&GolangConfig{BuiltinAnalyzers: &enabled})
+	if issues := l.runEmbeddedAnalyzers("main.go", src); len(issues) != 1 || issues[0].Rule != "unchecked-error" {
+		t.Errorf("builtinAnalyzers reported %v, want one unchecked-error", issues)
+	}
+}
+
+func TestFallbackAnalyzers(t *testing.T) {
+	tests := []struct {
+		name  string
+		body  string
+		want  []string
+		rules []string
+	}{
+		{
+			name:  "unchecked error",
+			body:  "os.Remove(\"x\")",
+			want:  []string{"Error return value of `os.Remove` is not checked"},
+			rules: []string{"unchecked-error"},
+		},
+		{
+			name: "checked and excluded errors",
+			body: "if err := os.Remove(\"x\"); err != nil {\n\t\treturn\n\t}\n\tfmt.Println(\"ok\")\n\tvar b strings.Builder\n\tb.WriteString(\"x\")\n\t_ = b",
+		},
+		{
+			name:  "overwritten before use",
+			body:  "x := 1\n\tx = 2\n\tfmt.Println(x)",
+			want:  []string{"ineffectual assignment to x"},
+			rules: []string{"ineffectual-assignment"},
+		},
+		{
+			name: "read before overwrite",
+			body: "x := 1\n\tfmt.Println(x)\n\tx = 2\n\tfmt.Println(x)",
+		},
+		{
+			name: "compound assignment reads",
+			body: "x := 1\n\tx += 2\n\tx = 3\n\tfmt.Println(x)",
+		},
+		{
+			name: "address taken",
+			body: "x := 1\n\tp := &x\n\tx = 2\n\tfmt.Println(*p)",
+		},
+		{
+			name: "branch stops analysis",
+			body: "x := 1\n\tif len(os.Args) > 1 {\n\t\tfmt.Println(x)\n\t}\n\tx = 2\n\tfmt.Println(x)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := "package main\n\nimport (\n\t\"fmt\"\n\t\"os\"\n\t\"strings\"\n)\n\nvar _ = strings.ToUpper\nvar _ = fmt.Sprint\nvar _ = os.Args\n\nfunc main() {\n\t" + tt.body + "\n}\n"
+			fset, file, info, err := typeCheckFile("main.go", []byte(src))
+			if err != nil {
+				t.Fatalf("typeCheckFile() error = %v", err)
+			}
+			issues := append(checkUncheckedErrors(fset, file, info, "main.go"), checkIneffectualAssignments(fset, file, info, "main.go")...)
+
+			if len(issues) != len(tt.want) {
+				t.Fatalf("got %d issues %v, want %d", len(issues), issues, len(tt.want))
+			}
+			for i, issue := range issues {
+				if issue.Message != tt.want[i] || issue.Rule != tt.rules[i] {
+					t.Errorf("issue %d = %s (%s), want %s (%s)", i, issue.Message, issue.Rule, tt.want[i], tt.rules[i])
+				}
+				if issue.Line == 0 {
+					t.Errorf("issue %d has no position", i)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,274 @@
+package golang
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+// DefaultBenchmarkBudget is the ns/op slowdown, in percent of the baseline,
+// tolerated before a benchmark is reported as a regression
+const DefaultBenchmarkBudget = 20.0
+
+// benchBaselineFile is where benchmark baselines are stored, in the project's
+// .claude directory
+const benchBaselineFile = "gismo-bench.json"
+
+// benchResultPattern matches a go test -bench result line, such as
+// "BenchmarkParse-8   	 1000000	      1042 ns/op"
+var benchResultPattern = regexp.MustCompile(`^(Benchmark\S*?)(?:-\d+)?\s+\d+\s+([0-9.]+) ns/op`)
+
+// baselineMu serializes updates of the baseline file by concurrent lints
+var baselineMu sync.Mutex
+
+// benchBaseline is the content of the baseline file
+type benchBaseline struct {
+	// Benchmarks are keyed by package import path and benchmark name, such as
+	// "example.com/app/parser.BenchmarkParse"
+	Benchmarks map[string]benchResult `json:"benchmarks"`
+}
+
+// benchResult is a benchmark's recorded speed
+type benchResult struct {
+	NsPerOp float64 `json:"nsPerOp"`
+}
+
+// benchmarksEnabled reports whether benchmarks run after a test file changes
+func (l *GoLinter) benchmarksEnabled() bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.config != nil && l.config.Benchmarks != nil && *l.config.Benchmarks && !l.isCheckDisabled("benchmark")
+}
+
+// benchmarkBudget returns the tolerated slowdown in percent
+func (l *GoLinter) benchmarkBudget() float64 {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if l.config == nil || l.config.BenchmarkBudget == nil {
+		return DefaultBenchmarkBudget
+	}
+	return *l.config.BenchmarkBudget
+}
+
+// checkBenchmarks runs the benchmarks of a changed test file and warns about
+// those slower than their baseline by more than the budget. Benchmarks without
+// a baseline are recorded, so the first run after enabling them sets it.
+func (l *GoLinter) checkBenchmarks(ctx context.Context, testFile string, content []byte, pending map[string][]byte) []linters.Issue {
+	if !l.benchmarksEnabled() {
+		return nil
+	}
+	benchmarks, err := parseBenchmarkFunctions(testFile, content)
+	if err != nil || len(benchmarks) == 0 {
+		return nil
+	}
+	moduleInfo, err := l.FindModuleRoot(testFile)
+	if err != nil {
+		return nil
+	}
+	relPath, err := filepath.Rel(moduleInfo.Root, filepath.Dir(testFile))
+	if err != nil {
+		return nil
+	}
+	importPath := moduleInfo.Path
+	if relPath != "." {
+		importPath += "/" + filepath.ToSlash(relPath)
+	}
+
+	names := make([]string, 0, len(benchmarks))
+	for name := range benchmarks {
+		names = append(names, regexp.QuoteMeta(name))
+	}
+	output, err := l.runBenchmarks(ctx, moduleInfo.Root, "./"+filepath.ToSlash(relPath), fmt.Sprintf("^(%s)$", strings.Join(names, "|")), pending)
+	if err != nil {
+		return nil
+	}
+	results := parseBenchOutput(output)
+
+	baselineMu.Lock()
+	defer baselineMu.Unlock()
+	baselinePath := filepath.Join(claudeDir(moduleInfo.Root), benchBaselineFile)
+	baseline := readBenchBaseline(baselinePath)
+	budget := l.benchmarkBudget()
+
+	var issues []linters.Issue
+	recorded := false
+	for name, nsPerOp := range results {
+		line, ok := benchmarks[name]
+		if !ok {
+			continue
+		}
+		key := importPath + "." + name
+		base, ok := baseline.Benchmarks[key]
+		if !ok || base.NsPerOp <= 0 {
+			baseline.Benchmarks[key] = benchResult{NsPerOp: nsPerOp}
+			recorded = true
+			continue
+		}
+		if slowdown := (nsPerOp - base.NsPerOp) * 100 / base.NsPerOp; slowdown > budget {
+			issues = append(issues, linters.Issue{
+				File:     testFile,
+				Line:     line,
+				Column:   1,
+				Severity: "warning",
+				Message: fmt.Sprintf("%s takes %s ns/op, %.0f%% slower than its %s ns/op baseline (budget %.0f%%); remove its entry from %s to accept the new speed",
+					name, formatNsPerOp(nsPerOp), slowdown, formatNsPerOp(base.NsPerOp), budget, benchBaselineFile),
+				Rule: "benchmark-regression",
+			})
+		}
+	}
+	if recorded {
+		writeBenchBaseline(baselinePath, baseline)
+	}
+	slices.SortFunc(issues, func(a, b linters.Issue) int { return a.Line - b.Line })
+	return issues
+}
+
+// runBenchmarks runs the benchmarks matching pattern in pkg, without its tests
+func (l *GoLinter) runBenchmarks(ctx context.Context, moduleRoot, pkg, pattern string, pending map[string][]byte) (string, error) {
+	args := []string{"test", "-run", "^$", "-bench", pattern}
+	if len(pending) > 0 {
+		overlay, err := newGoOverlay(pending)
+		if err != nil {
+			return "", err
+		}
+		defer func() { _ = overlay.Close() }()
+		args = append(args, overlay.flag())
+	}
+	l.mu.RLock()
+	if l.config != nil && l.config.BenchmarkTime != nil && *l.config.BenchmarkTime != "" {
+		args = append(args, "-benchtime", *l.config.BenchmarkTime)
+	}
+	if l.config != nil && l.config.TestTimeout != nil {
+		args = append(args, "-timeout", l.config.TestTimeout.Duration.String())
+	}
+	if tags := l.buildTags(); tags != "" {
+		args = append(args, "-tags="+tags)
+	}
+	l.mu.RUnlock()
+	args = append(args, pkg)
+
+	release, err := l.runner.Acquire(ctx, "go")
+	if err != nil {
+		return "", err
+	}
+	defer release()
+
+	cmd := l.runner.Command(ctx, l.Name(), "go", args...)
+	cmd.Dir = moduleRoot
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := linters.Run(cmd); err != nil {
+		return stdout.String(), fmt.Errorf("go test -bench failed: %w: %s", err, stderr.String())
+	}
+	return stdout.String(), nil
+}
+
+// parseBenchmarkFunctions returns the line of each benchmark function in a test file
+func parseBenchmarkFunctions(filePath string, content []byte) (map[string]int, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filePath, content, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse file: %w", err)
+	}
+	benchmarks := make(map[string]int)
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv != nil || !strings.HasPrefix(fn.Name.Name, "Benchmark") {
+			continue
+		}
+		if fn.Type.Params == nil || len(fn.Type.Params.List) != 1 {
+			continue
+		}
+		star, ok := fn.Type.Params.List[0].Type.(*ast.StarExpr)
+		if !ok {
+			continue
+		}
+		if sel, ok := star.X.(*ast.SelectorExpr); ok && sel.Sel.Name == "B" {
+			if ident, ok := sel.X.(*ast.Ident); ok && ident.Name == "testing" {
+				benchmarks[fn.Name.Name] = fset.Position(fn.Pos()).Line
+			}
+		}
+	}
+	return benchmarks, nil
+}
+
+// parseBenchOutput returns the ns/op of each top-level benchmark in go test
+// -bench output. Sub-benchmarks are left out, as they are reported under the
+// benchmark function that runs them.
+func parseBenchOutput(output string) map[string]float64 {
+	results := make(map[string]float64)
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		match := benchResultPattern.FindStringSubmatch(scanner.Text())
+		if match == nil || strings.Contains(match[1], "/") {
+			continue
+		}
+		if nsPerOp, err := strconv.ParseFloat(match[2], 64); err == nil {
+			results[match[1]] = nsPerOp
+		}
+	}
+	return results
+}
+
+// formatNsPerOp formats a ns/op value as go test prints it
+func formatNsPerOp(nsPerOp float64) string {
+	return strconv.FormatFloat(nsPerOp, 'f', -1, 64)
+}
+
+// claudeDir returns the project's .claude directory: the nearest one at or above
+// dir, within its repository and outside the home directory, or dir's own
+func claudeDir(dir string) string {
+	home, _ := os.UserHomeDir()
+	for current := dir; current != home; {
+		candidate := filepath.Join(current, ".claude")
+		if info, err := os.Stat(candidate); err == nil && info.IsDir() {
+			return candidate
+		}
+		parent := filepath.Dir(current)
+		if _, err := os.Stat(filepath.Join(current, ".git")); err == nil || parent == current {
+			break
+		}
+		current = parent
+	}
+	return filepath.Join(dir, ".claude")
+}
+
+// readBenchBaseline reads the baseline file, returning an empty baseline if it
+// is missing or invalid
+func readBenchBaseline(path string) *benchBaseline {
+	baseline := &benchBaseline{}
+	if data, err := os.ReadFile(path); err == nil { // #nosec G304 - path is the project's baseline file
+		_ = json.Unmarshal(data, baseline)
+	}
+	if baseline.Benchmarks == nil {
+		baseline.Benchmarks = make(map[string]benchResult)
+	}
+	return baseline
+}
+
+// writeBenchBaseline stores the baseline, ignoring failures since it is
+// recorded again on the next run
+func writeBenchBaseline(path string, baseline *benchBaseline) {
+	data, err := json.MarshalIndent(baseline, "", "  ")
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err == nil {
+		_ = os.WriteFile(path, append(data, '\n'), 0600)
+	}
+}
@@ -0,0 +1,63 @@
+package golang
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseBenchOutput(t *testing.T) {
+	output := `goos: linux
+goarch: amd64
+pkg: example.com/calc
+BenchmarkAdd-8        	1000000000	         0.2510 ns/op
+BenchmarkParse        	   12345	     98012 ns/op	    2048 B/op	      12 allocs/op
+BenchmarkParse/small-8	  100000	      1042 ns/op
+PASS
+`
+	results := parseBenchOutput(output)
+	if len(results) != 2 || results["BenchmarkAdd"] != 0.251 || results["BenchmarkParse"] != 98012 {
+		t.Errorf("parseBenchOutput() = %v", results)
+	}
+}
+
+func TestGoLinter_Benchmarks(t *testing.T) {
+	module := t.TempDir()
+	testContent := "package calc\n\nimport \"testing\"\n\nfunc BenchmarkAdd(b *testing.B) {\n\tfor i := 0; i < b.N; i++ {\n\t\t_ = Add(i, i)\n\t}\n}\n"
+	for name, content := range map[string]string{
+		"go.mod":       "module example.com/calc\n\ngo 1.21\n",
+		"calc.go":      "package calc\n\nfunc Add(a, b int) int {\n\treturn a + b\n}\n",
+		"calc_test.go": testContent,
+	} {
+		if err := os.WriteFile(filepath.Join(module, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	testFile := filepath.Join(module, "calc_test.go")
+
+	enabled, benchTime := true, "100x"
+	linter := NewGoLinterWithConfig(&GolangConfig{Benchmarks: &enabled, BenchmarkTime: &benchTime})
+
+	// The first run records the baseline
+	if issues := linter.checkBenchmarks(context.Background(), testFile, []byte(testContent), nil); len(issues) != 0 {
+		t.Fatalf("first run: issues = %+v", issues)
+	}
+	baselinePath := filepath.Join(module, ".claude", benchBaselineFile)
+	baseline := readBenchBaseline(baselinePath)
+	if _, ok := baseline.Benchmarks["example.com/calc.BenchmarkAdd"]; !ok {
+		t.Fatalf("baseline = %+v, want BenchmarkAdd recorded", baseline)
+	}
+
+	// A baseline far faster than any real run is a regression
+	baseline.Benchmarks["example.com/calc.BenchmarkAdd"] = benchResult{NsPerOp: 0.0001}
+	writeBenchBaseline(baselinePath, baseline)
+	issues := linter.checkBenchmarks(context.Background(), testFile, []byte(testContent), nil)
+	if len(issues) != 1 {
+		t.Fatalf("regression: issues = %+v", issues)
+	}
+	if issue := issues[0]; issue.Rule != "benchmark-regression" || issue.Severity != "warning" || issue.Line != 5 || !strings.Contains(issue.Message, "BenchmarkAdd") {
+		t.Errorf("issue = %+v", issue)
+	}
+}
@@ -0,0 +1,112 @@
+package golang
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+// minCoverage returns the configured minimum package coverage in percent
+func (l *GoLinter) minCoverage() (float64, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if l.config == nil || l.config.MinCoverage == nil || l.isCheckDisabled("coverage") {
+		return 0, false
+	}
+	return *l.config.MinCoverage, true
+}
+
+// runTestFile runs the tests of a changed test file. With a minimum coverage
+// configured, it runs the whole package with a coverage profile instead and
+// returns a blocking issue when the package's coverage is below the minimum.
+func (l *GoLinter) runTestFile(ctx context.Context, testFile string, pending map[string][]byte) (string, *linters.Issue, error) {
+	minimum, ok := l.minCoverage()
+	if !ok {
+		output, err := l.runTestsWithOverlay(ctx, testFile, pending)
+		return output, nil, err
+	}
+
+	profile, err := os.CreateTemp("", "gismo-cover-*.out")
+	if err != nil {
+		return "", nil, err
+	}
+	profilePath := profile.Name()
+	_ = profile.Close()
+	defer func() { _ = os.Remove(profilePath) }()
+
+	output, err := l.runGoTest(ctx, testFile, pending, profilePath)
+	if err != nil {
+		return output, nil, err
+	}
+	data, err := os.Open(profilePath) // #nosec G304 - profile created above
+	if err != nil {
+		// go test skips the profile when the package has no module root or tests
+		return output, nil, nil
+	}
+	defer func() { _ = data.Close() }()
+	coverage, statements, err := parseCoverProfile(data)
+	if err != nil || statements == 0 || coverage >= minimum {
+		return output, nil, nil
+	}
+	return output, &linters.Issue{
+		File:     testFile,
+		Line:     1,
+		Column:   1,
+		Severity: "error",
+		Message:  fmt.Sprintf("Package coverage is %.1f%% of statements, below the %.1f%% minimum; add tests for the uncovered code", coverage, minimum),
+		Rule:     "coverage",
+	}, nil
+}
+
+// parseCoverProfile returns the statement coverage in percent of a go test
+// coverage profile, and the number of statements it counts. Blocks listed more
+// than once, as when several test binaries cover a package, are counted once.
+func parseCoverProfile(r io.Reader) (float64, int, error) {
+	covered := make(map[string]bool)
+	statements := make(map[string]int)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" || strings.HasPrefix(line, "mode:") {
+			continue
+		}
+		// file.go:startLine.startCol,endLine.endCol numStatements count
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			return 0, 0, fmt.Errorf("invalid coverage profile line %q", line)
+		}
+		numStatements, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid coverage profile line %q", line)
+		}
+		count, err := strconv.Atoi(fields[2])
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid coverage profile line %q", line)
+		}
+		block := fields[0]
+		statements[block] = numStatements
+		covered[block] = covered[block] || count > 0
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, 0, err
+	}
+
+	var total, hit int
+	for block, n := range statements {
+		total += n
+		if covered[block] {
+			hit += n
+		}
+	}
+	if total == 0 {
+		return 0, 0, nil
+	}
+	return float64(hit) * 100 / float64(total), total, nil
+}
@@ -0,0 +1,63 @@
+package golang
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseCoverProfile(t *testing.T) {
+	profile := `mode: set
+example.com/calc/calc.go:3.24,5.2 1 1
+example.com/calc/calc.go:7.24,9.2 3 0
+example.com/calc/calc.go:7.24,9.2 3 0
+`
+	coverage, statements, err := parseCoverProfile(strings.NewReader(profile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if statements != 4 || coverage != 25 {
+		t.Errorf("coverage = %.1f%% of %d statements, want 25%% of 4", coverage, statements)
+	}
+
+	if _, _, err := parseCoverProfile(strings.NewReader("mode: set\ncalc.go:3.24,5.2 x 1\n")); err == nil {
+		t.Error("invalid profile: want an error")
+	}
+}
+
+func TestGoLinter_MinCoverage(t *testing.T) {
+	module := t.TempDir()
+	for name, content := range map[string]string{
+		"go.mod":       "module example.com/calc\n\ngo 1.21\n",
+		"calc.go":      "package calc\n\nfunc Add(a, b int) int {\n\treturn a + b\n}\n\nfunc Sub(a, b int) int {\n\treturn a - b\n}\n",
+		"calc_test.go": "package calc\n\nimport \"testing\"\n\nfunc TestAdd(t *testing.T) {\n\tif Add(1, 2) != 3 {\n\t\tt.Fatal(\"Add\")\n\t}\n}\n",
+	} {
+		if err := os.WriteFile(filepath.Join(module, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	testFile := filepath.Join(module, "calc_test.go")
+
+	for _, tt := range []struct {
+		minimum float64
+		blocked bool
+	}{
+		{80, true},
+		{50, false},
+	} {
+		minimum := tt.minimum
+		linter := NewGoLinterWithConfig(&GolangConfig{MinCoverage: &minimum})
+		_, issue, err := linter.runTestFile(context.Background(), testFile, nil)
+		if err != nil {
+			t.Fatalf("minCoverage %v: %v", minimum, err)
+		}
+		if blocked := issue != nil; blocked != tt.blocked {
+			t.Errorf("minCoverage %v: coverage issue = %+v, want blocked %v", minimum, issue, tt.blocked)
+		}
+		if issue != nil && (issue.Rule != "coverage" || issue.Severity != "error" || !strings.Contains(issue.Message, "50.0%")) {
+			t.Errorf("coverage issue = %+v", issue)
+		}
+	}
+}
@@ -0,0 +1,272 @@
+package golang
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	json "github.com/goccy/go-json"
+	"github.com/jrossi/gismo/linters"
+)
+
+// vetDiagnostic is a single finding in go vet -json output
+type vetDiagnostic struct {
+	Posn    string `json:"posn"`
+	Message string `json:"message"`
+}
+
+// staticcheckDiagnostic is a single finding in staticcheck -f json output
+type staticcheckDiagnostic struct {
+	Code     string `json:"code"`
+	Severity string `json:"severity"`
+	Location struct {
+		File   string `json:"file"`
+		Line   int    `json:"line"`
+		Column int    `json:"column"`
+	} `json:"location"`
+	Message string `json:"message"`
+}
+
+// runFallbackChecks runs the correctness checks used when golangci-lint is
+// unavailable: staticcheck on the file's package when it is installed, go vet
+// otherwise or when goVet is set, plus the built-in unchecked-error and
+// ineffectual-assignment passes on the file itself when builtinAnalyzers is set
+func (l *GoLinter) runFallbackChecks(ctx context.Context, filePath string, content []byte, pending map[string][]byte) []linters.Issue {
+	var issues []linters.Issue
+
+	ranStaticcheck := false
+	if !l.isCheckDisabled("staticcheck") {
+		if tool := l.findCachedTool(filePath, "staticcheck"); tool != "" {
+			if found, err := l.runStaticcheck(ctx, tool, filePath, pending); err == nil {
+				issues = append(issues, found...)
+				ranStaticcheck = true
+			}
+		}
+	}
+	if (!ranStaticcheck || l.goVetAlways()) && !l.isCheckDisabled("govet") {
+		issues = append(issues, l.runGoVet(ctx, filePath, pending)...)
+	}
+
+	issues = append(issues, l.runEmbeddedAnalyzers(filePath, content)...)
+	runeColumns(content, issues)
+	return issues
+}
+
+// runGoVet runs go vet -json on the package containing filePath and returns the
+// findings for that file. Failures to run vet are ignored like golangci-lint failures.
+func (l *GoLinter) runGoVet(ctx context.Context, filePath string, pending map[string][]byte) []linters.Issue {
+	moduleInfo, err := l.FindModuleRoot(filePath)
+	if err != nil {
+		return nil
+	}
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return nil
+	}
+	relPath, err := filepath.Rel(moduleInfo.Root, filepath.Dir(absPath))
+	if err != nil {
+		return nil
+	}
+
+	args := append([]string{"vet", "-json"}, l.vetAnalyzerFlags()...)
+	if tags := l.buildTags(); tags != "" {
+		args = append(args, "-tags="+tags)
+	}
+	// Diagnostics in pending content are reported under the overlay replacement path
+	reportedPath := absPath
+	if len(pending) > 0 {
+		overlay, err := newGoOverlay(pending)
+		if err != nil {
+			return nil
+		}
+		defer func() { _ = overlay.Close() }()
+		args = append(args, overlay.flag())
+		if replacement, ok := overlay.files[absPath]; ok {
+			reportedPath = replacement
+		}
+	}
+	args = append(args, "./"+filepath.ToSlash(relPath))
+
+	release, err := l.runner.Acquire(ctx, "go")
+	if err != nil {
+		return nil
+	}
+	defer release()
+
+	cmd := l.runner.Command(ctx, l.Name(), "go", args...)
+	cmd.Dir = moduleInfo.Root
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	// go vet exits non-zero when it reports findings
+	_ = linters.Run(cmd)
+
+	// Newer go versions write -json output to stdout, older ones to stderr
+	diagnostics := append(parseVetJSON(stdout.Bytes()), parseVetJSON(stderr.Bytes())...)
+
+	var issues []linters.Issue
+	for _, diag := range diagnostics {
+		path, line, column := splitPosn(diag.diagnostic.Posn)
+		if path != reportedPath {
+			continue
+		}
+		issues = append(issues, linters.Issue{
+			File:     filePath,
+			Line:     line,
+			Column:   column,
+			Severity: "warning",
+			Message:  diag.analyzer + ": " + diag.diagnostic.Message,
+			Rule:     "govet",
+		})
+	}
+	return issues
+}
+
+// runStaticcheck runs staticcheck on the package containing filePath and returns
+// the findings for that file, with the check code, such as SA4006, as the rule.
+// staticcheck has no overlay support, so pending content is checked in a shadow
+// workspace. It returns an error when staticcheck can't run, so go vet runs instead.
+func (l *GoLinter) runStaticcheck(ctx context.Context, tool, filePath string, pending map[string][]byte) ([]linters.Issue, error) {
+	moduleInfo, err := l.FindModuleRoot(filePath)
+	if err != nil {
+		return nil, err
+	}
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return nil, err
+	}
+	relPath, err := filepath.Rel(moduleInfo.Root, filepath.Dir(absPath))
+	if err != nil {
+		return nil, err
+	}
+
+	dir, reportedPath := moduleInfo.Root, absPath
+	if len(pending) > 0 {
+		ws, err := linters.NewShadowWorkspace(moduleInfo.Root, pending)
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = ws.Close() }()
+		dir, reportedPath = ws.Root, ws.Path(absPath)
+	}
+
+	args := []string{"-f", "json"}
+	if tags := l.buildTags(); tags != "" {
+		args = append(args, "-tags", tags)
+	}
+	args = append(args, "./"+filepath.ToSlash(relPath))
+
+	release, err := l.runner.Acquire(ctx, tool)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	cmd := l.runner.Command(ctx, l.Name(), tool, args...)
+	cmd.Dir = dir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	// staticcheck exits non-zero when it reports findings
+	if err := linters.Run(cmd); err != nil && stdout.Len() == 0 {
+		return nil, fmt.Errorf("staticcheck failed: %w: %s", err, stderr.String())
+	}
+
+	var issues []linters.Issue
+	scanner := bufio.NewScanner(&stdout)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		var diag staticcheckDiagnostic
+		if err := json.Unmarshal(scanner.Bytes(), &diag); err != nil {
+			continue
+		}
+		if diag.Location.File != reportedPath || diag.Severity == "ignored" || l.isCheckDisabled(diag.Code) {
+			continue
+		}
+		issues = append(issues, linters.ToolIssue("staticcheck", diag.Severity, linters.Issue{
+			File:    filePath,
+			Line:    diag.Location.Line,
+			Column:  diag.Location.Column,
+			Message: diag.Message,
+			Rule:    diag.Code,
+		}))
+	}
+	return issues, nil
+}
+
+// vetAnalyzerFlags returns the go vet flags selecting the configured analyzers.
+// Naming an analyzer runs only the named ones; turning one off runs the rest.
+func (l *GoLinter) vetAnalyzerFlags() []string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if l.config == nil {
+		return nil
+	}
+	var flags []string
+	for _, analyzer := range l.config.VetAnalyzers {
+		flags = append(flags, "-"+analyzer)
+	}
+	for _, analyzer := range l.config.DisabledVetAnalyzers {
+		flags = append(flags, "-"+analyzer+"=false")
+	}
+	return flags
+}
+
+// analyzerDiagnostic pairs a go vet finding with the analyzer that reported it
+type analyzerDiagnostic struct {
+	analyzer   string
+	diagnostic vetDiagnostic
+}
+
+// parseVetJSON decodes go vet -json output: "# pkg" comment lines followed by JSON
+// objects mapping package -> analyzer -> diagnostics
+func parseVetJSON(output []byte) []analyzerDiagnostic {
+	var filtered bytes.Buffer
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if bytes.HasPrefix(line, []byte("#")) {
+			continue
+		}
+		filtered.Write(line)
+		filtered.WriteByte('\n')
+	}
+
+	var diagnostics []analyzerDiagnostic
+	decoder := json.NewDecoder(&filtered)
+	for decoder.More() {
+		var packages map[string]map[string]json.RawMessage
+		if err := decoder.Decode(&packages); err != nil {
+			break
+		}
+		for _, analyzers := range packages {
+			for analyzer, raw := range analyzers {
+				// Analyzers that failed report {"error": ...} instead of a list
+				var found []vetDiagnostic
+				if err := json.Unmarshal(raw, &found); err != nil {
+					continue
+				}
+				for _, diag := range found {
+					diagnostics = append(diagnostics, analyzerDiagnostic{analyzer: analyzer, diagnostic: diag})
+				}
+			}
+		}
+	}
+	return diagnostics
+}
+
+// splitPosn splits a "file:line:col" position
+func splitPosn(posn string) (string, int, int) {
+	parts := strings.Split(posn, ":")
+	if len(parts) < 3 {
+		return posn, 0, 0
+	}
+	line, _ := strconv.Atoi(parts[len(parts)-2])
+	column, _ := strconv.Atoi(parts[len(parts)-1])
+	return strings.Join(parts[:len(parts)-2], ":"), line, column
+}
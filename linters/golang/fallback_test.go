@@ -0,0 +1,165 @@
+package golang
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jrossi/gismo/toolcache"
+)
+
+func TestParseVetJSON(t *testing.T) {
+	output := `# example.com/p
+{
+	"example.com/p": {
+		"printf": [
+			{"posn": "/src/p/a.go:5:24", "message": "bad format"}
+		],
+		"broken": {"error": "analysis failed"}
+	}
+}
+`
+	diags := parseVetJSON([]byte(output))
+	if len(diags) != 1 {
+		t.Fatalf("parseVetJSON() returned %d diagnostics, want 1", len(diags))
+	}
+	if diags[0].analyzer != "printf" || diags[0].diagnostic.Message != "bad format" {
+		t.Errorf("unexpected diagnostic %+v", diags[0])
+	}
+
+	path, line, column := splitPosn(diags[0].diagnostic.Posn)
+	if path != "/src/p/a.go" || line != 5 || column != 24 {
+		t.Errorf("splitPosn() = %q, %d, %d", path, line, column)
+	}
+}
+
+func TestGoLinter_RunGoVetPending(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/vet\n\ngo 1.21\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	filePath := filepath.Join(dir, "main.go")
+	if err := os.WriteFile(filePath, []byte("package main\n\nfunc main() {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The printf mistake only exists in the pending content, not on disk
+	pending := []byte("package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Printf(\"%d\\n\", \"x\")\n}\n")
+	linter := NewGoLinter()
+	issues := linter.runGoVet(context.Background(), filePath, linter.pendingContent(filePath, pending))
+
+	if len(issues) != 1 {
+		t.Fatalf("runGoVet() returned %v, want one printf issue", issues)
+	}
+	if issues[0].File != filePath || issues[0].Line != 6 || issues[0].Rule != "govet" || !strings.HasPrefix(issues[0].Message, "printf: ") {
+		t.Errorf("unexpected issue %+v", issues[0])
+	}
+}
+
+func TestGoLinter_StaticcheckFallback(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/sc\n\ngo 1.21\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	filePath := filepath.Join(dir, "main.go")
+	content := []byte("package main\n\nfunc main() {}\n")
+	if err := os.WriteFile(filePath, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The fake reports a finding in main.go of the directory it runs in, which is
+	// a shadow workspace for pending content
+	binDir := t.TempDir()
+	writeFakeTool(t, binDir, "staticcheck", `printf '{"code":"SA4006","severity":"error","location":{"file":"%s/main.go","line":3,"column":2},"message":"value never used"}\n{"code":"ST1000","severity":"ignored","location":{"file":"%s/main.go","line":1,"column":1},"message":"no package comment"}\n' "$(pwd)" "$(pwd)"
+exit 1`)
+	cache := toolcache.NewMemoryCache()
+	cache.AddTool("go", "staticcheck", filepath.Join(binDir, "staticcheck"))
+
+	for name, pending := range map[string][]byte{"written": content, "pending": []byte("package main\n\nfunc main() { _ = 1 }\n")} {
+		t.Run(name, func(t *testing.T) {
+			linter := NewGoLinterWithToolCache(nil, cache)
+			issues := linter.runFallbackChecks(context.Background(), filePath, pending, linter.pendingContent(filePath, pending))
+			if len(issues) != 1 {
+				t.Fatalf("issues = %+v, want the staticcheck finding only", issues)
+			}
+			if issues[0].Rule != "SA4006" || issues[0].File != filePath || issues[0].Line != 3 || issues[0].Severity != "warning" || issues[0].Tool != "staticcheck" {
+				t.Errorf("unexpected issue %+v", issues[0])
+			}
+		})
+	}
+
+	// Disabling staticcheck falls back to go vet
+	linter := NewGoLinterWithToolCache(&GolangConfig{DisabledChecks: []string{"staticcheck"}}, cache)
+	if issues := linter.runFallbackChecks(context.Background(), filePath, content, nil); len(issues) != 0 {
+		t.Errorf("issues = %+v, want none from go vet", issues)
+	}
+}
+
+func TestGoLinter_VetAnalyzerFlags(t *testing.T) {
+	linter := NewGoLinterWithConfig(&GolangConfig{
+		VetAnalyzers:         []string{"printf", "shift"},
+		DisabledVetAnalyzers: []string{"composites"},
+	})
+	if got := strings.Join(linter.vetAnalyzerFlags(), " "); got != "-printf -shift -composites=false" {
+		t.Errorf("vetAnalyzerFlags() = %q", got)
+	}
+}
+
+func TestGoLinter_GoVetWithGolangci(t *testing.T) {
+	// A golangci-lint that finds nothing, so only go vet can report the printf mistake
+	home := t.TempDir()
+	binDir := filepath.Join(home, "go", "bin")
+	if err := os.MkdirAll(binDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeFakeTool(t, binDir, "golangci-lint", `case "$1" in
+--version) echo "golangci-lint has version 2.1.0" ;;
+*) echo '{"Issues":[]}' ;;
+esac`)
+	// Keep the build cache, which is under HOME by default
+	if goCache, err := exec.Command("go", "env", "GOCACHE").Output(); err == nil {
+		t.Setenv("GOCACHE", strings.TrimSpace(string(goCache)))
+	}
+	t.Setenv("HOME", home)
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/vet\n\ngo 1.21\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	filePath := filepath.Join(dir, "main.go")
+	content := []byte("package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Printf(\"%d\\n\", \"x\")\n}\n")
+	if err := os.WriteFile(filePath, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	runTests, goVet := false, true
+	for _, tt := range []struct {
+		name   string
+		config *GolangConfig
+		want   int
+	}{
+		{"golangci-lint only", &GolangConfig{RunTests: &runTests}, 0},
+		{"go vet as well", &GolangConfig{RunTests: &runTests, GoVet: &goVet}, 1},
+		{"analyzer disabled", &GolangConfig{RunTests: &runTests, GoVet: &goVet, DisabledVetAnalyzers: []string{"printf"}}, 0},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			linter := NewGoLinterWithToolCache(tt.config, toolcache.NewMemoryCache())
+			result, err := linter.Lint(context.Background(), filePath, content)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var vet int
+			for _, issue := range result.Issues {
+				if issue.Rule == "govet" {
+					vet++
+				}
+			}
+			if vet != tt.want {
+				t.Errorf("govet issues = %d, want %d: %+v", vet, tt.want, result.Issues)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package golang
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/jrossi/gismo/linters"
+	"github.com/jrossi/gismo/toolcache"
+)
+
+// formatterStage is an optional formatting pass run after gofmt
+type formatterStage struct {
+	name    string
+	message string
+	run     func(ctx context.Context, tool, filePath string, content []byte) ([]byte, error)
+}
+
+// formatterStages returns the optional formatter passes enabled in config, in order
+func (l *GoLinter) formatterStages() []formatterStage {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	var stages []formatterStage
+	if l.config != nil && l.config.Gofumpt != nil && *l.config.Gofumpt && !l.isCheckDisabled("gofumpt") {
+		stages = append(stages, formatterStage{
+			name:    "gofumpt",
+			message: "File is not properly formatted with gofumpt",
+			run:     l.runGofumpt,
+		})
+	}
+	if l.config != nil && l.config.Gci != nil && *l.config.Gci && !l.isCheckDisabled("gci") {
+		stages = append(stages, formatterStage{
+			name:    "gci",
+			message: "Imports are not grouped according to gci",
+			run:     l.runGci,
+		})
+	}
+	return stages
+}
+
+// applyFormatters runs the enabled formatter passes over gofmt output. It returns the
+// combined formatted content and a warning for each pass that changed it. Passes whose
+// tool is not installed are skipped.
+func (l *GoLinter) applyFormatters(ctx context.Context, filePath string, formatted []byte) ([]byte, []linters.Issue) {
+	var issues []linters.Issue
+	for _, stage := range l.formatterStages() {
+		tool := findGoTool(stage.name)
+		if tool == "" {
+			continue
+		}
+		output, err := stage.run(ctx, tool, filePath, formatted)
+		if err != nil || len(output) == 0 {
+			continue
+		}
+		if !bytes.Equal(output, formatted) {
+			issues = append(issues, linters.Issue{
+				File:     filePath,
+				Line:     1,
+				Column:   1,
+				Severity: "warning",
+				Message:  stage.message,
+				Rule:     stage.name,
+			})
+			formatted = output
+		}
+	}
+	return formatted, issues
+}
+
+// runGofumpt formats content with gofumpt via stdin
+func (l *GoLinter) runGofumpt(ctx context.Context, tool, filePath string, content []byte) ([]byte, error) {
+	args := []string{}
+	// gofumpt needs the module path to group imports when reading stdin
+	if moduleInfo, err := l.FindModuleRoot(filePath); err == nil && moduleInfo.Path != "" {
+		args = append(args, "-modpath", moduleInfo.Path)
+	}
+
+	release, err := l.runner.Acquire(ctx, tool)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	cmd := l.runner.Command(ctx, l.Name(), tool, args...)
+	cmd.Stdin = bytes.NewReader(content)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := linters.Run(cmd); err != nil {
+		return nil, fmt.Errorf("gofumpt failed: %w: %s", err, stderr.String())
+	}
+	return stdout.Bytes(), nil
+}
+
+// runGci prints content with imports grouped by gci. gci only reads files, so the
+// content is written to a temporary file with the same name.
+func (l *GoLinter) runGci(ctx context.Context, tool, filePath string, content []byte) ([]byte, error) {
+	dir, err := os.MkdirTemp("", "gismo-gci-*")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	tmpFile := filepath.Join(dir, filepath.Base(filePath))
+	if err := os.WriteFile(tmpFile, content, 0600); err != nil {
+		return nil, err
+	}
+
+	args := []string{"print"}
+	l.mu.RLock()
+	for _, section := range l.config.GciSections {
+		args = append(args, "-s", section)
+	}
+	l.mu.RUnlock()
+	args = append(args, tmpFile)
+
+	release, err := l.runner.Acquire(ctx, tool)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	cmd := l.runner.Command(ctx, l.Name(), tool, args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := linters.Run(cmd); err != nil {
+		return nil, fmt.Errorf("gci failed: %w: %s", err, stderr.String())
+	}
+	return stdout.Bytes(), nil
+}
+
+// findCachedTool locates an optional Go tool such as staticcheck or govulncheck
+// through the tool cache, falling back to $HOME/go/bin where go install puts it
+func (l *GoLinter) findCachedTool(filePath, name string) string {
+	l.mu.Lock()
+	if l.cacheManager == nil {
+		if cache, err := toolcache.NewCacheManager(filePath); err == nil {
+			l.cacheManager = cache
+		}
+	}
+	cache := l.cacheManager
+	l.mu.Unlock()
+
+	if cache != nil {
+		if tool, err := cache.DiscoverTool("go", name); err == nil && tool.Available {
+			return tool.Path
+		}
+	}
+	return findGoTool(name)
+}
+
+// findGoTool locates a Go tool binary in $HOME/go/bin or PATH
+func findGoTool(name string) string {
+	standardPath := filepath.Join(os.Getenv("HOME"), "go", "bin", name)
+	if _, err := os.Stat(standardPath); err == nil {
+		return standardPath
+	}
+	if path, err := exec.LookPath(name); err == nil {
+		return path
+	}
+	return ""
+}
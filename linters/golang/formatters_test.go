@@ -0,0 +1,65 @@
+package golang
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeFakeTool writes an executable shell script named name into dir
+func writeFakeTool(t *testing.T, dir, name, script string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"+script+"\n"), 0755); err != nil {
+		t.Fatalf("failed to write fake %s: %v", name, err)
+	}
+}
+
+func TestGoLinter_Formatters(t *testing.T) {
+	binDir := t.TempDir()
+	// Fake tools append a marker so each pass is visible in the combined output
+	writeFakeTool(t, binDir, "gofumpt", `cat; echo "// gofumpt"`)
+	writeFakeTool(t, binDir, "gci", `for f; do :; done; cat "$f"; echo "// gci"`)
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv("HOME", t.TempDir())
+
+	content := []byte("package main\n")
+	enabled := true
+
+	tests := []struct {
+		name       string
+		config     *GolangConfig
+		wantRules  []string
+		wantSuffix string
+	}{
+		{"disabled by default", &GolangConfig{}, nil, ""},
+		{"gofumpt only", &GolangConfig{Gofumpt: &enabled}, []string{"gofumpt"}, "// gofumpt\n"},
+		{"gofumpt then gci", &GolangConfig{Gofumpt: &enabled, Gci: &enabled}, []string{"gofumpt", "gci"}, "// gofumpt\n// gci\n"},
+		{"disabled check is skipped", &GolangConfig{Gofumpt: &enabled, Gci: &enabled, DisabledChecks: []string{"gofumpt"}}, []string{"gci"}, "package main\n// gci\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			linter := NewGoLinterWithConfig(tt.config)
+			formatted, issues := linter.applyFormatters(context.Background(), "/nonexistent/main.go", content)
+
+			var rules []string
+			for _, issue := range issues {
+				rules = append(rules, issue.Rule)
+			}
+			if strings.Join(rules, ",") != strings.Join(tt.wantRules, ",") {
+				t.Errorf("issue rules = %v, want %v", rules, tt.wantRules)
+			}
+			if tt.wantSuffix == "" {
+				if string(formatted) != string(content) {
+					t.Errorf("formatted = %q, want unchanged", formatted)
+				}
+				return
+			}
+			if !strings.HasSuffix(string(formatted), tt.wantSuffix) {
+				t.Errorf("formatted = %q, want suffix %q", formatted, tt.wantSuffix)
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package golang
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+// defaultGenerateTimeout bounds go generate when no timeout is configured
+const defaultGenerateTimeout = 2 * time.Minute
+
+// generateDirective is a //go:generate comment found in a Go file
+type generateDirective struct {
+	file    string
+	line    int
+	command string
+}
+
+// generateDriftEnabled reports whether go:generate drift detection is configured
+func (l *GoLinter) generateDriftEnabled() bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.config != nil && l.config.GenerateDrift != nil && *l.config.GenerateDrift && !l.isCheckDisabled("generate")
+}
+
+// parseGenerateDirectives returns the //go:generate directives in content
+func parseGenerateDirectives(filePath string, content []byte) []generateDirective {
+	var directives []generateDirective
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if command, ok := strings.CutPrefix(text, "//go:generate "); ok {
+			directives = append(directives, generateDirective{file: filePath, line: line, command: strings.TrimSpace(command)})
+		}
+	}
+	return directives
+}
+
+// packageDirectives returns the directives in all Go files of dir, using content
+// in place of the file on disk for pendingPath
+func (l *GoLinter) packageDirectives(dir, pendingPath string, content []byte) []generateDirective {
+	matches, _ := filepath.Glob(filepath.Join(dir, "*.go"))
+	var directives []generateDirective
+	for _, path := range matches {
+		if path == pendingPath {
+			directives = append(directives, parseGenerateDirectives(path, content)...)
+			continue
+		}
+		data, err := l.fs.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		directives = append(directives, parseGenerateDirectives(path, data)...)
+	}
+	return directives
+}
+
+// sourceDirectives returns the directives in filePath's package that reference it
+// by name, identifying filePath as an input to code generation (e.g. .proto, .sql)
+func (l *GoLinter) sourceDirectives(filePath string) []generateDirective {
+	base := filepath.Base(filePath)
+	var referencing []generateDirective
+	for _, directive := range l.packageDirectives(filepath.Dir(filePath), "", nil) {
+		if strings.Contains(directive.command, base) {
+			referencing = append(referencing, directive)
+		}
+	}
+	return referencing
+}
+
+// isGenerateSource reports whether filePath is a non-Go input to go:generate
+func (l *GoLinter) isGenerateSource(filePath string) bool {
+	if strings.HasSuffix(filePath, ".go") || !l.generateDriftEnabled() {
+		return false
+	}
+	return len(l.sourceDirectives(filePath)) > 0
+}
+
+// checkGenerateDrift runs go generate for the package affected by filePath in a
+// copy of the module and warns about generated files that differ from disk.
+// It only runs when the file contains //go:generate directives or is named by one.
+func (l *GoLinter) checkGenerateDrift(ctx context.Context, filePath string, content []byte) []linters.Issue {
+	if !l.generateDriftEnabled() {
+		return nil
+	}
+
+	var directives []generateDirective
+	if strings.HasSuffix(filePath, ".go") {
+		directives = parseGenerateDirectives(filePath, content)
+	} else {
+		directives = l.sourceDirectives(filePath)
+	}
+	if len(directives) == 0 {
+		return nil
+	}
+
+	moduleInfo, err := l.FindModuleRoot(filePath)
+	if err != nil {
+		return nil
+	}
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return nil
+	}
+	relDir, err := filepath.Rel(moduleInfo.Root, filepath.Dir(absPath))
+	if err != nil {
+		return nil
+	}
+
+	// Generators write into the project, so they run in a copy rather than a symlink shadow
+	ws, err := linters.NewShadowCopy(moduleInfo.Root, map[string][]byte{absPath: content})
+	if err != nil {
+		return nil
+	}
+	defer func() { _ = ws.Close() }()
+
+	timeout := defaultGenerateTimeout
+	if l.config != nil && l.config.GenerateTimeout != nil {
+		timeout = l.config.GenerateTimeout.Duration
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Point at the directive when the changed file contains it
+	line := 1
+	if strings.HasSuffix(filePath, ".go") {
+		line = directives[0].line
+	}
+
+	started := time.Now()
+	pkg := "./" + filepath.ToSlash(relDir)
+	release, err := l.runner.Acquire(ctx, "go")
+	if err != nil {
+		return nil
+	}
+	defer release()
+
+	cmd := l.runner.Command(ctx, l.Name(), "go", "generate", pkg)
+	cmd.Dir = ws.Root
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	if err := linters.Run(cmd); err != nil {
+		return []linters.Issue{{
+			File:     filePath,
+			Line:     line,
+			Column:   1,
+			Severity: "warning",
+			Message:  fmt.Sprintf("go generate %s failed, could not check generated code: %v: %s", pkg, err, strings.TrimSpace(output.String())),
+			Rule:     "generate",
+		}}
+	}
+
+	stale, err := ws.ChangedSince(started, absPath)
+	if err != nil || len(stale) == 0 {
+		return nil
+	}
+
+	return []linters.Issue{{
+		File:     filePath,
+		Line:     line,
+		Column:   1,
+		Severity: "warning",
+		Message:  fmt.Sprintf("Generated code is stale, run `go generate %s` to update: %s", pkg, strings.Join(stale, ", ")),
+		Rule:     "generate",
+	}}
+}
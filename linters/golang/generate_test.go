@@ -0,0 +1,79 @@
+package golang
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseGenerateDirectives(t *testing.T) {
+	content := []byte("package p\n\n//go:generate stringer -type=Kind\n// go:generate not a directive\n//go:generate cp a b\n")
+	directives := parseGenerateDirectives("p.go", content)
+	if len(directives) != 2 {
+		t.Fatalf("got %d directives, want 2", len(directives))
+	}
+	if directives[0].line != 3 || directives[0].command != "stringer -type=Kind" {
+		t.Errorf("unexpected first directive %+v", directives[0])
+	}
+	if directives[1].line != 5 || directives[1].command != "cp a b" {
+		t.Errorf("unexpected second directive %+v", directives[1])
+	}
+}
+
+func TestGoLinter_GenerateDrift(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("go.mod", "module example.com/gen\n\ngo 1.21\n")
+	write("gen.go", "package gen\n\n//go:generate cp data.txt data_gen.txt\n")
+	write("data.txt", "v1\n")
+	write("data_gen.txt", "v1\n")
+	source := filepath.Join(dir, "data.txt")
+
+	enabled := true
+	tests := []struct {
+		name      string
+		config    *GolangConfig
+		content   string
+		wantStale bool
+	}{
+		{"disabled by default", &GolangConfig{}, "v2\n", false},
+		{"up to date", &GolangConfig{GenerateDrift: &enabled}, "v1\n", false},
+		{"stale after source change", &GolangConfig{GenerateDrift: &enabled}, "v2\n", true},
+		{"disabled check", &GolangConfig{GenerateDrift: &enabled, DisabledChecks: []string{"generate"}}, "v2\n", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			linter := NewGoLinterWithConfig(tt.config)
+			issues := linter.checkGenerateDrift(context.Background(), source, []byte(tt.content))
+
+			if !tt.wantStale {
+				if len(issues) != 0 {
+					t.Errorf("expected no issues, got %v", issues)
+				}
+				return
+			}
+			if !linter.CanHandle(source) {
+				t.Error("expected go:generate source to be handled")
+			}
+			if len(issues) != 1 || issues[0].Rule != "generate" || !strings.Contains(issues[0].Message, "data_gen.txt") {
+				t.Fatalf("expected stale data_gen.txt warning, got %v", issues)
+			}
+		})
+	}
+
+	// Generation happens in a copy; the project itself is never modified
+	if data, _ := os.ReadFile(filepath.Join(dir, "data_gen.txt")); string(data) != "v1\n" {
+		t.Errorf("data_gen.txt modified on disk: %q", data)
+	}
+	if NewGoLinter().CanHandle(source) {
+		t.Error("non-Go files should not be handled when drift detection is disabled")
+	}
+}
@@ -3,10 +3,12 @@ package golang
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"go/ast"
 	"go/format"
 	"go/parser"
+	"go/scanner"
 	"go/token"
 	"os"
 	"os/exec"
@@ -18,30 +20,226 @@ import (
 
 	json "github.com/goccy/go-json"
 	"github.com/jrossi/gismo/linters"
+	"github.com/jrossi/gismo/toolcache"
+	"github.com/jrossi/gismo/types"
 )
 
 // GoLinter handles Go file linting, formatting, and test running with golangci-lint integration
 type GoLinter struct {
 	// Cache module roots to avoid repeated filesystem walks
 	moduleCache map[string]*ModuleInfo
+	// Go toolchain versions selected in each module root, zero if unknown
+	goVersions map[string]linters.ToolVersion
 	// Cache golangci-lint binary path for performance
 	golangciPath string
 	golangciOnce sync.Once
-	mu           sync.RWMutex
-	config       *GolangConfig
+	// golangci-lint version, which selects its flags and output format
+	golangciVersion      linters.ToolVersion
+	golangciVersionKnown bool
+	mu                   sync.RWMutex
+	config               *GolangConfig
+	// Filesystem used for project discovery and config lookups
+	fs linters.FileSystem
+	// Runs external tools with the engine's limits, caches and environment
+	runner *linters.CommandRunner
+	// Locates optional tools such as govulncheck; nil uses the project's disk cache
+	cacheManager toolcache.ToolCache
 }
 
 // GolangConfig represents golang linter specific configuration
 type GolangConfig struct {
-	GolangciConfig *string   `json:"golangciConfig,omitempty"` // path to golangci.yml
-	DisabledChecks []string  `json:"disabledChecks,omitempty"`
-	TestTimeout    *Duration `json:"testTimeout,omitempty"`
+	GolangciConfig *string         `json:"golangciConfig,omitempty"` // path to golangci.yml
+	DisabledChecks []string        `json:"disabledChecks,omitempty"`
+	TestTimeout    *types.Duration `json:"testTimeout,omitempty"`
+	Gofumpt        *bool           `json:"gofumpt,omitempty"`     // run gofumpt after gofmt
+	Gci            *bool           `json:"gci,omitempty"`         // group imports with gci after gofmt
+	GciSections    []string        `json:"gciSections,omitempty"` // gci sections, e.g. "standard", "default", "prefix(github.com/org)"
+	// GenerateDrift warns when go:generate output on disk is stale relative to its sources
+	GenerateDrift   *bool           `json:"generateDrift,omitempty"`
+	GenerateTimeout *types.Duration `json:"generateTimeout,omitempty"` // default 2m
+
+	// EnabledChecks are golangci-lint linters enabled on top of its config
+	EnabledChecks []string `json:"enabledChecks,omitempty"`
+	// FastMode limits golangci-lint to its fast linters, default true
+	FastMode *bool `json:"fastMode,omitempty"`
+	// SkipGolangciLint runs the built-in fallback checks instead of golangci-lint
+	SkipGolangciLint *bool `json:"skipGolangciLint,omitempty"`
+	// BuildTags are passed to golangci-lint, staticcheck, go vet and go test
+	BuildTags []string `json:"buildTags,omitempty"`
+	// RunTests runs a file's tests after it changes, default true
+	RunTests *bool `json:"runTests,omitempty"`
+	// TestFlags are extra go test flags, such as "-race" or "-count=1"
+	TestFlags []string `json:"testFlags,omitempty"`
+	// MinCoverage is the package statement coverage, in percent, a changed test
+	// file's package must reach
+	MinCoverage *float64 `json:"minCoverage,omitempty"`
+	// Benchmarks runs a changed test file's benchmarks and warns when one is
+	// slower than its baseline in .claude/gismo-bench.json by more than BenchmarkBudget
+	Benchmarks *bool `json:"benchmarks,omitempty"`
+	// BenchmarkBudget is the tolerated ns/op slowdown in percent, default 20
+	BenchmarkBudget *float64 `json:"benchmarkBudget,omitempty"`
+	// BenchmarkTime is passed to go test as -benchtime, such as "100x" or "500ms"
+	BenchmarkTime *string `json:"benchmarkTime,omitempty"`
+	// Vulncheck runs govulncheck on the package of a written file
+	Vulncheck *bool `json:"vulncheck,omitempty"`
+	// GoVet runs go vet directly even when golangci-lint runs. Otherwise go vet
+	// only runs when golangci-lint is missing or its output was cut short.
+	GoVet *bool `json:"goVet,omitempty"`
+	// VetAnalyzers limits go vet to these analyzers, such as "printf"
+	VetAnalyzers []string `json:"vetAnalyzers,omitempty"`
+	// DisabledVetAnalyzers are go vet analyzers turned off, such as "composites"
+	DisabledVetAnalyzers []string `json:"disabledVetAnalyzers,omitempty"`
+	// BuiltinAnalyzers runs the built-in unchecked-error and ineffectual-assignment
+	// passes in the fallback checks. They see one file at a time, so they miss what
+	// errcheck and ineffassign find across a package; default false.
+	BuiltinAnalyzers *bool `json:"builtinAnalyzers,omitempty"`
 }
 
-// Duration is a wrapper around time.Duration for JSON unmarshaling
-type Duration struct {
-	time.Duration
-}
+// golangciConfigFiles are the golangci-lint config files looked up in the
+// module root, in golangci-lint's own order
+var golangciConfigFiles = []string{".golangci.yml", ".golangci.yaml", ".golangci.toml", ".golangci.json"}
+
+// errGolangciSkipped is returned when the config skips golangci-lint
+var errGolangciSkipped = errors.New("golangci-lint skipped by config")
+
+// configSchema is the JSON Schema for GolangConfig
+const configSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "properties": {
+    "golangciConfig": {
+      "type": "string",
+      "description": "Path to the golangci-lint configuration file"
+    },
+    "disabledChecks": {
+      "type": "array",
+      "items": {
+        "type": "string"
+      },
+      "description": "golangci-lint linters and checks to skip, or \"toolchain-mismatch\" to skip the Go version check"
+    },
+    "testTimeout": {
+      "type": [
+        "string",
+        "number"
+      ],
+      "description": "Timeout for go test, e.g. \"10m\""
+    },
+    "gofumpt": {
+      "type": "boolean",
+      "description": "Run gofumpt after gofmt"
+    },
+    "gci": {
+      "type": "boolean",
+      "description": "Group imports with gci after gofmt"
+    },
+    "gciSections": {
+      "type": "array",
+      "items": {
+        "type": "string"
+      },
+      "description": "gci sections, e.g. \"standard\", \"default\", \"prefix(github.com/org)\""
+    },
+    "generateDrift": {
+      "type": "boolean",
+      "description": "Warn when go:generate output is stale relative to its sources"
+    },
+    "generateTimeout": {
+      "type": [
+        "string",
+        "number"
+      ],
+      "description": "Timeout for go generate, e.g. \"2m\""
+    },
+    "enabledChecks": {
+      "type": "array",
+      "items": {
+        "type": "string"
+      },
+      "description": "golangci-lint linters to enable on top of its config"
+    },
+    "fastMode": {
+      "type": "boolean",
+      "description": "Run only golangci-lint's fast linters (default true)"
+    },
+    "skipGolangciLint": {
+      "type": "boolean",
+      "description": "Run the built-in fallback checks instead of golangci-lint"
+    },
+    "buildTags": {
+      "type": "array",
+      "items": {
+        "type": "string"
+      },
+      "description": "Build tags for golangci-lint, staticcheck, go vet and go test"
+    },
+    "runTests": {
+      "type": "boolean",
+      "description": "Run a file's tests after it changes (default true)"
+    },
+    "testFlags": {
+      "type": "array",
+      "items": {
+        "type": "string"
+      },
+      "description": "Extra go test flags, e.g. \"-race\""
+    },
+    "minCoverage": {
+      "type": "number",
+      "minimum": 0,
+      "maximum": 100,
+      "description": "Minimum package statement coverage in percent when a test file changes"
+    },
+    "benchmarks": {
+      "type": "boolean",
+      "description": "Run a changed test file's benchmarks and warn about regressions against .claude/gismo-bench.json"
+    },
+    "benchmarkBudget": {
+      "type": "number",
+      "minimum": 0,
+      "description": "Tolerated ns/op slowdown in percent of the baseline (default 20)"
+    },
+    "benchmarkTime": {
+      "type": "string",
+      "pattern": "^([0-9]+x|[0-9.]+(ns|us|µs|ms|s|m|h))$",
+      "description": "go test -benchtime, e.g. \"100x\" or \"500ms\""
+    },
+    "vulncheck": {
+      "type": "boolean",
+      "description": "Report known vulnerabilities the package calls, found with govulncheck"
+    },
+    "goVet": {
+      "type": "boolean",
+      "description": "Run go vet directly even when golangci-lint runs"
+    },
+    "vetAnalyzers": {
+      "type": "array",
+      "items": {
+        "type": "string",
+        "pattern": "^[a-z]+$"
+      },
+      "description": "Analyzers go vet runs, all by default, e.g. \"printf\""
+    },
+    "disabledVetAnalyzers": {
+      "type": "array",
+      "items": {
+        "type": "string",
+        "pattern": "^[a-z]+$"
+      },
+      "description": "Analyzers go vet skips, e.g. \"composites\""
+    },
+    "builtinAnalyzers": {
+      "type": "boolean",
+      "description": "Run the built-in single-file unchecked-error and ineffectual-assignment checks when golangci-lint is missing"
+    }
+  },
+  "additionalProperties": false
+}`
+
+// Duration is kept for compatibility.
+//
+// Deprecated: use types.Duration.
+type Duration = types.Duration
 
 // GolangciLintIssue represents an issue from golangci-lint JSON output
 type GolangciLintIssue struct {
@@ -63,6 +261,9 @@ type GolangciLintIssue struct {
 // GolangciLintOutput represents the complete JSON output from golangci-lint
 type GolangciLintOutput struct {
 	Issues []GolangciLintIssue `json:"Issues"`
+	// Partial is set when golangci-lint stopped mid-output, so Issues holds the
+	// ones it printed before stopping
+	Partial bool `json:"-"`
 }
 
 // ModuleInfo contains information about a Go module
@@ -77,6 +278,14 @@ func NewGoLinter() *GoLinter {
 	return NewGoLinterWithConfig(nil)
 }
 
+// NewGoLinterWithToolCache creates a Go linter that locates optional tools such
+// as govulncheck through cache. If cache is nil, the project's disk cache is used.
+func NewGoLinterWithToolCache(config *GolangConfig, cache toolcache.ToolCache) *GoLinter {
+	l := NewGoLinterWithConfig(config)
+	l.cacheManager = cache
+	return l
+}
+
 // NewGoLinterWithConfig creates a new Go linter with the given configuration
 func NewGoLinterWithConfig(config *GolangConfig) *GoLinter {
 	// Apply default configuration if not provided
@@ -86,12 +295,14 @@ func NewGoLinterWithConfig(config *GolangConfig) *GoLinter {
 
 	// Set defaults
 	if config.TestTimeout == nil {
-		defaultTimeout := &Duration{Duration: 10 * time.Minute}
+		defaultTimeout := types.NewDuration(10 * time.Minute)
 		config.TestTimeout = defaultTimeout
 	}
 
 	return &GoLinter{
 		moduleCache: make(map[string]*ModuleInfo),
+		fs:          linters.OSFileSystem{},
+		runner:      linters.NewCommandRunner(linters.RunnerConfig{}),
 		config:      config,
 	}
 }
@@ -111,13 +322,18 @@ func (l *GoLinter) SetConfig(configData json.RawMessage) error {
 
 	// Set defaults if not provided
 	if l.config.TestTimeout == nil {
-		defaultTimeout := &Duration{Duration: 10 * time.Minute}
+		defaultTimeout := types.NewDuration(10 * time.Minute)
 		l.config.TestTimeout = defaultTimeout
 	}
 
 	return nil
 }
 
+// ConfigSchema returns the JSON Schema for the linter configuration
+func (l *GoLinter) ConfigSchema() json.RawMessage {
+	return json.RawMessage(configSchema)
+}
+
 // isCheckDisabled returns true if the given check/linter is disabled in config
 func (l *GoLinter) isCheckDisabled(checkName string) bool {
 	if l.config == nil || len(l.config.DisabledChecks) == 0 {
@@ -133,38 +349,111 @@ func (l *GoLinter) isCheckDisabled(checkName string) bool {
 	return false
 }
 
+// fastMode reports whether golangci-lint runs only its fast linters
+func (l *GoLinter) fastMode() bool {
+	return l.config == nil || l.config.FastMode == nil || *l.config.FastMode
+}
+
+// skipGolangci reports whether the config skips golangci-lint
+func (l *GoLinter) skipGolangci() bool {
+	return l.config != nil && l.config.SkipGolangciLint != nil && *l.config.SkipGolangciLint
+}
+
+// runTestsEnabled reports whether tests run after a file changes
+func (l *GoLinter) runTestsEnabled() bool {
+	return l.config == nil || l.config.RunTests == nil || *l.config.RunTests
+}
+
+// goVetAlways reports whether go vet runs directly even when golangci-lint runs
+func (l *GoLinter) goVetAlways() bool {
+	return l.config != nil && l.config.GoVet != nil && *l.config.GoVet
+}
+
+// buildTags returns the configured build tags as a comma-separated list
+func (l *GoLinter) buildTags() string {
+	if l.config == nil {
+		return ""
+	}
+	return strings.Join(l.config.BuildTags, ",")
+}
+
 // Name returns the linter name
 func (l *GoLinter) Name() string {
 	return "go"
 }
 
-// CanHandle returns true for Go files
+// SetCommandRunner sets the runner used to start external tools
+func (l *GoLinter) SetCommandRunner(runner *linters.CommandRunner) {
+	l.runner = runner
+}
+
+// Capabilities reports the built-in checks and the external tools used when installed
+func (l *GoLinter) Capabilities() linters.Capabilities {
+	return linters.Capabilities{
+		Embedded: append([]string{"syntax", "gofmt"}, embeddedAnalyzers...),
+		Tools:    []string{"golangci-lint", "go", "staticcheck", "gofumpt", "gci", "govulncheck"},
+	}
+}
+
+// SetFileSystem sets the filesystem used for project discovery and config lookups
+func (l *GoLinter) SetFileSystem(fsys linters.FileSystem) {
+	l.fs = fsys
+}
+
+// CanHandle returns true for Go files, and for go:generate sources such as .proto
+// or .sql files when generate drift detection is enabled
 func (l *GoLinter) CanHandle(filePath string) bool {
-	return strings.HasSuffix(filePath, ".go")
+	return strings.HasSuffix(filePath, ".go") || l.isGenerateSource(filePath)
 }
 
-// findGolangciLint locates the golangci-lint binary and caches the path
+// findGolangciLint locates the golangci-lint binary and caches the path and version
 func (l *GoLinter) findGolangciLint() string {
 	l.golangciOnce.Do(func() {
 		// Check standard Go installation location first
 		standardPath := filepath.Join(os.Getenv("HOME"), "go", "bin", "golangci-lint")
 		if _, err := os.Stat(standardPath); err == nil {
 			l.golangciPath = standardPath
-			return
-		}
-
-		// Check PATH
-		if path, err := exec.LookPath("golangci-lint"); err == nil {
+		} else if path, err := exec.LookPath("golangci-lint"); err == nil {
+			// Check PATH
 			l.golangciPath = path
+		} else {
+			// Not found
+			l.golangciPath = ""
 			return
 		}
-
-		// Not found
-		l.golangciPath = ""
+		l.golangciVersion, l.golangciVersionKnown = linters.ParseToolVersion(toolcache.DetectVersion(l.golangciPath))
 	})
 	return l.golangciPath
 }
 
+// golangciArgs returns the arguments that run golangci-lint with JSON output,
+// in fast mode if fast is set. Version 2 renamed both flags; versions that
+// can't be detected get the 1.x flags.
+func golangciArgs(version linters.ToolVersion, known, fast bool) []string {
+	args := []string{"run"}
+	v2 := known && version.Major >= 2
+	if fast {
+		if v2 {
+			args = append(args, "--fast-only")
+		} else {
+			args = append(args, "--fast")
+		}
+	}
+	if v2 {
+		return append(args, "--output.json.path=stdout")
+	}
+	return append(args, "--out-format=json")
+}
+
+// golangciUnsupported returns the error for a golangci-lint run whose output
+// couldn't be read, if its version is newer than the supported ones
+func (l *GoLinter) golangciUnsupported() *linters.UnsupportedVersionError {
+	if !l.golangciVersionKnown || l.golangciVersion.Major <= 2 {
+		return nil
+	}
+	return &linters.UnsupportedVersionError{Tool: "golangci-lint", Version: l.golangciVersion, Supported: "1.x and 2.x"}
+}
+
 // runGolangciLint executes golangci-lint with fast mode on the specified file
 func (l *GoLinter) runGolangciLint(ctx context.Context, filePath string) (*GolangciLintOutput, error) {
 	return l.runGolangciLintMultiple(ctx, []string{filePath})
@@ -175,6 +464,9 @@ func (l *GoLinter) runGolangciLintMultiple(ctx context.Context, filePaths []stri
 	if len(filePaths) == 0 {
 		return &GolangciLintOutput{}, nil
 	}
+	if l.skipGolangci() {
+		return nil, errGolangciSkipped
+	}
 
 	golangciPath := l.findGolangciLint()
 	if golangciPath == "" {
@@ -188,19 +480,28 @@ func (l *GoLinter) runGolangciLintMultiple(ctx context.Context, filePaths []stri
 	}
 
 	// Build golangci-lint arguments
-	args := []string{"run", "--fast", "--out-format=json"}
+	args := golangciArgs(l.golangciVersion, l.golangciVersionKnown, l.fastMode())
 
 	// Check for configured golangci config file first
 	if l.config != nil && l.config.GolangciConfig != nil && *l.config.GolangciConfig != "" {
 		// Use the configured path
 		args = append(args, "--config="+*l.config.GolangciConfig)
 	} else {
-		// Check for default .golangci.yml config file
-		configPath := filepath.Join(moduleInfo.Root, ".golangci.yml")
-		if _, err := os.Stat(configPath); err == nil {
-			args = append(args, "--config="+configPath)
+		// Otherwise use the module's own config file
+		for _, name := range golangciConfigFiles {
+			configPath := filepath.Join(moduleInfo.Root, name)
+			if _, err := l.fs.Stat(configPath); err == nil {
+				args = append(args, "--config="+configPath)
+				break
+			}
 		}
 	}
+	if l.config != nil && len(l.config.EnabledChecks) > 0 {
+		args = append(args, "--enable="+strings.Join(l.config.EnabledChecks, ","))
+	}
+	if tags := l.buildTags(); tags != "" {
+		args = append(args, "--build-tags="+tags)
+	}
 
 	// Add parallelism flag for better performance
 	args = append(args, "--concurrency", fmt.Sprintf("%d", runtime.NumCPU()))
@@ -208,8 +509,14 @@ func (l *GoLinter) runGolangciLintMultiple(ctx context.Context, filePaths []stri
 	// Add all file paths
 	args = append(args, filePaths...)
 
+	release, err := l.runner.Acquire(ctx, golangciPath)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	// Execute golangci-lint
-	cmd := exec.CommandContext(ctx, golangciPath, args...)
+	cmd := l.runner.Command(ctx, l.Name(), golangciPath, args...)
 	cmd.Dir = moduleInfo.Root
 
 	var stdout, stderr bytes.Buffer
@@ -217,18 +524,31 @@ func (l *GoLinter) runGolangciLintMultiple(ctx context.Context, filePaths []stri
 	cmd.Stderr = &stderr
 
 	// golangci-lint returns non-zero exit code when issues are found, which is expected
-	err = cmd.Run()
+	err = linters.Run(cmd)
 
 	// Check if the error is due to issues found (expected) or actual failure
 	if err != nil && stdout.Len() == 0 {
+		if unsupported := l.golangciUnsupported(); unsupported != nil {
+			return nil, unsupported
+		}
 		return nil, fmt.Errorf("golangci-lint failed: %v\nstderr: %s", err, stderr.String())
 	}
 
-	// Parse JSON output
+	// Parse JSON output. Only the first value is read, as version 2 may print a
+	// text summary after it. Output cut short by a timeout keeps the issues
+	// printed before it.
 	var output GolangciLintOutput
 	if stdout.Len() > 0 {
-		if err := json.Unmarshal(stdout.Bytes(), &output); err != nil {
-			return nil, fmt.Errorf("failed to parse golangci-lint output: %w", err)
+		if err := json.NewDecoder(bytes.NewReader(stdout.Bytes())).Decode(&output); err != nil {
+			if unsupported := l.golangciUnsupported(); unsupported != nil {
+				return nil, unsupported
+			}
+			output = GolangciLintOutput{}
+			partial, err := linters.SalvageJSON(stdout.Bytes(), &output)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse golangci-lint output: %w", err)
+			}
+			output.Partial = partial
 		}
 	}
 
@@ -244,19 +564,13 @@ func (l *GoLinter) convertGolangciIssues(golangciIssues []GolangciLintIssue) []l
 			continue
 		}
 
-		severity := "warning"
-		if issue.Severity == "error" {
-			severity = "error"
-		}
-
-		issues = append(issues, linters.Issue{
-			File:     issue.Pos.Filename,
-			Line:     issue.Pos.Line,
-			Column:   issue.Pos.Column,
-			Severity: severity,
-			Message:  issue.Text,
-			Rule:     issue.FromLinter,
-		})
+		issues = append(issues, linters.ToolIssue("golangci-lint", issue.Severity, linters.Issue{
+			File:    issue.Pos.Filename,
+			Line:    issue.Pos.Line,
+			Column:  issue.Pos.Column,
+			Message: issue.Text,
+			Rule:    issue.FromLinter,
+		}))
 	}
 	return issues
 }
@@ -268,6 +582,12 @@ func (l *GoLinter) Lint(ctx context.Context, filePath string, content []byte) (*
 		Issues:  []linters.Issue{},
 	}
 
+	// Non-Go files are only handled as go:generate sources
+	if !strings.HasSuffix(filePath, ".go") {
+		result.Issues = append(result.Issues, l.checkGenerateDrift(ctx, filePath, content)...)
+		return result, nil
+	}
+
 	// Skip generated files
 	if bytes.Contains(content, []byte("// Code generated")) {
 		return result, nil
@@ -281,11 +601,12 @@ func (l *GoLinter) Lint(ctx context.Context, filePath string, content []byte) (*
 	// Always check basic syntax first with go/format (fast and reliable)
 	formatted, err := format.Source(content)
 	if err != nil {
+		line, column := syntaxErrorPosition(content, err)
 		result.Success = false
 		result.Issues = append(result.Issues, linters.Issue{
 			File:     filePath,
-			Line:     1,
-			Column:   1,
+			Line:     line,
+			Column:   column,
 			Severity: "error",
 			Message:  fmt.Sprintf("Go syntax error: %v", err),
 			Rule:     "syntax",
@@ -295,7 +616,6 @@ func (l *GoLinter) Lint(ctx context.Context, filePath string, content []byte) (*
 
 	// Check if formatting is needed
 	if !bytes.Equal(content, formatted) {
-		result.Formatted = formatted
 		result.Issues = append(result.Issues, linters.Issue{
 			File:     filePath,
 			Line:     1,
@@ -306,11 +626,43 @@ func (l *GoLinter) Lint(ctx context.Context, filePath string, content []byte) (*
 		})
 	}
 
+	// Run optional gofumpt/gci passes on top of gofmt for combined formatted output
+	formatted, formatIssues := l.applyFormatters(ctx, filePath, formatted)
+	result.Issues = append(result.Issues, formatIssues...)
+	if !bytes.Equal(content, formatted) {
+		result.Formatted = formatted
+	}
+
+	// Content that differs from disk is pending a write (PreToolUse). Tools that read
+	// the module from disk must see it, so lint in a shadow workspace and run tests
+	// with an overlay instead of checking the stale file.
+	pending := l.pendingContent(filePath, content)
+	lintPath := filePath
+	var shadow *linters.ShadowWorkspace
+	if pending != nil && !l.skipGolangci() && l.findGolangciLint() != "" {
+		if moduleInfo, err := l.FindModuleRoot(filePath); err == nil {
+			if ws, err := linters.NewShadowWorkspace(moduleInfo.Root, pending); err == nil {
+				defer func() { _ = ws.Close() }()
+				shadow = ws
+				lintPath = ws.Path(filePath)
+			}
+		}
+	}
+
 	// Try enhanced linting with golangci-lint fast mode
-	if golangciOutput, err := l.runGolangciLint(ctx, filePath); err == nil {
+	if golangciOutput, err := l.runGolangciLint(ctx, lintPath); err == nil {
 		// Successfully ran golangci-lint, add its issues
 		golangciIssues := l.convertGolangciIssues(golangciOutput.Issues)
+		runeColumns(content, golangciIssues)
+		if shadow != nil {
+			for i := range golangciIssues {
+				golangciIssues[i].File = shadow.Original(golangciIssues[i].File)
+			}
+		}
 		result.Issues = append(result.Issues, golangciIssues...)
+		if golangciOutput.Partial {
+			result.MarkPartial("golangci-lint", filePath)
+		}
 
 		// Check if any issues are errors (should block)
 		for _, issue := range golangciIssues {
@@ -318,12 +670,44 @@ func (l *GoLinter) Lint(ctx context.Context, filePath string, content []byte) (*
 				result.Success = false
 			}
 		}
+
+		// go vet runs directly when configured, and to cover what golangci-lint
+		// didn't get to before it was cut short
+		if (l.goVetAlways() || golangciOutput.Partial) && !l.isCheckDisabled("govet") {
+			vetIssues := l.runGoVet(ctx, filePath, pending)
+			runeColumns(content, vetIssues)
+			result.Issues = append(result.Issues, vetIssues...)
+		}
+	} else {
+		var unsupported *linters.UnsupportedVersionError
+		if errors.As(err, &unsupported) {
+			result.Issues = append(result.Issues, unsupported.Issue(filePath))
+		}
+		// Without golangci-lint, fall back to staticcheck or go vet
+		result.Issues = append(result.Issues, l.runFallbackChecks(ctx, filePath, content, pending)...)
+	}
+
+	// Warn when generated code no longer matches this file's go:generate directives
+	result.Issues = append(result.Issues, l.checkGenerateDrift(ctx, filePath, content)...)
+	result.Issues = append(result.Issues, l.checkToolchain(ctx, filePath)...)
+
+	// Static-only checks stop here
+	if linters.IsStaticOnly(ctx) {
+		return result, nil
+	}
+
+	// govulncheck reads the package from disk, so it only checks written files
+	if pending == nil {
+		result.Issues = append(result.Issues, l.checkVulnerabilities(ctx, filePath)...)
+	}
+
+	if !l.runTestsEnabled() {
+		return result, nil
 	}
-	// If golangci-lint fails, we continue with basic linting (graceful fallback)
 
 	// Run tests if this is a test file
 	if strings.HasSuffix(filePath, "_test.go") {
-		if output, err := l.runTests(ctx, filePath); err != nil {
+		if output, coverage, err := l.runTestFile(ctx, filePath, pending); err != nil {
 			result.Success = false
 			result.Issues = append(result.Issues, linters.Issue{
 				File:     filePath,
@@ -335,13 +719,18 @@ func (l *GoLinter) Lint(ctx context.Context, filePath string, content []byte) (*
 			})
 			result.TestOutput = output
 		} else {
+			if coverage != nil {
+				result.Success = false
+				result.Issues = append(result.Issues, *coverage)
+			}
 			result.TestOutput = output
+			result.Issues = append(result.Issues, l.checkBenchmarks(ctx, filePath, content, pending)...)
 		}
 	} else {
 		// For non-test files, check if corresponding test file exists and run it
 		testFile := strings.TrimSuffix(filePath, ".go") + "_test.go"
-		if _, err := os.Stat(testFile); err == nil {
-			if output, err := l.runTests(ctx, testFile); err != nil {
+		if _, err := l.fs.Stat(testFile); err == nil {
+			if output, err := l.runTestsWithOverlay(ctx, testFile, pending); err != nil {
 				result.Success = false
 				result.Issues = append(result.Issues, linters.Issue{
 					File:     testFile,
@@ -361,6 +750,26 @@ func (l *GoLinter) Lint(ctx context.Context, filePath string, content []byte) (*
 	return result, nil
 }
 
+// syntaxErrorPosition returns the line and rune column of the first error
+// go/format reports, or 1:1 if the error has no position
+func syntaxErrorPosition(content []byte, err error) (line, column int) {
+	var list scanner.ErrorList
+	if !errors.As(err, &list) || len(list) == 0 {
+		return 1, 1
+	}
+	pos := list[0].Pos
+	return pos.Line, linters.NewPositions(content).RuneColumn(pos.Line, pos.Column)
+}
+
+// runeColumns converts the byte columns Go tools report for content to the rune
+// columns issues use
+func runeColumns(content []byte, issues []linters.Issue) {
+	positions := linters.NewPositions(content)
+	for i := range issues {
+		issues[i].Column = positions.RuneColumn(issues[i].Line, issues[i].Column)
+	}
+}
+
 // findCommonPrefix finds the longest common prefix among test names
 func findCommonPrefix(tests []string) string {
 	if len(tests) == 0 {
@@ -395,11 +804,16 @@ func findCommonPrefix(tests []string) string {
 // extractTestFunctions parses a Go test file and extracts all test function names
 func (l *GoLinter) extractTestFunctions(filePath string) ([]string, error) {
 	// Read the file content
-	content, err := os.ReadFile(filePath)
+	content, err := l.fs.ReadFile(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
 
+	return parseTestFunctions(filePath, content)
+}
+
+// parseTestFunctions extracts all test function names from Go test source
+func parseTestFunctions(filePath string, content []byte) ([]string, error) {
 	// Parse the file - we only need function declarations, not the full AST
 	fset := token.NewFileSet()
 	file, err := parser.ParseFile(fset, filePath, content, parser.ParseComments)
@@ -471,13 +885,13 @@ func (l *GoLinter) FindModuleRoot(startPath string) (*ModuleInfo, error) {
 
 	// Walk up the directory tree
 	currentPath := absPath
-	if info, err := os.Stat(currentPath); err == nil && !info.IsDir() {
+	if info, err := l.fs.Stat(currentPath); err == nil && !info.IsDir() {
 		currentPath = filepath.Dir(currentPath)
 	}
 
 	for {
 		goModPath := filepath.Join(currentPath, "go.mod")
-		if _, err := os.Stat(goModPath); err == nil {
+		if _, err := l.fs.Stat(goModPath); err == nil {
 			// Found go.mod file
 			moduleInfo := &ModuleInfo{
 				Root:      currentPath,
@@ -485,7 +899,7 @@ func (l *GoLinter) FindModuleRoot(startPath string) (*ModuleInfo, error) {
 			}
 
 			// Read module path from go.mod
-			if data, err := os.ReadFile(goModPath); err == nil {
+			if data, err := l.fs.ReadFile(goModPath); err == nil {
 				lines := strings.Split(string(data), "\n")
 				for _, line := range lines {
 					if strings.HasPrefix(line, "module ") {
@@ -515,6 +929,19 @@ func (l *GoLinter) FindModuleRoot(startPath string) (*ModuleInfo, error) {
 
 // runTests runs tests for a specific Go file
 func (l *GoLinter) runTests(ctx context.Context, testFile string) (string, error) {
+	return l.runTestsWithOverlay(ctx, testFile, nil)
+}
+
+// runTestsWithOverlay runs tests for a specific Go file, substituting pending
+// content for files that have not been written to disk yet
+func (l *GoLinter) runTestsWithOverlay(ctx context.Context, testFile string, pending map[string][]byte) (string, error) {
+	return l.runGoTest(ctx, testFile, pending, "")
+}
+
+// runGoTest runs go test for testFile's package. Without a coverProfile only the
+// tests of testFile run; with one, all of the package's tests run so the
+// coverage written to coverProfile is the package's.
+func (l *GoLinter) runGoTest(ctx context.Context, testFile string, pending map[string][]byte, coverProfile string) (string, error) {
 	// Find module root
 	moduleInfo, err := l.FindModuleRoot(testFile)
 	if err != nil {
@@ -537,7 +964,12 @@ func (l *GoLinter) runTests(ctx context.Context, testFile string) (string, error
 	var testPattern string
 
 	// Try to extract actual test functions from the file
-	testFunctions, err := l.extractTestFunctions(testFile)
+	var testFunctions []string
+	if absTestFile, absErr := filepath.Abs(testFile); absErr == nil && pending[absTestFile] != nil {
+		testFunctions, err = parseTestFunctions(testFile, pending[absTestFile])
+	} else {
+		testFunctions, err = l.extractTestFunctions(testFile)
+	}
 	if err == nil && len(testFunctions) > 0 {
 		// Successfully extracted test functions
 		if len(testFunctions) == 1 {
@@ -574,24 +1006,49 @@ func (l *GoLinter) runTests(ctx context.Context, testFile string) (string, error
 
 	// Build test command with timeout
 	args := []string{"test", "-v", "-run", testPattern}
+	if coverProfile != "" {
+		args = []string{"test", "-v", "-coverprofile=" + coverProfile}
+	}
+
+	// Let go test see pending content without writing it to the module
+	if len(pending) > 0 {
+		overlay, err := newGoOverlay(pending)
+		if err != nil {
+			return "", err
+		}
+		defer func() { _ = overlay.Close() }()
+		args = append(args, overlay.flag())
+	}
 
 	// Add timeout if configured
 	if l.config != nil && l.config.TestTimeout != nil {
 		args = append(args, "-timeout", l.config.TestTimeout.Duration.String())
 	}
+	if tags := l.buildTags(); tags != "" {
+		args = append(args, "-tags="+tags)
+	}
+	if l.config != nil {
+		args = append(args, l.config.TestFlags...)
+	}
 
 	args = append(args, testPath)
 
+	release, err := l.runner.Acquire(ctx, "go")
+	if err != nil {
+		return "", err
+	}
+	defer release()
+
 	// Run go test with -run flag to only run tests matching the pattern
 	// This ensures we only run tests from the specific test file
-	cmd := exec.CommandContext(ctx, "go", args...)
+	cmd := l.runner.Command(ctx, l.Name(), "go", args...)
 	cmd.Dir = moduleInfo.Root
 
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 
-	err = cmd.Run()
+	err = linters.Run(cmd)
 	output := stdout.String()
 	if stderr.Len() > 0 {
 		output += "\n" + stderr.String()
@@ -621,6 +1078,13 @@ func (l *GoLinter) LintBatch(ctx context.Context, files map[string][]byte) (map[
 			Issues:  []linters.Issue{},
 		}
 
+		// Non-Go files are only handled as go:generate sources
+		if !strings.HasSuffix(filePath, ".go") {
+			result.Issues = append(result.Issues, l.checkGenerateDrift(ctx, filePath, content)...)
+			results[filePath] = result
+			continue
+		}
+
 		// Skip generated files
 		if bytes.Contains(content, []byte("// Code generated")) {
 			results[filePath] = result
@@ -636,11 +1100,12 @@ func (l *GoLinter) LintBatch(ctx context.Context, files map[string][]byte) (map[
 		// Check basic syntax first
 		formatted, err := format.Source(content)
 		if err != nil {
+			line, column := syntaxErrorPosition(content, err)
 			result.Success = false
 			result.Issues = append(result.Issues, linters.Issue{
 				File:     filePath,
-				Line:     1,
-				Column:   1,
+				Line:     line,
+				Column:   column,
 				Severity: "error",
 				Message:  fmt.Sprintf("Go syntax error: %v", err),
 				Rule:     "syntax",
@@ -651,7 +1116,6 @@ func (l *GoLinter) LintBatch(ctx context.Context, files map[string][]byte) (map[
 
 		// Check if formatting is needed
 		if !bytes.Equal(content, formatted) {
-			result.Formatted = formatted
 			// Only add formatting issue if gofmt is not disabled
 			if !l.isCheckDisabled("gofmt") {
 				result.Issues = append(result.Issues, linters.Issue{
@@ -665,6 +1129,17 @@ func (l *GoLinter) LintBatch(ctx context.Context, files map[string][]byte) (map[
 			}
 		}
 
+		// Run optional gofumpt/gci passes on top of gofmt
+		formatted, formatIssues := l.applyFormatters(ctx, filePath, formatted)
+		result.Issues = append(result.Issues, formatIssues...)
+		if !bytes.Equal(content, formatted) {
+			result.Formatted = formatted
+		}
+
+		// Warn when generated code no longer matches this file's go:generate directives
+		result.Issues = append(result.Issues, l.checkGenerateDrift(ctx, filePath, content)...)
+		result.Issues = append(result.Issues, l.checkToolchain(ctx, filePath)...)
+
 		results[filePath] = result
 		goFiles = append(goFiles, filePath)
 	}
@@ -672,6 +1147,11 @@ func (l *GoLinter) LintBatch(ctx context.Context, files map[string][]byte) (map[
 	// Run golangci-lint on all valid Go files at once
 	if len(goFiles) > 0 {
 		if golangciOutput, err := l.runGolangciLintMultiple(ctx, goFiles); err == nil {
+			if golangciOutput.Partial {
+				for _, filePath := range goFiles {
+					results[filePath].MarkPartial("golangci-lint", filePath)
+				}
+			}
 			// Map issues back to their files
 			for _, issue := range golangciOutput.Issues {
 				// Skip disabled checks
@@ -680,24 +1160,38 @@ func (l *GoLinter) LintBatch(ctx context.Context, files map[string][]byte) (map[
 				}
 
 				if result, exists := results[issue.Pos.Filename]; exists {
-					severity := "warning"
-					if issue.Severity == "error" {
-						severity = "error"
+					converted := linters.ToolIssue("golangci-lint", issue.Severity, linters.Issue{
+						File:    issue.Pos.Filename,
+						Line:    issue.Pos.Line,
+						Column:  linters.NewPositions(files[issue.Pos.Filename]).RuneColumn(issue.Pos.Line, issue.Pos.Column),
+						Message: issue.Text,
+						Rule:    issue.FromLinter,
+					})
+					if converted.Severity == "error" {
 						result.Success = false
 					}
-
-					result.Issues = append(result.Issues, linters.Issue{
-						File:     issue.Pos.Filename,
-						Line:     issue.Pos.Line,
-						Column:   issue.Pos.Column,
-						Severity: severity,
-						Message:  issue.Text,
-						Rule:     issue.FromLinter,
-					})
+					result.Issues = append(result.Issues, converted)
+				}
+			}
+			if (l.goVetAlways() || golangciOutput.Partial) && !l.isCheckDisabled("govet") {
+				for _, filePath := range goFiles {
+					vetIssues := l.runGoVet(ctx, filePath, nil)
+					runeColumns(files[filePath], vetIssues)
+					results[filePath].Issues = append(results[filePath].Issues, vetIssues...)
 				}
 			}
+		} else {
+			var unsupported *linters.UnsupportedVersionError
+			isUnsupported := errors.As(err, &unsupported)
+			// Without golangci-lint, still run core correctness checks on each file
+			for _, filePath := range goFiles {
+				if isUnsupported {
+					results[filePath].Issues = append(results[filePath].Issues, unsupported.Issue(filePath))
+				}
+				fallbackIssues := l.runFallbackChecks(ctx, filePath, files[filePath], nil)
+				results[filePath].Issues = append(results[filePath].Issues, fallbackIssues...)
+			}
 		}
-		// If golangci-lint fails, we continue with basic linting results
 	}
 
 	// Run tests for test files
@@ -705,12 +1199,12 @@ func (l *GoLinter) LintBatch(ctx context.Context, files map[string][]byte) (map[
 	var mu sync.Mutex
 
 	for filePath, content := range files {
-		if strings.HasSuffix(filePath, "_test.go") {
+		if strings.HasSuffix(filePath, "_test.go") && l.runTestsEnabled() {
 			wg.Add(1)
 			go func(path string, content []byte) {
 				defer wg.Done()
 
-				if output, err := l.runTests(ctx, path); err != nil {
+				if output, coverage, err := l.runTestFile(ctx, path, nil); err != nil {
 					mu.Lock()
 					if result, exists := results[path]; exists {
 						result.Success = false
@@ -728,9 +1222,20 @@ func (l *GoLinter) LintBatch(ctx context.Context, files map[string][]byte) (map[
 				} else {
 					mu.Lock()
 					if result, exists := results[path]; exists {
+						if coverage != nil {
+							result.Success = false
+							result.Issues = append(result.Issues, *coverage)
+						}
 						result.TestOutput = output
 					}
 					mu.Unlock()
+
+					benchmarkIssues := l.checkBenchmarks(ctx, path, content, nil)
+					mu.Lock()
+					if result, exists := results[path]; exists {
+						result.Issues = append(result.Issues, benchmarkIssues...)
+					}
+					mu.Unlock()
 				}
 			}(filePath, content)
 		}
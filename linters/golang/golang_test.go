@@ -2,12 +2,16 @@ package golang
 
 import (
 	"context"
+	"errors"
+	"go/format"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/goccy/go-json"
+	"github.com/jrossi/gismo/linters"
 )
 
 func TestGoLinter_CanHandle(t *testing.T) {
@@ -123,6 +127,21 @@ fmt.Println("Unformatted but generated")
 	}
 }
 
+func TestSyntaxErrorPosition(t *testing.T) {
+	content := []byte("package main\n\nvar s = \"é\" )\n")
+	_, err := format.Source(content)
+	if err == nil {
+		t.Fatal("expected a syntax error")
+	}
+	// The column counts é as one character
+	if line, column := syntaxErrorPosition(content, err); line != 3 || column != 13 {
+		t.Errorf("syntaxErrorPosition() = %d:%d, want 3:13", line, column)
+	}
+	if line, column := syntaxErrorPosition(content, errors.New("no position")); line != 1 || column != 1 {
+		t.Errorf("syntaxErrorPosition() without position = %d:%d, want 1:1", line, column)
+	}
+}
+
 func TestGoLinter_SkipTestData(t *testing.T) {
 	linter := NewGoLinter()
 	ctx := context.Background()
@@ -149,11 +168,45 @@ fmt.Println("This should be skipped")
 }
 
 func TestGoLinter_FindModuleRoot(t *testing.T) {
-	// This test would need a real filesystem setup
-	// For now, we'll just test that the method exists
-	// In a real test, we'd create temporary directories with go.mod files
+	fsys := linters.NewMemFileSystem()
+	_ = fsys.WriteFile("/repo/go.mod", []byte("module example.com/repo\n\ngo 1.23\n"), 0644)
+	_ = fsys.WriteFile("/repo/pkg/util/util.go", []byte("package util\n"), 0644)
+	_ = fsys.WriteFile("/repo/tools/go.mod", []byte("module example.com/repo/tools\n"), 0644)
 
-	t.Skip("Skipping filesystem-dependent test")
+	tests := []struct {
+		name     string
+		path     string
+		wantRoot string
+		wantPath string
+		wantErr  bool
+	}{
+		{"file in nested package", "/repo/pkg/util/util.go", "/repo", "example.com/repo", false},
+		{"file not yet on disk", "/repo/pkg/new.go", "/repo", "example.com/repo", false},
+		{"nested module", "/repo/tools/main.go", "/repo/tools", "example.com/repo/tools", false},
+		{"outside any module", "/elsewhere/main.go", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			linter := NewGoLinter()
+			linter.SetFileSystem(fsys)
+
+			info, err := linter.FindModuleRoot(tt.path)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("FindModuleRoot(%q) expected error, got %+v", tt.path, info)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FindModuleRoot(%q) error = %v", tt.path, err)
+			}
+			if info.Root != tt.wantRoot || info.Path != tt.wantPath {
+				t.Errorf("FindModuleRoot(%q) = {Root: %q, Path: %q}, want {Root: %q, Path: %q}",
+					tt.path, info.Root, info.Path, tt.wantRoot, tt.wantPath)
+			}
+		})
+	}
 }
 
 func TestGoLinter_FormatFile(t *testing.T) {
@@ -393,6 +446,19 @@ fmt.Println("Hello, World!")  // Unformatted to test basic linting
 	}
 }
 
+func TestGoLinter_SetConfig_Durations(t *testing.T) {
+	linter := NewGoLinter()
+	if err := linter.SetConfig([]byte(`{"testTimeout": "90s", "generateTimeout": 30000}`)); err != nil {
+		t.Fatalf("SetConfig() error = %v", err)
+	}
+	if got := linter.config.TestTimeout.Duration; got != 90*time.Second {
+		t.Errorf("TestTimeout = %v, want 90s", got)
+	}
+	if got := linter.config.GenerateTimeout.Duration; got != 30*time.Second {
+		t.Errorf("GenerateTimeout = %v, want 30s from integer milliseconds", got)
+	}
+}
+
 func TestGoLinter_ConfigFileDetection(t *testing.T) {
 	// Create a temporary directory with a .golangci.yml file
 	tempDir, err := os.MkdirTemp("", "golangci-test")
@@ -504,3 +570,50 @@ func main() {
 		}
 	}
 }
+
+func TestGolangciArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		version linters.ToolVersion
+		known   bool
+		full    bool
+		want    string
+	}{
+		{name: "unknown version", want: "run --fast --out-format=json"},
+		{name: "version 1", version: linters.ToolVersion{Major: 1, Minor: 64}, known: true, want: "run --fast --out-format=json"},
+		{name: "version 2", version: linters.ToolVersion{Major: 2, Minor: 1}, known: true, want: "run --fast-only --output.json.path=stdout"},
+		{name: "version 1 all linters", version: linters.ToolVersion{Major: 1, Minor: 64}, known: true, full: true, want: "run --out-format=json"},
+		{name: "version 2 all linters", version: linters.ToolVersion{Major: 2, Minor: 1}, known: true, full: true, want: "run --output.json.path=stdout"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := strings.Join(golangciArgs(tt.version, tt.known, !tt.full), " "); got != tt.want {
+				t.Errorf("golangciArgs() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGoLinter_SetConfigToggles(t *testing.T) {
+	linter := NewGoLinter()
+	if !linter.fastMode() || linter.skipGolangci() || !linter.runTestsEnabled() || linter.buildTags() != "" {
+		t.Error("defaults: want fast mode and tests, golangci-lint not skipped, no build tags")
+	}
+
+	err := linter.SetConfig(json.RawMessage(`{
+		"fastMode": false,
+		"skipGolangciLint": true,
+		"runTests": false,
+		"buildTags": ["integration", "e2e"],
+		"testFlags": ["-race"]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if linter.fastMode() || !linter.skipGolangci() || linter.runTestsEnabled() || linter.buildTags() != "integration,e2e" {
+		t.Errorf("configured toggles not applied: %+v", linter.config)
+	}
+	if _, err := linter.runGolangciLintMultiple(context.Background(), []string{"main.go"}); !errors.Is(err, errGolangciSkipped) {
+		t.Errorf("runGolangciLintMultiple() error = %v, want errGolangciSkipped", err)
+	}
+}
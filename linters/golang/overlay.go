@@ -0,0 +1,96 @@
+package golang
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	json "github.com/goccy/go-json"
+)
+
+// goOverlay materializes pending file content for the go command's -overlay flag,
+// letting go test and go vet see content that has not been written to disk yet
+type goOverlay struct {
+	dir  string
+	path string
+	// files maps original absolute paths to their overlay replacements
+	files map[string]string
+}
+
+// goOverlayFile is the JSON format accepted by -overlay
+type goOverlayFile struct {
+	Replace map[string]string `json:"Replace"`
+}
+
+// newGoOverlay writes each pending file to a temporary directory and creates the
+// overlay description mapping the original paths to those files
+func newGoOverlay(pending map[string][]byte) (*goOverlay, error) {
+	dir, err := os.MkdirTemp("", "gismo-overlay-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create overlay directory: %w", err)
+	}
+	o := &goOverlay{dir: dir, files: make(map[string]string, len(pending))}
+
+	overlay := goOverlayFile{Replace: make(map[string]string, len(pending))}
+	i := 0
+	for path, content := range pending {
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			_ = o.Close()
+			return nil, fmt.Errorf("failed to get absolute path: %w", err)
+		}
+
+		// Keep the base name so compiler diagnostics stay recognizable
+		replacement := filepath.Join(dir, strconv.Itoa(i), filepath.Base(path))
+		if err := os.MkdirAll(filepath.Dir(replacement), 0755); err != nil {
+			_ = o.Close()
+			return nil, fmt.Errorf("failed to create overlay directory: %w", err)
+		}
+		if err := os.WriteFile(replacement, content, 0644); err != nil {
+			_ = o.Close()
+			return nil, fmt.Errorf("failed to write overlay file: %w", err)
+		}
+		overlay.Replace[absPath] = replacement
+		o.files[absPath] = replacement
+		i++
+	}
+
+	data, err := json.Marshal(overlay)
+	if err != nil {
+		_ = o.Close()
+		return nil, fmt.Errorf("failed to encode overlay: %w", err)
+	}
+	o.path = filepath.Join(dir, "overlay.json")
+	if err := os.WriteFile(o.path, data, 0644); err != nil {
+		_ = o.Close()
+		return nil, fmt.Errorf("failed to write overlay: %w", err)
+	}
+
+	return o, nil
+}
+
+// flag returns the go command flag that activates the overlay
+func (o *goOverlay) flag() string {
+	return "-overlay=" + o.path
+}
+
+// Close removes the overlay files
+func (o *goOverlay) Close() error {
+	return os.RemoveAll(o.dir)
+}
+
+// pendingContent returns content keyed by absolute path when it differs from the
+// file on disk, as it does for PreToolUse Write checks. It returns nil when the
+// content has already been written.
+func (l *GoLinter) pendingContent(filePath string, content []byte) map[string][]byte {
+	if onDisk, err := l.fs.ReadFile(filePath); err == nil && bytes.Equal(onDisk, content) {
+		return nil
+	}
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return nil
+	}
+	return map[string][]byte{absPath: content}
+}
@@ -0,0 +1,94 @@
+package golang
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	json "github.com/goccy/go-json"
+)
+
+func TestNewGoOverlay(t *testing.T) {
+	target := filepath.Join(t.TempDir(), "main.go")
+	overlay, err := newGoOverlay(map[string][]byte{target: []byte("package main\n")})
+	if err != nil {
+		t.Fatalf("newGoOverlay failed: %v", err)
+	}
+	defer overlay.Close()
+
+	if !strings.HasPrefix(overlay.flag(), "-overlay=") {
+		t.Errorf("flag() = %q, want -overlay= prefix", overlay.flag())
+	}
+
+	data, err := os.ReadFile(overlay.path)
+	if err != nil {
+		t.Fatalf("failed to read overlay: %v", err)
+	}
+	var parsed goOverlayFile
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		t.Fatalf("invalid overlay JSON: %v", err)
+	}
+
+	replacement, ok := parsed.Replace[target]
+	if !ok {
+		t.Fatalf("overlay missing entry for %s: %v", target, parsed.Replace)
+	}
+	if content, err := os.ReadFile(replacement); err != nil || string(content) != "package main\n" {
+		t.Errorf("replacement content = %q, %v", content, err)
+	}
+
+	if err := overlay.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if _, err := os.Stat(overlay.dir); !os.IsNotExist(err) {
+		t.Errorf("expected overlay directory to be removed")
+	}
+}
+
+func TestGoLinter_LintPendingContentRunsTestsAgainstOverlay(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go command not available")
+	}
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":      "module example.com/calc\n\ngo 1.21\n",
+		"add.go":      "package calc\n\nfunc Add(a, b int) int {\n\treturn a + b\n}\n",
+		"add_test.go": "package calc\n\nimport \"testing\"\n\nfunc TestAdd(t *testing.T) {\n\tif Add(1, 2) != 3 {\n\t\tt.Fatal(\"Add(1, 2) != 3\")\n\t}\n}\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	linter := NewGoLinter()
+	addPath := filepath.Join(dir, "add.go")
+
+	// On-disk content passes
+	result, err := linter.Lint(context.Background(), addPath, []byte(files["add.go"]))
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+	if !result.Success {
+		t.Fatalf("expected on-disk content to pass, got issues: %+v", result.Issues)
+	}
+
+	// Pending content breaks the test even though the file on disk is unchanged
+	broken := "package calc\n\nfunc Add(a, b int) int {\n\treturn a - b\n}\n"
+	result, err = linter.Lint(context.Background(), addPath, []byte(broken))
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+	if result.Success {
+		t.Fatalf("expected pending content to fail tests, got output: %s", result.TestOutput)
+	}
+
+	onDisk, _ := os.ReadFile(addPath)
+	if string(onDisk) != files["add.go"] {
+		t.Errorf("Lint() modified the file on disk")
+	}
+}
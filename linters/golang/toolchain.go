@@ -0,0 +1,69 @@
+package golang
+
+import (
+	"bytes"
+	"context"
+	"strings"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+// checkToolchain warns when the go command the hooks run resolves to a
+// different toolchain than filePath's go.mod declares. The go version is looked
+// up once per module.
+func (l *GoLinter) checkToolchain(ctx context.Context, filePath string) []linters.Issue {
+	if l.isCheckDisabled(linters.RuleToolchainMismatch) {
+		return nil
+	}
+	moduleInfo, err := l.FindModuleRoot(filePath)
+	if err != nil {
+		return nil
+	}
+	goMod, err := l.fs.ReadFile(moduleInfo.GoModPath)
+	if err != nil {
+		return nil
+	}
+	running, ok := l.goVersion(ctx, moduleInfo.Root)
+	if !ok {
+		return nil
+	}
+	if mismatch := linters.CheckGoToolchain(goMod, running); mismatch != nil {
+		return []linters.Issue{mismatch.Issue(filePath)}
+	}
+	return nil
+}
+
+// goVersion returns the version of the toolchain the go command selects in
+// dir, which follows GOTOOLCHAIN and may differ from the go found on PATH
+func (l *GoLinter) goVersion(ctx context.Context, dir string) (linters.ToolVersion, bool) {
+	l.mu.RLock()
+	version, cached := l.goVersions[dir]
+	l.mu.RUnlock()
+	if cached {
+		return version, version != linters.ToolVersion{}
+	}
+
+	cmd := l.runner.Command(ctx, l.Name(), "go", "env", "GOVERSION")
+	cmd.Dir = dir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	output := ""
+	if err := linters.Run(cmd); err == nil {
+		output = stdout.String()
+	} else if _, running, found := strings.Cut(stderr.String(), "running go"); found {
+		// A toolchain too old for go.mod fails with "go.mod requires go >= 1.23
+		// (running go 1.22.5; GOTOOLCHAIN=local)"
+		output = running
+	}
+	// Without a go command the check is skipped, and not retried for the module
+	version, ok := linters.ParseToolVersion(strings.TrimPrefix(strings.TrimSpace(output), "go"))
+
+	l.mu.Lock()
+	if l.goVersions == nil {
+		l.goVersions = make(map[string]linters.ToolVersion)
+	}
+	l.goVersions[dir] = version
+	l.mu.Unlock()
+	return version, ok
+}
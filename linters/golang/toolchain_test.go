@@ -0,0 +1,66 @@
+package golang
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+func TestGoLinter_CheckToolchain(t *testing.T) {
+	binDir := t.TempDir()
+	calls := filepath.Join(binDir, "calls")
+	// The fake go refuses a module newer than itself, like GOTOOLCHAIN=local
+	writeFakeTool(t, binDir, "go", `echo "$*" >> `+calls+`
+if grep -q "^go 1.23" go.mod; then
+	echo "go: go.mod requires go >= 1.23.2 (running go 1.22.5; GOTOOLCHAIN=local)" >&2
+	exit 1
+fi
+echo go1.22.5`)
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	writeModule := func(goMod string) string {
+		t.Helper()
+		module := t.TempDir()
+		if err := os.WriteFile(filepath.Join(module, "go.mod"), []byte(goMod), 0600); err != nil {
+			t.Fatal(err)
+		}
+		return filepath.Join(module, "main.go")
+	}
+
+	l := NewGoLinter()
+	ctx := context.Background()
+
+	if issues := l.checkToolchain(ctx, writeModule("module example.com/a\n\ngo 1.21\n")); len(issues) != 0 {
+		t.Errorf("issues = %+v, want none for an older go directive", issues)
+	}
+
+	tooNew := writeModule("module example.com/b\n\ngo 1.23.2\n")
+	issues := l.checkToolchain(ctx, tooNew)
+	if len(issues) != 1 || issues[0].Rule != linters.RuleToolchainMismatch ||
+		!strings.Contains(issues[0].Message, "go 1.22.5 runs the hooks but go.mod expects go >= 1.23.2") {
+		t.Fatalf("issues = %+v, want a mismatch with the go directive", issues)
+	}
+	// The version is looked up once per module
+	l.checkToolchain(ctx, tooNew)
+	data, err := os.ReadFile(calls)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(string(data), "env GOVERSION"); n != 2 {
+		t.Errorf("go env ran %d times, want once per module", n)
+	}
+
+	pinned := writeModule("module example.com/c\n\ngo 1.21\n\ntoolchain go1.24.1\n")
+	if issues := l.checkToolchain(ctx, pinned); len(issues) != 1 || !strings.Contains(issues[0].Message, "toolchain directive expects go 1.24.1") {
+		t.Errorf("issues = %+v, want a mismatch with the toolchain directive", issues)
+	}
+
+	l.config.DisabledChecks = []string{linters.RuleToolchainMismatch}
+	if issues := l.checkToolchain(ctx, pinned); len(issues) != 0 {
+		t.Errorf("issues = %+v, want none when the check is disabled", issues)
+	}
+}
@@ -0,0 +1,248 @@
+package golang
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/jrossi/gismo/internal/statedir"
+	"github.com/jrossi/gismo/linters"
+)
+
+// vulncheckCacheTTL bounds how long govulncheck results are reused for an
+// unchanged go.sum, so newly published advisories are picked up daily
+const vulncheckCacheTTL = 24 * time.Hour
+
+// vulnFinding is a vulnerability govulncheck found the package calls
+type vulnFinding struct {
+	ID           string `json:"id"`
+	Summary      string `json:"summary"`
+	Symbol       string `json:"symbol"`
+	FixedVersion string `json:"fixedVersion,omitempty"`
+	// File and Line locate the call in the module that reaches the symbol
+	File string `json:"file,omitempty"`
+	Line int    `json:"line,omitempty"`
+}
+
+// govulncheckMessage is one message of govulncheck's streamed JSON output
+type govulncheckMessage struct {
+	OSV *struct {
+		ID      string `json:"id"`
+		Summary string `json:"summary"`
+	} `json:"osv"`
+	Finding *struct {
+		OSV          string `json:"osv"`
+		FixedVersion string `json:"fixed_version"`
+		Trace        []struct {
+			Module   string `json:"module"`
+			Package  string `json:"package"`
+			Function string `json:"function"`
+			Receiver string `json:"receiver"`
+			Position *struct {
+				Filename string `json:"filename"`
+				Line     int    `json:"line"`
+			} `json:"position"`
+		} `json:"trace"`
+	} `json:"finding"`
+}
+
+// vulncheckEnabled reports whether govulncheck runs on written files
+func (l *GoLinter) vulncheckEnabled() bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.config != nil && l.config.Vulncheck != nil && *l.config.Vulncheck && !l.isCheckDisabled("vulncheck")
+}
+
+// checkVulnerabilities reports known vulnerabilities that filePath's package
+// calls, as warnings with the OSV ID as the rule. Results are cached per package
+// and go.sum, so edits that don't change dependencies don't rerun govulncheck.
+func (l *GoLinter) checkVulnerabilities(ctx context.Context, filePath string) []linters.Issue {
+	if !l.vulncheckEnabled() {
+		return nil
+	}
+	moduleInfo, err := l.FindModuleRoot(filePath)
+	if err != nil {
+		return nil
+	}
+	relPath, err := filepath.Rel(moduleInfo.Root, filepath.Dir(filePath))
+	if err != nil {
+		return nil
+	}
+	pkg := "./" + filepath.ToSlash(relPath)
+
+	goSum, _ := l.fs.ReadFile(filepath.Join(moduleInfo.Root, "go.sum"))
+	cachePath := vulncheckCachePath(moduleInfo.Root, pkg, l.buildTags(), goSum)
+	findings, ok := readVulncheckCache(cachePath)
+	if !ok {
+		tool := l.findCachedTool(filePath, "govulncheck")
+		if tool == "" {
+			return nil
+		}
+		findings, err = l.runGovulncheck(ctx, tool, moduleInfo.Root, pkg)
+		if err != nil {
+			return nil
+		}
+		writeVulncheckCache(cachePath, findings)
+	}
+
+	absPath, _ := filepath.Abs(filePath)
+	issues := make([]linters.Issue, 0, len(findings))
+	for _, finding := range findings {
+		issue := linters.Issue{
+			File:     filePath,
+			Line:     1,
+			Column:   1,
+			Severity: "warning",
+			Message:  finding.message(),
+			Rule:     finding.ID,
+		}
+		if finding.File == absPath && finding.Line > 0 {
+			issue.Line = finding.Line
+		}
+		issues = append(issues, issue)
+	}
+	return issues
+}
+
+// message describes the finding for the issue list
+func (f vulnFinding) message() string {
+	message := fmt.Sprintf("%s: %s (calls %s)", f.ID, f.Summary, f.Symbol)
+	if f.FixedVersion != "" {
+		message += fmt.Sprintf("; fixed in %s", f.FixedVersion)
+	}
+	return message
+}
+
+// runGovulncheck runs govulncheck on pkg from the module root
+func (l *GoLinter) runGovulncheck(ctx context.Context, tool, moduleRoot, pkg string) ([]vulnFinding, error) {
+	args := []string{"-format", "json"}
+	if tags := l.buildTags(); tags != "" {
+		args = append(args, "-tags="+tags)
+	}
+	args = append(args, pkg)
+
+	release, err := l.runner.Acquire(ctx, tool)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	cmd := l.runner.Command(ctx, l.Name(), tool, args...)
+	cmd.Dir = moduleRoot
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := linters.Run(cmd); err != nil {
+		return nil, fmt.Errorf("govulncheck failed: %w: %s", err, stderr.String())
+	}
+	return parseGovulncheckOutput(&stdout, moduleRoot)
+}
+
+// parseGovulncheckOutput returns the symbol-level findings in govulncheck's JSON
+// output, one per OSV entry. Findings that only import a vulnerable package or
+// require a vulnerable module aren't reported, as the code can't reach the bug.
+func parseGovulncheckOutput(r io.Reader, moduleRoot string) ([]vulnFinding, error) {
+	summaries := make(map[string]string)
+	var findings []vulnFinding
+	seen := make(map[string]bool)
+
+	decoder := json.NewDecoder(r)
+	for {
+		var message govulncheckMessage
+		if err := decoder.Decode(&message); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("failed to parse govulncheck output: %w", err)
+		}
+		if message.OSV != nil {
+			summaries[message.OSV.ID] = message.OSV.Summary
+		}
+		finding := message.Finding
+		if finding == nil || len(finding.Trace) == 0 || finding.Trace[0].Function == "" || seen[finding.OSV] {
+			continue
+		}
+		seen[finding.OSV] = true
+
+		vulnerable := finding.Trace[0]
+		symbol := vulnerable.Function
+		if vulnerable.Receiver != "" {
+			symbol = vulnerable.Receiver + "." + symbol
+		}
+		result := vulnFinding{
+			ID:           finding.OSV,
+			Symbol:       vulnerable.Package + "." + symbol,
+			FixedVersion: finding.FixedVersion,
+		}
+		// The last frame is the call in the module being checked
+		if caller := finding.Trace[len(finding.Trace)-1]; caller.Position != nil {
+			result.File = caller.Position.Filename
+			if !filepath.IsAbs(result.File) {
+				result.File = filepath.Join(moduleRoot, result.File)
+			}
+			result.Line = caller.Position.Line
+		}
+		findings = append(findings, result)
+	}
+
+	for i := range findings {
+		findings[i].Summary = summaries[findings[i].ID]
+	}
+	return findings, nil
+}
+
+// vulncheckCachePath returns the cache file for pkg in the module at root, keyed
+// by the build tags and the go.sum content
+func vulncheckCachePath(root, pkg, tags string, goSum []byte) string {
+	hash := sha256.New()
+	for _, part := range []string{root, pkg, tags} {
+		hash.Write([]byte(part))
+		hash.Write([]byte{0})
+	}
+	hash.Write(goSum)
+	return statedir.CacheDir("govulncheck", hex.EncodeToString(hash.Sum(nil))+".json")
+}
+
+// readVulncheckCache returns cached findings younger than vulncheckCacheTTL
+func readVulncheckCache(path string) ([]vulnFinding, bool) {
+	// Findings from a directory other users can write to can't be trusted
+	if statedir.Check(filepath.Dir(path)) != nil {
+		return nil, false
+	}
+	info, err := os.Stat(path)
+	if err != nil || time.Since(info.ModTime()) >= vulncheckCacheTTL {
+		return nil, false
+	}
+	data, err := os.ReadFile(path) // #nosec G304 - path is derived from a hash under the cache dir
+	if err != nil {
+		return nil, false
+	}
+	var findings []vulnFinding
+	if err := json.Unmarshal(data, &findings); err != nil {
+		return nil, false
+	}
+	return findings, true
+}
+
+// writeVulncheckCache stores findings, ignoring failures since the cache is
+// only an optimization
+func writeVulncheckCache(path string, findings []vulnFinding) {
+	if findings == nil {
+		findings = []vulnFinding{}
+	}
+	data, err := json.Marshal(findings)
+	if err != nil {
+		return
+	}
+	if err := statedir.Ensure(filepath.Dir(path)); err == nil {
+		_ = os.WriteFile(path, data, 0600)
+	}
+}
@@ -0,0 +1,80 @@
+package golang
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jrossi/gismo/toolcache"
+)
+
+// govulncheckOutput is trimmed govulncheck JSON output with a called
+// vulnerability, reported twice, and one that is only imported
+const govulncheckOutput = `{"config":{"protocol_version":"v1.0.0","scanner_name":"govulncheck"}}
+{"osv":{"id":"GO-2024-0001","summary":"Panic on malformed input in golang.org/x/text"}}
+{"osv":{"id":"GO-2024-0002","summary":"Unused vulnerability"}}
+{"finding":{"osv":"GO-2024-0002","fixed_version":"v0.3.0","trace":[{"module":"golang.org/x/net","version":"v0.1.0","package":"golang.org/x/net/html"}]}}
+{"finding":{"osv":"GO-2024-0001","fixed_version":"v0.14.0","trace":[{"module":"golang.org/x/text","version":"v0.3.0","package":"golang.org/x/text/language","function":"Parse","position":{"filename":"language/parse.go","line":10}},{"module":"example.com/app","package":"example.com/app","function":"main","position":{"filename":"main.go","line":7}}]}}
+{"finding":{"osv":"GO-2024-0001","fixed_version":"v0.14.0","trace":[{"module":"golang.org/x/text","version":"v0.3.0","package":"golang.org/x/text/language","function":"MustParse"},{"module":"example.com/app","package":"example.com/app","function":"init","position":{"filename":"main.go","line":3}}]}}
+`
+
+func TestParseGovulncheckOutput(t *testing.T) {
+	findings, err := parseGovulncheckOutput(strings.NewReader(govulncheckOutput), "/src/app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(findings) != 1 {
+		t.Fatalf("findings = %+v, want only the called vulnerability", findings)
+	}
+	got := findings[0]
+	if got.ID != "GO-2024-0001" || got.Symbol != "golang.org/x/text/language.Parse" || got.FixedVersion != "v0.14.0" {
+		t.Errorf("finding = %+v", got)
+	}
+	if got.File != filepath.Join("/src/app", "main.go") || got.Line != 7 {
+		t.Errorf("position = %s:%d, want the call in main.go", got.File, got.Line)
+	}
+	if !strings.Contains(got.message(), "Panic on malformed input") {
+		t.Errorf("message = %q", got.message())
+	}
+}
+
+func TestGoLinter_Vulncheck(t *testing.T) {
+	t.Setenv("TMPDIR", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	module := t.TempDir()
+	mainFile := filepath.Join(module, "main.go")
+	for name, content := range map[string]string{
+		"go.mod":  "module example.com/app\n\ngo 1.21\n",
+		"go.sum":  "golang.org/x/text v0.3.0 h1:abc=\n",
+		"main.go": "package main\n\nfunc main() {}\n",
+	} {
+		if err := os.WriteFile(filepath.Join(module, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	binDir := t.TempDir()
+	calls := filepath.Join(binDir, "calls")
+	writeFakeTool(t, binDir, "govulncheck", "echo run >> "+calls+"\ncat <<'JSON'\n"+govulncheckOutput+"JSON")
+	cache := toolcache.NewMemoryCache()
+	cache.AddTool("go", "govulncheck", filepath.Join(binDir, "govulncheck"))
+
+	enabled := true
+	linter := NewGoLinterWithToolCache(&GolangConfig{Vulncheck: &enabled}, cache)
+	for range 2 {
+		issues := linter.checkVulnerabilities(context.Background(), mainFile)
+		if len(issues) != 1 || issues[0].Rule != "GO-2024-0001" || issues[0].Severity != "warning" || issues[0].Line != 7 {
+			t.Fatalf("issues = %+v", issues)
+		}
+	}
+	if data, _ := os.ReadFile(calls); strings.Count(string(data), "run") != 1 {
+		t.Errorf("govulncheck ran %d times, want 1 with the result cached", strings.Count(string(data), "run"))
+	}
+
+	if issues := NewGoLinterWithToolCache(&GolangConfig{}, cache).checkVulnerabilities(context.Background(), mainFile); len(issues) != 0 {
+		t.Errorf("vulncheck disabled by default, got %+v", issues)
+	}
+}
@@ -0,0 +1,57 @@
+//go:build !noembed
+
+package javascript
+
+import (
+	"strings"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+// embeddedChecks lists the checks compiled into gismo that run without any tools
+var embeddedChecks = []string{"brace balance"}
+
+// basicSyntaxCheck performs a very basic syntax validation
+func (l *JavaScriptLinter) basicSyntaxCheck(filePath string, content []byte) (*linters.LintResult, error) {
+	result := &linters.LintResult{
+		Success: true,
+		Issues:  []linters.Issue{},
+	}
+
+	// Basic checks for common syntax errors
+	lines := strings.Split(string(content), "\n")
+	for i, line := range lines {
+		lineNum := i + 1
+
+		// Check for common issues
+		trimmed := strings.TrimSpace(line)
+
+		// Check for unmatched braces (very basic)
+		openBraces := strings.Count(line, "{")
+		closeBraces := strings.Count(line, "}")
+		if openBraces != closeBraces && (openBraces > 0 || closeBraces > 0) {
+			result.Issues = append(result.Issues, linters.Issue{
+				File:     filePath,
+				Line:     lineNum,
+				Column:   1,
+				Severity: "warning",
+				Message:  "Possible unmatched braces",
+				Rule:     "basic-syntax",
+			})
+		}
+
+		// Check for common typos
+		if strings.Contains(trimmed, "function(") && !strings.Contains(trimmed, "function (") {
+			result.Issues = append(result.Issues, linters.Issue{
+				File:     filePath,
+				Line:     lineNum,
+				Column:   linters.Column(line, strings.Index(line, "function(")),
+				Severity: "info",
+				Message:  "Consider adding space after 'function'",
+				Rule:     "basic-style",
+			})
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,13 @@
+//go:build noembed
+
+package javascript
+
+import "github.com/jrossi/gismo/linters"
+
+// embeddedChecks is empty: noembed builds leave out the pure-Go fallbacks
+var embeddedChecks = []string{}
+
+// basicSyntaxCheck reports nothing in noembed builds, which rely on external tools
+func (l *JavaScriptLinter) basicSyntaxCheck(filePath string, content []byte) (*linters.LintResult, error) {
+	return &linters.LintResult{Success: true, Issues: []linters.Issue{}}, nil
+}
@@ -0,0 +1,67 @@
+//go:build !noembed
+
+package javascript
+
+import "testing"
+
+func TestJavaScriptLinter_BasicSyntaxCheck(t *testing.T) {
+	linter := NewJavaScriptLinter()
+
+	tests := []struct {
+		name        string
+		content     string
+		expectIssue bool
+		issueType   string
+	}{
+		{
+			name:        "Valid JavaScript",
+			content:     "console.log('Hello, World!');",
+			expectIssue: false,
+		},
+		{
+			name:        "Unmatched braces",
+			content:     "function test() { console.log('test');",
+			expectIssue: true,
+			issueType:   "basic-syntax",
+		},
+		{
+			name:        "Function spacing style",
+			content:     "function(x) { return x; }",
+			expectIssue: true,
+			issueType:   "basic-style",
+		},
+		{
+			name:        "Valid function spacing",
+			content:     "function (x) { return x; }",
+			expectIssue: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Test the basic syntax check method directly
+			result, err := linter.basicSyntaxCheck("test.js", []byte(tt.content))
+			if err != nil {
+				t.Fatalf("basicSyntaxCheck() error = %v", err)
+			}
+
+			hasIssue := len(result.Issues) > 0
+			if hasIssue != tt.expectIssue {
+				t.Errorf("basicSyntaxCheck() issues = %v, expectIssue %v", hasIssue, tt.expectIssue)
+			}
+
+			if tt.expectIssue && len(result.Issues) > 0 {
+				found := false
+				for _, issue := range result.Issues {
+					if issue.Rule == tt.issueType {
+						found = true
+						break
+					}
+				}
+				if !found {
+					t.Errorf("Expected issue type %s not found in issues", tt.issueType)
+				}
+			}
+		})
+	}
+}
@@ -1,9 +1,9 @@
 package javascript
 
 import (
-	"encoding/json"
-	"fmt"
 	"time"
+
+	"github.com/jrossi/gismo/types"
 )
 
 // JavaScriptConfig holds configuration for the JavaScript/TypeScript linter
@@ -13,8 +13,8 @@ type JavaScriptConfig struct {
 	PreferredTools []string `json:"preferredTools,omitempty"` // Priority order for discovery
 
 	// Performance and Limits
-	MaxFileSize *int64    `json:"maxFileSize,omitempty"` // Default 10MB (larger than other linters)
-	TestTimeout *Duration `json:"testTimeout,omitempty"` // Tool execution timeout
+	MaxFileSize *int64          `json:"maxFileSize,omitempty"` // Default 10MB (larger than other linters)
+	TestTimeout *types.Duration `json:"testTimeout,omitempty"` // Tool execution timeout
 
 	// Configuration Paths (skip discovery if specified)
 	BiomeConfigPath  *string `json:"biomeConfigPath,omitempty"`  // Force specific biome.json
@@ -28,6 +28,23 @@ type JavaScriptConfig struct {
 	ESLintPath *string `json:"eslintPath,omitempty"` // Force specific eslint binary
 	NodePath   *string `json:"nodePath,omitempty"`   // Force specific node binary
 
+	// Formatting
+	Formatter    *string `json:"formatter,omitempty"`    // "prettier", "dprint" or "none"; default: the one the project configures
+	PrettierPath *string `json:"prettierPath,omitempty"` // Force specific prettier binary
+	DprintPath   *string `json:"dprintPath,omitempty"`   // Force specific dprint binary
+
+	// Test Execution
+	RunTests   *bool    `json:"runTests,omitempty"`   // Run Jest or Vitest on written test files, default true
+	TestRunner *string  `json:"testRunner,omitempty"` // "jest" or "vitest"; default: the one package.json uses
+	TestArgs   []string `json:"testArgs,omitempty"`   // Extra arguments for the test runner
+
+	// Dependency Audit
+	Audit      *string `json:"audit,omitempty"`      // "npm", "osv-scanner" or "none" (default) - audit written package.json files
+	AuditLevel *string `json:"auditLevel,omitempty"` // Least severe vulnerability reported: "low", "moderate", "high" (default) or "critical"
+
+	// Type Checking
+	TypeCheck *bool `json:"typeCheck,omitempty"` // Run tsc --noEmit on .ts/.tsx files with a tsconfig.json
+
 	// Rule Configuration
 	DisabledChecks  []string `json:"disabledChecks,omitempty"`  // Tool-agnostic rule names
 	IncludePatterns []string `json:"includePatterns,omitempty"` // File patterns to include
@@ -38,15 +55,174 @@ type JavaScriptConfig struct {
 	PackageJsonPath *string `json:"packageJsonPath,omitempty"` // Force specific package.json
 }
 
-// Duration wraps time.Duration for JSON marshaling
-type Duration struct {
-	time.Duration
-}
+// configSchema is the JSON Schema for JavaScriptConfig
+const configSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "properties": {
+    "forceTool": {
+      "type": "string",
+      "enum": [
+        "biome",
+        "oxlint",
+        "eslint",
+        "node"
+      ],
+      "description": "Use this tool and skip discovery"
+    },
+    "preferredTools": {
+      "type": "array",
+      "items": {
+        "type": "string"
+      },
+      "description": "Tools to try in order of preference"
+    },
+    "maxFileSize": {
+      "type": "integer",
+      "minimum": 0,
+      "description": "Maximum file size in bytes to lint"
+    },
+    "testTimeout": {
+      "type": [
+        "string",
+        "number"
+      ],
+      "description": "Tool execution timeout, e.g. \"30s\""
+    },
+    "biomeConfigPath": {
+      "type": "string",
+      "description": "Path to biome.json"
+    },
+    "eslintConfigPath": {
+      "type": "string",
+      "description": "Path to the ESLint configuration"
+    },
+    "oxlintConfigPath": {
+      "type": "string",
+      "description": "Path to .oxlintrc.json"
+    },
+    "tsconfigPath": {
+      "type": "string",
+      "description": "Path to tsconfig.json"
+    },
+    "biomePath": {
+      "type": "string",
+      "description": "Path to the biome binary"
+    },
+    "oxlintPath": {
+      "type": "string",
+      "description": "Path to the oxlint binary"
+    },
+    "eslintPath": {
+      "type": "string",
+      "description": "Path to the eslint binary"
+    },
+    "nodePath": {
+      "type": "string",
+      "description": "Path to the node binary"
+    },
+    "formatter": {
+      "type": "string",
+      "enum": [
+        "prettier",
+        "dprint",
+        "none"
+      ],
+      "description": "Formatter to check files with; by default the one the project configures"
+    },
+    "prettierPath": {
+      "type": "string",
+      "description": "Path to the prettier binary"
+    },
+    "dprintPath": {
+      "type": "string",
+      "description": "Path to the dprint binary"
+    },
+    "runTests": {
+      "type": "boolean",
+      "description": "Run Jest or Vitest on written test files"
+    },
+    "testRunner": {
+      "type": "string",
+      "enum": [
+        "jest",
+        "vitest"
+      ],
+      "description": "Test runner; by default the one package.json uses"
+    },
+    "testArgs": {
+      "type": "array",
+      "items": {
+        "type": "string"
+      },
+      "description": "Extra arguments for the test runner"
+    },
+    "audit": {
+      "type": "string",
+      "enum": [
+        "npm",
+        "osv-scanner",
+        "none"
+      ],
+      "description": "Auditor to check the dependencies of written package.json files with"
+    },
+    "auditLevel": {
+      "type": "string",
+      "enum": [
+        "info",
+        "low",
+        "moderate",
+        "high",
+        "critical"
+      ],
+      "description": "Least severe vulnerability the audit reports, default high"
+    },
+    "typeCheck": {
+      "type": "boolean",
+      "description": "Type-check TypeScript files with tsc --noEmit, using the nearest tsconfig.json"
+    },
+    "disabledChecks": {
+      "type": "array",
+      "items": {
+        "type": "string"
+      },
+      "description": "Rule names to skip"
+    },
+    "includePatterns": {
+      "type": "array",
+      "items": {
+        "type": "string"
+      },
+      "description": "File patterns to include"
+    },
+    "excludePatterns": {
+      "type": "array",
+      "items": {
+        "type": "string"
+      },
+      "description": "File patterns to exclude"
+    },
+    "workspaceRoot": {
+      "type": "string",
+      "description": "Monorepo root directory"
+    },
+    "packageJsonPath": {
+      "type": "string",
+      "description": "Path to package.json"
+    }
+  },
+  "additionalProperties": false
+}`
+
+// Duration is kept for compatibility.
+//
+// Deprecated: use types.Duration.
+type Duration = types.Duration
 
 // DefaultJavaScriptConfig returns the default configuration for JavaScript/TypeScript linting
 func DefaultJavaScriptConfig() *JavaScriptConfig {
 	defaultMaxSize := int64(10 * 1024 * 1024) // 10MB (larger than other linters for JS projects)
-	defaultTimeout := Duration{30 * time.Second}
+	defaultTimeout := types.Duration{Duration: 30 * time.Second}
 	defaultPreferredTools := []string{"biome", "oxlint", "eslint"}
 
 	return &JavaScriptConfig{
@@ -56,31 +232,3 @@ func DefaultJavaScriptConfig() *JavaScriptConfig {
 		DisabledChecks: []string{},
 	}
 }
-
-// UnmarshalJSON implements json.Unmarshaler for Duration
-func (d *Duration) UnmarshalJSON(b []byte) error {
-	var v interface{}
-	if err := json.Unmarshal(b, &v); err != nil {
-		return err
-	}
-
-	switch value := v.(type) {
-	case float64:
-		d.Duration = time.Duration(value)
-		return nil
-	case string:
-		var err error
-		d.Duration, err = time.ParseDuration(value)
-		if err != nil {
-			return err
-		}
-		return nil
-	default:
-		return fmt.Errorf("invalid duration type: %T", v)
-	}
-}
-
-// MarshalJSON implements json.Marshaler for Duration
-func (d Duration) MarshalJSON() ([]byte, error) {
-	return json.Marshal(d.Duration.String())
-}
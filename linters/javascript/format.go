@@ -0,0 +1,176 @@
+package javascript
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+// Formatters selectable with the formatter setting
+const (
+	FormatterPrettier = "prettier"
+	FormatterDprint   = "dprint"
+	FormatterNone     = "none"
+)
+
+// prettierConfigFiles are the files that configure Prettier for their directory
+var prettierConfigFiles = []string{
+	".prettierrc", ".prettierrc.json", ".prettierrc.json5", ".prettierrc.yaml", ".prettierrc.yml",
+	".prettierrc.toml", ".prettierrc.js", ".prettierrc.cjs", ".prettierrc.mjs", ".prettierrc.ts",
+	"prettier.config.js", "prettier.config.cjs", "prettier.config.mjs", "prettier.config.ts",
+}
+
+// dprintConfigFiles are the files that configure dprint for their directory
+var dprintConfigFiles = []string{"dprint.json", ".dprint.json", "dprint.jsonc", ".dprint.jsonc"}
+
+// discoverFormatterConfigs records in info.ConfigFiles the Prettier and dprint
+// configuration in dir, if none closer to the file was found. A "prettier" key
+// in package.json configures Prettier too.
+func discoverFormatterConfigs(info *ProjectInfo, dir string) {
+	if info.ConfigFiles == nil {
+		info.ConfigFiles = make(map[string]string)
+	}
+	if _, found := info.ConfigFiles[FormatterPrettier]; !found {
+		for _, name := range prettierConfigFiles {
+			if path := filepath.Join(dir, name); fileExists(path) {
+				info.ConfigFiles[FormatterPrettier] = path
+				break
+			}
+		}
+	}
+	if _, found := info.ConfigFiles[FormatterPrettier]; !found {
+		path := filepath.Join(dir, "package.json")
+		if data, err := os.ReadFile(path); err == nil { // #nosec G304 - package.json of the linted project
+			var manifest map[string]json.RawMessage
+			if json.Unmarshal(data, &manifest) == nil && manifest["prettier"] != nil {
+				info.ConfigFiles[FormatterPrettier] = path
+			}
+		}
+	}
+	if _, found := info.ConfigFiles[FormatterDprint]; !found {
+		for _, name := range dprintConfigFiles {
+			if path := filepath.Join(dir, name); fileExists(path) {
+				info.ConfigFiles[FormatterDprint] = path
+				break
+			}
+		}
+	}
+}
+
+// selectFormatter returns the formatter for the file's project: the one set
+// with formatter, or else the one the project configures, preferring Prettier
+// when it configures both. It returns "" when the file isn't format-checked.
+func (l *JavaScriptLinter) selectFormatter(project *ProjectInfo) string {
+	if l.config.Formatter != nil {
+		if *l.config.Formatter == FormatterNone {
+			return ""
+		}
+		return *l.config.Formatter
+	}
+	for _, formatter := range []string{FormatterPrettier, FormatterDprint} {
+		if project.ConfigFiles[formatter] != "" {
+			return formatter
+		}
+	}
+	return ""
+}
+
+// runFormatCheck formats the content with Prettier or dprint, as prettier
+// --check would compare it, and returns a format warning along with the
+// formatted content when they differ. Content the formatter can't parse isn't
+// reported, since the syntax error is reported by the linters.
+func (l *JavaScriptLinter) runFormatCheck(ctx context.Context, filePath string, content []byte) ([]linters.Issue, []byte, error) {
+	project := l.findProject(filePath)
+	formatter := l.selectFormatter(project)
+	if formatter == "" {
+		return nil, nil, nil
+	}
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var tool string
+	var args []string
+	switch formatter {
+	case FormatterPrettier:
+		if l.config.PrettierPath != nil {
+			tool = *l.config.PrettierPath
+		} else {
+			tool = findNodeTool("prettier", filepath.Dir(absPath), project.WorkspaceRoot)
+		}
+		// Prettier resolves its configuration and ignore files from the path
+		args = []string{"--stdin-filepath", absPath}
+	case FormatterDprint:
+		if l.config.DprintPath != nil {
+			tool = *l.config.DprintPath
+		} else {
+			tool = findNodeTool("dprint", filepath.Dir(absPath), project.WorkspaceRoot)
+		}
+		args = []string{"fmt", "--stdin", absPath}
+	default:
+		return nil, nil, fmt.Errorf("unknown formatter: %s", formatter)
+	}
+	if tool == "" {
+		return nil, nil, nil
+	}
+
+	timeout := 30 * time.Second
+	if l.config.TestTimeout != nil {
+		timeout = l.config.TestTimeout.Duration
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	release, err := l.runner.Acquire(ctx, tool)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer release()
+
+	cmd := l.runner.Command(ctx, l.Name(), tool, args...)
+	// dprint finds its configuration from the working directory
+	cmd.Dir = filepath.Dir(absPath)
+	cmd.Stdin = bytes.NewReader(content)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := linters.Run(cmd); err != nil {
+		if ctx.Err() != nil {
+			return nil, nil, fmt.Errorf("%s timed out after %s", formatter, timeout)
+		}
+		if isFormatterSyntaxError(stderr.String()) {
+			return nil, nil, nil
+		}
+		return nil, nil, fmt.Errorf("%s failed: %w: %s", formatter, err, strings.TrimSpace(stderr.String()))
+	}
+	// An ignored file is echoed unchanged, or by dprint not at all
+	formatted := stdout.Bytes()
+	if len(formatted) == 0 && len(content) > 0 || bytes.Equal(formatted, content) {
+		return nil, nil, nil
+	}
+	issue := linters.Issue{
+		File:     filePath,
+		Line:     1,
+		Column:   1,
+		Severity: "warning",
+		Message:  fmt.Sprintf("File is not formatted with %s", formatter),
+		Rule:     "format",
+		Tool:     formatter,
+	}
+	return []linters.Issue{issue}, formatted, nil
+}
+
+// isFormatterSyntaxError reports whether a formatter failed because it
+// couldn't parse the file
+func isFormatterSyntaxError(stderr string) bool {
+	return strings.Contains(stderr, "SyntaxError") || strings.Contains(stderr, "Syntax error")
+}
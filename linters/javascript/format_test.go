@@ -0,0 +1,118 @@
+package javascript
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestDiscoverFormatterConfigs(t *testing.T) {
+	root := t.TempDir()
+	app := filepath.Join(root, "packages", "app")
+	if err := os.MkdirAll(app, 0750); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range map[string]string{
+		".git/HEAD":                  "ref: refs/heads/main\n",
+		"dprint.json":                "{}",
+		"package.json":               `{"name": "root", "prettier": {"semi": false}}`,
+		"packages/app/package.json":  `{"name": "app"}`,
+		"packages/app/.prettierrc":   "{}",
+		"packages/other/placeholder": "",
+	} {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	info := discoverProject(app)
+	if got := info.ConfigFiles[FormatterPrettier]; got != filepath.Join(app, ".prettierrc") {
+		t.Errorf("prettier config = %q, want the closest one", got)
+	}
+	if got := info.ConfigFiles[FormatterDprint]; got != filepath.Join(root, "dprint.json") {
+		t.Errorf("dprint config = %q", got)
+	}
+	info = discoverProject(filepath.Join(root, "packages", "other"))
+	if got := info.ConfigFiles[FormatterPrettier]; got != filepath.Join(root, "package.json") {
+		t.Errorf("prettier config = %q, want the package.json prettier key", got)
+	}
+
+	linter := NewJavaScriptLinter()
+	if got := linter.selectFormatter(info); got != FormatterPrettier {
+		t.Errorf("selectFormatter() = %q, want prettier over dprint", got)
+	}
+	none := FormatterNone
+	linter.config.Formatter = &none
+	if got := linter.selectFormatter(info); got != "" {
+		t.Errorf("selectFormatter() with formatter none = %q", got)
+	}
+	if got := NewJavaScriptLinter().selectFormatter(&ProjectInfo{}); got != "" {
+		t.Errorf("selectFormatter() without configuration = %q", got)
+	}
+}
+
+func TestJavaScriptLinter_FormatCheck(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake prettier is a shell script")
+	}
+	root := t.TempDir()
+	bin := filepath.Join(root, "node_modules", ".bin")
+	if err := os.MkdirAll(bin, 0750); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range map[string]string{
+		"package.json": `{"name": "app"}`,
+		".prettierrc":  "{}",
+	} {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The fake prettier adds missing semicolons and fails on unbalanced braces
+	calls := filepath.Join(t.TempDir(), "calls")
+	script := "#!/bin/sh\necho \"$*\" >> " + calls + "\n" +
+		"input=$(cat)\n" +
+		"case \"$input\" in *'{') echo '[error] app.js: SyntaxError: Unexpected token (1:9)' >&2; exit 2;; esac\n" +
+		"printf '%s\\n' \"$input\" | sed 's/[^;]$/&;/'\n"
+	if err := os.WriteFile(filepath.Join(bin, "prettier"), []byte(script), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	linter := NewJavaScriptLinterWithConfig(nil)
+	ctx := context.Background()
+	appFile := filepath.Join(root, "app.js")
+
+	issues, formatted, err := linter.runFormatCheck(ctx, appFile, []byte("const a = 1\n"))
+	if err != nil || len(issues) != 1 || issues[0].Rule != "format" || issues[0].Tool != "prettier" {
+		t.Fatalf("unformatted file: %+v, %v", issues, err)
+	}
+	if string(formatted) != "const a = 1;\n" {
+		t.Errorf("formatted = %q", formatted)
+	}
+	data, _ := os.ReadFile(calls)
+	if want := "--stdin-filepath " + appFile; strings.TrimSpace(string(data)) != want {
+		t.Errorf("prettier ran as %q, want %q", data, want)
+	}
+
+	if issues, formatted, err := linter.runFormatCheck(ctx, appFile, []byte("const a = 1;\n")); err != nil || len(issues) != 0 || formatted != nil {
+		t.Errorf("formatted file: %+v, %q, %v", issues, formatted, err)
+	}
+
+	// Syntax errors are left to the linters
+	if issues, _, err := linter.runFormatCheck(ctx, appFile, []byte("if (a) {")); err != nil || len(issues) != 0 {
+		t.Errorf("file with a syntax error: %+v, %v", issues, err)
+	}
+
+	// Without a formatter configured, nothing runs
+	other := filepath.Join(t.TempDir(), "app.js")
+	if issues, _, _ := linter.runFormatCheck(ctx, other, []byte("const a = 1\n")); len(issues) != 0 {
+		t.Errorf("project without a formatter: %+v", issues)
+	}
+}
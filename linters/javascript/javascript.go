@@ -7,6 +7,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"os/exec"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -18,7 +20,7 @@ import (
 // JavaScriptLinter handles linting of JavaScript and TypeScript files
 type JavaScriptLinter struct {
 	config       *JavaScriptConfig
-	cacheManager *toolcache.CacheManager
+	cacheManager toolcache.ToolCache
 
 	// Tool selection cache (protected by mutex)
 	mu           sync.RWMutex
@@ -27,6 +29,12 @@ type JavaScriptLinter struct {
 
 	// Project context cache
 	projectCache map[string]*ProjectInfo
+	// Version of the node on PATH, looked up once
+	nodeVersion     linters.ToolVersion
+	nodeVersionOK   bool
+	nodeVersionOnce sync.Once
+	// Runs external tools with the engine's limits, caches and environment
+	runner *linters.CommandRunner
 }
 
 // ProjectInfo contains cached project-specific information
@@ -144,14 +152,37 @@ func NewJavaScriptLinterWithConfig(config *JavaScriptConfig) *JavaScriptLinter {
 	return &JavaScriptLinter{
 		config:       config,
 		projectCache: make(map[string]*ProjectInfo),
+		runner:       linters.NewCommandRunner(linters.RunnerConfig{}),
 	}
 }
 
+// NewJavaScriptLinterWithToolCache creates a JavaScript/TypeScript linter that uses
+// the given tool cache for tool discovery. A nil cache falls back to the disk-backed
+// cache rooted at the linted file's project.
+func NewJavaScriptLinterWithToolCache(config *JavaScriptConfig, cache toolcache.ToolCache) *JavaScriptLinter {
+	l := NewJavaScriptLinterWithConfig(config)
+	l.cacheManager = cache
+	return l
+}
+
 // Name returns the linter name
 func (l *JavaScriptLinter) Name() string {
 	return "javascript"
 }
 
+// SetCommandRunner sets the runner used to start external tools
+func (l *JavaScriptLinter) SetCommandRunner(runner *linters.CommandRunner) {
+	l.runner = runner
+}
+
+// Capabilities reports the built-in checks and the external tools used when installed
+func (l *JavaScriptLinter) Capabilities() linters.Capabilities {
+	return linters.Capabilities{
+		Embedded: embeddedChecks,
+		Tools:    []string{"biome", "oxlint", "eslint", "node", "tsc", "prettier", "dprint", "jest", "vitest", "npm", "osv-scanner"},
+	}
+}
+
 // CanHandle returns true if this linter can handle the given file
 func (l *JavaScriptLinter) CanHandle(filePath string) bool {
 	lowerPath := strings.ToLower(filePath)
@@ -162,22 +193,89 @@ func (l *JavaScriptLinter) CanHandle(filePath string) bool {
 		strings.HasSuffix(lowerPath, ".mjs") ||
 		strings.HasSuffix(lowerPath, ".cjs") ||
 		strings.HasSuffix(lowerPath, ".vue") ||
-		strings.HasSuffix(lowerPath, ".svelte")
+		strings.HasSuffix(lowerPath, ".svelte") ||
+		isPackageJSON(filePath)
 }
 
 // SetConfig updates the linter configuration
 func (l *JavaScriptLinter) SetConfig(config []byte) error {
-	var jsConfig JavaScriptConfig
-	if err := json.Unmarshal(config, &jsConfig); err != nil {
+	// Settings not given keep their defaults
+	jsConfig := DefaultJavaScriptConfig()
+	if err := json.Unmarshal(config, jsConfig); err != nil {
 		return fmt.Errorf("failed to parse JavaScript config: %w", err)
 	}
 
-	l.config = &jsConfig
+	l.config = jsConfig
 	return nil
 }
 
-// Lint performs linting on a single JavaScript/TypeScript file
+// ConfigSchema returns the JSON Schema for the linter configuration
+func (l *JavaScriptLinter) ConfigSchema() json.RawMessage {
+	return json.RawMessage(configSchema)
+}
+
+// Lint performs linting on a single JavaScript/TypeScript file, or checks a
+// package.json
 func (l *JavaScriptLinter) Lint(ctx context.Context, filePath string, content []byte) (*linters.LintResult, error) {
+	if isPackageJSON(filePath) {
+		return l.lintPackageJSON(ctx, filePath, content)
+	}
+	result, err := l.lint(ctx, filePath, content)
+	if err != nil || result == nil {
+		return result, err
+	}
+	if typeIssues, err := l.runTypeCheck(ctx, filePath, content); err != nil {
+		result.Issues = append(result.Issues, linters.Issue{
+			File:     filePath,
+			Line:     1,
+			Column:   1,
+			Severity: "warning",
+			Message:  fmt.Sprintf("Type check failed: %v", err),
+			Rule:     "typecheck",
+		})
+	} else {
+		for _, issue := range typeIssues {
+			if issue.Severity == "error" {
+				result.Success = false
+			}
+		}
+		result.Issues = append(result.Issues, typeIssues...)
+	}
+	if formatIssues, formatted, err := l.runFormatCheck(ctx, filePath, content); err != nil {
+		result.Issues = append(result.Issues, linters.Issue{
+			File:     filePath,
+			Line:     1,
+			Column:   1,
+			Severity: "warning",
+			Message:  fmt.Sprintf("Format check failed: %v", err),
+			Rule:     "format",
+		})
+	} else {
+		result.Issues = append(result.Issues, formatIssues...)
+		if formatted != nil {
+			result.Formatted = formatted
+		}
+	}
+	result.Issues = append(result.Issues, l.checkToolchain(ctx, filePath)...)
+
+	testOutput, testErr := l.runTests(ctx, filePath, content)
+	result.TestOutput = testOutput
+	if testErr != nil {
+		result.Success = false
+		result.Issues = append(result.Issues, linters.Issue{
+			File:     filePath,
+			Line:     1,
+			Column:   1,
+			Severity: "error",
+			Message:  fmt.Sprintf("Tests failed: %v", testErr),
+			Rule:     "test",
+		})
+	}
+	return result, nil
+}
+
+// lint runs the selected tool on the file
+func (l *JavaScriptLinter) lint(ctx context.Context, filePath string, content []byte) (*linters.LintResult, error) {
 	result := &linters.LintResult{
 		Success: true,
 		Issues:  []linters.Issue{},
@@ -185,7 +283,7 @@ func (l *JavaScriptLinter) Lint(ctx context.Context, filePath string, content []
 
 	// Initialize cache manager if not already done
 	if l.cacheManager == nil {
-		cache, err := toolcache.GetCacheManager(filePath)
+		cache, err := toolcache.NewCacheManager(filePath)
 		if err != nil {
 			// Fallback to non-cached operation
 			return l.lintWithoutCache(ctx, filePath, content)
@@ -236,7 +334,7 @@ func (l *JavaScriptLinter) LintBatch(ctx context.Context, files map[string][]byt
 	// Initialize cache manager
 	if l.cacheManager == nil {
 		for filePath := range jsFiles {
-			cache, err := toolcache.GetCacheManager(filePath)
+			cache, err := toolcache.NewCacheManager(filePath)
 			if err == nil {
 				l.cacheManager = cache
 				break
@@ -423,18 +521,24 @@ func (l *JavaScriptLinter) lintWithBiome(ctx context.Context, filePath string, c
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
+	release, err := l.runner.Acquire(ctx, l.getToolPath())
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	// Run biome check
-	// #nosec G204 - toolPath is validated through cache discovery
-	cmd := exec.CommandContext(ctx, l.getToolPath(), "check", "--reporter=json", filePath)
+	cmd := l.runner.Command(ctx, l.Name(), l.getToolPath(), "check", "--reporter=json", filePath)
 
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 
-	err := cmd.Run()
+	err = linters.Run(cmd)
 
 	// Biome returns non-zero exit code when issues are found
-	if err != nil && ctx.Err() == context.DeadlineExceeded {
+	timedOut := err != nil && ctx.Err() == context.DeadlineExceeded
+	if timedOut {
 		result.Success = false
 		result.Issues = append(result.Issues, linters.Issue{
 			File:     filePath,
@@ -444,13 +548,17 @@ func (l *JavaScriptLinter) lintWithBiome(ctx context.Context, filePath string, c
 			Message:  "Biome execution timed out",
 			Rule:     "timeout",
 		})
-		return result, nil
 	}
 
-	// Parse Biome JSON output
+	// Parse Biome JSON output, keeping the issues printed before a timeout
 	if stdout.Len() > 0 {
-		issues, parseErr := l.parseBiomeOutput(stdout.Bytes(), filePath)
-		if parseErr != nil {
+		issues, partial, parseErr := l.parseBiomeOutput(stdout.Bytes(), filePath)
+		if parseErr == nil {
+			result.Issues = append(result.Issues, issues...)
+			if partial {
+				result.MarkPartial("biome", filePath)
+			}
+		} else if !timedOut {
 			// If we can't parse output, treat as error but continue
 			result.Issues = append(result.Issues, linters.Issue{
 				File:     filePath,
@@ -460,8 +568,6 @@ func (l *JavaScriptLinter) lintWithBiome(ctx context.Context, filePath string, c
 				Message:  fmt.Sprintf("Failed to parse Biome output: %v", parseErr),
 				Rule:     "parse-error",
 			})
-		} else {
-			result.Issues = append(result.Issues, issues...)
 		}
 	}
 
@@ -491,18 +597,24 @@ func (l *JavaScriptLinter) lintWithOxlint(ctx context.Context, filePath string,
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
+	release, err := l.runner.Acquire(ctx, l.getToolPath())
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	// Run oxlint
-	// #nosec G204 - toolPath is validated through cache discovery
-	cmd := exec.CommandContext(ctx, l.getToolPath(), "--format=json", filePath)
+	cmd := l.runner.Command(ctx, l.Name(), l.getToolPath(), "--format=json", filePath)
 
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 
-	err := cmd.Run()
+	err = linters.Run(cmd)
 
 	// Oxlint returns non-zero exit code when issues are found
-	if err != nil && ctx.Err() == context.DeadlineExceeded {
+	timedOut := err != nil && ctx.Err() == context.DeadlineExceeded
+	if timedOut {
 		result.Success = false
 		result.Issues = append(result.Issues, linters.Issue{
 			File:     filePath,
@@ -512,13 +624,17 @@ func (l *JavaScriptLinter) lintWithOxlint(ctx context.Context, filePath string,
 			Message:  "Oxlint execution timed out",
 			Rule:     "timeout",
 		})
-		return result, nil
 	}
 
-	// Parse Oxlint JSON output
+	// Parse Oxlint JSON output, keeping the issues printed before a timeout
 	if stdout.Len() > 0 {
-		issues, parseErr := l.parseOxlintOutput(stdout.Bytes(), filePath)
-		if parseErr != nil {
+		issues, partial, parseErr := l.parseOxlintOutput(stdout.Bytes(), filePath)
+		if parseErr == nil {
+			result.Issues = append(result.Issues, issues...)
+			if partial {
+				result.MarkPartial("oxlint", filePath)
+			}
+		} else if !timedOut {
 			result.Issues = append(result.Issues, linters.Issue{
 				File:     filePath,
 				Line:     1,
@@ -527,8 +643,6 @@ func (l *JavaScriptLinter) lintWithOxlint(ctx context.Context, filePath string,
 				Message:  fmt.Sprintf("Failed to parse Oxlint output: %v", parseErr),
 				Rule:     "parse-error",
 			})
-		} else {
-			result.Issues = append(result.Issues, issues...)
 		}
 	}
 
@@ -558,18 +672,30 @@ func (l *JavaScriptLinter) lintWithESLint(ctx context.Context, filePath string,
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
+	release, err := l.runner.Acquire(ctx, l.getToolPath())
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	args := []string{"--format=json"}
+	if dir := l.runner.CacheLocation("eslint"); dir != "" {
+		args = append(args, "--cache", "--cache-location", dir+string(filepath.Separator))
+	}
+	args = append(args, filePath)
+
 	// Run ESLint
-	// #nosec G204 - toolPath is validated through cache discovery
-	cmd := exec.CommandContext(ctx, l.getToolPath(), "--format=json", filePath)
+	cmd := l.runner.Command(ctx, l.Name(), l.getToolPath(), args...)
 
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 
-	err := cmd.Run()
+	err = linters.Run(cmd)
 
 	// ESLint returns non-zero exit code when issues are found
-	if err != nil && ctx.Err() == context.DeadlineExceeded {
+	timedOut := err != nil && ctx.Err() == context.DeadlineExceeded
+	if timedOut {
 		result.Success = false
 		result.Issues = append(result.Issues, linters.Issue{
 			File:     filePath,
@@ -579,13 +705,21 @@ func (l *JavaScriptLinter) lintWithESLint(ctx context.Context, filePath string,
 			Message:  "ESLint execution timed out",
 			Rule:     "timeout",
 		})
-		return result, nil
 	}
 
-	// Parse ESLint JSON output
+	// Parse ESLint JSON output, keeping the issues printed before a timeout
 	if stdout.Len() > 0 {
-		issues, parseErr := l.parseESLintOutput(stdout.Bytes(), filePath)
-		if parseErr != nil {
+		issues, partial, parseErr := l.parseESLintOutput(stdout.Bytes(), filePath)
+		if parseErr == nil {
+			result.Issues = append(result.Issues, issues...)
+			if partial {
+				result.MarkPartial("eslint", filePath)
+			}
+		} else if !timedOut {
+			if unsupported := eslintUnsupported(l.getToolPath()); unsupported != nil {
+				result.Issues = append(result.Issues, unsupported.Issue(filePath))
+				return result, nil
+			}
 			result.Issues = append(result.Issues, linters.Issue{
 				File:     filePath,
 				Line:     1,
@@ -594,8 +728,6 @@ func (l *JavaScriptLinter) lintWithESLint(ctx context.Context, filePath string,
 				Message:  fmt.Sprintf("Failed to parse ESLint output: %v", parseErr),
 				Rule:     "parse-error",
 			})
-		} else {
-			result.Issues = append(result.Issues, issues...)
 		}
 	}
 
@@ -610,6 +742,16 @@ func (l *JavaScriptLinter) lintWithESLint(ctx context.Context, filePath string,
 	return result, nil
 }
 
+// eslintUnsupported returns the error for ESLint output that couldn't be read,
+// if the ESLint at toolPath is newer than the versions whose JSON format is known
+func eslintUnsupported(toolPath string) *linters.UnsupportedVersionError {
+	version, ok := linters.ParseToolVersion(toolcache.DetectVersion(toolPath))
+	if !ok || version.Major <= 9 {
+		return nil
+	}
+	return &linters.UnsupportedVersionError{Tool: "eslint", Version: version, Supported: "up to 9.x"}
+}
+
 // lintWithNode performs basic syntax checking using Node.js
 func (l *JavaScriptLinter) lintWithNode(ctx context.Context, filePath string, content []byte) (*linters.LintResult, error) {
 	result := &linters.LintResult{
@@ -625,14 +767,19 @@ func (l *JavaScriptLinter) lintWithNode(ctx context.Context, filePath string, co
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
+	release, err := l.runner.Acquire(ctx, l.getToolPath())
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	// Use Node.js to check syntax
-	// #nosec G204 - toolPath is validated through cache discovery
-	cmd := exec.CommandContext(ctx, l.getToolPath(), "-c", string(content))
+	cmd := l.runner.Command(ctx, l.Name(), l.getToolPath(), "-c", string(content))
 
 	var stderr bytes.Buffer
 	cmd.Stderr = &stderr
 
-	err := cmd.Run()
+	err = linters.Run(cmd)
 
 	if err != nil {
 		if ctx.Err() == context.DeadlineExceeded {
@@ -677,143 +824,83 @@ func (l *JavaScriptLinter) lintWithoutCache(ctx context.Context, filePath string
 	return l.basicSyntaxCheck(filePath, content)
 }
 
-// basicSyntaxCheck performs a very basic syntax validation
-func (l *JavaScriptLinter) basicSyntaxCheck(filePath string, content []byte) (*linters.LintResult, error) {
-	result := &linters.LintResult{
-		Success: true,
-		Issues:  []linters.Issue{},
-	}
-
-	// Basic checks for common syntax errors
-	lines := strings.Split(string(content), "\n")
-	for i, line := range lines {
-		lineNum := i + 1
-
-		// Check for common issues
-		trimmed := strings.TrimSpace(line)
-
-		// Check for unmatched braces (very basic)
-		openBraces := strings.Count(line, "{")
-		closeBraces := strings.Count(line, "}")
-		if openBraces != closeBraces && (openBraces > 0 || closeBraces > 0) {
-			result.Issues = append(result.Issues, linters.Issue{
-				File:     filePath,
-				Line:     lineNum,
-				Column:   1,
-				Severity: "warning",
-				Message:  "Possible unmatched braces",
-				Rule:     "basic-syntax",
-			})
-		}
-
-		// Check for common typos
-		if strings.Contains(trimmed, "function(") && !strings.Contains(trimmed, "function (") {
-			result.Issues = append(result.Issues, linters.Issue{
-				File:     filePath,
-				Line:     lineNum,
-				Column:   strings.Index(line, "function(") + 1,
-				Severity: "info",
-				Message:  "Consider adding space after 'function'",
-				Rule:     "basic-style",
-			})
-		}
-	}
-
-	return result, nil
-}
-
-// parseBiomeOutput parses Biome JSON output into linter issues
-func (l *JavaScriptLinter) parseBiomeOutput(output []byte, filePath string) ([]linters.Issue, error) {
+// parseBiomeOutput parses Biome JSON output into linter issues. partial is true
+// if the output was cut short and only the issues before the cut were read.
+func (l *JavaScriptLinter) parseBiomeOutput(output []byte, filePath string) (issues []linters.Issue, partial bool, err error) {
 	var biomeResult struct {
 		Diagnostics []BiomeIssue `json:"diagnostics"`
 	}
 
-	if err := json.Unmarshal(output, &biomeResult); err != nil {
-		return nil, fmt.Errorf("failed to parse Biome JSON: %w", err)
+	partial, err = linters.SalvageJSON(output, &biomeResult)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to parse Biome JSON: %w", err)
 	}
 
-	var issues []linters.Issue
 	for _, diag := range biomeResult.Diagnostics {
-		severity := "warning"
-		if diag.Severity == "error" {
-			severity = "error"
-		}
-
-		issue := linters.Issue{
-			File:     filePath,
-			Line:     diag.Location.Span.Start.Line,
-			Column:   diag.Location.Span.Start.Column,
-			Severity: severity,
-			Message:  diag.Message.Text,
-			Rule:     diag.Category,
-		}
+		issue := linters.ToolIssue("biome", diag.Severity, linters.Issue{
+			File:    filePath,
+			Line:    diag.Location.Span.Start.Line,
+			Column:  diag.Location.Span.Start.Column,
+			Message: diag.Message.Text,
+			Rule:    diag.Category,
+		})
 
 		issues = append(issues, issue)
 	}
 
-	return issues, nil
+	return issues, partial, nil
 }
 
-// parseOxlintOutput parses Oxlint JSON output into linter issues
-func (l *JavaScriptLinter) parseOxlintOutput(output []byte, filePath string) ([]linters.Issue, error) {
+// parseOxlintOutput parses Oxlint JSON output into linter issues. partial is
+// true if the output was cut short and only the issues before the cut were read.
+func (l *JavaScriptLinter) parseOxlintOutput(output []byte, filePath string) (issues []linters.Issue, partial bool, err error) {
 	var oxlintIssues []OxlintIssue
 
-	if err := json.Unmarshal(output, &oxlintIssues); err != nil {
-		return nil, fmt.Errorf("failed to parse Oxlint JSON: %w", err)
+	partial, err = linters.SalvageJSON(output, &oxlintIssues)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to parse Oxlint JSON: %w", err)
 	}
 
-	var issues []linters.Issue
 	for _, oxIssue := range oxlintIssues {
-		severity := "warning"
-		if oxIssue.Severity == "error" {
-			severity = "error"
-		}
-
-		issue := linters.Issue{
-			File:     filePath,
-			Line:     oxIssue.Location.Line,
-			Column:   oxIssue.Location.Column,
-			Severity: severity,
-			Message:  oxIssue.Message,
-			Rule:     oxIssue.Rule,
-		}
+		issue := linters.ToolIssue("oxlint", oxIssue.Severity, linters.Issue{
+			File:    filePath,
+			Line:    oxIssue.Location.Line,
+			Column:  oxIssue.Location.Column,
+			Message: oxIssue.Message,
+			Rule:    oxIssue.Rule,
+		})
 
 		issues = append(issues, issue)
 	}
 
-	return issues, nil
+	return issues, partial, nil
 }
 
-// parseESLintOutput parses ESLint JSON output into linter issues
-func (l *JavaScriptLinter) parseESLintOutput(output []byte, filePath string) ([]linters.Issue, error) {
+// parseESLintOutput parses ESLint JSON output into linter issues. partial is
+// true if the output was cut short and only the issues before the cut were read.
+func (l *JavaScriptLinter) parseESLintOutput(output []byte, filePath string) (issues []linters.Issue, partial bool, err error) {
 	var eslintResults []ESLintIssue
 
-	if err := json.Unmarshal(output, &eslintResults); err != nil {
-		return nil, fmt.Errorf("failed to parse ESLint JSON: %w", err)
+	partial, err = linters.SalvageJSON(output, &eslintResults)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to parse ESLint JSON: %w", err)
 	}
 
-	var issues []linters.Issue
 	for _, result := range eslintResults {
 		for _, msg := range result.Messages {
-			severity := "warning"
-			if msg.Severity == 2 {
-				severity = "error"
-			}
-
-			issue := linters.Issue{
-				File:     filePath,
-				Line:     msg.Line,
-				Column:   msg.Column,
-				Severity: severity,
-				Message:  msg.Message,
-				Rule:     msg.RuleId,
-			}
+			issue := linters.ToolIssue("eslint", strconv.Itoa(msg.Severity), linters.Issue{
+				File:    filePath,
+				Line:    msg.Line,
+				Column:  msg.Column,
+				Message: msg.Message,
+				Rule:    msg.RuleId,
+			})
 
 			issues = append(issues, issue)
 		}
 	}
 
-	return issues, nil
+	return issues, partial, nil
 }
 
 // parseNodeError parses Node.js syntax error into a linter issue
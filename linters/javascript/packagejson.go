@@ -0,0 +1,664 @@
+package javascript
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+// Rules reported for package.json
+const (
+	RulePackageJSON          = "package-json"
+	RuleInvalidVersionRange  = "invalid-version-range"
+	RuleAnyVersion           = "any-version"
+	RuleDuplicateDependency  = "duplicate-dependency"
+	RuleVulnerableDependency = "vulnerable-dependency"
+)
+
+// Dependency auditors selectable with the audit setting
+const (
+	AuditNpm        = "npm"
+	AuditOSVScanner = "osv-scanner"
+	AuditNone       = "none"
+)
+
+// auditTimeout bounds a dependency audit, which queries an advisory database
+const auditTimeout = time.Minute
+
+// auditLevels orders the severities auditors report; auditLevel reports
+// vulnerabilities at or above one of them
+var auditLevels = map[string]int{"info": 0, "low": 1, "moderate": 2, "high": 3, "critical": 4}
+
+// defaultAuditLevel is the least severe vulnerability reported by default
+const defaultAuditLevel = "high"
+
+// dependencySections are the package.json sections that map package names to
+// version ranges
+var dependencySections = []string{"dependencies", "devDependencies", "peerDependencies", "optionalDependencies"}
+
+// packageVersion matches a full semver version, as the version field requires
+var packageVersion = regexp.MustCompile(`^v?\d+\.\d+\.\d+(?:-[0-9A-Za-z.-]+)?(?:\+[0-9A-Za-z.-]+)?$`)
+
+// auditNetworkErrors mark auditor failures caused by the network rather than
+// the project, which aren't reported
+var auditNetworkErrors = []string{
+	"ENOTFOUND",
+	"EAI_AGAIN",
+	"ECONNREFUSED",
+	"ECONNRESET",
+	"ETIMEDOUT",
+	"ENETUNREACH",
+	"no such host",
+	"dial tcp",
+}
+
+// isPackageJSON reports whether filePath is a package manifest
+func isPackageJSON(filePath string) bool {
+	return strings.EqualFold(filepath.Base(filePath), "package.json")
+}
+
+// packageKeyLines maps the top-level keys of a package.json, and the keys of
+// its top-level objects, to the line that defines them
+type packageKeyLines map[[2]string]int
+
+// newPackageKeyLines locates the keys of content, which must be valid JSON
+func newPackageKeyLines(content []byte) packageKeyLines {
+	lines := make(packageKeyLines)
+	positions := linters.NewPositions(content)
+	type frame struct {
+		object    bool
+		key       string
+		expectKey bool
+	}
+	var stack []*frame
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return lines
+		}
+		if delim, ok := token.(json.Delim); ok {
+			switch delim {
+			case '{', '[':
+				// The container is the value of the enclosing key, after which a key follows
+				if len(stack) > 0 && stack[len(stack)-1].object {
+					stack[len(stack)-1].expectKey = true
+				}
+				stack = append(stack, &frame{object: delim == '{', expectKey: delim == '{'})
+			default:
+				stack = stack[:len(stack)-1]
+			}
+			continue
+		}
+		if len(stack) == 0 {
+			continue
+		}
+		top := stack[len(stack)-1]
+		if !top.object {
+			continue
+		}
+		if !top.expectKey {
+			top.expectKey = true
+			continue
+		}
+		key, _ := token.(string)
+		top.key, top.expectKey = key, false
+		line, _ := positions.Position(int(decoder.InputOffset()))
+		switch len(stack) {
+		case 1:
+			lines[[2]string{key, ""}] = line
+		case 2:
+			lines[[2]string{stack[0].key, key}] = line
+		}
+	}
+}
+
+// line returns the line of key in section, else of section, else 1. An empty
+// section is the top level.
+func (p packageKeyLines) line(section, key string) int {
+	if section == "" {
+		section, key = key, ""
+	}
+	if line, ok := p[[2]string{section, key}]; ok {
+		return line
+	}
+	if line, ok := p[[2]string{section, ""}]; ok {
+		return line
+	}
+	return 1
+}
+
+// packageIssues collects the issues found in a package.json
+type packageIssues struct {
+	filePath string
+	lines    packageKeyLines
+	disabled []string
+	issues   []linters.Issue
+}
+
+// add reports an issue for rule at key in section, unless the rule is disabled
+func (p *packageIssues) add(severity, rule, section, key, format string, args ...interface{}) {
+	if slices.Contains(p.disabled, rule) {
+		return
+	}
+	p.issues = append(p.issues, linters.Issue{
+		File:     p.filePath,
+		Line:     p.lines.line(section, key),
+		Column:   1,
+		Severity: severity,
+		Message:  fmt.Sprintf(format, args...),
+		Rule:     rule,
+	})
+}
+
+// dependencyLine returns the line declaring name as a dependency, or 1 for
+// packages that are only installed as dependencies of dependencies
+func (p *packageIssues) dependencyLine(name string) int {
+	for _, section := range dependencySections {
+		if line, ok := p.lines[[2]string{section, name}]; ok {
+			return line
+		}
+	}
+	return 1
+}
+
+// lintPackageJSON checks a package.json: that it is valid JSON with a name and
+// version, that its dependency ranges can be read, and that no package is both
+// a dependency and a dev dependency. A written manifest is also audited when
+// the audit setting names an auditor.
+func (l *JavaScriptLinter) lintPackageJSON(ctx context.Context, filePath string, content []byte) (*linters.LintResult, error) {
+	result := &linters.LintResult{Success: true, Issues: []linters.Issue{}}
+
+	var manifest map[string]interface{}
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		line, column := 1, 1
+		var syntaxErr *json.SyntaxError
+		var typeErr *json.UnmarshalTypeError
+		switch {
+		case errors.As(err, &syntaxErr):
+			line, column = linters.NewPositions(content).Position(int(syntaxErr.Offset))
+		case errors.As(err, &typeErr):
+			err = errors.New("package.json must be a JSON object")
+		}
+		result.Success = false
+		result.Issues = append(result.Issues, linters.Issue{
+			File:     filePath,
+			Line:     line,
+			Column:   column,
+			Severity: "error",
+			Message:  fmt.Sprintf("Invalid JSON syntax: %v", err),
+			Rule:     "syntax",
+		})
+		return result, nil
+	}
+
+	p := &packageIssues{filePath: filePath, lines: newPackageKeyLines(content), disabled: l.config.DisabledChecks}
+	checkPackageFields(p, manifest)
+	checkPackageDependencies(p, manifest)
+
+	auditIssues, err := l.auditDependencies(ctx, filePath, content, p)
+	if err != nil {
+		p.add("warning", RuleVulnerableDependency, "", "", "Dependency audit failed: %v", err)
+	}
+	p.issues = append(p.issues, auditIssues...)
+
+	for _, issue := range p.issues {
+		if issue.Severity == "error" {
+			result.Success = false
+		}
+	}
+	result.Issues = append(result.Issues, p.issues...)
+	return result, nil
+}
+
+// checkPackageFields checks the name and version npm requires to publish a
+// package. Private packages aren't published, so they may leave both out.
+func checkPackageFields(p *packageIssues, manifest map[string]interface{}) {
+	private, _ := manifest["private"].(bool)
+
+	switch name, ok := manifest["name"].(string); {
+	case manifest["name"] == nil:
+		if !private {
+			p.add("error", RulePackageJSON, "", "", "package.json needs a name, or \"private\": true if it isn't published")
+		}
+	case !ok:
+		p.add("error", RulePackageJSON, "", "name", "name must be a string")
+	default:
+		if problem := packageNameProblem(name); problem != "" {
+			p.add("error", RulePackageJSON, "", "name", "name %q %s", name, problem)
+		}
+	}
+
+	switch version, ok := manifest["version"].(string); {
+	case manifest["version"] == nil:
+		if !private {
+			p.add("error", RulePackageJSON, "", "", "package.json needs a version such as \"1.0.0\", or \"private\": true if it isn't published")
+		}
+	case !ok:
+		p.add("error", RulePackageJSON, "", "version", "version must be a string such as \"1.0.0\"")
+	case !packageVersion.MatchString(version):
+		p.add("error", RulePackageJSON, "", "version", "version %q is not a semver version such as \"1.0.0\"", version)
+	}
+}
+
+// packageNameProblem describes why npm would reject name, or returns ""
+func packageNameProblem(name string) string {
+	switch {
+	case name == "":
+		return "is empty"
+	case len(name) > 214:
+		return "is longer than 214 characters"
+	case strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_"):
+		return "can't start with . or _"
+	case strings.ToLower(name) != name:
+		return "must be lowercase"
+	case strings.TrimSpace(name) != name || strings.ContainsAny(name, " ~)('!*"):
+		return "can't contain spaces or any of ~)('!*"
+	}
+	return ""
+}
+
+// checkPackageDependencies checks each dependency section maps names to version
+// ranges that can be read, and warns about packages that are both dependencies
+// and dev dependencies
+func checkPackageDependencies(p *packageIssues, manifest map[string]interface{}) {
+	sections := make(map[string]map[string]interface{})
+	for _, section := range dependencySections {
+		value, present := manifest[section]
+		if !present {
+			continue
+		}
+		dependencies, ok := value.(map[string]interface{})
+		if !ok {
+			p.add("error", RulePackageJSON, "", section, "%s must be an object mapping package names to version ranges", section)
+			continue
+		}
+		sections[section] = dependencies
+		for _, name := range sortedNames(dependencies) {
+			spec, ok := dependencies[name].(string)
+			if !ok {
+				p.add("error", RulePackageJSON, section, name, "Dependency %q in %s must be a version range string", name, section)
+				continue
+			}
+			if acceptsAnyVersion(spec) {
+				// Peer dependencies commonly accept any version of the host package
+				if section == "dependencies" || section == "devDependencies" {
+					p.add("warning", RuleAnyVersion, section, name, "Dependency %q in %s accepts any version with %q; require a range such as \"^1.2.0\" so installs don't pick up breaking releases", name, section, spec)
+				}
+				continue
+			}
+			versionRange, isRange := registryRange(spec)
+			if !isRange {
+				continue
+			}
+			if _, ok := linters.SatisfiesVersionRange(linters.ToolVersion{}, versionRange); !ok {
+				p.add("error", RuleInvalidVersionRange, section, name, "Dependency %q in %s has version range %q, which npm can't read; use a range such as \"^1.2.0\"", name, section, spec)
+			}
+		}
+	}
+
+	for _, name := range sortedNames(sections["devDependencies"]) {
+		if _, ok := sections["dependencies"][name]; ok {
+			p.add("warning", RuleDuplicateDependency, "devDependencies", name,
+				"Dependency %q is in both dependencies and devDependencies; npm installs the dependencies range, so remove it from devDependencies", name)
+		}
+	}
+}
+
+// registryRange returns the version range of a dependency resolved from the
+// registry, including the range of an "npm:name@range" alias. Git, URL, path,
+// workspace and dist-tag dependencies aren't ranges.
+func registryRange(spec string) (string, bool) {
+	spec = strings.TrimSpace(spec)
+	if alias, ok := strings.CutPrefix(spec, "npm:"); ok {
+		at := strings.LastIndex(alias, "@")
+		if at <= 0 {
+			return "", false
+		}
+		spec = alias[at+1:]
+	}
+	if strings.ContainsAny(spec, ":/") {
+		return "", false
+	}
+	if spec != "" {
+		switch c := spec[0]; {
+		case c >= '0' && c <= '9', strings.IndexByte("<>=^~*vxX", c) >= 0:
+		default:
+			// Dist-tags such as "next"
+			return "", false
+		}
+	}
+	return spec, true
+}
+
+// acceptsAnyVersion reports whether spec lets npm install any version
+func acceptsAnyVersion(spec string) bool {
+	switch strings.TrimSpace(spec) {
+	case "", "*", "x", "X", "latest", ">=0", ">=0.0.0":
+		return true
+	}
+	return false
+}
+
+// sortedNames returns the keys of m in order
+func sortedNames(m map[string]interface{}) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// auditDependencies runs the configured auditor on a written package.json and
+// reports the vulnerable packages at or above auditLevel. Auditors read the
+// lockfile next to the manifest, or at the workspace root, and query an
+// advisory database, so unwritten content, static-only runs and network
+// failures aren't audited.
+func (l *JavaScriptLinter) auditDependencies(ctx context.Context, filePath string, content []byte, p *packageIssues) ([]linters.Issue, error) {
+	auditor := AuditNone
+	if l.config.Audit != nil {
+		auditor = *l.config.Audit
+	}
+	if auditor == AuditNone || linters.IsStaticOnly(ctx) || slices.Contains(l.config.DisabledChecks, RuleVulnerableDependency) {
+		return nil, nil
+	}
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return nil, nil
+	}
+	if onDisk, err := os.ReadFile(absPath); err != nil || !bytes.Equal(onDisk, content) { // #nosec G304 - the linted file
+		return nil, nil
+	}
+
+	level := defaultAuditLevel
+	if l.config.AuditLevel != nil {
+		level = *l.config.AuditLevel
+	}
+	threshold, ok := auditLevels[level]
+	if !ok {
+		return nil, fmt.Errorf("unknown audit level %q", level)
+	}
+
+	var lockfiles []string
+	switch auditor {
+	case AuditNpm:
+		lockfiles = []string{"package-lock.json", "npm-shrinkwrap.json"}
+	case AuditOSVScanner:
+		lockfiles = []string{"package-lock.json", "yarn.lock", "pnpm-lock.yaml"}
+	default:
+		return nil, fmt.Errorf("unknown auditor: %s", auditor)
+	}
+	packageDir, workspaceRoot := filepath.Dir(absPath), l.findProject(filePath).WorkspaceRoot
+	lockfile := findLockfile(packageDir, workspaceRoot, lockfiles)
+	if lockfile == "" {
+		return nil, nil
+	}
+	tool := findNodeTool(auditor, packageDir, workspaceRoot)
+	if tool == "" {
+		return nil, nil
+	}
+
+	var args []string
+	if auditor == AuditNpm {
+		args = []string{"audit", "--json"}
+	} else {
+		args = []string{"--format", "json", "--lockfile", lockfile}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, auditTimeout)
+	defer cancel()
+	release, err := l.runner.Acquire(ctx, tool)
+	if err != nil {
+		return nil, nil
+	}
+	defer release()
+
+	cmd := l.runner.Command(ctx, l.Name(), tool, args...)
+	cmd.Dir = filepath.Dir(lockfile)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	// Auditors exit non-zero when they find vulnerabilities, so the output
+	// decides whether the audit failed
+	runErr := linters.Run(cmd)
+	var execErr *exec.Error
+	if ctx.Err() != nil || errors.As(runErr, &execErr) || isAuditNetworkError(stdout.String()+stderr.String()) {
+		return nil, nil
+	}
+
+	var vulnerabilities []auditFinding
+	if auditor == AuditNpm {
+		vulnerabilities, err = parseNpmAudit(&stdout)
+	} else {
+		vulnerabilities, err = parseOSVScannerOutput(&stdout)
+	}
+	if err != nil {
+		if runErr != nil && stderr.Len() > 0 {
+			return nil, fmt.Errorf("%s failed: %s", auditor, strings.TrimSpace(stderr.String()))
+		}
+		return nil, err
+	}
+
+	var issues []linters.Issue
+	for _, finding := range vulnerabilities {
+		if auditLevels[finding.Severity] < threshold {
+			continue
+		}
+		issues = append(issues, linters.Issue{
+			File:     filePath,
+			Line:     p.dependencyLine(finding.Package),
+			Column:   1,
+			Severity: "warning",
+			Message:  finding.message(),
+			Rule:     RuleVulnerableDependency,
+			Tool:     auditor,
+		})
+	}
+	return issues, nil
+}
+
+// findLockfile returns the first of names in dir, or else in the workspace root
+func findLockfile(dir, workspaceRoot string, names []string) string {
+	for _, candidate := range []string{dir, workspaceRoot} {
+		if candidate == "" {
+			continue
+		}
+		for _, name := range names {
+			if path := filepath.Join(candidate, name); fileExists(path) {
+				return path
+			}
+		}
+	}
+	return ""
+}
+
+// isAuditNetworkError reports whether auditor output shows it couldn't reach
+// the advisory database
+func isAuditNetworkError(output string) bool {
+	for _, networkError := range auditNetworkErrors {
+		if strings.Contains(output, networkError) {
+			return true
+		}
+	}
+	return false
+}
+
+// auditFinding is a vulnerable package an auditor reported
+type auditFinding struct {
+	Package  string
+	Version  string
+	Severity string
+	// Advisories are the titles or IDs of the package's advisories
+	Advisories []string
+	// Via names the dependencies that pull in a package with no advisory of its own
+	Via       []string
+	FixExists bool
+}
+
+// message describes the finding for the issue list
+func (f auditFinding) message() string {
+	name := f.Package
+	if f.Version != "" {
+		name += "@" + f.Version
+	}
+	message := fmt.Sprintf("%s has a %s severity vulnerability", name, f.Severity)
+	switch {
+	case len(f.Advisories) > 0:
+		message += ": " + strings.Join(f.Advisories, "; ")
+	case len(f.Via) > 0:
+		message += " through " + strings.Join(f.Via, ", ")
+	}
+	if f.FixExists {
+		message += "; a fix is available"
+	}
+	return message
+}
+
+// npmAuditReport is the JSON output of npm audit --json, npm 7 and later
+type npmAuditReport struct {
+	Vulnerabilities map[string]struct {
+		Severity     string            `json:"severity"`
+		Via          []json.RawMessage `json:"via"`
+		FixAvailable json.RawMessage   `json:"fixAvailable"`
+	} `json:"vulnerabilities"`
+	Error *struct {
+		Code    string `json:"code"`
+		Summary string `json:"summary"`
+	} `json:"error"`
+}
+
+// parseNpmAudit returns the vulnerable packages in npm audit --json output
+func parseNpmAudit(r io.Reader) ([]auditFinding, error) {
+	var report npmAuditReport
+	if err := json.NewDecoder(r).Decode(&report); err != nil {
+		return nil, fmt.Errorf("failed to parse npm audit output: %w", err)
+	}
+	if report.Error != nil {
+		return nil, fmt.Errorf("npm audit failed: %s", strings.TrimSpace(report.Error.Code+" "+report.Error.Summary))
+	}
+	findings := make([]auditFinding, 0, len(report.Vulnerabilities))
+	for name, vulnerability := range report.Vulnerabilities {
+		finding := auditFinding{
+			Package:   name,
+			Severity:  vulnerability.Severity,
+			FixExists: len(vulnerability.FixAvailable) > 0 && string(vulnerability.FixAvailable) != "false",
+		}
+		// via holds advisories, or the names of vulnerable dependencies
+		for _, via := range vulnerability.Via {
+			var advisory struct {
+				Title string `json:"title"`
+				URL   string `json:"url"`
+			}
+			var dependency string
+			switch {
+			case json.Unmarshal(via, &dependency) == nil:
+				finding.Via = append(finding.Via, dependency)
+			case json.Unmarshal(via, &advisory) == nil && advisory.Title != "":
+				text := advisory.Title
+				if advisory.URL != "" {
+					text += " (" + advisory.URL + ")"
+				}
+				finding.Advisories = append(finding.Advisories, text)
+			}
+		}
+		findings = append(findings, finding)
+	}
+	sort.Slice(findings, func(i, j int) bool { return findings[i].Package < findings[j].Package })
+	return findings, nil
+}
+
+// osvScannerReport is the JSON output of osv-scanner --format json
+type osvScannerReport struct {
+	Results []struct {
+		Packages []struct {
+			Package struct {
+				Name    string `json:"name"`
+				Version string `json:"version"`
+			} `json:"package"`
+			Vulnerabilities []struct {
+				ID               string `json:"id"`
+				Summary          string `json:"summary"`
+				DatabaseSpecific struct {
+					Severity string `json:"severity"`
+				} `json:"database_specific"`
+			} `json:"vulnerabilities"`
+			Groups []struct {
+				IDs         []string `json:"ids"`
+				MaxSeverity string   `json:"max_severity"`
+			} `json:"groups"`
+		} `json:"packages"`
+	} `json:"results"`
+}
+
+// parseOSVScannerOutput returns the vulnerable packages osv-scanner reported,
+// each with the severity of its most severe advisory. Severities are the
+// advisory's own rating, else its CVSS score.
+func parseOSVScannerOutput(r io.Reader) ([]auditFinding, error) {
+	var report osvScannerReport
+	if err := json.NewDecoder(r).Decode(&report); err != nil {
+		return nil, fmt.Errorf("failed to parse osv-scanner output: %w", err)
+	}
+	var findings []auditFinding
+	for _, result := range report.Results {
+		for _, pkg := range result.Packages {
+			finding := auditFinding{Package: pkg.Package.Name, Version: pkg.Package.Version}
+			scores := make(map[string]string)
+			for _, group := range pkg.Groups {
+				for _, id := range group.IDs {
+					scores[id] = group.MaxSeverity
+				}
+			}
+			for _, vulnerability := range pkg.Vulnerabilities {
+				severity := strings.ToLower(vulnerability.DatabaseSpecific.Severity)
+				if _, ok := auditLevels[severity]; !ok {
+					severity = cvssLevel(scores[vulnerability.ID])
+				}
+				if auditLevels[severity] > auditLevels[finding.Severity] || finding.Severity == "" {
+					finding.Severity = severity
+				}
+				text := vulnerability.ID
+				if vulnerability.Summary != "" {
+					text += " " + vulnerability.Summary
+				}
+				finding.Advisories = append(finding.Advisories, text)
+			}
+			if len(finding.Advisories) > 0 {
+				findings = append(findings, finding)
+			}
+		}
+	}
+	return findings, nil
+}
+
+// cvssLevel returns the severity level of a CVSS score, as GitHub rates them.
+// Advisories without a score are rated low.
+func cvssLevel(score string) string {
+	value, err := strconv.ParseFloat(score, 64)
+	switch {
+	case err != nil:
+		return "low"
+	case value >= 9:
+		return "critical"
+	case value >= 7:
+		return "high"
+	case value >= 4:
+		return "moderate"
+	}
+	return "low"
+}
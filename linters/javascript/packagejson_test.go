@@ -0,0 +1,194 @@
+package javascript
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+func TestJavaScriptLinter_LintPackageJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		// want lists the expected issues as "line rule"
+		want    []string
+		success bool
+	}{
+		{
+			name:    "valid",
+			content: `{"name": "@acme/app", "version": "1.2.0", "dependencies": {"react": "^18.2.0", "lib": "workspace:*", "tool": "github:acme/tool"}, "peerDependencies": {"react-dom": "*"}}`,
+			success: true,
+		},
+		{
+			name:    "private without name or version",
+			content: `{"private": true, "devDependencies": {"vitest": "^2.0.0 || >= 1.6 <2", "next": "canary"}}`,
+			success: true,
+		},
+		{
+			name:    "invalid JSON",
+			content: "{\n  \"name\": \"app\",\n  \"version\": \"1.0.0\"\n  \"main\": \"index.js\"\n}\n",
+			want:    []string{"4 syntax"},
+		},
+		{
+			name:    "missing fields",
+			content: "{\n  \"description\": \"no name\"\n}\n",
+			want:    []string{"1 package-json", "1 package-json"},
+		},
+		{
+			name:    "invalid name and version",
+			content: "{\n  \"name\": \"My App\",\n  \"version\": \"1.0\"\n}\n",
+			want:    []string{"2 package-json", "3 package-json"},
+		},
+		{
+			name: "dependency ranges",
+			content: "{\n  \"name\": \"app\",\n  \"version\": \"1.0.0\",\n  \"dependencies\": {\n" +
+				"    \"lodash\": \"~>4.17\",\n    \"left-pad\": \"*\",\n    \"chalk\": \"npm:chalk@^5\"\n  },\n" +
+				"  \"devDependencies\": {\n    \"chalk\": \"^5.3.0\",\n    \"eslint\": \"latest\"\n  }\n}\n",
+			want: []string{"6 any-version", "5 invalid-version-range", "11 any-version", "10 duplicate-dependency"},
+		},
+		{
+			name:    "dependencies not an object",
+			content: "{\n  \"name\": \"app\",\n  \"version\": \"1.0.0\",\n  \"dependencies\": [\"lodash\"]\n}\n",
+			want:    []string{"4 package-json"},
+		},
+	}
+
+	linter := NewJavaScriptLinterWithConfig(nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := linter.Lint(context.Background(), "package.json", []byte(tt.content))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, issue := range result.Issues {
+				got = append(got, fmt.Sprintf("%d %s", issue.Line, issue.Rule))
+			}
+			if strings.Join(got, ", ") != strings.Join(tt.want, ", ") {
+				t.Errorf("issues = %v, want %v\n%+v", got, tt.want, result.Issues)
+			}
+			if result.Success != tt.success {
+				t.Errorf("Success = %v, want %v", result.Success, tt.success)
+			}
+		})
+	}
+
+	linter.config.DisabledChecks = []string{RuleAnyVersion}
+	result, _ := linter.Lint(context.Background(), "package.json", []byte(`{"name": "app", "version": "1.0.0", "dependencies": {"a": "*"}}`))
+	if len(result.Issues) != 0 {
+		t.Errorf("disabled rule reported: %+v", result.Issues)
+	}
+}
+
+func TestParseOSVScannerOutput(t *testing.T) {
+	output := `{"results": [{"source": {"path": "/app/package-lock.json"}, "packages": [
+		{"package": {"name": "lodash", "version": "4.17.20", "ecosystem": "npm"},
+		 "vulnerabilities": [
+			{"id": "GHSA-35jh-r3h4-6jhm", "summary": "Command Injection in lodash", "database_specific": {"severity": "HIGH"}},
+			{"id": "GHSA-29mw-wpgm-hmr9", "summary": "ReDoS in lodash", "database_specific": {"severity": "MODERATE"}}],
+		 "groups": [{"ids": ["GHSA-35jh-r3h4-6jhm"], "max_severity": "7.2"}, {"ids": ["GHSA-29mw-wpgm-hmr9"], "max_severity": "5.3"}]},
+		{"package": {"name": "minimist", "version": "1.2.5", "ecosystem": "npm"},
+		 "vulnerabilities": [{"id": "GHSA-xvch-5gv4-984h", "summary": "Prototype Pollution in minimist"}],
+		 "groups": [{"ids": ["GHSA-xvch-5gv4-984h"], "max_severity": "9.8"}]}]}]}`
+
+	findings, err := parseOSVScannerOutput(strings.NewReader(output))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(findings) != 2 || findings[0].Severity != "high" || findings[1].Severity != "critical" {
+		t.Fatalf("findings = %+v", findings)
+	}
+	want := "lodash@4.17.20 has a high severity vulnerability: GHSA-35jh-r3h4-6jhm Command Injection in lodash; GHSA-29mw-wpgm-hmr9 ReDoS in lodash"
+	if got := findings[0].message(); got != want {
+		t.Errorf("message() = %q, want %q", got, want)
+	}
+}
+
+func TestJavaScriptLinter_AuditDependencies(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake npm is a shell script")
+	}
+	root := t.TempDir()
+	bin := filepath.Join(root, "node_modules", ".bin")
+	if err := os.MkdirAll(bin, 0750); err != nil {
+		t.Fatal(err)
+	}
+	manifest := "{\n  \"name\": \"app\",\n  \"version\": \"1.0.0\",\n  \"dependencies\": {\n    \"lodash\": \"^4.17.20\"\n  }\n}\n"
+	for name, content := range map[string]string{
+		"package.json":      manifest,
+		"package-lock.json": `{"lockfileVersion": 3}`,
+	} {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The fake npm reports a high severity advisory for lodash and a moderate
+	// one for a transitive dependency, and exits 1 as npm does
+	calls := filepath.Join(t.TempDir(), "calls")
+	script := "#!/bin/sh\necho \"$(pwd) $*\" >> " + calls + "\n" + `cat <<'EOF'
+{"auditReportVersion": 2, "vulnerabilities": {
+  "lodash": {"name": "lodash", "severity": "high", "isDirect": true,
+    "via": [{"source": 1, "name": "lodash", "title": "Command Injection in lodash", "url": "https://github.com/advisories/GHSA-35jh-r3h4-6jhm", "severity": "high"}],
+    "fixAvailable": true},
+  "minimist": {"name": "minimist", "severity": "moderate", "isDirect": false, "via": ["mkdirp"], "fixAvailable": false}}}
+EOF
+exit 1
+`
+	if err := os.WriteFile(filepath.Join(bin, "npm"), []byte(script), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	linter := NewJavaScriptLinterWithConfig(nil)
+	audit := AuditNpm
+	linter.config.Audit = &audit
+	ctx := context.Background()
+	manifestPath := filepath.Join(root, "package.json")
+
+	result, err := linter.Lint(ctx, manifestPath, []byte(manifest))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Issues) != 1 || !result.Success {
+		t.Fatalf("audit issues = %+v", result.Issues)
+	}
+	issue := result.Issues[0]
+	want := "lodash has a high severity vulnerability: Command Injection in lodash (https://github.com/advisories/GHSA-35jh-r3h4-6jhm); a fix is available"
+	if issue.Rule != RuleVulnerableDependency || issue.Line != 5 || issue.Severity != "warning" || issue.Message != want {
+		t.Errorf("issue = %+v", issue)
+	}
+	data, _ := os.ReadFile(calls)
+	if want := root + " audit --json"; strings.TrimSpace(string(data)) != want {
+		t.Errorf("npm ran as %q, want %q", data, want)
+	}
+
+	level := "moderate"
+	linter.config.AuditLevel = &level
+	result, _ = linter.Lint(ctx, manifestPath, []byte(manifest))
+	if len(result.Issues) != 2 || result.Issues[1].Line != 1 || !strings.Contains(result.Issues[1].Message, "minimist has a moderate severity vulnerability through mkdirp") {
+		t.Errorf("moderate audit issues = %+v", result.Issues)
+	}
+
+	// Unwritten content and static-only runs aren't audited
+	pending := strings.Replace(manifest, "^4.17.20", "^4.17.21", 1)
+	if result, _ := linter.Lint(ctx, manifestPath, []byte(pending)); len(result.Issues) != 0 {
+		t.Errorf("pending content: %+v", result.Issues)
+	}
+	if result, _ := linter.Lint(linters.WithStaticOnly(ctx), manifestPath, []byte(manifest)); len(result.Issues) != 0 {
+		t.Errorf("static-only run: %+v", result.Issues)
+	}
+
+	// Network failures aren't reported
+	offline := "#!/bin/sh\necho '{\"error\": {\"code\": \"ENOTFOUND\", \"summary\": \"request to https://registry.npmjs.org failed\"}}'\nexit 1\n"
+	if err := os.WriteFile(filepath.Join(bin, "npm"), []byte(offline), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if result, _ := linter.Lint(ctx, manifestPath, []byte(manifest)); len(result.Issues) != 0 {
+		t.Errorf("offline audit: %+v", result.Issues)
+	}
+}
@@ -0,0 +1,80 @@
+package javascript
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// projectCacheTTL is how long a discovered project is reused, so a tsconfig.json
+// created while gismo runs is picked up
+const projectCacheTTL = time.Minute
+
+// findProject returns the project of a file, discovering it once per directory
+// and caching it for projectCacheTTL. Paths set in the configuration take the
+// place of discovered ones.
+func (l *JavaScriptLinter) findProject(filePath string) *ProjectInfo {
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		absPath = filePath
+	}
+	dir := filepath.Dir(absPath)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if info, ok := l.projectCache[dir]; ok && time.Since(info.LastDiscovered) < projectCacheTTL {
+		return info
+	}
+	info := discoverProject(dir)
+	if l.config.PackageJsonPath != nil {
+		info.PackageJsonPath = *l.config.PackageJsonPath
+	}
+	if l.config.TSConfigPath != nil {
+		info.TSConfigPath = *l.config.TSConfigPath
+	}
+	if l.config.WorkspaceRoot != nil {
+		info.WorkspaceRoot = *l.config.WorkspaceRoot
+	}
+	if l.projectCache == nil {
+		l.projectCache = make(map[string]*ProjectInfo)
+	}
+	l.projectCache[dir] = info
+	return info
+}
+
+// discoverProject finds the nearest package.json, tsconfig.json and formatter
+// configuration at or above dir, and the workspace root: the repository root, or else the outermost
+// directory with a package.json
+func discoverProject(dir string) *ProjectInfo {
+	info := &ProjectInfo{
+		LastDiscovered: time.Now(),
+	}
+	for current := dir; ; {
+		if path := filepath.Join(current, "package.json"); fileExists(path) {
+			if info.PackageJsonPath == "" {
+				info.PackageJsonPath = path
+			}
+			info.WorkspaceRoot = current
+		}
+		if path := filepath.Join(current, "tsconfig.json"); info.TSConfigPath == "" && fileExists(path) {
+			info.TSConfigPath = path
+		}
+		discoverFormatterConfigs(info, current)
+		if _, err := os.Stat(filepath.Join(current, ".git")); err == nil {
+			info.WorkspaceRoot = current
+			break
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			break
+		}
+		current = parent
+	}
+	return info
+}
+
+// fileExists reports whether path is a regular file
+func fileExists(path string) bool {
+	stat, err := os.Stat(path)
+	return err == nil && !stat.IsDir()
+}
@@ -0,0 +1,145 @@
+package javascript
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+// Test runners selectable with the testRunner setting
+const (
+	TestRunnerJest   = "jest"
+	TestRunnerVitest = "vitest"
+)
+
+// testFileName matches the file names Jest and Vitest pick up as tests, such
+// as app.test.ts and button.spec.jsx
+var testFileName = regexp.MustCompile(`\.(test|spec)\.[cm]?[jt]sx?$`)
+
+// isTestFile reports whether a file holds Jest or Vitest tests: a *.test.* or
+// *.spec.* file, or a script in a __tests__ directory
+func isTestFile(filePath string) bool {
+	if testFileName.MatchString(strings.ToLower(filepath.Base(filePath))) {
+		return true
+	}
+	return strings.Contains(filepath.ToSlash(filePath), "/__tests__/")
+}
+
+// detectTestRunner returns the test runner a package.json uses: the one its
+// test script runs, or else the one it depends on. It returns "" when the
+// package uses neither Jest nor Vitest.
+func detectTestRunner(packageJSON []byte) string {
+	var manifest struct {
+		Scripts         map[string]string `json:"scripts"`
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}
+	if json.Unmarshal(packageJSON, &manifest) != nil {
+		return ""
+	}
+	script := strings.Fields(manifest.Scripts["test"])
+	for _, runner := range []string{TestRunnerVitest, TestRunnerJest} {
+		for _, word := range script {
+			if word == runner {
+				return runner
+			}
+		}
+	}
+	for _, runner := range []string{TestRunnerVitest, TestRunnerJest} {
+		if _, ok := manifest.DevDependencies[runner]; ok {
+			return runner
+		}
+		if _, ok := manifest.Dependencies[runner]; ok {
+			return runner
+		}
+	}
+	return ""
+}
+
+// runTests runs a written test file with Jest or Vitest from its package
+// directory and returns the runner's output. The runner is testRunner, or the
+// one the nearest package.json uses. Content that hasn't been written yet
+// isn't tested, since the runner loads the file from disk.
+func (l *JavaScriptLinter) runTests(ctx context.Context, filePath string, content []byte) (string, error) {
+	if l.config.RunTests != nil && !*l.config.RunTests || !isTestFile(filePath) || linters.IsStaticOnly(ctx) {
+		return "", nil
+	}
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return "", nil
+	}
+	if onDisk, err := os.ReadFile(absPath); err != nil || !bytes.Equal(onDisk, content) { // #nosec G304 - the linted file
+		return "", nil
+	}
+	project := l.findProject(filePath)
+	if project.PackageJsonPath == "" {
+		return "", nil
+	}
+	packageDir := filepath.Dir(project.PackageJsonPath)
+
+	runner := ""
+	if l.config.TestRunner != nil {
+		runner = *l.config.TestRunner
+	} else if data, err := os.ReadFile(project.PackageJsonPath); err == nil { // #nosec G304 - package.json of the linted project
+		runner = detectTestRunner(data)
+	}
+	if runner == "" {
+		return "", nil
+	}
+	tool := findNodeTool(runner, packageDir, project.WorkspaceRoot)
+	if tool == "" {
+		return "", nil
+	}
+	relPath, err := filepath.Rel(packageDir, absPath)
+	if err != nil {
+		return "", nil
+	}
+
+	var args []string
+	switch runner {
+	case TestRunnerJest:
+		args = []string{"--ci", "--passWithNoTests", "--runTestsByPath"}
+	case TestRunnerVitest:
+		args = []string{"run", "--passWithNoTests"}
+	default:
+		return "", fmt.Errorf("unknown test runner: %s", runner)
+	}
+	args = append(append(args, l.config.TestArgs...), filepath.ToSlash(relPath))
+
+	timeout := 30 * time.Second
+	if l.config.TestTimeout != nil {
+		timeout = l.config.TestTimeout.Duration
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	release, err := l.runner.Acquire(ctx, tool)
+	if err != nil {
+		return "", err
+	}
+	defer release()
+
+	cmd := l.runner.Command(ctx, l.Name(), tool, args...)
+	cmd.Dir = packageDir
+	// Jest reports results on stderr, so both streams make up the output
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	if err := linters.Run(cmd); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return output.String(), fmt.Errorf("tests timed out after %s", timeout)
+		}
+		return output.String(), fmt.Errorf("tests failed")
+	}
+	return output.String(), nil
+}
@@ -0,0 +1,119 @@
+package javascript
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+func TestIsTestFile(t *testing.T) {
+	tests := map[string]bool{
+		"src/app.test.ts":       true,
+		"src/Button.spec.jsx":   true,
+		"src/util.test.mjs":     true,
+		"src/__tests__/util.ts": true,
+		"src/app.ts":            false,
+		"src/test-utils.ts":     false,
+		"src/contest.ts":        false,
+		"src/app.test.ts.snap":  false,
+	}
+	for path, want := range tests {
+		if got := isTestFile(path); got != want {
+			t.Errorf("isTestFile(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestDetectTestRunner(t *testing.T) {
+	tests := []struct {
+		name        string
+		packageJSON string
+		want        string
+	}{
+		{"test script", `{"scripts": {"test": "vitest --coverage"}, "devDependencies": {"jest": "^29"}}`, "vitest"},
+		{"jest dependency", `{"scripts": {"test": "npm run build && node test.js"}, "devDependencies": {"jest": "^29"}}`, "jest"},
+		{"vitest dependency", `{"devDependencies": {"vitest": "^2", "typescript": "^5"}}`, "vitest"},
+		{"no runner", `{"scripts": {"test": "mocha"}}`, ""},
+		{"invalid", `{`, ""},
+	}
+	for _, tt := range tests {
+		if got := detectTestRunner([]byte(tt.packageJSON)); got != tt.want {
+			t.Errorf("%s: detectTestRunner() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestJavaScriptLinter_RunTests(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake jest is a shell script")
+	}
+	root := t.TempDir()
+	bin := filepath.Join(root, "node_modules", ".bin")
+	testFile := filepath.Join(root, "src", "sum.test.js")
+	for _, dir := range []string{bin, filepath.Dir(testFile)} {
+		if err := os.MkdirAll(dir, 0750); err != nil {
+			t.Fatal(err)
+		}
+	}
+	passing := "test('sum', () => expect(1 + 1).toBe(2));\n"
+	for name, content := range map[string]string{
+		"package.json":    `{"name": "app", "scripts": {"test": "jest"}, "devDependencies": {"jest": "^29.7.0"}}`,
+		"src/sum.test.js": passing,
+		"src/sum.js":      "module.exports = (a, b) => a + b;\n",
+	} {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The fake jest reports on stderr, as jest does, and fails on toBe(3)
+	calls := filepath.Join(t.TempDir(), "calls")
+	script := "#!/bin/sh\necho \"$(pwd) $*\" >> " + calls + "\n" +
+		"for f; do file=$f; done\n" +
+		"if grep -q 'toBe(3)' \"$file\"; then echo \"FAIL $file\" >&2; exit 1; fi\n" +
+		"echo \"PASS $file\" >&2\n"
+	if err := os.WriteFile(filepath.Join(bin, "jest"), []byte(script), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	linter := NewJavaScriptLinterWithConfig(nil)
+	ctx := context.Background()
+
+	output, err := linter.runTests(ctx, testFile, []byte(passing))
+	if err != nil || !strings.Contains(output, "PASS src/sum.test.js") {
+		t.Errorf("passing tests: %q, %v", output, err)
+	}
+	data, _ := os.ReadFile(calls)
+	if want := root + " --ci --passWithNoTests --runTestsByPath src/sum.test.js"; strings.TrimSpace(string(data)) != want {
+		t.Errorf("jest ran as %q, want %q", data, want)
+	}
+
+	failing := "test('sum', () => expect(1 + 1).toBe(3));\n"
+	if err := os.WriteFile(testFile, []byte(failing), 0600); err != nil {
+		t.Fatal(err)
+	}
+	output, err = linter.runTests(ctx, testFile, []byte(failing))
+	if err == nil || !strings.Contains(output, "FAIL src/sum.test.js") {
+		t.Errorf("failing tests: %q, %v", output, err)
+	}
+
+	// Pending content, source files and static-only runs aren't tested
+	if output, err := linter.runTests(ctx, testFile, []byte(passing)); output != "" || err != nil {
+		t.Errorf("pending content: %q, %v", output, err)
+	}
+	if output, _ := linter.runTests(ctx, filepath.Join(root, "src", "sum.js"), []byte("module.exports = (a, b) => a + b;\n")); output != "" {
+		t.Errorf("source file: %q", output)
+	}
+	if output, _ := linter.runTests(linters.WithStaticOnly(ctx), testFile, []byte(failing)); output != "" {
+		t.Errorf("static-only run: %q", output)
+	}
+	disabled := false
+	linter.config.RunTests = &disabled
+	if output, _ := linter.runTests(ctx, testFile, []byte(failing)); output != "" {
+		t.Errorf("runTests false: %q", output)
+	}
+}
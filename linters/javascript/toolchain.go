@@ -0,0 +1,47 @@
+package javascript
+
+import (
+	"context"
+	"os/exec"
+	"path/filepath"
+	"slices"
+
+	"github.com/jrossi/gismo/linters"
+	"github.com/jrossi/gismo/toolcache"
+)
+
+// checkToolchain warns when the node the hooks run with doesn't match the
+// project's .nvmrc or package.json engines.node
+func (l *JavaScriptLinter) checkToolchain(ctx context.Context, filePath string) []linters.Issue {
+	if slices.Contains(l.config.DisabledChecks, linters.RuleToolchainMismatch) || ctx.Err() != nil {
+		return nil
+	}
+	running, ok := l.runningNode()
+	if !ok {
+		return nil
+	}
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return nil
+	}
+	if mismatch := linters.CheckNodeToolchain(linters.OSFileSystem{}, filepath.Dir(absPath), running); mismatch != nil {
+		return []linters.Issue{mismatch.Issue(filePath)}
+	}
+	return nil
+}
+
+// runningNode returns the version of the configured node, or the one on PATH
+func (l *JavaScriptLinter) runningNode() (linters.ToolVersion, bool) {
+	l.nodeVersionOnce.Do(func() {
+		nodePath := ""
+		if l.config.NodePath != nil {
+			nodePath = *l.config.NodePath
+		} else if path, err := exec.LookPath("node"); err == nil {
+			nodePath = path
+		}
+		if nodePath != "" {
+			l.nodeVersion, l.nodeVersionOK = linters.ParseToolVersion(toolcache.DetectVersion(nodePath))
+		}
+	})
+	return l.nodeVersion, l.nodeVersionOK
+}
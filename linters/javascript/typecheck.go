@@ -0,0 +1,164 @@
+package javascript
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+// tscDiagnostic matches a diagnostic line of tsc --pretty false, such as
+// "src/app.ts(3,7): error TS2322: Type 'string' is not assignable to type 'number'."
+var tscDiagnostic = regexp.MustCompile(`^(.+)\((\d+),(\d+)\): (error|warning) (TS\d+): (.*)$`)
+
+// isTypeScript reports whether a file is type-checked by tsc
+func isTypeScript(filePath string) bool {
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".ts", ".tsx", ".mts", ".cts":
+		return true
+	}
+	return false
+}
+
+// runTypeCheck type-checks a TypeScript file's project with tsc --noEmit and
+// returns the diagnostics in the file. Content that hasn't been written yet is
+// checked in a shadow of the project. It returns no issues when type checking
+// is off, the file has no tsconfig.json or tsc isn't installed.
+func (l *JavaScriptLinter) runTypeCheck(ctx context.Context, filePath string, content []byte) ([]linters.Issue, error) {
+	if l.config.TypeCheck == nil || !*l.config.TypeCheck || !isTypeScript(filePath) {
+		return nil, nil
+	}
+	project := l.findProject(filePath)
+	if project.TSConfigPath == "" {
+		return nil, nil
+	}
+	tsconfig, err := filepath.Abs(project.TSConfigPath)
+	if err != nil {
+		return nil, err
+	}
+	projectDir := filepath.Dir(tsconfig)
+	tsc := findNodeTool("tsc", projectDir, project.WorkspaceRoot)
+	if tsc == "" {
+		return nil, nil
+	}
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	// tsc reads the whole project from disk, so pending content is checked in a shadow
+	target := absPath
+	if onDisk, err := os.ReadFile(absPath); err != nil || !bytes.Equal(onDisk, content) { // #nosec G304 - the linted file
+		ws, err := linters.NewShadowWorkspace(projectDir, map[string][]byte{absPath: content})
+		if err != nil {
+			return nil, nil
+		}
+		defer func() { _ = ws.Close() }()
+		projectDir = ws.Root
+		tsconfig = ws.Path(tsconfig)
+		target = ws.Path(absPath)
+	}
+
+	timeout := 30 * time.Second
+	if l.config.TestTimeout != nil {
+		timeout = l.config.TestTimeout.Duration
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	release, err := l.runner.Acquire(ctx, tsc)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	cmd := l.runner.Command(ctx, l.Name(), tsc, "--noEmit", "--pretty", "false", "-p", tsconfig)
+	cmd.Dir = projectDir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	// tsc exits non-zero when it finds errors
+	runErr := linters.Run(cmd)
+	if ctx.Err() != nil {
+		return nil, fmt.Errorf("tsc timed out after %s", timeout)
+	}
+	issues := parseTSCOutput(stdout.Bytes(), projectDir, target, filePath)
+	if runErr != nil && len(issues) == 0 && stdout.Len() == 0 {
+		return nil, fmt.Errorf("tsc failed: %w: %s", runErr, strings.TrimSpace(stderr.String()))
+	}
+	return issues, nil
+}
+
+// findNodeTool returns the named tool installed in node_modules at or above
+// dir, up to root, or else the one on PATH
+func findNodeTool(name, dir, root string) string {
+	bin := name
+	if runtime.GOOS == "windows" {
+		bin = name + ".cmd"
+	}
+	for current := dir; ; {
+		if path := filepath.Join(current, "node_modules", ".bin", bin); fileExists(path) {
+			return path
+		}
+		parent := filepath.Dir(current)
+		if current == root || parent == current {
+			break
+		}
+		current = parent
+	}
+	if path, err := exec.LookPath(name); err == nil {
+		return path
+	}
+	return ""
+}
+
+// parseTSCOutput converts tsc's diagnostics for target, relative to dir, into
+// issues for filePath. Lines indented under a diagnostic elaborate on it and
+// are added to its message.
+func parseTSCOutput(output []byte, dir, target, filePath string) []linters.Issue {
+	var issues []linters.Issue
+	inTarget := false
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		match := tscDiagnostic.FindStringSubmatch(line)
+		if match == nil {
+			if inTarget && strings.HasPrefix(line, " ") && strings.TrimSpace(line) != "" {
+				issues[len(issues)-1].Message += " " + strings.TrimSpace(line)
+			}
+			continue
+		}
+		file := match[1]
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(dir, file)
+		}
+		inTarget = filepath.Clean(file) == target
+		if !inTarget {
+			continue
+		}
+		lineNumber, _ := strconv.Atoi(match[2])
+		column, _ := strconv.Atoi(match[3])
+		issues = append(issues, linters.Issue{
+			File:     filePath,
+			Line:     lineNumber,
+			Column:   column,
+			Severity: match[4],
+			Message:  match[6],
+			Rule:     match[5],
+			Tool:     "tsc",
+		})
+	}
+	return issues
+}
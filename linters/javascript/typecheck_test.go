@@ -0,0 +1,93 @@
+package javascript
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// tscOutput is tsc --pretty false output with an elaborated error in app.ts
+// and an error in another file
+const tscOutput = `src/app.ts(3,7): error TS2322: Type 'string' is not assignable to type 'number'.
+src/other.ts(1,1): error TS2304: Cannot find name 'foo'.
+src/app.ts(5,12): error TS2345: Argument of type '{ id: string; }' is not assignable to parameter of type 'User'.
+  Property 'name' is missing in type '{ id: string; }' but required in type 'User'.
+`
+
+func TestParseTSCOutput(t *testing.T) {
+	issues := parseTSCOutput([]byte(tscOutput), "/repo", "/repo/src/app.ts", "src/app.ts")
+	if len(issues) != 2 {
+		t.Fatalf("issues = %+v, want the two in app.ts", issues)
+	}
+	if got := issues[0]; got.Line != 3 || got.Column != 7 || got.Rule != "TS2322" || got.Severity != "error" || got.File != "src/app.ts" {
+		t.Errorf("first issue = %+v", got)
+	}
+	if !strings.HasSuffix(issues[1].Message, "is not assignable to parameter of type 'User'. Property 'name' is missing in type '{ id: string; }' but required in type 'User'.") {
+		t.Errorf("elaboration not added: %q", issues[1].Message)
+	}
+}
+
+func TestJavaScriptLinter_TypeCheck(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake tsc is a shell script")
+	}
+	root := t.TempDir()
+	bin := filepath.Join(root, "node_modules", ".bin")
+	appFile := filepath.Join(root, "src", "app.ts")
+	for _, dir := range []string{bin, filepath.Dir(appFile)} {
+		if err := os.MkdirAll(dir, 0750); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for name, content := range map[string]string{
+		"package.json":  `{"name": "app"}`,
+		"tsconfig.json": `{"compilerOptions": {"strict": true}}`,
+		"src/app.ts":    "export const n: number = 1;\n",
+	} {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The fake tsc reports an error in app.ts when it assigns a string
+	calls := filepath.Join(t.TempDir(), "calls")
+	script := "#!/bin/sh\necho \"$(pwd) $*\" >> " + calls + "\n" +
+		"grep -q '\"' src/app.ts && echo \"src/app.ts(1,14): error TS2322: Type 'string' is not assignable to type 'number'.\" && exit 2\nexit 0\n"
+	if err := os.WriteFile(filepath.Join(bin, "tsc"), []byte(script), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	enabled := true
+	config := DefaultJavaScriptConfig()
+	config.TypeCheck = &enabled
+	linter := NewJavaScriptLinterWithConfig(config)
+	ctx := context.Background()
+
+	if issues, err := linter.runTypeCheck(ctx, appFile, []byte("export const n: number = 1;\n")); err != nil || len(issues) != 0 {
+		t.Errorf("written file without errors: %+v, %v", issues, err)
+	}
+	data, _ := os.ReadFile(calls)
+	if want := root + " --noEmit --pretty false -p " + filepath.Join(root, "tsconfig.json"); strings.TrimSpace(string(data)) != want {
+		t.Errorf("tsc ran as %q, want %q", data, want)
+	}
+
+	// Pending content is checked in a shadow of the project
+	issues, err := linter.runTypeCheck(ctx, appFile, []byte("export const n: number = \"1\";\n"))
+	if err != nil || len(issues) != 1 || issues[0].Rule != "TS2322" || issues[0].File != appFile {
+		t.Errorf("pending content with a type error: %+v, %v", issues, err)
+	}
+	if data, _ := os.ReadFile(appFile); strings.Contains(string(data), `"`) {
+		t.Error("the project file was modified")
+	}
+
+	// JavaScript files and projects without a tsconfig.json aren't type-checked
+	if issues, _ := linter.runTypeCheck(ctx, filepath.Join(root, "src", "app.js"), []byte("const n = \"1\";\n")); len(issues) != 0 {
+		t.Errorf("JavaScript file: %+v", issues)
+	}
+	other := filepath.Join(t.TempDir(), "app.ts")
+	if issues, _ := linter.runTypeCheck(ctx, other, []byte("export const n: number = \"1\";\n")); len(issues) != 0 {
+		t.Errorf("file without a tsconfig.json: %+v", issues)
+	}
+}
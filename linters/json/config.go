@@ -3,7 +3,8 @@ package json
 import (
 	"encoding/json"
 	"fmt"
-	"time"
+
+	"github.com/jrossi/gismo/types"
 )
 
 // JSONFormat represents the JSON format type
@@ -50,10 +51,63 @@ type JSONConfig struct {
 	AllowComments *bool `json:"allowComments,omitempty"`
 }
 
-// Duration wraps time.Duration for JSON marshaling
-type Duration struct {
-	time.Duration
-}
+// configSchema is the JSON Schema for JSONConfig
+const configSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "properties": {
+    "maxFileSize": {
+      "type": "integer",
+      "minimum": 0,
+      "description": "Maximum file size in bytes to process"
+    },
+    "validationLevel": {
+      "type": "string",
+      "enum": [
+        "syntax",
+        "structure",
+        "schema"
+      ],
+      "description": "Validation strictness level"
+    },
+    "jsonSchema": {
+      "type": [
+        "object",
+        "string"
+      ],
+      "description": "JSON Schema to validate against, inline or as a file path"
+    },
+    "formatDetection": {
+      "type": "boolean",
+      "description": "Detect JSON Lines files automatically"
+    },
+    "disabledChecks": {
+      "type": "array",
+      "items": {
+        "type": "string"
+      },
+      "description": "Checks to skip"
+    },
+    "strictMode": {
+      "type": "boolean",
+      "description": "Enforce strict RFC 7159 compliance"
+    },
+    "prettyPrint": {
+      "type": "boolean",
+      "description": "Format JSON output"
+    },
+    "allowComments": {
+      "type": "boolean",
+      "description": "Accept JSON with comments"
+    }
+  },
+  "additionalProperties": false
+}`
+
+// Duration is kept for compatibility.
+//
+// Deprecated: use types.Duration.
+type Duration = types.Duration
 
 // DefaultJSONConfig returns the default configuration for JSON linting
 func DefaultJSONConfig() *JSONConfig {
@@ -75,33 +129,6 @@ func DefaultJSONConfig() *JSONConfig {
 	}
 }
 
-// UnmarshalJSON implements json.Unmarshaler for Duration
-func (d *Duration) UnmarshalJSON(b []byte) error {
-	var v interface{}
-	if err := json.Unmarshal(b, &v); err != nil {
-		return err
-	}
-	switch value := v.(type) {
-	case float64:
-		d.Duration = time.Duration(value)
-		return nil
-	case string:
-		var err error
-		d.Duration, err = time.ParseDuration(value)
-		if err != nil {
-			return err
-		}
-		return nil
-	default:
-		return fmt.Errorf("invalid duration type: %T", v)
-	}
-}
-
-// MarshalJSON implements json.Marshaler for Duration
-func (d Duration) MarshalJSON() ([]byte, error) {
-	return json.Marshal(d.Duration.String())
-}
-
 // UnmarshalJSON implements json.Unmarshaler for ValidationLevel
 func (v *ValidationLevel) UnmarshalJSON(b []byte) error {
 	var s string
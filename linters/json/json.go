@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -24,6 +25,8 @@ type JSONLinter struct {
 	scannerPool *sync.Pool
 	// Schema cache for performance
 	schemas map[string]*jsonschema.Schema
+	// Filesystem used for project discovery and config lookups
+	fs linters.FileSystem
 }
 
 // NewJSONLinter creates a new JSON linter with default configuration
@@ -40,6 +43,7 @@ func NewJSONLinterWithConfig(config *JSONConfig) *JSONLinter {
 	return &JSONLinter{
 		config:  config,
 		schemas: make(map[string]*jsonschema.Schema),
+		fs:      linters.OSFileSystem{},
 		bufferPool: &sync.Pool{
 			New: func() interface{} {
 				return bytes.NewBuffer(make([]byte, 0, 4096))
@@ -58,6 +62,18 @@ func (l *JSONLinter) Name() string {
 	return "json"
 }
 
+// Capabilities reports the built-in checks and the external tools used when installed
+func (l *JSONLinter) Capabilities() linters.Capabilities {
+	return linters.Capabilities{
+		Embedded: []string{"syntax", "structure", "JSON Schema validation"},
+	}
+}
+
+// SetFileSystem sets the filesystem used for project discovery and config lookups
+func (l *JSONLinter) SetFileSystem(fsys linters.FileSystem) {
+	l.fs = fsys
+}
+
 // CanHandle returns true if this linter can handle the given file
 func (l *JSONLinter) CanHandle(filePath string) bool {
 	lowerPath := strings.ToLower(filePath)
@@ -69,14 +85,20 @@ func (l *JSONLinter) CanHandle(filePath string) bool {
 
 // SetConfig updates the linter configuration
 func (l *JSONLinter) SetConfig(config []byte) error {
-	var jsonConfig JSONConfig
-	if err := gojson.Unmarshal(config, &jsonConfig); err != nil {
+	// Settings not given keep their defaults
+	jsonConfig := DefaultJSONConfig()
+	if err := gojson.Unmarshal(config, jsonConfig); err != nil {
 		return fmt.Errorf("failed to unmarshal json config: %w", err)
 	}
-	l.config = &jsonConfig
+	l.config = jsonConfig
 	return nil
 }
 
+// ConfigSchema returns the JSON Schema for the linter configuration
+func (l *JSONLinter) ConfigSchema() json.RawMessage {
+	return json.RawMessage(configSchema)
+}
+
 // Lint performs linting on a single JSON file
 func (l *JSONLinter) Lint(ctx context.Context, filePath string, content []byte) (*linters.LintResult, error) {
 	result := &linters.LintResult{
@@ -278,7 +300,7 @@ func (l *JSONLinter) validateJSONLines(ctx context.Context, filePath string, con
 				result.Issues = append(result.Issues, linters.Issue{
 					File:     filePath,
 					Line:     lineNum,
-					Column:   1,
+					Column:   l.findErrorPosition([]byte(line), err).Column,
 					Severity: "error",
 					Message:  fmt.Sprintf("Invalid JSON syntax on line %d: %v", lineNum, err),
 					Rule:     "syntax",
@@ -343,7 +365,7 @@ func (l *JSONLinter) loadSchema(schemaData *json.RawMessage) (*jsonschema.Schema
 		}
 
 		// Load schema from file
-		schemaBytes, err := os.ReadFile(schemaPath)
+		schemaBytes, err := l.fs.ReadFile(schemaPath)
 		if err != nil {
 			return nil, fmt.Errorf("failed to read schema file %s: %w", schemaPath, err)
 		}
@@ -436,12 +458,22 @@ type ErrorPosition struct {
 	Column int
 }
 
-// findErrorPosition finds the line and column of a JSON error
+// findErrorPosition finds the line and column of a JSON error from its byte
+// offset, or the start of the file if the error has none
 func (l *JSONLinter) findErrorPosition(content []byte, err error) ErrorPosition {
-	// For now, return a basic position
-	// In a production implementation, this would parse the error message
-	// to extract precise line/column information
-	return ErrorPosition{Line: 1, Column: 1}
+	var offset int64
+	var syntaxErr *gojson.SyntaxError
+	var typeErr *gojson.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		offset = syntaxErr.Offset
+	case errors.As(err, &typeErr):
+		offset = typeErr.Offset
+	default:
+		return ErrorPosition{Line: 1, Column: 1}
+	}
+	line, column := linters.NewPositions(content).Position(int(offset))
+	return ErrorPosition{Line: line, Column: column}
 }
 
 // isCheckDisabled checks if a specific check is disabled
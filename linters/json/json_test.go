@@ -150,6 +150,10 @@ func TestJSONLinter_Lint_InvalidJSON(t *testing.T) {
 	if issue.Rule != "syntax" {
 		t.Errorf("Expected rule 'syntax', got %s", issue.Rule)
 	}
+	// The trailing comma is found at the closing brace
+	if issue.Line != 8 || issue.Column != 3 {
+		t.Errorf("Expected position 8:3, got %d:%d", issue.Line, issue.Column)
+	}
 }
 
 func TestJSONLinter_Lint_ValidJSONLines(t *testing.T) {
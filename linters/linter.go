@@ -22,14 +22,63 @@ type LintResult struct {
 	Issues     []Issue
 	Formatted  []byte // Formatted content if applicable
 	TestOutput string // Output from running tests
+	// Partial is set when a tool stopped mid-output, such as at a timeout, so
+	// Issues holds only what could be salvaged from its output
+	Partial bool
 }
 
 // Issue represents a single linting issue
 type Issue struct {
-	File     string
-	Line     int
-	Column   int
-	Severity string // "error", "warning", "info"
-	Message  string
-	Rule     string // Rule that was violated
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	Severity string `json:"severity"` // "error", "warning", "info"
+	Message  string `json:"message"`
+	Rule     string `json:"rule,omitempty"` // Rule that was violated
+	// Tool and ToolSeverity name the external tool that reported the issue and
+	// its own severity label, see ToolIssue
+	Tool         string `json:"tool,omitempty"`
+	ToolSeverity string `json:"toolSeverity,omitempty"`
+	// Fingerprint identifies the issue across runs, see SetFingerprints
+	Fingerprint string `json:"fingerprint,omitempty"`
+	// SourceLines are the lines around the issue, starting at line SourceStart,
+	// see AttachSourceLines
+	SourceLines []string `json:"sourceLines,omitempty"`
+	SourceStart int      `json:"sourceStart,omitempty"`
+}
+
+// RuleDescriber is implemented by linters that define their own rules, as
+// opposed to reporting rules of external tools
+type RuleDescriber interface {
+	// Rules returns a description of each rule, keyed by the rule name in Issue.Rule
+	Rules() map[string]string
+}
+
+// Capabilities describes what a linter checks on its own and which external
+// tools it runs when they are installed
+type Capabilities struct {
+	// Embedded lists the checks built into gismo, which run with no tools installed
+	Embedded []string `json:"embedded"`
+	// Tools lists the external tools the linter uses when found, in order of preference
+	Tools []string `json:"tools,omitempty"`
+}
+
+// CapabilityDescriber is implemented by linters that report their capabilities
+type CapabilityDescriber interface {
+	Capabilities() Capabilities
+}
+
+// staticOnlyKey marks contexts in which linters must not execute project code
+type staticOnlyKey struct{}
+
+// WithStaticOnly returns a context asking linters to skip checks that execute
+// project code, such as running tests, and report only static findings
+func WithStaticOnly(ctx context.Context) context.Context {
+	return context.WithValue(ctx, staticOnlyKey{}, true)
+}
+
+// IsStaticOnly reports whether linters should skip running tests and other project code
+func IsStaticOnly(ctx context.Context) bool {
+	static, _ := ctx.Value(staticOnlyKey{}).(bool)
+	return static
 }
@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/jrossi/gismo/linters"
 	"github.com/kaptinlin/jsonschema"
@@ -27,6 +28,8 @@ type MarkdownLinter struct {
 	rules   []MarkdownRule
 	schemas map[string]*jsonschema.Schema
 	config  *MarkdownConfig
+	// Filesystem used for project discovery and config lookups
+	fs linters.FileSystem
 }
 
 // MarkdownConfig represents markdown linter specific configuration
@@ -39,6 +42,48 @@ type MarkdownConfig struct {
 	ListIndentSize     *int             `json:"listIndentSize,omitempty"`
 }
 
+// configSchema is the JSON Schema for MarkdownConfig
+const configSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "properties": {
+    "maxLineLength": {
+      "type": "integer",
+      "minimum": 0,
+      "description": "Maximum line length"
+    },
+    "requireFrontmatter": {
+      "type": "boolean",
+      "description": "Require YAML frontmatter in every file"
+    },
+    "frontmatterSchema": {
+      "type": [
+        "object",
+        "string"
+      ],
+      "description": "JSON Schema for frontmatter, inline or as a file path"
+    },
+    "disabledRules": {
+      "type": "array",
+      "items": {
+        "type": "string"
+      },
+      "description": "Markdown rules to skip"
+    },
+    "maxBlankLines": {
+      "type": "integer",
+      "minimum": 0,
+      "description": "Maximum consecutive blank lines"
+    },
+    "listIndentSize": {
+      "type": "integer",
+      "minimum": 0,
+      "description": "Spaces per list indentation level"
+    }
+  },
+  "additionalProperties": false
+}`
+
 // MarkdownRule defines the interface for markdown linting rules
 type MarkdownRule interface {
 	Check(doc ast.Node, source []byte, filePath string) []linters.Issue
@@ -117,6 +162,7 @@ func NewMarkdownLinterWithConfig(config *MarkdownConfig) *MarkdownLinter {
 		rules:   enabledRules,
 		schemas: make(map[string]*jsonschema.Schema),
 		config:  config,
+		fs:      linters.OSFileSystem{},
 	}
 }
 
@@ -177,11 +223,44 @@ func (l *MarkdownLinter) SetConfig(configData json.RawMessage) error {
 	return nil
 }
 
+// ConfigSchema returns the JSON Schema for the linter configuration
+func (l *MarkdownLinter) ConfigSchema() json.RawMessage {
+	return json.RawMessage(configSchema)
+}
+
 // Name returns the linter name
 func (l *MarkdownLinter) Name() string {
 	return "markdown"
 }
 
+// Capabilities reports the built-in checks and the external tools used when installed
+func (l *MarkdownLinter) Capabilities() linters.Capabilities {
+	return linters.Capabilities{
+		Embedded: []string{"markdown rules", "formatting"},
+	}
+}
+
+// Rules describes the markdown rules, including the ones disabled by configuration
+func (l *MarkdownLinter) Rules() map[string]string {
+	return map[string]string{
+		"heading-hierarchy":    "Heading levels don't skip a level (H1, H2, H3) and the document has a single H1",
+		"list-indentation":     "Nested list items are indented by the configured listIndentSize",
+		"code-block-language":  "Fenced code blocks specify a language for syntax highlighting",
+		"line-length":          "Lines are no longer than the configured maxLineLength",
+		"trailing-whitespace":  "Lines do not end with spaces or tabs",
+		"emphasis-consistency": "Italic emphasis uses * rather than _",
+		"blank-line-spacing":   "No more consecutive blank lines than the configured maxBlankLines",
+		"require-frontmatter":  "The document starts with frontmatter, when requireFrontmatter is set",
+		"frontmatter-schema":   "Frontmatter matches the JSON Schema configured for the file",
+		"formatting":           "The document is formatted as the markdown formatter would format it",
+	}
+}
+
+// SetFileSystem sets the filesystem used for project discovery and config lookups
+func (l *MarkdownLinter) SetFileSystem(fsys linters.FileSystem) {
+	l.fs = fsys
+}
+
 // CanHandle returns true for markdown files
 func (l *MarkdownLinter) CanHandle(filePath string) bool {
 	return strings.HasSuffix(filePath, ".md") || strings.HasSuffix(filePath, ".markdown")
@@ -294,7 +373,7 @@ func (l *MarkdownLinter) loadSchema(schemaData *json.RawMessage) (*jsonschema.Sc
 		}
 
 		// Load schema from file
-		schemaBytes, err := os.ReadFile(schemaPath)
+		schemaBytes, err := l.fs.ReadFile(schemaPath)
 		if err != nil {
 			return nil, fmt.Errorf("failed to read schema file %s: %w", schemaPath, err)
 		}
@@ -371,17 +450,19 @@ func (r *HeadingHierarchyRule) Name() string {
 
 func (r *HeadingHierarchyRule) Check(doc ast.Node, source []byte, filePath string) []linters.Issue {
 	var issues []linters.Issue
+	positions := linters.NewPositions(source)
 	var lastLevel int
 	var hasH1 bool
 
 	_ = ast.Walk(doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
 		if heading, ok := node.(*ast.Heading); ok && entering {
+			line, column := nodePosition(positions, source, heading)
 			if heading.Level == 1 {
 				if hasH1 {
 					issues = append(issues, linters.Issue{
 						File:     filePath,
-						Line:     getLineNumber(source, heading),
-						Column:   1,
+						Line:     line,
+						Column:   column,
 						Severity: "warning",
 						Message:  "Multiple H1 headings found, consider using H2 for subsequent sections",
 						Rule:     r.Name(),
@@ -393,8 +474,8 @@ func (r *HeadingHierarchyRule) Check(doc ast.Node, source []byte, filePath strin
 			if lastLevel > 0 && heading.Level > lastLevel+1 {
 				issues = append(issues, linters.Issue{
 					File:     filePath,
-					Line:     getLineNumber(source, heading),
-					Column:   1,
+					Line:     line,
+					Column:   column,
 					Severity: "error",
 					Message:  fmt.Sprintf("Heading level %d skips level %d (should not skip levels)", heading.Level, lastLevel+1),
 					Rule:     r.Name(),
@@ -437,7 +518,7 @@ func (r *ListIndentationRule) Check(_ ast.Node, source []byte, filePath string)
 				issues = append(issues, linters.Issue{
 					File:     filePath,
 					Line:     i + 1,
-					Column:   1,
+					Column:   indent + 1,
 					Severity: "warning",
 					Message:  fmt.Sprintf("List items should use %d-space indentation for nesting", indentSize),
 					Rule:     r.Name(),
@@ -458,14 +539,16 @@ func (r *CodeBlockRule) Name() string {
 
 func (r *CodeBlockRule) Check(doc ast.Node, source []byte, filePath string) []linters.Issue {
 	var issues []linters.Issue
+	positions := linters.NewPositions(source)
 
 	_ = ast.Walk(doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
 		if codeBlock, ok := node.(*ast.FencedCodeBlock); ok && entering {
 			if codeBlock.Info == nil || codeBlock.Info.Value(source) == nil || len(codeBlock.Info.Value(source)) == 0 {
+				line, column := nodePosition(positions, source, codeBlock)
 				issues = append(issues, linters.Issue{
 					File:     filePath,
-					Line:     getLineNumber(source, codeBlock),
-					Column:   1,
+					Line:     line,
+					Column:   column,
 					Severity: "warning",
 					Message:  "Code blocks should specify a language for syntax highlighting",
 					Rule:     r.Name(),
@@ -492,13 +575,14 @@ func (r *LineLengthRule) Check(_ ast.Node, source []byte, filePath string) []lin
 	lines := strings.Split(string(source), "\n")
 
 	for i, line := range lines {
-		if len(line) > r.MaxLength {
+		// Length counts characters, not bytes
+		if length := utf8.RuneCountInString(line); length > r.MaxLength {
 			issues = append(issues, linters.Issue{
 				File:     filePath,
 				Line:     i + 1,
 				Column:   r.MaxLength + 1,
 				Severity: "warning",
-				Message:  fmt.Sprintf("Line exceeds maximum length of %d characters (%d)", r.MaxLength, len(line)),
+				Message:  fmt.Sprintf("Line exceeds maximum length of %d characters (%d)", r.MaxLength, length),
 				Rule:     r.Name(),
 			})
 		}
@@ -523,7 +607,7 @@ func (r *TrailingWhitespaceRule) Check(_ ast.Node, source []byte, filePath strin
 			issues = append(issues, linters.Issue{
 				File:     filePath,
 				Line:     i + 1,
-				Column:   len(line),
+				Column:   linters.Column(line, len(strings.TrimRight(line, " \t"))),
 				Severity: "error",
 				Message:  "Line has trailing whitespace",
 				Rule:     r.Name(),
@@ -543,20 +627,21 @@ func (r *EmphasisConsistencyRule) Name() string {
 
 func (r *EmphasisConsistencyRule) Check(doc ast.Node, source []byte, filePath string) []linters.Issue {
 	var issues []linters.Issue
+	positions := linters.NewPositions(source)
+	lines := strings.Split(string(source), "\n")
 
 	_ = ast.Walk(doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
 		if emphasis, ok := node.(*ast.Emphasis); ok && entering {
 			// Check if using * for italic (preferred)
-			line := getLineNumber(source, emphasis)
+			line, column := nodePosition(positions, source, emphasis)
 			if line > 0 {
-				lines := strings.Split(string(source), "\n")
 				if line <= len(lines) {
 					lineText := lines[line-1]
 					if strings.Contains(lineText, "_") && !strings.Contains(lineText, "*") {
 						issues = append(issues, linters.Issue{
 							File:     filePath,
 							Line:     line,
-							Column:   1,
+							Column:   column,
 							Severity: "info",
 							Message:  "Prefer * for italic emphasis over _",
 							Rule:     r.Name(),
@@ -613,57 +698,56 @@ func (r *BlankLineRule) Check(_ ast.Node, source []byte, filePath string) []lint
 	return issues
 }
 
-// getLineNumber calculates the line number for an AST node
-func getLineNumber(source []byte, node ast.Node) int {
-	// Try to get line information from different node types
-	switch n := node.(type) {
-	case *ast.Heading:
-		if n.Lines().Len() > 0 {
-			segment := n.Lines().At(0)
-			lineCount := 1
-			for i := 0; i < segment.Start && i < len(source); i++ {
-				if source[i] == '\n' {
-					lineCount++
-				}
-			}
-			return lineCount
+// nodePosition returns the line and column of an AST node. Block nodes report
+// the first non-blank character of their first line, inline nodes their opening
+// marker; nodes without source text report their parent's position. positions
+// indexes source, and is built once per check since documents have many nodes.
+func nodePosition(positions *linters.Positions, source []byte, node ast.Node) (line, column int) {
+	for n := node; n != nil; n = n.Parent() {
+		offset, ok := nodeOffset(n)
+		if !ok {
+			continue
 		}
-		// Fallback: search for heading text
-		lines := strings.Split(string(source), "\n")
-		for i, line := range lines {
-			if strings.HasPrefix(strings.TrimSpace(line), strings.Repeat("#", n.Level)) {
-				return i + 1
-			}
+		switch n := n.(type) {
+		case *ast.Emphasis:
+			return positions.Position(offset - n.Level)
+		case *ast.FencedCodeBlock:
+			// The fence is on the line before the first line of code
+			line, _ = positions.Position(offset)
+			line = max(1, line-1)
+			return line, indentColumn(source, positions, line)
 		}
-	case *ast.FencedCodeBlock:
-		if n.Lines().Len() > 0 {
-			segment := n.Lines().At(0)
-			lineCount := 1
-			for i := 0; i < segment.Start && i < len(source); i++ {
-				if source[i] == '\n' {
-					lineCount++
-				}
-			}
-			return lineCount
-		}
-	case *ast.List:
-		if n.Lines().Len() > 0 {
-			segment := n.Lines().At(0)
-			lineCount := 1
-			for i := 0; i < segment.Start && i < len(source); i++ {
-				if source[i] == '\n' {
-					lineCount++
-				}
-			}
-			return lineCount
+		if n.Type() == ast.TypeBlock {
+			line, _ = positions.Position(offset)
+			return line, indentColumn(source, positions, line)
 		}
+		return positions.Position(offset)
 	}
+	return 1, 1
+}
 
-	// For other nodes, try to find a parent with line info
-	parent := node.Parent()
-	if parent != nil {
-		return getLineNumber(source, parent)
+// nodeOffset returns the byte offset of the first source text of node
+func nodeOffset(node ast.Node) (int, bool) {
+	if text, ok := node.(*ast.Text); ok {
+		return text.Segment.Start, true
 	}
+	if node.Type() == ast.TypeBlock && node.Lines().Len() > 0 {
+		return node.Lines().At(0).Start, true
+	}
+	for child := node.FirstChild(); child != nil; child = child.NextSibling() {
+		if offset, ok := nodeOffset(child); ok {
+			return offset, true
+		}
+	}
+	return 0, false
+}
 
-	return 1
+// indentColumn returns the column of the first non-blank character of line
+func indentColumn(source []byte, positions *linters.Positions, line int) int {
+	offset := positions.Offset(line, 1)
+	for offset < len(source) && (source[offset] == ' ' || source[offset] == '\t') {
+		offset++
+	}
+	_, column := positions.Position(offset)
+	return column
 }
@@ -41,6 +41,16 @@ func TestMarkdownLinter_Name(t *testing.T) {
 	}
 }
 
+func TestMarkdownLinter_Rules(t *testing.T) {
+	linter := NewMarkdownLinter()
+	described := linter.Rules()
+	for _, rule := range linter.rules {
+		if described[rule.Name()] == "" {
+			t.Errorf("rule %s has no description", rule.Name())
+		}
+	}
+}
+
 func TestMarkdownLinter_HeadingHierarchy(t *testing.T) {
 	linter := NewMarkdownLinter()
 
@@ -275,6 +285,50 @@ func TestMarkdownLinter_CodeBlockLanguage(t *testing.T) {
 	}
 }
 
+func TestMarkdownLinter_Positions(t *testing.T) {
+	linter := NewMarkdownLinter()
+
+	tests := []struct {
+		name    string
+		content string
+		rule    string
+		line    int
+		column  int
+	}{
+		{name: "indented heading", content: "# Title\n\n  ### Skipped\n", rule: "heading-hierarchy", line: 3, column: 3},
+		{name: "code block fence", content: "Intro\n\n```\ncode\n```\n", rule: "code-block-language", line: 3, column: 1},
+		{name: "emphasis marker", content: "Some é _word_ here\n", rule: "emphasis-consistency", line: 1, column: 8},
+		{name: "trailing whitespace", content: "héllo  \n", rule: "trailing-whitespace", line: 1, column: 6},
+		{name: "list marker", content: "- item\n   - nested\n", rule: "list-indentation", line: 2, column: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := linter.Lint(context.Background(), "test.md", []byte(tt.content))
+			if err != nil {
+				t.Fatalf("Lint() error = %v", err)
+			}
+			for _, issue := range result.Issues {
+				if issue.Rule == tt.rule {
+					if issue.Line != tt.line || issue.Column != tt.column {
+						t.Errorf("%s at %d:%d, want %d:%d", tt.rule, issue.Line, issue.Column, tt.line, tt.column)
+					}
+					return
+				}
+			}
+			t.Errorf("no %s issue in %+v", tt.rule, result.Issues)
+		})
+	}
+
+	// Line length counts characters, not bytes
+	result, _ := linter.Lint(context.Background(), "test.md", []byte(strings.Repeat("é", 70)+"\n"))
+	for _, issue := range result.Issues {
+		if issue.Rule == "line-length" {
+			t.Errorf("70 two-byte characters reported as too long: %+v", issue)
+		}
+	}
+}
+
 func TestMarkdownLinter_LineLength(t *testing.T) {
 	linter := NewMarkdownLinter()
 
@@ -2,13 +2,18 @@ package linters
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"runtime"
 	"sync"
+	"time"
 )
 
 // ParallelExecutor runs multiple linters concurrently for improved performance
 type ParallelExecutor struct {
 	maxWorkers int
+	// timeouts bound each run of a linter, keyed by linter name
+	timeouts map[string]time.Duration
 }
 
 // NewParallelExecutor creates a new parallel executor with the specified number of workers
@@ -22,6 +27,13 @@ func NewParallelExecutor(maxWorkers int) *ParallelExecutor {
 	}
 }
 
+// SetLinterTimeouts sets how long each linter, keyed by name, may run on one
+// file. A linter that runs out of time reports a timeout issue, so one slow
+// linter doesn't fail the others.
+func (pe *ParallelExecutor) SetLinterTimeouts(timeouts map[string]time.Duration) {
+	pe.timeouts = timeouts
+}
+
 // LintTask represents a single linting task
 type LintTask struct {
 	Linter   Linter
@@ -44,13 +56,7 @@ func (pe *ParallelExecutor) ExecuteTasks(ctx context.Context, tasks []LintTask)
 
 	// For single task, run directly without goroutines
 	if len(tasks) == 1 {
-		task := tasks[0]
-		result, err := task.Linter.Lint(ctx, task.FilePath, task.Content)
-		return []LintTaskResult{{
-			LinterName: task.Linter.Name(),
-			Result:     result,
-			Error:      err,
-		}}
+		return []LintTaskResult{pe.runTask(ctx, tasks[0])}
 	}
 
 	// Create channels for task distribution and result collection
@@ -84,12 +90,7 @@ func (pe *ParallelExecutor) ExecuteTasks(ctx context.Context, tasks []LintTask)
 				}
 
 				// Execute linting task
-				result, err := task.Linter.Lint(ctx, task.FilePath, task.Content)
-				resultChan <- LintTaskResult{
-					LinterName: task.Linter.Name(),
-					Result:     result,
-					Error:      err,
-				}
+				resultChan <- pe.runTask(ctx, task)
 			}
 		}()
 	}
@@ -115,6 +116,40 @@ func (pe *ParallelExecutor) ExecuteTasks(ctx context.Context, tasks []LintTask)
 	return results
 }
 
+// runTask runs one task within its linter's timeout
+func (pe *ParallelExecutor) runTask(ctx context.Context, task LintTask) LintTaskResult {
+	name := task.Linter.Name()
+	timeout := pe.timeouts[name]
+	if timeout <= 0 {
+		result, err := task.Linter.Lint(ctx, task.FilePath, task.Content)
+		return LintTaskResult{LinterName: name, Result: result, Error: err}
+	}
+
+	taskCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	result, err := task.Linter.Lint(taskCtx, task.FilePath, task.Content)
+	// Only the linter's own deadline becomes an issue; the hook's is an error
+	if err == nil || ctx.Err() != nil || !errors.Is(taskCtx.Err(), context.DeadlineExceeded) {
+		return LintTaskResult{LinterName: name, Result: result, Error: err}
+	}
+
+	// Issues found before the deadline are kept
+	timedOut := &LintResult{Success: true, Partial: true}
+	if result != nil {
+		timedOut.Issues = append(timedOut.Issues, result.Issues...)
+		timedOut.Success = result.Success
+	}
+	timedOut.Issues = append(timedOut.Issues, Issue{
+		File:     task.FilePath,
+		Line:     1,
+		Column:   1,
+		Severity: "warning",
+		Message:  fmt.Sprintf("%s linter timed out after %s; its results are incomplete", name, timeout),
+		Rule:     "timeout",
+	})
+	return LintTaskResult{LinterName: name, Result: timedOut}
+}
+
 // ExecuteLinters runs multiple linters on a single file in parallel
 func (pe *ParallelExecutor) ExecuteLinters(ctx context.Context, linters []Linter, filePath string, content []byte) []LintTaskResult {
 	tasks := make([]LintTask, 0, len(linters))
@@ -130,8 +165,51 @@ func (pe *ParallelExecutor) ExecuteLinters(ctx context.Context, linters []Linter
 	return pe.ExecuteTasks(ctx, tasks)
 }
 
+// SeverityPolicy adjusts the severity of warnings when results are aggregated
+type SeverityPolicy struct {
+	// Strict reports warnings as errors, so they fail the result
+	Strict bool
+	// WarningsAsInfo reports warnings as info. Strict takes precedence.
+	WarningsAsInfo bool
+	// ToolSeverities override the built-in severity mapping of external tools,
+	// keyed by tool name and the tool's severity label
+	ToolSeverities map[string]SeverityMap
+}
+
+// Apply returns severity adjusted by the policy
+func (p SeverityPolicy) Apply(severity string) string {
+	if severity != "warning" {
+		return severity
+	}
+	switch {
+	case p.Strict:
+		return "error"
+	case p.WarningsAsInfo:
+		return "info"
+	}
+	return severity
+}
+
+// ApplyIssue returns the severity of issue adjusted by the policy, after mapping
+// the tool's own severity label through ToolSeverities
+func (p SeverityPolicy) ApplyIssue(issue Issue) string {
+	severity := issue.Severity
+	if issue.Tool != "" {
+		if mapped, ok := p.ToolSeverities[issue.Tool][issue.ToolSeverity]; ok {
+			severity = mapped
+		}
+	}
+	return p.Apply(severity)
+}
+
 // AggregateResults combines multiple lint results into a single result
 func AggregateResults(results []LintTaskResult) (*LintResult, []error) {
+	return AggregateResultsWithPolicy(results, SeverityPolicy{})
+}
+
+// AggregateResultsWithPolicy combines multiple lint results into a single result,
+// adjusting warning severities by policy. Linters' own results are left unchanged.
+func AggregateResultsWithPolicy(results []LintTaskResult, policy SeverityPolicy) (*LintResult, []error) {
 	aggregated := &LintResult{
 		Success: true,
 		Issues:  []Issue{},
@@ -147,12 +225,24 @@ func AggregateResults(results []LintTaskResult) (*LintResult, []error) {
 
 		if taskResult.Result != nil {
 			// Merge issues
-			aggregated.Issues = append(aggregated.Issues, taskResult.Result.Issues...)
+			for _, issue := range taskResult.Result.Issues {
+				// Warnings made errors fail the result like the linter's own errors
+				if severity := policy.ApplyIssue(issue); severity != issue.Severity {
+					if severity == "error" {
+						aggregated.Success = false
+					}
+					issue.Severity = severity
+				}
+				aggregated.Issues = append(aggregated.Issues, issue)
+			}
 
 			// Update success status
 			if !taskResult.Result.Success {
 				aggregated.Success = false
 			}
+			if taskResult.Result.Partial {
+				aggregated.Partial = true
+			}
 
 			// Keep first non-nil formatted content
 			if aggregated.Formatted == nil && taskResult.Result.Formatted != nil {
@@ -3,6 +3,7 @@ package linters
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -318,6 +319,40 @@ func TestParallelExecutor_ContextCancellation(t *testing.T) {
 	close(workChan)
 }
 
+func TestParallelExecutor_LinterTimeout(t *testing.T) {
+	workChan := make(chan struct{})
+	defer close(workChan)
+	slowLinter := &MockLinter{name: "slow", workChan: workChan}
+	fastLinter := &MockLinter{
+		name:       "fast",
+		lintResult: &LintResult{Success: true, Issues: []Issue{{Message: "fast issue"}}},
+	}
+
+	executor := NewParallelExecutor(2)
+	executor.SetLinterTimeouts(map[string]time.Duration{"slow": 10 * time.Millisecond})
+	results := executor.ExecuteLinters(context.Background(), []Linter{slowLinter, fastLinter}, "test.go", []byte("test"))
+
+	aggregated, errs := AggregateResults(results)
+	if len(errs) != 0 {
+		t.Fatalf("a linter timeout failed the run: %v", errs)
+	}
+	if !aggregated.Success || !aggregated.Partial {
+		t.Errorf("success = %v, partial = %v, want a successful partial result", aggregated.Success, aggregated.Partial)
+	}
+	var timeouts int
+	for _, issue := range aggregated.Issues {
+		if issue.Rule == "timeout" {
+			timeouts++
+			if issue.Severity != "warning" || !strings.Contains(issue.Message, "slow linter timed out after 10ms") {
+				t.Errorf("timeout issue = %+v", issue)
+			}
+		}
+	}
+	if timeouts != 1 || len(aggregated.Issues) != 2 {
+		t.Errorf("issues = %+v, want the fast linter's issue and one timeout", aggregated.Issues)
+	}
+}
+
 func TestAggregateResults(t *testing.T) {
 	results := []LintTaskResult{
 		{
@@ -377,6 +412,88 @@ func TestAggregateResults(t *testing.T) {
 	}
 }
 
+func TestAggregateResultsWithPolicy(t *testing.T) {
+	results := []LintTaskResult{
+		{
+			LinterName: "linter1",
+			Result: &LintResult{
+				Success: true,
+				Issues: []Issue{
+					{Severity: "warning", Message: "warning1"},
+					{Severity: "info", Message: "info1"},
+				},
+			},
+		},
+		{
+			LinterName: "linter2",
+			Result: &LintResult{
+				Success: false,
+				Issues:  []Issue{{Severity: "error", Message: "error1"}},
+			},
+		},
+	}
+	toolResults := []LintTaskResult{
+		{
+			LinterName: "javascript",
+			Result: &LintResult{
+				Success: true,
+				Issues: []Issue{
+					ToolIssue("eslint", "1", Issue{Message: "no-unused-vars"}),
+					ToolIssue("biome", "information", Issue{Message: "useConst"}),
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		policy      SeverityPolicy
+		results     []LintTaskResult
+		severities  string
+		wantSuccess bool
+	}{
+		{name: "default", results: results[:1], severities: "warning,info", wantSuccess: true},
+		{name: "strict", policy: SeverityPolicy{Strict: true}, results: results[:1], severities: "error,info", wantSuccess: false},
+		{name: "warnings as info", policy: SeverityPolicy{WarningsAsInfo: true}, results: results, severities: "info,info,error", wantSuccess: false},
+		{name: "strict wins", policy: SeverityPolicy{Strict: true, WarningsAsInfo: true}, results: results[:1], severities: "error,info", wantSuccess: false},
+		{name: "tool defaults", results: toolResults, severities: "warning,info", wantSuccess: true},
+		{
+			name:        "tool override",
+			policy:      SeverityPolicy{ToolSeverities: map[string]SeverityMap{"eslint": {"1": "error"}}},
+			results:     toolResults,
+			severities:  "error,info",
+			wantSuccess: false,
+		},
+		{
+			name:        "tool override then strict",
+			policy:      SeverityPolicy{Strict: true, ToolSeverities: map[string]SeverityMap{"biome": {"information": "warning"}}},
+			results:     toolResults,
+			severities:  "error,error",
+			wantSuccess: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			aggregated, _ := AggregateResultsWithPolicy(tt.results, tt.policy)
+			var severities []string
+			for _, issue := range aggregated.Issues {
+				severities = append(severities, issue.Severity)
+			}
+			if got := strings.Join(severities, ","); got != tt.severities {
+				t.Errorf("severities = %s, want %s", got, tt.severities)
+			}
+			if aggregated.Success != tt.wantSuccess {
+				t.Errorf("Success = %v, want %v", aggregated.Success, tt.wantSuccess)
+			}
+		})
+	}
+
+	// The linters' own results are unchanged
+	if results[0].Result.Issues[0].Severity != "warning" {
+		t.Error("policy changed the linter's result")
+	}
+}
+
 func TestParallelExecutor_DefaultWorkers(t *testing.T) {
 	// Test with 0 workers (should default to NumCPU)
 	executor := NewParallelExecutor(0)
@@ -0,0 +1,111 @@
+package linters
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// RulePartialOutput is reported when a tool stopped mid-output, so only the
+// issues it printed before stopping are reported
+const RulePartialOutput = "partial-output"
+
+// SalvageJSON unmarshals output into v. Output cut short, as by a tool killed
+// at a timeout, is cut back to its last complete array element and closed, so
+// the elements printed before the interruption are kept; partial is then true.
+// The original error is returned if nothing can be salvaged.
+func SalvageJSON(output []byte, v any) (partial bool, err error) {
+	err = json.Unmarshal(output, v)
+	if err == nil {
+		return false, nil
+	}
+	repaired, ok := repairJSON(output)
+	if !ok || json.Unmarshal(repaired, v) != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// repairJSON returns the longest prefix of a truncated JSON document that ends
+// at an array boundary, with the containers still open at that point closed
+func repairJSON(output []byte) ([]byte, bool) {
+	var open []byte
+	inString, escaped := false, false
+	cut := -1
+	var closers []byte
+
+	for i, c := range output {
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+			continue
+		}
+		switch c {
+		case '"':
+			inString = true
+			continue
+		case '{', '[':
+			open = append(open, c)
+		case '}', ']':
+			if len(open) == 0 {
+				return nil, false
+			}
+			open = open[:len(open)-1]
+			if len(open) == 0 {
+				// The document is complete, so truncation isn't what broke it
+				return nil, false
+			}
+		default:
+			continue
+		}
+		// Just inside an array, or just after one of its elements, the array
+		// can be closed with the elements read so far
+		if open[len(open)-1] == '[' {
+			cut = i + 1
+			closers = closers[:0]
+			for j := len(open) - 1; j >= 0; j-- {
+				if open[j] == '[' {
+					closers = append(closers, ']')
+				} else {
+					closers = append(closers, '}')
+				}
+			}
+		}
+	}
+	if cut < 0 {
+		return nil, false
+	}
+	return append(output[:cut:cut], closers...), true
+}
+
+// IncompleteLine reports whether JSON lines output ends in a line cut short,
+// which tools printing one JSON value per line leave when they are killed
+func IncompleteLine(output []byte) bool {
+	trimmed := bytes.TrimRight(output, " \t\r")
+	if len(trimmed) == 0 || trimmed[len(trimmed)-1] == '\n' {
+		return false
+	}
+	last := trimmed[bytes.LastIndexByte(trimmed, '\n')+1:]
+	return !json.Valid(last)
+}
+
+// MarkPartial tags the result as partial and adds a warning at the top of
+// filePath saying that tool stopped mid-output, so its issues may be incomplete
+func (r *LintResult) MarkPartial(tool, filePath string) {
+	r.Partial = true
+	r.Issues = append(r.Issues, Issue{
+		File:     filePath,
+		Line:     1,
+		Column:   1,
+		Severity: "warning",
+		Message:  fmt.Sprintf("%s stopped before finishing its output, so only the issues it reported before stopping are shown", tool),
+		Rule:     RulePartialOutput,
+		Tool:     tool,
+	})
+}
@@ -0,0 +1,136 @@
+package linters
+
+import "testing"
+
+func TestSalvageJSON(t *testing.T) {
+	type message struct {
+		Line int    `json:"line"`
+		Text string `json:"text"`
+	}
+	type file struct {
+		Path     string    `json:"path"`
+		Messages []message `json:"messages"`
+	}
+
+	tests := []struct {
+		name        string
+		output      string
+		wantPartial bool
+		wantErr     bool
+		wantFiles   int
+		wantLines   []int
+	}{
+		{
+			name:      "complete",
+			output:    `[{"path":"a.js","messages":[{"line":1,"text":"x"},{"line":2,"text":"y"}]}]`,
+			wantFiles: 1,
+			wantLines: []int{1, 2},
+		},
+		{
+			name:        "cut inside an element",
+			output:      `[{"path":"a.js","messages":[{"line":1,"text":"x"},{"line":2,"te`,
+			wantPartial: true,
+			wantFiles:   1,
+			wantLines:   []int{1},
+		},
+		{
+			name:        "cut inside a string with brackets",
+			output:      `[{"path":"a.js","messages":[{"line":1,"text":"x"},{"line":2,"text":"[{\"`,
+			wantPartial: true,
+			wantFiles:   1,
+			wantLines:   []int{1},
+		},
+		{
+			name:        "cut before any element",
+			output:      `[{"path":"a.js","messages":[`,
+			wantPartial: true,
+			wantFiles:   1,
+		},
+		{
+			name:        "cut inside the first element",
+			output:      `[{"path":"a.`,
+			wantPartial: true,
+		},
+		{
+			name:    "cut before any array",
+			output:  `{"files":`,
+			wantErr: true,
+		},
+		{
+			name:    "not JSON",
+			output:  `Error: no config found`,
+			wantErr: true,
+		},
+		{
+			name:    "complete but invalid",
+			output:  `[{"path":"a.js"}] trailing`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var files []file
+			partial, err := SalvageJSON([]byte(tt.output), &files)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SalvageJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if partial != tt.wantPartial {
+				t.Errorf("partial = %v, want %v", partial, tt.wantPartial)
+			}
+			if len(files) != tt.wantFiles {
+				t.Fatalf("files = %+v, want %d", files, tt.wantFiles)
+			}
+			var lines []int
+			for _, f := range files {
+				for _, m := range f.Messages {
+					lines = append(lines, m.Line)
+				}
+			}
+			if len(lines) != len(tt.wantLines) {
+				t.Fatalf("lines = %v, want %v", lines, tt.wantLines)
+			}
+			for i := range lines {
+				if lines[i] != tt.wantLines[i] {
+					t.Errorf("lines = %v, want %v", lines, tt.wantLines)
+				}
+			}
+		})
+	}
+}
+
+func TestIncompleteLine(t *testing.T) {
+	tests := []struct {
+		output string
+		want   bool
+	}{
+		{output: "", want: false},
+		{output: "{\"a\":1}\n{\"b\":2}\n", want: false},
+		{output: "{\"a\":1}\n{\"b\":2}", want: false},
+		{output: "{\"a\":1}\n{\"b\":", want: true},
+	}
+	for _, tt := range tests {
+		if got := IncompleteLine([]byte(tt.output)); got != tt.want {
+			t.Errorf("IncompleteLine(%q) = %v, want %v", tt.output, got, tt.want)
+		}
+	}
+}
+
+func TestLintResult_MarkPartial(t *testing.T) {
+	result := &LintResult{Success: true}
+	result.MarkPartial("eslint", "index.js")
+	if !result.Partial {
+		t.Error("Partial = false, want true")
+	}
+	if len(result.Issues) != 1 || result.Issues[0].Rule != RulePartialOutput || result.Issues[0].Tool != "eslint" {
+		t.Errorf("Issues = %+v, want one partial-output warning from eslint", result.Issues)
+	}
+
+	aggregated, _ := AggregateResults([]LintTaskResult{{LinterName: "javascript", Result: result}})
+	if !aggregated.Partial {
+		t.Error("aggregated Partial = false, want true")
+	}
+}
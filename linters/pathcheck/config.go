@@ -0,0 +1,70 @@
+package pathcheck
+
+// PathConfig holds configuration for the path portability linter
+type PathConfig struct {
+	// DisabledChecks lists check rules to skip, e.g. "case-collision"
+	DisabledChecks []string `json:"disabledChecks,omitempty"`
+	// Severity of reported issues, "warning" (default) reports without blocking
+	Severity *string `json:"severity,omitempty"`
+	// HygieneSeverity is the severity of the repository hygiene checks,
+	// tracked-case-collision, path-too-long and name-too-long. They default to
+	// "error", blocking paths that can't be checked out on macOS or Windows.
+	HygieneSeverity *string `json:"hygieneSeverity,omitempty"`
+	// MaxPathLength limits the length of a new path from the repository root, in
+	// characters, default 260; 0 disables the limit
+	MaxPathLength *int `json:"maxPathLength,omitempty"`
+	// MaxComponentLength limits the length of each new file or directory name,
+	// in bytes, default 255; 0 disables the limit
+	MaxComponentLength *int `json:"maxComponentLength,omitempty"`
+}
+
+// configSchema is the JSON Schema for PathConfig
+const configSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "properties": {
+    "disabledChecks": {
+      "type": "array",
+      "items": {
+        "type": "string"
+      },
+      "description": "Check rules to skip, e.g. \"case-collision\""
+    },
+    "severity": {
+      "type": "string",
+      "enum": [
+        "error",
+        "warning",
+        "info"
+      ],
+      "description": "Severity of reported issues, \"warning\" by default"
+    },
+    "hygieneSeverity": {
+      "type": "string",
+      "enum": [
+        "error",
+        "warning",
+        "info"
+      ],
+      "description": "Severity of the tracked-case-collision, path-too-long and name-too-long checks, \"error\" by default"
+    },
+    "maxPathLength": {
+      "type": "integer",
+      "minimum": 0,
+      "description": "Maximum length of a new path from the repository root in characters, 260 by default; 0 disables the limit"
+    },
+    "maxComponentLength": {
+      "type": "integer",
+      "minimum": 0,
+      "description": "Maximum length of a new file or directory name in bytes, 255 by default; 0 disables the limit"
+    }
+  },
+  "additionalProperties": false
+}`
+
+// DefaultPathConfig returns the default configuration for path checks
+func DefaultPathConfig() *PathConfig {
+	severity := "warning"
+	hygieneSeverity := "error"
+	return &PathConfig{Severity: &severity, HygieneSeverity: &hygieneSeverity}
+}
@@ -0,0 +1,153 @@
+package pathcheck
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+// Default path length limits. Windows fails checkouts whose paths reach 260
+// characters unless long paths are enabled, and most filesystems limit a name
+// to 255 bytes.
+const (
+	DefaultMaxPathLength      = 260
+	DefaultMaxComponentLength = 255
+)
+
+// trackedPaths indexes a repository's tracked paths and their directories by
+// folded name, so a new path can be matched against them case-insensitively
+type trackedPaths struct {
+	// indexModTime is the git index's modification time when the paths were listed
+	indexModTime time.Time
+	folded       map[string]string
+}
+
+// repositoryRoot returns the nearest directory above filePath containing .git,
+// or "" outside a repository
+func repositoryRoot(filePath string) string {
+	dir := filepath.Dir(filepath.Clean(filePath))
+	for {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// checkHygiene reports new path components of filePath that collide with a
+// tracked path case-insensitively, and paths or names longer than the limits.
+// It returns the index in components of the collision it reported, or -1, so
+// the weaker directory check doesn't report it again.
+func (l *PathLinter) checkHygiene(ctx context.Context, filePath string, components []component, config *PathConfig, report func(rule, message string)) int {
+	if len(components) == 0 {
+		return -1
+	}
+
+	maxComponent := DefaultMaxComponentLength
+	if config.MaxComponentLength != nil {
+		maxComponent = *config.MaxComponentLength
+	}
+	for _, c := range components {
+		if maxComponent > 0 && len(c.name) > maxComponent {
+			report(RuleNameTooLong, fmt.Sprintf("%q is %d bytes long, over the %d byte limit; shorten it", c.name, len(c.name), maxComponent))
+		}
+	}
+
+	root := repositoryRoot(filePath)
+	if root == "" {
+		return -1
+	}
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return -1
+	}
+	rel, err := filepath.Rel(root, absPath)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return -1
+	}
+	rel = filepath.ToSlash(rel)
+
+	maxPath := DefaultMaxPathLength
+	if config.MaxPathLength != nil {
+		maxPath = *config.MaxPathLength
+	}
+	if length := utf8.RuneCountInString(rel); maxPath > 0 && length > maxPath {
+		report(RulePathTooLong, fmt.Sprintf("path is %d characters long from the repository root, over the %d character limit; shorten it", length, maxPath))
+	}
+
+	tracked := l.trackedPaths(ctx, root)
+	if tracked == nil {
+		return -1
+	}
+	// Only the new components can collide: existing ones are already checked out
+	parts := strings.Split(rel, "/")
+	first := len(parts) - len(components)
+	for i := max(first, 0); i < len(parts); i++ {
+		prefix := strings.Join(parts[:i+1], "/")
+		if existing, ok := tracked.folded[foldName(prefix)]; ok && existing != prefix {
+			report(RuleTrackedCaseCollision, fmt.Sprintf("%q differs only by case from tracked %q; checkouts on macOS and Windows can't hold both", prefix, existing))
+			return i - first
+		}
+	}
+	return -1
+}
+
+// trackedPaths returns the tracked paths of the repository at root, listing them
+// again when the git index changes. It returns nil if git can't list them.
+func (l *PathLinter) trackedPaths(ctx context.Context, root string) *trackedPaths {
+	var indexModTime time.Time
+	if info, err := os.Stat(filepath.Join(root, ".git", "index")); err == nil {
+		indexModTime = info.ModTime()
+	}
+
+	l.mu.RLock()
+	cached, ok := l.tracked[root]
+	runner := l.runner
+	l.mu.RUnlock()
+	if ok && cached.indexModTime.Equal(indexModTime) {
+		return cached
+	}
+
+	release, err := runner.Acquire(ctx, "git")
+	if err != nil {
+		return nil
+	}
+	defer release()
+
+	cmd := runner.Command(ctx, l.Name(), "git", "ls-files", "-z")
+	cmd.Dir = root
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	if err := linters.Run(cmd); err != nil {
+		return nil
+	}
+
+	tracked := &trackedPaths{indexModTime: indexModTime, folded: make(map[string]string)}
+	for _, path := range strings.Split(stdout.String(), "\x00") {
+		// Index the file and each of its directories
+		for path != "" && path != "." {
+			folded := foldName(path)
+			if _, exists := tracked.folded[folded]; exists {
+				break
+			}
+			tracked.folded[folded] = path
+			path = filepath.ToSlash(filepath.Dir(path))
+		}
+	}
+
+	l.mu.Lock()
+	l.tracked[root] = tracked
+	l.mu.Unlock()
+	return tracked
+}
@@ -0,0 +1,251 @@
+package pathcheck
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/jrossi/gismo/linters"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Check rules reported by the linter
+const (
+	RuleReservedName         = "reserved-name"
+	RuleTrailingDotOrSpace   = "trailing-dot-or-space"
+	RuleInvalidCharacter     = "invalid-character"
+	RuleUnicodeNormalization = "unicode-normalization"
+	RuleCaseCollision        = "case-collision"
+	RuleTrackedCaseCollision = "tracked-case-collision"
+	RulePathTooLong          = "path-too-long"
+	RuleNameTooLong          = "name-too-long"
+)
+
+// reservedNames are the Windows device names, which can't be used as a file
+// name with any extension
+var reservedNames = map[string]bool{
+	"CON": true, "PRN": true, "AUX": true, "NUL": true,
+	"COM1": true, "COM2": true, "COM3": true, "COM4": true, "COM5": true,
+	"COM6": true, "COM7": true, "COM8": true, "COM9": true,
+	"LPT1": true, "LPT2": true, "LPT3": true, "LPT4": true, "LPT5": true,
+	"LPT6": true, "LPT7": true, "LPT8": true, "LPT9": true,
+}
+
+// invalidCharacters can't appear in Windows file names. A backslash is a path
+// separator there, so a name containing one turns into a directory.
+const invalidCharacters = `<>:"|?*\`
+
+// hygieneRules are the repository hygiene checks, reported with HygieneSeverity
+var hygieneRules = map[string]bool{
+	RuleTrackedCaseCollision: true,
+	RulePathTooLong:          true,
+	RuleNameTooLong:          true,
+}
+
+// PathLinter warns when a write creates a file or directory whose name breaks
+// checkouts on other platforms, and blocks new paths that collide with tracked
+// ones or exceed the length limits. Only path components that don't exist yet
+// are checked, so existing files are never reported.
+type PathLinter struct {
+	mu     sync.RWMutex
+	config *PathConfig
+	// Runs git to list tracked paths
+	runner *linters.CommandRunner
+	// tracked caches each repository's tracked paths, keyed by its root
+	tracked map[string]*trackedPaths
+}
+
+// NewPathLinter creates a new path portability linter with default configuration
+func NewPathLinter() *PathLinter {
+	return NewPathLinterWithConfig(nil)
+}
+
+// NewPathLinterWithConfig creates a new path portability linter with custom configuration
+func NewPathLinterWithConfig(config *PathConfig) *PathLinter {
+	if config == nil {
+		config = DefaultPathConfig()
+	}
+	return &PathLinter{config: config, tracked: make(map[string]*trackedPaths)}
+}
+
+// Name returns the linter name
+func (l *PathLinter) Name() string {
+	return "paths"
+}
+
+// SetCommandRunner sets the runner used to list tracked paths with git
+func (l *PathLinter) SetCommandRunner(runner *linters.CommandRunner) {
+	l.mu.Lock()
+	l.runner = runner
+	l.mu.Unlock()
+}
+
+// Capabilities reports the built-in checks and the external tools used when installed
+func (l *PathLinter) Capabilities() linters.Capabilities {
+	return linters.Capabilities{
+		Embedded: []string{"reserved names", "trailing dots and spaces", "invalid characters", "unicode normalization", "case collisions", "path length"},
+	}
+}
+
+// Rules describes the check rules
+func (l *PathLinter) Rules() map[string]string {
+	return map[string]string{
+		RuleReservedName:         "Windows reserves device names such as CON, NUL and COM1, with any extension, so files named after them can't be checked out there",
+		RuleTrailingDotOrSpace:   "Windows strips trailing dots and spaces from names, so the file can't be checked out under its real name",
+		RuleInvalidCharacter:     "Characters such as : * ? and control characters are invalid in Windows file names",
+		RuleUnicodeNormalization: "Names not in Unicode NFC can be stored differently by macOS, so git sees the file as deleted and re-added",
+		RuleCaseCollision:        "Names differing only by case from an existing file collide on case-insensitive filesystems, such as the macOS and Windows defaults",
+		RuleTrackedCaseCollision: "Paths differing only by case from a tracked path can't both be checked out on macOS or Windows, leaving teammates with a modified working tree they can't fix",
+		RulePathTooLong:          "Paths longer than Windows' 260 character limit fail to check out unless long paths are enabled",
+		RuleNameTooLong:          "Most filesystems limit a file or directory name to 255 bytes",
+	}
+}
+
+// CanHandle returns true for every file
+func (l *PathLinter) CanHandle(filePath string) bool {
+	return true
+}
+
+// SetConfig updates the linter configuration
+func (l *PathLinter) SetConfig(config []byte) error {
+	pathConfig := DefaultPathConfig()
+	if err := json.Unmarshal(config, pathConfig); err != nil {
+		return fmt.Errorf("failed to parse paths config: %w", err)
+	}
+	l.mu.Lock()
+	l.config = pathConfig
+	l.mu.Unlock()
+	return nil
+}
+
+// ConfigSchema returns the JSON Schema for the linter configuration
+func (l *PathLinter) ConfigSchema() json.RawMessage {
+	return json.RawMessage(configSchema)
+}
+
+// Lint reports names in filePath that break on other platforms
+func (l *PathLinter) Lint(ctx context.Context, filePath string, content []byte) (*linters.LintResult, error) {
+	l.mu.RLock()
+	config := l.config
+	l.mu.RUnlock()
+
+	severity := "warning"
+	if config.Severity != nil && *config.Severity != "" {
+		severity = *config.Severity
+	}
+	hygieneSeverity := "error"
+	if config.HygieneSeverity != nil && *config.HygieneSeverity != "" {
+		hygieneSeverity = *config.HygieneSeverity
+	}
+	disabled := make(map[string]bool, len(config.DisabledChecks))
+	for _, rule := range config.DisabledChecks {
+		disabled[rule] = true
+	}
+
+	result := &linters.LintResult{Success: true}
+	report := func(rule, message string) {
+		if disabled[rule] {
+			return
+		}
+		issueSeverity := severity
+		if hygieneRules[rule] {
+			issueSeverity = hygieneSeverity
+		}
+		result.Issues = append(result.Issues, linters.Issue{
+			File:     filePath,
+			Line:     1,
+			Column:   1,
+			Severity: issueSeverity,
+			Message:  message,
+			Rule:     rule,
+		})
+		if issueSeverity == "error" {
+			result.Success = false
+		}
+	}
+
+	components := newComponents(filePath)
+	collided := l.checkHygiene(ctx, filePath, components, config, report)
+	for i, component := range components {
+		name := component.name
+		stem, _, _ := strings.Cut(name, ".")
+		if reservedNames[strings.ToUpper(strings.TrimRight(stem, " "))] {
+			report(RuleReservedName, fmt.Sprintf("%q is a reserved device name on Windows; rename it", name))
+		}
+		if strings.HasSuffix(name, ".") || strings.HasSuffix(name, " ") {
+			report(RuleTrailingDotOrSpace, fmt.Sprintf("%q ends with a dot or space, which Windows strips; rename it", name))
+		}
+		if r, ok := invalidCharacter(name); ok {
+			report(RuleInvalidCharacter, fmt.Sprintf("%q contains %q, which is invalid in Windows file names; rename it", name, r))
+		}
+		if !norm.NFC.IsNormalString(name) {
+			report(RuleUnicodeNormalization, fmt.Sprintf("%q is not in Unicode NFC form; use the composed form %q", name, norm.NFC.String(name)))
+		}
+		if i == collided {
+			continue
+		}
+		for _, existing := range component.collisions {
+			report(RuleCaseCollision, fmt.Sprintf("%q differs only by case from existing %q; they collide on case-insensitive filesystems (macOS, Windows)", name, existing))
+		}
+	}
+	return result, nil
+}
+
+// invalidCharacter returns the first character of name that Windows rejects
+func invalidCharacter(name string) (rune, bool) {
+	for _, r := range name {
+		if r < 0x20 || strings.ContainsRune(invalidCharacters, r) {
+			return r, true
+		}
+	}
+	return 0, false
+}
+
+// component is a path component a write would create
+type component struct {
+	name string
+	// collisions are the existing entries of its directory with the same name
+	// under case folding and Unicode normalization
+	collisions []string
+}
+
+// newComponents returns the components of filePath that don't exist yet,
+// outermost first. The search stops at the first existing directory, whose
+// entries are compared exactly so case-insensitive filesystems don't hide a
+// new name behind an existing one.
+func newComponents(filePath string) []component {
+	var components []component
+	path := filepath.Clean(filePath)
+	for {
+		parent, name := filepath.Dir(path), filepath.Base(path)
+		if parent == path || name == "" {
+			break
+		}
+		entries, err := os.ReadDir(parent)
+		if err != nil {
+			components = append([]component{{name: name}}, components...)
+			path = parent
+			continue
+		}
+		c := component{name: name}
+		for _, entry := range entries {
+			if entry.Name() == name {
+				return components
+			}
+			if foldName(entry.Name()) == foldName(name) {
+				c.collisions = append(c.collisions, entry.Name())
+			}
+		}
+		return append([]component{c}, components...)
+	}
+	return components
+}
+
+// foldName returns name as case-insensitive filesystems compare it
+func foldName(name string) string {
+	return strings.ToLower(norm.NFC.String(name))
+}
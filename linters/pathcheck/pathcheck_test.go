@@ -0,0 +1,152 @@
+package pathcheck
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPathLinter_Lint(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("# Readme\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "con.ts"), []byte("export {}\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		path string
+		want []string
+	}{
+		{"portable name", "main.go", nil},
+		{"reserved name with extension", "aux.ts", []string{RuleReservedName}},
+		{"reserved name in new directory", "nul/main.go", []string{RuleReservedName}},
+		{"reserved name prefix is fine", "console.ts", nil},
+		{"trailing dot", "notes.", []string{RuleTrailingDotOrSpace}},
+		{"trailing space", "draft ", []string{RuleTrailingDotOrSpace}},
+		{"colon", "12:30.log", []string{RuleInvalidCharacter}},
+		{"decomposed name", "cafe\u0301.md", []string{RuleUnicodeNormalization}},
+		{"case collision", "Readme.md", []string{RuleCaseCollision}},
+		{"existing file is not reported", "con.ts", nil},
+	}
+	linter := NewPathLinter()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := linter.Lint(context.Background(), filepath.Join(dir, tt.path), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var rules []string
+			for _, issue := range result.Issues {
+				rules = append(rules, issue.Rule)
+				if issue.Severity != "warning" {
+					t.Errorf("severity = %q, want warning", issue.Severity)
+				}
+			}
+			if strings.Join(rules, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("rules = %v, want %v", rules, tt.want)
+			}
+			if !result.Success {
+				t.Error("warnings must not fail the result")
+			}
+		})
+	}
+}
+
+func TestPathLinter_SetConfig(t *testing.T) {
+	linter := NewPathLinter()
+	if err := linter.SetConfig([]byte(`{"disabledChecks": ["trailing-dot-or-space"], "severity": "error"}`)); err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	result, err := linter.Lint(context.Background(), filepath.Join(dir, "prn.txt."), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Issues) != 1 || result.Issues[0].Rule != RuleReservedName || result.Success {
+		t.Errorf("result = %+v, want a blocking reserved-name issue only", result)
+	}
+}
+
+// gitRepository creates a repository tracking files, skipping the test
+// without git
+func gitRepository(t *testing.T, files ...string) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	for _, file := range files {
+		path := filepath.Join(dir, file)
+		if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, args := range [][]string{{"init", "-q"}, append([]string{"add"}, files...)} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	return dir
+}
+
+func TestPathLinter_Hygiene(t *testing.T) {
+	dir := gitRepository(t, "docs/guide.md", "Makefile")
+	// An untracked directory only collides on disk
+	if err := os.Mkdir(filepath.Join(dir, "build"), 0750); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		want     []string
+		blocking bool
+	}{
+		{"new file in tracked directory", "docs/api.md", nil, false},
+		{"directory collides with tracked directory", "Docs/api.md", []string{RuleTrackedCaseCollision}, true},
+		{"file collides with tracked file", "makefile", []string{RuleTrackedCaseCollision}, true},
+		{"untracked collision only warns", "Build/out.txt", []string{RuleCaseCollision}, false},
+		{"path too long", "docs/" + strings.Repeat("a", 300) + ".md", []string{RuleNameTooLong, RulePathTooLong}, true},
+	}
+	linter := NewPathLinter()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := linter.Lint(context.Background(), filepath.Join(dir, tt.path), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var rules []string
+			for _, issue := range result.Issues {
+				rules = append(rules, issue.Rule)
+			}
+			if strings.Join(rules, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("rules = %v, want %v", rules, tt.want)
+			}
+			if result.Success == tt.blocking {
+				t.Errorf("success = %v, want blocking %v", result.Success, tt.blocking)
+			}
+		})
+	}
+
+	if err := linter.SetConfig([]byte(`{"maxPathLength": 20, "maxComponentLength": 0, "hygieneSeverity": "warning"}`)); err != nil {
+		t.Fatal(err)
+	}
+	result, err := linter.Lint(context.Background(), filepath.Join(dir, "docs", "installation-guide.md"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Issues) != 1 || result.Issues[0].Rule != RulePathTooLong || !result.Success {
+		t.Errorf("configured limit: result = %+v, want a path-too-long warning", result)
+	}
+}
@@ -0,0 +1,70 @@
+package linters
+
+import (
+	"sort"
+	"unicode/utf8"
+)
+
+// Positions maps byte offsets in content to 1-based line and column numbers.
+// Columns count runes rather than bytes, so a multi-byte character is one column
+// as editors and SARIF consumers expect.
+type Positions struct {
+	content []byte
+	// lineStarts holds the byte offset of each line's first byte
+	lineStarts []int
+}
+
+// NewPositions indexes the line starts of content
+func NewPositions(content []byte) *Positions {
+	lineStarts := []int{0}
+	for i, c := range content {
+		if c == '\n' {
+			lineStarts = append(lineStarts, i+1)
+		}
+	}
+	return &Positions{content: content, lineStarts: lineStarts}
+}
+
+// Position returns the line and rune column of a byte offset. Offsets past the
+// end of content map to the end.
+func (p *Positions) Position(offset int) (line, column int) {
+	offset = max(0, min(offset, len(p.content)))
+	// The last line starting at or before offset
+	index := sort.SearchInts(p.lineStarts, offset+1) - 1
+	return index + 1, utf8.RuneCount(p.content[p.lineStarts[index]:offset]) + 1
+}
+
+// Offset returns the byte offset of a line and rune column, clamped to the line
+func (p *Positions) Offset(line, column int) int {
+	if line < 1 {
+		return 0
+	}
+	if line > len(p.lineStarts) {
+		return len(p.content)
+	}
+	offset := p.lineStarts[line-1]
+	for ; column > 1 && offset < len(p.content) && p.content[offset] != '\n'; column-- {
+		_, size := utf8.DecodeRune(p.content[offset:])
+		offset += size
+	}
+	return offset
+}
+
+// RuneColumn converts a 1-based byte column on line, as reported by Go tools and
+// most parsers, to a rune column
+func (p *Positions) RuneColumn(line, byteColumn int) int {
+	if line < 1 || line > len(p.lineStarts) || byteColumn < 1 {
+		return byteColumn
+	}
+	start, end := p.lineStarts[line-1], len(p.content)
+	if line < len(p.lineStarts) {
+		end = p.lineStarts[line] - 1
+	}
+	return utf8.RuneCount(p.content[start:min(start+byteColumn-1, end)]) + 1
+}
+
+// Column returns the 1-based rune column of a byte offset in a single line
+func Column(line string, offset int) int {
+	offset = max(0, min(offset, len(line)))
+	return utf8.RuneCountInString(line[:offset]) + 1
+}
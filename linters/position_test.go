@@ -0,0 +1,67 @@
+package linters
+
+import "testing"
+
+func TestPositions(t *testing.T) {
+	content := []byte("ab\nçé x\n\nlast")
+	positions := NewPositions(content)
+
+	tests := []struct {
+		offset int
+		line   int
+		column int
+	}{
+		{offset: 0, line: 1, column: 1},
+		{offset: 2, line: 1, column: 3},
+		{offset: 3, line: 2, column: 1},
+		// ç and é are two bytes each
+		{offset: 7, line: 2, column: 3},
+		{offset: 8, line: 2, column: 4},
+		{offset: 11, line: 4, column: 1},
+		{offset: 100, line: 4, column: 5},
+		{offset: -1, line: 1, column: 1},
+	}
+	for _, tt := range tests {
+		line, column := positions.Position(tt.offset)
+		if line != tt.line || column != tt.column {
+			t.Errorf("Position(%d) = %d:%d, want %d:%d", tt.offset, line, column, tt.line, tt.column)
+		}
+		if tt.offset >= 0 && tt.offset <= len(content) {
+			if got := positions.Offset(tt.line, tt.column); got != tt.offset {
+				t.Errorf("Offset(%d, %d) = %d, want %d", tt.line, tt.column, got, tt.offset)
+			}
+		}
+	}
+}
+
+func TestPositions_RuneColumn(t *testing.T) {
+	positions := NewPositions([]byte("x := \"héllo\" + y\nz\n"))
+
+	tests := []struct {
+		line       int
+		byteColumn int
+		want       int
+	}{
+		{line: 1, byteColumn: 1, want: 1},
+		{line: 1, byteColumn: 6, want: 6},
+		// Past the two-byte é
+		{line: 1, byteColumn: 16, want: 15},
+		// Clamped to the end of the line
+		{line: 2, byteColumn: 10, want: 2},
+		{line: 9, byteColumn: 3, want: 3},
+	}
+	for _, tt := range tests {
+		if got := positions.RuneColumn(tt.line, tt.byteColumn); got != tt.want {
+			t.Errorf("RuneColumn(%d, %d) = %d, want %d", tt.line, tt.byteColumn, got, tt.want)
+		}
+	}
+}
+
+func TestColumn(t *testing.T) {
+	if got := Column("日本 x", len("日本 ")); got != 4 {
+		t.Errorf("Column() = %d, want 4", got)
+	}
+	if got := Column("ab", 10); got != 3 {
+		t.Errorf("Column() past the end = %d, want 3", got)
+	}
+}
@@ -1,8 +1,9 @@
 package protobuf
 
 import (
-	"encoding/json"
 	"time"
+
+	"github.com/jrossi/gismo/types"
 )
 
 // ProtobufConfig represents protobuf linter specific configuration
@@ -30,40 +31,116 @@ type ProtobufConfig struct {
 	// MaxFileSize is the maximum file size in bytes to lint
 	MaxFileSize *int64 `json:"maxFileSize,omitempty"`
 	// TestTimeout is the timeout for running tests
-	TestTimeout *Duration `json:"testTimeout,omitempty"`
+	TestTimeout *types.Duration `json:"testTimeout,omitempty"`
+	// CheckGenerated warns when generated stubs are stale after a .proto change
+	CheckGenerated *bool `json:"checkGenerated,omitempty"`
+	// GenerateTemplate is the buf generate template, default buf.gen.yaml in the workspace root
+	GenerateTemplate *string `json:"generateTemplate,omitempty"`
+	// GenerateTimeout is the timeout for buf generate
+	GenerateTimeout *types.Duration `json:"generateTimeout,omitempty"`
 	// Verbose enables verbose output
 	Verbose bool `json:"verbose,omitempty"`
 }
 
-// Duration is a wrapper around time.Duration for JSON unmarshaling
-type Duration struct {
-	time.Duration
-}
+// configSchema is the JSON Schema for ProtobufConfig
+const configSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "properties": {
+    "preferredTools": {
+      "type": "array",
+      "items": {
+        "type": "string"
+      },
+      "description": "Tools to try in order of preference"
+    },
+    "forceTool": {
+      "type": "string",
+      "description": "Use this tool and skip discovery"
+    },
+    "bufPath": {
+      "type": "string",
+      "description": "Path to the buf binary"
+    },
+    "protocPath": {
+      "type": "string",
+      "description": "Path to the protoc binary"
+    },
+    "protolintPath": {
+      "type": "string",
+      "description": "Path to the protolint binary"
+    },
+    "bufConfigPath": {
+      "type": "string",
+      "description": "Path to buf.yaml"
+    },
+    "bufWorkPath": {
+      "type": "string",
+      "description": "Path to buf.work.yaml"
+    },
+    "disabledChecks": {
+      "type": "array",
+      "items": {
+        "type": "string"
+      },
+      "description": "buf lint checks to skip"
+    },
+    "categories": {
+      "type": "array",
+      "items": {
+        "type": "string"
+      },
+      "description": "buf lint categories to check"
+    },
+    "protolintConfig": {
+      "type": "string",
+      "description": "Path to .protolint.yaml"
+    },
+    "maxFileSize": {
+      "type": "integer",
+      "minimum": 0,
+      "description": "Maximum file size in bytes to lint"
+    },
+    "testTimeout": {
+      "type": [
+        "string",
+        "number"
+      ],
+      "description": "Timeout for running tools, e.g. \"1m\""
+    },
+    "checkGenerated": {
+      "type": "boolean",
+      "description": "Warn when generated stubs are stale after a .proto change"
+    },
+    "generateTemplate": {
+      "type": "string",
+      "description": "buf generate template, default buf.gen.yaml in the workspace root"
+    },
+    "generateTimeout": {
+      "type": [
+        "string",
+        "number"
+      ],
+      "description": "Timeout for buf generate, e.g. \"2m\""
+    },
+    "verbose": {
+      "type": "boolean",
+      "description": "Enable verbose output"
+    }
+  },
+  "additionalProperties": false
+}`
 
-// UnmarshalJSON implements json.Unmarshaler for Duration
-func (d *Duration) UnmarshalJSON(b []byte) error {
-	var s string
-	if err := json.Unmarshal(b, &s); err != nil {
-		return err
-	}
-	duration, err := time.ParseDuration(s)
-	if err != nil {
-		return err
-	}
-	d.Duration = duration
-	return nil
-}
-
-// MarshalJSON implements json.Marshaler for Duration
-func (d Duration) MarshalJSON() ([]byte, error) {
-	return json.Marshal(d.Duration.String())
-}
+// Duration is kept for compatibility.
+//
+// Deprecated: use types.Duration.
+type Duration = types.Duration
 
 // DefaultProtobufConfig returns the default configuration for Protobuf linting
 func DefaultProtobufConfig() *ProtobufConfig {
 	return &ProtobufConfig{
 		PreferredTools: []string{"buf", "protolint", "protoc"},
-		TestTimeout:    &Duration{Duration: 2 * time.Minute},
+		TestTimeout:    types.NewDuration(2 * time.Minute),
 		MaxFileSize:    intPtr(10 * 1024 * 1024), // 10MB
 		Verbose:        false,
 	}
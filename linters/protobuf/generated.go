@@ -0,0 +1,176 @@
+package protobuf
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+// defaultGenerateTimeout bounds buf generate when no timeout is configured
+const defaultGenerateTimeout = 2 * time.Minute
+
+// stubSuffixes are the file name suffixes protoc plugins commonly generate next to
+// or from a .proto file, replacing the .proto extension
+var stubSuffixes = []string{
+	".pb.go", "_grpc.pb.go", ".pb.gw.go", "_vtproto.pb.go",
+	"_pb.js", "_pb.d.ts", "_pb.ts", "_grpc_pb.js", "_grpc_pb.d.ts", "_connect.ts", "_connectweb.ts",
+	"_pb2.py", "_pb2.pyi", "_pb2_grpc.py",
+}
+
+// checkGeneratedCode warns when the generated stubs for a changed .proto file are
+// stale. With buf and a buf.gen.yaml template it regenerates into a copy of the
+// workspace and compares the output; otherwise it compares stub files found next
+// to the .proto against the change.
+func (l *ProtobufLinter) checkGeneratedCode(ctx context.Context, filePath string, content []byte) []linters.Issue {
+	l.mu.RLock()
+	enabled := l.config.CheckGenerated != nil && *l.config.CheckGenerated
+	l.mu.RUnlock()
+	if !enabled {
+		return nil
+	}
+
+	l.findProtoTools()
+	if l.toolPaths.hasBuf {
+		if workspaceInfo, err := l.FindProtoWorkspace(filePath); err == nil {
+			if template := l.generateTemplate(workspaceInfo); template != "" {
+				return l.checkBufGenerate(ctx, filePath, content, workspaceInfo, template)
+			}
+		}
+	}
+	return l.checkStubFiles(filePath, content)
+}
+
+// generateTemplate returns the buf generate template for a workspace, if any
+func (l *ProtobufLinter) generateTemplate(workspaceInfo *ProtoWorkspaceInfo) string {
+	if l.config.GenerateTemplate != nil && *l.config.GenerateTemplate != "" {
+		template := *l.config.GenerateTemplate
+		if !filepath.IsAbs(template) {
+			template = filepath.Join(workspaceInfo.Root, template)
+		}
+		return template
+	}
+	template := filepath.Join(workspaceInfo.Root, "buf.gen.yaml")
+	if _, err := l.fs.Stat(template); err == nil {
+		return template
+	}
+	return ""
+}
+
+// checkBufGenerate runs buf generate in a copy of the workspace with the pending
+// content and reports generated files that differ from the ones on disk
+func (l *ProtobufLinter) checkBufGenerate(ctx context.Context, filePath string, content []byte, workspaceInfo *ProtoWorkspaceInfo, template string) []linters.Issue {
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return nil
+	}
+
+	// Generators write into the workspace, so they run in a copy rather than a symlink shadow
+	ws, err := linters.NewShadowCopy(workspaceInfo.Root, map[string][]byte{absPath: content})
+	if err != nil {
+		return nil
+	}
+	defer func() { _ = ws.Close() }()
+
+	timeout := defaultGenerateTimeout
+	if l.config.GenerateTimeout != nil {
+		timeout = l.config.GenerateTimeout.Duration
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	started := time.Now()
+	release, err := l.runner.Acquire(ctx, l.toolPaths.buf)
+	if err != nil {
+		return nil
+	}
+	defer release()
+
+	cmd := l.runner.Command(ctx, l.Name(), l.toolPaths.buf, "generate", "--template", ws.Path(template))
+	cmd.Dir = ws.Root
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	if err := linters.Run(cmd); err != nil {
+		return []linters.Issue{{
+			File:     filePath,
+			Line:     1,
+			Column:   1,
+			Severity: "warning",
+			Message:  fmt.Sprintf("buf generate failed, could not check generated code: %v: %s", err, strings.TrimSpace(output.String())),
+			Rule:     "generated-code",
+		}}
+	}
+
+	stale, err := ws.ChangedSince(started, absPath)
+	if err != nil || len(stale) == 0 {
+		return nil
+	}
+	return []linters.Issue{{
+		File:     filePath,
+		Line:     1,
+		Column:   1,
+		Severity: "warning",
+		Message:  fmt.Sprintf("Generated code is stale, run `buf generate` to update: %s", strings.Join(stale, ", ")),
+		Rule:     "generated-code",
+	}}
+}
+
+// checkStubFiles compares generated stubs next to the .proto with the change: stubs
+// are stale if the .proto content is about to change or was modified after them
+func (l *ProtobufLinter) checkStubFiles(filePath string, content []byte) []linters.Issue {
+	stubs := l.findStubFiles(filePath)
+	if len(stubs) == 0 {
+		return nil
+	}
+
+	onDisk, err := l.fs.ReadFile(filePath)
+	pendingChange := err != nil || !bytes.Equal(onDisk, content)
+
+	var protoModified time.Time
+	if info, err := l.fs.Stat(filePath); err == nil {
+		protoModified = info.ModTime()
+	}
+
+	var stale []string
+	for _, stub := range stubs {
+		info, err := l.fs.Stat(stub)
+		if err != nil {
+			continue
+		}
+		if pendingChange || info.ModTime().Before(protoModified) {
+			stale = append(stale, filepath.Base(stub))
+		}
+	}
+	if len(stale) == 0 {
+		return nil
+	}
+
+	return []linters.Issue{{
+		File:     filePath,
+		Line:     1,
+		Column:   1,
+		Severity: "warning",
+		Message:  fmt.Sprintf("Generated stubs are older than %s, regenerate them after this change: %s", filepath.Base(filePath), strings.Join(stale, ", ")),
+		Rule:     "generated-code",
+	}}
+}
+
+// findStubFiles returns generated files next to filePath named after it
+func (l *ProtobufLinter) findStubFiles(filePath string) []string {
+	base := strings.TrimSuffix(filePath, ".proto")
+	var stubs []string
+	for _, suffix := range stubSuffixes {
+		candidate := base + suffix
+		if _, err := l.fs.Stat(candidate); err == nil {
+			stubs = append(stubs, candidate)
+		}
+	}
+	sort.Strings(stubs)
+	return stubs
+}
@@ -0,0 +1,109 @@
+package protobuf
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProtobufLinter_CheckStubFiles(t *testing.T) {
+	dir := t.TempDir()
+	proto := filepath.Join(dir, "api.proto")
+	stub := filepath.Join(dir, "api.pb.go")
+	for path, content := range map[string]string{proto: "syntax = \"proto3\";\n", stub: "package api\n"} {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	enabled := true
+	config := DefaultProtobufConfig()
+	config.CheckGenerated = &enabled
+	linter := NewProtobufLinterWithConfig(config)
+	// Keep the fallback path even on machines with buf installed
+	linter.toolOnce.Do(func() {})
+
+	older, newer := time.Now().Add(-time.Hour), time.Now()
+	tests := []struct {
+		name      string
+		protoTime time.Time
+		stubTime  time.Time
+		content   string
+		wantStale bool
+	}{
+		{"stubs regenerated after proto", older, newer, "syntax = \"proto3\";\n", false},
+		{"proto modified after stubs", newer, older, "syntax = \"proto3\";\n", true},
+		{"pending proto change", older, newer, "syntax = \"proto3\";\nmessage A {}\n", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := os.Chtimes(proto, tt.protoTime, tt.protoTime); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.Chtimes(stub, tt.stubTime, tt.stubTime); err != nil {
+				t.Fatal(err)
+			}
+
+			issues := linter.checkGeneratedCode(context.Background(), proto, []byte(tt.content))
+			if !tt.wantStale {
+				if len(issues) != 0 {
+					t.Errorf("expected no issues, got %v", issues)
+				}
+				return
+			}
+			if len(issues) != 1 || issues[0].Rule != "generated-code" || !strings.Contains(issues[0].Message, "api.pb.go") {
+				t.Errorf("expected stale api.pb.go warning, got %v", issues)
+			}
+		})
+	}
+
+	if issues := NewProtobufLinter().checkGeneratedCode(context.Background(), proto, []byte("changed")); len(issues) != 0 {
+		t.Errorf("check should be disabled by default, got %v", issues)
+	}
+}
+
+func TestProtobufLinter_CheckBufGenerate(t *testing.T) {
+	dir := t.TempDir()
+	write := func(path, content string, mode os.FileMode) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), mode); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A fake buf that "generates" by copying the proto into gen/
+	fakeBuf := filepath.Join(t.TempDir(), "buf")
+	write(fakeBuf, "#!/bin/sh\nmkdir -p gen && cp api.proto gen/api.pb.txt\n", 0755)
+	write(filepath.Join(dir, "buf.yaml"), "version: v2\n", 0644)
+	write(filepath.Join(dir, "buf.gen.yaml"), "version: v2\n", 0644)
+	write(filepath.Join(dir, "api.proto"), "v1\n", 0644)
+	write(filepath.Join(dir, "gen", "api.pb.txt"), "v1\n", 0644)
+
+	enabled := true
+	config := DefaultProtobufConfig()
+	config.CheckGenerated = &enabled
+	config.BufPath = &fakeBuf
+	linter := NewProtobufLinterWithConfig(config)
+	proto := filepath.Join(dir, "api.proto")
+
+	if issues := linter.checkGeneratedCode(context.Background(), proto, []byte("v1\n")); len(issues) != 0 {
+		t.Errorf("expected up-to-date stubs, got %v", issues)
+	}
+
+	issues := linter.checkGeneratedCode(context.Background(), proto, []byte("v2\n"))
+	if len(issues) != 1 || !strings.Contains(issues[0].Message, "gen/api.pb.txt") {
+		t.Fatalf("expected stale gen/api.pb.txt warning, got %v", issues)
+	}
+
+	// Generation happens in a copy of the workspace
+	if data, _ := os.ReadFile(filepath.Join(dir, "gen", "api.pb.txt")); string(data) != "v1\n" {
+		t.Errorf("generated file modified on disk: %q", data)
+	}
+}
@@ -4,8 +4,8 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
@@ -13,6 +13,8 @@ import (
 	"time"
 
 	"github.com/jrossi/gismo/linters"
+	"github.com/jrossi/gismo/toolcache"
+	"github.com/jrossi/gismo/types"
 )
 
 // ProtobufLinter handles Protocol Buffer file linting using buf, protolint, or protoc
@@ -31,6 +33,10 @@ type ProtobufLinter struct {
 	toolOnce sync.Once
 	mu       sync.RWMutex
 	config   *ProtobufConfig
+	// Filesystem used for project discovery and config lookups
+	fs linters.FileSystem
+	// Runs external tools with the engine's limits, caches and environment
+	runner *linters.CommandRunner
 }
 
 // ProtoWorkspaceInfo contains information about a protobuf workspace
@@ -74,6 +80,8 @@ func NewProtobufLinterWithConfig(config *ProtobufConfig) *ProtobufLinter {
 
 	return &ProtobufLinter{
 		workspaceCache: make(map[string]*ProtoWorkspaceInfo),
+		fs:             linters.OSFileSystem{},
+		runner:         linters.NewCommandRunner(linters.RunnerConfig{}),
 		config:         config,
 	}
 }
@@ -96,7 +104,7 @@ func (l *ProtobufLinter) SetConfig(configData json.RawMessage) error {
 		l.config.PreferredTools = []string{"buf", "protolint", "protoc"}
 	}
 	if l.config.TestTimeout == nil {
-		defaultTimeout := &Duration{Duration: 2 * time.Minute}
+		defaultTimeout := types.NewDuration(2 * time.Minute)
 		l.config.TestTimeout = defaultTimeout
 	}
 	if l.config.MaxFileSize == nil {
@@ -106,11 +114,34 @@ func (l *ProtobufLinter) SetConfig(configData json.RawMessage) error {
 	return nil
 }
 
+// ConfigSchema returns the JSON Schema for the linter configuration
+func (l *ProtobufLinter) ConfigSchema() json.RawMessage {
+	return json.RawMessage(configSchema)
+}
+
 // Name returns the linter name
 func (l *ProtobufLinter) Name() string {
 	return "protobuf"
 }
 
+// SetCommandRunner sets the runner used to start external tools
+func (l *ProtobufLinter) SetCommandRunner(runner *linters.CommandRunner) {
+	l.runner = runner
+}
+
+// Capabilities reports the built-in checks and the external tools used when installed
+func (l *ProtobufLinter) Capabilities() linters.Capabilities {
+	return linters.Capabilities{
+		Embedded: []string{"generated code drift"},
+		Tools:    []string{"buf", "protolint", "protoc"},
+	}
+}
+
+// SetFileSystem sets the filesystem used for project discovery and config lookups
+func (l *ProtobufLinter) SetFileSystem(fsys linters.FileSystem) {
+	l.fs = fsys
+}
+
 // CanHandle returns true for Protocol Buffer files
 func (l *ProtobufLinter) CanHandle(filePath string) bool {
 	return strings.HasSuffix(filePath, ".proto")
@@ -165,14 +196,14 @@ func (l *ProtobufLinter) FindProtoWorkspace(startPath string) (*ProtoWorkspaceIn
 
 	// Walk up the directory tree
 	currentPath := absPath
-	if info, err := os.Stat(currentPath); err == nil && !info.IsDir() {
+	if info, err := l.fs.Stat(currentPath); err == nil && !info.IsDir() {
 		currentPath = filepath.Dir(currentPath)
 	}
 
 	for {
 		// Check for buf.work.yaml first (workspace)
 		bufWorkPath := filepath.Join(currentPath, "buf.work.yaml")
-		if _, err := os.Stat(bufWorkPath); err == nil {
+		if _, err := l.fs.Stat(bufWorkPath); err == nil {
 			workspaceInfo := &ProtoWorkspaceInfo{
 				Root:        currentPath,
 				ConfigPath:  bufWorkPath,
@@ -189,7 +220,7 @@ func (l *ProtobufLinter) FindProtoWorkspace(startPath string) (*ProtoWorkspaceIn
 
 		// Check for buf.yaml
 		bufConfigPath := filepath.Join(currentPath, "buf.yaml")
-		if _, err := os.Stat(bufConfigPath); err == nil {
+		if _, err := l.fs.Stat(bufConfigPath); err == nil {
 			workspaceInfo := &ProtoWorkspaceInfo{
 				Root:        currentPath,
 				ConfigPath:  bufConfigPath,
@@ -214,11 +245,12 @@ func (l *ProtobufLinter) FindProtoWorkspace(startPath string) (*ProtoWorkspaceIn
 	}
 }
 
-// runBuf executes buf lint on the specified file
-func (l *ProtobufLinter) runBuf(ctx context.Context, filePath string) ([]BufMessage, error) {
+// runBuf executes buf lint on the specified file. partial is true if buf
+// stopped mid-line, as when killed at a timeout, so messages may be incomplete.
+func (l *ProtobufLinter) runBuf(ctx context.Context, filePath string) (messages []BufMessage, partial bool, err error) {
 	l.findProtoTools()
 	if !l.toolPaths.hasBuf {
-		return nil, fmt.Errorf("buf not found")
+		return nil, false, fmt.Errorf("buf not found")
 	}
 
 	// Find workspace root for proper context
@@ -248,9 +280,14 @@ func (l *ProtobufLinter) runBuf(ctx context.Context, filePath string) ([]BufMess
 	// Add the file path
 	args = append(args, filePath)
 
+	release, err := l.runner.Acquire(ctx, l.toolPaths.buf)
+	if err != nil {
+		return nil, false, err
+	}
+	defer release()
+
 	// Execute buf
-	// #nosec G204 - toolPaths.buf is validated through findProtoTools()
-	cmd := exec.CommandContext(ctx, l.toolPaths.buf, args...)
+	cmd := l.runner.Command(ctx, l.Name(), l.toolPaths.buf, args...)
 	cmd.Dir = workspaceInfo.Root
 
 	var stdout, stderr bytes.Buffer
@@ -258,27 +295,46 @@ func (l *ProtobufLinter) runBuf(ctx context.Context, filePath string) ([]BufMess
 	cmd.Stderr = &stderr
 
 	// buf returns non-zero exit code when lint issues are found, which is expected
-	err = cmd.Run()
+	err = linters.Run(cmd)
+
+	messages = parseBufOutput(stdout.Bytes())
 
-	// Parse JSON output line by line
+	// Check if the error is due to actual failure (not just lint issues)
+	if err != nil && len(messages) == 0 && stderr.Len() > 0 {
+		if unsupported := l.bufUnsupported(); unsupported != nil {
+			return nil, false, unsupported
+		}
+		return nil, false, fmt.Errorf("buf lint failed: %v\nstderr: %s", err, stderr.String())
+	}
+
+	return messages, linters.IncompleteLine(stdout.Bytes()), nil
+}
+
+// bufUnsupported returns the error for a buf run whose output couldn't be read,
+// if the installed buf is newer than the 1.x versions whose JSON format is known
+func (l *ProtobufLinter) bufUnsupported() *linters.UnsupportedVersionError {
+	version, ok := linters.ParseToolVersion(toolcache.DetectVersion(l.toolPaths.buf))
+	if !ok || version.Major <= 1 {
+		return nil
+	}
+	return &linters.UnsupportedVersionError{Tool: "buf", Version: version, Supported: "1.x"}
+}
+
+// parseBufOutput parses buf's JSON lines output, skipping lines that aren't
+// messages
+func parseBufOutput(output []byte) []BufMessage {
 	var messages []BufMessage
-	for _, line := range strings.Split(stdout.String(), "\n") {
-		if line == "" {
+	for _, line := range bytes.Split(output, []byte("\n")) {
+		if len(bytes.TrimSpace(line)) == 0 {
 			continue
 		}
 
 		var msg BufMessage
-		if err := json.Unmarshal([]byte(line), &msg); err == nil {
+		if err := json.Unmarshal(line, &msg); err == nil {
 			messages = append(messages, msg)
 		}
 	}
-
-	// Check if the error is due to actual failure (not just lint issues)
-	if err != nil && len(messages) == 0 && stderr.Len() > 0 {
-		return nil, fmt.Errorf("buf lint failed: %v\nstderr: %s", err, stderr.String())
-	}
-
-	return messages, nil
+	return messages
 }
 
 // runProtolint executes protolint on the specified file
@@ -298,9 +354,14 @@ func (l *ProtobufLinter) runProtolint(ctx context.Context, filePath string) ([]P
 
 	args = append(args, filePath)
 
+	release, err := l.runner.Acquire(ctx, l.toolPaths.protolint)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	// Execute protolint
-	// #nosec G204 - toolPaths.protolint is validated through findProtoTools()
-	cmd := exec.CommandContext(ctx, l.toolPaths.protolint, args...)
+	cmd := l.runner.Command(ctx, l.Name(), l.toolPaths.protolint, args...)
 	cmd.Dir = filepath.Dir(filePath)
 
 	var stdout, stderr bytes.Buffer
@@ -308,7 +369,7 @@ func (l *ProtobufLinter) runProtolint(ctx context.Context, filePath string) ([]P
 	cmd.Stderr = &stderr
 
 	// protolint returns non-zero exit code when lint issues are found
-	cmdErr := cmd.Run()
+	cmdErr := linters.Run(cmd)
 
 	// Parse JSON output
 	var result struct {
@@ -339,14 +400,19 @@ func (l *ProtobufLinter) runProtoc(ctx context.Context, filePath string) error {
 		filePath,
 	}
 
+	release, err := l.runner.Acquire(ctx, l.toolPaths.protoc)
+	if err != nil {
+		return err
+	}
+	defer release()
+
 	// Execute protoc
-	// #nosec G204 - toolPaths.protoc is validated through findProtoTools()
-	cmd := exec.CommandContext(ctx, l.toolPaths.protoc, args...)
+	cmd := l.runner.Command(ctx, l.Name(), l.toolPaths.protoc, args...)
 
 	var stderr bytes.Buffer
 	cmd.Stderr = &stderr
 
-	err := cmd.Run()
+	err = linters.Run(cmd)
 	if err != nil {
 		return fmt.Errorf("protoc validation failed: %v\nstderr: %s", err, stderr.String())
 	}
@@ -371,8 +437,8 @@ func (l *ProtobufLinter) convertBufMessages(messages []BufMessage, filePath stri
 
 		issue := linters.Issue{
 			File:     filePath,
-			Line:     msg.StartLine,
-			Column:   msg.StartColumn,
+			Line:     max(msg.StartLine, 0),
+			Column:   max(msg.StartColumn, 0),
 			Severity: severity,
 			Message:  msg.Message,
 			Rule:     msg.Type,
@@ -419,6 +485,9 @@ func (l *ProtobufLinter) Lint(ctx context.Context, filePath string, content []by
 		return result, nil // Skip large files
 	}
 
+	// Warn about stale generated stubs before linting the .proto itself
+	result.Issues = append(result.Issues, l.checkGeneratedCode(ctx, filePath, content)...)
+
 	// Determine which tool to use
 	var toolsToTry []string
 	if l.config.ForceTool != nil && *l.config.ForceTool != "" {
@@ -431,9 +500,18 @@ func (l *ProtobufLinter) Lint(ctx context.Context, filePath string, content []by
 	for _, tool := range toolsToTry {
 		switch tool {
 		case "buf":
-			if messages, err := l.runBuf(ctx, filePath); err == nil {
+			messages, partial, err := l.runBuf(ctx, filePath)
+			var unsupported *linters.UnsupportedVersionError
+			if errors.As(err, &unsupported) {
+				// Explain why buf's checks are missing, then try the next tool
+				result.Issues = append(result.Issues, unsupported.Issue(filePath))
+			}
+			if err == nil {
 				issues := l.convertBufMessages(messages, filePath)
 				result.Issues = append(result.Issues, issues...)
+				if partial {
+					result.MarkPartial("buf", filePath)
+				}
 
 				// Check if any issues are errors
 				for _, issue := range issues {
@@ -8,6 +8,8 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/jrossi/gismo/linters"
 )
 
 func TestProtobufLinter_Name(t *testing.T) {
@@ -383,3 +385,18 @@ func TestProtobufLinter_MaxFileSize(t *testing.T) {
 		t.Error("Expected no issues for skipped large file")
 	}
 }
+
+func FuzzParseBufOutput(f *testing.F) {
+	f.Add([]byte(`{"path":"api/v1/user.proto","start_line":5,"start_column":1,"type":"FIELD_LOWER_SNAKE_CASE","message":"Field name should be lower_snake_case."}` + "\n"))
+	f.Add([]byte("{\"type\":\"COMPILE\",\"message\":\"syntax error\",\"start_line\":-1}\nnot json\n\n"))
+	f.Add([]byte(`{"path":"other.proto","start_line":1}`))
+	linter := NewProtobufLinter()
+	filePath := "/proj/api/v1/user.proto"
+	f.Fuzz(func(t *testing.T, output []byte) {
+		for _, issue := range linter.convertBufMessages(parseBufOutput(output), filePath) {
+			if !linters.IsSeverity(issue.Severity) || issue.Line < 0 || issue.Column < 0 || issue.File != filePath {
+				t.Fatalf("invalid issue %+v", issue)
+			}
+		}
+	})
+}
@@ -1,9 +1,9 @@
 package python
 
 import (
-	"encoding/json"
-	"fmt"
 	"time"
+
+	"github.com/jrossi/gismo/types"
 )
 
 // PythonConfig holds configuration for the Python linter
@@ -12,62 +12,143 @@ type PythonConfig struct {
 	RuffArgs      []string `json:"ruffArgs,omitempty"`
 	MaxLineLength *int     `json:"maxLineLength,omitempty"`
 
-	// Type checking via uvx
-	TypeChecker   string   `json:"typeChecker,omitempty"` // e.g., "mypy", "pyright"
+	// Type checking via uvx or an installed checker, off unless a checker is set
+	TypeChecker   string   `json:"typeChecker,omitempty"` // "mypy" or "pyright"
 	TypeCheckArgs []string `json:"typeCheckArgs,omitempty"`
+	// Severity of type errors: "error" (default) blocks the edit, "warning" doesn't
+	TypeCheckSeverity string `json:"typeCheckSeverity,omitempty"`
+
+	// Security scanning with bandit via uvx or an installed bandit, off by
+	// default. High severity findings block the edit.
+	Bandit     bool     `json:"bandit,omitempty"`
+	BanditArgs []string `json:"banditArgs,omitempty"`
+
+	// Dependency files: how requirements must be pinned, "any" (default) for
+	// any version specifier, "exact" for == pins or "off"
+	RequirementPins string `json:"requirementPins,omitempty"`
+	// UVLockCheck runs uv lock --check when uv.lock is written
+	UVLockCheck bool `json:"uvLockCheck,omitempty"`
 
 	// Test runner configuration
-	TestRunner  string    `json:"testRunner,omitempty"` // e.g., "pytest", "unittest"
-	TestArgs    []string  `json:"testArgs,omitempty"`
-	TestTimeout *Duration `json:"testTimeout,omitempty"`
-	RunTests    bool      `json:"runTests,omitempty"`
+	TestRunner  string          `json:"testRunner,omitempty"` // e.g., "pytest", "unittest"
+	TestArgs    []string        `json:"testArgs,omitempty"`
+	TestKeyword string          `json:"testKeyword,omitempty"` // -k expression selecting the tests to run
+	TestTimeout *types.Duration `json:"testTimeout,omitempty"`
+	RunTests    bool            `json:"runTests"` // defaults to true, so false is always written
 }
 
-// Duration wraps time.Duration for JSON marshaling
-type Duration struct {
-	time.Duration
-}
+// configSchema is the JSON Schema for PythonConfig
+const configSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "properties": {
+    "ruffArgs": {
+      "type": "array",
+      "items": {
+        "type": "string"
+      },
+      "description": "Extra arguments for ruff"
+    },
+    "maxLineLength": {
+      "type": "integer",
+      "minimum": 0,
+      "description": "Maximum line length"
+    },
+    "typeChecker": {
+      "type": "string",
+      "enum": [
+        "mypy",
+        "pyright"
+      ],
+      "description": "Type checker to run; type checking is off when unset"
+    },
+    "typeCheckArgs": {
+      "type": "array",
+      "items": {
+        "type": "string"
+      },
+      "description": "Extra arguments for the type checker"
+    },
+    "typeCheckSeverity": {
+      "type": "string",
+      "enum": [
+        "error",
+        "warning"
+      ],
+      "description": "Severity of type errors; \"error\" blocks the edit"
+    },
+    "bandit": {
+      "type": "boolean",
+      "description": "Scan edited files with bandit; high severity findings block the edit"
+    },
+    "banditArgs": {
+      "type": "array",
+      "items": {
+        "type": "string"
+      },
+      "description": "Extra arguments for bandit"
+    },
+    "requirementPins": {
+      "type": "string",
+      "enum": [
+        "any",
+        "exact",
+        "off"
+      ],
+      "description": "How requirements must be pinned: \"any\" version specifier, \"exact\" == pins, or \"off\""
+    },
+    "uvLockCheck": {
+      "type": "boolean",
+      "description": "Run uv lock --check when uv.lock is written"
+    },
+    "testRunner": {
+      "type": "string",
+      "description": "Test runner, e.g. \"pytest\" or \"unittest\""
+    },
+    "testArgs": {
+      "type": "array",
+      "items": {
+        "type": "string"
+      },
+      "description": "Extra arguments for the test runner"
+    },
+    "testKeyword": {
+      "type": "string",
+      "description": "Expression passed with -k to select the tests to run, e.g. \"not slow\""
+    },
+    "testTimeout": {
+      "type": [
+        "string",
+        "number"
+      ],
+      "description": "Timeout for running tests, e.g. \"2m\""
+    },
+    "runTests": {
+      "type": "boolean",
+      "description": "Run tests for edited test files"
+    }
+  },
+  "additionalProperties": false
+}`
+
+// Duration is kept for compatibility.
+//
+// Deprecated: use types.Duration.
+type Duration = types.Duration
 
 // DefaultPythonConfig returns the default configuration for Python linting
 func DefaultPythonConfig() *PythonConfig {
-	defaultTimeout := &Duration{Duration: 2 * time.Minute}
+	defaultTimeout := types.NewDuration(2 * time.Minute)
 	defaultLineLength := 88 // Ruff default
 
 	return &PythonConfig{
-		RuffArgs:      []string{},
-		MaxLineLength: &defaultLineLength,
-		TypeChecker:   "mypy",
-		TypeCheckArgs: []string{"--strict"},
-		TestRunner:    "pytest",
-		TestArgs:      []string{"-v"},
-		TestTimeout:   defaultTimeout,
-		RunTests:      true,
+		RuffArgs:          []string{},
+		MaxLineLength:     &defaultLineLength,
+		TypeCheckSeverity: "error",
+		RequirementPins:   RequirementPinsAny,
+		TestRunner:        "pytest",
+		TestArgs:          []string{"-v"},
+		TestTimeout:       defaultTimeout,
+		RunTests:          true,
 	}
 }
-
-// UnmarshalJSON implements json.Unmarshaler for Duration
-func (d *Duration) UnmarshalJSON(b []byte) error {
-	var v interface{}
-	if err := json.Unmarshal(b, &v); err != nil {
-		return err
-	}
-	switch value := v.(type) {
-	case float64:
-		d.Duration = time.Duration(value)
-		return nil
-	case string:
-		var err error
-		d.Duration, err = time.ParseDuration(value)
-		if err != nil {
-			return err
-		}
-		return nil
-	default:
-		return fmt.Errorf("invalid duration type: %T", v)
-	}
-}
-
-// MarshalJSON implements json.Marshaler for Duration
-func (d Duration) MarshalJSON() ([]byte, error) {
-	return json.Marshal(d.Duration.String())
-}
@@ -0,0 +1,168 @@
+//go:build !noembed
+
+package python
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+// embeddedChecks lists the checks compiled into gismo that run without python3
+var embeddedChecks = []string{"basic syntax"}
+
+// compoundKeywords start statements whose header must end in a colon
+var compoundKeywords = map[string]bool{
+	"def": true, "class": true, "if": true, "elif": true, "else": true, "for": true,
+	"while": true, "try": true, "except": true, "finally": true, "with": true,
+}
+
+// closingBrackets maps each closing bracket to its opening bracket
+var closingBrackets = map[byte]byte{')': '(', ']': '[', '}': '{'}
+
+// openBracket is an unclosed bracket and where it was opened
+type openBracket struct {
+	char   byte
+	line   int
+	offset int
+}
+
+// checkBasicSyntax is the pure-Go syntax check used when python3 is not installed.
+// It doesn't parse Python: it reports unbalanced brackets, unterminated strings and
+// compound statements missing their colon, with the messages python3 would use.
+func checkBasicSyntax(filePath string, content []byte) []linters.Issue {
+	var issues []linters.Issue
+	positions := linters.NewPositions(content)
+	report := func(offset int, message string) {
+		line, column := positions.Position(offset)
+		issues = append(issues, linters.Issue{
+			File:     filePath,
+			Line:     line,
+			Column:   column,
+			Severity: "warning",
+			Message:  message,
+			Rule:     "basic-syntax",
+		})
+	}
+
+	var (
+		stack    []openBracket
+		logical  strings.Builder // code of the current logical line, strings blanked
+		start    int             // offset of the first code of the logical line
+		hasColon bool            // the logical line has a colon outside brackets
+		line     = 1
+	)
+	endLogicalLine := func() {
+		if keyword := leadingKeyword(logical.String()); compoundKeywords[keyword] && !hasColon {
+			// Point at the keyword, past the indentation
+			offset := start
+			for offset < len(content) && (content[offset] == ' ' || content[offset] == '\t') {
+				offset++
+			}
+			report(offset, "expected ':'")
+		}
+		logical.Reset()
+		hasColon = false
+	}
+
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+		switch {
+		case c == '#':
+			for i+1 < len(content) && content[i+1] != '\n' {
+				i++
+			}
+		case c == '\'' || c == '"':
+			quote, quoteLine := i, line
+			if i+2 < len(content) && content[i+1] == c && content[i+2] == c {
+				i += 3
+				for i < len(content) && !(content[i] == c && i+2 < len(content) && content[i+1] == c && content[i+2] == c) {
+					if content[i] == '\\' {
+						i++
+					}
+					if i < len(content) && content[i] == '\n' {
+						line++
+					}
+					i++
+				}
+				if i >= len(content) {
+					report(quote, fmt.Sprintf("unterminated triple-quoted string literal (detected at line %d)", line))
+					return issues
+				}
+				i += 2
+			} else {
+				i++
+				for i < len(content) && content[i] != c && content[i] != '\n' {
+					if content[i] == '\\' && i+1 < len(content) {
+						if content[i+1] == '\n' {
+							line++
+						}
+						i++
+					}
+					i++
+				}
+				if i >= len(content) || content[i] == '\n' {
+					report(quote, fmt.Sprintf("unterminated string literal (detected at line %d)", quoteLine))
+					return issues
+				}
+			}
+			logical.WriteString(`""`)
+		case c == '\\' && i+1 < len(content) && content[i+1] == '\n':
+			// Explicit line continuation
+			i++
+			line++
+		case c == '(' || c == '[' || c == '{':
+			stack = append(stack, openBracket{char: c, line: line, offset: i})
+			logical.WriteByte(c)
+		case closingBrackets[c] != 0:
+			if len(stack) == 0 {
+				report(i, fmt.Sprintf("unmatched '%c'", c))
+				return issues
+			}
+			top := stack[len(stack)-1]
+			if top.char != closingBrackets[c] {
+				report(i, fmt.Sprintf("closing parenthesis '%c' does not match opening parenthesis '%c' on line %d", c, top.char, top.line))
+				return issues
+			}
+			stack = stack[:len(stack)-1]
+			logical.WriteByte(c)
+		case c == ':':
+			// A walrus := is not a block colon
+			if len(stack) == 0 && (i+1 >= len(content) || content[i+1] != '=') {
+				hasColon = true
+			}
+			logical.WriteByte(c)
+		case c == '\n':
+			line++
+			if len(stack) == 0 {
+				endLogicalLine()
+				start = i + 1
+			}
+		default:
+			logical.WriteByte(c)
+		}
+	}
+
+	if len(stack) > 0 {
+		top := stack[len(stack)-1]
+		report(top.offset, fmt.Sprintf("'%c' was never closed", top.char))
+		return issues
+	}
+	endLogicalLine()
+	return issues
+}
+
+// leadingKeyword returns the first word of a logical line, skipping async
+func leadingKeyword(code string) string {
+	fields := strings.FieldsFunc(code, func(r rune) bool {
+		return !(r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+	})
+	if len(fields) == 0 || !strings.HasPrefix(strings.TrimSpace(code), fields[0]) {
+		return ""
+	}
+	if fields[0] == "async" && len(fields) > 1 {
+		return fields[1]
+	}
+	return fields[0]
+}
@@ -0,0 +1,13 @@
+//go:build noembed
+
+package python
+
+import "github.com/jrossi/gismo/linters"
+
+// embeddedChecks is empty: noembed builds leave out the pure-Go fallbacks
+var embeddedChecks = []string{}
+
+// checkBasicSyntax reports nothing in noembed builds, which rely on python3
+func checkBasicSyntax(filePath string, content []byte) []linters.Issue {
+	return nil
+}
@@ -0,0 +1,62 @@
+//go:build !noembed
+
+package python
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCheckBasicSyntax(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+		line    int
+		column  int
+	}{
+		{name: "valid", content: "import os\n\ndef f(a: int) -> int:\n    return {'a': a}[\"a\"]\n"},
+		{name: "brackets across lines", content: "x = [\n    1,\n    2,\n]\nif (x and\n        y):\n    pass\n"},
+		{name: "strings and comments hide brackets", content: "s = ')'  # (\nt = \"\"\"\n(\n\"\"\"\nif s: pass\n"},
+		{name: "escaped quotes", content: "s = 'it\\'s'\nt = \"\"\"say \\\"\"\"\"\n"},
+		{name: "comprehension continuation", content: "x = [a\n     for a in b]\nwhile (n := f()):\n    pass\n"},
+		{name: "unclosed bracket", content: "def f(:\n", want: "'(' was never closed", line: 1, column: 6},
+		{name: "unmatched closing", content: "x = 1)\n", want: "unmatched ')'", line: 1, column: 6},
+		{name: "mismatched closing", content: "x = [1,\n2)\n", want: "closing parenthesis ')' does not match opening parenthesis '[' on line 1", line: 2, column: 2},
+		{name: "unterminated string", content: "x = 1\ns = 'abc\n", want: "unterminated string literal (detected at line 2)", line: 2, column: 5},
+		{name: "unterminated triple string", content: "s = \"\"\"abc\n\n", want: "unterminated triple-quoted string literal (detected at line 3)", line: 1, column: 5},
+		{name: "missing colon", content: "x = 1\nif x\n    pass\n", want: "expected ':'", line: 2, column: 1},
+		{name: "missing colon async def", content: "async def f()\n    pass\n", want: "expected ':'", line: 1, column: 1},
+		{name: "missing colon indented", content: "def f():\n    if x\n        pass\n", want: "expected ':'", line: 2, column: 5},
+		{name: "columns count characters", content: "s = 'é' + (\n", want: "'(' was never closed", line: 1, column: 11},
+		{name: "identifier starting with keyword", content: "iffy = 1\nclass_ = 2\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := checkBasicSyntax("app.py", []byte(tt.content))
+			if tt.want == "" {
+				if len(issues) != 0 {
+					t.Errorf("checkBasicSyntax() = %+v, want no issues", issues)
+				}
+				return
+			}
+			if len(issues) != 1 || issues[0].Message != tt.want || issues[0].Line != tt.line || issues[0].Column != tt.column || issues[0].Rule != "basic-syntax" {
+				t.Errorf("checkBasicSyntax() = %+v, want %q at %d:%d", issues, tt.want, tt.line, tt.column)
+			}
+		})
+	}
+}
+
+func TestPythonLinter_WithoutPython(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+
+	result, err := NewPythonLinter().Lint(context.Background(), "app.py", []byte("def f(:\n"))
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+	// Without python3 the embedded check reports the syntax error
+	if len(result.Issues) != 1 || result.Issues[0].Rule != "basic-syntax" {
+		t.Errorf("Lint() = %+v, want one basic-syntax issue", result)
+	}
+}
@@ -0,0 +1,137 @@
+package python
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+)
+
+// projectCacheTTL is how long a discovered project is reused, so a virtualenv
+// created while gismo runs is picked up
+const projectCacheTTL = time.Minute
+
+// projectMarkers are files marking the directory Python tools run from, so
+// project configuration and imports resolve as they do for the project
+var projectMarkers = []string{"pyproject.toml", "setup.cfg", "setup.py", "mypy.ini", ".mypy.ini", "pyrightconfig.json"}
+
+// ruffConfigFiles are the files ruff reads its settings from, in ruff's order
+// of precedence. pyproject.toml only counts with a [tool.ruff] table.
+var ruffConfigFiles = []string{".ruff.toml", "ruff.toml", "pyproject.toml"}
+
+// venvDirs are the project virtualenv directories whose tools are preferred
+// over uv's global tools
+var venvDirs = []string{".venv", "venv"}
+
+// ProjectInfo contains cached information about the Python project of a file
+type ProjectInfo struct {
+	// Root is the directory tools run from: the nearest one holding a project
+	// or type checker configuration, or else the file's directory
+	Root string `json:"root"`
+	// RuffConfig is the ruff configuration file in Root, "" if there is none
+	RuffConfig string `json:"ruffConfig"`
+	// VenvBin is the executables directory of the project's virtualenv, "" if there is none
+	VenvBin        string    `json:"venvBin"`
+	LastDiscovered time.Time `json:"lastDiscovered"`
+}
+
+// findProject returns the project of a file, discovering it once per directory
+// and caching it for projectCacheTTL
+func (l *PythonLinter) findProject(filePath string) *ProjectInfo {
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		absPath = filePath
+	}
+	dir := filepath.Dir(absPath)
+
+	l.projectMu.Lock()
+	defer l.projectMu.Unlock()
+	if info, ok := l.projectCache[dir]; ok && time.Since(info.LastDiscovered) < projectCacheTTL {
+		return info
+	}
+	info := discoverProject(absPath)
+	if l.projectCache == nil {
+		l.projectCache = make(map[string]*ProjectInfo)
+	}
+	l.projectCache[dir] = info
+	return info
+}
+
+// discoverProject finds the project root of a file and its ruff configuration
+// and virtualenv
+func discoverProject(absPath string) *ProjectInfo {
+	info := &ProjectInfo{
+		Root:           projectDir(absPath),
+		LastDiscovered: time.Now(),
+	}
+	if info.Root == "" {
+		return info
+	}
+	for _, name := range ruffConfigFiles {
+		path := filepath.Join(info.Root, name)
+		data, err := os.ReadFile(path) // #nosec G304 - path is a config file in the project root
+		if err != nil || (name == "pyproject.toml" && !bytes.Contains(data, []byte("[tool.ruff"))) {
+			continue
+		}
+		info.RuffConfig = path
+		break
+	}
+	binDir := "bin"
+	if runtime.GOOS == "windows" {
+		binDir = "Scripts"
+	}
+	for _, name := range venvDirs {
+		bin := filepath.Join(info.Root, name, binDir)
+		if stat, err := os.Stat(bin); err == nil && stat.IsDir() {
+			info.VenvBin = bin
+			break
+		}
+	}
+	return info
+}
+
+// venvTool returns the path of a tool installed in the file's project
+// virtualenv, or "" if it isn't installed there
+func (l *PythonLinter) venvTool(filePath, tool string) string {
+	bin := l.findProject(filePath).VenvBin
+	if bin == "" {
+		return ""
+	}
+	if runtime.GOOS == "windows" {
+		tool += ".exe"
+	}
+	path := filepath.Join(bin, tool)
+	if stat, err := os.Stat(path); err != nil || stat.IsDir() {
+		return ""
+	}
+	return path
+}
+
+// projectDir returns the nearest directory at or above the file's holding a
+// Python project or type checker configuration, within its repository, or
+// else the closest existing directory of the file
+func projectDir(absPath string) string {
+	fallback := ""
+	for current := filepath.Dir(absPath); ; {
+		if info, err := os.Stat(current); err == nil && info.IsDir() {
+			if fallback == "" {
+				fallback = current
+			}
+			for _, marker := range projectMarkers {
+				if _, err := os.Stat(filepath.Join(current, marker)); err == nil {
+					return current
+				}
+			}
+			if _, err := os.Stat(filepath.Join(current, ".git")); err == nil {
+				break
+			}
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			break
+		}
+		current = parent
+	}
+	return fallback
+}
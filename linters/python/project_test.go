@@ -0,0 +1,135 @@
+package python
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestProjectDir(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "src", "app")
+	if err := os.MkdirAll(nested, 0750); err != nil {
+		t.Fatal(err)
+	}
+	if got := projectDir(filepath.Join(nested, "missing", "mod.py")); got != nested {
+		t.Errorf("without markers projectDir() = %q, want the closest existing directory %q", got, nested)
+	}
+	if err := os.WriteFile(filepath.Join(root, "mypy.ini"), nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if got := projectDir(filepath.Join(nested, "mod.py")); got != root {
+		t.Errorf("projectDir() = %q, want %q", got, root)
+	}
+}
+
+func TestDiscoverProject(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		wantRuff string
+		wantVenv bool
+	}{
+		{"setup.cfg only", map[string]string{"setup.cfg": "[metadata]\n"}, "", false},
+		{"pyproject without ruff settings", map[string]string{"pyproject.toml": "[project]\nname = \"app\"\n"}, "", false},
+		{"pyproject with ruff settings", map[string]string{"pyproject.toml": "[tool.ruff]\nline-length = 100\n"}, "pyproject.toml", false},
+		{"ruff.toml wins", map[string]string{"pyproject.toml": "[tool.ruff]\n", "ruff.toml": "line-length = 100\n"}, "ruff.toml", false},
+		{"virtualenv", map[string]string{"pyproject.toml": "[project]\n", ".venv/bin/ruff": "", ".venv/Scripts/ruff.exe": ""}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			for name, content := range tt.files {
+				path := filepath.Join(root, name)
+				if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := os.MkdirAll(filepath.Join(root, "pkg"), 0750); err != nil {
+				t.Fatal(err)
+			}
+
+			info := discoverProject(filepath.Join(root, "pkg", "mod.py"))
+			if info.Root != root {
+				t.Errorf("Root = %q, want %q", info.Root, root)
+			}
+			if want := ""; tt.wantRuff != "" {
+				want = filepath.Join(root, tt.wantRuff)
+				if info.RuffConfig != want {
+					t.Errorf("RuffConfig = %q, want %q", info.RuffConfig, want)
+				}
+			} else if info.RuffConfig != want {
+				t.Errorf("RuffConfig = %q, want none", info.RuffConfig)
+			}
+			if (info.VenvBin != "") != tt.wantVenv {
+				t.Errorf("VenvBin = %q, want a virtualenv: %v", info.VenvBin, tt.wantVenv)
+			}
+		})
+	}
+}
+
+func TestPythonLinter_ProjectRuff(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake ruff is a shell script")
+	}
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "pyproject.toml"), []byte("[tool.ruff]\nline-length = 100\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	bin := filepath.Join(root, ".venv", "bin")
+	if err := os.MkdirAll(bin, 0750); err != nil {
+		t.Fatal(err)
+	}
+	// The project's ruff records how it was run and reports one issue
+	calls := filepath.Join(t.TempDir(), "calls")
+	script := "#!/bin/sh\n" +
+		"echo \"$(pwd) $*\" >> " + calls + "\n" +
+		"cat > /dev/null\n" +
+		`[ "$1" = "check" ] && [ "$2" = "--output-format" ] && echo '[{"code": "F401", "message": "unused import", "location": {"row": 1, "column": 8}}]'` + "\n" +
+		"exit 0\n"
+	if err := os.WriteFile(filepath.Join(bin, "ruff"), []byte(script), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	// Without uv the virtualenv's ruff still runs
+	linter := NewPythonLinter()
+	linter.initOnce.Do(func() {})
+	filePath := filepath.Join(root, "app", "mod.py")
+	result, err := linter.Lint(context.Background(), filePath, []byte("import os\n"))
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+	if len(result.Issues) != 1 || result.Issues[0].Rule != "F401" {
+		t.Errorf("issues = %+v, want the virtualenv ruff's F401", result.Issues)
+	}
+
+	data, err := os.ReadFile(calls)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("ruff calls = %q, want check and format", lines)
+	}
+	for _, line := range lines {
+		if !strings.HasPrefix(line, root+" ") || !strings.Contains(line, "--config "+filepath.Join(root, "pyproject.toml")) {
+			t.Errorf("ruff call %q should run in %s with the project's config", line, root)
+		}
+	}
+
+	// The virtualenv's tools are preferred over uv's
+	linter.hasUV, linter.uvPath = true, "/usr/bin/uv"
+	if command, args := linter.toolCommand(filePath, "ruff"); command != filepath.Join(bin, "ruff") || len(args) != 0 {
+		t.Errorf("toolCommand(ruff) = %q %q, want the virtualenv's ruff", command, args)
+	}
+	if command, args := linter.toolCommand(filePath, "mypy"); command != "/usr/bin/uv" || strings.Join(args, " ") != "tool run mypy" {
+		t.Errorf("toolCommand(mypy) = %q %q, want uv", command, args)
+	}
+}
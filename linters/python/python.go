@@ -7,18 +7,28 @@ import (
 	"fmt"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 
 	"github.com/jrossi/gismo/linters"
+	"github.com/jrossi/gismo/toolcache"
 )
 
 // PythonLinter handles linting of Python files using UV/UVX
 type PythonLinter struct {
-	config   *PythonConfig
-	hasUV    bool
-	uvPath   string
-	initOnce sync.Once
+	config    *PythonConfig
+	hasUV     bool
+	uvPath    string
+	hasPython bool
+	initOnce  sync.Once
+	// Tool cache used to discover type checkers without uv; nil uses the disk-backed cache of the linted file's project
+	cache toolcache.ToolCache
+	// Project context cache, keyed by directory
+	projectMu    sync.Mutex
+	projectCache map[string]*ProjectInfo
+	// Runs external tools with the engine's limits, caches and environment
+	runner *linters.CommandRunner
 }
 
 // RuffIssue represents a single issue from ruff's JSON output
@@ -27,6 +37,13 @@ type RuffIssue struct {
 	Message  string    `json:"message"`
 	Location *Location `json:"location"`
 	End      *Location `json:"end_location"`
+	Fix      *RuffFix  `json:"fix"`
+}
+
+// RuffFix describes an automatic fix ruff can apply for an issue
+type RuffFix struct {
+	Applicability string `json:"applicability"`
+	Message       string `json:"message"`
 }
 
 // Location represents a position in the file
@@ -43,7 +60,8 @@ type RuffOutput struct {
 // NewPythonLinter creates a new Python linter with default configuration
 func NewPythonLinter() *PythonLinter {
 	return &PythonLinter{
-		config: DefaultPythonConfig(),
+		config:       DefaultPythonConfig(),
+		projectCache: make(map[string]*ProjectInfo),
 	}
 }
 
@@ -53,37 +71,70 @@ func NewPythonLinterWithConfig(config *PythonConfig) *PythonLinter {
 		config = DefaultPythonConfig()
 	}
 	return &PythonLinter{
-		config: config,
+		config:       config,
+		runner:       linters.NewCommandRunner(linters.RunnerConfig{}),
+		projectCache: make(map[string]*ProjectInfo),
 	}
 }
 
+// NewPythonLinterWithToolCache creates a Python linter that discovers its type
+// checker with the given tool cache when uv is unavailable. A nil cache falls
+// back to the disk-backed cache rooted at the linted file's project.
+func NewPythonLinterWithToolCache(config *PythonConfig, cache toolcache.ToolCache) *PythonLinter {
+	l := NewPythonLinterWithConfig(config)
+	l.cache = cache
+	return l
+}
+
 // Name returns the linter name
 func (l *PythonLinter) Name() string {
 	return "python"
 }
 
+// SetCommandRunner sets the runner used to start external tools
+func (l *PythonLinter) SetCommandRunner(runner *linters.CommandRunner) {
+	l.runner = runner
+}
+
+// Capabilities reports the built-in checks and the external tools used when installed
+func (l *PythonLinter) Capabilities() linters.Capabilities {
+	return linters.Capabilities{
+		Embedded: embeddedChecks,
+		Tools:    []string{"python3", "uv", "mypy", "pyright", "bandit"},
+	}
+}
+
 // CanHandle returns true if this linter can handle the given file
 func (l *PythonLinter) CanHandle(filePath string) bool {
-	return strings.HasSuffix(filePath, ".py")
+	return strings.HasSuffix(filePath, ".py") || isDependencyFile(filePath)
 }
 
 // SetConfig updates the linter configuration
 func (l *PythonLinter) SetConfig(config json.RawMessage) error {
-	var pythonConfig PythonConfig
-	if err := json.Unmarshal(config, &pythonConfig); err != nil {
+	// Settings not given keep their defaults
+	pythonConfig := DefaultPythonConfig()
+	if err := json.Unmarshal(config, pythonConfig); err != nil {
 		return fmt.Errorf("failed to unmarshal python config: %w", err)
 	}
-	l.config = &pythonConfig
+	l.config = pythonConfig
 	return nil
 }
 
-// Initialize checks for UV availability
+// ConfigSchema returns the JSON Schema for the linter configuration
+func (l *PythonLinter) ConfigSchema() json.RawMessage {
+	return json.RawMessage(configSchema)
+}
+
+// Initialize checks for UV and python3 availability
 func (l *PythonLinter) initialize() {
 	l.initOnce.Do(func() {
 		if path, err := exec.LookPath("uv"); err == nil {
 			l.hasUV = true
 			l.uvPath = path
 		}
+		if _, err := exec.LookPath("python3"); err == nil {
+			l.hasPython = true
+		}
 	})
 }
 
@@ -91,13 +142,20 @@ func (l *PythonLinter) initialize() {
 func (l *PythonLinter) Lint(ctx context.Context, filePath string, content []byte) (*linters.LintResult, error) {
 	l.initialize()
 
+	// Requirements files and lockfiles get dependency checks instead
+	if isDependencyFile(filePath) {
+		return l.lintDependencies(ctx, filePath, content), nil
+	}
+
 	result := &linters.LintResult{
 		Success: true,
 		Issues:  []linters.Issue{},
 	}
 
-	// Basic syntax check using Python's ast module
-	if err := l.checkSyntax(ctx, filePath, content); err != nil {
+	// Basic syntax check using Python's ast module, or the embedded check without python3
+	if !l.hasPython {
+		result.Issues = append(result.Issues, checkBasicSyntax(filePath, content)...)
+	} else if err := l.checkSyntax(ctx, filePath, content); err != nil {
 		result.Success = false
 		result.Issues = append(result.Issues, linters.Issue{
 			File:     filePath,
@@ -110,13 +168,44 @@ func (l *PythonLinter) Lint(ctx context.Context, filePath string, content []byte
 		return result, nil
 	}
 
-	// If UV is not available, return with just syntax check
-	if !l.hasUV {
+	// Type check with the configured checker, through uv or an installed copy
+	typeIssues, err := l.runTypeCheck(ctx, filePath, content)
+	if err != nil {
+		result.Issues = append(result.Issues, linters.Issue{
+			File:     filePath,
+			Line:     1,
+			Column:   1,
+			Severity: "warning",
+			Message:  fmt.Sprintf("Type check failed: %v", err),
+			Rule:     "typecheck",
+		})
+	} else {
+		result.Issues = append(result.Issues, typeIssues...)
+	}
+
+	// Scan for security issues with bandit
+	securityIssues, err := l.runBandit(ctx, filePath, content)
+	if err != nil {
+		result.Issues = append(result.Issues, linters.Issue{
+			File:     filePath,
+			Line:     1,
+			Column:   1,
+			Severity: "warning",
+			Message:  fmt.Sprintf("Bandit scan failed: %v", err),
+			Rule:     "bandit",
+		})
+	} else {
+		result.Issues = append(result.Issues, securityIssues...)
+	}
+
+	// Without ruff in the project's virtualenv or uv, return with the syntax, type and security checks
+	if !l.hasRuff(filePath) {
+		l.updateSuccess(result)
 		return result, nil
 	}
 
 	// Run ruff linting
-	ruffIssues, err := l.runRuffCheck(ctx, filePath, content)
+	ruffIssues, fixable, err := l.runRuffCheck(ctx, filePath, content)
 	if err != nil {
 		// Log the error but don't fail the entire lint
 		result.Issues = append(result.Issues, linters.Issue{
@@ -149,8 +238,19 @@ func (l *PythonLinter) Lint(ctx context.Context, filePath string, content []byte
 		}
 	}
 
+	// Apply ruff's safe fixes so the result carries the complete corrected content
+	if fixable {
+		base := content
+		if result.Formatted != nil {
+			base = result.Formatted
+		}
+		if fixed, err := l.runRuffFix(ctx, filePath, base); err == nil && len(fixed) > 0 && !bytes.Equal(fixed, base) {
+			result.Formatted = fixed
+		}
+	}
+
 	// Run tests if this is a test file
-	if l.isTestFile(filePath) && l.config.RunTests {
+	if l.isTestFile(filePath) && l.config.RunTests && !linters.IsStaticOnly(ctx) {
 		testOutput, testErr := l.runTests(ctx, filePath, content)
 		result.TestOutput = testOutput
 		if testErr != nil {
@@ -166,14 +266,7 @@ func (l *PythonLinter) Lint(ctx context.Context, filePath string, content []byte
 		}
 	}
 
-	// Update success based on issues
-	for _, issue := range result.Issues {
-		if issue.Severity == "error" {
-			result.Success = false
-			break
-		}
-	}
-
+	l.updateSuccess(result)
 	return result, nil
 }
 
@@ -187,7 +280,9 @@ func (l *PythonLinter) LintBatch(ctx context.Context, files map[string][]byte) (
 	// Filter Python files
 	pythonFiles := make(map[string][]byte)
 	for path, content := range files {
-		if l.CanHandle(path) {
+		if isDependencyFile(path) {
+			results[path] = l.lintDependencies(ctx, path, content)
+		} else if l.CanHandle(path) {
 			pythonFiles[path] = content
 		}
 	}
@@ -210,6 +305,12 @@ func (l *PythonLinter) LintBatch(ctx context.Context, files map[string][]byte) (
 		wg.Add(1)
 		go func(path string, data []byte) {
 			defer wg.Done()
+			if !l.hasPython {
+				mu.Lock()
+				results[path].Issues = append(results[path].Issues, checkBasicSyntax(path, data)...)
+				mu.Unlock()
+				return
+			}
 			if err := l.checkSyntax(ctx, path, data); err != nil {
 				mu.Lock()
 				results[path].Success = false
@@ -227,11 +328,6 @@ func (l *PythonLinter) LintBatch(ctx context.Context, files map[string][]byte) (
 	}
 	wg.Wait()
 
-	// If UV is not available, return with just syntax checks
-	if !l.hasUV {
-		return results, nil
-	}
-
 	// Collect files that passed syntax check
 	validFiles := make([]string, 0, len(pythonFiles))
 	for filePath, result := range results {
@@ -240,11 +336,31 @@ func (l *PythonLinter) LintBatch(ctx context.Context, files map[string][]byte) (
 		}
 	}
 
-	if len(validFiles) > 0 {
+	// Type check the valid files
+	if l.config.TypeChecker != "" {
+		l.runCheckBatch(ctx, validFiles, pythonFiles, results, l.runTypeCheck, "typecheck", "Type check")
+	}
+
+	// Scan the valid files for security issues
+	if l.config.Bandit {
+		l.runCheckBatch(ctx, validFiles, pythonFiles, results, l.runBandit, "bandit", "Bandit scan")
+	}
+
+	// Without ruff in a project's virtualenv or uv, return with the syntax, type and security checks
+	if !l.hasRuff(validFiles...) {
+		for _, result := range results {
+			l.updateSuccess(result)
+		}
+		return results, nil
+	}
+
+	// Files whose project has no ruff are left to the other checks
+	ruffFiles := slices.DeleteFunc(slices.Clone(validFiles), func(path string) bool { return !l.hasRuff(path) })
+	if len(ruffFiles) > 0 {
 		// Run ruff check on all valid files at once
-		if err := l.runRuffBatch(ctx, validFiles, pythonFiles, results); err != nil {
+		if err := l.runRuffBatch(ctx, ruffFiles, pythonFiles, results); err != nil {
 			// Log error but continue
-			for _, path := range validFiles {
+			for _, path := range ruffFiles {
 				results[path].Issues = append(results[path].Issues, linters.Issue{
 					File:     path,
 					Line:     1,
@@ -257,8 +373,8 @@ func (l *PythonLinter) LintBatch(ctx context.Context, files map[string][]byte) (
 		}
 
 		// Run format check on all valid files
-		if err := l.runRuffFormatBatch(ctx, validFiles, pythonFiles, results); err != nil {
-			for _, path := range validFiles {
+		if err := l.runRuffFormatBatch(ctx, ruffFiles, pythonFiles, results); err != nil {
+			for _, path := range ruffFiles {
 				results[path].Issues = append(results[path].Issues, linters.Issue{
 					File:     path,
 					Line:     1,
@@ -299,12 +415,7 @@ func (l *PythonLinter) LintBatch(ctx context.Context, files map[string][]byte) (
 
 	// Update success status based on issues
 	for _, result := range results {
-		for _, issue := range result.Issues {
-			if issue.Severity == "error" {
-				result.Success = false
-				break
-			}
-		}
+		l.updateSuccess(result)
 	}
 
 	return results, nil
@@ -313,22 +424,157 @@ func (l *PythonLinter) LintBatch(ctx context.Context, files map[string][]byte) (
 // checkSyntax performs basic syntax checking using Python's ast module
 func (l *PythonLinter) checkSyntax(ctx context.Context, filePath string, content []byte) error {
 	// Use Python's ast module to check syntax
-	cmd := exec.CommandContext(ctx, "python3", "-m", "ast", "-")
+	cmd := l.runner.Command(ctx, l.Name(), "python3", "-m", "ast", "-")
 	cmd.Stdin = bytes.NewReader(content)
 
 	var stderr bytes.Buffer
 	cmd.Stderr = &stderr
 
-	if err := cmd.Run(); err != nil {
+	if err := linters.Run(cmd); err != nil {
 		return fmt.Errorf("%s", strings.TrimSpace(stderr.String()))
 	}
 
 	return nil
 }
 
-// runRuffCheck runs ruff linting on a single file
-func (l *PythonLinter) runRuffCheck(ctx context.Context, filePath string, content []byte) ([]linters.Issue, error) {
-	args := []string{"ruff", "check", "--output-format", "json"}
+// acquireUVTool waits for slots for uv, the process actually started, and for
+// the tool it runs, so limits keyed by either name apply
+func (l *PythonLinter) acquireUVTool(ctx context.Context, tool string) (func(), error) {
+	releaseUV, err := l.runner.Acquire(ctx, l.uvPath)
+	if err != nil {
+		return nil, err
+	}
+	releaseTool, err := l.runner.Acquire(ctx, tool)
+	if err != nil {
+		releaseUV()
+		return nil, err
+	}
+	return func() {
+		releaseTool()
+		releaseUV()
+	}, nil
+}
+
+// runCheckBatch runs a per-file check on multiple files in parallel. A check
+// that fails to run is reported as a warning with the given rule.
+func (l *PythonLinter) runCheckBatch(ctx context.Context, files []string, contents map[string][]byte, results map[string]*linters.LintResult,
+	check func(context.Context, string, []byte) ([]linters.Issue, error), rule, name string) {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+
+	for _, filePath := range files {
+		wg.Add(1)
+		go func(path string) {
+			defer wg.Done()
+
+			issues, err := check(ctx, path, contents[path])
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				results[path].Issues = append(results[path].Issues, linters.Issue{
+					File:     path,
+					Line:     1,
+					Column:   1,
+					Severity: "warning",
+					Message:  fmt.Sprintf("%s failed: %v", name, err),
+					Rule:     rule,
+				})
+				return
+			}
+			results[path].Issues = append(results[path].Issues, issues...)
+		}(filePath)
+	}
+
+	wg.Wait()
+}
+
+// toolCommand returns how to run a Python tool: the copy in the project's
+// virtualenv, through uv when it is installed, or else an installed copy found
+// with the tool cache. The command is "" when the tool isn't available.
+func (l *PythonLinter) toolCommand(filePath, tool string) (string, []string) {
+	if command, args := l.projectToolCommand(filePath, tool); command != "" {
+		return command, args
+	}
+	return l.discover(filePath, tool), nil
+}
+
+// projectToolCommand returns how to run a tool from the project's virtualenv
+// or through uv, or "" when neither has it
+func (l *PythonLinter) projectToolCommand(filePath, tool string) (string, []string) {
+	if venv := l.venvTool(filePath, tool); venv != "" {
+		return venv, nil
+	}
+	if l.hasUV {
+		return l.uvPath, []string{"tool", "run", tool}
+	}
+	return "", nil
+}
+
+// hasRuff reports whether ruff can run for any of the files
+func (l *PythonLinter) hasRuff(filePaths ...string) bool {
+	if l.hasUV {
+		return true
+	}
+	for _, filePath := range filePaths {
+		if l.venvTool(filePath, "ruff") != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// acquireTool waits for the slots to run a tool with the command toolCommand returned
+func (l *PythonLinter) acquireTool(ctx context.Context, command, tool string) (func(), error) {
+	if l.hasUV && command == l.uvPath {
+		return l.acquireUVTool(ctx, tool)
+	}
+	return l.runner.Acquire(ctx, tool)
+}
+
+// ruffCommand prepares a ruff subcommand for a file, run from the project's
+// virtualenv or through uv in the project root with the project's configuration
+// file, unless ruffArgs names one. The command reads the content from stdin.
+func (l *PythonLinter) ruffCommand(ctx context.Context, filePath string, content []byte, args ...string) (*exec.Cmd, func(), error) {
+	command, prefix := l.projectToolCommand(filePath, "ruff")
+	if command == "" {
+		return nil, nil, fmt.Errorf("ruff is not available")
+	}
+	project := l.findProject(filePath)
+	args = append(prefix, args...)
+	if project.RuffConfig != "" && !slices.Contains(l.config.RuffArgs, "--config") {
+		args = append(args, "--config", project.RuffConfig)
+	}
+
+	release, err := l.acquireTool(ctx, command, "ruff")
+	if err != nil {
+		return nil, nil, err
+	}
+	cmd := l.runner.Command(ctx, l.Name(), command, args...)
+	cmd.Dir = project.Root
+	cmd.Stdin = bytes.NewReader(content)
+	return cmd, release, nil
+}
+
+// discover returns the path of an installed Python tool, or "" if it isn't installed
+func (l *PythonLinter) discover(filePath, toolName string) string {
+	cache := l.cache
+	if cache == nil {
+		manager, err := toolcache.NewCacheManager(filePath)
+		if err != nil {
+			return ""
+		}
+		cache = manager
+	}
+	tool, err := cache.DiscoverTool("python", toolName)
+	if err != nil || tool == nil || !tool.Available {
+		return ""
+	}
+	return tool.Path
+}
+
+// runRuffCheck runs ruff linting on a single file and reports whether any issue has a safe fix
+func (l *PythonLinter) runRuffCheck(ctx context.Context, filePath string, content []byte) ([]linters.Issue, bool, error) {
+	args := []string{"check", "--output-format", "json"}
 
 	// Add custom arguments from config
 	if l.config.RuffArgs != nil {
@@ -338,28 +584,36 @@ func (l *PythonLinter) runRuffCheck(ctx context.Context, filePath string, conten
 	// Use stdin to avoid writing temp files
 	args = append(args, "--stdin-filename", filePath, "-")
 
-	cmd := exec.CommandContext(ctx, l.uvPath, append([]string{"tool", "run"}, args...)...) //#nosec G204 -- uvPath is validated
-	cmd.Stdin = bytes.NewReader(content)
+	cmd, release, err := l.ruffCommand(ctx, filePath, content, args...)
+	if err != nil {
+		return nil, false, err
+	}
+	defer release()
 
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 
 	// ruff returns non-zero exit code when issues are found
-	_ = cmd.Run()
+	_ = linters.Run(cmd)
 
 	// Parse JSON output
 	var ruffOutput []RuffIssue
 	if len(stdout.Bytes()) > 0 {
 		if err := json.Unmarshal(stdout.Bytes(), &ruffOutput); err != nil {
 			// Try alternative format or return error
-			return nil, fmt.Errorf("failed to parse ruff output: %w", err)
+			return nil, false, fmt.Errorf("failed to parse ruff output: %w", err)
 		}
 	}
 
 	// Convert to linters.Issue
 	issues := make([]linters.Issue, 0, len(ruffOutput))
+	fixable := false
 	for _, ruffIssue := range ruffOutput {
+		if ruffIssue.Fix != nil && ruffIssue.Fix.Applicability == "safe" {
+			fixable = true
+		}
+
 		issue := linters.Issue{
 			File:     filePath,
 			Message:  ruffIssue.Message,
@@ -375,22 +629,47 @@ func (l *PythonLinter) runRuffCheck(ctx context.Context, filePath string, conten
 		issues = append(issues, issue)
 	}
 
-	return issues, nil
+	return issues, fixable, nil
+}
+
+// runRuffFix returns content with ruff's safe fixes applied
+func (l *PythonLinter) runRuffFix(ctx context.Context, filePath string, content []byte) ([]byte, error) {
+	args := []string{"check", "--fix-only", "--exit-zero"}
+	if l.config.RuffArgs != nil {
+		args = append(args, l.config.RuffArgs...)
+	}
+	args = append(args, "--stdin-filename", filePath, "-")
+
+	cmd, release, err := l.ruffCommand(ctx, filePath, content, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := linters.Run(cmd); err != nil {
+		return nil, fmt.Errorf("ruff fix failed: %w: %s", err, stderr.String())
+	}
+	return stdout.Bytes(), nil
 }
 
 // runRuffFormat checks formatting and optionally returns formatted content
 func (l *PythonLinter) runRuffFormat(ctx context.Context, filePath string, content []byte) ([]linters.Issue, []byte, error) {
 	// First check if formatting is needed
-	args := []string{"ruff", "format", "--check", "--stdin-filename", filePath, "-"}
-
-	cmd := exec.CommandContext(ctx, l.uvPath, append([]string{"tool", "run"}, args...)...) //#nosec G204 -- uvPath is validated
-	cmd.Stdin = bytes.NewReader(content)
+	cmd, release, err := l.ruffCommand(ctx, filePath, content, "format", "--check", "--stdin-filename", filePath, "-")
+	if err != nil {
+		return nil, nil, err
+	}
+	defer release()
 
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 
-	if err := cmd.Run(); err != nil {
+	if err := linters.Run(cmd); err != nil {
 		// File needs formatting
 		issue := linters.Issue{
 			File:     filePath,
@@ -402,14 +681,17 @@ func (l *PythonLinter) runRuffFormat(ctx context.Context, filePath string, conte
 		}
 
 		// Get the formatted version
-		args[2] = "--"                                                                               // Remove --check
-		formatCmd := exec.CommandContext(ctx, l.uvPath, append([]string{"tool", "run"}, args...)...) //#nosec G204 -- uvPath is validated
-		formatCmd.Stdin = bytes.NewReader(content)
+		release()
+		formatCmd, releaseFormat, err := l.ruffCommand(ctx, filePath, content, "format", "--stdin-filename", filePath, "-")
+		if err != nil {
+			return []linters.Issue{issue}, nil, nil
+		}
+		defer releaseFormat()
 
 		var formatOut bytes.Buffer
 		formatCmd.Stdout = &formatOut
 
-		if err := formatCmd.Run(); err == nil {
+		if err := linters.Run(formatCmd); err == nil {
 			return []linters.Issue{issue}, formatOut.Bytes(), nil
 		}
 
@@ -433,7 +715,7 @@ func (l *PythonLinter) runRuffBatch(ctx context.Context, files []string, content
 		go func(path string) {
 			defer wg.Done()
 
-			issues, err := l.runRuffCheck(ctx, path, contents[path])
+			issues, _, err := l.runRuffCheck(ctx, path, contents[path])
 			if err != nil {
 				mu.Lock()
 				results[path].Issues = append(results[path].Issues, linters.Issue{
@@ -496,54 +778,19 @@ func (l *PythonLinter) runRuffFormatBatch(ctx context.Context, files []string, c
 	return nil
 }
 
+// updateSuccess marks a result failed when it has an error-level issue
+func (l *PythonLinter) updateSuccess(result *linters.LintResult) {
+	for _, issue := range result.Issues {
+		if issue.Severity == "error" {
+			result.Success = false
+			return
+		}
+	}
+}
+
 // isTestFile checks if a file is a test file
 func (l *PythonLinter) isTestFile(filePath string) bool {
 	base := filepath.Base(filePath)
 	return (strings.HasPrefix(base, "test_") && strings.HasSuffix(base, ".py")) ||
 		strings.HasSuffix(base, "_test.py")
 }
-
-// runTests runs Python tests for a file
-func (l *PythonLinter) runTests(ctx context.Context, filePath string, content []byte) (string, error) {
-	// Create a temp file for testing
-	tmpFile := filepath.Join("/tmp", filepath.Base(filePath))
-	// #nosec G204 -- tmpFile is generated from safe filepath
-	if err := exec.CommandContext(ctx, "bash", "-c", fmt.Sprintf("cat > %s", tmpFile)).Run(); err != nil {
-		return "", fmt.Errorf("failed to create temp file: %w", err)
-	}
-	defer func() {
-		_ = exec.Command("rm", "-f", tmpFile).Run()
-	}()
-
-	// Write content to temp file
-	cmd := exec.Command("bash", "-c", fmt.Sprintf("cat > %s", tmpFile)) //#nosec G204 -- tmpFile is safe
-	cmd.Stdin = bytes.NewReader(content)
-	if err := cmd.Run(); err != nil {
-		return "", fmt.Errorf("failed to write temp file: %w", err)
-	}
-
-	// Run tests based on configured test runner
-	testRunner := l.config.TestRunner
-	if testRunner == "" {
-		testRunner = "pytest"
-	}
-
-	args := []string{"run", testRunner}
-	if l.config.TestArgs != nil {
-		args = append(args, l.config.TestArgs...)
-	}
-	args = append(args, tmpFile)
-
-	testCmd := exec.CommandContext(ctx, l.uvPath, args...) //#nosec G204 -- uvPath is validated
-
-	var stdout, stderr bytes.Buffer
-	testCmd.Stdout = &stdout
-	testCmd.Stderr = &stderr
-
-	if err := testCmd.Run(); err != nil {
-		output := stdout.String() + "\n" + stderr.String()
-		return output, fmt.Errorf("tests failed")
-	}
-
-	return stdout.String(), nil
-}
@@ -27,6 +27,11 @@ func TestPythonLinter_CanHandle(t *testing.T) {
 		{"Text file", "readme.txt", false},
 		{"No extension", "Makefile", false},
 		{"Hidden Python file", ".hidden.py", true},
+		{"Requirements file", "requirements-dev.txt", true},
+		{"Requirements directory", "requirements/base.txt", true},
+		{"uv lockfile", "/app/uv.lock", true},
+		{"Poetry lockfile", "poetry.lock", true},
+		{"Other lockfile", "Cargo.lock", false},
 	}
 
 	for _, tt := range tests {
@@ -84,6 +89,20 @@ func TestPythonLinter_SetConfig(t *testing.T) {
 	}
 }
 
+func TestPythonLinter_SetConfig_KeepsDefaults(t *testing.T) {
+	linter := NewPythonLinter()
+	if err := linter.SetConfig(json.RawMessage(`{"maxLineLength": 100}`)); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+
+	if *linter.config.MaxLineLength != 100 {
+		t.Errorf("MaxLineLength = %v, want 100", *linter.config.MaxLineLength)
+	}
+	if linter.config.TypeCheckSeverity != "error" || !linter.config.RunTests {
+		t.Errorf("settings not given should keep their defaults, got %+v", linter.config)
+	}
+}
+
 func TestPythonLinter_Lint_ValidFile(t *testing.T) {
 	linter := NewPythonLinter()
 	ctx := context.Background()
@@ -370,8 +389,8 @@ func TestDefaultPythonConfig(t *testing.T) {
 	} else if *config.MaxLineLength != 88 {
 		t.Errorf("MaxLineLength = %v, want %v", *config.MaxLineLength, 88)
 	}
-	if config.TypeChecker != "mypy" {
-		t.Errorf("TypeChecker = %v, want %v", config.TypeChecker, "mypy")
+	if config.TypeChecker != "" {
+		t.Errorf("TypeChecker = %v, want type checking off by default", config.TypeChecker)
 	}
 	if config.TestRunner != "pytest" {
 		t.Errorf("TestRunner = %v, want %v", config.TestRunner, "pytest")
@@ -0,0 +1,331 @@
+package python
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/jrossi/gismo/linters"
+)
+
+// Requirement pinning policies
+const (
+	RequirementPinsAny   = "any"
+	RequirementPinsExact = "exact"
+	RequirementPinsOff   = "off"
+)
+
+// Rules reported for dependency files
+const (
+	RuleUnpinnedRequirement  = "unpinned-requirement"
+	RuleDuplicateRequirement = "duplicate-requirement"
+	RuleLockfileDesync       = "lockfile-desync"
+	RuleLockfile             = "lockfile"
+)
+
+// requirementLine splits a PEP 508 requirement into its name, extras and the
+// rest: the version specifier or URL, then any markers
+var requirementLine = regexp.MustCompile(`^([A-Za-z0-9](?:[A-Za-z0-9._-]*[A-Za-z0-9])?)\s*(\[[^\]]*\])?\s*(.*)$`)
+
+// exactPin matches a version specifier pinning a single version
+var exactPin = regexp.MustCompile(`^\(?\s*===?\s*[^,*\s()]+\s*\)?$`)
+
+// nameSeparators are the runs of characters PEP 503 normalizes to "-"
+var nameSeparators = regexp.MustCompile(`[-_.]+`)
+
+// uvNetworkErrors mark uv failures caused by the network rather than the lockfile
+var uvNetworkErrors = []string{"Failed to fetch", "error sending request", "dns error", "Network is unreachable"}
+
+// isDependencyFile reports whether a file lists Python dependencies:
+// requirements*.txt, a .txt file in a requirements directory, poetry.lock or
+// uv.lock
+func isDependencyFile(filePath string) bool {
+	base := strings.ToLower(filepath.Base(filePath))
+	switch {
+	case base == "poetry.lock" || base == "uv.lock":
+		return true
+	case strings.HasSuffix(base, ".txt"):
+		return strings.HasPrefix(base, "requirements") || filepath.Base(filepath.Dir(filePath)) == "requirements"
+	}
+	return false
+}
+
+// normalizeName returns the PEP 503 normalized form of a package name
+func normalizeName(name string) string {
+	return strings.ToLower(nameSeparators.ReplaceAllString(name, "-"))
+}
+
+// requirement is a package requirement read from a requirements file
+type requirement struct {
+	name    string // normalized
+	spec    string // version specifier, or "@ url" for a direct reference
+	markers string
+	line    int
+}
+
+// lintDependencies checks a requirements file or lockfile
+func (l *PythonLinter) lintDependencies(ctx context.Context, filePath string, content []byte) *linters.LintResult {
+	result := &linters.LintResult{
+		Success: true,
+		Issues:  []linters.Issue{},
+	}
+	switch strings.ToLower(filepath.Base(filePath)) {
+	case "poetry.lock", "uv.lock":
+		result.Issues = append(result.Issues, l.checkLockfile(ctx, filePath, content)...)
+	default:
+		result.Issues = append(result.Issues, l.checkRequirements(filePath, content)...)
+	}
+	l.updateSuccess(result)
+	return result
+}
+
+// checkRequirements reports requirements that aren't pinned as the
+// requirementPins policy asks and packages listed more than once
+func (l *PythonLinter) checkRequirements(filePath string, content []byte) []linters.Issue {
+	var issues []linters.Issue
+	seen := make(map[string]requirement)
+	for _, req := range parseRequirements(content) {
+		if previous, ok := seen[req.name+";"+req.markers]; ok {
+			issues = append(issues, linters.Issue{
+				File:     filePath,
+				Line:     req.line,
+				Column:   1,
+				Severity: "warning",
+				Message:  fmt.Sprintf("%s is already required on line %d; pip fails when the requirements conflict", req.name, previous.line),
+				Rule:     RuleDuplicateRequirement,
+			})
+		} else {
+			seen[req.name+";"+req.markers] = req
+		}
+		if message := l.pinProblem(req); message != "" {
+			issues = append(issues, linters.Issue{
+				File:     filePath,
+				Line:     req.line,
+				Column:   1,
+				Severity: "warning",
+				Message:  message,
+				Rule:     RuleUnpinnedRequirement,
+			})
+		}
+	}
+	return issues
+}
+
+// pinProblem describes how a requirement breaks the pinning policy, or returns ""
+func (l *PythonLinter) pinProblem(req requirement) string {
+	if strings.HasPrefix(req.spec, "@") {
+		return ""
+	}
+	switch l.config.RequirementPins {
+	case RequirementPinsOff:
+		return ""
+	case RequirementPinsExact:
+		if !exactPin.MatchString(req.spec) {
+			return fmt.Sprintf("%s isn't pinned to a single version; pin it with ==, as requirementPins is \"exact\"", req.name)
+		}
+	default:
+		if req.spec == "" {
+			return fmt.Sprintf("%s has no version specifier, so installs pick up whatever is newest; add one such as %s>=1.2", req.name, req.name)
+		}
+	}
+	return ""
+}
+
+// parseRequirements reads the package requirements of a requirements file,
+// leaving out options, includes and bare URLs or paths
+func parseRequirements(content []byte) []requirement {
+	var requirements []requirement
+	lines := strings.Split(string(content), "\n")
+	for i := 0; i < len(lines); i++ {
+		start := i + 1
+		line := strings.TrimRight(lines[i], "\r")
+		// A trailing backslash continues the line
+		for strings.HasSuffix(line, "\\") && i+1 < len(lines) {
+			i++
+			line = strings.TrimSuffix(line, "\\") + " " + strings.TrimRight(lines[i], "\r")
+		}
+		if index := strings.Index(line, " #"); index >= 0 {
+			line = line[:index]
+		}
+		// Per-requirement options, such as --hash, follow the requirement
+		if index := strings.Index(line, " --"); index >= 0 {
+			line = line[:index]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "-") ||
+			strings.Contains(strings.SplitN(line, "@", 2)[0], "/") {
+			continue
+		}
+		match := requirementLine.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		spec, markers, _ := strings.Cut(match[3], ";")
+		requirements = append(requirements, requirement{
+			name:    normalizeName(match[1]),
+			spec:    strings.TrimSpace(spec),
+			markers: strings.Join(strings.Fields(markers), " "),
+			line:    start,
+		})
+	}
+	return requirements
+}
+
+// checkLockfile reports a lockfile that doesn't parse or doesn't lock every
+// dependency of the pyproject.toml next to it, and runs uv lock --check on a
+// written uv.lock when uvLockCheck is set
+func (l *PythonLinter) checkLockfile(ctx context.Context, filePath string, content []byte) []linters.Issue {
+	base := filepath.Base(filePath)
+	issue := func(severity, message, rule string) linters.Issue {
+		return linters.Issue{File: filePath, Line: 1, Column: 1, Severity: severity, Message: message, Rule: rule}
+	}
+
+	var lock struct {
+		Package []struct {
+			Name string `toml:"name"`
+		} `toml:"package"`
+	}
+	if _, err := toml.Decode(string(content), &lock); err != nil {
+		return []linters.Issue{issue("error", fmt.Sprintf("%s doesn't parse: %v", base, err), RuleLockfile)}
+	}
+	locked := make(map[string]bool, len(lock.Package))
+	for _, pkg := range lock.Package {
+		locked[normalizeName(pkg.Name)] = true
+	}
+
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return nil
+	}
+	dir := filepath.Dir(absPath)
+	pyproject, err := os.ReadFile(filepath.Join(dir, "pyproject.toml")) // #nosec G304 - the project manifest next to the lockfile
+	if err != nil {
+		return nil
+	}
+	poetry := strings.EqualFold(base, "poetry.lock")
+	lockCommand := "uv lock"
+	if poetry {
+		lockCommand = "poetry lock"
+	}
+
+	var issues []linters.Issue
+	for _, name := range manifestDependencies(pyproject, poetry) {
+		if !locked[name] {
+			issues = append(issues, issue("warning",
+				fmt.Sprintf("%s is a dependency in pyproject.toml but isn't in %s; run %s", name, base, lockCommand), RuleLockfileDesync))
+		}
+	}
+
+	if l.config.UVLockCheck && !poetry && l.hasUV {
+		if message := l.uvLockCheck(ctx, absPath, content); message != "" {
+			issues = append(issues, issue("error", message, RuleLockfileDesync))
+		}
+	}
+	return issues
+}
+
+// manifestDependencies returns the normalized names of the dependencies a
+// pyproject.toml declares, including optional ones and dependency groups, and
+// with poetry those in [tool.poetry]
+func manifestDependencies(pyproject []byte, poetry bool) []string {
+	var manifest struct {
+		Project struct {
+			Name                 string              `toml:"name"`
+			Dependencies         []string            `toml:"dependencies"`
+			OptionalDependencies map[string][]string `toml:"optional-dependencies"`
+		} `toml:"project"`
+		DependencyGroups map[string][]interface{} `toml:"dependency-groups"`
+		Tool             struct {
+			Poetry struct {
+				Name         string                 `toml:"name"`
+				Dependencies map[string]interface{} `toml:"dependencies"`
+				Group        map[string]struct {
+					Dependencies map[string]interface{} `toml:"dependencies"`
+				} `toml:"group"`
+			} `toml:"poetry"`
+		} `toml:"tool"`
+	}
+	if _, err := toml.Decode(string(pyproject), &manifest); err != nil {
+		return nil
+	}
+
+	var requirements []string
+	requirements = append(requirements, manifest.Project.Dependencies...)
+	for _, extra := range slices.Sorted(maps.Keys(manifest.Project.OptionalDependencies)) {
+		requirements = append(requirements, manifest.Project.OptionalDependencies[extra]...)
+	}
+	for _, group := range slices.Sorted(maps.Keys(manifest.DependencyGroups)) {
+		for _, entry := range manifest.DependencyGroups[group] {
+			// {include-group = "..."} entries name other groups
+			if requirement, ok := entry.(string); ok {
+				requirements = append(requirements, requirement)
+			}
+		}
+	}
+	var names []string
+	for _, req := range parseRequirements([]byte(strings.Join(requirements, "\n"))) {
+		names = append(names, req.name)
+	}
+	if poetry {
+		names = append(names, slices.Sorted(maps.Keys(manifest.Tool.Poetry.Dependencies))...)
+		for _, group := range slices.Sorted(maps.Keys(manifest.Tool.Poetry.Group)) {
+			names = append(names, slices.Sorted(maps.Keys(manifest.Tool.Poetry.Group[group].Dependencies))...)
+		}
+	}
+
+	// The project itself, as in "app[test]", and python aren't locked packages
+	self := manifest.Project.Name
+	if self == "" {
+		self = manifest.Tool.Poetry.Name
+	}
+	self = normalizeName(self)
+	seen := map[string]bool{self: true, "python": true}
+	var dependencies []string
+	for _, name := range names {
+		name = normalizeName(name)
+		if !seen[name] {
+			seen[name] = true
+			dependencies = append(dependencies, name)
+		}
+	}
+	return dependencies
+}
+
+// uvLockCheck runs uv lock --check for a written uv.lock and returns why the
+// lockfile is out of date, or "" if it is current or couldn't be checked
+func (l *PythonLinter) uvLockCheck(ctx context.Context, absPath string, content []byte) string {
+	if onDisk, err := os.ReadFile(absPath); err != nil || !bytes.Equal(onDisk, content) { // #nosec G304 - the linted file
+		return ""
+	}
+	release, err := l.runner.Acquire(ctx, l.uvPath)
+	if err != nil {
+		return ""
+	}
+	defer release()
+
+	cmd := l.runner.Command(ctx, l.Name(), l.uvPath, "lock", "--check")
+	cmd.Dir = filepath.Dir(absPath)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := linters.Run(cmd); err == nil || ctx.Err() != nil {
+		return ""
+	}
+	output := strings.TrimSpace(stderr.String())
+	for _, networkError := range uvNetworkErrors {
+		if strings.Contains(output, networkError) {
+			return ""
+		}
+	}
+	message := "uv.lock is out of date with pyproject.toml; run uv lock"
+	if last := output[strings.LastIndex(output, "\n")+1:]; last != "" {
+		message += ": " + strings.TrimPrefix(strings.TrimSpace(last), "error: ")
+	}
+	return message
+}
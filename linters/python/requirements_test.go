@@ -0,0 +1,169 @@
+package python
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+// describeIssues returns "line:rule" for each issue
+func describeIssues(issues []linters.Issue) string {
+	parts := []string{}
+	for _, issue := range issues {
+		parts = append(parts, fmt.Sprintf("%d:%s", issue.Line, issue.Rule))
+	}
+	return strings.Join(parts, ",")
+}
+
+const requirementsTxt = `# Runtime dependencies
+-r base.txt
+--index-url https://pypi.org/simple
+requests>=2.31
+Django==5.0.1 \
+    --hash=sha256:abc
+numpy
+flask[async] == 3.0.*
+mylib @ git+https://github.com/org/mylib@v1
+./local-package
+importlib-metadata>=6; python_version < "3.10"
+importlib_metadata>=4; python_version < "3.10"
+Importlib.Metadata>=7; python_version >= "3.10"
+`
+
+func TestPythonLinter_Requirements(t *testing.T) {
+	tests := []struct {
+		pins string
+		want string
+	}{
+		{RequirementPinsAny, "7:unpinned-requirement,12:duplicate-requirement"},
+		{RequirementPinsExact, "4:unpinned-requirement,7:unpinned-requirement,8:unpinned-requirement,11:unpinned-requirement,12:duplicate-requirement,12:unpinned-requirement,13:unpinned-requirement"},
+		{RequirementPinsOff, "12:duplicate-requirement"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pins, func(t *testing.T) {
+			config := DefaultPythonConfig()
+			config.RequirementPins = tt.pins
+			linter := NewPythonLinterWithConfig(config)
+			result, err := linter.Lint(context.Background(), "requirements.txt", []byte(requirementsTxt))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := describeIssues(result.Issues); got != tt.want {
+				t.Errorf("issues = %q, want %q (%+v)", got, tt.want, result.Issues)
+			}
+			if !result.Success {
+				t.Error("requirement warnings should not fail the lint")
+			}
+		})
+	}
+}
+
+func TestPythonLinter_Lockfiles(t *testing.T) {
+	dir := t.TempDir()
+	pyproject := `[project]
+name = "app"
+version = "1.0"
+dependencies = ["httpx>=0.27", "Pydantic_Core"]
+
+[project.optional-dependencies]
+test = ["pytest", "app[extra]"]
+
+[dependency-groups]
+dev = ["ruff", {include-group = "test"}]
+
+[tool.poetry.dependencies]
+python = "^3.11"
+rich = "^13"
+`
+	if err := os.WriteFile(filepath.Join(dir, "pyproject.toml"), []byte(pyproject), 0600); err != nil {
+		t.Fatal(err)
+	}
+	lock := `version = 1
+
+[[package]]
+name = "httpx"
+version = "0.27.0"
+
+[[package]]
+name = "pydantic-core"
+version = "2.18.0"
+
+[[package]]
+name = "pytest"
+version = "8.2.0"
+`
+	linter := NewPythonLinter()
+	ctx := context.Background()
+
+	result, err := linter.Lint(ctx, filepath.Join(dir, "uv.lock"), []byte(lock))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Issues) != 1 || result.Issues[0].Rule != RuleLockfileDesync ||
+		!strings.Contains(result.Issues[0].Message, "ruff is a dependency in pyproject.toml but isn't in uv.lock; run uv lock") {
+		t.Errorf("uv.lock issues = %+v", result.Issues)
+	}
+
+	// Poetry's own dependency tables are compared with poetry.lock
+	result, err = linter.Lint(ctx, filepath.Join(dir, "poetry.lock"), []byte(lock))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := describeIssues(result.Issues); got != "1:lockfile-desync,1:lockfile-desync" ||
+		!strings.Contains(result.Issues[1].Message, "rich is a dependency in pyproject.toml but isn't in poetry.lock; run poetry lock") {
+		t.Errorf("poetry.lock issues = %+v", result.Issues)
+	}
+
+	result, err = linter.Lint(ctx, filepath.Join(dir, "uv.lock"), []byte("[[package]\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Success || describeIssues(result.Issues) != "1:lockfile" {
+		t.Errorf("broken lockfile: %+v", result)
+	}
+}
+
+func TestPythonLinter_UVLockCheck(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake uv is a shell script")
+	}
+	dir := t.TempDir()
+	lockPath := filepath.Join(dir, "uv.lock")
+	lock := []byte("version = 1\n")
+	for path, content := range map[string][]byte{lockPath: lock, filepath.Join(dir, "pyproject.toml"): []byte("[project]\nname = \"app\"\n")} {
+		if err := os.WriteFile(path, content, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	uv := filepath.Join(t.TempDir(), "uv")
+	script := "#!/bin/sh\n[ \"$*\" = \"lock --check\" ] || exit 2\necho 'Resolved 3 packages in 1ms' >&2\necho 'error: The lockfile at `uv.lock` needs to be updated, but `--locked` was provided.' >&2\nexit 1\n"
+	if err := os.WriteFile(uv, []byte(script), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	config := DefaultPythonConfig()
+	config.UVLockCheck = true
+	linter := NewPythonLinterWithConfig(config)
+	linter.initOnce.Do(func() {})
+	linter.hasUV, linter.uvPath = true, uv
+
+	result, err := linter.Lint(context.Background(), lockPath, lock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Success || len(result.Issues) != 1 ||
+		!strings.Contains(result.Issues[0].Message, "uv.lock is out of date with pyproject.toml; run uv lock: The lockfile at `uv.lock` needs to be updated") {
+		t.Errorf("uv lock --check failure: %+v", result)
+	}
+
+	// A lockfile that isn't written yet isn't checked
+	if result, _ := linter.Lint(context.Background(), lockPath, []byte("version = 1\n\n")); len(result.Issues) != 0 {
+		t.Errorf("unwritten lockfile: %+v", result.Issues)
+	}
+}
@@ -0,0 +1,111 @@
+package python
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+// banditOutput is bandit's -f json report
+type banditOutput struct {
+	Results []banditResult `json:"results"`
+	Errors  []struct {
+		Reason string `json:"reason"`
+	} `json:"errors"`
+}
+
+// banditResult is a single bandit finding
+type banditResult struct {
+	TestID     string `json:"test_id"`
+	TestName   string `json:"test_name"`
+	IssueText  string `json:"issue_text"`
+	Severity   string `json:"issue_severity"`   // "LOW", "MEDIUM" or "HIGH"
+	Confidence string `json:"issue_confidence"` // "LOW", "MEDIUM" or "HIGH"
+	LineNumber int    `json:"line_number"`
+	ColOffset  int    `json:"col_offset"` // 0-based
+}
+
+// banditSeverities maps bandit's severities to issue severities; only high
+// severity findings, such as shell=True subprocesses, block the edit
+var banditSeverities = map[string]string{
+	"HIGH":   "error",
+	"MEDIUM": "warning",
+	"LOW":    "info",
+}
+
+// runBandit scans content for security issues with bandit, run through uv or,
+// without uv, an installed copy found with the tool cache. It returns no
+// issues when scanning is off or bandit isn't available.
+func (l *PythonLinter) runBandit(ctx context.Context, filePath string, content []byte) ([]linters.Issue, error) {
+	if !l.config.Bandit {
+		return nil, nil
+	}
+	command, args := l.toolCommand(filePath, "bandit")
+	if command == "" {
+		return nil, nil
+	}
+	// Read the content from stdin since it may not be on disk yet
+	args = append(args, "-f", "json", "-q")
+	args = append(args, l.config.BanditArgs...)
+	args = append(args, "-")
+
+	release, err := l.acquireTool(ctx, command, "bandit")
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	cmd := l.runner.Command(ctx, l.Name(), command, args...)
+	cmd.Dir = l.findProject(filePath).Root
+	cmd.Stdin = bytes.NewReader(content)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	// bandit exits with 1 when it finds issues
+	runErr := linters.Run(cmd)
+	if stdout.Len() == 0 {
+		if runErr != nil {
+			return nil, fmt.Errorf("bandit failed: %w: %s", runErr, strings.TrimSpace(stderr.String()))
+		}
+		return nil, nil
+	}
+	return parseBanditOutput(stdout.Bytes(), filePath)
+}
+
+// parseBanditOutput converts bandit's JSON report into issues
+func parseBanditOutput(output []byte, filePath string) ([]linters.Issue, error) {
+	var report banditOutput
+	if err := json.Unmarshal(output, &report); err != nil {
+		return nil, fmt.Errorf("failed to parse bandit output: %w", err)
+	}
+	if len(report.Results) == 0 && len(report.Errors) > 0 {
+		return nil, fmt.Errorf("bandit failed: %s", report.Errors[0].Reason)
+	}
+
+	issues := make([]linters.Issue, 0, len(report.Results))
+	for _, result := range report.Results {
+		severity, ok := banditSeverities[strings.ToUpper(result.Severity)]
+		if !ok {
+			severity = "warning"
+		}
+		message := result.IssueText
+		if result.TestName != "" && result.Confidence != "" {
+			message = fmt.Sprintf("%s (%s, %s confidence)", message, result.TestName, strings.ToLower(result.Confidence))
+		}
+		issues = append(issues, linters.Issue{
+			File:     filePath,
+			Line:     max(result.LineNumber, 1),
+			Column:   result.ColOffset + 1,
+			Severity: severity,
+			Message:  message,
+			Rule:     result.TestID,
+		})
+	}
+	return issues, nil
+}
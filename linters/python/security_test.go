@@ -0,0 +1,56 @@
+package python
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPythonLinter_Bandit(t *testing.T) {
+	fake := fakePythonTools(t)
+	filePath := filepath.Join(t.TempDir(), "run.py")
+
+	tests := []struct {
+		name        string
+		bandit      bool
+		content     string
+		wantRules   string
+		wantSuccess bool
+	}{
+		{"shell=True blocks", true, "import subprocess\nsubprocess.run(cmd, shell=True)\nassert ok\n", "B602:error,B101:info", false},
+		{"clean file", true, "print('hi')\n", "", true},
+		{"off by default", false, "import subprocess\nsubprocess.run(cmd, shell=True)\n", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultPythonConfig()
+			config.Bandit = tt.bandit
+			linter := NewPythonLinterWithConfig(config)
+			linter.initOnce.Do(func() {})
+			linter.hasUV, linter.uvPath = true, fake
+
+			result, err := linter.Lint(context.Background(), filePath, []byte(tt.content))
+			if err != nil {
+				t.Fatalf("Lint() error = %v", err)
+			}
+			var rules []string
+			for _, issue := range result.Issues {
+				rules = append(rules, issue.Rule+":"+issue.Severity)
+			}
+			if got := strings.Join(rules, ","); got != tt.wantRules {
+				t.Errorf("issues = %s, want %s (%+v)", got, tt.wantRules, result.Issues)
+			}
+			if result.Success != tt.wantSuccess {
+				t.Errorf("Success = %v, want %v", result.Success, tt.wantSuccess)
+			}
+			if tt.wantRules != "" {
+				if issue := result.Issues[0]; issue.Line != 2 || issue.File != filePath ||
+					!strings.Contains(issue.Message, "subprocess_popen_with_shell_equals_true, high confidence") {
+					t.Errorf("first issue = %+v", issue)
+				}
+			}
+		})
+	}
+}
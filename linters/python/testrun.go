@@ -0,0 +1,103 @@
+package python
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+// pytestNoTestsCollected is pytest's exit code when nothing was selected, such
+// as a testKeyword no test in the file matches
+const pytestNoTestsCollected = 5
+
+// runTests runs the tests of a written test file with the configured runner,
+// from the project's virtualenv or with uv run, in the project root so the
+// tests import the project as they do when run by hand. The file is selected
+// by its pytest node ID or unittest module name. Content that hasn't been
+// written yet isn't tested, since the runner imports the file from disk.
+func (l *PythonLinter) runTests(ctx context.Context, filePath string, content []byte) (string, error) {
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return "", nil
+	}
+	if onDisk, err := os.ReadFile(absPath); err != nil || !bytes.Equal(onDisk, content) { // #nosec G304 - the linted file
+		return "", nil
+	}
+	project := l.findProject(filePath)
+	relPath, err := filepath.Rel(project.Root, absPath)
+	if err != nil {
+		return "", nil
+	}
+	command, args, tool := l.testCommand(filePath, filepath.ToSlash(relPath))
+	if command == "" {
+		return "", nil
+	}
+
+	if l.config.TestTimeout != nil && l.config.TestTimeout.Duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, l.config.TestTimeout.Duration)
+		defer cancel()
+	}
+	release, err := l.acquireTool(ctx, command, tool)
+	if err != nil {
+		return "", err
+	}
+	defer release()
+
+	cmd := l.runner.Command(ctx, l.Name(), command, args...)
+	cmd.Dir = project.Root
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := linters.Run(cmd); err != nil {
+		output := stdout.String() + "\n" + stderr.String()
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return output, fmt.Errorf("tests timed out after %s", l.config.TestTimeout.Duration)
+		}
+		var exitErr *exec.ExitError
+		if tool != "python" && errors.As(err, &exitErr) && exitErr.ExitCode() == pytestNoTestsCollected {
+			return output, nil
+		}
+		return output, fmt.Errorf("tests failed")
+	}
+
+	return stdout.String(), nil
+}
+
+// testCommand returns how to run the configured test runner on the test file
+// at relPath in its project, and the tool it runs. pytest is given the file's
+// node ID and unittest its module name; testKeyword selects tests with -k.
+func (l *PythonLinter) testCommand(filePath, relPath string) (command string, args []string, tool string) {
+	args = append(args, l.config.TestArgs...)
+	if l.config.TestKeyword != "" {
+		args = append(args, "-k", l.config.TestKeyword)
+	}
+	if l.config.TestRunner == "unittest" {
+		tool = "python"
+		module := strings.ReplaceAll(strings.TrimSuffix(relPath, ".py"), "/", ".")
+		args = append(append([]string{"-m", "unittest"}, args...), module)
+	} else {
+		tool = l.config.TestRunner
+		if tool == "" {
+			tool = "pytest"
+		}
+		args = append(args, relPath)
+	}
+
+	if venv := l.venvTool(filePath, tool); venv != "" {
+		return venv, args, tool
+	}
+	if l.hasUV {
+		return l.uvPath, append([]string{"run", tool}, args...), tool
+	}
+	return "", nil, ""
+}
@@ -0,0 +1,92 @@
+package python
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestPythonLinter_RunTests(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake test runners are shell scripts")
+	}
+	root := t.TempDir()
+	bin := filepath.Join(root, ".venv", "bin")
+	testFile := filepath.Join(root, "tests", "test_app.py")
+	for _, dir := range []string{bin, filepath.Dir(testFile)} {
+		if err := os.MkdirAll(dir, 0750); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(root, "pyproject.toml"), []byte("[project]\nname = \"app\"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	// The runners record how they were run and exit with the code in EXIT
+	calls := filepath.Join(t.TempDir(), "calls")
+	script := "#!/bin/sh\necho \"$(pwd) $*\" >> " + calls + "\necho collected\nexit ${EXIT:-0}\n"
+	for _, tool := range []string{"pytest", "python"} {
+		if err := os.WriteFile(filepath.Join(bin, tool), []byte(script), 0700); err != nil {
+			t.Fatal(err)
+		}
+	}
+	content := []byte("def test_app():\n    assert True\n")
+	if err := os.WriteFile(testFile, content, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	lastCall := func() string {
+		t.Helper()
+		data, err := os.ReadFile(calls)
+		if err != nil {
+			return ""
+		}
+		lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+		return lines[len(lines)-1]
+	}
+
+	config := DefaultPythonConfig()
+	config.TestKeyword = "not slow"
+	linter := NewPythonLinterWithConfig(config)
+	ctx := context.Background()
+
+	// pytest runs the file by its node ID in the project root
+	output, err := linter.runTests(ctx, testFile, content)
+	if err != nil || !strings.Contains(output, "collected") {
+		t.Fatalf("runTests() = %q, %v", output, err)
+	}
+	if want := root + " -v -k not slow tests/test_app.py"; lastCall() != want {
+		t.Errorf("pytest ran as %q, want %q", lastCall(), want)
+	}
+
+	t.Setenv("EXIT", "1")
+	if _, err := linter.runTests(ctx, testFile, content); err == nil {
+		t.Error("failing tests should be reported")
+	}
+	t.Setenv("EXIT", "5")
+	if _, err := linter.runTests(ctx, testFile, content); err != nil {
+		t.Errorf("no tests selected by the keyword should not fail: %v", err)
+	}
+	t.Setenv("EXIT", "0")
+
+	// Content that isn't written yet isn't tested
+	if err := os.Remove(calls); err != nil {
+		t.Fatal(err)
+	}
+	if output, err := linter.runTests(ctx, testFile, []byte("def test_new():\n    pass\n")); output != "" || err != nil || lastCall() != "" {
+		t.Errorf("unwritten content ran %q: %q, %v", lastCall(), output, err)
+	}
+
+	// unittest runs the file by its module name
+	config.TestRunner = "unittest"
+	config.TestArgs = nil
+	config.TestKeyword = ""
+	if _, err := linter.runTests(ctx, testFile, content); err != nil {
+		t.Fatal(err)
+	}
+	if want := root + " -m unittest tests.test_app"; lastCall() != want {
+		t.Errorf("unittest ran as %q, want %q", lastCall(), want)
+	}
+}
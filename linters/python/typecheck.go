@@ -0,0 +1,231 @@
+package python
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+// Supported type checkers
+const (
+	TypeCheckerMypy    = "mypy"
+	TypeCheckerPyright = "pyright"
+)
+
+// mypyDiagnostic is one line of mypy's -O json output
+type mypyDiagnostic struct {
+	File     string  `json:"file"`
+	Line     int     `json:"line"`
+	Column   int     `json:"column"` // 0-based, -1 when unknown
+	Message  string  `json:"message"`
+	Hint     *string `json:"hint"`
+	Code     *string `json:"code"`
+	Severity string  `json:"severity"` // "error" or "note"
+}
+
+// pyrightOutput is pyright's --outputjson report
+type pyrightOutput struct {
+	GeneralDiagnostics []pyrightDiagnostic `json:"generalDiagnostics"`
+}
+
+// pyrightDiagnostic is a single pyright finding
+type pyrightDiagnostic struct {
+	File     string `json:"file"`
+	Severity string `json:"severity"` // "error", "warning" or "information"
+	Message  string `json:"message"`
+	Rule     string `json:"rule"`
+	Range    struct {
+		Start struct {
+			Line      int `json:"line"`      // 0-based
+			Character int `json:"character"` // 0-based
+		} `json:"start"`
+	} `json:"range"`
+}
+
+// runTypeCheck type-checks content with the configured checker, run through uv
+// or, without uv, an installed copy found with the tool cache. It returns no
+// issues when type checking is off or the checker isn't available.
+func (l *PythonLinter) runTypeCheck(ctx context.Context, filePath string, content []byte) ([]linters.Issue, error) {
+	checker := l.config.TypeChecker
+	if checker == "" {
+		return nil, nil
+	}
+	if checker != TypeCheckerMypy && checker != TypeCheckerPyright {
+		return nil, fmt.Errorf("unsupported type checker %q", checker)
+	}
+
+	command, args := l.toolCommand(filePath, checker)
+	if command == "" {
+		return nil, nil
+	}
+
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	// The content may not be on disk yet, so the checker reads it from a copy
+	tmpDir, err := os.MkdirTemp("", "gismo-typecheck-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+	if resolved, err := filepath.EvalSymlinks(tmpDir); err == nil {
+		tmpDir = resolved
+	}
+	tmpFile := filepath.Join(tmpDir, filepath.Base(filePath))
+	if err := os.WriteFile(tmpFile, content, 0600); err != nil {
+		return nil, fmt.Errorf("failed to write temp file: %w", err)
+	}
+
+	target := tmpFile
+	switch checker {
+	case TypeCheckerMypy:
+		args = append(args, "-O", "json")
+		args = append(args, l.config.TypeCheckArgs...)
+		// mypy checks an existing file in place, with the new content shadowing it
+		if _, err := os.Stat(absPath); err == nil {
+			args = append(args, "--shadow-file", absPath, tmpFile)
+			target = absPath
+		}
+	case TypeCheckerPyright:
+		args = append(args, "--outputjson")
+		args = append(args, l.config.TypeCheckArgs...)
+	}
+	args = append(args, target)
+
+	release, err := l.acquireTool(ctx, command, checker)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	dir := l.findProject(filePath).Root
+	cmd := l.runner.Command(ctx, l.Name(), command, args...)
+	cmd.Dir = dir
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	// Type checkers exit non-zero when they find errors
+	runErr := linters.Run(cmd)
+
+	// Only findings in the checked file are reported, not those of its imports
+	isTarget := func(file string) bool {
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(dir, file)
+		}
+		file = filepath.Clean(file)
+		return file == target || file == tmpFile
+	}
+
+	var issues []linters.Issue
+	if checker == TypeCheckerMypy {
+		issues, err = l.parseMypyOutput(stdout.Bytes(), filePath, isTarget)
+	} else {
+		issues, err = l.parsePyrightOutput(stdout.Bytes(), filePath, isTarget)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if runErr != nil && len(issues) == 0 && stdout.Len() == 0 {
+		return nil, fmt.Errorf("%s failed: %w: %s", checker, runErr, strings.TrimSpace(stderr.String()))
+	}
+	return issues, nil
+}
+
+// parseMypyOutput converts mypy's JSON lines into issues. Notes are left out,
+// since they explain the error reported before them.
+func (l *PythonLinter) parseMypyOutput(output []byte, filePath string, isTarget func(string) bool) ([]linters.Issue, error) {
+	var issues []linters.Issue
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var diag mypyDiagnostic
+		if err := json.Unmarshal(line, &diag); err != nil {
+			return nil, fmt.Errorf("failed to parse mypy output: %w", err)
+		}
+		if diag.Severity != "error" || !isTarget(diag.File) {
+			continue
+		}
+		message := diag.Message
+		if diag.Hint != nil && *diag.Hint != "" {
+			message += " (" + *diag.Hint + ")"
+		}
+		rule := "mypy"
+		if diag.Code != nil && *diag.Code != "" {
+			rule = *diag.Code
+		}
+		issues = append(issues, linters.Issue{
+			File:     filePath,
+			Line:     max(diag.Line, 1),
+			Column:   max(diag.Column+1, 1),
+			Severity: l.typeErrorSeverity(),
+			Message:  message,
+			Rule:     rule,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read mypy output: %w", err)
+	}
+	return issues, nil
+}
+
+// parsePyrightOutput converts pyright's JSON report into issues. Information
+// diagnostics are left out.
+func (l *PythonLinter) parsePyrightOutput(output []byte, filePath string, isTarget func(string) bool) ([]linters.Issue, error) {
+	if len(bytes.TrimSpace(output)) == 0 {
+		return nil, nil
+	}
+	var report pyrightOutput
+	if err := json.Unmarshal(output, &report); err != nil {
+		return nil, fmt.Errorf("failed to parse pyright output: %w", err)
+	}
+	var issues []linters.Issue
+	for _, diag := range report.GeneralDiagnostics {
+		if !isTarget(diag.File) {
+			continue
+		}
+		severity := l.typeErrorSeverity()
+		switch diag.Severity {
+		case "error":
+		case "warning":
+			severity = "warning"
+		default:
+			continue
+		}
+		rule := "pyright"
+		if diag.Rule != "" {
+			rule = diag.Rule
+		}
+		issues = append(issues, linters.Issue{
+			File:     filePath,
+			Line:     diag.Range.Start.Line + 1,
+			Column:   diag.Range.Start.Character + 1,
+			Severity: severity,
+			Message:  diag.Message,
+			Rule:     rule,
+		})
+	}
+	return issues, nil
+}
+
+// typeErrorSeverity returns the configured severity of type errors
+func (l *PythonLinter) typeErrorSeverity() string {
+	if l.config.TypeCheckSeverity == "warning" {
+		return "warning"
+	}
+	return "error"
+}
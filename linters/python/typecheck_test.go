@@ -0,0 +1,164 @@
+package python
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/jrossi/gismo/linters"
+	"github.com/jrossi/gismo/toolcache"
+)
+
+// fakePythonTools writes a script standing in for uv, mypy, pyright and
+// bandit. Ruff finds nothing; mypy and pyright report type errors in the file
+// they check, which is their last argument, and one in an imported module.
+// bandit reports a shell=True subprocess and an assert read from stdin.
+func fakePythonTools(t *testing.T) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake Python tools are a shell script")
+	}
+	script := `#!/bin/sh
+tool=$(basename "$0")
+[ "$1" = "tool" ] && tool=$3
+for arg; do file=$arg; done
+case "$tool" in
+ruff) cat > /dev/null; [ "$2" = "check" ] && echo '[]'; exit 0 ;;
+mypy)
+  echo '{"file": "'"$file"'", "line": 3, "column": 11, "message": "Argument 1 to \"f\" has incompatible type \"str\"; expected \"int\"", "hint": null, "code": "arg-type", "severity": "error"}'
+  echo '{"file": "'"$file"'", "line": 3, "column": 11, "message": "See the docs", "hint": null, "code": null, "severity": "note"}'
+  echo '{"file": "lib/other.py", "line": 1, "column": 0, "message": "Missing return", "hint": null, "code": "return", "severity": "error"}'
+  exit 1 ;;
+pyright)
+  echo '{"generalDiagnostics": [
+    {"file": "'"$file"'", "severity": "error", "message": "Cannot access attribute", "rule": "reportAttributeAccessIssue", "range": {"start": {"line": 4, "character": 2}}},
+    {"file": "'"$file"'", "severity": "information", "message": "Unused", "range": {"start": {"line": 0, "character": 0}}},
+    {"file": "/elsewhere/other.py", "severity": "error", "message": "Other file", "rule": "reportGeneralTypeIssues", "range": {"start": {"line": 0, "character": 0}}}
+  ]}'
+  exit 1 ;;
+bandit)
+  grep -q "shell=True" || { echo '{"errors": [], "results": []}'; exit 0; }
+  echo '{"errors": [], "results": [
+    {"test_id": "B602", "test_name": "subprocess_popen_with_shell_equals_true", "issue_text": "subprocess call with shell=True identified, security issue.", "issue_severity": "HIGH", "issue_confidence": "HIGH", "line_number": 2, "col_offset": 0},
+    {"test_id": "B101", "test_name": "assert_used", "issue_text": "Use of assert detected.", "issue_severity": "LOW", "issue_confidence": "HIGH", "line_number": 3, "col_offset": 4}
+  ]}'
+  exit 1 ;;
+esac
+exit 2
+`
+	dir := t.TempDir()
+	path := filepath.Join(dir, "fake")
+	if err := os.WriteFile(path, []byte(script), 0700); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestPythonLinter_TypeCheck(t *testing.T) {
+	fake := fakePythonTools(t)
+	project := t.TempDir()
+	if err := os.WriteFile(filepath.Join(project, "pyproject.toml"), []byte("[project]\nname = \"app\"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	existing := filepath.Join(project, "app.py")
+	if err := os.WriteFile(existing, []byte("x = 1\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		checker     string
+		severity    string
+		useUV       bool
+		filePath    string
+		wantRule    string
+		wantLine    int
+		wantColumn  int
+		wantSuccess bool
+	}{
+		{"mypy via uv on an existing file", TypeCheckerMypy, "", true, existing, "arg-type", 3, 12, false},
+		{"mypy via uv on a new file", TypeCheckerMypy, "", true, filepath.Join(project, "new.py"), "arg-type", 3, 12, false},
+		{"pyright from the tool cache", TypeCheckerPyright, "", false, existing, "reportAttributeAccessIssue", 5, 3, false},
+		{"warning severity doesn't block", TypeCheckerMypy, "warning", true, existing, "arg-type", 3, 12, true},
+		{"off by default", "", "", true, existing, "", 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The fake acts as the checker it is installed as
+			cache := toolcache.NewMemoryCache()
+			if tt.checker != "" {
+				installed := filepath.Join(t.TempDir(), tt.checker)
+				if err := os.Symlink(fake, installed); err != nil {
+					t.Fatal(err)
+				}
+				cache.AddTool("python", tt.checker, installed)
+			}
+
+			config := DefaultPythonConfig()
+			config.TypeChecker = tt.checker
+			if tt.severity != "" {
+				config.TypeCheckSeverity = tt.severity
+			}
+			linter := NewPythonLinterWithToolCache(config, cache)
+			linter.initOnce.Do(func() {})
+			linter.hasUV, linter.uvPath = tt.useUV, fake
+
+			result, err := linter.Lint(context.Background(), tt.filePath, []byte("def f(n: int) -> int:\n    return n\nf(\"a\")\n"))
+			if err != nil {
+				t.Fatalf("Lint() error = %v", err)
+			}
+			var typeIssues []linters.Issue
+			for _, issue := range result.Issues {
+				if issue.Rule != "syntax" {
+					typeIssues = append(typeIssues, issue)
+				}
+			}
+			if tt.wantRule == "" {
+				if len(typeIssues) != 0 {
+					t.Errorf("expected no type check issues, got %+v", typeIssues)
+				}
+				return
+			}
+			if len(typeIssues) != 1 {
+				t.Fatalf("expected one type error in the checked file, got %+v", typeIssues)
+			}
+			issue := typeIssues[0]
+			if issue.Rule != tt.wantRule || issue.Line != tt.wantLine || issue.Column != tt.wantColumn || issue.File != tt.filePath {
+				t.Errorf("issue = %+v", issue)
+			}
+			if want := map[bool]string{true: "warning", false: "error"}[tt.wantSuccess]; issue.Severity != want {
+				t.Errorf("severity = %q, want %q", issue.Severity, want)
+			}
+			if result.Success != tt.wantSuccess {
+				t.Errorf("Success = %v, want %v", result.Success, tt.wantSuccess)
+			}
+		})
+	}
+}
+
+func TestPythonLinter_TypeCheckBatch(t *testing.T) {
+	fake := fakePythonTools(t)
+	dir := t.TempDir()
+	config := DefaultPythonConfig()
+	config.TypeChecker = TypeCheckerMypy
+	linter := NewPythonLinterWithConfig(config)
+	linter.initOnce.Do(func() {})
+	linter.hasUV, linter.uvPath = true, fake
+
+	files := map[string][]byte{
+		filepath.Join(dir, "a.py"): []byte("x = 1\n"),
+		filepath.Join(dir, "b.py"): []byte("y = 2\n"),
+	}
+	results, err := linter.LintBatch(context.Background(), files)
+	if err != nil {
+		t.Fatalf("LintBatch() error = %v", err)
+	}
+	for path, result := range results {
+		if result.Success || len(result.Issues) != 1 || result.Issues[0].Rule != "arg-type" || result.Issues[0].File != path {
+			t.Errorf("%s: result = %+v", path, result)
+		}
+	}
+}
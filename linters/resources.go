@@ -0,0 +1,89 @@
+package linters
+
+import (
+	"os/exec"
+	"strconv"
+	"time"
+)
+
+// I/O scheduling classes understood by ionice
+const (
+	IOClassIdle       = "idle"
+	IOClassBestEffort = "best-effort"
+)
+
+// ResourceLimits lowers the priority and bounds the resources of spawned tools so
+// hook executions don't starve the IDE or builds on shared machines. Limits are
+// applied by running the tool through nice, ionice and prlimit when they are
+// installed; missing wrappers are skipped.
+type ResourceLimits struct {
+	// Nice is the niceness increment for the tool, 0 leaves priority alone
+	Nice int
+	// IOClass is the ionice scheduling class, "idle" or "best-effort" (Linux only)
+	IOClass string
+	// MaxMemory caps the tool's address space in bytes, 0 means no limit (Linux only)
+	MaxMemory uint64
+	// MaxCPUTime caps the tool's CPU time, 0 means no limit (Linux only)
+	MaxCPUTime time.Duration
+}
+
+// Apply rewrites cmd to run through the wrappers enforcing the limits
+func (r ResourceLimits) Apply(cmd *exec.Cmd) {
+	if cmd.Err != nil || cmd.Path == "" {
+		return
+	}
+	wrapper := r.wrapper(exec.LookPath)
+	if len(wrapper) == 0 {
+		return
+	}
+
+	args := append(wrapper, cmd.Path)
+	if len(cmd.Args) > 1 {
+		args = append(args, cmd.Args[1:]...)
+	}
+	cmd.Path = wrapper[0]
+	cmd.Args = args
+}
+
+// wrapper returns the command prefix enforcing the limits, skipping wrappers
+// lookPath can't find. Each wrapper execs the next, so the tool keeps the pid
+// that context cancellation kills.
+func (r ResourceLimits) wrapper(lookPath func(string) (string, error)) []string {
+	var prefix []string
+	if r.Nice != 0 {
+		if path, err := lookPath("nice"); err == nil {
+			prefix = append(prefix, path, "-n", strconv.Itoa(r.Nice))
+		}
+	}
+	if class := ioniceClass(r.IOClass); class != "" {
+		if path, err := lookPath("ionice"); err == nil {
+			prefix = append(prefix, path, "-c", class)
+		}
+	}
+	if r.MaxMemory > 0 || r.MaxCPUTime > 0 {
+		if path, err := lookPath("prlimit"); err == nil {
+			prefix = append(prefix, path)
+			if r.MaxMemory > 0 {
+				prefix = append(prefix, "--as="+strconv.FormatUint(r.MaxMemory, 10))
+			}
+			if r.MaxCPUTime > 0 {
+				seconds := int64((r.MaxCPUTime + time.Second - 1) / time.Second)
+				prefix = append(prefix, "--cpu="+strconv.FormatInt(seconds, 10))
+			}
+			prefix = append(prefix, "--")
+		}
+	}
+	return prefix
+}
+
+// ioniceClass maps an I/O class name to its ionice number
+func ioniceClass(class string) string {
+	switch class {
+	case IOClassIdle:
+		return "3"
+	case IOClassBestEffort:
+		return "2"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,73 @@
+package linters
+
+import (
+	"errors"
+	"os/exec"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestResourceLimits_Wrapper(t *testing.T) {
+	all := func(name string) (string, error) { return "/usr/bin/" + name, nil }
+	noPrlimit := func(name string) (string, error) {
+		if name == "prlimit" {
+			return "", errors.New("not found")
+		}
+		return "/usr/bin/" + name, nil
+	}
+
+	tests := []struct {
+		name     string
+		limits   ResourceLimits
+		lookPath func(string) (string, error)
+		want     []string
+	}{
+		{"no limits", ResourceLimits{}, all, nil},
+		{"nice only", ResourceLimits{Nice: 10}, all, []string{"/usr/bin/nice", "-n", "10"}},
+		{
+			"all limits",
+			ResourceLimits{Nice: 5, IOClass: IOClassIdle, MaxMemory: 1 << 30, MaxCPUTime: 90 * time.Second},
+			all,
+			[]string{
+				"/usr/bin/nice", "-n", "5",
+				"/usr/bin/ionice", "-c", "3",
+				"/usr/bin/prlimit", "--as=1073741824", "--cpu=90", "--",
+			},
+		},
+		{"cpu time rounds up", ResourceLimits{MaxCPUTime: 1500 * time.Millisecond}, all, []string{"/usr/bin/prlimit", "--cpu=2", "--"}},
+		{"unknown io class ignored", ResourceLimits{IOClass: "realtime"}, all, nil},
+		{"missing wrapper skipped", ResourceLimits{IOClass: IOClassBestEffort, MaxMemory: 1024}, noPrlimit, []string{"/usr/bin/ionice", "-c", "2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.limits.wrapper(tt.lookPath); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("wrapper() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResourceLimits_Apply(t *testing.T) {
+	nice, err := exec.LookPath("nice")
+	if err != nil {
+		t.Skip("nice not installed")
+	}
+
+	cmd := exec.Command("sh", "-c", "echo ok")
+	original := cmd.Path
+	ResourceLimits{}.Apply(cmd)
+	if cmd.Path != original {
+		t.Fatalf("command rewritten without limits: %v", cmd.Args)
+	}
+
+	ResourceLimits{Nice: 10}.Apply(cmd)
+	want := []string{nice, "-n", "10", original, "-c", "echo ok"}
+	if cmd.Path != nice || !reflect.DeepEqual(cmd.Args, want) {
+		t.Fatalf("Apply() = %s %v, want %s %v", cmd.Path, cmd.Args, nice, want)
+	}
+	if out, err := cmd.Output(); err != nil || string(out) != "ok\n" {
+		t.Errorf("wrapped command output = %q, %v", out, err)
+	}
+}
@@ -1,8 +1,9 @@
 package rust
 
 import (
-	"encoding/json"
 	"time"
+
+	"github.com/jrossi/gismo/types"
 )
 
 // RustConfig represents rust linter specific configuration
@@ -16,7 +17,7 @@ type RustConfig struct {
 	// EnabledLints is a list of additional clippy lints to enable
 	EnabledLints []string `json:"enabledLints,omitempty"`
 	// TestTimeout is the timeout for running cargo test
-	TestTimeout *Duration `json:"testTimeout,omitempty"`
+	TestTimeout *types.Duration `json:"testTimeout,omitempty"`
 	// NoDeps runs clippy only on the given crate, without linting dependencies
 	NoDeps bool `json:"noDeps,omitempty"`
 	// AllTargets checks all targets (lib, bin, test, example, etc.)
@@ -29,34 +30,76 @@ type RustConfig struct {
 	Verbose bool `json:"verbose,omitempty"`
 }
 
-// Duration is a wrapper around time.Duration for JSON unmarshaling
-type Duration struct {
-	time.Duration
-}
+// configSchema is the JSON Schema for RustConfig
+const configSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "properties": {
+    "clippyConfig": {
+      "type": "string",
+      "description": "Path to clippy.toml"
+    },
+    "rustfmtConfig": {
+      "type": "string",
+      "description": "Path to rustfmt.toml"
+    },
+    "disabledLints": {
+      "type": "array",
+      "items": {
+        "type": "string"
+      },
+      "description": "Clippy lints to disable"
+    },
+    "enabledLints": {
+      "type": "array",
+      "items": {
+        "type": "string"
+      },
+      "description": "Additional clippy lints to enable"
+    },
+    "testTimeout": {
+      "type": [
+        "string",
+        "number"
+      ],
+      "description": "Timeout for cargo test, e.g. \"5m\""
+    },
+    "noDeps": {
+      "type": "boolean",
+      "description": "Run clippy on the crate only, without dependencies"
+    },
+    "allTargets": {
+      "type": "boolean",
+      "description": "Check all targets"
+    },
+    "allFeatures": {
+      "type": "boolean",
+      "description": "Activate all features"
+    },
+    "features": {
+      "type": "array",
+      "items": {
+        "type": "string"
+      },
+      "description": "Features to activate"
+    },
+    "verbose": {
+      "type": "boolean",
+      "description": "Enable verbose output"
+    }
+  },
+  "additionalProperties": false
+}`
 
-// UnmarshalJSON implements json.Unmarshaler for Duration
-func (d *Duration) UnmarshalJSON(b []byte) error {
-	var s string
-	if err := json.Unmarshal(b, &s); err != nil {
-		return err
-	}
-	duration, err := time.ParseDuration(s)
-	if err != nil {
-		return err
-	}
-	d.Duration = duration
-	return nil
-}
-
-// MarshalJSON implements json.Marshaler for Duration
-func (d Duration) MarshalJSON() ([]byte, error) {
-	return json.Marshal(d.Duration.String())
-}
+// Duration is kept for compatibility.
+//
+// Deprecated: use types.Duration.
+type Duration = types.Duration
 
 // DefaultRustConfig returns the default configuration for Rust linting
 func DefaultRustConfig() *RustConfig {
 	return &RustConfig{
-		TestTimeout: &Duration{Duration: 10 * time.Minute},
+		TestTimeout: types.NewDuration(10 * time.Minute),
 		NoDeps:      true,  // Default to checking only the current crate
 		AllTargets:  true,  // Check all targets by default
 		AllFeatures: false, // Don't enable all features by default
@@ -0,0 +1,165 @@
+//go:build !noembed
+
+package rust
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+// embeddedChecks lists the checks compiled into gismo that run without cargo
+var embeddedChecks = []string{"basic syntax"}
+
+// closingDelimiters maps each closing delimiter to its opening delimiter
+var closingDelimiters = map[byte]byte{')': '(', ']': '[', '}': '{'}
+
+// openDelimiter is an unclosed delimiter and where it was opened
+type openDelimiter struct {
+	char   byte
+	line   int
+	offset int
+}
+
+// checkBasicSyntax is the pure-Go syntax check used when cargo is not installed.
+// It doesn't parse Rust: it reports unbalanced delimiters and unterminated strings
+// and block comments, with messages modelled on rustc's.
+func checkBasicSyntax(filePath string, content []byte) []linters.Issue {
+	var issues []linters.Issue
+	positions := linters.NewPositions(content)
+	report := func(offset int, message string) {
+		line, column := positions.Position(offset)
+		issues = append(issues, linters.Issue{
+			File:     filePath,
+			Line:     line,
+			Column:   column,
+			Severity: "warning",
+			Message:  message,
+			Rule:     "basic-syntax",
+		})
+	}
+
+	var stack []openDelimiter
+	line := 1
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+		switch {
+		case c == '\n':
+			line++
+		case c == '/' && i+1 < len(content) && content[i+1] == '/':
+			for i+1 < len(content) && content[i+1] != '\n' {
+				i++
+			}
+		case c == '/' && i+1 < len(content) && content[i+1] == '*':
+			// Block comments nest in Rust
+			start, depth := i, 1
+			for i += 2; i < len(content) && depth > 0; i++ {
+				switch {
+				case content[i] == '\n':
+					line++
+				case content[i] == '/' && i+1 < len(content) && content[i+1] == '*':
+					depth++
+					i++
+				case content[i] == '*' && i+1 < len(content) && content[i+1] == '/':
+					depth--
+					i++
+				}
+			}
+			if depth > 0 {
+				report(start, "unterminated block comment")
+				return issues
+			}
+			i--
+		case c == 'r' && rawStringHashes(content[i+1:]) >= 0 && !identByte(content, i-1) ||
+			c == 'b' && i+1 < len(content) && content[i+1] == 'r' && rawStringHashes(content[i+2:]) >= 0 && !identByte(content, i-1):
+			// Raw strings end at a quote followed by the same number of hashes
+			start := i
+			if c == 'b' {
+				i++
+			}
+			hashes := rawStringHashes(content[i+1:])
+			closing := []byte("\"" + strings.Repeat("#", hashes))
+			i += hashes + 2
+			for i < len(content) && !bytes.HasPrefix(content[i:], closing) {
+				if content[i] == '\n' {
+					line++
+				}
+				i++
+			}
+			if i >= len(content) {
+				report(start, "unterminated raw string")
+				return issues
+			}
+			i += len(closing) - 1
+		case c == '"':
+			start := i
+			for i++; i < len(content) && content[i] != '"'; i++ {
+				if content[i] == '\\' {
+					i++
+				}
+				if i < len(content) && content[i] == '\n' {
+					line++
+				}
+			}
+			if i >= len(content) {
+				report(start, "unterminated double quote string")
+				return issues
+			}
+		case c == '\'':
+			// A quote starts a character literal, or a lifetime or label such as 'a
+			if i+1 < len(content) && content[i+1] == '\\' {
+				// Skip the escaped character, which may itself be a quote
+				i += 3
+				for i < len(content) && content[i] != '\'' && content[i] != '\n' {
+					i++
+				}
+			} else if _, size := utf8.DecodeRune(content[i+1:]); i+1+size < len(content) && content[i+1+size] == '\'' {
+				i += size + 1
+			}
+		case c == '(' || c == '[' || c == '{':
+			stack = append(stack, openDelimiter{char: c, line: line, offset: i})
+		case closingDelimiters[c] != 0:
+			if len(stack) == 0 {
+				report(i, fmt.Sprintf("unexpected closing delimiter: `%c`", c))
+				return issues
+			}
+			top := stack[len(stack)-1]
+			if top.char != closingDelimiters[c] {
+				report(i, fmt.Sprintf("mismatched closing delimiter: `%c` (opened with `%c` on line %d)", c, top.char, top.line))
+				return issues
+			}
+			stack = stack[:len(stack)-1]
+		}
+	}
+
+	if len(stack) > 0 {
+		top := stack[len(stack)-1]
+		report(top.offset, fmt.Sprintf("unclosed delimiter: `%c`", top.char))
+	}
+	return issues
+}
+
+// rawStringHashes returns the number of hashes opening a raw string whose r prefix
+// precedes rest, or -1 if rest doesn't open a raw string
+func rawStringHashes(rest []byte) int {
+	hashes := 0
+	for hashes < len(rest) && rest[hashes] == '#' {
+		hashes++
+	}
+	if hashes < len(rest) && rest[hashes] == '"' {
+		return hashes
+	}
+	return -1
+}
+
+// identByte reports whether content[i] is part of an identifier
+func identByte(content []byte, i int) bool {
+	if i < 0 {
+		return false
+	}
+	c := content[i]
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
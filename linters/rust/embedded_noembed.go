@@ -0,0 +1,13 @@
+//go:build noembed
+
+package rust
+
+import "github.com/jrossi/gismo/linters"
+
+// embeddedChecks is empty: noembed builds leave out the pure-Go fallbacks
+var embeddedChecks = []string{}
+
+// checkBasicSyntax reports nothing in noembed builds, which rely on cargo
+func checkBasicSyntax(filePath string, content []byte) []linters.Issue {
+	return nil
+}
@@ -0,0 +1,56 @@
+//go:build !noembed
+
+package rust
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCheckBasicSyntax(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+		line    int
+		column  int
+	}{
+		{name: "valid", content: "fn main() {\n    let v = vec![1, 2];\n    println!(\"{:?}\", v);\n}\n"},
+		{name: "lifetimes and chars", content: "fn f<'a>(s: &'a str) -> char {\n    let _ = '\\'';\n    let _ = '{';\n    'x'\n}\n"},
+		{name: "strings and comments hide delimiters", content: "fn main() {\n    // (\n    /* { /* nested */ [ */\n    let s = \"}\\\"\";\n    let r = r#\"\"(\"#;\n    let b = br\"]\";\n}\n"},
+		{name: "unclosed delimiter", content: "fn main() {\n    let x = 1;\n", want: "unclosed delimiter: `{`", line: 1, column: 11},
+		{name: "unexpected closing", content: "fn main() {}\n}\n", want: "unexpected closing delimiter: `}`", line: 2, column: 1},
+		{name: "mismatched closing", content: "fn main() {\n    let v = vec![1, 2);\n}\n", want: "mismatched closing delimiter: `)` (opened with `[` on line 2)", line: 2, column: 22},
+		{name: "unterminated string", content: "fn main() {\n    let s = \"abc;\n}\n", want: "unterminated double quote string", line: 2, column: 13},
+		{name: "unterminated raw string", content: "let s = r#\"abc\"\n", want: "unterminated raw string", line: 1, column: 9},
+		{name: "columns count characters", content: "fn main() { let s = \"é\"; )\n}\n", want: "mismatched closing delimiter: `)` (opened with `{` on line 1)", line: 1, column: 26},
+		{name: "unterminated block comment", content: "/* a /* b */\nfn main() {}\n", want: "unterminated block comment", line: 1, column: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := checkBasicSyntax("main.rs", []byte(tt.content))
+			if tt.want == "" {
+				if len(issues) != 0 {
+					t.Errorf("checkBasicSyntax() = %+v, want no issues", issues)
+				}
+				return
+			}
+			if len(issues) != 1 || issues[0].Message != tt.want || issues[0].Line != tt.line || issues[0].Column != tt.column || issues[0].Rule != "basic-syntax" {
+				t.Errorf("checkBasicSyntax() = %+v, want %q at %d:%d", issues, tt.want, tt.line, tt.column)
+			}
+		})
+	}
+}
+
+func TestRustLinter_WithoutCargo(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+
+	result, err := NewRustLinter().Lint(context.Background(), "main.rs", []byte("fn main() {\n"))
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+	if len(result.Issues) != 1 || result.Issues[0].Rule != "basic-syntax" {
+		t.Errorf("Lint() = %+v, want one basic-syntax issue", result)
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
@@ -13,6 +12,7 @@ import (
 	"time"
 
 	"github.com/jrossi/gismo/linters"
+	"github.com/jrossi/gismo/types"
 )
 
 // RustLinter handles Rust file linting, formatting, and test running with cargo tools
@@ -29,6 +29,10 @@ type RustLinter struct {
 	cargoOnce sync.Once
 	mu        sync.RWMutex
 	config    *RustConfig
+	// Filesystem used for project discovery and config lookups
+	fs linters.FileSystem
+	// Runs external tools with the engine's limits, caches and environment
+	runner *linters.CommandRunner
 }
 
 // CargoInfo contains information about a Cargo workspace or package
@@ -74,6 +78,8 @@ func NewRustLinterWithConfig(config *RustConfig) *RustLinter {
 
 	return &RustLinter{
 		cargoCache: make(map[string]*CargoInfo),
+		fs:         linters.OSFileSystem{},
+		runner:     linters.NewCommandRunner(linters.RunnerConfig{}),
 		config:     config,
 	}
 }
@@ -93,18 +99,41 @@ func (l *RustLinter) SetConfig(configData json.RawMessage) error {
 
 	// Set defaults if not provided
 	if l.config.TestTimeout == nil {
-		defaultTimeout := &Duration{Duration: 10 * time.Minute}
+		defaultTimeout := types.NewDuration(10 * time.Minute)
 		l.config.TestTimeout = defaultTimeout
 	}
 
 	return nil
 }
 
+// ConfigSchema returns the JSON Schema for the linter configuration
+func (l *RustLinter) ConfigSchema() json.RawMessage {
+	return json.RawMessage(configSchema)
+}
+
 // Name returns the linter name
 func (l *RustLinter) Name() string {
 	return "rust"
 }
 
+// SetCommandRunner sets the runner used to start external tools
+func (l *RustLinter) SetCommandRunner(runner *linters.CommandRunner) {
+	l.runner = runner
+}
+
+// Capabilities reports the built-in checks and the external tools used when installed
+func (l *RustLinter) Capabilities() linters.Capabilities {
+	return linters.Capabilities{
+		Embedded: embeddedChecks,
+		Tools:    []string{"cargo"},
+	}
+}
+
+// SetFileSystem sets the filesystem used for project discovery and config lookups
+func (l *RustLinter) SetFileSystem(fsys linters.FileSystem) {
+	l.fs = fsys
+}
+
 // CanHandle returns true for Rust files
 func (l *RustLinter) CanHandle(filePath string) bool {
 	return strings.HasSuffix(filePath, ".rs")
@@ -120,13 +149,13 @@ func (l *RustLinter) findCargoTools() {
 
 			// Check if clippy is available
 			cmd := exec.Command(path, "clippy", "--version")
-			if err := cmd.Run(); err == nil {
+			if err := linters.Run(cmd); err == nil {
 				l.cargoPaths.clippy = path
 			}
 
 			// Check if rustfmt is available
 			cmd = exec.Command(path, "fmt", "--version")
-			if err := cmd.Run(); err == nil {
+			if err := linters.Run(cmd); err == nil {
 				l.cargoPaths.fmt = path
 			}
 		}
@@ -150,13 +179,13 @@ func (l *RustLinter) FindCargoRoot(startPath string) (*CargoInfo, error) {
 
 	// Walk up the directory tree
 	currentPath := absPath
-	if info, err := os.Stat(currentPath); err == nil && !info.IsDir() {
+	if info, err := l.fs.Stat(currentPath); err == nil && !info.IsDir() {
 		currentPath = filepath.Dir(currentPath)
 	}
 
 	for {
 		cargoTomlPath := filepath.Join(currentPath, "Cargo.toml")
-		if _, err := os.Stat(cargoTomlPath); err == nil {
+		if _, err := l.fs.Stat(cargoTomlPath); err == nil {
 			// Found Cargo.toml
 			cargoInfo := &CargoInfo{
 				Root:          currentPath,
@@ -164,7 +193,7 @@ func (l *RustLinter) FindCargoRoot(startPath string) (*CargoInfo, error) {
 			}
 
 			// Check if this is a workspace
-			if data, err := os.ReadFile(cargoTomlPath); err == nil {
+			if data, err := l.fs.ReadFile(cargoTomlPath); err == nil {
 				if bytes.Contains(data, []byte("[workspace]")) {
 					cargoInfo.IsWorkspace = true
 				}
@@ -188,17 +217,19 @@ func (l *RustLinter) FindCargoRoot(startPath string) (*CargoInfo, error) {
 	}
 }
 
-// runClippy executes cargo clippy on the specified file
-func (l *RustLinter) runClippy(ctx context.Context, filePath string) ([]ClippyMessage, error) {
+// runClippy executes cargo clippy on the specified file. partial is true if
+// clippy stopped mid-line, as when killed at a timeout, so messages may be
+// incomplete.
+func (l *RustLinter) runClippy(ctx context.Context, filePath string) (messages []ClippyMessage, partial bool, err error) {
 	l.findCargoTools()
 	if !l.cargoPaths.hasRust || l.cargoPaths.clippy == "" {
-		return nil, fmt.Errorf("cargo clippy not found")
+		return nil, false, fmt.Errorf("cargo clippy not found")
 	}
 
 	// Find cargo root for proper context
 	cargoInfo, err := l.FindCargoRoot(filePath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to find Cargo.toml: %w", err)
+		return nil, false, fmt.Errorf("failed to find Cargo.toml: %w", err)
 	}
 
 	// Build clippy arguments
@@ -231,9 +262,14 @@ func (l *RustLinter) runClippy(ctx context.Context, filePath string) ([]ClippyMe
 		args = append(args, "-A", lint)
 	}
 
+	release, err := l.runner.Acquire(ctx, l.cargoPaths.cargo)
+	if err != nil {
+		return nil, false, err
+	}
+	defer release()
+
 	// Execute clippy
-	// #nosec G204 - cargoPaths.cargo is validated through findCargoTools()
-	cmd := exec.CommandContext(ctx, l.cargoPaths.cargo, args...)
+	cmd := l.runner.Command(ctx, l.Name(), l.cargoPaths.cargo, args...)
 	cmd.Dir = cargoInfo.Root
 
 	var stdout, stderr bytes.Buffer
@@ -241,27 +277,33 @@ func (l *RustLinter) runClippy(ctx context.Context, filePath string) ([]ClippyMe
 	cmd.Stderr = &stderr
 
 	// clippy returns non-zero exit code when warnings are found, which is expected
-	err = cmd.Run()
+	err = linters.Run(cmd)
 
-	// Parse JSON output line by line
+	messages = parseClippyOutput(stdout.Bytes())
+
+	// Check if the error is due to actual failure (not just warnings)
+	if err != nil && len(messages) == 0 && stderr.Len() > 0 {
+		return nil, false, fmt.Errorf("cargo clippy failed: %v\nstderr: %s", err, stderr.String())
+	}
+
+	return messages, linters.IncompleteLine(stdout.Bytes()), nil
+}
+
+// parseClippyOutput parses cargo's JSON lines output, keeping the compiler
+// messages and skipping build progress and lines that aren't JSON
+func parseClippyOutput(output []byte) []ClippyMessage {
 	var messages []ClippyMessage
-	for _, line := range strings.Split(stdout.String(), "\n") {
-		if line == "" {
+	for _, line := range bytes.Split(output, []byte("\n")) {
+		if len(bytes.TrimSpace(line)) == 0 {
 			continue
 		}
 
 		var msg ClippyMessage
-		if err := json.Unmarshal([]byte(line), &msg); err == nil && msg.Reason == "compiler-message" {
+		if err := json.Unmarshal(line, &msg); err == nil && msg.Reason == "compiler-message" {
 			messages = append(messages, msg)
 		}
 	}
-
-	// Check if the error is due to actual failure (not just warnings)
-	if err != nil && len(messages) == 0 && stderr.Len() > 0 {
-		return nil, fmt.Errorf("cargo clippy failed: %v\nstderr: %s", err, stderr.String())
-	}
-
-	return messages, nil
+	return messages
 }
 
 // runFmtCheck checks if the file needs formatting
@@ -283,11 +325,16 @@ func (l *RustLinter) runFmtCheck(ctx context.Context, filePath string) (bool, er
 		args = append(args, "--verbose")
 	}
 
-	// #nosec G204 - cargoPaths.cargo is validated through findCargoTools()
-	cmd := exec.CommandContext(ctx, l.cargoPaths.cargo, args...)
+	release, err := l.runner.Acquire(ctx, l.cargoPaths.cargo)
+	if err != nil {
+		return false, err
+	}
+	defer release()
+
+	cmd := l.runner.Command(ctx, l.Name(), l.cargoPaths.cargo, args...)
 	cmd.Dir = cargoInfo.Root
 
-	err = cmd.Run()
+	err = linters.Run(cmd)
 	// If the command returns non-zero, formatting is needed
 	return err == nil, nil
 }
@@ -309,28 +356,16 @@ func (l *RustLinter) convertClippyMessages(messages []ClippyMessage, filePath st
 			continue
 		}
 
-		// Map clippy levels to our severity
-		severity := "warning"
-		switch msg.Message.Level {
-		case "error":
-			severity = "error"
-		case "warning":
-			severity = "warning"
-		case "note", "help":
-			severity = "info"
-		}
-
 		// Find the primary span for this file
 		for _, span := range msg.Message.Spans {
 			if span.FileName == filePath {
-				issue := linters.Issue{
-					File:     filePath,
-					Line:     span.LineStart,
-					Column:   span.ColumnStart,
-					Severity: severity,
-					Message:  strings.TrimSpace(msg.Message.Rendered),
-					Rule:     msg.Message.Code.Code,
-				}
+				issue := linters.ToolIssue("clippy", msg.Message.Level, linters.Issue{
+					File:    filePath,
+					Line:    span.LineStart,
+					Column:  span.ColumnStart,
+					Message: strings.TrimSpace(msg.Message.Rendered),
+					Rule:    msg.Message.Code.Code,
+				})
 				issues = append(issues, issue)
 				break // Only add one issue per message
 			}
@@ -347,10 +382,10 @@ func (l *RustLinter) Lint(ctx context.Context, filePath string, content []byte)
 		Issues:  []linters.Issue{},
 	}
 
-	// Skip if not in a Cargo project
-	if _, err := l.FindCargoRoot(filePath); err != nil {
-		// Not in a Cargo project, just check basic syntax
-		// For now, we'll skip files not in a Cargo project
+	// Without cargo or outside a Cargo project, only the embedded checks can run
+	l.findCargoTools()
+	if _, err := l.FindCargoRoot(filePath); err != nil || !l.cargoPaths.hasRust {
+		result.Issues = append(result.Issues, checkBasicSyntax(filePath, content)...)
 		return result, nil
 	}
 
@@ -368,9 +403,12 @@ func (l *RustLinter) Lint(ctx context.Context, filePath string, content []byte)
 	}
 
 	// Run clippy
-	if messages, err := l.runClippy(ctx, filePath); err == nil {
+	if messages, partial, err := l.runClippy(ctx, filePath); err == nil {
 		clippyIssues := l.convertClippyMessages(messages, filePath)
 		result.Issues = append(result.Issues, clippyIssues...)
+		if partial {
+			result.MarkPartial("clippy", filePath)
+		}
 
 		// Check if any issues are errors
 		for _, issue := range clippyIssues {
@@ -382,7 +420,7 @@ func (l *RustLinter) Lint(ctx context.Context, filePath string, content []byte)
 	// If clippy fails, we continue with just formatting results
 
 	// Run tests if this is a test file or has tests
-	if strings.Contains(string(content), "#[test]") || strings.Contains(string(content), "#[cfg(test)]") {
+	if (strings.Contains(string(content), "#[test]") || strings.Contains(string(content), "#[cfg(test)]")) && !linters.IsStaticOnly(ctx) {
 		if output, err := l.runTests(ctx, filePath); err != nil {
 			result.Success = false
 			result.Issues = append(result.Issues, linters.Issue{
@@ -449,16 +487,21 @@ func (l *RustLinter) runTests(ctx context.Context, filePath string) (string, err
 		args = append(args, "--features", strings.Join(l.config.Features, ","))
 	}
 
+	release, err := l.runner.Acquire(ctx, l.cargoPaths.cargo)
+	if err != nil {
+		return "", err
+	}
+	defer release()
+
 	// Run tests
-	// #nosec G204 - cargoPaths.cargo is validated through findCargoTools()
-	cmd := exec.CommandContext(ctx, l.cargoPaths.cargo, args...)
+	cmd := l.runner.Command(ctx, l.Name(), l.cargoPaths.cargo, args...)
 	cmd.Dir = cargoInfo.Root
 
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 
-	err = cmd.Run()
+	err = linters.Run(cmd)
 	output := stdout.String()
 	if stderr.Len() > 0 {
 		output += "\n" + stderr.String()
@@ -7,6 +7,8 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/jrossi/gismo/linters"
 )
 
 // Helper function to create a test Rust file
@@ -622,3 +624,17 @@ func TestRustLinter_SetConfig(t *testing.T) {
 		t.Errorf("Expected test timeout to be 5m, got %v", linter.config.TestTimeout.Duration)
 	}
 }
+
+func FuzzParseClippyOutput(f *testing.F) {
+	f.Add([]byte(`{"reason":"compiler-message","message":{"rendered":"warning: unused variable","level":"warning","spans":[{"file_name":"src/main.rs","line_start":2,"column_start":9}],"code":{"code":"unused_variables"}}}` + "\n"))
+	f.Add([]byte("{\"reason\":\"compiler-artifact\"}\n{\"reason\":\"compiler-message\",\"message\":{\"level\":\"error\",\"spans\":[{\"file_name\":\"src/main.rs\",\"line_start\":-4}],\"code\":null}}\n"))
+	f.Add([]byte("   Compiling demo v0.1.0\n"))
+	linter := NewRustLinter()
+	f.Fuzz(func(t *testing.T, output []byte) {
+		for _, issue := range linter.convertClippyMessages(parseClippyOutput(output), "src/main.rs") {
+			if !linters.IsSeverity(issue.Severity) || issue.Line < 0 || issue.Column < 0 || issue.File != "src/main.rs" {
+				t.Fatalf("invalid issue %+v", issue)
+			}
+		}
+	})
+}
@@ -0,0 +1,63 @@
+package secrets
+
+// SecretsConfig holds configuration for the secrets scanner
+type SecretsConfig struct {
+	// DisabledChecks lists check rules to skip, e.g. "high-entropy-string"
+	DisabledChecks []string `json:"disabledChecks,omitempty"`
+	// Severity of reported issues, "error" (default) blocks the change
+	Severity *string `json:"severity,omitempty"`
+	// Allowlist holds regular expressions; findings whose value or line matches one
+	// are not reported
+	Allowlist []string `json:"allowlist,omitempty"`
+	// MinEntropy is the Shannon entropy in bits per character above which a token
+	// counts as a high-entropy string, 4.5 by default
+	MinEntropy *float64 `json:"minEntropy,omitempty"`
+}
+
+// configSchema is the JSON Schema for SecretsConfig
+const configSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "properties": {
+    "disabledChecks": {
+      "type": "array",
+      "items": {
+        "type": "string"
+      },
+      "description": "Check rules to skip, e.g. \"high-entropy-string\""
+    },
+    "severity": {
+      "type": "string",
+      "enum": [
+        "error",
+        "warning",
+        "info"
+      ],
+      "description": "Severity of reported issues, \"error\" by default"
+    },
+    "allowlist": {
+      "type": "array",
+      "items": {
+        "type": "string"
+      },
+      "description": "Regular expressions; findings whose value or line matches one are not reported"
+    },
+    "minEntropy": {
+      "type": "number",
+      "minimum": 0,
+      "maximum": 8,
+      "description": "Shannon entropy in bits per character above which a token is reported as a high-entropy string, 4.5 by default"
+    }
+  },
+  "additionalProperties": false
+}`
+
+// DefaultMinEntropy is the default high-entropy threshold in bits per character
+const DefaultMinEntropy = 4.5
+
+// DefaultSecretsConfig returns the default configuration for the secrets scanner
+func DefaultSecretsConfig() *SecretsConfig {
+	severity := "error"
+	minEntropy := DefaultMinEntropy
+	return &SecretsConfig{Severity: &severity, MinEntropy: &minEntropy}
+}
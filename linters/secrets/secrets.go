@@ -0,0 +1,309 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+// Check rules reported by the linter
+const (
+	RuleAWSAccessKey      = "aws-access-key"
+	RuleAWSSecretKey      = "aws-secret-key"
+	RuleGitHubToken       = "github-token"
+	RulePrivateKey        = "private-key"
+	RuleHighEntropyString = "high-entropy-string"
+)
+
+// pattern is a credential format recognized by its shape
+type pattern struct {
+	rule        string
+	description string
+	regexp      *regexp.Regexp
+	// group is the submatch holding the secret, 0 for the whole match
+	group int
+}
+
+// patterns are the credential formats detected in every file
+var patterns = []pattern{
+	{
+		rule:        RuleAWSAccessKey,
+		description: "AWS access key ID",
+		regexp:      regexp.MustCompile(`\b(?:AKIA|ASIA|ABIA|ACCA)[0-9A-Z]{16}\b`),
+	},
+	{
+		rule:        RuleAWSSecretKey,
+		description: "AWS secret access key",
+		regexp:      regexp.MustCompile(`(?i)aws.{0,20}secret.{0,20}?[=:"'\s]\s*["']?([A-Za-z0-9/+]{40})(?:[^A-Za-z0-9/+]|$)`),
+		group:       1,
+	},
+	{
+		rule:        RuleGitHubToken,
+		description: "GitHub token",
+		regexp:      regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36,255}|github_pat_[A-Za-z0-9_]{22,255})\b`),
+	},
+	{
+		rule:        RulePrivateKey,
+		description: "private key",
+		regexp:      regexp.MustCompile(`-----BEGIN (?:(?:RSA|DSA|EC|OPENSSH|PGP|ENCRYPTED) )?PRIVATE KEY(?: BLOCK)?-----`),
+	},
+}
+
+// entropyCandidate matches tokens long enough to be generated credentials. An
+// equals sign only ends a token as base64 padding, so assignments are split.
+var entropyCandidate = regexp.MustCompile(`[A-Za-z0-9+/_\-]{20,}={0,2}`)
+
+// integrityHash matches subresource integrity hashes, which are random by design
+var integrityHash = regexp.MustCompile(`^sha(1|256|384|512)-`)
+
+// lockFiles hold integrity hashes that look random by design, so they are not
+// checked for high-entropy strings
+var lockFiles = map[string]bool{
+	"go.sum": true, "package-lock.json": true, ".package-lock.json": true, "npm-shrinkwrap.json": true, "yarn.lock": true,
+	"pnpm-lock.yaml": true, "Cargo.lock": true, "poetry.lock": true, "uv.lock": true,
+	"Pipfile.lock": true, "composer.lock": true, "Gemfile.lock": true,
+}
+
+// SecretsLinter blocks credentials written into any file: AWS keys, GitHub tokens,
+// private key blocks and high-entropy strings
+type SecretsLinter struct {
+	mu        sync.RWMutex
+	config    *SecretsConfig
+	allowlist []*regexp.Regexp
+}
+
+// NewSecretsLinter creates a new secrets scanner with default configuration
+func NewSecretsLinter() *SecretsLinter {
+	return NewSecretsLinterWithConfig(nil)
+}
+
+// NewSecretsLinterWithConfig creates a new secrets scanner with custom configuration.
+// Invalid allowlist patterns are ignored; SetConfig reports them.
+func NewSecretsLinterWithConfig(config *SecretsConfig) *SecretsLinter {
+	if config == nil {
+		config = DefaultSecretsConfig()
+	}
+	allowlist, _ := compileAllowlist(config.Allowlist)
+	return &SecretsLinter{config: config, allowlist: allowlist}
+}
+
+// Name returns the linter name
+func (l *SecretsLinter) Name() string {
+	return "secrets"
+}
+
+// Capabilities reports the built-in checks and the external tools used when installed
+func (l *SecretsLinter) Capabilities() linters.Capabilities {
+	return linters.Capabilities{
+		Embedded: []string{"AWS keys", "GitHub tokens", "private keys", "high-entropy strings"},
+	}
+}
+
+// Rules describes the check rules
+func (l *SecretsLinter) Rules() map[string]string {
+	return map[string]string{
+		RuleAWSAccessKey:      "AWS access key IDs (AKIA..., ASIA...) must not be committed; load credentials from the environment or a secret manager",
+		RuleAWSSecretKey:      "AWS secret access keys assigned in code or config must not be committed",
+		RuleGitHubToken:       "GitHub personal access, OAuth, app and refresh tokens (ghp_, gho_, ghu_, ghs_, ghr_, github_pat_) must not be committed",
+		RulePrivateKey:        "PEM private key blocks must not be committed; keep keys outside the repository",
+		RuleHighEntropyString: "Long random-looking tokens are likely generated credentials such as API keys or passwords",
+	}
+}
+
+// CanHandle returns true for every file; binary content is skipped in Lint
+func (l *SecretsLinter) CanHandle(filePath string) bool {
+	return true
+}
+
+// SetConfig updates the linter configuration
+func (l *SecretsLinter) SetConfig(config []byte) error {
+	secretsConfig := DefaultSecretsConfig()
+	if err := json.Unmarshal(config, secretsConfig); err != nil {
+		return fmt.Errorf("failed to parse secrets config: %w", err)
+	}
+	allowlist, err := compileAllowlist(secretsConfig.Allowlist)
+	if err != nil {
+		return err
+	}
+	l.mu.Lock()
+	l.config = secretsConfig
+	l.allowlist = allowlist
+	l.mu.Unlock()
+	return nil
+}
+
+// ConfigSchema returns the JSON Schema for the linter configuration
+func (l *SecretsLinter) ConfigSchema() json.RawMessage {
+	return json.RawMessage(configSchema)
+}
+
+// Lint reports credentials found in the content
+func (l *SecretsLinter) Lint(ctx context.Context, filePath string, content []byte) (*linters.LintResult, error) {
+	l.mu.RLock()
+	config := l.config
+	allowlist := l.allowlist
+	l.mu.RUnlock()
+
+	result := &linters.LintResult{Success: true}
+	// Binary files can't be scanned line by line
+	if bytes.IndexByte(content, 0) >= 0 || !utf8.Valid(content) {
+		return result, nil
+	}
+
+	severity := "error"
+	if config.Severity != nil && *config.Severity != "" {
+		severity = *config.Severity
+	}
+	minEntropy := DefaultMinEntropy
+	if config.MinEntropy != nil {
+		minEntropy = *config.MinEntropy
+	}
+	disabled := make(map[string]bool, len(config.DisabledChecks))
+	for _, rule := range config.DisabledChecks {
+		disabled[rule] = true
+	}
+	checkEntropy := !disabled[RuleHighEntropyString] && !lockFiles[filepath.Base(filePath)]
+
+	allowed := func(value, line string) bool {
+		for _, re := range allowlist {
+			if re.MatchString(value) || re.MatchString(line) {
+				return true
+			}
+		}
+		return false
+	}
+
+	inKeyBlock := false
+	for i, line := range strings.Split(string(content), "\n") {
+		lineNum := i + 1
+		// Spans already reported by a specific pattern aren't reported again as entropy
+		var found [][]int
+		for _, p := range patterns {
+			if disabled[p.rule] {
+				continue
+			}
+			for _, match := range p.regexp.FindAllStringSubmatchIndex(line, -1) {
+				start, end := match[2*p.group], match[2*p.group+1]
+				found = append(found, []int{start, end})
+				value := line[start:end]
+				if allowed(value, line) {
+					continue
+				}
+				result.Issues = append(result.Issues, newIssue(filePath, lineNum, linters.Column(line, start), severity, p.rule, p.description, value))
+			}
+		}
+
+		// The body of a private key block is reported once, by its header
+		if strings.Contains(line, "-----BEGIN ") && strings.Contains(line, "PRIVATE KEY") {
+			inKeyBlock = true
+			continue
+		}
+		if inKeyBlock {
+			inKeyBlock = !strings.Contains(line, "-----END ")
+			continue
+		}
+		if !checkEntropy {
+			continue
+		}
+		for _, span := range entropyCandidate.FindAllStringIndex(line, -1) {
+			value := line[span[0]:span[1]]
+			if overlaps(found, span) || integrityHash.MatchString(value) || !looksGenerated(value) || shannonEntropy(value) < minEntropy || allowed(value, line) {
+				continue
+			}
+			result.Issues = append(result.Issues, newIssue(filePath, lineNum, linters.Column(line, span[0]), severity, RuleHighEntropyString, "high-entropy string", value))
+		}
+	}
+
+	for _, issue := range result.Issues {
+		if issue.Severity == "error" {
+			result.Success = false
+			break
+		}
+	}
+	return result, nil
+}
+
+// newIssue builds the issue for a secret, showing only enough of it to find it
+func newIssue(filePath string, line, column int, severity, rule, description, value string) linters.Issue {
+	return linters.Issue{
+		File:     filePath,
+		Line:     line,
+		Column:   column,
+		Severity: severity,
+		Message: fmt.Sprintf("possible %s %s; remove it and load it from the environment or a secret manager",
+			description, redact(value)),
+		Rule: rule,
+	}
+}
+
+// redact keeps the first characters of a secret so it can be located without
+// repeating it in feedback and logs
+func redact(value string) string {
+	if len(value) <= 8 {
+		return strings.Repeat("*", len(value))
+	}
+	return value[:4] + strings.Repeat("*", 8)
+}
+
+// overlaps reports whether span overlaps any of the found spans
+func overlaps(found [][]int, span []int) bool {
+	for _, f := range found {
+		if span[0] < f[1] && f[0] < span[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// looksGenerated reports whether a token mixes letters and digits like generated
+// credentials do, rather than being a long identifier or word
+func looksGenerated(value string) bool {
+	var letters, digits bool
+	for _, r := range value {
+		switch {
+		case unicode.IsLetter(r):
+			letters = true
+		case unicode.IsDigit(r):
+			digits = true
+		}
+	}
+	return letters && digits
+}
+
+// shannonEntropy returns the Shannon entropy of s in bits per character
+func shannonEntropy(s string) float64 {
+	counts := make(map[rune]int)
+	for _, r := range s {
+		counts[r]++
+	}
+	n := float64(utf8.RuneCountInString(s))
+	var entropy float64
+	for _, count := range counts {
+		p := float64(count) / n
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+// compileAllowlist compiles allowlist patterns
+func compileAllowlist(patterns []string) ([]*regexp.Regexp, error) {
+	var compiled []*regexp.Regexp
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid secrets allowlist pattern %q: %w", pattern, err)
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
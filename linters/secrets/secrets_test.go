@@ -0,0 +1,162 @@
+package secrets
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// Sample credentials are split so this file doesn't trip the scanner itself
+var (
+	awsAccessKey = "AKIA" + "IOSFODNN7EXAMPLE"
+	awsSecretKey = "wJalrXUtnFEMI/K7MDENG/" + "bPxRfiCYEXAMPLEKEY"
+	githubToken  = "ghp_" + "a1B2c3D4e5F6g7H8i9J0k1L2m3N4o5P6q7R8"
+	apiKey       = "sk_live_" + "9fQ2xVb7LmZt4RkW8pYc3NhJ6sDg"
+)
+
+func TestSecretsLinter_Lint(t *testing.T) {
+	tests := []struct {
+		name      string
+		file      string
+		content   string
+		wantRules []string
+	}{
+		{
+			name:      "aws access key in go",
+			file:      "config.go",
+			content:   "package config\n\nconst key = \"" + awsAccessKey + "\"\n",
+			wantRules: []string{RuleAWSAccessKey},
+		},
+		{
+			name:      "aws secret key in credentials file",
+			file:      "credentials",
+			content:   "[default]\naws_secret_access_key = " + awsSecretKey + "\n",
+			wantRules: []string{RuleAWSSecretKey},
+		},
+		{
+			name:      "github token in yaml",
+			file:      "ci.yaml",
+			content:   "env:\n  GITHUB_TOKEN: " + githubToken + "\n",
+			wantRules: []string{RuleGitHubToken},
+		},
+		{
+			name:      "private key block",
+			file:      "deploy/id_rsa",
+			content:   "-----BEGIN RSA " + "PRIVATE KEY-----\nMIIEowIBAAKCAQEA3Tz2mr7SZiAMfQyuvBjM9Oi6Z4qvG8b1x9pB2fXcE4rWsYk\n-----END RSA PRIVATE KEY-----\n",
+			wantRules: []string{RulePrivateKey},
+		},
+		{
+			name:      "high entropy api key",
+			file:      "settings.py",
+			content:   "STRIPE_KEY = \"" + apiKey + "\"\n",
+			wantRules: []string{RuleHighEntropyString},
+		},
+		{
+			name:    "ordinary code",
+			file:    "main.go",
+			content: "package main\n\nfunc TestCachingRuleEngine_HitsTrackBlocks() {}\n\nvar opts = \"NODE_OPTIONS=--max-old-space-size=4096\"\n",
+		},
+		{
+			name:    "hex digest and uuid",
+			file:    "checksums.txt",
+			content: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855\n123e4567-e89b-12d3-a456-426614174000\n",
+		},
+		{
+			name:    "integrity hash",
+			file:    "index.html",
+			content: "<script integrity=\"sha384-oqVuAfXRKap7fdgcCY5uykM6+R9GqQ8K/uxy9rx7HNQlGYl1kPzQho1wx4JwY8wC\"></script>\n",
+		},
+		{
+			name:    "lock file",
+			file:    "go.sum",
+			content: "example.com/mod v1.0.0 h1:Xv6a1B2c3D4e5F6g7H8i9J0k1L2m3N4o5P6q7R8s9T0=\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewSecretsLinter().Lint(context.Background(), tt.file, []byte(tt.content))
+			if err != nil {
+				t.Fatalf("Lint() error = %v", err)
+			}
+			var gotRules []string
+			for _, issue := range result.Issues {
+				gotRules = append(gotRules, issue.Rule)
+				if issue.Severity != "error" {
+					t.Errorf("issue %s severity = %q, want error", issue.Rule, issue.Severity)
+				}
+			}
+			if strings.Join(gotRules, ",") != strings.Join(tt.wantRules, ",") {
+				t.Errorf("Lint() rules = %v, want %v (issues %+v)", gotRules, tt.wantRules, result.Issues)
+			}
+			if result.Success != (len(tt.wantRules) == 0) {
+				t.Errorf("Lint() success = %v, want %v", result.Success, len(tt.wantRules) == 0)
+			}
+		})
+	}
+}
+
+func TestSecretsLinter_RedactsSecret(t *testing.T) {
+	result, _ := NewSecretsLinter().Lint(context.Background(), "a.env", []byte("TOKEN="+githubToken+"\n"))
+	if len(result.Issues) != 1 {
+		t.Fatalf("expected one issue, got %+v", result.Issues)
+	}
+	issue := result.Issues[0]
+	if strings.Contains(issue.Message, githubToken) || !strings.Contains(issue.Message, "ghp_") {
+		t.Errorf("message should show only a prefix of the token: %q", issue.Message)
+	}
+	if issue.Line != 1 || issue.Column != 7 {
+		t.Errorf("position = %d:%d, want 1:7", issue.Line, issue.Column)
+	}
+}
+
+func TestSecretsLinter_Config(t *testing.T) {
+	content := []byte("key = \"" + awsAccessKey + "\"\ntoken = \"" + apiKey + "\"\n")
+
+	tests := []struct {
+		name      string
+		config    string
+		wantRules []string
+		severity  string
+	}{
+		{name: "allowlist by value", config: `{"allowlist": ["EXAMPLE$"]}`, wantRules: []string{RuleHighEntropyString}},
+		{name: "allowlist by line", config: `{"allowlist": ["^token = "]}`, wantRules: []string{RuleAWSAccessKey}},
+		{name: "disabled check", config: `{"disabledChecks": ["high-entropy-string"]}`, wantRules: []string{RuleAWSAccessKey}},
+		{name: "entropy threshold", config: `{"minEntropy": 6}`, wantRules: []string{RuleAWSAccessKey}},
+		{name: "severity", config: `{"severity": "warning"}`, wantRules: []string{RuleAWSAccessKey, RuleHighEntropyString}, severity: "warning"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewSecretsLinter()
+			if err := l.SetConfig([]byte(tt.config)); err != nil {
+				t.Fatalf("SetConfig() error = %v", err)
+			}
+			result, err := l.Lint(context.Background(), "settings.toml", content)
+			if err != nil {
+				t.Fatalf("Lint() error = %v", err)
+			}
+			var gotRules []string
+			for _, issue := range result.Issues {
+				gotRules = append(gotRules, issue.Rule)
+				if tt.severity != "" && issue.Severity != tt.severity {
+					t.Errorf("severity = %q, want %q", issue.Severity, tt.severity)
+				}
+			}
+			if strings.Join(gotRules, ",") != strings.Join(tt.wantRules, ",") {
+				t.Errorf("Lint() rules = %v, want %v", gotRules, tt.wantRules)
+			}
+		})
+	}
+
+	if err := NewSecretsLinter().SetConfig([]byte(`{"allowlist": ["("]}`)); err == nil {
+		t.Error("SetConfig() should reject an invalid allowlist pattern")
+	}
+}
+
+func TestSecretsLinter_SkipsBinary(t *testing.T) {
+	result, _ := NewSecretsLinter().Lint(context.Background(), "blob.bin", []byte("\x00"+awsAccessKey))
+	if len(result.Issues) != 0 {
+		t.Errorf("expected binary content to be skipped, got %+v", result.Issues)
+	}
+}
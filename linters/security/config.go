@@ -0,0 +1,40 @@
+package security
+
+// SecurityConfig holds configuration for the security review linter
+type SecurityConfig struct {
+	// DisabledChecks lists check rules to skip, e.g. "dynamic-code-exec"
+	DisabledChecks []string `json:"disabledChecks,omitempty"`
+	// Severity of reported issues, "warning" (default) or "error" to block risky changes
+	Severity *string `json:"severity,omitempty"`
+}
+
+// configSchema is the JSON Schema for SecurityConfig
+const configSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "properties": {
+    "disabledChecks": {
+      "type": "array",
+      "items": {
+        "type": "string"
+      },
+      "description": "Check rules to skip, e.g. \"dynamic-code-exec\""
+    },
+    "severity": {
+      "type": "string",
+      "enum": [
+        "error",
+        "warning",
+        "info"
+      ],
+      "description": "Severity of reported issues, \"warning\" by default"
+    }
+  },
+  "additionalProperties": false
+}`
+
+// DefaultSecurityConfig returns the default configuration for security review
+func DefaultSecurityConfig() *SecurityConfig {
+	severity := "warning"
+	return &SecurityConfig{Severity: &severity}
+}
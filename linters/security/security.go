@@ -0,0 +1,281 @@
+package security
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+// check is a risky-change pattern applied to changed lines
+type check struct {
+	rule    string
+	message string
+	// patterns match a risky line; any match reports the check
+	patterns []*regexp.Regexp
+	// removals also reports lines the edit deletes, for checks where taking
+	// code away is the risk
+	removals bool
+}
+
+// checks are the risky patterns reviewed in every edit
+var checks = []check{
+	{
+		rule:    "tls-verify-disabled",
+		message: "disables TLS certificate verification",
+		patterns: []*regexp.Regexp{
+			regexp.MustCompile(`InsecureSkipVerify\s*:\s*true`),
+			regexp.MustCompile(`\bverify\s*=\s*False\b`),
+			regexp.MustCompile(`rejectUnauthorized\s*:\s*false`),
+			regexp.MustCompile(`NODE_TLS_REJECT_UNAUTHORIZED\s*=\s*['"]?0`),
+			regexp.MustCompile(`ssl\._create_unverified_context|CERT_NONE`),
+			regexp.MustCompile(`danger_accept_invalid_certs\s*\(\s*true`),
+			regexp.MustCompile(`\bcurl\b.*\s(-k|--insecure)\b`),
+		},
+	},
+	{
+		rule:    "cors-wildcard",
+		message: "allows cross-origin requests from any origin",
+		patterns: []*regexp.Regexp{
+			regexp.MustCompile(`(?i)Access-Control-Allow-Origin['"]?\s*[:,=]\s*['"]\*['"]`),
+			regexp.MustCompile(`AllowAllOrigins\s*:\s*true`),
+			regexp.MustCompile(`AllowedOrigins\s*:.*"\*"`),
+			regexp.MustCompile(`\borigins?\s*[:=]\s*(['"]\*['"]|true\b)`),
+			regexp.MustCompile(`allow_origins\s*=\s*\[\s*['"]\*['"]`),
+			regexp.MustCompile(`CORS_(ORIGIN_)?ALLOW_ALL(_ORIGINS)?\s*=\s*True`),
+		},
+	},
+	{
+		rule:    "dynamic-code-exec",
+		message: "executes dynamically built code or shell commands",
+		patterns: []*regexp.Regexp{
+			regexp.MustCompile(`(^|[^\w.])eval\s*\(`),
+			regexp.MustCompile(`new\s+Function\s*\(`),
+			regexp.MustCompile(`(^|[^\w.])exec\s*\(`),
+			regexp.MustCompile(`\bos\.(system|popen)\s*\(`),
+			regexp.MustCompile(`subprocess\.\w+\(.*shell\s*=\s*True`),
+			regexp.MustCompile(`child_process['"]?\)?\.?(exec|execSync)?`),
+			regexp.MustCompile(`exec\.Command(Context)?\((ctx,\s*)?"(sh|bash)",\s*"-c"`),
+		},
+	},
+	{
+		rule:    "auth-middleware-change",
+		message: "changes authentication or authorization middleware",
+		patterns: []*regexp.Regexp{
+			regexp.MustCompile(`(?i)(\.use|\.Use|middleware|before_action|permission_classes|Depends)\W.*\b\w*(auth|jwt|login|permission)\w*`),
+			regexp.MustCompile(`(?i)@(login_required|permission_required|PreAuthorize|Secured|RolesAllowed|authenticated)\b`),
+			regexp.MustCompile(`(?i)\b(skip_before_action|AllowAny|permitAll|@PermitAll|anonymous\(\))`),
+		},
+		removals: true,
+	},
+	{
+		rule:    "world-writable-permissions",
+		message: "makes files or directories world-writable",
+		patterns: []*regexp.Regexp{
+			regexp.MustCompile(`\bchmod\s+(-R\s+)?(0?777|a\+w|o\+w)\b`),
+			regexp.MustCompile(`(?i)(chmod|mkdir|mkdirall|makedirs|writefile|openfile|umask)\b.*\b0o?777\b`),
+			regexp.MustCompile(`\bumask\s*\(?\s*0?0?0\s*\)?\s*$`),
+		},
+	},
+}
+
+// docExtensions are prose files where risky snippets are examples, not code
+var docExtensions = map[string]bool{
+	".md": true, ".markdown": true, ".mdx": true, ".rst": true, ".txt": true, ".adoc": true,
+}
+
+// SecurityLinter reviews the lines an edit changes for risky patterns, independent
+// of the file's language
+type SecurityLinter struct {
+	mu     sync.RWMutex
+	config *SecurityConfig
+	// Filesystem used to read the file before the edit
+	fs linters.FileSystem
+}
+
+// NewSecurityLinter creates a new security review linter with default configuration
+func NewSecurityLinter() *SecurityLinter {
+	return NewSecurityLinterWithConfig(nil)
+}
+
+// NewSecurityLinterWithConfig creates a new security review linter with custom configuration
+func NewSecurityLinterWithConfig(config *SecurityConfig) *SecurityLinter {
+	if config == nil {
+		config = DefaultSecurityConfig()
+	}
+	return &SecurityLinter{config: config, fs: linters.OSFileSystem{}}
+}
+
+// Name returns the linter name
+func (l *SecurityLinter) Name() string {
+	return "security"
+}
+
+// Capabilities reports the built-in checks and the external tools used when installed
+func (l *SecurityLinter) Capabilities() linters.Capabilities {
+	return linters.Capabilities{
+		Embedded: []string{"security patterns"},
+	}
+}
+
+// Rules describes the risky-change checks
+func (l *SecurityLinter) Rules() map[string]string {
+	rules := make(map[string]string, len(checks))
+	for _, c := range checks {
+		rules[c.rule] = "Flags changed lines that " + c.message
+	}
+	return rules
+}
+
+// SetFileSystem sets the filesystem used to read the file before the edit
+func (l *SecurityLinter) SetFileSystem(fsys linters.FileSystem) {
+	l.fs = fsys
+}
+
+// CanHandle returns true for every file except documentation
+func (l *SecurityLinter) CanHandle(filePath string) bool {
+	return !docExtensions[strings.ToLower(filepath.Ext(filePath))]
+}
+
+// SetConfig updates the linter configuration
+func (l *SecurityLinter) SetConfig(config []byte) error {
+	securityConfig := DefaultSecurityConfig()
+	if err := json.Unmarshal(config, securityConfig); err != nil {
+		return fmt.Errorf("failed to parse security config: %w", err)
+	}
+	l.mu.Lock()
+	l.config = securityConfig
+	l.mu.Unlock()
+	return nil
+}
+
+// ConfigSchema returns the JSON Schema for the linter configuration
+func (l *SecurityLinter) ConfigSchema() json.RawMessage {
+	return json.RawMessage(configSchema)
+}
+
+// Lint reports risky patterns in lines the new content adds, or for removal
+// checks deletes, compared to the file on disk
+func (l *SecurityLinter) Lint(ctx context.Context, filePath string, content []byte) (*linters.LintResult, error) {
+	l.mu.RLock()
+	config := l.config
+	l.mu.RUnlock()
+
+	// New files count as entirely added
+	before, _ := l.fs.ReadFile(filePath)
+	added, removed := changedLines(before, content)
+
+	severity := "warning"
+	if config.Severity != nil && *config.Severity != "" {
+		severity = *config.Severity
+	}
+
+	result := &linters.LintResult{Success: true}
+	for _, c := range checks {
+		if isDisabled(config, c.rule) {
+			continue
+		}
+		for _, line := range added {
+			if column, ok := c.match(line.text); ok {
+				result.Issues = append(result.Issues, c.issue(filePath, line.number, column, severity, ""))
+			}
+		}
+		if !c.removals {
+			continue
+		}
+		for _, line := range removed {
+			if column, ok := c.match(line.text); ok {
+				result.Issues = append(result.Issues, c.issue(filePath, line.number, column, severity, "removes: "+strings.TrimSpace(line.text)))
+			}
+		}
+	}
+
+	for _, issue := range result.Issues {
+		if issue.Severity == "error" {
+			result.Success = false
+		}
+	}
+	return result, nil
+}
+
+// match returns the column of the first pattern of the check that matches line
+func (c check) match(line string) (int, bool) {
+	for _, pattern := range c.patterns {
+		if loc := pattern.FindStringIndex(line); loc != nil {
+			return linters.Column(line, loc[0]), true
+		}
+	}
+	return 0, false
+}
+
+// issue builds the issue reported for a check
+func (c check) issue(filePath string, line, column int, severity, detail string) linters.Issue {
+	message := "Security review: this change " + c.message + "; confirm it is intended and safe"
+	if detail != "" {
+		message += " (" + detail + ")"
+	}
+	return linters.Issue{
+		File:     filePath,
+		Line:     line,
+		Column:   column,
+		Severity: severity,
+		Message:  message,
+		Rule:     c.rule,
+	}
+}
+
+// isDisabled checks if a check rule is disabled
+func isDisabled(config *SecurityConfig, rule string) bool {
+	for _, disabled := range config.DisabledChecks {
+		if disabled == rule {
+			return true
+		}
+	}
+	return false
+}
+
+// changedLine is a line an edit adds or removes
+type changedLine struct {
+	// number is the 1-based line in the new content, 1 for removed lines
+	number int
+	text   string
+}
+
+// changedLines compares the lines of before and after as multisets, so moved
+// lines don't count as changes. Removed lines are reported at the first line of
+// the new content, since their position no longer exists.
+func changedLines(before, after []byte) (added, removed []changedLine) {
+	counts := make(map[string]int)
+	for _, line := range splitLines(before) {
+		counts[strings.TrimSpace(line)]++
+	}
+	for i, line := range splitLines(after) {
+		key := strings.TrimSpace(line)
+		if counts[key] > 0 {
+			counts[key]--
+			continue
+		}
+		added = append(added, changedLine{number: i + 1, text: line})
+	}
+	for _, line := range splitLines(before) {
+		key := strings.TrimSpace(line)
+		if counts[key] > 0 {
+			counts[key]--
+			removed = append(removed, changedLine{number: 1, text: line})
+		}
+	}
+	return added, removed
+}
+
+// splitLines splits content into lines without their trailing newlines
+func splitLines(content []byte) []string {
+	if len(content) == 0 {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+}
@@ -0,0 +1,177 @@
+package security
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+func TestSecurityLinter_CanHandle(t *testing.T) {
+	l := NewSecurityLinter()
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"main.go", true},
+		{"server.js", true},
+		{"deploy.sh", true},
+		{"Dockerfile", true},
+		{"README.md", false},
+		{"docs/notes.TXT", false},
+	}
+	for _, tt := range tests {
+		if got := l.CanHandle(tt.path); got != tt.want {
+			t.Errorf("CanHandle(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestSecurityLinter_Lint(t *testing.T) {
+	tests := []struct {
+		name      string
+		file      string
+		before    string
+		after     string
+		wantRules []string
+	}{
+		{
+			name:      "go tls verification disabled",
+			file:      "client.go",
+			before:    "package main\n",
+			after:     "package main\n\nvar cfg = &tls.Config{InsecureSkipVerify: true}\n",
+			wantRules: []string{"tls-verify-disabled"},
+		},
+		{
+			name:      "python requests without verify",
+			file:      "fetch.py",
+			after:     "requests.get(url, verify=False)\n",
+			wantRules: []string{"tls-verify-disabled"},
+		},
+		{
+			name:      "wildcard cors header",
+			file:      "server.js",
+			before:    "const app = express()\n",
+			after:     "const app = express()\nres.setHeader('Access-Control-Allow-Origin', '*')\n",
+			wantRules: []string{"cors-wildcard"},
+		},
+		{
+			name:      "fastapi allow all origins",
+			file:      "app.py",
+			after:     "app.add_middleware(CORSMiddleware, allow_origins=[\"*\"])\n",
+			wantRules: []string{"cors-wildcard"},
+		},
+		{
+			name:      "javascript eval",
+			file:      "run.js",
+			after:     "const result = eval(userInput)\n",
+			wantRules: []string{"dynamic-code-exec"},
+		},
+		{
+			name:      "shell true subprocess",
+			file:      "run.py",
+			after:     "subprocess.run(cmd, shell=True)\n",
+			wantRules: []string{"dynamic-code-exec"},
+		},
+		{
+			name:      "go shell command",
+			file:      "run.go",
+			after:     "cmd := exec.Command(\"sh\", \"-c\", script)\n",
+			wantRules: []string{"dynamic-code-exec"},
+		},
+		{
+			name:      "removed auth middleware",
+			file:      "routes.go",
+			before:    "r := chi.NewRouter()\nr.Use(authMiddleware)\nr.Get(\"/\", index)\n",
+			after:     "r := chi.NewRouter()\nr.Get(\"/\", index)\n",
+			wantRules: []string{"auth-middleware-change"},
+		},
+		{
+			name:      "chmod 777",
+			file:      "setup.sh",
+			after:     "#!/bin/sh\nchmod -R 777 /var/data\n",
+			wantRules: []string{"world-writable-permissions"},
+		},
+		{
+			name:      "go world writable directory",
+			file:      "dirs.go",
+			after:     "os.MkdirAll(dir, 0777)\n",
+			wantRules: []string{"world-writable-permissions"},
+		},
+		{
+			name:   "pre-existing risky line is not reported",
+			file:   "client.go",
+			before: "var cfg = &tls.Config{InsecureSkipVerify: true}\n",
+			after:  "// Deprecated client\nvar cfg = &tls.Config{InsecureSkipVerify: true}\n",
+		},
+		{
+			name:   "moved lines are not changes",
+			file:   "routes.go",
+			before: "r.Use(authMiddleware)\nr.Use(logger)\n",
+			after:  "r.Use(logger)\nr.Use(authMiddleware)\n",
+		},
+		{
+			name:  "safe method names",
+			file:  "db.py",
+			after: "cursor.execute(query, params)\nmodel.eval()\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsys := linters.NewMemFileSystem()
+			if tt.before != "" {
+				_ = fsys.WriteFile(tt.file, []byte(tt.before), 0o644)
+			}
+			l := NewSecurityLinter()
+			l.SetFileSystem(fsys)
+
+			result, err := l.Lint(context.Background(), tt.file, []byte(tt.after))
+			if err != nil {
+				t.Fatalf("Lint() error = %v", err)
+			}
+			var rules []string
+			for _, issue := range result.Issues {
+				rules = append(rules, issue.Rule)
+				if issue.Severity != "warning" {
+					t.Errorf("expected warning severity, got %q", issue.Severity)
+				}
+			}
+			if len(rules) != len(tt.wantRules) {
+				t.Fatalf("rules = %v, want %v", rules, tt.wantRules)
+			}
+			for i := range rules {
+				if rules[i] != tt.wantRules[i] {
+					t.Errorf("rules = %v, want %v", rules, tt.wantRules)
+				}
+			}
+			if !result.Success {
+				t.Error("warnings should not fail the lint result")
+			}
+		})
+	}
+}
+
+func TestSecurityLinter_SetConfig(t *testing.T) {
+	l := NewSecurityLinter()
+	l.SetFileSystem(linters.NewMemFileSystem())
+	if err := l.SetConfig([]byte(`{"disabledChecks": ["dynamic-code-exec"], "severity": "error"}`)); err != nil {
+		t.Fatalf("SetConfig() error = %v", err)
+	}
+
+	content := []byte("eval(x)\nchmod 777 data\n")
+	result, err := l.Lint(context.Background(), "run.sh", content)
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+	if len(result.Issues) != 1 || result.Issues[0].Rule != "world-writable-permissions" {
+		t.Fatalf("expected only the enabled check, got %+v", result.Issues)
+	}
+	if result.Success || result.Issues[0].Severity != "error" {
+		t.Errorf("expected blocking error severity, got %+v", result)
+	}
+
+	if err := l.SetConfig([]byte(`{invalid`)); err == nil {
+		t.Error("expected error for invalid config")
+	}
+}
@@ -0,0 +1,56 @@
+package linters
+
+import "strings"
+
+// SeverityMap maps the severity labels of one external tool, lower-cased, to
+// "error", "warning" or "info"
+type SeverityMap map[string]string
+
+// toolSeverities are the built-in severity mappings of the external tools whose
+// output is parsed, so a warning means the same thing whichever tool reported it
+var toolSeverities = map[string]SeverityMap{
+	// Biome diagnostics: fatal, error, warning, information, hint
+	"biome": {"fatal": "error", "error": "error", "warning": "warning", "information": "info", "hint": "info"},
+	// Oxlint diagnostics: error, warning, advice
+	"oxlint": {"error": "error", "warning": "warning", "advice": "info"},
+	// ESLint rule levels: 2 error, 1 warn
+	"eslint": {"2": "error", "1": "warning", "0": "info"},
+	// golangci-lint leaves severity empty unless severity rules are configured
+	"golangci-lint": {"error": "error", "warning": "warning", "info": "info", "": "warning"},
+	// rustc and clippy diagnostic levels
+	"clippy": {"error": "error", "error: internal compiler error": "error", "warning": "warning",
+		"note": "info", "help": "info", "failure-note": "info"},
+	// staticcheck reports every finding as an error unless configured otherwise;
+	// like golangci-lint's, they're warnings
+	"staticcheck": {"error": "warning", "warning": "warning", "ignored": "info"},
+	"hadolint":    {"error": "error", "warning": "warning", "info": "info", "style": "info"},
+	"shellcheck":  {"error": "error", "warning": "warning", "info": "info", "style": "info"},
+	"stylelint":   {"error": "error", "warning": "warning"},
+	"yamllint":    {"error": "error", "warning": "warning"},
+}
+
+// IsSeverity reports whether severity is one gismo reports
+func IsSeverity(severity string) bool {
+	return severity == "error" || severity == "warning" || severity == "info"
+}
+
+// NormalizeSeverity returns the severity for level as reported by tool, using the
+// built-in mapping for the tool. Unknown tools and levels are reported as warnings.
+func NormalizeSeverity(tool, level string) string {
+	if severity, ok := toolSeverities[tool][strings.ToLower(level)]; ok {
+		return severity
+	}
+	return "warning"
+}
+
+// ToolIssue returns an issue reported by an external tool, with its severity
+// normalized and the tool's own label kept for SeverityPolicy overrides.
+// Negative positions from malformed output are reported as unknown (0).
+func ToolIssue(tool, level string, issue Issue) Issue {
+	issue.Line = max(issue.Line, 0)
+	issue.Column = max(issue.Column, 0)
+	issue.Severity = NormalizeSeverity(tool, level)
+	issue.Tool = tool
+	issue.ToolSeverity = strings.ToLower(level)
+	return issue
+}
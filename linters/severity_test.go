@@ -0,0 +1,43 @@
+package linters
+
+import "testing"
+
+func TestNormalizeSeverity(t *testing.T) {
+	tests := []struct {
+		tool  string
+		level string
+		want  string
+	}{
+		{tool: "biome", level: "fatal", want: "error"},
+		{tool: "biome", level: "information", want: "info"},
+		{tool: "oxlint", level: "advice", want: "info"},
+		{tool: "eslint", level: "2", want: "error"},
+		{tool: "eslint", level: "1", want: "warning"},
+		{tool: "golangci-lint", level: "", want: "warning"},
+		{tool: "clippy", level: "error: internal compiler error", want: "error"},
+		{tool: "clippy", level: "help", want: "info"},
+		{tool: "hadolint", level: "style", want: "info"},
+		{tool: "shellcheck", level: "Error", want: "error"},
+		{tool: "yamllint", level: "warning", want: "warning"},
+		{tool: "yamllint", level: "unknown", want: "warning"},
+		{tool: "unknown-tool", level: "error", want: "warning"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tool+"/"+tt.level, func(t *testing.T) {
+			if got := NormalizeSeverity(tt.tool, tt.level); got != tt.want {
+				t.Errorf("NormalizeSeverity(%q, %q) = %q, want %q", tt.tool, tt.level, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToolIssue(t *testing.T) {
+	issue := ToolIssue("clippy", "Note", Issue{Message: "consider borrowing", Rule: "clippy::needless_borrow"})
+	if issue.Severity != "info" || issue.Tool != "clippy" || issue.ToolSeverity != "note" {
+		t.Errorf("ToolIssue() = %+v", issue)
+	}
+	if issue.Message != "consider borrowing" || issue.Rule != "clippy::needless_borrow" {
+		t.Errorf("ToolIssue() changed the issue: %+v", issue)
+	}
+}
@@ -0,0 +1,229 @@
+package linters
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ShadowWorkspace mirrors a project directory into a temporary directory and
+// replaces selected files with pending content. Tools that need the whole project
+// on disk (golangci-lint, cargo, etc.) can then check content before it is written.
+//
+// Only the directories leading to pending files are recreated; every other entry
+// is a symlink to the original, so creating a workspace costs a few symlinks per
+// level however large the project is (vendor/, node_modules/), and never modifies
+// the original project.
+type ShadowWorkspace struct {
+	// Root is the temporary directory mirroring the project root
+	Root string
+	// source is the absolute path of the original project root
+	source string
+}
+
+// NewShadowWorkspace creates a shadow of projectRoot with pending content applied.
+// Pending paths must be inside projectRoot; they may refer to files that do not exist yet.
+// Callers must call Close to remove the workspace.
+func NewShadowWorkspace(projectRoot string, pending map[string][]byte) (*ShadowWorkspace, error) {
+	return newShadowWorkspace(projectRoot, pending, os.Symlink, true)
+}
+
+// NewShadowCopy is like NewShadowWorkspace but copies files instead of symlinking
+// them, for tools that write into the project (go generate, code formatters).
+// Writes through a symlink would otherwise modify the original file.
+func NewShadowCopy(projectRoot string, pending map[string][]byte) (*ShadowWorkspace, error) {
+	return newShadowWorkspace(projectRoot, pending, copyFile, false)
+}
+
+// newShadowWorkspace builds a shadow workspace, mirroring files with mirror. When
+// sparse is set, directories not leading to a pending file are mirrored as a whole.
+func newShadowWorkspace(projectRoot string, pending map[string][]byte, mirror func(src, dst string) error, sparse bool) (*ShadowWorkspace, error) {
+	source, err := filepath.Abs(projectRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get absolute path: %w", err)
+	}
+
+	// Validate pending paths before touching the filesystem
+	overrides := make(map[string][]byte, len(pending))
+	for path, content := range pending {
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get absolute path: %w", err)
+		}
+		rel, err := filepath.Rel(source, absPath)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return nil, fmt.Errorf("pending file %s is outside project root %s", path, source)
+		}
+		overrides[rel] = content
+	}
+
+	// Directories that must be real so pending files can replace entries in them
+	onPath := map[string]bool{".": true}
+	for rel := range overrides {
+		for dir := filepath.Dir(rel); dir != "."; dir = filepath.Dir(dir) {
+			onPath[dir] = true
+		}
+	}
+
+	root, err := os.MkdirTemp("", "gismo-shadow-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create shadow workspace: %w", err)
+	}
+	w := &ShadowWorkspace{Root: root, source: source}
+
+	err = filepath.WalkDir(source, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(source, path)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+
+		// Version control metadata is large and never needed by linters
+		if d.IsDir() && d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+
+		target := filepath.Join(root, rel)
+		if d.IsDir() {
+			if sparse && !onPath[rel] {
+				if err := mirror(path, target); err != nil {
+					return err
+				}
+				return filepath.SkipDir
+			}
+			return os.Mkdir(target, 0755)
+		}
+		if _, replaced := overrides[rel]; replaced {
+			return nil
+		}
+		return mirror(path, target)
+	})
+	if err != nil {
+		_ = w.Close()
+		return nil, fmt.Errorf("failed to mirror project: %w", err)
+	}
+
+	// Materialize pending content, creating directories for new files as needed
+	for rel, content := range overrides {
+		target := filepath.Join(root, rel)
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			_ = w.Close()
+			return nil, fmt.Errorf("failed to create directory for %s: %w", rel, err)
+		}
+		if err := os.WriteFile(target, content, 0644); err != nil {
+			_ = w.Close()
+			return nil, fmt.Errorf("failed to write pending content for %s: %w", rel, err)
+		}
+	}
+
+	return w, nil
+}
+
+// copyFile copies src to dst, preserving the file mode
+func copyFile(src, dst string) error {
+	info, err := os.Lstat(src)
+	if err != nil {
+		return err
+	}
+	// Keep symlinks in the project as symlinks
+	if info.Mode()&os.ModeSymlink != 0 {
+		link, err := os.Readlink(src)
+		if err != nil {
+			return err
+		}
+		return os.Symlink(link, dst)
+	}
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(dst, data, info.Mode().Perm())
+}
+
+// ChangedSince returns the files written in the workspace after since whose content
+// differs from the original project, as slash-separated paths relative to the root.
+// Files listed in skip (original paths, such as pending content) are ignored.
+func (w *ShadowWorkspace) ChangedSince(since time.Time, skip ...string) ([]string, error) {
+	skipped := make(map[string]bool, len(skip))
+	for _, path := range skip {
+		if absPath, err := filepath.Abs(path); err == nil {
+			skipped[absPath] = true
+		}
+	}
+
+	var changed []string
+	err := filepath.WalkDir(w.Root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil || info.Mode()&os.ModeSymlink != 0 || info.ModTime().Before(since) {
+			return nil
+		}
+
+		original := w.Original(path)
+		if skipped[original] {
+			return nil
+		}
+		written, err := os.ReadFile(path)
+		if err != nil {
+			return nil
+		}
+		if onDisk, err := os.ReadFile(original); err == nil && bytes.Equal(written, onDisk) {
+			return nil
+		}
+
+		rel, err := filepath.Rel(w.Root, path)
+		if err != nil {
+			return nil
+		}
+		changed = append(changed, filepath.ToSlash(rel))
+		return nil
+	})
+	sort.Strings(changed)
+	return changed, err
+}
+
+// Path maps a path in the original project to the corresponding shadow path.
+// Paths outside the project are returned unchanged.
+func (w *ShadowWorkspace) Path(original string) string {
+	return remapPath(original, w.source, w.Root)
+}
+
+// Original maps a shadow path back to the original project path. Relative paths
+// are taken relative to the workspace root, where tools such as golangci-lint run
+// and report them. Absolute paths outside the shadow are returned unchanged.
+func (w *ShadowWorkspace) Original(shadowPath string) string {
+	if !filepath.IsAbs(shadowPath) {
+		return filepath.Join(w.source, shadowPath)
+	}
+	return remapPath(shadowPath, w.Root, w.source)
+}
+
+// Close removes the shadow workspace. The original project is never modified.
+func (w *ShadowWorkspace) Close() error {
+	return os.RemoveAll(w.Root)
+}
+
+// remapPath rewrites path from one root directory to another
+func remapPath(path, fromRoot, toRoot string) string {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+	rel, err := filepath.Rel(fromRoot, absPath)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return path
+	}
+	return filepath.Join(toRoot, rel)
+}
@@ -0,0 +1,162 @@
+package linters
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestShadowWorkspace(t *testing.T) {
+	project := t.TempDir()
+	mustWrite := func(rel, content string) {
+		t.Helper()
+		path := filepath.Join(project, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	mustWrite("go.mod", "module example.com/p\n")
+	mustWrite("pkg/a.go", "package pkg // original\n")
+	mustWrite("pkg/b.go", "package pkg\n")
+	mustWrite(".git/HEAD", "ref: refs/heads/main\n")
+	mustWrite("vendor/example.com/dep/dep.go", "package dep\n")
+	mustWrite("internal/util/util.go", "package util\n")
+
+	pending := map[string][]byte{
+		filepath.Join(project, "pkg/a.go"):       []byte("package pkg // pending\n"),
+		filepath.Join(project, "pkg/new/new.go"): []byte("package new\n"),
+	}
+
+	ws, err := NewShadowWorkspace(project, pending)
+	if err != nil {
+		t.Fatalf("NewShadowWorkspace failed: %v", err)
+	}
+
+	read := func(path string) string {
+		t.Helper()
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("ReadFile(%s) failed: %v", path, err)
+		}
+		return string(data)
+	}
+
+	if got := read(ws.Path(filepath.Join(project, "pkg/a.go"))); got != "package pkg // pending\n" {
+		t.Errorf("shadow a.go = %q, want pending content", got)
+	}
+	if got := read(ws.Path(filepath.Join(project, "pkg/b.go"))); got != "package pkg\n" {
+		t.Errorf("shadow b.go = %q, want mirrored content", got)
+	}
+	if got := read(ws.Path(filepath.Join(project, "pkg/new/new.go"))); got != "package new\n" {
+		t.Errorf("shadow new.go = %q, want pending content", got)
+	}
+	if _, err := os.Stat(filepath.Join(ws.Root, ".git")); !os.IsNotExist(err) {
+		t.Errorf("expected .git to be skipped, got err = %v", err)
+	}
+
+	// Directories without pending files are linked as a whole, not walked
+	for _, dir := range []string{"vendor", "internal"} {
+		info, err := os.Lstat(filepath.Join(ws.Root, dir))
+		if err != nil || info.Mode()&os.ModeSymlink == 0 {
+			t.Errorf("expected %s to be a single symlink, got %v, %v", dir, info, err)
+		}
+	}
+	if got := read(ws.Path(filepath.Join(project, "internal/util/util.go"))); got != "package util\n" {
+		t.Errorf("shadow util.go = %q, want mirrored content", got)
+	}
+	if info, err := os.Lstat(filepath.Join(ws.Root, "pkg")); err != nil || !info.IsDir() {
+		t.Errorf("expected pkg to be a real directory, got %v, %v", info, err)
+	}
+
+	// The original project must be untouched
+	if got := read(filepath.Join(project, "pkg/a.go")); got != "package pkg // original\n" {
+		t.Errorf("original a.go modified: %q", got)
+	}
+	if _, err := os.Stat(filepath.Join(project, "pkg/new")); !os.IsNotExist(err) {
+		t.Errorf("pending file leaked into original project")
+	}
+
+	// Paths map back to the original project
+	shadowPath := ws.Path(filepath.Join(project, "pkg/b.go"))
+	if got := ws.Original(shadowPath); got != filepath.Join(project, "pkg/b.go") {
+		t.Errorf("Original(%q) = %q", shadowPath, got)
+	}
+	if got := ws.Original("pkg/b.go"); got != filepath.Join(project, "pkg/b.go") {
+		t.Errorf("Original() should resolve relative paths against the project, got %q", got)
+	}
+
+	if err := ws.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if _, err := os.Stat(ws.Root); !os.IsNotExist(err) {
+		t.Errorf("expected shadow workspace to be removed")
+	}
+}
+
+func TestShadowWorkspace_PendingOutsideRoot(t *testing.T) {
+	project := t.TempDir()
+	outside := filepath.Join(t.TempDir(), "other.go")
+
+	if _, err := NewShadowWorkspace(project, map[string][]byte{outside: []byte("x")}); err == nil {
+		t.Error("expected error for pending file outside project root")
+	}
+}
+
+func TestShadowCopy_WritesStayInShadow(t *testing.T) {
+	project := t.TempDir()
+	original := filepath.Join(project, "gen.txt")
+	if err := os.WriteFile(original, []byte("original\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ws, err := NewShadowCopy(project, nil)
+	if err != nil {
+		t.Fatalf("NewShadowCopy failed: %v", err)
+	}
+	defer func() { _ = ws.Close() }()
+
+	// Writing to the copy must not reach the original, unlike a symlink mirror
+	if err := os.WriteFile(ws.Path(original), []byte("regenerated\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(original); string(data) != "original\n" {
+		t.Errorf("original modified through shadow copy: %q", data)
+	}
+}
+
+func TestShadowWorkspace_ChangedSince(t *testing.T) {
+	project := t.TempDir()
+	for name, content := range map[string]string{"same.txt": "same\n", "stale.txt": "old\n", "src.txt": "src\n"} {
+		if err := os.WriteFile(filepath.Join(project, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ws, err := NewShadowCopy(project, nil)
+	if err != nil {
+		t.Fatalf("NewShadowCopy failed: %v", err)
+	}
+	defer func() { _ = ws.Close() }()
+
+	since := time.Now()
+	time.Sleep(10 * time.Millisecond)
+	// Simulate a generator rewriting files: one unchanged, one changed, one new
+	for name, content := range map[string]string{"same.txt": "same\n", "stale.txt": "new\n", "added.txt": "x\n", "src.txt": "changed\n"} {
+		if err := os.WriteFile(ws.Path(filepath.Join(project, name)), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	changed, err := ws.ChangedSince(since, filepath.Join(project, "src.txt"))
+	if err != nil {
+		t.Fatalf("ChangedSince failed: %v", err)
+	}
+	if got := strings.Join(changed, ","); got != "added.txt,stale.txt" {
+		t.Errorf("ChangedSince() = %q, want %q", got, "added.txt,stale.txt")
+	}
+}
@@ -0,0 +1,65 @@
+package shell
+
+// ShellConfig represents shell script linter specific configuration
+type ShellConfig struct {
+	// UseShellcheck runs shellcheck when it is installed (default true)
+	UseShellcheck *bool `json:"useShellcheck,omitempty"`
+	// Shell forces the dialect: "sh", "bash", "dash", "ksh" or "zsh". If unset, the
+	// shebang decides, then the file extension
+	Shell *string `json:"shell,omitempty"`
+	// Severity is the lowest shellcheck level reported: "error", "warning", "info"
+	// or "style" (default "style")
+	Severity *string `json:"severity,omitempty"`
+	// DisabledCodes lists shellcheck codes to skip, such as "SC2086" or "2086"
+	DisabledCodes []string `json:"disabledCodes,omitempty"`
+	// MaxFileSize is the maximum file size in bytes to lint (default 1MB)
+	MaxFileSize *int64 `json:"maxFileSize,omitempty"`
+}
+
+// configSchema is the JSON Schema for ShellConfig
+const configSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "properties": {
+    "useShellcheck": {
+      "type": "boolean",
+      "description": "Run shellcheck when it is installed"
+    },
+    "shell": {
+      "type": "string",
+      "enum": ["sh", "bash", "dash", "ksh", "zsh"],
+      "description": "Shell dialect, instead of detecting it from the shebang and extension"
+    },
+    "severity": {
+      "type": "string",
+      "enum": ["error", "warning", "info", "style"],
+      "description": "Lowest shellcheck level reported"
+    },
+    "disabledCodes": {
+      "type": "array",
+      "items": {
+        "type": "string",
+        "pattern": "^(SC)?[0-9]+$"
+      },
+      "description": "Shellcheck codes to skip, such as SC2086"
+    },
+    "maxFileSize": {
+      "type": "integer",
+      "minimum": 0,
+      "description": "Maximum file size in bytes to lint"
+    }
+  },
+  "additionalProperties": false
+}`
+
+// DefaultShellConfig returns the default configuration for shell script linting
+func DefaultShellConfig() *ShellConfig {
+	useShellcheck := true
+	severity := "style"
+	maxFileSize := int64(1024 * 1024)
+	return &ShellConfig{
+		UseShellcheck: &useShellcheck,
+		Severity:      &severity,
+		MaxFileSize:   &maxFileSize,
+	}
+}
@@ -0,0 +1,370 @@
+package shell
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/jrossi/gismo/linters"
+	"github.com/jrossi/gismo/toolcache"
+)
+
+// RuleSyntax is reported by the bash -n and zsh -n fallback
+const RuleSyntax = "syntax"
+
+// shellcheckDialects are the dialects shellcheck understands
+var shellcheckDialects = map[string]bool{"sh": true, "bash": true, "dash": true, "ksh": true}
+
+// extensionDialects maps file extensions to the dialect assumed without a shebang
+var extensionDialects = map[string]string{".sh": "sh", ".bash": "bash", ".zsh": "zsh"}
+
+// syntaxErrorLine matches the line number in bash -n and zsh -n errors, e.g.
+// "bash: line 3: syntax error near unexpected token `fi'" or "zsh:3: parse error near `fi'"
+var syntaxErrorLine = regexp.MustCompile(`^[^:]*:(?: line )?(\d+): (.*)$`)
+
+// shellcheckComment is one finding in shellcheck's JSON output
+type shellcheckComment struct {
+	Line      int    `json:"line"`
+	EndLine   int    `json:"endLine"`
+	Column    int    `json:"column"`
+	EndColumn int    `json:"endColumn"`
+	Level     string `json:"level"`
+	Code      int    `json:"code"`
+	Message   string `json:"message"`
+}
+
+// ShellLinter checks shell scripts with shellcheck, or only their syntax with
+// bash -n or zsh -n when shellcheck is unavailable
+type ShellLinter struct {
+	mu     sync.RWMutex
+	config *ShellConfig
+	// Tool cache used to discover shellcheck, bash and zsh; nil uses the disk-backed cache of the linted file's project
+	cache toolcache.ToolCache
+	// Runs external tools with the engine's limits, caches and environment
+	runner *linters.CommandRunner
+}
+
+// NewShellLinter creates a new shell script linter with default configuration
+func NewShellLinter() *ShellLinter {
+	return NewShellLinterWithConfig(nil)
+}
+
+// NewShellLinterWithConfig creates a new shell script linter with the given configuration
+func NewShellLinterWithConfig(config *ShellConfig) *ShellLinter {
+	if config == nil {
+		config = DefaultShellConfig()
+	}
+	return &ShellLinter{
+		config: config,
+		runner: linters.NewCommandRunner(linters.RunnerConfig{}),
+	}
+}
+
+// NewShellLinterWithToolCache creates a shell script linter that discovers its tools
+// with the given tool cache. A nil cache falls back to the disk-backed cache rooted
+// at the linted file's project.
+func NewShellLinterWithToolCache(config *ShellConfig, cache toolcache.ToolCache) *ShellLinter {
+	l := NewShellLinterWithConfig(config)
+	l.cache = cache
+	return l
+}
+
+// Name returns the linter name
+func (l *ShellLinter) Name() string {
+	return "shell"
+}
+
+// SetCommandRunner sets the runner used to start external tools
+func (l *ShellLinter) SetCommandRunner(runner *linters.CommandRunner) {
+	l.runner = runner
+}
+
+// Capabilities reports the built-in checks and the external tools used when installed
+func (l *ShellLinter) Capabilities() linters.Capabilities {
+	return linters.Capabilities{
+		Embedded: []string{},
+		Tools:    []string{"shellcheck", "bash", "zsh"},
+	}
+}
+
+// CanHandle returns true for .sh, .bash and .zsh files
+func (l *ShellLinter) CanHandle(filePath string) bool {
+	_, ok := extensionDialects[strings.ToLower(filepath.Ext(filePath))]
+	return ok
+}
+
+// SetConfig updates the linter configuration
+func (l *ShellLinter) SetConfig(configData json.RawMessage) error {
+	// Settings not given keep their defaults
+	config := DefaultShellConfig()
+	if err := json.Unmarshal(configData, config); err != nil {
+		return fmt.Errorf("failed to parse shell config: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.config = config
+	return nil
+}
+
+// ConfigSchema returns the JSON Schema for the linter configuration
+func (l *ShellLinter) ConfigSchema() json.RawMessage {
+	return json.RawMessage(configSchema)
+}
+
+// Rules describes the checks made without shellcheck. Shellcheck's own codes are
+// documented at https://www.shellcheck.net/wiki/
+func (l *ShellLinter) Rules() map[string]string {
+	return map[string]string{
+		RuleSyntax: "The script parses with bash -n, or zsh -n for zsh scripts, when shellcheck is not installed",
+	}
+}
+
+// Lint checks a shell script with shellcheck if it is installed and supports the
+// script's dialect, and with the shell's syntax check otherwise
+func (l *ShellLinter) Lint(ctx context.Context, filePath string, content []byte) (*linters.LintResult, error) {
+	l.mu.RLock()
+	config := l.config
+	l.mu.RUnlock()
+
+	result := &linters.LintResult{
+		Success: true,
+		Issues:  []linters.Issue{},
+	}
+
+	if config.MaxFileSize != nil && int64(len(content)) > *config.MaxFileSize {
+		result.Issues = append(result.Issues, linters.Issue{
+			File:     filePath,
+			Line:     1,
+			Column:   1,
+			Severity: "error",
+			Message:  fmt.Sprintf("File size %d exceeds limit %d", len(content), *config.MaxFileSize),
+			Rule:     "file-size",
+		})
+		result.Success = false
+		return result, nil
+	}
+
+	dialect := detectDialect(config, filePath, content)
+
+	var issues []linters.Issue
+	var err error
+	if shellcheck := l.shellcheckPath(config, filePath, dialect); shellcheck != "" {
+		issues, err = l.runShellcheck(ctx, config, shellcheck, dialect, filePath, content)
+	} else {
+		issues, err = l.runSyntaxCheck(ctx, dialect, filePath, content)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, issue := range issues {
+		if isDisabled(config, issue.Rule) {
+			continue
+		}
+		if issue.Severity == "error" {
+			result.Success = false
+		}
+		result.Issues = append(result.Issues, issue)
+	}
+	return result, nil
+}
+
+// detectDialect returns the configured dialect, else the shebang's, else the
+// one implied by the file extension
+func detectDialect(config *ShellConfig, filePath string, content []byte) string {
+	if config.Shell != nil && *config.Shell != "" {
+		return *config.Shell
+	}
+	if dialect := shebangDialect(content); dialect != "" {
+		return dialect
+	}
+	return extensionDialects[strings.ToLower(filepath.Ext(filePath))]
+}
+
+// shebangDialect returns the shell named by a "#!/bin/bash" or "#!/usr/bin/env bash"
+// line, or "" without one
+func shebangDialect(content []byte) string {
+	firstLine, _, _ := bytes.Cut(content, []byte("\n"))
+	line := strings.TrimSpace(string(firstLine))
+	if !strings.HasPrefix(line, "#!") {
+		return ""
+	}
+	fields := strings.Fields(strings.TrimPrefix(line, "#!"))
+	if len(fields) == 0 {
+		return ""
+	}
+	interpreter := filepath.Base(fields[0])
+	if interpreter == "env" {
+		// Skip env options such as -S
+		for _, field := range fields[1:] {
+			if !strings.HasPrefix(field, "-") {
+				interpreter = filepath.Base(field)
+				break
+			}
+		}
+	}
+	switch interpreter {
+	case "sh", "bash", "dash", "ksh", "zsh":
+		return interpreter
+	default:
+		return ""
+	}
+}
+
+// normalizeCode turns "2086" or "sc2086" into "SC2086"
+func normalizeCode(code string) string {
+	return "SC" + strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(code)), "SC")
+}
+
+// isDisabled reports whether a rule is disabled by configuration
+func isDisabled(config *ShellConfig, rule string) bool {
+	for _, disabled := range config.DisabledCodes {
+		if normalizeCode(disabled) == rule {
+			return true
+		}
+	}
+	return false
+}
+
+// discover returns the path of a shell tool, or "" if it isn't installed
+func (l *ShellLinter) discover(filePath, toolName string) string {
+	cache := l.cache
+	if cache == nil {
+		manager, err := toolcache.NewCacheManager(filePath)
+		if err != nil {
+			return ""
+		}
+		cache = manager
+	}
+	tool, err := cache.DiscoverTool("shell", toolName)
+	if err != nil || tool == nil || !tool.Available {
+		return ""
+	}
+	return tool.Path
+}
+
+// shellcheckPath returns the shellcheck binary to run, or "" to use the syntax check
+func (l *ShellLinter) shellcheckPath(config *ShellConfig, filePath, dialect string) string {
+	if config.UseShellcheck != nil && !*config.UseShellcheck {
+		return ""
+	}
+	if !shellcheckDialects[dialect] {
+		return ""
+	}
+	return l.discover(filePath, "shellcheck")
+}
+
+// runShellcheck lints content with shellcheck, read from stdin since the content
+// may not be on disk yet
+func (l *ShellLinter) runShellcheck(ctx context.Context, config *ShellConfig, shellcheck, dialect, filePath string, content []byte) ([]linters.Issue, error) {
+	args := []string{"--format=json", "--shell=" + dialect}
+	if config.Severity != nil && *config.Severity != "" {
+		args = append(args, "--severity="+*config.Severity)
+	}
+	if len(config.DisabledCodes) > 0 {
+		codes := make([]string, len(config.DisabledCodes))
+		for i, code := range config.DisabledCodes {
+			codes[i] = normalizeCode(code)
+		}
+		args = append(args, "--exclude="+strings.Join(codes, ","))
+	}
+	args = append(args, "-")
+
+	release, err := l.runner.Acquire(ctx, shellcheck)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	cmd := l.runner.Command(ctx, l.Name(), shellcheck, args...)
+	// shellcheck looks for .shellcheckrc from the working directory when reading stdin
+	cmd.Dir = linters.ExistingDir(filePath)
+	cmd.Stdin = bytes.NewReader(content)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	// shellcheck exits with 1 when it reports findings
+	runErr := linters.Run(cmd)
+
+	var comments []shellcheckComment
+	if err := json.Unmarshal(bytes.TrimSpace(stdout.Bytes()), &comments); err != nil {
+		if runErr != nil {
+			return nil, fmt.Errorf("shellcheck failed: %v\nstderr: %s", runErr, stderr.String())
+		}
+		return nil, fmt.Errorf("failed to parse shellcheck output: %w", err)
+	}
+
+	issues := make([]linters.Issue, 0, len(comments))
+	for _, comment := range comments {
+		issues = append(issues, linters.ToolIssue("shellcheck", comment.Level, linters.Issue{
+			File:    filePath,
+			Line:    comment.Line,
+			Column:  comment.Column,
+			Message: comment.Message,
+			Rule:    "SC" + strconv.Itoa(comment.Code),
+		}))
+	}
+	return issues, nil
+}
+
+// runSyntaxCheck checks syntax with zsh -n for zsh scripts and bash -n otherwise.
+// Without the shell there is nothing to check.
+func (l *ShellLinter) runSyntaxCheck(ctx context.Context, dialect, filePath string, content []byte) ([]linters.Issue, error) {
+	shell := "bash"
+	if dialect == "zsh" {
+		shell = "zsh"
+	}
+	path := l.discover(filePath, shell)
+	if path == "" {
+		return nil, nil
+	}
+
+	release, err := l.runner.Acquire(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	cmd := l.runner.Command(ctx, l.Name(), path, "-n")
+	cmd.Dir = linters.ExistingDir(filePath)
+	cmd.Stdin = bytes.NewReader(content)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := linters.Run(cmd); err == nil {
+		return nil, nil
+	}
+
+	var issues []linters.Issue
+	for _, line := range strings.Split(strings.TrimSpace(stderr.String()), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		lineNum, message := 1, line
+		if match := syntaxErrorLine.FindStringSubmatch(line); match != nil {
+			lineNum, _ = strconv.Atoi(match[1])
+			message = match[2]
+		}
+		// bash follows each error with the offending source line in backquotes
+		if strings.HasPrefix(message, "`") {
+			continue
+		}
+		issues = append(issues, linters.Issue{
+			File:     filePath,
+			Line:     lineNum,
+			Column:   1,
+			Severity: "error",
+			Message:  fmt.Sprintf("%s -n: %s", shell, message),
+			Rule:     RuleSyntax,
+		})
+	}
+	return issues, nil
+}
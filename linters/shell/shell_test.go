@@ -0,0 +1,178 @@
+package shell
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/jrossi/gismo/linters"
+	"github.com/jrossi/gismo/toolcache"
+)
+
+// fakeShellcheck writes a shellcheck stand-in that records its arguments and
+// prints output, and returns its path and the arguments file
+func fakeShellcheck(t *testing.T, output string) (string, string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake shellcheck is a shell script")
+	}
+	dir := t.TempDir()
+	args := filepath.Join(dir, "args")
+	script := "#!/bin/sh\n" +
+		"echo \"$@\" > " + args + "\n" +
+		"cat > /dev/null\n" +
+		"cat <<'EOF'\n" + output + "\nEOF\n" +
+		"exit 1\n"
+	path := filepath.Join(dir, "shellcheck")
+	if err := os.WriteFile(path, []byte(script), 0700); err != nil {
+		t.Fatal(err)
+	}
+	return path, args
+}
+
+// rulesOf returns the rule of each issue
+func rulesOf(issues []linters.Issue) string {
+	rules := []string{}
+	for _, issue := range issues {
+		rules = append(rules, issue.Rule)
+	}
+	return strings.Join(rules, ",")
+}
+
+func TestShellLinter_CanHandle(t *testing.T) {
+	linter := NewShellLinter()
+	for path, want := range map[string]bool{
+		"scripts/build.sh": true,
+		"lib.BASH":         true,
+		".zshrc.zsh":       true,
+		"install.shtml":    false,
+		"Makefile":         false,
+	} {
+		if got := linter.CanHandle(path); got != want {
+			t.Errorf("CanHandle(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestDetectDialect(t *testing.T) {
+	bash := "bash"
+	tests := []struct {
+		name    string
+		config  *ShellConfig
+		path    string
+		content string
+		want    string
+	}{
+		{name: "extension", config: &ShellConfig{}, path: "a.sh", content: "echo hi\n", want: "sh"},
+		{name: "shebang", config: &ShellConfig{}, path: "a.sh", content: "#!/bin/bash\necho hi\n", want: "bash"},
+		{name: "env shebang", config: &ShellConfig{}, path: "a.sh", content: "#!/usr/bin/env -S zsh -f\n", want: "zsh"},
+		{name: "unknown shebang", config: &ShellConfig{}, path: "a.bash", content: "#!/usr/bin/python3\n", want: "bash"},
+		{name: "configured", config: &ShellConfig{Shell: &bash}, path: "a.sh", content: "#!/bin/sh\n", want: "bash"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := detectDialect(tt.config, tt.path, []byte(tt.content)); got != tt.want {
+				t.Errorf("detectDialect() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShellLinter_Shellcheck(t *testing.T) {
+	shellcheck, args := fakeShellcheck(t, `[
+  {"file":"-","line":3,"endLine":3,"column":6,"endColumn":10,"level":"warning","code":2086,"message":"Double quote to prevent globbing and word splitting.","fix":null},
+  {"file":"-","line":5,"endLine":5,"column":1,"endColumn":2,"level":"error","code":1072,"message":"Expected 'fi'.","fix":null},
+  {"file":"-","line":1,"endLine":1,"column":1,"endColumn":2,"level":"style","code":2006,"message":"Use $(...) notation instead of legacy backticks.","fix":null},
+  {"file":"-","line":2,"endLine":2,"column":1,"endColumn":2,"level":"info","code":2034,"message":"foo appears unused.","fix":null}
+]`)
+	cache := toolcache.NewMemoryCache()
+	cache.AddTool("shell", "shellcheck", shellcheck)
+	linter := NewShellLinterWithToolCache(nil, cache)
+	if err := linter.SetConfig(json.RawMessage(`{"disabledCodes": ["2034"], "severity": "info"}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := linter.Lint(context.Background(), "/project/build.sh", []byte("#!/bin/bash\necho $1\n"))
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+	// The fake ignores --exclude, so SC2034 is filtered from the output too
+	if got := rulesOf(result.Issues); got != "SC2086,SC1072,SC2006" {
+		t.Errorf("rules = %s", got)
+	}
+	if result.Success {
+		t.Error("Success = true, want false for an error-level finding")
+	}
+	if issue := result.Issues[0]; issue.Line != 3 || issue.Column != 6 || issue.Severity != "warning" || issue.File != "/project/build.sh" {
+		t.Errorf("first issue = %+v", issue)
+	}
+	if severity := result.Issues[2].Severity; severity != "info" {
+		t.Errorf("style finding severity = %q, want info", severity)
+	}
+
+	data, err := os.ReadFile(args)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(string(data)); got != "--format=json --shell=bash --severity=info --exclude=SC2034 -" {
+		t.Errorf("shellcheck args = %q", got)
+	}
+}
+
+func TestShellLinter_ShellcheckSkipsZsh(t *testing.T) {
+	shellcheck, args := fakeShellcheck(t, "[]")
+	cache := toolcache.NewMemoryCache()
+	cache.AddTool("shell", "shellcheck", shellcheck)
+
+	// shellcheck doesn't support zsh, and without zsh installed there is no check
+	result, err := NewShellLinterWithToolCache(nil, cache).Lint(context.Background(), "prompt.zsh", []byte("setopt prompt_subst\n"))
+	if err != nil || !result.Success || len(result.Issues) != 0 {
+		t.Fatalf("Lint() = %+v, %v", result, err)
+	}
+	if _, err := os.Stat(args); err == nil {
+		t.Error("shellcheck ran on a zsh script")
+	}
+}
+
+func TestShellLinter_SyntaxFallback(t *testing.T) {
+	bash, err := exec.LookPath("bash")
+	if err != nil {
+		t.Skip("bash not installed")
+	}
+	cache := toolcache.NewMemoryCache()
+	cache.AddTool("shell", "bash", bash)
+	linter := NewShellLinterWithToolCache(nil, cache)
+
+	result, err := linter.Lint(context.Background(), "ok.sh", []byte("if true; then\n  echo hi\nfi\n"))
+	if err != nil || !result.Success || len(result.Issues) != 0 {
+		t.Fatalf("Lint() valid script = %+v, %v", result, err)
+	}
+
+	result, err = linter.Lint(context.Background(), "bad.sh", []byte("if true; then\n  echo hi\nfi fi\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Success || len(result.Issues) != 1 {
+		t.Fatalf("Lint() invalid script = %+v", result)
+	}
+	issue := result.Issues[0]
+	if issue.Line != 3 || issue.Rule != RuleSyntax || !strings.Contains(issue.Message, "unexpected token") {
+		t.Errorf("issue = %+v", issue)
+	}
+}
+
+func TestShellLinter_MaxFileSize(t *testing.T) {
+	linter := NewShellLinterWithToolCache(nil, toolcache.NewMemoryCache())
+	if err := linter.SetConfig(json.RawMessage(`{"maxFileSize": 4}`)); err != nil {
+		t.Fatal(err)
+	}
+	result, err := linter.Lint(context.Background(), "a.sh", []byte("echo hello\n"))
+	if err != nil || result.Success || len(result.Issues) != 1 {
+		t.Errorf("Lint() = %+v, %v", result, err)
+	}
+}
@@ -0,0 +1,42 @@
+package linters
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+)
+
+// AttachSourceLines sets the source lines of the issues in filePath to the issue
+// line and n lines either side of it, taken from content. Issues about the whole
+// file, or about other files, are left without source lines.
+func AttachSourceLines(filePath string, content []byte, issues []Issue, n int) {
+	if n < 0 || len(content) == 0 {
+		return
+	}
+	lines := strings.Split(string(bytes.TrimSuffix(content, []byte("\n"))), "\n")
+	for i := range issues {
+		issue := &issues[i]
+		if issue.Line < 1 || issue.Line > len(lines) || !sameFile(filePath, issue.File) {
+			continue
+		}
+		start := max(issue.Line-n, 1)
+		end := min(issue.Line+n, len(lines))
+		issue.SourceStart = start
+		issue.SourceLines = make([]string, 0, end-start+1)
+		for _, line := range lines[start-1 : end] {
+			issue.SourceLines = append(issue.SourceLines, strings.TrimSuffix(line, "\r"))
+		}
+	}
+}
+
+// sameFile reports whether an issue's file names filePath. Tools report paths
+// relative to where they ran, so a relative issue path matches its suffix.
+func sameFile(filePath, issueFile string) bool {
+	if issueFile == "" || issueFile == filePath {
+		return true
+	}
+	if filepath.IsAbs(issueFile) {
+		return filepath.Clean(issueFile) == filepath.Clean(filePath)
+	}
+	return strings.HasSuffix(filepath.ToSlash(filePath), "/"+filepath.ToSlash(filepath.Clean(issueFile)))
+}
@@ -0,0 +1,38 @@
+package linters
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAttachSourceLines(t *testing.T) {
+	content := []byte("one\ntwo\r\nthree\nfour\nfive\n")
+
+	tests := []struct {
+		name      string
+		issue     Issue
+		n         int
+		wantStart int
+		wantLines string
+	}{
+		{name: "middle", issue: Issue{Line: 3}, n: 1, wantStart: 2, wantLines: "two|three|four"},
+		{name: "clamped at start", issue: Issue{Line: 1}, n: 2, wantStart: 1, wantLines: "one|two|three"},
+		{name: "clamped at end", issue: Issue{Line: 5}, n: 2, wantStart: 3, wantLines: "three|four|five"},
+		{name: "issue line only", issue: Issue{Line: 4}, n: 0, wantStart: 4, wantLines: "four"},
+		{name: "relative path", issue: Issue{File: "pkg/main.go", Line: 2}, n: 0, wantStart: 2, wantLines: "two"},
+		{name: "whole file", issue: Issue{Line: 0}, n: 2},
+		{name: "past the end", issue: Issue{Line: 9}, n: 2},
+		{name: "other file", issue: Issue{File: "/repo/other.go", Line: 2}, n: 2},
+		{name: "disabled", issue: Issue{Line: 2}, n: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := []Issue{tt.issue}
+			AttachSourceLines("/repo/pkg/main.go", content, issues, tt.n)
+			if issues[0].SourceStart != tt.wantStart || strings.Join(issues[0].SourceLines, "|") != tt.wantLines {
+				t.Errorf("source = %d %q, want %d %q", issues[0].SourceStart, issues[0].SourceLines, tt.wantStart, tt.wantLines)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package toml
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+// cargoMetadataTimeout bounds cargo metadata, which may update the registry
+// index to resolve new dependencies
+const cargoMetadataTimeout = time.Minute
+
+// cargoNetworkErrors mark cargo failures caused by the network rather than the
+// manifest, which aren't reported
+var cargoNetworkErrors = []string{
+	"spurious network error",
+	"Could not resolve host",
+	"Couldn't resolve host",
+	"failed to download",
+	"failed to update registry",
+	"offline mode",
+}
+
+// checkCargoMetadata runs cargo metadata to confirm a written Cargo.toml still
+// resolves. cargo reads the manifest and its workspace from disk, so content
+// that hasn't been written yet isn't checked.
+func (l *TOMLLinter) checkCargoMetadata(ctx context.Context, filePath string, content []byte) []linters.Issue {
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return nil
+	}
+	if onDisk, err := os.ReadFile(absPath); err != nil || !bytes.Equal(onDisk, content) { // #nosec G304 - the linted file
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, cargoMetadataTimeout)
+	defer cancel()
+	release, err := l.runner.Acquire(ctx, "cargo")
+	if err != nil {
+		return nil
+	}
+	defer release()
+
+	cmd := l.runner.Command(ctx, l.Name(), "cargo", "metadata", "--format-version", "1", "--manifest-path", absPath)
+	cmd.Dir = filepath.Dir(absPath)
+	cmd.Stdout = io.Discard
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	err = linters.Run(cmd)
+	var execErr *exec.Error
+	if err == nil || ctx.Err() != nil || errors.As(err, &execErr) {
+		return nil
+	}
+	message := cargoErrorMessage(stderr.String())
+	if message == "" {
+		return nil
+	}
+	return []linters.Issue{{
+		File:     filePath,
+		Line:     1,
+		Column:   1,
+		Severity: "error",
+		Message:  "cargo metadata failed: " + message,
+		Rule:     RuleCargoMetadata,
+	}}
+}
+
+// cargoErrorMessage joins cargo's error and its causes into one line, such as
+// "failed to parse manifest at `Cargo.toml`: no targets specified in the
+// manifest". It returns "" for network failures.
+func cargoErrorMessage(stderr string) string {
+	for _, networkError := range cargoNetworkErrors {
+		if strings.Contains(stderr, networkError) {
+			return ""
+		}
+	}
+	var parts []string
+	for _, line := range strings.Split(stderr, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || line == "Caused by:" || strings.HasPrefix(line, "warning:") {
+			continue
+		}
+		parts = append(parts, strings.TrimPrefix(line, "error: "))
+	}
+	return strings.Join(parts, ": ")
+}
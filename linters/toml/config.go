@@ -0,0 +1,54 @@
+package toml
+
+// TOMLConfig represents TOML linter specific configuration
+type TOMLConfig struct {
+	// ManifestChecks checks the structure of Cargo.toml and pyproject.toml
+	// (default true)
+	ManifestChecks *bool `json:"manifestChecks,omitempty"`
+	// CargoMetadata runs cargo metadata on written Cargo.toml files to confirm
+	// they still resolve (default false)
+	CargoMetadata *bool `json:"cargoMetadata,omitempty"`
+	// DisabledRules lists rules to skip
+	DisabledRules []string `json:"disabledRules,omitempty"`
+	// MaxFileSize is the maximum file size in bytes to lint (default 1MB)
+	MaxFileSize *int64 `json:"maxFileSize,omitempty"`
+}
+
+// configSchema is the JSON Schema for TOMLConfig
+const configSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "properties": {
+    "manifestChecks": {
+      "type": "boolean",
+      "description": "Check the structure of Cargo.toml and pyproject.toml"
+    },
+    "cargoMetadata": {
+      "type": "boolean",
+      "description": "Run cargo metadata on written Cargo.toml files to confirm they still resolve"
+    },
+    "disabledRules": {
+      "type": "array",
+      "items": {
+        "type": "string"
+      },
+      "description": "Rules to skip"
+    },
+    "maxFileSize": {
+      "type": "integer",
+      "minimum": 0,
+      "description": "Maximum file size in bytes to lint"
+    }
+  },
+  "additionalProperties": false
+}`
+
+// DefaultTOMLConfig returns the default configuration for TOML linting
+func DefaultTOMLConfig() *TOMLConfig {
+	manifestChecks := true
+	maxFileSize := int64(1024 * 1024)
+	return &TOMLConfig{
+		ManifestChecks: &manifestChecks,
+		MaxFileSize:    &maxFileSize,
+	}
+}
@@ -0,0 +1,396 @@
+package toml
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+// cargoEditions are the Rust editions Cargo accepts
+var cargoEditions = map[string]bool{"2015": true, "2018": true, "2021": true, "2024": true}
+
+// cargoDependencyTables are the dependency tables of a package or target
+var cargoDependencyTables = []string{"dependencies", "dev-dependencies", "build-dependencies"}
+
+// lineFinder locates tables and keys in TOML source, since the parser doesn't
+// report positions
+type lineFinder struct {
+	lines []string
+}
+
+// newLineFinder returns a lineFinder for content
+func newLineFinder(content []byte) lineFinder {
+	return lineFinder{lines: strings.Split(string(content), "\n")}
+}
+
+// line returns the line defining key in table, else the header of the table
+// [table.key], else the header of table, else 1. An empty table is the root.
+func (f lineFinder) line(table, key string) int {
+	if line := f.keyLine(table, key); line > 0 {
+		return line
+	}
+	if key != "" {
+		if line := f.headerLine(joinKey(table, key)); line > 0 {
+			return line
+		}
+	}
+	if line := f.headerLine(table); line > 0 {
+		return line
+	}
+	return 1
+}
+
+// headerLine returns the line of the [table] or [[table]] header, or 0
+func (f lineFinder) headerLine(table string) int {
+	if table == "" {
+		return 0
+	}
+	for i, line := range f.lines {
+		if name, ok := headerName(line); ok && name == table {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// keyLine returns the line of "key = ..." or "key.sub = ..." directly in table, or 0
+func (f lineFinder) keyLine(table, key string) int {
+	if key == "" {
+		return 0
+	}
+	current := ""
+	for i, line := range f.lines {
+		if name, ok := headerName(line); ok {
+			current = name
+			continue
+		}
+		if current != table {
+			continue
+		}
+		trimmed := strings.TrimSpace(line)
+		for _, quoted := range []string{key, `"` + key + `"`, "'" + key + "'"} {
+			if rest, ok := strings.CutPrefix(trimmed, quoted); ok {
+				rest = strings.TrimSpace(rest)
+				if strings.HasPrefix(rest, "=") || strings.HasPrefix(rest, ".") {
+					return i + 1
+				}
+			}
+		}
+	}
+	return 0
+}
+
+// headerName returns the table name of a [table] or [[table]] header line, with
+// quotes removed from its keys
+func headerName(line string) (string, bool) {
+	trimmed := strings.TrimSpace(line)
+	if !strings.HasPrefix(trimmed, "[") {
+		return "", false
+	}
+	trimmed = strings.TrimLeft(trimmed, "[")
+
+	var keys []string
+	var key strings.Builder
+	var quote rune
+	for _, r := range trimmed {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				key.WriteRune(r)
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '.':
+			keys = append(keys, strings.TrimSpace(key.String()))
+			key.Reset()
+		case r == ']':
+			return strings.Join(append(keys, strings.TrimSpace(key.String())), "."), true
+		default:
+			key.WriteRune(r)
+		}
+	}
+	return "", false
+}
+
+// joinKey joins a table name and a key into a dotted table name
+func joinKey(table, key string) string {
+	if table == "" {
+		return key
+	}
+	return table + "." + key
+}
+
+// manifestIssues collects errors found in a manifest
+type manifestIssues struct {
+	filePath string
+	rule     string
+	finder   lineFinder
+	issues   []linters.Issue
+}
+
+// add reports an error at key in table
+func (m *manifestIssues) add(table, key, format string, args ...interface{}) {
+	m.issues = append(m.issues, linters.Issue{
+		File:     m.filePath,
+		Line:     m.finder.line(table, key),
+		Column:   1,
+		Severity: "error",
+		Message:  fmt.Sprintf(format, args...),
+		Rule:     m.rule,
+	})
+}
+
+// warn reports a warning for rule at key in table
+func (m *manifestIssues) warn(rule, table, key, format string, args ...interface{}) {
+	m.issues = append(m.issues, linters.Issue{
+		File:     m.filePath,
+		Line:     m.finder.line(table, key),
+		Column:   1,
+		Severity: "warning",
+		Message:  fmt.Sprintf(format, args...),
+		Rule:     rule,
+	})
+}
+
+// checkCargo checks the structure of a Cargo.toml manifest
+func checkCargo(filePath string, data map[string]interface{}, finder lineFinder) []linters.Issue {
+	m := &manifestIssues{filePath: filePath, rule: RuleCargo, finder: finder}
+
+	pkg, hasPackage := data["package"].(map[string]interface{})
+	workspace, hasWorkspace := data["workspace"].(map[string]interface{})
+	if !hasPackage && !hasWorkspace {
+		m.add("", "", "Cargo.toml needs a [package] or [workspace] table")
+	}
+
+	if hasPackage {
+		if name, ok := pkg["name"].(string); !ok || name == "" {
+			m.add("package", "name", "[package] needs a name")
+		}
+		if version, ok := pkg["version"]; ok && !isString(version) && !inheritsWorkspace(version) {
+			m.add("package", "version", "package.version must be a string such as \"0.1.0\"")
+		}
+		if edition, ok := pkg["edition"]; ok && !inheritsWorkspace(edition) {
+			if s, ok := edition.(string); !ok || !cargoEditions[s] {
+				m.add("package", "edition", "package.edition %v is not one of 2015, 2018, 2021 or 2024", formatValue(edition))
+			}
+		}
+	}
+
+	for _, table := range cargoDependencyTables {
+		checkCargoDependencies(m, table, data[table])
+	}
+	checkCargoDuplicates(m, "", data)
+	if hasWorkspace {
+		checkCargoDependencies(m, "workspace.dependencies", workspace["dependencies"])
+	}
+	if targets, ok := data["target"].(map[string]interface{}); ok {
+		for _, target := range sortedKeys(targets) {
+			if tables, ok := targets[target].(map[string]interface{}); ok {
+				for _, table := range cargoDependencyTables {
+					checkCargoDependencies(m, "target."+target+"."+table, tables[table])
+				}
+				checkCargoDuplicates(m, "target."+target+".", tables)
+			}
+		}
+	}
+	return m.issues
+}
+
+// checkCargoDependencies checks that each dependency names where it comes from
+func checkCargoDependencies(m *manifestIssues, table string, value interface{}) {
+	if value == nil {
+		return
+	}
+	dependencies, ok := value.(map[string]interface{})
+	if !ok {
+		m.add("", table, "[%s] must be a table of dependencies", table)
+		return
+	}
+	for _, name := range sortedKeys(dependencies) {
+		version := ""
+		switch spec := dependencies[name].(type) {
+		case string:
+			version = spec
+		case map[string]interface{}:
+			if !hasAnyKey(spec, "version", "path", "git", "workspace") {
+				m.add(table, name, "Dependency %q in [%s] needs a version, path, git or workspace key", name, table)
+			}
+			version, _ = spec["version"].(string)
+		default:
+			m.add(table, name, "Dependency %q in [%s] must be a version string or a table", name, table)
+		}
+		if strings.Contains(version, "*") {
+			m.warn(RuleCargoWildcard, table, name, "Dependency %q in [%s] accepts any version with %q; require a version such as \"1.2\", as crates.io rejects wildcard requirements", name, table, version)
+		}
+	}
+}
+
+// checkCargoDuplicates warns about crates that are both regular and dev
+// dependencies of the same package or target, prefix being "" or
+// "target.<cfg>."
+func checkCargoDuplicates(m *manifestIssues, prefix string, tables map[string]interface{}) {
+	regular, ok := tables["dependencies"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	crates := make(map[string]string, len(regular))
+	for _, name := range sortedKeys(regular) {
+		crates[crateName(name, regular[name])] = name
+	}
+	dev, _ := tables["dev-dependencies"].(map[string]interface{})
+	for _, name := range sortedKeys(dev) {
+		if regularName, ok := crates[crateName(name, dev[name])]; ok {
+			m.warn(RuleCargoDuplicate, prefix+"dev-dependencies", name,
+				"Dependency %q in [%sdev-dependencies] is also %q in [%sdependencies]; tests already see regular dependencies, so remove it or only add the features tests need",
+				name, prefix, regularName, prefix)
+		}
+	}
+}
+
+// crateName returns the crate a dependency refers to, which differs from its
+// key when it is renamed with package = "..."
+func crateName(key string, spec interface{}) string {
+	if table, ok := spec.(map[string]interface{}); ok {
+		if name, ok := table["package"].(string); ok && name != "" {
+			return name
+		}
+	}
+	return key
+}
+
+// checkPyproject checks the [build-system] and [project] tables of a
+// pyproject.toml against PEP 517 and PEP 621
+func checkPyproject(filePath string, data map[string]interface{}, finder lineFinder) []linters.Issue {
+	m := &manifestIssues{filePath: filePath, rule: RulePyproject, finder: finder}
+
+	if value, ok := data["build-system"]; ok {
+		buildSystem, ok := value.(map[string]interface{})
+		switch {
+		case !ok:
+			m.add("", "build-system", "build-system must be a table")
+		case buildSystem["requires"] == nil:
+			m.add("build-system", "", "[build-system] needs requires, the packages needed to build the project")
+		case !isStringArray(buildSystem["requires"]):
+			m.add("build-system", "requires", "build-system.requires must be a list of strings")
+		}
+		if ok && buildSystem["build-backend"] != nil && !isString(buildSystem["build-backend"]) {
+			m.add("build-system", "build-backend", "build-system.build-backend must be a string")
+		}
+	}
+
+	value, ok := data["project"]
+	if !ok {
+		return m.issues
+	}
+	project, ok := value.(map[string]interface{})
+	if !ok {
+		m.add("", "project", "project must be a table")
+		return m.issues
+	}
+
+	dynamic := make(map[string]bool)
+	if value, ok := project["dynamic"]; ok {
+		if !isStringArray(value) {
+			m.add("project", "dynamic", "project.dynamic must be a list of strings")
+		} else {
+			for _, field := range value.([]interface{}) {
+				dynamic[field.(string)] = true
+			}
+		}
+	}
+
+	if name, ok := project["name"].(string); !ok || name == "" {
+		m.add("project", "name", "[project] needs a name")
+	}
+	if dynamic["name"] {
+		m.add("project", "dynamic", "project.name can't be dynamic")
+	}
+	switch version, ok := project["version"]; {
+	case ok && dynamic["version"]:
+		m.add("project", "version", "project.version is set but also listed in project.dynamic")
+	case ok && !isString(version):
+		m.add("project", "version", "project.version must be a string")
+	case !ok && !dynamic["version"]:
+		m.add("project", "", "[project] needs a version, or \"version\" in project.dynamic")
+	}
+
+	for _, field := range []string{"description", "requires-python"} {
+		if value, ok := project[field]; ok && !isString(value) {
+			m.add("project", field, "project.%s must be a string", field)
+		}
+	}
+	if value, ok := project["dependencies"]; ok && !isStringArray(value) {
+		m.add("project", "dependencies", "project.dependencies must be a list of requirement strings")
+	}
+	if value, ok := project["optional-dependencies"]; ok {
+		extras, ok := value.(map[string]interface{})
+		if !ok {
+			m.add("project", "optional-dependencies", "project.optional-dependencies must be a table of lists")
+		} else {
+			for _, extra := range sortedKeys(extras) {
+				if !isStringArray(extras[extra]) {
+					m.add("project.optional-dependencies", extra, "Optional dependencies %q must be a list of requirement strings", extra)
+				}
+			}
+		}
+	}
+	return m.issues
+}
+
+// isString reports whether value is a TOML string
+func isString(value interface{}) bool {
+	_, ok := value.(string)
+	return ok
+}
+
+// isStringArray reports whether value is a TOML array of strings
+func isStringArray(value interface{}) bool {
+	items, ok := value.([]interface{})
+	if !ok {
+		return false
+	}
+	for _, item := range items {
+		if !isString(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// inheritsWorkspace reports whether value is {workspace = true}
+func inheritsWorkspace(value interface{}) bool {
+	table, ok := value.(map[string]interface{})
+	return ok && table["workspace"] == true
+}
+
+// hasAnyKey reports whether table has one of keys
+func hasAnyKey(table map[string]interface{}, keys ...string) bool {
+	for _, key := range keys {
+		if _, ok := table[key]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedKeys returns the keys of table in order, for stable issue order
+func sortedKeys(table map[string]interface{}) []string {
+	keys := make([]string, 0, len(table))
+	for key := range table {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// formatValue formats a TOML value for a message, quoting strings
+func formatValue(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+	return fmt.Sprintf("%v", value)
+}
@@ -0,0 +1,201 @@
+package toml
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/BurntSushi/toml"
+	"github.com/jrossi/gismo/linters"
+)
+
+// Rules reported by the TOML linter
+const (
+	RuleSyntax         = "syntax"
+	RuleCargo          = "cargo-manifest"
+	RuleCargoDuplicate = "cargo-duplicate-dependency"
+	RuleCargoWildcard  = "cargo-wildcard-version"
+	RuleCargoMetadata  = "cargo-metadata"
+	RulePyproject      = "pyproject"
+	RuleFileSize       = "file-size"
+)
+
+// errorPrefix matches the location BurntSushi/toml puts before its messages, e.g.
+// `toml: line 3 (last key "b"): `
+var errorPrefix = regexp.MustCompile(`^toml: line \d+(?: \(last key "[^"]*"\))?: `)
+
+// TOMLLinter checks TOML syntax and the structure of Cargo.toml and pyproject.toml
+type TOMLLinter struct {
+	mu     sync.RWMutex
+	config *TOMLConfig
+	// Runs cargo metadata with the engine's limits and environment
+	runner *linters.CommandRunner
+}
+
+// NewTOMLLinter creates a new TOML linter with default configuration
+func NewTOMLLinter() *TOMLLinter {
+	return NewTOMLLinterWithConfig(nil)
+}
+
+// NewTOMLLinterWithConfig creates a new TOML linter with the given configuration
+func NewTOMLLinterWithConfig(config *TOMLConfig) *TOMLLinter {
+	if config == nil {
+		config = DefaultTOMLConfig()
+	}
+	return &TOMLLinter{config: config, runner: linters.NewCommandRunner(linters.RunnerConfig{})}
+}
+
+// Name returns the linter name
+func (l *TOMLLinter) Name() string {
+	return "toml"
+}
+
+// Capabilities reports the built-in checks and the external tools used when installed
+func (l *TOMLLinter) Capabilities() linters.Capabilities {
+	return linters.Capabilities{
+		Embedded: []string{"syntax", "Cargo.toml", "pyproject.toml"},
+		Tools:    []string{"cargo"},
+	}
+}
+
+// SetCommandRunner sets the runner used to start cargo
+func (l *TOMLLinter) SetCommandRunner(runner *linters.CommandRunner) {
+	l.runner = runner
+}
+
+// CanHandle returns true for TOML files
+func (l *TOMLLinter) CanHandle(filePath string) bool {
+	return strings.HasSuffix(strings.ToLower(filePath), ".toml")
+}
+
+// SetConfig updates the linter configuration
+func (l *TOMLLinter) SetConfig(configData json.RawMessage) error {
+	// Settings not given keep their defaults
+	config := DefaultTOMLConfig()
+	if err := json.Unmarshal(configData, config); err != nil {
+		return fmt.Errorf("failed to parse toml config: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.config = config
+	return nil
+}
+
+// ConfigSchema returns the JSON Schema for the linter configuration
+func (l *TOMLLinter) ConfigSchema() json.RawMessage {
+	return json.RawMessage(configSchema)
+}
+
+// Rules describes the checks
+func (l *TOMLLinter) Rules() map[string]string {
+	return map[string]string{
+		RuleSyntax:         "The file parses as TOML, without duplicate keys or tables",
+		RuleCargo:          "Cargo.toml has a [package] with a name or a [workspace], a known edition, and dependencies with a version, path, git or workspace source",
+		RuleCargoDuplicate: "A crate in Cargo.toml's [dependencies] isn't repeated in [dev-dependencies]",
+		RuleCargoWildcard:  "Cargo.toml dependencies require a version rather than \"*\"",
+		RuleCargoMetadata:  "cargo metadata resolves the written Cargo.toml, when cargoMetadata is enabled",
+		RulePyproject:      "pyproject.toml follows PEP 517 and PEP 621: build-system.requires and project.dependencies are lists of strings, and [project] has a name and a version unless it is dynamic",
+		RuleFileSize:       "The file is no larger than the configured maxFileSize",
+	}
+}
+
+// Lint checks TOML syntax, then the structure of well-known manifests
+func (l *TOMLLinter) Lint(ctx context.Context, filePath string, content []byte) (*linters.LintResult, error) {
+	l.mu.RLock()
+	config := l.config
+	l.mu.RUnlock()
+
+	result := &linters.LintResult{
+		Success: true,
+		Issues:  []linters.Issue{},
+	}
+
+	if config.MaxFileSize != nil && int64(len(content)) > *config.MaxFileSize {
+		result.Issues = append(result.Issues, linters.Issue{
+			File:     filePath,
+			Line:     1,
+			Column:   1,
+			Severity: "error",
+			Message:  fmt.Sprintf("File size %d exceeds limit %d", len(content), *config.MaxFileSize),
+			Rule:     RuleFileSize,
+		})
+		result.Success = false
+		return result, nil
+	}
+
+	var data map[string]interface{}
+	var issues []linters.Issue
+	if _, err := toml.Decode(string(content), &data); err != nil {
+		issues = append(issues, syntaxIssue(filePath, content, err))
+	} else if config.ManifestChecks == nil || *config.ManifestChecks {
+		finder := newLineFinder(content)
+		switch strings.ToLower(filepath.Base(filePath)) {
+		case "cargo.toml":
+			issues = checkCargo(filePath, data, finder)
+			if config.CargoMetadata != nil && *config.CargoMetadata && !hasError(issues) {
+				issues = append(issues, l.checkCargoMetadata(ctx, filePath, content)...)
+			}
+		case "pyproject.toml":
+			issues = checkPyproject(filePath, data, finder)
+		}
+	}
+
+	for _, issue := range issues {
+		if isDisabled(config, issue.Rule) {
+			continue
+		}
+		if issue.Severity == "error" {
+			result.Success = false
+		}
+		result.Issues = append(result.Issues, issue)
+	}
+	return result, nil
+}
+
+// syntaxIssue converts a parse error into an issue at the position it names.
+// The position is taken from the byte offset, as the reported line is one too
+// far for errors at the end of a line.
+func syntaxIssue(filePath string, content []byte, err error) linters.Issue {
+	line, column := 1, 1
+	message := err.Error()
+	var parseErr toml.ParseError
+	if errors.As(err, &parseErr) {
+		if start := parseErr.Position.Start; start >= 0 && start <= len(content) {
+			line, column = linters.NewPositions(content).Position(start)
+		}
+	}
+	return linters.Issue{
+		File:     filePath,
+		Line:     line,
+		Column:   column,
+		Severity: "error",
+		Message:  "TOML syntax error: " + errorPrefix.ReplaceAllString(message, ""),
+		Rule:     RuleSyntax,
+	}
+}
+
+// hasError reports whether any of issues is an error
+func hasError(issues []linters.Issue) bool {
+	for _, issue := range issues {
+		if issue.Severity == "error" {
+			return true
+		}
+	}
+	return false
+}
+
+// isDisabled reports whether a rule is disabled by configuration
+func isDisabled(config *TOMLConfig, rule string) bool {
+	for _, disabled := range config.DisabledRules {
+		if disabled == rule {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,308 @@
+package toml
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+// describe returns "line:rule" for each issue
+func describe(issues []linters.Issue) string {
+	parts := []string{}
+	for _, issue := range issues {
+		parts = append(parts, fmt.Sprintf("%d:%s", issue.Line, issue.Rule))
+	}
+	return strings.Join(parts, ",")
+}
+
+func TestTOMLLinter_CanHandle(t *testing.T) {
+	linter := NewTOMLLinter()
+	for path, want := range map[string]bool{
+		"Cargo.toml":          true,
+		"py/pyproject.TOML":   true,
+		"config/app.toml":     true,
+		"go.work":             false,
+		"Cargo.lock":          false,
+		"notes.toml.md":       false,
+		"settings.tomlconfig": false,
+	} {
+		if got := linter.CanHandle(path); got != want {
+			t.Errorf("CanHandle(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestTOMLLinter_Syntax(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      string
+		line, column int
+		message      string
+	}{
+		{name: "missing value", content: "a = 1\nb = \n", line: 2, column: 5, message: "expected value"},
+		{name: "duplicate key", content: "[package]\nname = \"a\"\nname = \"b\"\n", line: 3, message: "already been defined"},
+		{name: "unterminated string", content: "[x]\ny = \"abc\n", line: 2, message: "newlines"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewTOMLLinter().Lint(context.Background(), "config.toml", []byte(tt.content))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Success || len(result.Issues) != 1 {
+				t.Fatalf("Lint() = %+v", result)
+			}
+			issue := result.Issues[0]
+			if issue.Rule != RuleSyntax || issue.Line != tt.line || !strings.Contains(issue.Message, tt.message) {
+				t.Errorf("issue = %+v", issue)
+			}
+			if tt.column > 0 && issue.Column != tt.column {
+				t.Errorf("column = %d, want %d", issue.Column, tt.column)
+			}
+			if strings.Contains(issue.Message, "toml: line") {
+				t.Errorf("message repeats the location: %q", issue.Message)
+			}
+		})
+	}
+
+	result, err := NewTOMLLinter().Lint(context.Background(), "config.toml", []byte("title = \"ok\"\n[owner]\nname = \"x\"\n"))
+	if err != nil || !result.Success || len(result.Issues) != 0 {
+		t.Errorf("Lint() valid TOML = %+v, %v", result, err)
+	}
+}
+
+func TestTOMLLinter_Cargo(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "valid package",
+			content: "[package]\nname = \"app\"\nversion = \"0.1.0\"\nedition = \"2021\"\n\n[dependencies]\nserde = { version = \"1\", features = [\"derive\"] }\nlocal = { path = \"../local\" }\nanyhow = \"1\"\n\n[dependencies.tokio]\nversion = \"1\"\n",
+			want:    "",
+		},
+		{
+			name:    "valid workspace",
+			content: "[workspace]\nmembers = [\"a\", \"b\"]\n\n[workspace.dependencies]\nlog = \"0.4\"\n",
+			want:    "",
+		},
+		{
+			name:    "inherited fields",
+			content: "[package]\nname = \"a\"\nversion.workspace = true\nedition = { workspace = true }\n\n[dependencies]\nlog.workspace = true\n",
+			want:    "",
+		},
+		{name: "no package or workspace", content: "[dependencies]\nserde = \"1\"\n", want: "1:cargo-manifest"},
+		{name: "missing name", content: "# app\n[package]\nversion = \"0.1.0\"\n", want: "2:cargo-manifest"},
+		{name: "bad edition", content: "[package]\nname = \"a\"\nedition = \"2020\"\n", want: "3:cargo-manifest"},
+		{name: "bad version", content: "[package]\nname = \"a\"\nversion = 1\n", want: "3:cargo-manifest"},
+		{
+			name:    "dependency without source",
+			content: "[package]\nname = \"a\"\n\n[dev-dependencies]\nmockall = { features = [\"nightly\"] }\n",
+			want:    "5:cargo-manifest",
+		},
+		{
+			name:    "target dependencies",
+			content: "[package]\nname = \"a\"\n\n[target.'cfg(target_os = \"linux\")'.dependencies]\nnix = 1\n",
+			want:    "5:cargo-manifest",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewTOMLLinter().Lint(context.Background(), "/crate/Cargo.toml", []byte(tt.content))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := describe(result.Issues); got != tt.want {
+				t.Errorf("issues = %q, want %q (%+v)", got, tt.want, result.Issues)
+			}
+			if result.Success != (tt.want == "") {
+				t.Errorf("Success = %v", result.Success)
+			}
+		})
+	}
+}
+
+func TestTOMLLinter_CargoDependencyTableHeader(t *testing.T) {
+	content := "[package]\nname = \"a\"\n\n[dependencies.tokio]\nfeatures = [\"full\"]\n"
+	result, err := NewTOMLLinter().Lint(context.Background(), "Cargo.toml", []byte(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Dependencies declared as their own table are reported at the header
+	if got := describe(result.Issues); got != "4:cargo-manifest" {
+		t.Errorf("issues = %q", got)
+	}
+}
+
+func TestTOMLLinter_CargoDependencyWarnings(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "wildcard versions",
+			content: "[package]\nname = \"a\"\n\n[dependencies]\nrand = \"*\"\nlog = { version = \"0.4.*\" }\nserde = \"1\"\n\n[workspace.dependencies]\nregex = \"*\"\n",
+			want:    "6:cargo-wildcard-version,5:cargo-wildcard-version,10:cargo-wildcard-version",
+		},
+		{
+			name:    "dev dependency repeated",
+			content: "[package]\nname = \"a\"\n\n[dependencies]\nserde = \"1\"\nlog = \"0.4\"\n\n[dev-dependencies]\nserde = { version = \"1\", features = [\"derive\"] }\nproptest = \"1\"\n",
+			want:    "9:cargo-duplicate-dependency",
+		},
+		{
+			name:    "renamed dev dependency",
+			content: "[package]\nname = \"a\"\n\n[dependencies]\ntokio = \"1\"\n\n[dev-dependencies]\ntokio-test = { package = \"tokio\", version = \"1\" }\n",
+			want:    "8:cargo-duplicate-dependency",
+		},
+		{
+			name:    "target dev dependency repeated",
+			content: "[package]\nname = \"a\"\n\n[dependencies]\nnix = \"0.29\"\n\n[target.'cfg(unix)'.dependencies]\nlibc = \"0.2\"\n\n[target.'cfg(unix)'.dev-dependencies]\nlibc = \"0.2\"\nnix = \"0.29\"\n",
+			want:    "11:cargo-duplicate-dependency",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewTOMLLinter().Lint(context.Background(), "Cargo.toml", []byte(tt.content))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := describe(result.Issues); got != tt.want {
+				t.Errorf("issues = %q, want %q (%+v)", got, tt.want, result.Issues)
+			}
+			if !result.Success {
+				t.Error("warnings should not fail the lint")
+			}
+		})
+	}
+}
+
+func TestTOMLLinter_CargoMetadata(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake cargo is a shell script")
+	}
+	binDir := t.TempDir()
+	calls := filepath.Join(binDir, "calls")
+	// The fake cargo fails for manifests without a [lib] or [[bin]], or reports a
+	// network failure for those depending on "offline"
+	script := "#!/bin/sh\necho \"$*\" >> " + calls + "\n" +
+		"manifest=$5\n" +
+		"if grep -q offline \"$manifest\"; then echo 'warning: spurious network error (2 tries remaining)' >&2; exit 101; fi\n" +
+		"grep -q '^\\[lib\\]' \"$manifest\" && exit 0\n" +
+		"printf 'error: failed to parse manifest at `%s`\\n\\nCaused by:\\n  no targets specified in the manifest\\n' \"$manifest\" >&2\nexit 101\n"
+	if err := os.WriteFile(filepath.Join(binDir, "cargo"), []byte(script), 0700); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	linter := NewTOMLLinter()
+	if err := linter.SetConfig(json.RawMessage(`{"cargoMetadata": true}`)); err != nil {
+		t.Fatal(err)
+	}
+	manifest := filepath.Join(t.TempDir(), "Cargo.toml")
+	lint := func(content string, write bool) *linters.LintResult {
+		t.Helper()
+		if write {
+			if err := os.WriteFile(manifest, []byte(content), 0600); err != nil {
+				t.Fatal(err)
+			}
+		}
+		result, err := linter.Lint(context.Background(), manifest, []byte(content))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result
+	}
+
+	broken := "[package]\nname = \"a\"\nversion = \"0.1.0\"\n"
+	result := lint(broken, true)
+	if result.Success || len(result.Issues) != 1 || result.Issues[0].Rule != RuleCargoMetadata ||
+		!strings.Contains(result.Issues[0].Message, "failed to parse manifest at `"+manifest+"`: no targets specified in the manifest") {
+		t.Errorf("written manifest that doesn't resolve: %+v", result)
+	}
+
+	if result := lint(broken+"\n[lib]\npath = \"lib.rs\"\n", true); !result.Success || len(result.Issues) != 0 {
+		t.Errorf("manifest that resolves: %+v", result)
+	}
+	if result := lint(broken+"\n[dependencies]\noffline = \"1\"\n", true); len(result.Issues) != 0 {
+		t.Errorf("network failures should not be reported: %+v", result.Issues)
+	}
+
+	// Content that isn't on disk yet isn't checked
+	data, _ := os.ReadFile(calls)
+	before := strings.Count(string(data), "\n")
+	lint("[package]\nname = \"b\"\n", false)
+	data, _ = os.ReadFile(calls)
+	if after := strings.Count(string(data), "\n"); after != before || !strings.Contains(string(data), "metadata --format-version 1 --manifest-path "+manifest) {
+		t.Errorf("cargo calls = %q", data)
+	}
+}
+
+func TestTOMLLinter_Pyproject(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "valid",
+			content: "[build-system]\nrequires = [\"hatchling\"]\nbuild-backend = \"hatchling.build\"\n\n[project]\nname = \"app\"\nversion = \"1.0\"\nrequires-python = \">=3.10\"\ndependencies = [\"httpx>=0.27\"]\n\n[project.optional-dependencies]\ntest = [\"pytest\"]\n",
+			want:    "",
+		},
+		{name: "dynamic version", content: "[project]\nname = \"app\"\ndynamic = [\"version\"]\n", want: ""},
+		{name: "tool only", content: "[tool.ruff]\nline-length = 100\n", want: ""},
+		{name: "missing version", content: "[project]\nname = \"app\"\n", want: "1:pyproject"},
+		{name: "version also dynamic", content: "[project]\nname = \"app\"\nversion = \"1\"\ndynamic = [\"version\"]\n", want: "3:pyproject"},
+		{name: "missing requires", content: "[build-system]\nbuild-backend = \"setuptools.build_meta\"\n", want: "1:pyproject"},
+		{
+			name:    "dependencies not strings",
+			content: "[project]\nname = \"app\"\nversion = \"1\"\ndependencies = \"httpx\"\n\n[project.optional-dependencies]\ndev = [\"ruff\", 1]\n",
+			want:    "4:pyproject,7:pyproject",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewTOMLLinter().Lint(context.Background(), "pyproject.toml", []byte(tt.content))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := describe(result.Issues); got != tt.want {
+				t.Errorf("issues = %q, want %q (%+v)", got, tt.want, result.Issues)
+			}
+		})
+	}
+}
+
+func TestTOMLLinter_Config(t *testing.T) {
+	content := []byte("[dependencies]\nserde = \"1\"\n")
+
+	linter := NewTOMLLinter()
+	if err := linter.SetConfig(json.RawMessage(`{"manifestChecks": false}`)); err != nil {
+		t.Fatal(err)
+	}
+	if result, _ := linter.Lint(context.Background(), "Cargo.toml", content); len(result.Issues) != 0 {
+		t.Errorf("manifestChecks false: issues = %+v", result.Issues)
+	}
+
+	if err := linter.SetConfig(json.RawMessage(`{"disabledRules": ["cargo-manifest"], "maxFileSize": 1000}`)); err != nil {
+		t.Fatal(err)
+	}
+	if result, _ := linter.Lint(context.Background(), "Cargo.toml", content); len(result.Issues) != 0 {
+		t.Errorf("disabled rule: issues = %+v", result.Issues)
+	}
+
+	if err := linter.SetConfig(json.RawMessage(`{"maxFileSize": 4}`)); err != nil {
+		t.Fatal(err)
+	}
+	if result, _ := linter.Lint(context.Background(), "Cargo.toml", content); result.Success || describe(result.Issues) != "1:file-size" {
+		t.Errorf("maxFileSize: %+v", result)
+	}
+}
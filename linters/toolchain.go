@@ -0,0 +1,213 @@
+package linters
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// RuleToolchainMismatch is reported when the toolchain the hooks run with isn't
+// the one the project declares, a common reason results differ between machines
+const RuleToolchainMismatch = "toolchain-mismatch"
+
+// ToolchainMismatch is a toolchain version that doesn't match the project's
+// declared version
+type ToolchainMismatch struct {
+	Tool     string // "go" or "node"
+	Running  ToolVersion
+	Expected string // the declared version, such as ">= 1.23" or "^20.11"
+	Source   string // where the version is declared, such as "go.mod" or ".nvmrc"
+}
+
+// Issue returns a warning at the top of filePath describing the mismatch
+func (m *ToolchainMismatch) Issue(filePath string) Issue {
+	return Issue{
+		File:     filePath,
+		Line:     1,
+		Column:   1,
+		Severity: "warning",
+		Message: fmt.Sprintf("%s %s runs the hooks but %s expects %s %s, so results may differ from the project's toolchain",
+			m.Tool, m.Running, m.Source, m.Tool, m.Expected),
+		Rule: RuleToolchainMismatch,
+		Tool: m.Tool,
+	}
+}
+
+// CheckGoToolchain compares the running go version against goMod's toolchain
+// directive, or without one its go directive. A toolchain directive must match
+// the running major.minor version; the go directive is a minimum.
+func CheckGoToolchain(goMod []byte, running ToolVersion) *ToolchainMismatch {
+	var goLine, toolchainLine string
+	scanner := bufio.NewScanner(bytes.NewReader(goMod))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		switch fields[0] {
+		case "go":
+			goLine = fields[1]
+		case "toolchain":
+			toolchainLine = strings.TrimPrefix(fields[1], "go")
+		}
+	}
+
+	if toolchain, ok := ParseToolVersion(toolchainLine); ok {
+		if running.Major != toolchain.Major || running.Minor != toolchain.Minor {
+			return &ToolchainMismatch{Tool: "go", Running: running, Expected: toolchainLine, Source: "go.mod's toolchain directive"}
+		}
+		return nil
+	}
+	if minimum, ok := ParseToolVersion(goLine); ok && running.Compare(minimum) < 0 {
+		return &ToolchainMismatch{Tool: "go", Running: running, Expected: ">= " + goLine, Source: "go.mod"}
+	}
+	return nil
+}
+
+// CheckNodeToolchain compares the running node version against the closest
+// .nvmrc or package.json engines.node at or above dir. Declarations that aren't
+// version numbers or ranges, such as "lts/*", are not checked.
+func CheckNodeToolchain(fsys FileSystem, dir string, running ToolVersion) *ToolchainMismatch {
+	for {
+		if data, err := fsys.ReadFile(filepath.Join(dir, ".nvmrc")); err == nil {
+			expected, _, _ := strings.Cut(strings.TrimSpace(string(data)), "\n")
+			return nodeMismatch(running, strings.TrimSpace(expected), ".nvmrc")
+		}
+		if data, err := fsys.ReadFile(filepath.Join(dir, "package.json")); err == nil {
+			var manifest struct {
+				Engines struct {
+					Node string `json:"node"`
+				} `json:"engines"`
+			}
+			if json.Unmarshal(data, &manifest) == nil && manifest.Engines.Node != "" {
+				return nodeMismatch(running, manifest.Engines.Node, "package.json engines")
+			}
+		}
+		if _, err := fsys.Stat(filepath.Join(dir, ".git")); err == nil {
+			return nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil
+		}
+		dir = parent
+	}
+}
+
+// nodeMismatch returns the mismatch if running doesn't satisfy expected
+func nodeMismatch(running ToolVersion, expected, source string) *ToolchainMismatch {
+	if satisfied, ok := SatisfiesVersionRange(running, expected); ok && !satisfied {
+		return &ToolchainMismatch{Tool: "node", Running: running, Expected: expected, Source: source}
+	}
+	return nil
+}
+
+// SatisfiesVersionRange reports whether v is in the npm-style version range,
+// such as "20", "^18.17", ">=18 <21" or "18.x || 20.x". ok is false if the
+// range can't be read.
+func SatisfiesVersionRange(v ToolVersion, versionRange string) (satisfied, ok bool) {
+	for _, alternative := range strings.Split(versionRange, "||") {
+		tokens := strings.Fields(alternative)
+		// A hyphen range, "1.2 - 2.3", is inclusive at both ends
+		if len(tokens) == 3 && tokens[1] == "-" {
+			tokens = []string{">=" + tokens[0], "<=" + tokens[2]}
+		}
+		all := true
+		for i := 0; i < len(tokens); i++ {
+			comparator := tokens[i]
+			// Operators may be separated from their version, as in ">= 18"
+			if strings.Trim(comparator, "<>=^~") == "" && i+1 < len(tokens) {
+				i++
+				comparator += tokens[i]
+			}
+			match, ok := satisfiesComparator(v, comparator)
+			if !ok {
+				return false, false
+			}
+			all = all && match
+		}
+		if all {
+			satisfied = true
+		}
+	}
+	return satisfied, true
+}
+
+// satisfiesComparator reports whether v satisfies a single comparator, such as
+// ">=18.17" or "^20"
+func satisfiesComparator(v ToolVersion, comparator string) (satisfied, ok bool) {
+	version := strings.TrimLeft(comparator, "<>=^~")
+	operator := comparator[:len(comparator)-len(version)]
+	parts, n, ok := parsePartialVersion(version)
+	if !ok {
+		return false, false
+	}
+	cmp := comparePrefix(v, parts, n)
+	switch operator {
+	case "", "=":
+		return cmp == 0, true
+	case ">":
+		return cmp > 0, true
+	case ">=":
+		return cmp >= 0, true
+	case "<":
+		return cmp < 0, true
+	case "<=":
+		return cmp <= 0, true
+	case "~":
+		// Patch updates: ~1.2.3 is >=1.2.3 <1.3.0, ~1 is 1.x
+		return cmp >= 0 && comparePrefix(v, parts, min(n, 2)) == 0, true
+	case "^":
+		// Updates that leave the first non-zero part alone
+		fixed := 1
+		for fixed < n && parts[fixed-1] == 0 {
+			fixed++
+		}
+		return cmp >= 0 && comparePrefix(v, parts, min(n, fixed)) == 0, true
+	}
+	return false, false
+}
+
+// parsePartialVersion parses a version that may leave out trailing parts or
+// give them as wildcards, such as "v20", "18.x" or "*". n is the number of
+// parts given.
+func parsePartialVersion(s string) (parts [3]int, n int, ok bool) {
+	s = strings.TrimPrefix(s, "v")
+	if s == "" {
+		return parts, 0, true
+	}
+	// Prerelease and build metadata are ignored
+	if i := strings.IndexAny(s, "-+"); i >= 0 {
+		s = s[:i]
+	}
+	for _, field := range strings.SplitN(s, ".", 3) {
+		if field == "x" || field == "X" || field == "*" {
+			break
+		}
+		value, err := strconv.Atoi(field)
+		if err != nil || value < 0 {
+			return parts, 0, false
+		}
+		parts[n] = value
+		n++
+	}
+	return parts, n, true
+}
+
+// comparePrefix compares the first n parts of v and parts
+func comparePrefix(v ToolVersion, parts [3]int, n int) int {
+	values := [3]int{v.Major, v.Minor, v.Patch}
+	for i := range n {
+		if values[i] != parts[i] {
+			if values[i] < parts[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,113 @@
+package linters
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheckGoToolchain(t *testing.T) {
+	tests := []struct {
+		name    string
+		goMod   string
+		running ToolVersion
+		want    string
+	}{
+		{"newer than go directive", "module x\n\ngo 1.22\n", ToolVersion{1, 24, 1}, ""},
+		{"same as go directive", "module x\n\ngo 1.22.3\n", ToolVersion{1, 22, 3}, ""},
+		{"older than go directive", "module x\n\ngo 1.23.2\n", ToolVersion{1, 23, 0}, ">= 1.23.2"},
+		{"toolchain patch differs", "module x\n\ngo 1.22\n\ntoolchain go1.24.0\n", ToolVersion{1, 24, 3}, ""},
+		{"toolchain minor differs", "module x\n\ngo 1.22\n\ntoolchain go1.24.0\n", ToolVersion{1, 25, 0}, "1.24.0"},
+		{"no directives", "module x\n", ToolVersion{1, 20, 0}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ""
+			if mismatch := CheckGoToolchain([]byte(tt.goMod), tt.running); mismatch != nil {
+				got = mismatch.Expected
+			}
+			if got != tt.want {
+				t.Errorf("CheckGoToolchain() expects %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSatisfiesVersionRange(t *testing.T) {
+	node := ToolVersion{20, 11, 1}
+	tests := []struct {
+		versionRange string
+		satisfied    bool
+		ok           bool
+	}{
+		{"20", true, true},
+		{"v20.11.1", true, true},
+		{"18", false, true},
+		{"20.x", true, true},
+		{"*", true, true},
+		{">=18", true, true},
+		{">= 18 <20", false, true},
+		{">18", true, true},
+		{">20", false, true},
+		{"<=20", true, true},
+		{"^20.10.0", true, true},
+		{"^20.12", false, true},
+		{"^18.17.0", false, true},
+		{"~20.11.0", true, true},
+		{"~20.10", false, true},
+		{"16.x || 18.x", false, true},
+		{"18.x || >=20.9", true, true},
+		{"18 - 20.5", false, true},
+		{"18 - 20", true, true},
+		{"lts/iron", false, false},
+	}
+	for _, tt := range tests {
+		satisfied, ok := SatisfiesVersionRange(node, tt.versionRange)
+		if satisfied != tt.satisfied || ok != tt.ok {
+			t.Errorf("SatisfiesVersionRange(%s, %q) = %v, %v, want %v, %v", node, tt.versionRange, satisfied, ok, tt.satisfied, tt.ok)
+		}
+	}
+
+	if satisfied, _ := SatisfiesVersionRange(ToolVersion{0, 3, 5}, "^0.2.1"); satisfied {
+		t.Error("^0.2.1 should not allow 0.3.5")
+	}
+}
+
+func TestCheckNodeToolchain(t *testing.T) {
+	root := t.TempDir()
+	pkg := filepath.Join(root, "packages", "app")
+	if err := os.MkdirAll(pkg, 0750); err != nil {
+		t.Fatal(err)
+	}
+	write := func(path, content string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(filepath.Join(root, ".nvmrc"), "v18\n")
+	write(filepath.Join(pkg, "package.json"), `{"name": "app"}`)
+
+	// The workspace's .nvmrc applies to packages without engines
+	mismatch := CheckNodeToolchain(OSFileSystem{}, pkg, ToolVersion{20, 11, 1})
+	if mismatch == nil || mismatch.Source != ".nvmrc" || mismatch.Expected != "v18" {
+		t.Fatalf("mismatch = %+v, want the workspace .nvmrc", mismatch)
+	}
+	issue := mismatch.Issue("index.js")
+	if issue.Rule != RuleToolchainMismatch || issue.Severity != "warning" ||
+		!strings.Contains(issue.Message, "node 20.11.1 runs the hooks but .nvmrc expects node v18") {
+		t.Errorf("Issue() = %+v", issue)
+	}
+
+	// A package's engines take precedence
+	write(filepath.Join(pkg, "package.json"), `{"name": "app", "engines": {"node": ">=20"}}`)
+	if mismatch := CheckNodeToolchain(OSFileSystem{}, pkg, ToolVersion{20, 11, 1}); mismatch != nil {
+		t.Errorf("mismatch = %+v, want none for engines >=20", mismatch)
+	}
+
+	write(filepath.Join(root, ".nvmrc"), "lts/*\n")
+	if mismatch := CheckNodeToolchain(OSFileSystem{}, root, ToolVersion{16, 0, 0}); mismatch != nil {
+		t.Errorf("mismatch = %+v, want none for an alias", mismatch)
+	}
+}
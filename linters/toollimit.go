@@ -0,0 +1,86 @@
+package linters
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// DefaultToolLimits caps concurrent processes of heavyweight tools that contend
+// for build locks and memory when batch events fan out
+var DefaultToolLimits = map[string]int{
+	"cargo":         1,
+	"golangci-lint": 2,
+}
+
+// ToolLimiter bounds how many processes of each external tool run at once
+type ToolLimiter struct {
+	mu     sync.Mutex
+	limits map[string]int
+	sems   map[string]chan struct{}
+}
+
+// NewToolLimiter creates a limiter from per-tool limits. Tools without a
+// positive limit are not restricted.
+func NewToolLimiter(limits map[string]int) *ToolLimiter {
+	l := &ToolLimiter{
+		limits: make(map[string]int, len(limits)),
+		sems:   make(map[string]chan struct{}),
+	}
+	for tool, limit := range limits {
+		l.limits[toolKey(tool)] = limit
+	}
+	return l
+}
+
+// Acquire waits for a slot for tool, given as a name or path to its binary, and
+// returns a function releasing it. It fails if ctx is done while waiting.
+func (l *ToolLimiter) Acquire(ctx context.Context, tool string) (func(), error) {
+	sem := l.semaphore(toolKey(tool))
+	if sem == nil {
+		return func() {}, nil
+	}
+	select {
+	case sem <- struct{}{}:
+		var once sync.Once
+		return func() { once.Do(func() { <-sem }) }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// semaphore returns the semaphore for tool, or nil if it is unlimited
+func (l *ToolLimiter) semaphore(tool string) chan struct{} {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if sem, ok := l.sems[tool]; ok {
+		return sem
+	}
+	limit := l.limits[tool]
+	if limit <= 0 {
+		return nil
+	}
+	sem := make(chan struct{}, limit)
+	l.sems[tool] = sem
+	return sem
+}
+
+// toolKey normalizes a tool name or binary path to the name limits are keyed by
+func toolKey(tool string) string {
+	name := filepath.Base(tool)
+	return strings.TrimSuffix(name, ".exe")
+}
+
+// withDefaultToolLimits returns limits applied on top of DefaultToolLimits; a
+// limit of 0 removes a default
+func withDefaultToolLimits(limits map[string]int) map[string]int {
+	merged := make(map[string]int, len(DefaultToolLimits)+len(limits))
+	for tool, limit := range DefaultToolLimits {
+		merged[tool] = limit
+	}
+	for tool, limit := range limits {
+		merged[tool] = limit
+	}
+	return merged
+}
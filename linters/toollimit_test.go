@@ -0,0 +1,90 @@
+package linters
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestToolLimiter_Acquire(t *testing.T) {
+	tests := []struct {
+		name    string
+		limits  map[string]int
+		tool    string
+		workers int
+		wantMax int32
+	}{
+		{"limit by name", map[string]int{"cargo": 1}, "cargo", 4, 1},
+		{"limit by binary path", map[string]int{"golangci-lint": 2}, "/usr/local/bin/golangci-lint", 6, 2},
+		{"unlimited tool", map[string]int{"cargo": 1}, "ruff", 3, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter := NewToolLimiter(tt.limits)
+			var running, peak int32
+			var wg sync.WaitGroup
+			start := make(chan struct{})
+
+			for i := 0; i < tt.workers; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					<-start
+					release, err := limiter.Acquire(context.Background(), tt.tool)
+					if err != nil {
+						t.Errorf("Acquire() error = %v", err)
+						return
+					}
+					defer release()
+					n := atomic.AddInt32(&running, 1)
+					for {
+						p := atomic.LoadInt32(&peak)
+						if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+							break
+						}
+					}
+					time.Sleep(20 * time.Millisecond)
+					atomic.AddInt32(&running, -1)
+				}()
+			}
+			close(start)
+			wg.Wait()
+
+			if peak != tt.wantMax {
+				t.Errorf("peak concurrency = %d, want %d", peak, tt.wantMax)
+			}
+		})
+	}
+}
+
+func TestToolLimiter_AcquireCanceled(t *testing.T) {
+	limiter := NewToolLimiter(map[string]int{"cargo": 1})
+	release, err := limiter.Acquire(context.Background(), "cargo")
+	if err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := limiter.Acquire(ctx, "cargo"); err == nil {
+		t.Error("expected error when context expires while waiting")
+	}
+
+	// Releasing twice must not free a second slot
+	release()
+	release()
+	again, err := limiter.Acquire(context.Background(), "cargo")
+	if err != nil {
+		t.Fatalf("Acquire() after release error = %v", err)
+	}
+	ctx, cancel2 := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel2()
+	if _, err := limiter.Acquire(ctx, "cargo"); err == nil {
+		t.Error("double release should not allow a second cargo process")
+	}
+	again()
+}
@@ -0,0 +1,49 @@
+package unicodecheck
+
+// UnicodeConfig holds configuration for the Unicode safety linter
+type UnicodeConfig struct {
+	// DisabledChecks lists check rules to skip, e.g. "mixed-script-identifier"
+	DisabledChecks []string `json:"disabledChecks,omitempty"`
+	// Severity of reported issues, "error" (default) blocks the change
+	Severity *string `json:"severity,omitempty"`
+	// AllowedCharacters are code points never reported, as "U+200D" or the character itself
+	AllowedCharacters []string `json:"allowedCharacters,omitempty"`
+}
+
+// configSchema is the JSON Schema for UnicodeConfig
+const configSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "properties": {
+    "disabledChecks": {
+      "type": "array",
+      "items": {
+        "type": "string"
+      },
+      "description": "Check rules to skip, e.g. \"mixed-script-identifier\""
+    },
+    "severity": {
+      "type": "string",
+      "enum": [
+        "error",
+        "warning",
+        "info"
+      ],
+      "description": "Severity of reported issues, \"error\" by default"
+    },
+    "allowedCharacters": {
+      "type": "array",
+      "items": {
+        "type": "string"
+      },
+      "description": "Characters never reported, as \"U+200D\" or the character itself"
+    }
+  },
+  "additionalProperties": false
+}`
+
+// DefaultUnicodeConfig returns the default configuration for Unicode checks
+func DefaultUnicodeConfig() *UnicodeConfig {
+	severity := "error"
+	return &UnicodeConfig{Severity: &severity}
+}
@@ -0,0 +1,340 @@
+package unicodecheck
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+// Check rules reported by the linter
+const (
+	RuleBidiControl           = "bidi-control"
+	RuleInvisibleCharacter    = "invisible-character"
+	RuleMixedScriptIdentifier = "mixed-script-identifier"
+)
+
+// bidiControls are the Trojan Source characters that reorder how code displays
+var bidiControls = map[rune]string{
+	'\u061C': "ARABIC LETTER MARK",
+	'\u200E': "LEFT-TO-RIGHT MARK",
+	'\u200F': "RIGHT-TO-LEFT MARK",
+	'\u202A': "LEFT-TO-RIGHT EMBEDDING",
+	'\u202B': "RIGHT-TO-LEFT EMBEDDING",
+	'\u202C': "POP DIRECTIONAL FORMATTING",
+	'\u202D': "LEFT-TO-RIGHT OVERRIDE",
+	'\u202E': "RIGHT-TO-LEFT OVERRIDE",
+	'\u2066': "LEFT-TO-RIGHT ISOLATE",
+	'\u2067': "RIGHT-TO-LEFT ISOLATE",
+	'\u2068': "FIRST STRONG ISOLATE",
+	'\u2069': "POP DIRECTIONAL ISOLATE",
+}
+
+// invisibleCharacters render as nothing and can hide differences between
+// identifiers or strings that look identical
+var invisibleCharacters = map[rune]string{
+	'\u00AD': "SOFT HYPHEN",
+	'\u180E': "MONGOLIAN VOWEL SEPARATOR",
+	'\u200B': "ZERO WIDTH SPACE",
+	'\u200C': "ZERO WIDTH NON-JOINER",
+	'\u200D': "ZERO WIDTH JOINER",
+	'\u2060': "WORD JOINER",
+	'\u2061': "FUNCTION APPLICATION",
+	'\u2062': "INVISIBLE TIMES",
+	'\u2063': "INVISIBLE SEPARATOR",
+	'\u2064': "INVISIBLE PLUS",
+	'\uFEFF': "ZERO WIDTH NO-BREAK SPACE",
+}
+
+// UnicodeLinter blocks Trojan Source bidirectional controls, invisible characters
+// and identifiers mixing Latin with look-alike letters, in any text file
+type UnicodeLinter struct {
+	mu     sync.RWMutex
+	config *UnicodeConfig
+}
+
+// NewUnicodeLinter creates a new Unicode safety linter with default configuration
+func NewUnicodeLinter() *UnicodeLinter {
+	return NewUnicodeLinterWithConfig(nil)
+}
+
+// NewUnicodeLinterWithConfig creates a new Unicode safety linter with custom configuration
+func NewUnicodeLinterWithConfig(config *UnicodeConfig) *UnicodeLinter {
+	if config == nil {
+		config = DefaultUnicodeConfig()
+	}
+	return &UnicodeLinter{config: config}
+}
+
+// Name returns the linter name
+func (l *UnicodeLinter) Name() string {
+	return "unicode"
+}
+
+// Capabilities reports the built-in checks and the external tools used when installed
+func (l *UnicodeLinter) Capabilities() linters.Capabilities {
+	return linters.Capabilities{
+		Embedded: []string{"bidi controls", "invisible characters", "mixed-script identifiers"},
+	}
+}
+
+// Rules describes the check rules
+func (l *UnicodeLinter) Rules() map[string]string {
+	return map[string]string{
+		RuleBidiControl:           "Bidirectional control characters reorder how code displays, so reviewers see different code than the compiler (Trojan Source)",
+		RuleInvisibleCharacter:    "Zero-width and other invisible characters hide differences between identifiers and strings that look the same",
+		RuleMixedScriptIdentifier: "Words mixing Latin letters with look-alike letters from other scripts, such as Cyrillic, can impersonate other identifiers",
+	}
+}
+
+// CanHandle returns true for every file; binary content is skipped in Lint
+func (l *UnicodeLinter) CanHandle(filePath string) bool {
+	return true
+}
+
+// SetConfig updates the linter configuration
+func (l *UnicodeLinter) SetConfig(config []byte) error {
+	unicodeConfig := DefaultUnicodeConfig()
+	if err := json.Unmarshal(config, unicodeConfig); err != nil {
+		return fmt.Errorf("failed to parse unicode config: %w", err)
+	}
+	if _, err := parseAllowed(unicodeConfig.AllowedCharacters); err != nil {
+		return err
+	}
+	l.mu.Lock()
+	l.config = unicodeConfig
+	l.mu.Unlock()
+	return nil
+}
+
+// ConfigSchema returns the JSON Schema for the linter configuration
+func (l *UnicodeLinter) ConfigSchema() json.RawMessage {
+	return json.RawMessage(configSchema)
+}
+
+// Lint reports suspicious Unicode characters and mixed-script identifiers
+func (l *UnicodeLinter) Lint(ctx context.Context, filePath string, content []byte) (*linters.LintResult, error) {
+	l.mu.RLock()
+	config := l.config
+	l.mu.RUnlock()
+
+	result := &linters.LintResult{Success: true}
+	// Binary files and other encodings can't hide text-level tricks we can see
+	if bytes.IndexByte(content, 0) >= 0 || !utf8.Valid(content) {
+		return result, nil
+	}
+
+	severity := "error"
+	if config.Severity != nil && *config.Severity != "" {
+		severity = *config.Severity
+	}
+	allowed, _ := parseAllowed(config.AllowedCharacters)
+	disabled := make(map[string]bool, len(config.DisabledChecks))
+	for _, rule := range config.DisabledChecks {
+		disabled[rule] = true
+	}
+
+	report := func(line, column int, rule, message string) {
+		if disabled[rule] {
+			return
+		}
+		result.Issues = append(result.Issues, linters.Issue{
+			File:     filePath,
+			Line:     line,
+			Column:   column,
+			Severity: severity,
+			Message:  message,
+			Rule:     rule,
+		})
+	}
+
+	for i, line := range strings.Split(string(content), "\n") {
+		lineNum := i + 1
+		runes := []rune(line)
+		for col, r := range runes {
+			if allowed[r] {
+				continue
+			}
+			if name, ok := bidiControls[r]; ok {
+				report(lineNum, col+1, RuleBidiControl, fmt.Sprintf(
+					"%s %s can make code display differently than it compiles (Trojan Source); remove it", codePoint(r), name))
+				continue
+			}
+			if name, ok := invisibleCharacters[r]; ok && !legitimateInvisible(runes, col, lineNum) {
+				report(lineNum, col+1, RuleInvisibleCharacter, fmt.Sprintf(
+					"invisible character %s %s; remove it or use an escape sequence", codePoint(r), name))
+			}
+		}
+
+		for _, word := range mixedScriptWords(runes) {
+			report(lineNum, word.column, RuleMixedScriptIdentifier, fmt.Sprintf(
+				"%q mixes Latin letters with look-alike %s letters (homoglyph); use one script", word.text, word.script))
+		}
+	}
+
+	for _, issue := range result.Issues {
+		if issue.Severity == "error" {
+			result.Success = false
+			break
+		}
+	}
+	return result, nil
+}
+
+// legitimateInvisible reports whether the invisible character at runes[col] has a
+// legitimate use: a byte order mark opening the file, a joiner inside an emoji
+// sequence, or a non-joiner between letters of scripts that need it
+func legitimateInvisible(runes []rune, col, line int) bool {
+	r := runes[col]
+	var prev rune
+	if col > 0 {
+		prev = runes[col-1]
+	}
+	switch r {
+	case '\uFEFF':
+		return line == 1 && col == 0
+	case '\u200D':
+		return col > 0 && (unicode.Is(unicode.So, prev) || unicode.Is(unicode.Sk, prev) || prev == '\uFE0F')
+	case '\u200C':
+		return col > 0 && unicode.IsLetter(prev) && prev > unicode.MaxLatin1
+	}
+	return false
+}
+
+// confusables maps Cyrillic and Greek letters to the Latin letters they are
+// indistinguishable from in most fonts
+var confusables = map[rune]rune{
+	'\u0391': 'A', // GREEK CAPITAL LETTER ALPHA
+	'\u0392': 'B', // GREEK CAPITAL LETTER BETA
+	'\u0395': 'E', // GREEK CAPITAL LETTER EPSILON
+	'\u0396': 'Z', // GREEK CAPITAL LETTER ZETA
+	'\u0397': 'H', // GREEK CAPITAL LETTER ETA
+	'\u0399': 'I', // GREEK CAPITAL LETTER IOTA
+	'\u039A': 'K', // GREEK CAPITAL LETTER KAPPA
+	'\u039C': 'M', // GREEK CAPITAL LETTER MU
+	'\u039D': 'N', // GREEK CAPITAL LETTER NU
+	'\u039F': 'O', // GREEK CAPITAL LETTER OMICRON
+	'\u03A1': 'P', // GREEK CAPITAL LETTER RHO
+	'\u03A4': 'T', // GREEK CAPITAL LETTER TAU
+	'\u03A5': 'Y', // GREEK CAPITAL LETTER UPSILON
+	'\u03A7': 'X', // GREEK CAPITAL LETTER CHI
+	'\u03B9': 'i', // GREEK SMALL LETTER IOTA
+	'\u03BA': 'k', // GREEK SMALL LETTER KAPPA
+	'\u03BD': 'v', // GREEK SMALL LETTER NU
+	'\u03BF': 'o', // GREEK SMALL LETTER OMICRON
+	'\u03C1': 'p', // GREEK SMALL LETTER RHO
+	'\u03C5': 'u', // GREEK SMALL LETTER UPSILON
+	'\u0405': 'S', // CYRILLIC CAPITAL LETTER DZE
+	'\u0406': 'I', // CYRILLIC CAPITAL LETTER BYELORUSSIAN-UKRAINIAN I
+	'\u0408': 'J', // CYRILLIC CAPITAL LETTER JE
+	'\u0410': 'A', // CYRILLIC CAPITAL LETTER A
+	'\u0412': 'B', // CYRILLIC CAPITAL LETTER VE
+	'\u0415': 'E', // CYRILLIC CAPITAL LETTER IE
+	'\u041A': 'K', // CYRILLIC CAPITAL LETTER KA
+	'\u041C': 'M', // CYRILLIC CAPITAL LETTER EM
+	'\u041D': 'H', // CYRILLIC CAPITAL LETTER EN
+	'\u041E': 'O', // CYRILLIC CAPITAL LETTER O
+	'\u0420': 'P', // CYRILLIC CAPITAL LETTER ER
+	'\u0421': 'C', // CYRILLIC CAPITAL LETTER ES
+	'\u0422': 'T', // CYRILLIC CAPITAL LETTER TE
+	'\u0425': 'X', // CYRILLIC CAPITAL LETTER HA
+	'\u0430': 'a', // CYRILLIC SMALL LETTER A
+	'\u0432': 'B', // CYRILLIC SMALL LETTER VE
+	'\u0435': 'e', // CYRILLIC SMALL LETTER IE
+	'\u043A': 'k', // CYRILLIC SMALL LETTER KA
+	'\u043C': 'M', // CYRILLIC SMALL LETTER EM
+	'\u043D': 'H', // CYRILLIC SMALL LETTER EN
+	'\u043E': 'o', // CYRILLIC SMALL LETTER O
+	'\u0440': 'p', // CYRILLIC SMALL LETTER ER
+	'\u0441': 'c', // CYRILLIC SMALL LETTER ES
+	'\u0442': 'T', // CYRILLIC SMALL LETTER TE
+	'\u0443': 'y', // CYRILLIC SMALL LETTER U
+	'\u0445': 'x', // CYRILLIC SMALL LETTER HA
+	'\u0455': 's', // CYRILLIC SMALL LETTER DZE
+	'\u0456': 'i', // CYRILLIC SMALL LETTER BYELORUSSIAN-UKRAINIAN I
+	'\u0458': 'j', // CYRILLIC SMALL LETTER JE
+	'\u04AF': 'y', // CYRILLIC SMALL LETTER STRAIGHT U
+	'\u04BB': 'h', // CYRILLIC SMALL LETTER SHHA
+	'\u0501': 'd', // CYRILLIC SMALL LETTER KOMI DE
+	'\u051B': 'q', // CYRILLIC SMALL LETTER QA
+	'\u051D': 'w', // CYRILLIC SMALL LETTER WE
+}
+
+// mixedWord is a word mixing Latin letters with a confusable script
+type mixedWord struct {
+	text   string
+	script string
+	column int
+}
+
+// mixedScriptWords returns the words in a line that mix Latin letters with
+// Cyrillic or Greek look-alikes, the classic homoglyph attack on identifiers
+// and strings. Greek or Cyrillic letters that don't resemble Latin ones, as in
+// "10μs", are left alone
+func mixedScriptWords(runes []rune) []mixedWord {
+	var words []mixedWord
+	for start := 0; start < len(runes); {
+		if !isWordRune(runes[start]) {
+			start++
+			continue
+		}
+		end := start
+		hasLatin := false
+		script := ""
+		for end < len(runes) && isWordRune(runes[end]) {
+			r := runes[end]
+			if r < utf8.RuneSelf && unicode.IsLetter(r) {
+				hasLatin = true
+			} else if _, ok := confusables[r]; ok {
+				script = "Greek"
+				if unicode.Is(unicode.Cyrillic, r) {
+					script = "Cyrillic"
+				}
+			}
+			end++
+		}
+		if hasLatin && script != "" {
+			words = append(words, mixedWord{text: string(runes[start:end]), script: script, column: start + 1})
+		}
+		start = end
+	}
+	return words
+}
+
+// isWordRune reports whether r can be part of an identifier
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.Is(unicode.Mn, r)
+}
+
+// codePoint formats r as U+XXXX
+func codePoint(r rune) string {
+	return fmt.Sprintf("U+%04X", r)
+}
+
+// parseAllowed parses allowed characters given as "U+200D" or the character itself
+func parseAllowed(values []string) (map[rune]bool, error) {
+	allowed := make(map[rune]bool, len(values))
+	for _, value := range values {
+		if hex, ok := strings.CutPrefix(strings.ToUpper(value), "U+"); ok {
+			n, err := strconv.ParseUint(hex, 16, 32)
+			if err != nil {
+				return nil, fmt.Errorf("invalid allowed character %q: %w", value, err)
+			}
+			allowed[rune(n)] = true
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(value)
+		if size == 0 || size != len(value) {
+			return nil, fmt.Errorf("invalid allowed character %q: use one character or U+XXXX", value)
+		}
+		allowed[r] = true
+	}
+	return allowed, nil
+}
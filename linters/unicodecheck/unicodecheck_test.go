@@ -0,0 +1,114 @@
+package unicodecheck
+
+import (
+	"context"
+	"testing"
+)
+
+func TestUnicodeLinter_Lint(t *testing.T) {
+	tests := []struct {
+		name      string
+		file      string
+		content   string
+		wantRules []string
+	}{
+		{
+			name:      "trojan source override in comment",
+			file:      "auth.go",
+			content:   "package main\n\n/* \u202E } \u2066if isAdmin\u2069 \u2066 begin admins only */\n",
+			wantRules: []string{RuleBidiControl, RuleBidiControl, RuleBidiControl, RuleBidiControl},
+		},
+		{
+			name:      "zero width space in identifier",
+			file:      "access.js",
+			content:   "const is\u200BAdmin = false\n",
+			wantRules: []string{RuleInvisibleCharacter},
+		},
+		{
+			name:      "cyrillic homoglyph in identifier",
+			file:      "check.py",
+			content:   "def is_\u0430dmin(user):\n    return True\n",
+			wantRules: []string{RuleMixedScriptIdentifier},
+		},
+		{
+			name:      "greek homoglyph in string",
+			file:      "config.rs",
+			content:   "let host = \"\u03BFpenai.com\";\n",
+			wantRules: []string{RuleMixedScriptIdentifier},
+		},
+		{
+			name:    "byte order mark at start of file",
+			file:    "data.json",
+			content: "\uFEFF{\"key\": \"value\"}\n",
+		},
+		{
+			name:    "emoji zero width joiner sequence",
+			file:    "README.md",
+			content: "Built by \U0001F469\u200D\U0001F4BB developers\n",
+		},
+		{
+			name:    "single script words",
+			file:    "i18n.go",
+			content: "var greeting = \"\u043F\u0440\u0438\u0432\u0435\u0442 world\"\nconst timeout = \"10\u03BCs\"\n",
+		},
+		{
+			name:    "binary content is skipped",
+			file:    "image.png",
+			content: "\x89PNG\x00\u202E",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewUnicodeLinter().Lint(context.Background(), tt.file, []byte(tt.content))
+			if err != nil {
+				t.Fatalf("Lint() error = %v", err)
+			}
+			var rules []string
+			for _, issue := range result.Issues {
+				rules = append(rules, issue.Rule)
+				if issue.Severity != "error" {
+					t.Errorf("expected error severity, got %q", issue.Severity)
+				}
+			}
+			if len(rules) != len(tt.wantRules) {
+				t.Fatalf("rules = %v, want %v", rules, tt.wantRules)
+			}
+			for i := range rules {
+				if rules[i] != tt.wantRules[i] {
+					t.Errorf("rules = %v, want %v", rules, tt.wantRules)
+				}
+			}
+			if result.Success != (len(tt.wantRules) == 0) {
+				t.Errorf("Success = %v, want blocking only when issues are found", result.Success)
+			}
+		})
+	}
+}
+
+func TestUnicodeLinter_SetConfig(t *testing.T) {
+	l := NewUnicodeLinter()
+	config := `{"disabledChecks": ["mixed-script-identifier"], "severity": "warning", "allowedCharacters": ["U+200B"]}`
+	if err := l.SetConfig([]byte(config)); err != nil {
+		t.Fatalf("SetConfig() error = %v", err)
+	}
+
+	content := []byte("x := \"a\u200Bb\"\ny := \"\u0430b\"\nz := \"a\u2060b\"\n")
+	result, err := l.Lint(context.Background(), "main.go", content)
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+	if len(result.Issues) != 1 || result.Issues[0].Rule != RuleInvisibleCharacter || result.Issues[0].Line != 3 {
+		t.Fatalf("expected only the word joiner on line 3, got %+v", result.Issues)
+	}
+	if !result.Success || result.Issues[0].Severity != "warning" {
+		t.Errorf("expected non-blocking warning, got %+v", result)
+	}
+
+	if err := l.SetConfig([]byte(`{"allowedCharacters": ["U+ZZZZ"]}`)); err == nil {
+		t.Error("expected error for invalid allowed character")
+	}
+	if err := l.SetConfig([]byte(`{invalid`)); err == nil {
+		t.Error("expected error for invalid config")
+	}
+}
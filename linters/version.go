@@ -0,0 +1,77 @@
+package linters
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// RuleUnsupportedVersion is reported when an installed tool's version has an
+// output format gismo can't read, so its checks were skipped
+const RuleUnsupportedVersion = "unsupported-tool-version"
+
+// versionNumber matches the first dotted version in a tool's version output
+var versionNumber = regexp.MustCompile(`(\d+)\.(\d+)(?:\.(\d+))?`)
+
+// ToolVersion is the version of an external tool
+type ToolVersion struct {
+	Major, Minor, Patch int
+}
+
+// ParseToolVersion returns the version in a tool's version output, such as
+// "golangci-lint has version 2.1.6 built with go1.24" or "v9.4.0". ok is false
+// if the output holds no version number.
+func ParseToolVersion(output string) (version ToolVersion, ok bool) {
+	match := versionNumber.FindStringSubmatch(output)
+	if match == nil {
+		return ToolVersion{}, false
+	}
+	version.Major, _ = strconv.Atoi(match[1])
+	version.Minor, _ = strconv.Atoi(match[2])
+	version.Patch, _ = strconv.Atoi(match[3])
+	return version, true
+}
+
+// AtLeast reports whether the version is major.minor or later
+func (v ToolVersion) AtLeast(major, minor int) bool {
+	return v.Major > major || v.Major == major && v.Minor >= minor
+}
+
+// Compare returns -1, 0 or 1 as the version is older than, the same as or
+// newer than other
+func (v ToolVersion) Compare(other ToolVersion) int {
+	return comparePrefix(v, [3]int{other.Major, other.Minor, other.Patch}, 3)
+}
+
+// String returns the version as major.minor.patch
+func (v ToolVersion) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// UnsupportedVersionError reports an installed tool whose output format gismo
+// doesn't know how to read
+type UnsupportedVersionError struct {
+	Tool    string
+	Version ToolVersion
+	// Supported describes the supported versions, such as "1.x and 2.x"
+	Supported string
+}
+
+// Error names the tool, its version and the supported versions
+func (e *UnsupportedVersionError) Error() string {
+	return fmt.Sprintf("%s %s is not supported (supported: %s)", e.Tool, e.Version, e.Supported)
+}
+
+// Issue returns a warning at the top of filePath explaining that the tool's
+// checks were skipped
+func (e *UnsupportedVersionError) Issue(filePath string) Issue {
+	return Issue{
+		File:     filePath,
+		Line:     1,
+		Column:   1,
+		Severity: "warning",
+		Message:  e.Error() + ", so its checks were skipped; install a supported version",
+		Rule:     RuleUnsupportedVersion,
+		Tool:     e.Tool,
+	}
+}
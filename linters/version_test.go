@@ -0,0 +1,43 @@
+package linters
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseToolVersion(t *testing.T) {
+	tests := []struct {
+		output string
+		want   ToolVersion
+		ok     bool
+	}{
+		{output: "golangci-lint has version 1.55.2 built with go1.21.3 from e3c2265", want: ToolVersion{1, 55, 2}, ok: true},
+		{output: "golangci-lint has version v2.1.6 built with go1.24.2", want: ToolVersion{2, 1, 6}, ok: true},
+		{output: "v9.4.0", want: ToolVersion{9, 4, 0}, ok: true},
+		{output: "buf 1.50", want: ToolVersion{1, 50, 0}, ok: true},
+		{output: "no version here", ok: false},
+		{output: "", ok: false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseToolVersion(tt.output)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("ParseToolVersion(%q) = %v, %v, want %v, %v", tt.output, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	v := ToolVersion{Major: 2, Minor: 1}
+	if !v.AtLeast(2, 1) || !v.AtLeast(1, 60) || v.AtLeast(2, 2) || v.AtLeast(3, 0) {
+		t.Errorf("AtLeast() wrong for %s", v)
+	}
+}
+
+func TestUnsupportedVersionError_Issue(t *testing.T) {
+	err := &UnsupportedVersionError{Tool: "eslint", Version: ToolVersion{Major: 10}, Supported: "up to 9.x"}
+	issue := err.Issue("app.js")
+	if issue.Rule != RuleUnsupportedVersion || issue.Severity != "warning" || issue.Tool != "eslint" {
+		t.Errorf("Issue() = %+v", issue)
+	}
+	if !strings.Contains(issue.Message, "eslint 10.0.0 is not supported (supported: up to 9.x)") {
+		t.Errorf("Issue() message = %q", issue.Message)
+	}
+}
@@ -0,0 +1,79 @@
+package yaml
+
+// YAMLConfig represents YAML linter specific configuration
+type YAMLConfig struct {
+	// IndentSize is the number of spaces per nesting level (default 2)
+	IndentSize *int `json:"indentSize,omitempty"`
+	// MaxLineLength is the longest allowed line, 0 for no limit (default 120)
+	MaxLineLength *int `json:"maxLineLength,omitempty"`
+	// RequireDocumentStart requires every file to start with "---" (default false)
+	RequireDocumentStart *bool `json:"requireDocumentStart,omitempty"`
+	// UseYamllint runs yamllint when it is installed (default true)
+	UseYamllint *bool `json:"useYamllint,omitempty"`
+	// YamllintConfig is the path to a yamllint config file. If unset, a .yamllint
+	// file next to the linted file or above it is used, otherwise the settings above
+	YamllintConfig *string `json:"yamllintConfig,omitempty"`
+	// DisabledRules lists rules to skip, including yamllint rules
+	DisabledRules []string `json:"disabledRules,omitempty"`
+	// MaxFileSize is the maximum file size in bytes to lint (default 1MB)
+	MaxFileSize *int64 `json:"maxFileSize,omitempty"`
+}
+
+// configSchema is the JSON Schema for YAMLConfig
+const configSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "properties": {
+    "indentSize": {
+      "type": "integer",
+      "minimum": 1,
+      "description": "Spaces per nesting level"
+    },
+    "maxLineLength": {
+      "type": "integer",
+      "minimum": 0,
+      "description": "Longest allowed line, 0 for no limit"
+    },
+    "requireDocumentStart": {
+      "type": "boolean",
+      "description": "Require files to start with ---"
+    },
+    "useYamllint": {
+      "type": "boolean",
+      "description": "Run yamllint when it is installed"
+    },
+    "yamllintConfig": {
+      "type": "string",
+      "description": "Path to a yamllint config file"
+    },
+    "disabledRules": {
+      "type": "array",
+      "items": {
+        "type": "string"
+      },
+      "description": "Rules to skip, including yamllint rules"
+    },
+    "maxFileSize": {
+      "type": "integer",
+      "minimum": 0,
+      "description": "Maximum file size in bytes to lint"
+    }
+  },
+  "additionalProperties": false
+}`
+
+// DefaultYAMLConfig returns the default configuration for YAML linting
+func DefaultYAMLConfig() *YAMLConfig {
+	indentSize := 2
+	maxLineLength := 120
+	requireDocumentStart := false
+	useYamllint := true
+	maxFileSize := int64(1024 * 1024)
+	return &YAMLConfig{
+		IndentSize:           &indentSize,
+		MaxLineLength:        &maxLineLength,
+		RequireDocumentStart: &requireDocumentStart,
+		UseYamllint:          &useYamllint,
+		MaxFileSize:          &maxFileSize,
+	}
+}
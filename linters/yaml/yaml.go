@@ -0,0 +1,470 @@
+package yaml
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/jrossi/gismo/linters"
+	"github.com/jrossi/gismo/toolcache"
+	"gopkg.in/yaml.v3"
+)
+
+// Rules reported by the built-in checks
+const (
+	RuleSyntax        = "syntax"
+	RuleDuplicateKey  = "duplicate-key"
+	RuleIndentation   = "indentation"
+	RuleLineLength    = "line-length"
+	RuleDocumentStart = "document-start"
+	RuleFileSize      = "file-size"
+)
+
+// yamllintConfigFiles are the project config files yamllint reads
+var yamllintConfigFiles = []string{".yamllint", ".yamllint.yaml", ".yamllint.yml"}
+
+// yamllintLine matches yamllint's parsable output, e.g.
+// "stdin:3:5: [warning] wrong indentation: expected 2 but found 4 (indentation)"
+var yamllintLine = regexp.MustCompile(`^[^:]*:(\d+):(\d+): \[(\w+)\] (.*?)(?: \(([\w-]+)\))?$`)
+
+// syntaxErrorLine extracts the line number from a yaml.v3 error
+var syntaxErrorLine = regexp.MustCompile(`line (\d+)`)
+
+// YAMLLinter checks YAML files with built-in syntax and style checks, or with
+// yamllint when it is installed
+type YAMLLinter struct {
+	mu     sync.RWMutex
+	config *YAMLConfig
+	// Tool cache used to discover yamllint; nil uses the disk-backed cache of the linted file's project
+	cache toolcache.ToolCache
+	// Filesystem used for yamllint config lookups
+	fs linters.FileSystem
+	// Runs external tools with the engine's limits, caches and environment
+	runner *linters.CommandRunner
+}
+
+// NewYAMLLinter creates a new YAML linter with default configuration
+func NewYAMLLinter() *YAMLLinter {
+	return NewYAMLLinterWithConfig(nil)
+}
+
+// NewYAMLLinterWithConfig creates a new YAML linter with the given configuration
+func NewYAMLLinterWithConfig(config *YAMLConfig) *YAMLLinter {
+	if config == nil {
+		config = DefaultYAMLConfig()
+	}
+	return &YAMLLinter{
+		config: config,
+		fs:     linters.OSFileSystem{},
+		runner: linters.NewCommandRunner(linters.RunnerConfig{}),
+	}
+}
+
+// NewYAMLLinterWithToolCache creates a YAML linter that discovers yamllint with the
+// given tool cache. A nil cache falls back to the disk-backed cache rooted at the
+// linted file's project.
+func NewYAMLLinterWithToolCache(config *YAMLConfig, cache toolcache.ToolCache) *YAMLLinter {
+	l := NewYAMLLinterWithConfig(config)
+	l.cache = cache
+	return l
+}
+
+// Name returns the linter name
+func (l *YAMLLinter) Name() string {
+	return "yaml"
+}
+
+// SetCommandRunner sets the runner used to start external tools
+func (l *YAMLLinter) SetCommandRunner(runner *linters.CommandRunner) {
+	l.runner = runner
+}
+
+// Capabilities reports the built-in checks and the external tools used when installed
+func (l *YAMLLinter) Capabilities() linters.Capabilities {
+	return linters.Capabilities{
+		Embedded: []string{"syntax", "duplicate keys", "indentation", "line length", "document start"},
+		Tools:    []string{"yamllint"},
+	}
+}
+
+// CanHandle returns true for YAML files
+func (l *YAMLLinter) CanHandle(filePath string) bool {
+	lowerPath := strings.ToLower(filePath)
+	return strings.HasSuffix(lowerPath, ".yml") || strings.HasSuffix(lowerPath, ".yaml")
+}
+
+// SetConfig updates the linter configuration
+func (l *YAMLLinter) SetConfig(configData json.RawMessage) error {
+	// Settings not given keep their defaults
+	config := DefaultYAMLConfig()
+	if err := json.Unmarshal(configData, config); err != nil {
+		return fmt.Errorf("failed to parse yaml config: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.config = config
+	return nil
+}
+
+// ConfigSchema returns the JSON Schema for the linter configuration
+func (l *YAMLLinter) ConfigSchema() json.RawMessage {
+	return json.RawMessage(configSchema)
+}
+
+// SetFileSystem sets the filesystem used for yamllint config lookups
+func (l *YAMLLinter) SetFileSystem(fsys linters.FileSystem) {
+	l.fs = fsys
+}
+
+// Rules describes the built-in checks
+func (l *YAMLLinter) Rules() map[string]string {
+	return map[string]string{
+		RuleSyntax:        "The file parses as YAML",
+		RuleDuplicateKey:  "A mapping does not repeat a key; YAML parsers keep only one of the values",
+		RuleIndentation:   "Nested mappings are indented by the configured indentSize; sequences under a key may also start at the key's column",
+		RuleLineLength:    "Lines are no longer than the configured maxLineLength",
+		RuleDocumentStart: "The file starts with ---, when requireDocumentStart is set",
+		RuleFileSize:      "The file is no larger than the configured maxFileSize",
+	}
+}
+
+// Lint checks YAML syntax, then style with yamllint if it is installed and
+// with the built-in checks otherwise
+func (l *YAMLLinter) Lint(ctx context.Context, filePath string, content []byte) (*linters.LintResult, error) {
+	l.mu.RLock()
+	config := l.config
+	l.mu.RUnlock()
+
+	result := &linters.LintResult{
+		Success: true,
+		Issues:  []linters.Issue{},
+	}
+
+	if config.MaxFileSize != nil && int64(len(content)) > *config.MaxFileSize {
+		result.Issues = append(result.Issues, linters.Issue{
+			File:     filePath,
+			Line:     1,
+			Column:   1,
+			Severity: "error",
+			Message:  fmt.Sprintf("File size %d exceeds limit %d", len(content), *config.MaxFileSize),
+			Rule:     RuleFileSize,
+		})
+		result.Success = false
+		return result, nil
+	}
+
+	documents, err := parseDocuments(content)
+	if err != nil {
+		result.Issues = append(result.Issues, syntaxIssue(filePath, err))
+		result.Success = false
+		return result, nil
+	}
+
+	var issues []linters.Issue
+	if yamllint := l.yamllintPath(config, filePath); yamllint != "" {
+		issues, err = l.runYamllint(ctx, config, yamllint, filePath, content)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		issues = builtinChecks(config, filePath, content, documents)
+	}
+
+	for _, issue := range issues {
+		if isDisabled(config, issue.Rule) {
+			continue
+		}
+		if issue.Severity == "error" {
+			result.Success = false
+		}
+		result.Issues = append(result.Issues, issue)
+	}
+	return result, nil
+}
+
+// parseDocuments parses every document in a YAML stream
+func parseDocuments(content []byte) ([]*yaml.Node, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	var documents []*yaml.Node
+	for {
+		var document yaml.Node
+		err := decoder.Decode(&document)
+		if errors.Is(err, io.EOF) {
+			return documents, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		documents = append(documents, &document)
+	}
+}
+
+// syntaxIssue converts a parse error into an issue, on the line yaml.v3 names if any
+func syntaxIssue(filePath string, err error) linters.Issue {
+	line := 1
+	if match := syntaxErrorLine.FindStringSubmatch(err.Error()); match != nil {
+		line, _ = strconv.Atoi(match[1])
+	}
+	message := strings.TrimPrefix(err.Error(), "yaml: ")
+	return linters.Issue{
+		File:     filePath,
+		Line:     line,
+		Column:   1,
+		Severity: "error",
+		Message:  "YAML syntax error: " + message,
+		Rule:     RuleSyntax,
+	}
+}
+
+// builtinChecks runs the style checks used when yamllint is not available
+func builtinChecks(config *YAMLConfig, filePath string, content []byte, documents []*yaml.Node) []linters.Issue {
+	var issues []linters.Issue
+	indentSize := 2
+	if config.IndentSize != nil {
+		indentSize = *config.IndentSize
+	}
+	for _, document := range documents {
+		issues = append(issues, checkNode(filePath, document, indentSize)...)
+	}
+
+	lines := strings.Split(string(content), "\n")
+	if config.MaxLineLength != nil && *config.MaxLineLength > 0 {
+		for i, line := range lines {
+			if length := utf8.RuneCountInString(strings.TrimRight(line, "\r")); length > *config.MaxLineLength {
+				issues = append(issues, linters.Issue{
+					File:     filePath,
+					Line:     i + 1,
+					Column:   *config.MaxLineLength + 1,
+					Severity: "warning",
+					Message:  fmt.Sprintf("Line exceeds maximum length of %d characters (%d)", *config.MaxLineLength, length),
+					Rule:     RuleLineLength,
+				})
+			}
+		}
+	}
+
+	if config.RequireDocumentStart != nil && *config.RequireDocumentStart {
+		if line, ok := missingDocumentStart(lines); ok {
+			issues = append(issues, linters.Issue{
+				File:     filePath,
+				Line:     line,
+				Column:   1,
+				Severity: "warning",
+				Message:  "Missing document start \"---\"",
+				Rule:     RuleDocumentStart,
+			})
+		}
+	}
+	return issues
+}
+
+// checkNode reports duplicate keys and misindented block collections under node
+func checkNode(filePath string, node *yaml.Node, indentSize int) []linters.Issue {
+	var issues []linters.Issue
+	if node.Kind == yaml.MappingNode {
+		seen := make(map[string]int)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if key.Kind == yaml.ScalarNode {
+				if first, ok := seen[key.Value]; ok {
+					issues = append(issues, linters.Issue{
+						File:     filePath,
+						Line:     key.Line,
+						Column:   key.Column,
+						Severity: "error",
+						Message:  fmt.Sprintf("Duplicate key %q, first defined on line %d", key.Value, first),
+						Rule:     RuleDuplicateKey,
+					})
+				} else {
+					seen[key.Value] = key.Line
+				}
+			}
+			if issue, ok := checkIndentation(filePath, key, value, indentSize); ok {
+				issues = append(issues, issue)
+			}
+		}
+	}
+	for _, child := range node.Content {
+		issues = append(issues, checkNode(filePath, child, indentSize)...)
+	}
+	return issues
+}
+
+// checkIndentation checks a block collection that starts on the line after its key
+func checkIndentation(filePath string, key, value *yaml.Node, indentSize int) (linters.Issue, bool) {
+	if value.Style&yaml.FlowStyle != 0 || value.Line <= key.Line || len(value.Content) == 0 {
+		return linters.Issue{}, false
+	}
+	expected := key.Column + indentSize
+	switch value.Kind {
+	case yaml.MappingNode:
+		if value.Column == expected {
+			return linters.Issue{}, false
+		}
+	case yaml.SequenceNode:
+		// Sequences may also start at the key's column, as many tools write them
+		if value.Column == expected || value.Column == key.Column {
+			return linters.Issue{}, false
+		}
+	default:
+		return linters.Issue{}, false
+	}
+	return linters.Issue{
+		File:     filePath,
+		Line:     value.Line,
+		Column:   value.Column,
+		Severity: "warning",
+		Message:  fmt.Sprintf("Wrong indentation: expected %d but found %d", expected-1, value.Column-1),
+		Rule:     RuleIndentation,
+	}, true
+}
+
+// missingDocumentStart reports the first content line if it isn't a document
+// start marker or a directive
+func missingDocumentStart(lines []string) (int, bool) {
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "%") || trimmed == "---" || strings.HasPrefix(trimmed, "--- ") {
+			return 0, false
+		}
+		return i + 1, true
+	}
+	return 0, false
+}
+
+// isDisabled reports whether a rule is disabled by configuration
+func isDisabled(config *YAMLConfig, rule string) bool {
+	for _, disabled := range config.DisabledRules {
+		if disabled == rule {
+			return true
+		}
+	}
+	return false
+}
+
+// yamllintPath returns the yamllint binary to run, or "" to use the built-in checks
+func (l *YAMLLinter) yamllintPath(config *YAMLConfig, filePath string) string {
+	if config.UseYamllint != nil && !*config.UseYamllint {
+		return ""
+	}
+	cache := l.cache
+	if cache == nil {
+		manager, err := toolcache.NewCacheManager(filePath)
+		if err != nil {
+			return ""
+		}
+		cache = manager
+	}
+	tool, err := cache.DiscoverTool("yaml", "yamllint")
+	if err != nil || tool == nil || !tool.Available {
+		return ""
+	}
+	return tool.Path
+}
+
+// runYamllint lints content with yamllint, read from stdin since the content may
+// not be on disk yet
+func (l *YAMLLinter) runYamllint(ctx context.Context, config *YAMLConfig, yamllint, filePath string, content []byte) ([]linters.Issue, error) {
+	args := []string{"--format", "parsable"}
+	if configFile := l.yamllintConfigFile(config, filePath); configFile != "" {
+		args = append(args, "--config-file", configFile)
+	} else {
+		args = append(args, "--config-data", yamllintConfigData(config))
+	}
+	args = append(args, "-")
+
+	release, err := l.runner.Acquire(ctx, yamllint)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	cmd := l.runner.Command(ctx, l.Name(), yamllint, args...)
+	cmd.Dir = linters.ExistingDir(filePath)
+	cmd.Stdin = bytes.NewReader(content)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	// yamllint exits with 1 when it reports errors
+	runErr := linters.Run(cmd)
+
+	var issues []linters.Issue
+	for _, line := range strings.Split(stdout.String(), "\n") {
+		match := yamllintLine.FindStringSubmatch(strings.TrimSpace(line))
+		if match == nil {
+			continue
+		}
+		lineNum, _ := strconv.Atoi(match[1])
+		column, _ := strconv.Atoi(match[2])
+		issues = append(issues, linters.ToolIssue("yamllint", match[3], linters.Issue{
+			File:    filePath,
+			Line:    lineNum,
+			Column:  column,
+			Message: match[4],
+			Rule:    match[5],
+		}))
+	}
+	if len(issues) == 0 && runErr != nil && stderr.Len() > 0 {
+		return nil, fmt.Errorf("yamllint failed: %v\nstderr: %s", runErr, stderr.String())
+	}
+	return issues, nil
+}
+
+// yamllintConfigFile returns the configured yamllint config file, or the nearest
+// project config file at or above the linted file's directory
+func (l *YAMLLinter) yamllintConfigFile(config *YAMLConfig, filePath string) string {
+	if config.YamllintConfig != nil && *config.YamllintConfig != "" {
+		return *config.YamllintConfig
+	}
+	dir := filepath.Dir(filePath)
+	for {
+		for _, name := range yamllintConfigFiles {
+			candidate := filepath.Join(dir, name)
+			if _, err := l.fs.Stat(candidate); err == nil {
+				return candidate
+			}
+		}
+		// The repository root ends the search
+		if _, err := l.fs.Stat(filepath.Join(dir, ".git")); err == nil {
+			return ""
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// yamllintConfigData translates the linter settings into yamllint config
+func yamllintConfigData(config *YAMLConfig) string {
+	rules := []string{}
+	if config.IndentSize != nil {
+		rules = append(rules, fmt.Sprintf("indentation: {spaces: %d}", *config.IndentSize))
+	}
+	if config.MaxLineLength != nil && *config.MaxLineLength > 0 {
+		rules = append(rules, fmt.Sprintf("line-length: {max: %d}", *config.MaxLineLength))
+	} else {
+		rules = append(rules, "line-length: disable")
+	}
+	if config.RequireDocumentStart != nil && *config.RequireDocumentStart {
+		rules = append(rules, "document-start: {present: true}")
+	} else {
+		rules = append(rules, "document-start: disable")
+	}
+	return "{extends: default, rules: {" + strings.Join(rules, ", ") + "}}"
+}
@@ -0,0 +1,221 @@
+package yaml
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/jrossi/gismo/linters"
+	"github.com/jrossi/gismo/toolcache"
+)
+
+// builtinLinter returns a linter that never runs yamllint
+func builtinLinter(t *testing.T, config string) *YAMLLinter {
+	t.Helper()
+	linter := NewYAMLLinterWithToolCache(nil, toolcache.NewMemoryCache())
+	if config != "" {
+		if err := linter.SetConfig(json.RawMessage(config)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return linter
+}
+
+// rulesOf returns the rule of each issue
+func rulesOf(issues []linters.Issue) []string {
+	rules := []string{}
+	for _, issue := range issues {
+		rules = append(rules, issue.Rule)
+	}
+	return rules
+}
+
+func TestYAMLLinter_CanHandle(t *testing.T) {
+	linter := NewYAMLLinter()
+	for path, want := range map[string]bool{
+		"config.yml":                true,
+		".github/workflows/ci.YAML": true,
+		"values.yaml.tmpl":          false,
+		"data.json":                 false,
+	} {
+		if got := linter.CanHandle(path); got != want {
+			t.Errorf("CanHandle(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestYAMLLinter_Builtin(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      string
+		content     string
+		wantRules   []string
+		wantSuccess bool
+	}{
+		{
+			name:        "valid",
+			content:     "name: app\nservices:\n  web:\n    image: nginx\n  ports:\n  - 80\n  - 443\n",
+			wantRules:   []string{},
+			wantSuccess: true,
+		},
+		{
+			name:        "multiple documents",
+			content:     "---\na: 1\n---\nb: 2\n",
+			wantRules:   []string{},
+			wantSuccess: true,
+		},
+		{
+			name:        "syntax error",
+			content:     "a: 1\nb: [1, 2\nc: 3\n",
+			wantRules:   []string{RuleSyntax},
+			wantSuccess: false,
+		},
+		{
+			name:        "duplicate key",
+			content:     "a: 1\nb: 2\na: 3\n",
+			wantRules:   []string{RuleDuplicateKey},
+			wantSuccess: false,
+		},
+		{
+			name:        "wrong indentation",
+			content:     "a:\n    b: 1\nc:\n      - 1\n",
+			wantRules:   []string{RuleIndentation, RuleIndentation},
+			wantSuccess: true,
+		},
+		{
+			name:        "configured indentation",
+			config:      `{"indentSize": 4}`,
+			content:     "a:\n    b: 1\n",
+			wantRules:   []string{},
+			wantSuccess: true,
+		},
+		{
+			name:        "line length",
+			config:      `{"maxLineLength": 10}`,
+			content:     "short: 1\nlonger_key: value\n",
+			wantRules:   []string{RuleLineLength},
+			wantSuccess: true,
+		},
+		{
+			name:        "document start required",
+			config:      `{"requireDocumentStart": true}`,
+			content:     "# comment\na: 1\n",
+			wantRules:   []string{RuleDocumentStart},
+			wantSuccess: true,
+		},
+		{
+			name:        "document start present",
+			config:      `{"requireDocumentStart": true}`,
+			content:     "# comment\n---\na: 1\n",
+			wantRules:   []string{},
+			wantSuccess: true,
+		},
+		{
+			name:        "disabled rule",
+			config:      `{"disabledRules": ["duplicate-key"]}`,
+			content:     "a: 1\na: 2\n",
+			wantRules:   []string{},
+			wantSuccess: true,
+		},
+		{
+			name:        "file size",
+			config:      `{"maxFileSize": 4}`,
+			content:     "a: 12345\n",
+			wantRules:   []string{RuleFileSize},
+			wantSuccess: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := builtinLinter(t, tt.config).Lint(context.Background(), "test.yaml", []byte(tt.content))
+			if err != nil {
+				t.Fatalf("Lint() error = %v", err)
+			}
+			if got := rulesOf(result.Issues); strings.Join(got, ",") != strings.Join(tt.wantRules, ",") {
+				t.Errorf("rules = %v, want %v (issues %+v)", got, tt.wantRules, result.Issues)
+			}
+			if result.Success != tt.wantSuccess {
+				t.Errorf("Success = %v, want %v", result.Success, tt.wantSuccess)
+			}
+		})
+	}
+}
+
+func TestYAMLLinter_SyntaxErrorLine(t *testing.T) {
+	result, err := builtinLinter(t, "").Lint(context.Background(), "test.yaml", []byte("a: 1\nb:\n\tc: 2\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Issues) != 1 || result.Issues[0].Line != 3 {
+		t.Errorf("issues = %+v, want one syntax error on line 3", result.Issues)
+	}
+}
+
+func TestYAMLLinter_Yamllint(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake yamllint is a shell script")
+	}
+	dir := t.TempDir()
+	args := filepath.Join(dir, "args")
+	script := "#!/bin/sh\n" +
+		"echo \"$@\" > " + args + "\n" +
+		"cat > /dev/null\n" +
+		"echo 'stdin:2:5: [warning] wrong indentation: expected 2 but found 4 (indentation)'\n" +
+		"echo 'stdin:3:1: [error] duplication of key \"a\" in mapping (key-duplicates)'\n" +
+		"echo 'stdin:1:1: [warning] missing document start \"---\" (document-start)'\n" +
+		"exit 1\n"
+	yamllint := filepath.Join(dir, "yamllint")
+	if err := os.WriteFile(yamllint, []byte(script), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	cache := toolcache.NewMemoryCache()
+	cache.AddTool("yaml", "yamllint", yamllint)
+	linter := NewYAMLLinterWithToolCache(nil, cache)
+	if err := linter.SetConfig(json.RawMessage(`{"indentSize": 4, "disabledRules": ["document-start"]}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	file := filepath.Join(dir, "config.yaml")
+	result, err := linter.Lint(context.Background(), file, []byte("a:\n    b: 1\na: 2\n"))
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+	if got := rulesOf(result.Issues); strings.Join(got, ",") != "indentation,key-duplicates" {
+		t.Errorf("rules = %v, want yamllint's rules without the disabled one", got)
+	}
+	if result.Success || result.Issues[1].Severity != "error" || result.Issues[0].Line != 2 || result.Issues[0].File != file {
+		t.Errorf("result = %+v", result)
+	}
+
+	// Without a project config, the linter settings are passed as yamllint config
+	data, err := os.ReadFile(args)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "--config-data {extends: default, rules: {indentation: {spaces: 4}") {
+		t.Errorf("yamllint args = %s", data)
+	}
+
+	// A project .yamllint takes precedence
+	if err := os.WriteFile(filepath.Join(dir, ".yamllint"), []byte("extends: relaxed\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := linter.Lint(context.Background(), file, []byte("a: 1\n")); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(args); !strings.Contains(string(data), "--config-file "+filepath.Join(dir, ".yamllint")) {
+		t.Errorf("yamllint args = %s, want the project config", data)
+	}
+}
+
+func TestYAMLLinter_Rules(t *testing.T) {
+	var _ linters.RuleDescriber = NewYAMLLinter()
+	if got := NewYAMLLinter().Rules()[RuleDuplicateKey]; got == "" {
+		t.Error("duplicate-key rule has no description")
+	}
+}
@@ -4,18 +4,36 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 
+	"github.com/jrossi/gismo/i18n"
 	"github.com/jrossi/gismo/linters"
+	"github.com/jrossi/gismo/linters/conflicts"
+	"github.com/jrossi/gismo/linters/css"
+	"github.com/jrossi/gismo/linters/custom"
+	"github.com/jrossi/gismo/linters/dockerfile"
 	"github.com/jrossi/gismo/linters/golang"
 	"github.com/jrossi/gismo/linters/javascript"
 	jsonlinter "github.com/jrossi/gismo/linters/json"
 	"github.com/jrossi/gismo/linters/markdown"
+	"github.com/jrossi/gismo/linters/pathcheck"
 	"github.com/jrossi/gismo/linters/protobuf"
 	"github.com/jrossi/gismo/linters/python"
 	"github.com/jrossi/gismo/linters/rust"
+	"github.com/jrossi/gismo/linters/secrets"
+	"github.com/jrossi/gismo/linters/security"
+	"github.com/jrossi/gismo/linters/shell"
+	tomllinter "github.com/jrossi/gismo/linters/toml"
+	"github.com/jrossi/gismo/linters/unicodecheck"
+	yamllinter "github.com/jrossi/gismo/linters/yaml"
+	"github.com/jrossi/gismo/toolcache"
 )
 
 // LintingRuleEngine implements RuleEngine to provide linting functionality
@@ -23,6 +41,40 @@ type LintingRuleEngine struct {
 	linters  []linters.Linter
 	executor *linters.ParallelExecutor
 	config   *AppConfig
+	fs       linters.FileSystem
+	runner   *linters.CommandRunner
+	sessions *SessionStore
+	events   EventSink
+
+	// Destination of human-readable feedback, stderr by default
+	feedback io.Writer
+
+	// Treat warnings as errors whatever the config says, set by --strict
+	strict bool
+
+	// Source lines shown either side of an issue whatever the config says, set
+	// by -context; 0 follows the config
+	contextLines int
+
+	// Message catalog for feedback, from the config or the locale
+	messages *i18n.Catalog
+
+	// Sub-projects discovered under root, loaded on first use
+	root         string
+	projects     map[string]toolcache.ProjectConfig
+	projectsOnce sync.Once
+
+	// CODEOWNERS of the repository, loaded on first use
+	codeowners     *CodeOwners
+	codeownersOnce sync.Once
+
+	// Linters currently configured with file-specific overrides
+	overridden map[string]bool
+	// Config applied to each overridden linter, part of its result cache key
+	applied map[string]json.RawMessage
+
+	// Cached lint results; nil runs the linters every time
+	results *ResultCache
 }
 
 // LintingConfig provides configuration options for the linting engine
@@ -32,6 +84,33 @@ type LintingConfig struct {
 	MaxWorkers int
 	// DisableParallel disables parallel execution for debugging
 	DisableParallel bool
+	// ToolLimits caps concurrent processes per external tool, e.g. {"cargo": 1},
+	// on top of linters.DefaultToolLimits
+	ToolLimits map[string]int
+	// ResourceLimits sets nice, ionice and rlimits for spawned linter processes
+	// If nil, processes run without limits
+	ResourceLimits *linters.ResourceLimits
+	// CacheDirs pins tool-internal caches such as golangci-lint's and ruff's
+	// If nil, tools keep their caches where they put them
+	CacheDirs *linters.CacheDirs
+	// ToolCache overrides tool discovery for linters that locate external tools
+	// If nil, each linter uses the disk-backed cache for its project
+	ToolCache toolcache.ToolCache
+	// FileSystem overrides filesystem access for the engine and linters that support it
+	// If nil, the real OS filesystem is used
+	FileSystem linters.FileSystem
+	// SessionStore persists per-session state such as block streaks for escalation
+	// If nil, escalation after repeated blocks is disabled
+	SessionStore *SessionStore
+	// EventSink receives lint-start, issue and lint-end events
+	// If nil, no events are emitted
+	EventSink EventSink
+	// ProjectRoot is the repository root sub-projects are discovered from
+	// If empty, the working directory is used
+	ProjectRoot string
+	// ResultCache reuses lint results for content a linter has already checked
+	// If nil, linters run on every hook
+	ResultCache *ResultCache
 }
 
 // NewLintingRuleEngine creates a new linting rule engine with default linters
@@ -45,38 +124,140 @@ func NewLintingRuleEngineWithConfig(config LintingConfig) *LintingRuleEngine {
 	if config.DisableParallel {
 		maxWorkers = 1
 	}
+	runnerConfig := linters.RunnerConfig{ToolLimits: config.ToolLimits}
+	if config.ResourceLimits != nil {
+		runnerConfig.ResourceLimits = *config.ResourceLimits
+	}
+	if config.CacheDirs != nil {
+		runnerConfig.CacheDirs = *config.CacheDirs
+	}
 
 	engine := &LintingRuleEngine{
 		linters:  []linters.Linter{},
 		executor: linters.NewParallelExecutor(maxWorkers),
 		config:   NewAppConfig(),
+		fs:       config.FileSystem,
+		runner:   linters.NewCommandRunner(runnerConfig),
+		sessions: config.SessionStore,
+		events:   config.EventSink,
+		root:     config.ProjectRoot,
+		results:  config.ResultCache,
+		messages: i18n.Lookup(i18n.Detect("")),
+		feedback: os.Stderr,
+	}
+	if engine.fs == nil {
+		engine.fs = linters.OSFileSystem{}
 	}
 
 	// Initialize linters with empty configs for now
 	// We'll update them when SetAppConfig is called
-	engine.linters = append(engine.linters, golang.NewGoLinter())
-	engine.linters = append(engine.linters, javascript.NewJavaScriptLinter())
+	engine.linters = append(engine.linters, conflicts.NewConflictLinter())
+	engine.linters = append(engine.linters, css.NewCSSLinterWithToolCache(nil, config.ToolCache))
+	engine.linters = append(engine.linters, dockerfile.NewDockerfileLinterWithToolCache(nil, config.ToolCache))
+	engine.linters = append(engine.linters, golang.NewGoLinterWithToolCache(nil, config.ToolCache))
+	engine.linters = append(engine.linters, javascript.NewJavaScriptLinterWithToolCache(nil, config.ToolCache))
 	engine.linters = append(engine.linters, jsonlinter.NewJSONLinter())
 	engine.linters = append(engine.linters, markdown.NewMarkdownLinter())
+	engine.linters = append(engine.linters, pathcheck.NewPathLinter())
 	engine.linters = append(engine.linters, protobuf.NewProtobufLinter())
-	engine.linters = append(engine.linters, python.NewPythonLinter())
+	engine.linters = append(engine.linters, python.NewPythonLinterWithToolCache(nil, config.ToolCache))
 	engine.linters = append(engine.linters, rust.NewRustLinter())
+	engine.linters = append(engine.linters, secrets.NewSecretsLinter())
+	engine.linters = append(engine.linters, security.NewSecurityLinter())
+	engine.linters = append(engine.linters, shell.NewShellLinterWithToolCache(nil, config.ToolCache))
+	engine.linters = append(engine.linters, tomllinter.NewTOMLLinter())
+	engine.linters = append(engine.linters, unicodecheck.NewUnicodeLinter())
+	engine.linters = append(engine.linters, yamllinter.NewYAMLLinterWithToolCache(nil, config.ToolCache))
+
+	for _, linter := range engine.linters {
+		engine.applyFileSystem(linter)
+		engine.applyCommandRunner(linter)
+	}
 
 	return engine
 }
 
 // AddLinter adds a custom linter to the engine
 func (e *LintingRuleEngine) AddLinter(linter linters.Linter) {
+	e.applyFileSystem(linter)
+	e.applyCommandRunner(linter)
 	e.linters = append(e.linters, linter)
 }
 
+// applyFileSystem redirects a linter's filesystem access to the engine's filesystem
+func (e *LintingRuleEngine) applyFileSystem(linter linters.Linter) {
+	if aware, ok := linter.(linters.FileSystemAware); ok {
+		aware.SetFileSystem(e.fs)
+	}
+}
+
+// applyCommandRunner shares the engine's command runner with a linter, so tool
+// limits hold across linters and settings stay with this engine
+func (e *LintingRuleEngine) applyCommandRunner(linter linters.Linter) {
+	if aware, ok := linter.(linters.CommandRunnerAware); ok {
+		aware.SetCommandRunner(e.runner)
+	}
+}
+
+// SetFeedbackWriter redirects the feedback normally written to stderr, such as
+// for the HTTP hook server
+func (e *LintingRuleEngine) SetFeedbackWriter(w io.Writer) {
+	e.feedback = w
+}
+
+// SetStrict treats warnings as blocking errors, in addition to the strict setting
+// in the configuration
+func (e *LintingRuleEngine) SetStrict(strict bool) {
+	e.strict = strict
+}
+
+// SetContextLines shows n source lines either side of each issue, overriding
+// the contextLines setting in the configuration; 0 follows the configuration
+func (e *LintingRuleEngine) SetContextLines(n int) {
+	e.contextLines = n
+}
+
+// sourceContext returns how many source lines to attach either side of an
+// issue, or -1 for none
+func (e *LintingRuleEngine) sourceContext() int {
+	if e.contextLines > 0 {
+		return e.contextLines
+	}
+	return e.config.GetContextLines()
+}
+
+// severityPolicy returns how warnings are reported under the configuration
+func (e *LintingRuleEngine) severityPolicy() linters.SeverityPolicy {
+	return linters.SeverityPolicy{
+		Strict:         e.strict || e.config.IsStrict(),
+		WarningsAsInfo: e.config.IsWarningsAsInfo(),
+		ToolSeverities: e.config.GetSeverityMap(),
+	}
+}
+
 // SetAppConfig sets the application configuration
 func (e *LintingRuleEngine) SetAppConfig(config *AppConfig) {
 	e.config = config
+	e.messages = i18n.Lookup(i18n.Detect(config.GetLanguage()))
+
+	e.registerCustomLinters(config)
+	e.executor.SetLinterTimeouts(config.GetLinterTimeouts())
 
 	// Update linter configurations
 	if config != nil {
+		for tool, labels := range config.SeverityMap {
+			for label, severity := range labels {
+				if !linters.IsSeverity(severity) {
+					fmt.Fprintf(os.Stderr, "Warning: severityMap maps %s %q to %q, expected error, warning or info\n", tool, label, severity)
+				}
+			}
+		}
+
 		for _, linter := range e.linters {
+			// Environment and PATH for the linter's subprocesses
+			linterConfig := config.Linters[linter.Name()]
+			e.runner.SetCommandEnv(linter.Name(), linters.CommandEnv{Env: linterConfig.Env, Path: linterConfig.Path})
+
 			// Check if this linter is disabled
 			if !config.IsLinterEnabled(linter.Name()) {
 				continue
@@ -96,6 +277,59 @@ func (e *LintingRuleEngine) SetAppConfig(config *AppConfig) {
 	}
 }
 
+// registerCustomLinters replaces the custom linters of a previous config with
+// those config declares. Invalid declarations and names taken by built-in
+// linters are skipped with a warning.
+func (e *LintingRuleEngine) registerCustomLinters(config *AppConfig) {
+	builtin := e.linters[:0]
+	for _, linter := range e.linters {
+		if _, ok := linter.(*custom.Linter); !ok {
+			builtin = append(builtin, linter)
+		}
+	}
+	e.linters = builtin
+
+	declared := config.GetCustomLinters()
+	names := make([]string, 0, len(declared))
+	for name := range declared {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if slices.Contains(e.LinterNames(), name) {
+			fmt.Fprintf(os.Stderr, "Warning: custom linter %s ignored, a built-in linter has that name\n", name)
+			continue
+		}
+		linter, err := custom.New(name, declared[name])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			continue
+		}
+		e.AddLinter(linter)
+	}
+}
+
+// LinterNames returns the names of the registered linters
+func (e *LintingRuleEngine) LinterNames() []string {
+	names := make([]string, 0, len(e.linters))
+	for _, linter := range e.linters {
+		names = append(names, linter.Name())
+	}
+	return names
+}
+
+// LinterCapabilities returns the capabilities of each linter that reports them,
+// keyed by linter name
+func (e *LintingRuleEngine) LinterCapabilities() map[string]linters.Capabilities {
+	capabilities := make(map[string]linters.Capabilities)
+	for _, linter := range e.linters {
+		if describer, ok := linter.(linters.CapabilityDescriber); ok {
+			capabilities[linter.Name()] = describer.Capabilities()
+		}
+	}
+	return capabilities
+}
+
 // GetAppConfig returns the application configuration
 func (e *LintingRuleEngine) GetAppConfig() *AppConfig {
 	return e.config
@@ -107,50 +341,229 @@ type ConfigurableLinter interface {
 	SetConfig(config json.RawMessage) error
 }
 
-// applyRuleOverrides applies any rule overrides for the given file path
+// applyRuleOverrides configures each linter for the given file path. The base
+// linter config, sub-project settings and matching rule overrides are deep-merged
+// in that order, so an override changes only the keys it sets.
 func (e *LintingRuleEngine) applyRuleOverrides(filePath string) {
-	if e.config == nil {
+	e.applyOverrides(filePath, nil, nil)
+}
+
+// applyFileConfig configures each linter for filePath like applyRuleOverrides,
+// with the file's gismo:config directive merged last when inline config is
+// enabled. It returns the directive so callers can drop the rules it disables.
+func (e *LintingRuleEngine) applyFileConfig(filePath string, content []byte) *InlineConfig {
+	var inline *InlineConfig
+	if e.config.IsInlineConfigEnabled() {
+		inline = ParseInlineConfig(content)
+	}
+	e.applyOverrides(filePath, content, inline)
+
+	if inline != nil {
+		linterKeys := make(map[string]map[string]bool)
+		for _, linter := range e.linters {
+			if linter.CanHandle(filePath) || e.config.IsRouted(filePath, content, linter.Name()) {
+				linterKeys[linter.Name()] = schemaKeys(linter)
+			}
+		}
+		for _, key := range inline.unknownKeys(linterKeys) {
+			fmt.Fprintf(os.Stderr, "Warning: gismo:config in %s sets %q, which no linter checking the file accepts\n", filePath, key)
+		}
+	}
+	return inline
+}
+
+// applyOverrides applies the config layers for filePath and its content, plus
+// the inline directive if given, to each linter
+func (e *LintingRuleEngine) applyOverrides(filePath string, content []byte, inline *InlineConfig) {
+	if e.config == nil && inline == nil && len(e.overridden) == 0 {
 		return
 	}
 
 	// Apply overrides for each linter
 	for _, linter := range e.linters {
-		// Get any rule overrides for this file and linter
-		overrides := e.config.GetRuleOverrides(filePath, linter.Name())
-		if len(overrides) == 0 {
+		configurable, ok := linter.(ConfigurableLinter)
+		if !ok {
 			continue
 		}
 
-		// Try to cast to configurable linter
-		if configurable, ok := linter.(ConfigurableLinter); ok {
-			// Merge all overrides into a single config
-			mergedConfig := make(map[string]interface{})
+		var overrides []json.RawMessage
+		if e.config != nil {
+			overrides = e.linterOverrides(filePath, content, linter.Name())
+		}
+		if layer := inline.layer(linter.Name(), schemaKeys(linter)); layer != nil {
+			overrides = append(overrides, layer)
+		}
+
+		// A file without overrides gets the base config back after one that had them
+		if len(overrides) == 0 && !e.overridden[linter.Name()] {
+			continue
+		}
 
-			for _, override := range overrides {
-				var overrideMap map[string]interface{}
-				if err := json.Unmarshal(override, &overrideMap); err != nil {
-					continue
-				}
+		var base json.RawMessage
+		if e.config != nil {
+			base, _ = e.config.GetLinterConfig(linter.Name())
+		}
+		configData, err := MergeLinterConfigs(append([]json.RawMessage{base}, overrides...)...)
+		if err == nil {
+			err = configurable.SetConfig(configData)
+		}
+		if err != nil {
+			// Log error but continue
+			fmt.Fprintf(os.Stderr, "Warning: Failed to apply rule override for %s linter: %v\n", linter.Name(), err)
+			continue
+		}
+		if e.overridden == nil {
+			e.overridden = make(map[string]bool)
+			e.applied = make(map[string]json.RawMessage)
+		}
+		e.overridden[linter.Name()] = len(overrides) > 0
+		if len(overrides) > 0 {
+			e.applied[linter.Name()] = configData
+		} else {
+			delete(e.applied, linter.Name())
+		}
+	}
+}
 
-				// Merge this override into the merged config
-				for k, v := range overrideMap {
-					mergedConfig[k] = v
+// linterOverrides returns the config layers applied on top of a linter's base
+// config for filePath: sub-project settings first, then matching rule overrides.
+// Rules matching on content apply only when content is given.
+func (e *LintingRuleEngine) linterOverrides(filePath string, content []byte, linterName string) []json.RawMessage {
+	return e.configOverrides(e.config, filePath, content, linterName)
+}
+
+// configOverrides returns the layers linterOverrides would return under config
+func (e *LintingRuleEngine) configOverrides(config *AppConfig, filePath string, content []byte, linterName string) []json.RawMessage {
+	var overrides []json.RawMessage
+	if config.Projects != nil && len(config.Projects.Linters) > 0 {
+		if rel, inRoot := e.projectRelPath(filePath); inRoot {
+			for _, projectConfig := range config.projectLinterConfig(rel, linterName) {
+				if projectConfig.Config != nil {
+					overrides = append(overrides, projectConfig.Config)
 				}
 			}
+		}
+	}
+	return append(overrides, config.GetContentRuleOverrides(filePath, content, linterName)...)
+}
 
-			// Convert back to JSON and apply
-			if configData, err := json.Marshal(mergedConfig); err == nil {
-				if err := configurable.SetConfig(configData); err != nil {
-					// Log error but continue
-					fmt.Fprintf(os.Stderr, "Warning: Failed to apply rule override for %s linter: %v\n", linter.Name(), err)
-				}
+// LinterConfigsForPath returns the merged config of each linter that would
+// check filePath, keyed by linter name. Keys left unset use the linter's defaults.
+func (e *LintingRuleEngine) LinterConfigsForPath(filePath string) (map[string]json.RawMessage, error) {
+	configs := make(map[string]json.RawMessage)
+	for _, linter := range e.linters {
+		if !linter.CanHandle(filePath) {
+			continue
+		}
+		layers := []json.RawMessage{}
+		if e.config != nil {
+			base, _ := e.config.GetLinterConfig(linter.Name())
+			layers = append(append(layers, base), e.linterOverrides(filePath, nil, linter.Name())...)
+		}
+		merged, err := MergeLinterConfigs(layers...)
+		if err != nil {
+			return nil, fmt.Errorf("%s linter config: %w", linter.Name(), err)
+		}
+		configs[linter.Name()] = merged
+	}
+	return configs, nil
+}
+
+// LintFile runs the linters that handle filePath on content with the file's
+// rule overrides applied, and returns every issue found
+func (e *LintingRuleEngine) LintFile(ctx context.Context, filePath string, content []byte) ([]Diagnostic, error) {
+	inline := e.applyFileConfig(filePath, content)
+
+	results := e.runLinters(ctx, "", "", "", filePath, content)
+	e.fingerprintResults(filePath, content, results)
+
+	diagnostics := []Diagnostic{}
+	policy := e.severityPolicy()
+	sourceLines := e.sourceContext()
+	seen := make(map[string]bool)
+	for _, result := range results {
+		if result.Error != nil {
+			return nil, fmt.Errorf("%s linter: %w", result.LinterName, result.Error)
+		}
+		if result.Result == nil {
+			continue
+		}
+		for _, issue := range result.Result.Issues {
+			// Skip findings another linter already reported, and rules the file disables
+			if seen[issue.Fingerprint] || inline.disables(issue.Rule) {
+				continue
+			}
+			seen[issue.Fingerprint] = true
+			if issue.File == "" {
+				issue.File = filePath
 			}
+			issue.Severity = policy.ApplyIssue(issue)
+			issues := []linters.Issue{issue}
+			linters.AttachSourceLines(filePath, content, issues, sourceLines)
+			diagnostics = append(diagnostics, Diagnostic{Linter: result.LinterName, Issue: issues[0]})
+		}
+	}
+	return diagnostics, nil
+}
+
+// fingerprintPath returns filePath relative to the project root, or to the working
+// directory without one, so issue fingerprints don't depend on the checkout location
+func (e *LintingRuleEngine) fingerprintPath(filePath string) string {
+	root := e.root
+	if root == "" {
+		root, _ = os.Getwd()
+	}
+	if abs, err := filepath.Abs(filePath); err == nil && root != "" {
+		if rel, err := filepath.Rel(root, abs); err == nil && !strings.HasPrefix(rel, "..") {
+			return rel
+		}
+	}
+	return filePath
+}
+
+// fingerprintResults sets the fingerprints of the issues each linter found in content
+func (e *LintingRuleEngine) fingerprintResults(filePath string, content []byte, results []linters.LintTaskResult) {
+	path := e.fingerprintPath(filePath)
+	for _, result := range results {
+		if result.Result != nil {
+			linters.SetFingerprints(path, content, result.Result.Issues)
 		}
 	}
 }
 
+// RuleDescription describes a rule defined by one of the engine's linters
+type RuleDescription struct {
+	Linter      string `json:"linter"`
+	Rule        string `json:"rule"`
+	Description string `json:"description"`
+}
+
+// ExplainRule returns the description of rule from each linter that defines it.
+// Rules reported by external tools, such as golangci-lint linters, have none.
+func (e *LintingRuleEngine) ExplainRule(rule string) []RuleDescription {
+	var descriptions []RuleDescription
+	for _, linter := range e.linters {
+		describer, ok := linter.(linters.RuleDescriber)
+		if !ok {
+			continue
+		}
+		if description, ok := describer.Rules()[rule]; ok {
+			if localized, ok := e.messages.Text("rule." + linter.Name() + "." + rule); ok {
+				description = localized
+			}
+			descriptions = append(descriptions, RuleDescription{Linter: linter.Name(), Rule: rule, Description: description})
+		}
+	}
+	return descriptions
+}
+
 // EvaluatePreToolUse checks files before they're written
 func (e *LintingRuleEngine) EvaluatePreToolUse(ctx context.Context, msg *PreToolUseMessage) (*HookResponse, error) {
+	// Bash commands are checked against the command policy
+	if msg.ToolName == "Bash" {
+		return e.evaluateCommand(msg), nil
+	}
+
 	// Only check Write and Edit operations
 	if msg.ToolName != "Write" && msg.ToolName != "Edit" && msg.ToolName != "MultiEdit" {
 		return &HookResponse{Decision: "approve"}, nil
@@ -171,35 +584,56 @@ func (e *LintingRuleEngine) EvaluatePreToolUse(ctx context.Context, msg *PreTool
 		return &HookResponse{Decision: "approve"}, nil
 	}
 
-	// For Edit/MultiEdit, we can't lint until after the edit is done
-	if msg.ToolName == "Edit" || msg.ToolName == "MultiEdit" {
-		return &HookResponse{Decision: "approve"}, nil
+	// New files must be created where the project's conventions put them
+	if block := e.evaluateNewFile(msg, filePath); block != nil {
+		return block, nil
 	}
 
-	// For Write operations, check the content
-	contentRaw, exists := msg.ToolInput["content"]
-	if !exists {
-		return &HookResponse{Decision: "approve"}, nil
+	// Generated files are regenerated, not edited by hand
+	if block := e.evaluateGeneratedCode(msg, filePath); block != nil {
+		return block, nil
 	}
+
+	// The project's health is recorded before the session's first edit lands
+	e.captureProjectBaseline(ctx, msg.SessionID)
+
 	var content string
-	if err := json.Unmarshal(contentRaw, &content); err != nil {
-		return &HookResponse{Decision: "approve"}, nil
+	if msg.ToolName == "Write" {
+		// For Write operations, check the content
+		contentRaw, exists := msg.ToolInput["content"]
+		if !exists {
+			return &HookResponse{Decision: "approve"}, nil
+		}
+		if err := json.Unmarshal(contentRaw, &content); err != nil {
+			return &HookResponse{Decision: "approve"}, nil
+		}
+	} else {
+		// For Edit/MultiEdit, apply the edits in memory to get the resulting content.
+		// If they can't be applied the tool itself will fail, so there is nothing to check.
+		edited, ok := e.applyPendingEdits(filePath, msg)
+		if !ok {
+			return &HookResponse{Decision: "approve"}, nil
+		}
+		content = edited
 	}
 
-	// Apply rule overrides for this file
-	e.applyRuleOverrides(filePath)
+	// Apply rule overrides and the file's own directive
+	inline := e.applyFileConfig(filePath, []byte(content))
 
 	// Run all applicable linters in parallel
-	results := e.executor.ExecuteLinters(ctx, e.linters, filePath, []byte(content))
+	results := e.runLinters(ctx, string(PreToolUseEvent), msg.SessionID, msg.ToolName, filePath, []byte(content))
 
 	// Aggregate results
-	aggregatedResult, errs := linters.AggregateResults(results)
+	e.fingerprintResults(filePath, []byte(content), results)
+	aggregatedResult, errs := linters.AggregateResultsWithPolicy(results, e.severityPolicy())
+	aggregatedResult.Issues = inline.filter(linters.DeduplicateIssues(aggregatedResult.Issues))
+	linters.AttachSourceLines(filePath, []byte(content), aggregatedResult.Issues, e.sourceContext())
 
 	// Handle any linting errors
 	if len(errs) > 0 {
 		return &HookResponse{
 			Decision: "block",
-			Reason:   fmt.Sprintf("Linting error: %v", errs[0]),
+			Reason:   e.messages.Sprintf("reason.linting_error", errs[0]),
 		}, nil
 	}
 
@@ -213,46 +647,195 @@ func (e *LintingRuleEngine) EvaluatePreToolUse(ctx context.Context, msg *PreTool
 		}
 	}
 
+	// Edits only block on errors they introduce; errors already in the file
+	// would otherwise prevent every edit, including the ones fixing them
+	if len(errorIssues) > 0 && msg.ToolName != "Write" {
+		var preExisting []linters.Issue
+		errorIssues, preExisting = e.splitPreExistingErrors(ctx, filePath, errorIssues, e.blockingLinters(filePath, results))
+		warningIssues = append(warningIssues, preExisting...)
+	}
+
+	// Rules a person relaxed with gismo allow don't block until the override expires
+	errorIssues, relaxed, overrideNote := e.relaxRules(msg.SessionID, filePath, errorIssues)
+	warningIssues = append(warningIssues, relaxed...)
+
 	// If there are syntax errors, block the write
 	if len(errorIssues) > 0 {
 		output := e.formatLintOutput(filePath, errorIssues, true)
 		// Write detailed output to stderr for user visibility
-		fmt.Fprintf(os.Stderr, "\n> Write operation feedback:\n%s\n", output)
-		return &HookResponse{
+		fmt.Fprintf(e.feedback, "\n> %s:\n%s\n", e.messages.Sprintf("feedback.operation", msg.ToolName), output)
+		response := &HookResponse{
 			Decision: "block",
-			Reason:   fmt.Sprintf("Found %d error(s) in %s", len(errorIssues), filePath),
-		}, nil
+			Reason:   e.messages.Sprintf("reason.errors_found", len(errorIssues), filePath),
+		}
+		if owners := e.crossTeamOwners(filePath); owners != "" {
+			response.Reason += e.messages.Sprintf("reason.owned_by", owners)
+		}
+		response.Reason = e.appendFixPayload(response.Reason, filePath, []byte(content), aggregatedResult.Formatted)
+		return e.trackBlock(msg.SessionID, filePath, errorIssues, aggregatedResult.Formatted, response), nil
+	}
+
+	// Edits to files owned by another team may need acknowledgment
+	acknowledge, ownershipWarning := e.checkCrossTeamEdit(msg.SessionID, filePath)
+	if acknowledge != nil {
+		fmt.Fprintf(e.feedback, "\n> %s:\n  - [gismo]: %s\n", e.messages.Sprintf("feedback.operation", msg.ToolName), acknowledge.Reason)
+		return acknowledge, nil
+	}
+
+	// Edits too large to review are flagged, or blocked until retried
+	sizeBlock, sizeWarning := e.checkEditSize(msg, filePath, content)
+	if sizeBlock != nil {
+		fmt.Fprintf(e.feedback, "\n> %s:\n  - [gismo]: %s\n", e.messages.Sprintf("feedback.operation", msg.ToolName), sizeBlock.Reason)
+		return sizeBlock, nil
+	}
+	var notices []string
+	for _, notice := range []string{overrideNote, ownershipWarning, sizeWarning} {
+		if notice != "" {
+			notices = append(notices, notice)
+		}
 	}
+	notice := strings.Join(notices, "\n")
+
+	// Approval ends any run of consecutive blocks on this file
+	e.trackApprove(msg.SessionID, filePath)
 
 	// If formatting is needed, inform but don't block
 	if len(warningIssues) > 0 {
 		output := e.formatLintOutput(filePath, warningIssues, false)
 		// Write detailed output to stderr for user visibility
-		fmt.Fprintf(os.Stderr, "\n> Write operation feedback:\n%s\n", output)
+		fmt.Fprintf(e.feedback, "\n> %s:\n%s\n", e.messages.Sprintf("feedback.operation", msg.ToolName), output)
+		message := e.messages.Sprintf("reason.warnings_found", len(warningIssues), filePath)
+		if notice != "" {
+			message += "\n" + notice
+		}
 		return &HookResponse{
 			Decision: "approve",
-			Message:  fmt.Sprintf("Found %d warning(s) in %s", len(warningIssues), filePath),
+			Message:  e.appendFixPayload(message, filePath, []byte(content), aggregatedResult.Formatted),
 		}, nil
 	}
 
+	if notice != "" {
+		fmt.Fprintf(e.feedback, "\n> %s:\n  - [gismo]: %s\n", e.messages.Sprintf("feedback.operation", msg.ToolName), notice)
+		return &HookResponse{Decision: "approve", Message: notice}, nil
+	}
+
 	// Write success message to stderr (matching smart-lint.sh behavior)
-	fmt.Fprintf(os.Stderr, "\n> Write operation feedback:\n  - [gismo]: ✅ Style clean. Continue with your task.\n")
+	fmt.Fprintf(e.feedback, "\n> %s:\n  - [gismo]: %s\n", e.messages.Sprintf("feedback.operation", msg.ToolName), e.messages.Sprintf("feedback.style_clean"))
 	return &HookResponse{Decision: "approve"}, nil
 }
 
+// applyPendingEdits reads the current file and applies the Edit/MultiEdit input to it
+// in memory, as the tool would, including to files with CRLF line endings. It
+// returns false if the input is malformed or the edits don't apply.
+func (e *LintingRuleEngine) applyPendingEdits(filePath string, msg *PreToolUseMessage) (string, bool) {
+	var current string
+	data, err := e.fs.ReadFile(filePath)
+	if err == nil {
+		current = string(data)
+	} else if !os.IsNotExist(err) {
+		return "", false
+	}
+
+	input, err := ParseToolInput(msg.ToolName, msg.ToolInput)
+	if err != nil {
+		return "", false
+	}
+
+	var apply func(string) (string, error)
+	switch in := input.(type) {
+	case EditToolInput:
+		apply = in.Apply
+	case MultiEditToolInput:
+		apply = in.Apply
+	default:
+		return "", false
+	}
+	edited, err := apply(current)
+	if err != nil && strings.Contains(current, "\r\n") {
+		// The Edit tool matches edits against the file with LF line endings
+		// and writes it back with CRLF
+		edited, err = apply(strings.ReplaceAll(current, "\r\n", "\n"))
+		edited = strings.ReplaceAll(edited, "\n", "\r\n")
+	}
+	if err != nil {
+		return "", false
+	}
+	return edited, true
+}
+
+// splitPreExistingErrors lints the file as it is on disk and separates errors that
+// already exist there from errors introduced by the pending edit. Issues are matched
+// by fingerprint, which doesn't change when an edit moves the offending line, and
+// then by rule and message. Only the linters that reported errors run again, and
+// only their static checks: tests are not run twice per edit, so failing tests
+// always count as introduced.
+func (e *LintingRuleEngine) splitPreExistingErrors(ctx context.Context, filePath string, errorIssues []linters.Issue, blocking []linters.Linter) (introduced, preExisting []linters.Issue) {
+	original, err := e.fs.ReadFile(filePath)
+	if err != nil {
+		return errorIssues, nil
+	}
+
+	results := e.executeLinters(linters.WithStaticOnly(ctx), blocking, filePath, original)
+	e.fingerprintResults(filePath, original, results)
+	originalResult, _ := linters.AggregateResultsWithPolicy(results, e.severityPolicy())
+
+	var baseline []linters.Issue
+	for _, issue := range originalResult.Issues {
+		if issue.Severity == "error" {
+			baseline = append(baseline, issue)
+		}
+	}
+	introduced, preExisting = linters.SplitByBaseline(errorIssues, baseline)
+	for i := range preExisting {
+		preExisting[i].Severity = "warning"
+	}
+	return introduced, preExisting
+}
+
+// blockingLinters returns the linters for filePath whose results include errors
+// under the severity policy
+func (e *LintingRuleEngine) blockingLinters(filePath string, results []linters.LintTaskResult) []linters.Linter {
+	policy := e.severityPolicy()
+	names := make(map[string]bool)
+	for _, result := range results {
+		if result.Result == nil {
+			continue
+		}
+		for _, issue := range result.Result.Issues {
+			if policy.ApplyIssue(issue) == "error" {
+				names[result.LinterName] = true
+				break
+			}
+		}
+	}
+
+	var blocking []linters.Linter
+	for _, linter := range e.linters {
+		if !names[linter.Name()] {
+			continue
+		}
+		// A linter that checked the file by routing checks its original the same way
+		if !linter.CanHandle(filePath) {
+			linter = routedLinter{linter}
+		}
+		blocking = append(blocking, linter)
+	}
+	return blocking
+}
+
 // EvaluatePostToolUse runs linters and tests after file operations
 func (e *LintingRuleEngine) EvaluatePostToolUse(ctx context.Context, msg *PostToolUseMessage) (*HookResponse, error) {
 	// Only check Write and Edit operations
 	if msg.ToolName != "Write" && msg.ToolName != "Edit" && msg.ToolName != "MultiEdit" {
 		// Show status for non-file operations on stderr (matching smart-lint.sh behavior)
-		fmt.Fprintf(os.Stderr, "\n> Tool execution feedback:\n  - [gismo]: ℹ️  %s operation completed (no linting required)\n", msg.ToolName)
+		fmt.Fprintf(e.feedback, "\n> %s:\n  - [gismo]: %s\n", e.messages.Sprintf("feedback.tool_execution"), e.messages.Sprintf("feedback.no_linting", msg.ToolName))
 		return nil, nil
 	}
 
 	// Skip if there was an error
 	if msg.ToolError != "" {
 		// Tool errors trigger exit code 1, shown on stderr
-		fmt.Fprintf(os.Stderr, "\n> Tool execution feedback:\n  - [gismo]: ⚠️  Tool error: %s (skipping linting)\n", msg.ToolError)
+		fmt.Fprintf(e.feedback, "\n> %s:\n  - [gismo]: %s\n", e.messages.Sprintf("feedback.tool_execution"), e.messages.Sprintf("feedback.tool_error", msg.ToolError))
 		return nil, nil
 	}
 
@@ -272,30 +855,48 @@ func (e *LintingRuleEngine) EvaluatePostToolUse(ctx context.Context, msg *PostTo
 	}
 
 	// Read the actual file from disk
-	content, err := os.ReadFile(filePath)
+	content, err := e.fs.ReadFile(filePath)
 	if err != nil {
 		// File errors shown on stderr (matching smart-lint.sh behavior)
 		if os.IsNotExist(err) {
-			fmt.Fprintf(os.Stderr, "\n> Write operation feedback:\n  - [gismo]: ⚠️  File not found: %s\n", filePath)
+			fmt.Fprintf(e.feedback, "\n> %s:\n  - [gismo]: %s\n", e.messages.Sprintf("feedback.operation", "Write"), e.messages.Sprintf("feedback.file_not_found", filePath))
 		} else {
-			fmt.Fprintf(os.Stderr, "\n> Write operation feedback:\n  - [gismo]: ⚠️  Cannot read file: %v\n", err)
+			fmt.Fprintf(e.feedback, "\n> %s:\n  - [gismo]: %s\n", e.messages.Sprintf("feedback.operation", "Write"), e.messages.Sprintf("feedback.cannot_read", err))
 		}
 		return nil, nil
 	}
 
-	// Apply rule overrides for this file
-	e.applyRuleOverrides(filePath)
+	// Tell Claude about edits that changed nothing, so it stops rewriting the
+	// file; the lint results of an unchanged file are what they were
+	change := classifyEdit(msg)
+	if note := e.editChangeNote(change, filePath); note != "" {
+		fmt.Fprintf(e.feedback, "\n> %s:\n  - [gismo]: %s\n", e.messages.Sprintf("feedback.operation", msg.ToolName), note)
+	}
+	if change == editUnchanged {
+		return nil, nil
+	}
+
+	// Edits to dependency manifests list the dependencies they change
+	if note := e.dependencyChangeNote(msg, filePath, content); note != "" {
+		fmt.Fprintf(e.feedback, "\n> %s:\n  - [gismo]: %s\n", e.messages.Sprintf("feedback.operation", msg.ToolName), note)
+	}
+
+	// Apply rule overrides and the file's own directive
+	inline := e.applyFileConfig(filePath, content)
 
 	// Run all applicable linters in parallel
-	results := e.executor.ExecuteLinters(ctx, e.linters, filePath, content)
+	results := e.runLinters(ctx, string(PostToolUseEvent), msg.SessionID, msg.ToolName, filePath, content)
 
 	// Aggregate results
-	aggregatedResult, errs := linters.AggregateResults(results)
+	e.fingerprintResults(filePath, content, results)
+	aggregatedResult, errs := linters.AggregateResultsWithPolicy(results, e.severityPolicy())
+	aggregatedResult.Issues = inline.filter(linters.DeduplicateIssues(aggregatedResult.Issues))
+	linters.AttachSourceLines(filePath, content, aggregatedResult.Issues, e.sourceContext())
 
 	// Handle any linting errors
 	for _, err := range errs {
 		// Linting errors trigger exit code 1, shown on stderr
-		fmt.Fprintf(os.Stderr, "\n> Linting error for %s: %v\n", filePath, err)
+		fmt.Fprintf(e.feedback, "\n> %s\n", e.messages.Sprintf("feedback.linting_error_for", filePath, err))
 	}
 
 	// Check for issues and format detailed output
@@ -308,21 +909,33 @@ func (e *LintingRuleEngine) EvaluatePostToolUse(ctx context.Context, msg *PostTo
 		}
 	}
 
+	// Check for associated test files if it's a Go file
+	var testPath string
+	var testIssues []linters.Issue
+	if strings.HasSuffix(filePath, ".go") && !strings.HasSuffix(filePath, "_test.go") {
+		testPath, testIssues = e.lintTestFile(ctx, filePath)
+	}
+
+	// Feedback covering several files is consolidated into one ranked summary
+	if len(testIssues) > 0 {
+		summary := NewFeedbackSummary(map[string][]linters.Issue{
+			filePath: aggregatedResult.Issues,
+			testPath: testIssues,
+		}, e.config.GetMaxIssuesPerFile())
+		fmt.Fprintf(e.feedback, "\n> %s:\n%s\n", e.messages.Sprintf("feedback.operation", "Write"), summary.FormatIn(e.messages))
+		return nil, nil
+	}
+
 	// Issues trigger exit code 1, shown on stderr
 	if len(errorIssues) > 0 {
 		output := e.formatLintOutput(filePath, errorIssues, true)
-		fmt.Fprintf(os.Stderr, "\n> Write operation feedback:\n%s\n", output)
+		fmt.Fprintf(e.feedback, "\n> %s:\n%s\n", e.messages.Sprintf("feedback.operation", "Write"), output)
 	} else if len(warningIssues) > 0 {
 		output := e.formatLintOutput(filePath, warningIssues, false)
-		fmt.Fprintf(os.Stderr, "\n> Write operation feedback:\n%s\n", output)
+		fmt.Fprintf(e.feedback, "\n> %s:\n%s\n", e.messages.Sprintf("feedback.operation", "Write"), output)
 	} else if len(errs) == 0 {
 		// Success shown on stderr (matching smart-lint.sh behavior)
-		fmt.Fprintf(os.Stderr, "\n> Write operation feedback:\n  - [gismo]: ✅ Style clean. Continue with your task.\n")
-	}
-
-	// Check for associated test files if it's a Go file
-	if strings.HasSuffix(filePath, ".go") && !strings.HasSuffix(filePath, "_test.go") {
-		e.checkTestFile(ctx, filePath)
+		fmt.Fprintf(e.feedback, "\n> %s:\n  - [gismo]: %s\n", e.messages.Sprintf("feedback.operation", "Write"), e.messages.Sprintf("feedback.style_clean"))
 	}
 
 	// Always return nil for PostToolUse to avoid JSON output interfering with stderr
@@ -335,9 +948,16 @@ func (e *LintingRuleEngine) EvaluateNotification(ctx context.Context, msg *Notif
 	return nil, nil
 }
 
-// EvaluateStop handles main agent completion
+// EvaluateStop handles main agent completion. With stopHook.projectScan
+// enabled it reports how the project's health changed during the session; the
+// report never blocks stopping.
 func (e *LintingRuleEngine) EvaluateStop(ctx context.Context, msg *StopMessage) (*HookResponse, error) {
-	return nil, nil
+	delta := e.projectHealthDelta(ctx, msg.SessionID)
+	if delta == "" {
+		return nil, nil
+	}
+	fmt.Fprintf(e.feedback, "\n%s\n", delta)
+	return &HookResponse{Message: delta}, nil
 }
 
 // EvaluateSubagentStop handles subagent completion
@@ -375,69 +995,86 @@ func (e *LintingRuleEngine) formatLintOutput(filePath string, issues []linters.I
 		if issue.Rule != "" {
 			output.WriteString(fmt.Sprintf(" (%s)", issue.Rule))
 		}
+		writeSourceLines(&output, issue)
 	}
 
 	output.WriteString("\n")
 
 	// Add footer similar to smart-lint.sh
 	if isBlocking {
-		issueCount := len(issues)
-		output.WriteString(fmt.Sprintf("\n❌ Found %d blocking issue(s) - fix all above\n", issueCount))
-		output.WriteString("⛔ BLOCKING: Must fix ALL errors above before continuing")
+		output.WriteString("\n" + e.messages.Sprintf("output.blocking_count", len(issues)) + "\n")
+		output.WriteString(e.messages.Sprintf("output.blocking"))
 	} else {
-		issueCount := len(issues)
-		output.WriteString(fmt.Sprintf("\n⚠️  Found %d warning(s) - consider fixing\n", issueCount))
-		output.WriteString("📝 NON-BLOCKING: Issues detected but you can continue")
+		output.WriteString("\n" + e.messages.Sprintf("output.warning_count", len(issues)) + "\n")
+		output.WriteString(e.messages.Sprintf("output.non_blocking"))
 	}
 
 	return output.String()
 }
 
-// checkTestFile checks for an associated _test.go file and runs linting on it
-func (e *LintingRuleEngine) checkTestFile(ctx context.Context, filePath string) {
+// writeSourceLines writes an issue's source lines, numbered, with the issue line
+// marked and a caret under the issue column
+func writeSourceLines(output *strings.Builder, issue linters.Issue) {
+	width := len(strconv.Itoa(issue.SourceStart + len(issue.SourceLines) - 1))
+	for i, line := range issue.SourceLines {
+		number := issue.SourceStart + i
+		marker := " "
+		if number == issue.Line {
+			marker = ">"
+		}
+		output.WriteString(fmt.Sprintf("\n  %s %*d | %s", marker, width, number, line))
+		if number == issue.Line && issue.Column > 0 {
+			output.WriteString(fmt.Sprintf("\n    %*s | %s^", width, "", caretIndent(line, issue.Column)))
+		}
+	}
+}
+
+// caretIndent returns the whitespace placing a caret under a rune column of
+// line, keeping tabs so the caret lines up however tabs are displayed
+func caretIndent(line string, column int) string {
+	var indent strings.Builder
+	for i, r := range []rune(line) {
+		if i >= column-1 {
+			break
+		}
+		if r == '\t' {
+			indent.WriteRune('\t')
+		} else {
+			indent.WriteRune(' ')
+		}
+	}
+	return indent.String()
+}
+
+// lintTestFile lints the _test.go file associated with filePath, if one exists,
+// and returns its path and issues
+func (e *LintingRuleEngine) lintTestFile(ctx context.Context, filePath string) (string, []linters.Issue) {
 	// Construct test file path
 	base := strings.TrimSuffix(filePath, ".go")
 	testPath := base + "_test.go"
 
 	// Check if test file exists
-	content, err := os.ReadFile(testPath)
+	content, err := e.fs.ReadFile(testPath)
 	if err != nil {
 		// No test file, that's ok
-		return
+		return testPath, nil
 	}
 
 	// Run all applicable linters on test file in parallel
-	results := e.executor.ExecuteLinters(ctx, e.linters, testPath, content)
+	results := e.executor.ExecuteLinters(ctx, e.lintersFor(testPath), testPath, content)
 
 	// Aggregate results
-	aggregatedResult, errs := linters.AggregateResults(results)
+	e.fingerprintResults(testPath, content, results)
+	aggregatedResult, errs := linters.AggregateResultsWithPolicy(results, e.severityPolicy())
+	aggregatedResult.Issues = linters.DeduplicateIssues(aggregatedResult.Issues)
 
 	// Handle any linting errors
 	for _, err := range errs {
 		// Test file linting errors trigger exit code 1, shown on stderr
-		fmt.Fprintf(os.Stderr, "\n> Test file linting error for %s: %v\n", testPath, err)
+		fmt.Fprintf(e.feedback, "\n> %s\n", e.messages.Sprintf("feedback.test_linting_error_for", testPath, err))
 	}
 
-	// Report any issues found in test file
-	if len(aggregatedResult.Issues) > 0 {
-		var errorIssues, warningIssues []linters.Issue
-		for _, issue := range aggregatedResult.Issues {
-			if issue.Severity == "error" {
-				errorIssues = append(errorIssues, issue)
-			} else {
-				warningIssues = append(warningIssues, issue)
-			}
-		}
-
-		// Test file issues trigger exit code 1, shown on stderr
-		if len(errorIssues) > 0 {
-			output := e.formatLintOutput(testPath, errorIssues, true)
-			fmt.Fprintf(os.Stderr, "\n> Test file feedback:\n%s\n", output)
-		} else if len(warningIssues) > 0 {
-			output := e.formatLintOutput(testPath, warningIssues, false)
-			fmt.Fprintf(os.Stderr, "\n> Test file feedback:\n%s\n", output)
-		}
-	}
+	return testPath, aggregatedResult.Issues
 }
 
 // isTemporaryTestFile checks if a file path represents a temporary test file
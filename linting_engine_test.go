@@ -1,10 +1,16 @@
 package gismo
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/jrossi/gismo/linters"
+	"github.com/jrossi/gismo/linters/custom"
 )
 
 // MockLinter for testing
@@ -47,7 +53,7 @@ func TestLintingRuleEngine_EvaluatePreToolUse(t *testing.T) {
 			input: map[string]interface{}{
 				"file_path": "test.go",
 			},
-			want: "approve", // Edit is approved, linting happens after
+			want: "approve", // Edit without applicable edits is left to the tool
 		},
 		{
 			name:     "write with no file path",
@@ -156,6 +162,149 @@ func TestLintingRuleEngine_EvaluatePreToolUse(t *testing.T) {
 	}
 }
 
+func TestLintingRuleEngine_SeverityPolicy(t *testing.T) {
+	msg := &PreToolUseMessage{
+		BaseHookMessage: BaseHookMessage{HookEventName: PreToolUseEvent},
+		ToolName:        "Write",
+		ToolInput:       testConvertToRawMessage(map[string]interface{}{"file_path": "a.go", "content": "package a"}),
+	}
+	newEngine := func() *LintingRuleEngine {
+		engine := NewLintingRuleEngine()
+		engine.linters = []linters.Linter{&MockLinter{
+			canHandle: true,
+			result: &linters.LintResult{Success: true, Issues: []linters.Issue{
+				{Severity: "warning", Message: "unused variable", Rule: "unused"},
+			}},
+		}}
+		return engine
+	}
+	enabled := true
+
+	tests := []struct {
+		name   string
+		config *AppConfig
+		strict bool
+		want   string
+	}{
+		{name: "default", config: &AppConfig{}, want: "approve"},
+		{name: "strict config", config: &AppConfig{Strict: &enabled}, want: "block"},
+		{name: "strict flag", config: &AppConfig{}, strict: true, want: "block"},
+		{name: "strict wins over warningsAsInfo", config: &AppConfig{Strict: &enabled, WarningsAsInfo: &enabled}, want: "block"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := newEngine()
+			engine.SetAppConfig(tt.config)
+			engine.SetStrict(tt.strict)
+			resp, err := engine.EvaluatePreToolUse(context.Background(), msg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.Decision != tt.want {
+				t.Errorf("decision = %s, want %s", resp.Decision, tt.want)
+			}
+		})
+	}
+
+	engine := newEngine()
+	engine.SetAppConfig(&AppConfig{WarningsAsInfo: &enabled})
+	diagnostics, err := engine.LintFile(context.Background(), "a.go", []byte("package a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diagnostics) != 1 || diagnostics[0].Severity != "info" {
+		t.Errorf("LintFile() with warningsAsInfo = %+v", diagnostics)
+	}
+}
+
+func TestLintingRuleEngine_Language(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "zh_CN.UTF-8")
+	engine := NewLintingRuleEngine()
+	engine.linters = []linters.Linter{&MockLinter{
+		canHandle: true,
+		result: &linters.LintResult{Issues: []linters.Issue{
+			{Severity: "error", Message: "syntax error", Rule: "syntax"},
+		}},
+	}}
+	msg := &PreToolUseMessage{
+		BaseHookMessage: BaseHookMessage{HookEventName: PreToolUseEvent},
+		ToolName:        "Write",
+		ToolInput:       testConvertToRawMessage(map[string]interface{}{"file_path": "a.go", "content": "package a"}),
+	}
+
+	resp, err := engine.EvaluatePreToolUse(context.Background(), msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Reason != "在 a.go 中发现 1 个错误" {
+		t.Errorf("reason with LANG=zh_CN = %q", resp.Reason)
+	}
+
+	// The configured language wins over the locale
+	language := "ja"
+	engine.SetAppConfig(&AppConfig{Feedback: &FeedbackConfig{Language: &language}})
+	if resp, _ = engine.EvaluatePreToolUse(context.Background(), msg); resp.Reason != "a.go に 1 件のエラーがあります" {
+		t.Errorf("reason with language ja = %q", resp.Reason)
+	}
+
+	// Rule explanations are translated where the catalog has them
+	engine = NewLintingRuleEngine()
+	engine.SetAppConfig(&AppConfig{Feedback: &FeedbackConfig{Language: &language}})
+	descriptions := engine.ExplainRule("bidi-control")
+	if len(descriptions) != 1 || !strings.HasPrefix(descriptions[0].Description, "双方向制御文字") {
+		t.Errorf("ExplainRule(bidi-control) = %+v", descriptions)
+	}
+}
+
+func TestLintingRuleEngine_ContextLines(t *testing.T) {
+	engine := NewLintingRuleEngine()
+	engine.linters = []linters.Linter{&MockLinter{
+		canHandle: true,
+		result: &linters.LintResult{Issues: []linters.Issue{
+			{Line: 3, Column: 2, Severity: "error", Message: "undefined: x", Rule: "typecheck"},
+		}},
+	}}
+	var feedback bytes.Buffer
+	engine.SetFeedbackWriter(&feedback)
+	content := "package a\n\nfunc f() {\n\tx()\n}\n"
+	msg := &PreToolUseMessage{
+		BaseHookMessage: BaseHookMessage{HookEventName: PreToolUseEvent},
+		ToolName:        "Write",
+		ToolInput:       testConvertToRawMessage(map[string]interface{}{"file_path": "a.go", "content": content}),
+	}
+
+	// No source is shown by default
+	if _, err := engine.EvaluatePreToolUse(context.Background(), msg); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(feedback.String(), "func f()") {
+		t.Errorf("feedback shows source without contextLines:\n%s", feedback.String())
+	}
+
+	lines := 1
+	engine.SetAppConfig(&AppConfig{Feedback: &FeedbackConfig{ContextLines: &lines}})
+	feedback.Reset()
+	if _, err := engine.EvaluatePreToolUse(context.Background(), msg); err != nil {
+		t.Fatal(err)
+	}
+	want := "  2 | \n  > 3 | func f() {\n      |  ^\n    4 | \tx()"
+	if !strings.Contains(feedback.String(), want) {
+		t.Errorf("feedback missing source lines %q:\n%s", want, feedback.String())
+	}
+
+	// The engine setting wins over the config
+	engine.SetContextLines(2)
+	diagnostics, err := engine.LintFile(context.Background(), "a.go", []byte(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diagnostics) != 1 || diagnostics[0].SourceStart != 1 || len(diagnostics[0].SourceLines) != 5 {
+		t.Errorf("LintFile() source = %d %q, want lines 1-5", diagnostics[0].SourceStart, diagnostics[0].SourceLines)
+	}
+}
+
 func TestLintingRuleEngine_AddLinter(t *testing.T) {
 	engine := NewLintingRuleEngine()
 	initialCount := len(engine.linters)
@@ -172,6 +321,33 @@ func TestLintingRuleEngine_AddLinter(t *testing.T) {
 	}
 }
 
+func TestLintingRuleEngine_CustomLinters(t *testing.T) {
+	engine := NewLintingRuleEngine()
+	builtin := len(engine.LinterNames())
+
+	config := NewAppConfig()
+	config.CustomLinters = map[string]custom.Config{
+		"xyz":      {Command: "xyzlint {file}", Patterns: []string{"*.xyz"}},
+		"markdown": {Command: "mdlint {file}", Patterns: []string{"*.md"}},
+		"broken":   {Command: "", Patterns: []string{"*.b"}},
+	}
+	engine.SetAppConfig(config)
+
+	names := engine.LinterNames()
+	if len(names) != builtin+1 || !slices.Contains(names, "xyz") {
+		t.Errorf("linters = %v, want the built-ins and xyz only", names)
+	}
+
+	// Reconfiguring replaces the custom linters rather than adding to them
+	config = NewAppConfig()
+	config.CustomLinters = map[string]custom.Config{"abc": {Command: "abclint", Patterns: []string{"*.abc"}}}
+	engine.SetAppConfig(config)
+	names = engine.LinterNames()
+	if len(names) != builtin+1 || !slices.Contains(names, "abc") || slices.Contains(names, "xyz") {
+		t.Errorf("linters after reconfiguring = %v, want the built-ins and abc", names)
+	}
+}
+
 func TestLintingRuleEngine_OtherEvaluateMethods(t *testing.T) {
 	engine := NewLintingRuleEngine()
 	ctx := context.Background()
@@ -217,3 +393,263 @@ func TestLintingRuleEngine_OtherEvaluateMethods(t *testing.T) {
 		t.Errorf("EvaluatePreCompact() should return nil")
 	}
 }
+
+// recordingLinter records the content it was asked to lint
+type recordingLinter struct {
+	MockLinter
+	linted map[string]string
+}
+
+func (r *recordingLinter) Lint(ctx context.Context, filePath string, content []byte) (*linters.LintResult, error) {
+	r.linted[filePath] = string(content)
+	return &linters.LintResult{Success: true}, nil
+}
+
+func TestLintingRuleEngine_FileSystem(t *testing.T) {
+	fsys := linters.NewMemFileSystem()
+	_ = fsys.WriteFile("/proj/main.go", []byte("package main\n"), 0644)
+	_ = fsys.WriteFile("/proj/main_test.go", []byte("package main_test\n"), 0644)
+
+	engine := NewLintingRuleEngineWithConfig(LintingConfig{FileSystem: fsys})
+	recorder := &recordingLinter{
+		MockLinter: MockLinter{name: "recorder", canHandle: true},
+		linted:     make(map[string]string),
+	}
+	engine.linters = []linters.Linter{recorder}
+
+	msg := &PostToolUseMessage{
+		BaseHookMessage: BaseHookMessage{HookEventName: PostToolUseEvent},
+		ToolName:        "Write",
+		ToolInput:       testConvertToRawMessage(map[string]interface{}{"file_path": "/proj/main.go"}),
+	}
+	if _, err := engine.EvaluatePostToolUse(context.Background(), msg); err != nil {
+		t.Fatalf("EvaluatePostToolUse() error = %v", err)
+	}
+
+	if got := recorder.linted["/proj/main.go"]; got != "package main\n" {
+		t.Errorf("expected content from in-memory filesystem, got %q", got)
+	}
+	if got := recorder.linted["/proj/main_test.go"]; got != "package main_test\n" {
+		t.Errorf("expected associated test file from in-memory filesystem, got %q", got)
+	}
+}
+
+// syntaxLinter reports an error for every occurrence of "BROKEN" in the content
+type syntaxLinter struct {
+	MockLinter
+}
+
+func (s *syntaxLinter) Lint(ctx context.Context, filePath string, content []byte) (*linters.LintResult, error) {
+	result := &linters.LintResult{Success: true}
+	for i := 0; i < strings.Count(string(content), "BROKEN"); i++ {
+		result.Success = false
+		result.Issues = append(result.Issues, linters.Issue{
+			File:     filePath,
+			Line:     1,
+			Severity: "error",
+			Message:  "syntax error",
+			Rule:     "syntax",
+		})
+	}
+	return result, nil
+}
+
+func TestLintingRuleEngine_PreToolUseEdits(t *testing.T) {
+	tests := []struct {
+		name     string
+		onDisk   string
+		toolName string
+		input    map[string]interface{}
+		want     string
+	}{
+		{
+			name:     "edit introducing error is blocked",
+			onDisk:   "ok\n",
+			toolName: "Edit",
+			input:    map[string]interface{}{"old_string": "ok", "new_string": "BROKEN"},
+			want:     "block",
+		},
+		{
+			name:     "clean edit is approved",
+			onDisk:   "ok\n",
+			toolName: "Edit",
+			input:    map[string]interface{}{"old_string": "ok", "new_string": "fine"},
+			want:     "approve",
+		},
+		{
+			name:     "edit that does not apply is left to the tool",
+			onDisk:   "ok\n",
+			toolName: "Edit",
+			input:    map[string]interface{}{"old_string": "missing", "new_string": "BROKEN"},
+			want:     "approve",
+		},
+		{
+			name:     "pre-existing error does not block unrelated edit",
+			onDisk:   "BROKEN ok\n",
+			toolName: "Edit",
+			input:    map[string]interface{}{"old_string": "ok", "new_string": "fine"},
+			want:     "approve",
+		},
+		{
+			name:     "additional error on top of pre-existing one is blocked",
+			onDisk:   "BROKEN ok\n",
+			toolName: "Edit",
+			input:    map[string]interface{}{"old_string": "ok", "new_string": "BROKEN"},
+			want:     "block",
+		},
+		{
+			name:     "multiedit applies edits in sequence",
+			onDisk:   "a b\n",
+			toolName: "MultiEdit",
+			input: map[string]interface{}{
+				"edits": []map[string]interface{}{
+					{"old_string": "a", "new_string": "c"},
+					{"old_string": "c", "new_string": "BROKEN"},
+				},
+			},
+			want: "block",
+		},
+		{
+			name:     "edit spanning lines of a CRLF file is checked",
+			onDisk:   "ok\r\nmore\r\n",
+			toolName: "Edit",
+			input:    map[string]interface{}{"old_string": "ok\nmore", "new_string": "BROKEN\nmore"},
+			want:     "block",
+		},
+		{
+			name:     "multiedit on a CRLF file is checked",
+			onDisk:   "a\r\nb\r\n",
+			toolName: "MultiEdit",
+			input: map[string]interface{}{
+				"edits": []map[string]interface{}{
+					{"old_string": "a\nb", "new_string": "a\nc"},
+					{"old_string": "c", "new_string": "BROKEN"},
+				},
+			},
+			want: "block",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsys := linters.NewMemFileSystem()
+			_ = fsys.WriteFile("/proj/file.txt", []byte(tt.onDisk), 0644)
+
+			engine := NewLintingRuleEngineWithConfig(LintingConfig{FileSystem: fsys})
+			engine.linters = []linters.Linter{&syntaxLinter{MockLinter{name: "syntax", canHandle: true}}}
+
+			input := map[string]interface{}{"file_path": "/proj/file.txt"}
+			for k, v := range tt.input {
+				input[k] = v
+			}
+			msg := &PreToolUseMessage{
+				BaseHookMessage: BaseHookMessage{HookEventName: PreToolUseEvent},
+				ToolName:        tt.toolName,
+				ToolInput:       testConvertToRawMessage(input),
+			}
+
+			resp, err := engine.EvaluatePreToolUse(context.Background(), msg)
+			if err != nil {
+				t.Fatalf("EvaluatePreToolUse() error = %v", err)
+			}
+			if resp.Decision != tt.want {
+				t.Errorf("EvaluatePreToolUse() decision = %v, want %v", resp.Decision, tt.want)
+			}
+		})
+	}
+}
+
+// contextRecordingLinter records whether each run was static-only
+type contextRecordingLinter struct {
+	MockLinter
+	static []bool
+}
+
+func (c *contextRecordingLinter) Lint(ctx context.Context, filePath string, content []byte) (*linters.LintResult, error) {
+	c.static = append(c.static, linters.IsStaticOnly(ctx))
+	return &linters.LintResult{Success: true}, nil
+}
+
+func TestLintingRuleEngine_PreExistingErrorsRerunStaticOnly(t *testing.T) {
+	fsys := linters.NewMemFileSystem()
+	_ = fsys.WriteFile("/proj/file.txt", []byte("BROKEN ok\n"), 0644)
+
+	engine := NewLintingRuleEngineWithConfig(LintingConfig{FileSystem: fsys, DisableParallel: true})
+	clean := &contextRecordingLinter{MockLinter: MockLinter{name: "clean", canHandle: true}}
+	engine.linters = []linters.Linter{&syntaxLinter{MockLinter{name: "syntax", canHandle: true}}, clean}
+
+	msg := &PreToolUseMessage{
+		BaseHookMessage: BaseHookMessage{HookEventName: PreToolUseEvent},
+		ToolName:        "Edit",
+		ToolInput: testConvertToRawMessage(map[string]interface{}{
+			"file_path": "/proj/file.txt", "old_string": "ok", "new_string": "fine",
+		}),
+	}
+	resp, err := engine.EvaluatePreToolUse(context.Background(), msg)
+	if err != nil {
+		t.Fatalf("EvaluatePreToolUse() error = %v", err)
+	}
+	if resp.Decision != "approve" {
+		t.Errorf("decision = %v, want approve", resp.Decision)
+	}
+	// The linter without errors only checks the pending content
+	if len(clean.static) != 1 || clean.static[0] {
+		t.Errorf("clean linter runs = %v, want one full run", clean.static)
+	}
+}
+
+func TestLintingRuleEngine_BlockingLintersStaticOnly(t *testing.T) {
+	fsys := linters.NewMemFileSystem()
+	_ = fsys.WriteFile("/proj/file.txt", []byte("ok\n"), 0644)
+
+	engine := NewLintingRuleEngineWithConfig(LintingConfig{FileSystem: fsys})
+	recorder := &contextRecordingLinter{MockLinter: MockLinter{name: "recorder", canHandle: true}}
+	engine.linters = []linters.Linter{recorder}
+
+	introduced, _ := engine.splitPreExistingErrors(context.Background(), "/proj/file.txt",
+		[]linters.Issue{{Severity: "error", Rule: "test", Message: "Tests failed"}}, []linters.Linter{recorder})
+	if len(introduced) != 1 {
+		t.Errorf("introduced = %v, want the test failure", introduced)
+	}
+	if len(recorder.static) != 1 || !recorder.static[0] {
+		t.Errorf("re-run on original = %v, want one static-only run", recorder.static)
+	}
+}
+
+func TestLintingRuleEngine_ContentRouting(t *testing.T) {
+	fsys := linters.NewMemFileSystem()
+	engine := NewLintingRuleEngineWithConfig(LintingConfig{FileSystem: fsys})
+	shell := &MockLinter{
+		name:      "shell",
+		canHandle: false,
+		result: &linters.LintResult{Issues: []linters.Issue{
+			{File: "deploy", Line: 2, Severity: "error", Message: "unquoted variable", Rule: "SC2086"},
+		}},
+	}
+	engine.linters = []linters.Linter{shell}
+	engine.SetAppConfig(&AppConfig{Rules: []RuleOverride{
+		{FirstLine: "^#!/usr/bin/env bash", Linter: "shell", Route: true},
+	}})
+
+	write := func(content string) *HookResponse {
+		t.Helper()
+		response, err := engine.EvaluatePreToolUse(context.Background(), &PreToolUseMessage{
+			ToolName: "Write",
+			ToolInput: map[string]json.RawMessage{
+				"file_path": json.RawMessage(`"deploy"`),
+				"content":   json.RawMessage(strconv.Quote(content)),
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return response
+	}
+
+	if got := write("#!/usr/bin/env bash\necho $1\n"); got.Decision != "block" {
+		t.Errorf("routed script decision = %q, want block", got.Decision)
+	}
+	if got := write("plain notes\n"); got.Decision != "approve" {
+		t.Errorf("unrouted file decision = %q, want approve", got.Decision)
+	}
+}
@@ -0,0 +1,386 @@
+package gismo
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// mcpProtocolVersion is the Model Context Protocol revision the server speaks
+const mcpProtocolVersion = "2025-06-18"
+
+// defaultMaxProjectFiles bounds the files lint_project checks unless asked for more
+const defaultMaxProjectFiles = 200
+
+// JSON-RPC error codes used by the MCP server
+const (
+	rpcParseError     = -32700
+	rpcMethodNotFound = -32601
+	rpcInvalidParams  = -32602
+)
+
+// skippedProjectDirs are directories lint_project never descends into
+var skippedProjectDirs = map[string]bool{
+	"node_modules": true,
+	"vendor":       true,
+	"target":       true,
+	"__pycache__":  true,
+}
+
+// MCPServer exposes the linting engine as Model Context Protocol tools over
+// newline-delimited JSON-RPC, so Claude can lint before writing instead of
+// only being checked by hooks
+type MCPServer struct {
+	engine  *LintingRuleEngine
+	version string
+	tools   []mcpTool
+}
+
+// mcpTool is a tool the server offers, with the JSON Schema of its arguments
+type mcpTool struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	InputSchema json.RawMessage `json:"inputSchema"`
+	call        func(ctx context.Context, args json.RawMessage) (interface{}, error)
+}
+
+// mcpFileReport lists the issues found in one file
+type mcpFileReport struct {
+	File        string       `json:"file"`
+	Diagnostics []Diagnostic `json:"diagnostics"`
+}
+
+// rpcRequest is a JSON-RPC request, or a notification when ID is absent
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// rpcResponse is a JSON-RPC response carrying either Result or Error
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// rpcError is a JSON-RPC error object
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// NewMCPServer creates an MCP server for engine
+func NewMCPServer(engine *LintingRuleEngine, version string) *MCPServer {
+	s := &MCPServer{engine: engine, version: version}
+	s.tools = []mcpTool{
+		{
+			Name:        "lint_file",
+			Description: "Lint a file with gismo's linters and configuration. Pass content to check proposed contents before writing them.",
+			InputSchema: json.RawMessage(`{"type":"object","properties":{"path":{"type":"string","description":"File path, absolute or relative to the working directory"},"content":{"type":"string","description":"Contents to lint instead of the file on disk"}},"required":["path"]}`),
+			call:        s.lintFile,
+		},
+		{
+			Name:        "lint_project",
+			Description: "Lint every file under a directory that a gismo linter handles and report the files with issues.",
+			InputSchema: json.RawMessage(`{"type":"object","properties":{"path":{"type":"string","description":"Directory to lint, default the working directory"},"max_files":{"type":"integer","minimum":1,"description":"Maximum number of files to lint, default 200"}}}`),
+			call:        s.lintProject,
+		},
+		{
+			Name:        "explain_rule",
+			Description: "Explain a rule name reported in a gismo issue, such as bidi-control or line-length.",
+			InputSchema: json.RawMessage(`{"type":"object","properties":{"rule":{"type":"string","description":"Rule name from an issue"}},"required":["rule"]}`),
+			call:        s.explainRule,
+		},
+		{
+			Name:        "get_config_for_path",
+			Description: "Show which linters check a file and their configuration after rule overrides for that path.",
+			InputSchema: json.RawMessage(`{"type":"object","properties":{"path":{"type":"string","description":"File path, absolute or relative to the working directory"}},"required":["path"]}`),
+			call:        s.configForPath,
+		},
+	}
+	return s
+}
+
+// Serve reads JSON-RPC messages from r, one per line, and writes responses to w
+// until r is exhausted or ctx is cancelled
+func (s *MCPServer) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxHookRequestBytes)
+	encoder := json.NewEncoder(w)
+	for scanner.Scan() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if response := s.handle(ctx, []byte(line)); response != nil {
+			if err := encoder.Encode(response); err != nil {
+				return fmt.Errorf("failed to write response: %w", err)
+			}
+		}
+	}
+	return scanner.Err()
+}
+
+// handle processes one JSON-RPC message and returns its response, or nil for notifications
+func (s *MCPServer) handle(ctx context.Context, data []byte) *rpcResponse {
+	var req rpcRequest
+	if err := json.Unmarshal(data, &req); err != nil {
+		return &rpcResponse{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &rpcError{Code: rpcParseError, Message: err.Error()}}
+	}
+	if len(req.ID) == 0 {
+		return nil
+	}
+
+	response := &rpcResponse{JSONRPC: "2.0", ID: req.ID}
+	switch req.Method {
+	case "initialize":
+		response.Result = map[string]interface{}{
+			"protocolVersion": mcpProtocolVersion,
+			"capabilities":    map[string]interface{}{"tools": map[string]interface{}{}},
+			"serverInfo":      map[string]string{"name": "gismo", "version": s.version},
+		}
+	case "ping":
+		response.Result = map[string]interface{}{}
+	case "tools/list":
+		response.Result = map[string]interface{}{"tools": s.tools}
+	case "tools/call":
+		result, err := s.callTool(ctx, req.Params)
+		if err != nil {
+			response.Error = &rpcError{Code: rpcInvalidParams, Message: err.Error()}
+		} else {
+			response.Result = result
+		}
+	default:
+		response.Error = &rpcError{Code: rpcMethodNotFound, Message: fmt.Sprintf("method not found: %s", req.Method)}
+	}
+	return response
+}
+
+// callTool runs the tool named in params. Failures of the tool itself are
+// reported in the result, so the model sees them, rather than as protocol errors.
+func (s *MCPServer) callTool(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	var call struct {
+		Name      string          `json:"name"`
+		Arguments json.RawMessage `json:"arguments"`
+	}
+	if err := json.Unmarshal(params, &call); err != nil {
+		return nil, fmt.Errorf("invalid tools/call params: %w", err)
+	}
+	for _, tool := range s.tools {
+		if tool.Name != call.Name {
+			continue
+		}
+		if len(call.Arguments) == 0 {
+			call.Arguments = json.RawMessage("{}")
+		}
+		output, err := tool.call(ctx, call.Arguments)
+		if err != nil {
+			return mcpToolResult(err.Error(), nil, true), nil
+		}
+		text, err := json.MarshalIndent(output, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return mcpToolResult(string(text), output, false), nil
+	}
+	return nil, fmt.Errorf("unknown tool: %s", call.Name)
+}
+
+// mcpToolResult builds a tools/call result with text content
+func mcpToolResult(text string, structured interface{}, isError bool) map[string]interface{} {
+	result := map[string]interface{}{
+		"content": []map[string]string{{"type": "text", "text": text}},
+		"isError": isError,
+	}
+	if structured != nil {
+		result["structuredContent"] = structured
+	}
+	return result
+}
+
+// lintFile implements the lint_file tool
+func (s *MCPServer) lintFile(ctx context.Context, args json.RawMessage) (interface{}, error) {
+	var input struct {
+		Path    string  `json:"path"`
+		Content *string `json:"content"`
+	}
+	if err := json.Unmarshal(args, &input); err != nil || input.Path == "" {
+		return nil, errors.New("lint_file needs a path")
+	}
+	path, err := filepath.Abs(input.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	var content []byte
+	if input.Content != nil {
+		content = []byte(*input.Content)
+	} else if content, err = os.ReadFile(path); err != nil { // #nosec G304 - path chosen by the MCP client
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	diagnostics, err := s.engine.LintFile(ctx, path, content)
+	if err != nil {
+		return nil, err
+	}
+	return mcpFileReport{File: path, Diagnostics: diagnostics}, nil
+}
+
+// lintProject implements the lint_project tool
+func (s *MCPServer) lintProject(ctx context.Context, args json.RawMessage) (interface{}, error) {
+	var input struct {
+		Path     string `json:"path"`
+		MaxFiles int    `json:"max_files"`
+	}
+	if err := json.Unmarshal(args, &input); err != nil {
+		return nil, fmt.Errorf("invalid lint_project arguments: %w", err)
+	}
+	if input.Path == "" {
+		input.Path = "."
+	}
+	if input.MaxFiles <= 0 {
+		input.MaxFiles = defaultMaxProjectFiles
+	}
+	root, err := filepath.Abs(input.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	files, truncated, err := s.projectFiles(root, input.MaxFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	report := struct {
+		Root      string          `json:"root"`
+		Checked   int             `json:"checked"`
+		Truncated bool            `json:"truncated,omitempty"`
+		Errors    int             `json:"errors"`
+		Warnings  int             `json:"warnings"`
+		Files     []mcpFileReport `json:"files"`
+	}{Root: root, Truncated: truncated, Files: []mcpFileReport{}}
+
+	for _, path := range files {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		content, err := os.ReadFile(path) // #nosec G304 - path found under the requested directory
+		if err != nil {
+			continue
+		}
+		diagnostics, err := s.engine.LintFile(ctx, path, content)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		report.Checked++
+		if len(diagnostics) == 0 {
+			continue
+		}
+		for _, diagnostic := range diagnostics {
+			if diagnostic.Severity == "error" {
+				report.Errors++
+			} else {
+				report.Warnings++
+			}
+		}
+		report.Files = append(report.Files, mcpFileReport{File: path, Diagnostics: diagnostics})
+	}
+	return report, nil
+}
+
+// projectFiles lists up to maxFiles files under root that a linter handles, skipping
+// hidden and dependency directories. It reports whether files were left out.
+func (s *MCPServer) projectFiles(root string, maxFiles int) ([]string, bool, error) {
+	var files []string
+	truncated := false
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			name := entry.Name()
+			if path != root && (strings.HasPrefix(name, ".") || skippedProjectDirs[name]) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !s.handles(path) {
+			return nil
+		}
+		if len(files) == maxFiles {
+			truncated = true
+			return filepath.SkipAll
+		}
+		files = append(files, path)
+		return nil
+	})
+	return files, truncated, err
+}
+
+// handles reports whether any active linter handles filePath
+func (s *MCPServer) handles(filePath string) bool {
+	for _, linter := range s.engine.lintersFor(filePath) {
+		if linter.CanHandle(filePath) {
+			return true
+		}
+	}
+	return false
+}
+
+// explainRule implements the explain_rule tool
+func (s *MCPServer) explainRule(_ context.Context, args json.RawMessage) (interface{}, error) {
+	var input struct {
+		Rule string `json:"rule"`
+	}
+	if err := json.Unmarshal(args, &input); err != nil || input.Rule == "" {
+		return nil, errors.New("explain_rule needs a rule")
+	}
+	descriptions := s.engine.ExplainRule(input.Rule)
+	if len(descriptions) == 0 {
+		return nil, fmt.Errorf("no gismo linter defines rule %q; it may come from an external tool such as golangci-lint, ruff or clippy, whose documentation describes it", input.Rule)
+	}
+	return map[string]interface{}{"rule": input.Rule, "definitions": descriptions}, nil
+}
+
+// configForPath implements the get_config_for_path tool
+func (s *MCPServer) configForPath(_ context.Context, args json.RawMessage) (interface{}, error) {
+	var input struct {
+		Path string `json:"path"`
+	}
+	if err := json.Unmarshal(args, &input); err != nil || input.Path == "" {
+		return nil, errors.New("get_config_for_path needs a path")
+	}
+	path, err := filepath.Abs(input.Path)
+	if err != nil {
+		return nil, err
+	}
+	configs, err := s.engine.LinterConfigsForPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(configs))
+	for name := range configs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return struct {
+		Path    string                     `json:"path"`
+		Linters []string                   `json:"linters"`
+		Configs map[string]json.RawMessage `json:"configs"`
+	}{Path: path, Linters: names, Configs: configs}, nil
+}
@@ -0,0 +1,167 @@
+package gismo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// mcpCall sends one request line to the server and decodes its response
+func mcpCall(t *testing.T, server *MCPServer, method string, params interface{}) rpcResponse {
+	t.Helper()
+	request, _ := json.Marshal(map[string]interface{}{"jsonrpc": "2.0", "id": 1, "method": method, "params": params})
+	var out bytes.Buffer
+	if err := server.Serve(context.Background(), bytes.NewReader(request), &out); err != nil {
+		t.Fatalf("Serve() error = %v", err)
+	}
+	var response struct {
+		rpcResponse
+		Result json.RawMessage `json:"result"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &response); err != nil {
+		t.Fatalf("invalid response %q: %v", out.String(), err)
+	}
+	response.rpcResponse.Result = response.Result
+	return response.rpcResponse
+}
+
+// toolText returns the text content of a tools/call result and whether it is an error
+func toolText(t *testing.T, response rpcResponse) (string, bool) {
+	t.Helper()
+	if response.Error != nil {
+		t.Fatalf("tools/call error = %+v", response.Error)
+	}
+	var result struct {
+		Content []struct {
+			Text string `json:"text"`
+		} `json:"content"`
+		IsError bool `json:"isError"`
+	}
+	if err := json.Unmarshal(response.Result.(json.RawMessage), &result); err != nil || len(result.Content) != 1 {
+		t.Fatalf("invalid tool result %s: %v", response.Result, err)
+	}
+	return result.Content[0].Text, result.IsError
+}
+
+func TestMCPServer_Protocol(t *testing.T) {
+	server := NewMCPServer(NewLintingRuleEngine(), "test")
+
+	input := strings.Join([]string{
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-06-18"}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`,
+		`{"jsonrpc":"2.0","id":3,"method":"resources/list"}`,
+	}, "\n")
+	var out bytes.Buffer
+	if err := server.Serve(context.Background(), strings.NewReader(input), &out); err != nil {
+		t.Fatalf("Serve() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d responses, want 3 (notifications get none):\n%s", len(lines), out.String())
+	}
+	if !strings.Contains(lines[0], `"protocolVersion":"2025-06-18"`) || !strings.Contains(lines[0], `"name":"gismo"`) {
+		t.Errorf("initialize = %s", lines[0])
+	}
+	for _, tool := range []string{"lint_file", "lint_project", "explain_rule", "get_config_for_path"} {
+		if !strings.Contains(lines[1], `"name":"`+tool+`"`) {
+			t.Errorf("tools/list is missing %s: %s", tool, lines[1])
+		}
+	}
+	if !strings.Contains(lines[2], `"code":-32601`) {
+		t.Errorf("unknown method = %s, want method not found", lines[2])
+	}
+}
+
+func TestMCPServer_LintTools(t *testing.T) {
+	dir := t.TempDir()
+	clean := filepath.Join(dir, "clean.md")
+	if err := os.WriteFile(clean, []byte("# Title\n\nText.\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "node_modules"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "node_modules", "skipped.md"), []byte("# A\n\n# B\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	server := NewMCPServer(NewLintingRuleEngine(), "test")
+
+	// Proposed content is linted instead of the file on disk
+	text, isError := toolText(t, mcpCall(t, server, "tools/call", map[string]interface{}{
+		"name":      "lint_file",
+		"arguments": map[string]string{"path": clean, "content": "# A\n\n# B\n"},
+	}))
+	if isError || !strings.Contains(text, "heading-hierarchy") {
+		t.Errorf("lint_file with content = %s", text)
+	}
+
+	text, isError = toolText(t, mcpCall(t, server, "tools/call", map[string]interface{}{
+		"name":      "lint_project",
+		"arguments": map[string]string{"path": dir},
+	}))
+	var report struct {
+		Checked int `json:"checked"`
+	}
+	if err := json.Unmarshal([]byte(text), &report); isError || err != nil || report.Checked != 1 {
+		t.Errorf("lint_project = %s, want 1 file checked outside node_modules", text)
+	}
+
+	if _, isError = toolText(t, mcpCall(t, server, "tools/call", map[string]interface{}{
+		"name":      "lint_file",
+		"arguments": map[string]string{"path": filepath.Join(dir, "missing.md")},
+	})); !isError {
+		t.Error("lint_file of a missing file should be a tool error")
+	}
+
+	if response := mcpCall(t, server, "tools/call", map[string]interface{}{"name": "no_such_tool"}); response.Error == nil {
+		t.Error("unknown tool should be a protocol error")
+	}
+}
+
+func TestMCPServer_ExplainAndConfig(t *testing.T) {
+	engine := NewLintingRuleEngine()
+	config := NewAppConfig()
+	config.Linters = map[string]LinterConfig{
+		"markdown": {Config: json.RawMessage(`{"maxLineLength": 100, "maxBlankLines": 3}`)},
+	}
+	config.Rules = []RuleOverride{
+		{Pattern: "guide*.md", Linter: "markdown", Rules: json.RawMessage(`{"maxLineLength": 200}`)},
+	}
+	engine.SetAppConfig(config)
+	server := NewMCPServer(engine, "test")
+
+	text, isError := toolText(t, mcpCall(t, server, "tools/call", map[string]interface{}{
+		"name":      "explain_rule",
+		"arguments": map[string]string{"rule": "bidi-control"},
+	}))
+	if isError || !strings.Contains(text, `"linter": "unicode"`) {
+		t.Errorf("explain_rule(bidi-control) = %s", text)
+	}
+	if text, isError = toolText(t, mcpCall(t, server, "tools/call", map[string]interface{}{
+		"name":      "explain_rule",
+		"arguments": map[string]string{"rule": "errcheck"},
+	})); !isError || !strings.Contains(text, "external tool") {
+		t.Errorf("explain_rule(errcheck) = %s, want an error pointing at external tools", text)
+	}
+
+	text, isError = toolText(t, mcpCall(t, server, "tools/call", map[string]interface{}{
+		"name":      "get_config_for_path",
+		"arguments": map[string]string{"path": "docs/guide.md"},
+	}))
+	var result struct {
+		Configs map[string]map[string]int `json:"configs"`
+	}
+	if err := json.Unmarshal([]byte(text), &result); isError || err != nil {
+		t.Fatalf("get_config_for_path = %s (%v)", text, err)
+	}
+	if markdown := result.Configs["markdown"]; markdown["maxLineLength"] != 200 || markdown["maxBlankLines"] != 3 {
+		t.Errorf("markdown config = %v, want the override merged onto the base config", markdown)
+	}
+}
@@ -44,7 +44,10 @@ type PostToolUseMessage struct {
 	ToolName   string                     `json:"tool_name"`
 	ToolInput  map[string]json.RawMessage `json:"tool_input"`
 	ToolOutput json.RawMessage            `json:"tool_output,omitempty"`
-	ToolError  string                     `json:"tool_error,omitempty"`
+	// ToolResponse is the tool's result as Claude Code reports it, such as the
+	// original content of a written file
+	ToolResponse json.RawMessage `json:"tool_response,omitempty"`
+	ToolError    string          `json:"tool_error,omitempty"`
 }
 
 func (m PostToolUseMessage) GetBaseMessage() BaseHookMessage { return m.BaseHookMessage }
@@ -99,6 +102,10 @@ type HookResponse struct {
 	Decision       string `json:"decision,omitempty"` // For PreToolUse: "block" or "approve"
 	Reason         string `json:"reason,omitempty"`   // For PreToolUse: reason for decision
 	Message        string `json:"message,omitempty"`  // User-visible message
+
+	// NoCache marks responses that depend on session state rather than the tool
+	// input, so the decision cache must not reuse them for a retry
+	NoCache bool `json:"-"`
 }
 
 // ExitCode represents the hook exit status
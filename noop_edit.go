@@ -0,0 +1,99 @@
+package gismo
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+)
+
+// editChange classifies what a Write, Edit or MultiEdit changed in a file
+type editChange int
+
+const (
+	// editChanged is an edit that changed the file's content
+	editChanged editChange = iota
+	// editUnchanged is an edit that left the file exactly as it was
+	editUnchanged
+	// editWhitespaceOnly is an edit that only changed whitespace
+	editWhitespaceOnly
+)
+
+// writeToolResponse is the part of Claude Code's Write, Edit and MultiEdit
+// results used to tell what the tool changed
+type writeToolResponse struct {
+	// Type is "create" for a new file and "update" for an existing one
+	Type string `json:"type"`
+	// OriginalFile is the file's content before the write
+	OriginalFile *string `json:"originalFile"`
+	// StructuredPatch holds the changed hunks, empty when nothing changed
+	StructuredPatch *[]json.RawMessage `json:"structuredPatch"`
+}
+
+// classifyEdit reports whether a file tool call changed nothing or only
+// whitespace. Edits compare their old and new strings; writes compare the new
+// content with the original file Claude Code reports in the tool response.
+func classifyEdit(msg *PostToolUseMessage) editChange {
+	input, err := ParseToolInput(msg.ToolName, msg.ToolInput)
+	if err != nil {
+		return editChanged
+	}
+	switch input := input.(type) {
+	case EditToolInput:
+		return compareEdit(input.OldString, input.NewString)
+	case MultiEditToolInput:
+		if len(input.Edits) == 0 {
+			return editChanged
+		}
+		change := editUnchanged
+		for _, edit := range input.Edits {
+			switch compareEdit(edit.OldString, edit.NewString) {
+			case editChanged:
+				return editChanged
+			case editWhitespaceOnly:
+				change = editWhitespaceOnly
+			}
+		}
+		return change
+	case WriteToolInput:
+		response := msg.ToolResponse
+		if len(response) == 0 {
+			response = msg.ToolOutput
+		}
+		var write writeToolResponse
+		if err := json.Unmarshal(response, &write); err != nil {
+			return editChanged
+		}
+		if write.OriginalFile != nil {
+			return compareEdit(*write.OriginalFile, input.Content)
+		}
+		if write.Type == "update" && write.StructuredPatch != nil && len(*write.StructuredPatch) == 0 {
+			return editUnchanged
+		}
+	}
+	return editChanged
+}
+
+// compareEdit classifies replacing before with after
+func compareEdit(before, after string) editChange {
+	switch {
+	case before == after:
+		return editUnchanged
+	case slices.Equal(strings.Fields(before), strings.Fields(after)):
+		return editWhitespaceOnly
+	}
+	return editChanged
+}
+
+// editChangeNote returns the feedback for an edit that changed nothing, or only
+// whitespace when the project forbids that, and "" otherwise
+func (e *LintingRuleEngine) editChangeNote(change editChange, filePath string) string {
+	switch change {
+	case editUnchanged:
+		return e.messages.Sprintf("edit.unchanged", filePath)
+	case editWhitespaceOnly:
+		if e.config.IsWhitespaceEditForbidden() {
+			return e.messages.Sprintf("edit.whitespace_only", filePath)
+		}
+	}
+	return ""
+}
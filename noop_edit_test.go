@@ -0,0 +1,131 @@
+package gismo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+func TestClassifyEdit(t *testing.T) {
+	tests := []struct {
+		name     string
+		tool     string
+		input    map[string]interface{}
+		response string
+		want     editChange
+	}{
+		{
+			name:  "edit changing code",
+			tool:  "Edit",
+			input: map[string]interface{}{"file_path": "a.go", "old_string": "x := 1", "new_string": "x := 2"},
+			want:  editChanged,
+		},
+		{
+			name:  "edit reindenting",
+			tool:  "Edit",
+			input: map[string]interface{}{"file_path": "a.go", "old_string": "\tx := 1\n", "new_string": "    x := 1  \n\n"},
+			want:  editWhitespaceOnly,
+		},
+		{
+			name:  "edit joining tokens",
+			tool:  "Edit",
+			input: map[string]interface{}{"file_path": "a.go", "old_string": "a b", "new_string": "ab"},
+			want:  editChanged,
+		},
+		{
+			name: "multi-edit with one real change",
+			tool: "MultiEdit",
+			input: map[string]interface{}{"file_path": "a.go", "edits": []map[string]string{
+				{"old_string": "a ", "new_string": "a"},
+				{"old_string": "b", "new_string": "c"},
+			}},
+			want: editChanged,
+		},
+		{
+			name:     "write of the original content",
+			tool:     "Write",
+			input:    map[string]interface{}{"file_path": "a.go", "content": "package a\n"},
+			response: `{"type": "update", "filePath": "a.go", "content": "package a\n", "originalFile": "package a\n"}`,
+			want:     editUnchanged,
+		},
+		{
+			name:     "write with an empty patch",
+			tool:     "Write",
+			input:    map[string]interface{}{"file_path": "a.go", "content": "package a\n"},
+			response: `{"type": "update", "filePath": "a.go", "structuredPatch": []}`,
+			want:     editUnchanged,
+		},
+		{
+			name:     "write creating a file",
+			tool:     "Write",
+			input:    map[string]interface{}{"file_path": "a.go", "content": "package a\n"},
+			response: `{"type": "create", "filePath": "a.go", "structuredPatch": []}`,
+			want:     editChanged,
+		},
+		{
+			name:  "write without a response",
+			tool:  "Write",
+			input: map[string]interface{}{"file_path": "a.go", "content": "package a\n"},
+			want:  editChanged,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := &PostToolUseMessage{ToolName: tt.tool, ToolInput: testConvertToRawMessage(tt.input)}
+			if tt.response != "" {
+				msg.ToolResponse = json.RawMessage(tt.response)
+			}
+			if got := classifyEdit(msg); got != tt.want {
+				t.Errorf("classifyEdit() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLintingRuleEngine_NoOpEditFeedback(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "a.go")
+	if err := os.WriteFile(filePath, []byte("package a\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	linter := &MockLinter{canHandle: true, result: &linters.LintResult{Success: true}}
+	engine := NewLintingRuleEngine()
+	engine.linters = []linters.Linter{linter}
+	var feedback bytes.Buffer
+	engine.SetFeedbackWriter(&feedback)
+
+	post := func(oldString, newString string) string {
+		feedback.Reset()
+		msg := &PostToolUseMessage{
+			BaseHookMessage: BaseHookMessage{HookEventName: PostToolUseEvent},
+			ToolName:        "Edit",
+			ToolInput:       testConvertToRawMessage(map[string]interface{}{"file_path": filePath, "old_string": oldString, "new_string": newString}),
+		}
+		if _, err := engine.EvaluatePostToolUse(context.Background(), msg); err != nil {
+			t.Fatal(err)
+		}
+		return feedback.String()
+	}
+
+	// Unchanged content is reported without linting it again
+	if got := post("package a", "package a"); !strings.Contains(got, "unchanged") || strings.Contains(got, "Style clean") {
+		t.Errorf("unchanged edit feedback:\n%s", got)
+	}
+
+	// Whitespace-only edits are linted, and reported only when the project forbids them
+	if got := post("package  a", "package a"); strings.Contains(got, "whitespace") || !strings.Contains(got, "Style clean") {
+		t.Errorf("whitespace edit feedback by default:\n%s", got)
+	}
+	forbid := true
+	engine.SetAppConfig(&AppConfig{Feedback: &FeedbackConfig{ForbidWhitespaceEdits: &forbid}})
+	engine.linters = []linters.Linter{linter}
+	if got := post("package  a", "package a"); !strings.Contains(got, "only changed whitespace") || !strings.Contains(got, "Style clean") {
+		t.Errorf("forbidden whitespace edit feedback:\n%s", got)
+	}
+}
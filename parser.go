@@ -4,12 +4,15 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"strings"
 
 	json "github.com/goccy/go-json"
 )
 
 // Parser handles high-performance JSON parsing of hook messages
 type Parser struct {
+	// debug receives notes about tolerated oddities such as unknown fields
+	debug io.Writer
 }
 
 // NewParser creates a new parser instance
@@ -17,13 +20,37 @@ func NewParser() *Parser {
 	return &Parser{}
 }
 
-// ParseHookMessage parses a generic hook message to determine its type
+// SetDebugOutput sets where notes about ignored unknown fields are written; nil disables them
+func (p *Parser) SetDebugOutput(w io.Writer) {
+	p.debug = w
+}
+
+// ParseHookMessage parses a generic hook message to determine its type. Required
+// fields and field types are validated, so a schema mismatch names the offending
+// field; unknown fields are ignored for forward compatibility.
 func (p *Parser) ParseHookMessage(data []byte) (HookMessage, error) {
-	// First, parse just the base message to get the event type
+	// First, parse the top-level fields to validate them against the event
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse base message: %w", err)
+	}
 	var base BaseHookMessage
-	if err := json.Unmarshal(data, &base); err != nil {
+	if value, ok := raw["hook_event_name"]; !ok || jsonType(value) != "string" {
+		return nil, fmt.Errorf("failed to parse base message: field %q must be a string naming the hook event", "hook_event_name")
+	} else if err := json.Unmarshal(value, &base.HookEventName); err != nil {
 		return nil, fmt.Errorf("failed to parse base message: %w", err)
 	}
+	if _, ok := hookEventFields[base.HookEventName]; !ok {
+		return nil, fmt.Errorf("unknown hook event type: %s", base.HookEventName)
+	}
+
+	unknown, err := validateHookFields(base.HookEventName, raw)
+	if err != nil {
+		return nil, err
+	}
+	if len(unknown) > 0 && p.debug != nil {
+		fmt.Fprintf(p.debug, "Debug: ignoring unknown %s fields: %s\n", base.HookEventName, strings.Join(unknown, ", "))
+	}
 
 	// Parse the specific message type based on the event
 	switch base.HookEventName {
@@ -279,3 +279,126 @@ func BenchmarkStreamParser(b *testing.B) {
 		}
 	}
 }
+
+func TestParseHookMessage_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{
+			name:    "missing event name",
+			input:   `{"session_id": "s", "tool_name": "Write"}`,
+			wantErr: `field "hook_event_name" must be a string`,
+		},
+		{
+			name:    "missing required field",
+			input:   `{"hook_event_name": "PreToolUse", "session_id": "s"}`,
+			wantErr: `PreToolUse message is missing required field "tool_name"`,
+		},
+		{
+			name:    "null required field",
+			input:   `{"hook_event_name": "PostToolUse", "tool_name": null}`,
+			wantErr: `PostToolUse message is missing required field "tool_name"`,
+		},
+		{
+			name:    "wrong field type",
+			input:   `{"hook_event_name": "PreToolUse", "tool_name": "Write", "tool_input": "file.go"}`,
+			wantErr: `PreToolUse message field "tool_input" must be an object, got string`,
+		},
+		{
+			name:    "fractional integer",
+			input:   `{"hook_event_name": "PreCompact", "current_tokens": 1.5}`,
+			wantErr: `PreCompact message field "current_tokens" must be an integer`,
+		},
+		{
+			name:  "unknown fields are tolerated",
+			input: `{"hook_event_name": "PostToolUse", "tool_name": "Edit", "cwd": "/src", "tool_response": {"success": true}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewParser().ParseHookMessage([]byte(tt.input))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("ParseHookMessage() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseHookMessage() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseHookMessage_UnknownFieldsDebugNote(t *testing.T) {
+	var debug bytes.Buffer
+	parser := NewParser()
+	parser.SetDebugOutput(&debug)
+
+	msg, err := parser.ParseHookMessage([]byte(`{"hook_event_name": "Stop", "stop_hook_active": false, "cwd": "/src"}`))
+	if err != nil {
+		t.Fatalf("ParseHookMessage() error = %v", err)
+	}
+	if msg.EventName() != StopEvent {
+		t.Errorf("EventName() = %s, want Stop", msg.EventName())
+	}
+	if got := debug.String(); got != "Debug: ignoring unknown Stop fields: cwd, stop_hook_active\n" {
+		t.Errorf("debug note = %q", got)
+	}
+}
+
+func TestHookMessageSchemas(t *testing.T) {
+	schemas := HookMessageSchemas()
+	if len(schemas) != len(HookEvents()) {
+		t.Fatalf("got %d schemas for %d events", len(schemas), len(HookEvents()))
+	}
+	var schema struct {
+		Properties map[string]struct {
+			Const string `json:"const"`
+		} `json:"properties"`
+		AdditionalProperties bool `json:"additionalProperties"`
+	}
+	if err := json.Unmarshal(schemas[PostToolUseEvent], &schema); err != nil {
+		t.Fatalf("schema is not JSON: %v", err)
+	}
+	if schema.Properties["hook_event_name"].Const != "PostToolUse" || !schema.AdditionalProperties {
+		t.Errorf("unexpected PostToolUse schema: %s", schemas[PostToolUseEvent])
+	}
+	if _, err := HookMessageSchema("Bogus"); err == nil {
+		t.Error("expected error for unknown event")
+	}
+}
+
+func FuzzParseHookMessage(f *testing.F) {
+	f.Add([]byte(`{"hook_event_name":"PreToolUse","session_id":"s","tool_name":"Write","tool_input":{"file_path":"main.go","content":"package main\n"}}`))
+	f.Add([]byte(`{"hook_event_name":"PostToolUse","session_id":"s","tool_name":"Edit","tool_output":{"result":"ok"}}`))
+	f.Add([]byte(`{"hook_event_name":"Stop","session_id":"s","stop_hook_active":true}` + "\n" + `{"hook_event_name":"Notification","message":"hi"}`))
+	f.Add([]byte(`{"hook_event_name":"PreCompact","trigger":1}`))
+	f.Add([]byte(`{"hook_event_name":null}`))
+	f.Add([]byte(`[]`))
+	parser := NewParser()
+	f.Fuzz(func(t *testing.T, data []byte) {
+		messages, err := splitHookMessages(data)
+		if err != nil {
+			return
+		}
+		for _, raw := range messages {
+			msg, err := parser.ParseHookMessage(raw)
+			if err != nil {
+				continue
+			}
+			if msg == nil {
+				t.Fatal("ParseHookMessage() = nil without an error")
+			}
+			if _, ok := hookEventFields[msg.EventName()]; !ok {
+				t.Fatalf("ParseHookMessage() accepted unknown event %q", msg.EventName())
+			}
+			if msg.GetBaseMessage().HookEventName != msg.EventName() {
+				t.Fatalf("event name %q does not match message type %q", msg.GetBaseMessage().HookEventName, msg.EventName())
+			}
+		}
+	})
+}
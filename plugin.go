@@ -0,0 +1,157 @@
+package gismo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// WASM plugins are rule engines compiled to WebAssembly, loaded from the plugins
+// section of the config and run after the built-in LintingRuleEngine.
+//
+// The plugin ABI: a module exports its linear memory as "memory" and an
+// allocator
+//
+//	gismo_alloc(size i32) i32
+//
+// returning a pointer to size bytes the host writes the input to. The host
+// calls it before every export and never frees the input, so a plugin may hand
+// out one reused buffer. Each hook event the plugin evaluates is an export
+// taking the hook message as JSON
+//
+//	gismo_pre_tool_use(ptr i32, len i32) i64
+//	gismo_post_tool_use, gismo_notification, gismo_stop,
+//	gismo_subagent_stop, gismo_pre_compact
+//
+// returning the pointer of a JSON HookResponse in the upper 32 bits and its
+// length in the lower 32 bits, or 0 for no response. Events a module doesn't
+// export get no response from it.
+
+// pluginExports maps hook events to the export evaluating them
+var pluginExports = map[HookEventName]string{
+	PreToolUseEvent:   "gismo_pre_tool_use",
+	PostToolUseEvent:  "gismo_post_tool_use",
+	NotificationEvent: "gismo_notification",
+	StopEvent:         "gismo_stop",
+	SubagentStopEvent: "gismo_subagent_stop",
+	PreCompactEvent:   "gismo_pre_compact",
+}
+
+// PluginConfig declares a WASM plugin in the plugins section of gismo.json
+type PluginConfig struct {
+	// Path is the .wasm module, relative to the working directory
+	Path string `json:"path"`
+}
+
+// pluginModule is an instantiated plugin module
+type pluginModule interface {
+	// call runs export with input and returns its output, nil for no response.
+	// ok is false if the module doesn't export it.
+	call(ctx context.Context, export string, input []byte) (output []byte, ok bool, err error)
+	close(ctx context.Context) error
+}
+
+// PluginRuleEngine evaluates hooks with a WASM plugin
+type PluginRuleEngine struct {
+	name string
+	// A module instance runs one call at a time
+	mu     sync.Mutex
+	module pluginModule
+}
+
+// NewPluginRuleEngine loads the WASM plugin at path
+func NewPluginRuleEngine(ctx context.Context, path string) (*PluginRuleEngine, error) {
+	wasm, err := os.ReadFile(path) // #nosec G304 - plugin path from the user's config
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plugin %s: %w", path, err)
+	}
+	module, err := openPluginModule(ctx, wasm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load plugin %s: %w", path, err)
+	}
+	return &PluginRuleEngine{name: strings.TrimSuffix(filepath.Base(path), ".wasm"), module: module}, nil
+}
+
+// LoadPlugins loads the plugins in configs, in order
+func LoadPlugins(ctx context.Context, configs []PluginConfig) ([]*PluginRuleEngine, error) {
+	var plugins []*PluginRuleEngine
+	for _, config := range configs {
+		plugin, err := NewPluginRuleEngine(ctx, config.Path)
+		if err != nil {
+			for _, loaded := range plugins {
+				_ = loaded.Close(ctx)
+			}
+			return nil, err
+		}
+		plugins = append(plugins, plugin)
+	}
+	return plugins, nil
+}
+
+// Name returns the plugin's file name without the .wasm extension
+func (p *PluginRuleEngine) Name() string {
+	return p.name
+}
+
+// Close releases the plugin's module
+func (p *PluginRuleEngine) Close(ctx context.Context) error {
+	return p.module.close(ctx)
+}
+
+// evaluate passes msg to the plugin's export for event
+func (p *PluginRuleEngine) evaluate(ctx context.Context, event HookEventName, msg interface{}) (*HookResponse, error) {
+	input, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	output, ok, err := p.module.call(ctx, pluginExports[event], input)
+	p.mu.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("plugin %s failed on %s: %w", p.name, event, err)
+	}
+	if !ok || len(output) == 0 {
+		return nil, nil
+	}
+
+	var response HookResponse
+	if err := json.Unmarshal(output, &response); err != nil {
+		return nil, fmt.Errorf("plugin %s returned an invalid %s response: %w", p.name, event, err)
+	}
+	return &response, nil
+}
+
+// EvaluatePreToolUse passes the message to the plugin's gismo_pre_tool_use
+func (p *PluginRuleEngine) EvaluatePreToolUse(ctx context.Context, msg *PreToolUseMessage) (*HookResponse, error) {
+	return p.evaluate(ctx, PreToolUseEvent, msg)
+}
+
+// EvaluatePostToolUse passes the message to the plugin's gismo_post_tool_use
+func (p *PluginRuleEngine) EvaluatePostToolUse(ctx context.Context, msg *PostToolUseMessage) (*HookResponse, error) {
+	return p.evaluate(ctx, PostToolUseEvent, msg)
+}
+
+// EvaluateNotification passes the message to the plugin's gismo_notification
+func (p *PluginRuleEngine) EvaluateNotification(ctx context.Context, msg *NotificationMessage) (*HookResponse, error) {
+	return p.evaluate(ctx, NotificationEvent, msg)
+}
+
+// EvaluateStop passes the message to the plugin's gismo_stop
+func (p *PluginRuleEngine) EvaluateStop(ctx context.Context, msg *StopMessage) (*HookResponse, error) {
+	return p.evaluate(ctx, StopEvent, msg)
+}
+
+// EvaluateSubagentStop passes the message to the plugin's gismo_subagent_stop
+func (p *PluginRuleEngine) EvaluateSubagentStop(ctx context.Context, msg *SubagentStopMessage) (*HookResponse, error) {
+	return p.evaluate(ctx, SubagentStopEvent, msg)
+}
+
+// EvaluatePreCompact passes the message to the plugin's gismo_pre_compact
+func (p *PluginRuleEngine) EvaluatePreCompact(ctx context.Context, msg *PreCompactMessage) (*HookResponse, error) {
+	return p.evaluate(ctx, PreCompactEvent, msg)
+}
@@ -0,0 +1,14 @@
+//go:build !wasmplugins
+
+package gismo
+
+import (
+	"context"
+	"errors"
+)
+
+// openPluginModule fails in builds without the wasmplugins tag, which leave out
+// the WASM runtime
+func openPluginModule(ctx context.Context, wasm []byte) (pluginModule, error) {
+	return nil, errors.New("gismo was built without WASM plugin support, build it with -tags wasmplugins")
+}
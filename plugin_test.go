@@ -0,0 +1,106 @@
+package gismo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakePluginModule answers exports with canned output and records their input
+type fakePluginModule struct {
+	outputs map[string]string
+	inputs  map[string][]byte
+	err     error
+	closed  bool
+}
+
+func (m *fakePluginModule) call(ctx context.Context, export string, input []byte) ([]byte, bool, error) {
+	if m.inputs == nil {
+		m.inputs = make(map[string][]byte)
+	}
+	m.inputs[export] = input
+	if m.err != nil {
+		return nil, true, m.err
+	}
+	output, ok := m.outputs[export]
+	if !ok {
+		return nil, false, nil
+	}
+	return []byte(output), true, nil
+}
+
+func (m *fakePluginModule) close(ctx context.Context) error {
+	m.closed = true
+	return nil
+}
+
+func TestPluginRuleEngine_Evaluate(t *testing.T) {
+	module := &fakePluginModule{outputs: map[string]string{
+		"gismo_pre_tool_use": `{"decision":"block","reason":"no writes to vendor/"}`,
+		"gismo_stop":         ``,
+	}}
+	plugin := &PluginRuleEngine{name: "policy", module: module}
+	ctx := context.Background()
+
+	msg := &PreToolUseMessage{
+		BaseHookMessage: BaseHookMessage{HookEventName: PreToolUseEvent},
+		ToolName:        "Write",
+		ToolInput:       map[string]json.RawMessage{"file_path": json.RawMessage(`"vendor/x.go"`)},
+	}
+	response, err := plugin.EvaluatePreToolUse(ctx, msg)
+	if err != nil {
+		t.Fatalf("EvaluatePreToolUse() error = %v", err)
+	}
+	if response == nil || response.Decision != "block" || response.Reason != "no writes to vendor/" {
+		t.Errorf("EvaluatePreToolUse() = %+v, want the plugin's block", response)
+	}
+	if !strings.Contains(string(module.inputs["gismo_pre_tool_use"]), `"tool_name":"Write"`) {
+		t.Errorf("plugin input = %s, want the hook message as JSON", module.inputs["gismo_pre_tool_use"])
+	}
+
+	// No output and missing exports are no response
+	if response, err := plugin.EvaluateStop(ctx, &StopMessage{}); err != nil || response != nil {
+		t.Errorf("EvaluateStop() = %+v, %v, want no response", response, err)
+	}
+	if response, err := plugin.EvaluateNotification(ctx, &NotificationMessage{}); err != nil || response != nil {
+		t.Errorf("EvaluateNotification() = %+v, %v, want no response", response, err)
+	}
+
+	module.outputs["gismo_pre_compact"] = `not json`
+	if _, err := plugin.EvaluatePreCompact(ctx, &PreCompactMessage{}); err == nil || !strings.Contains(err.Error(), "plugin policy") {
+		t.Errorf("EvaluatePreCompact() error = %v, want an invalid response error naming the plugin", err)
+	}
+	module.err = errors.New("trap")
+	if _, err := plugin.EvaluatePostToolUse(ctx, &PostToolUseMessage{}); err == nil || !strings.Contains(err.Error(), "trap") {
+		t.Errorf("EvaluatePostToolUse() error = %v, want the module's error", err)
+	}
+
+	if err := plugin.Close(ctx); err != nil || !module.closed {
+		t.Errorf("Close() = %v, closed = %v", err, module.closed)
+	}
+}
+
+func TestPluginRuleEngine_Composite(t *testing.T) {
+	plugin := &PluginRuleEngine{name: "policy", module: &fakePluginModule{outputs: map[string]string{
+		"gismo_pre_tool_use": `{"decision":"block","reason":"blocked by plugin"}`,
+	}}}
+	engine := NewCompositeRuleEngine(NewBaseRuleEngine(), plugin)
+
+	response, err := engine.EvaluatePreToolUse(context.Background(), &PreToolUseMessage{ToolName: "Bash"})
+	if err != nil {
+		t.Fatalf("EvaluatePreToolUse() error = %v", err)
+	}
+	if response.Decision != "block" || response.Reason != "blocked by plugin" {
+		t.Errorf("EvaluatePreToolUse() = %+v, want the plugin's block", response)
+	}
+}
+
+func TestLoadPlugins_MissingModule(t *testing.T) {
+	_, err := LoadPlugins(context.Background(), []PluginConfig{{Path: filepath.Join(t.TempDir(), "missing.wasm")}})
+	if err == nil || !strings.Contains(err.Error(), "missing.wasm") {
+		t.Errorf("LoadPlugins() error = %v, want a read error naming the module", err)
+	}
+}
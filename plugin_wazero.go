@@ -0,0 +1,80 @@
+//go:build wasmplugins
+
+package gismo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+)
+
+// wazeroModule is a plugin module instantiated in its own wazero runtime
+type wazeroModule struct {
+	runtime wazero.Runtime
+	module  api.Module
+	alloc   api.Function
+}
+
+// openPluginModule compiles and instantiates a plugin. Calls are interrupted
+// when their context is done, which closes the module for later calls too.
+func openPluginModule(ctx context.Context, wasm []byte) (pluginModule, error) {
+	runtime := wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfig().WithCloseOnContextDone(true))
+	// Plugins built for WASI, such as by TinyGo or Rust's wasm32-wasip1 target,
+	// import it even when they don't use it
+	wasi_snapshot_preview1.MustInstantiate(ctx, runtime)
+
+	// Plugins are reactors: their initializer runs, but there is no main to run
+	module, err := runtime.InstantiateWithConfig(ctx, wasm, wazero.NewModuleConfig().WithStartFunctions("_initialize"))
+	if err != nil {
+		_ = runtime.Close(ctx)
+		return nil, err
+	}
+	alloc := module.ExportedFunction("gismo_alloc")
+	if alloc == nil || module.Memory() == nil {
+		_ = runtime.Close(ctx)
+		return nil, errors.New("module must export memory and gismo_alloc")
+	}
+	return &wazeroModule{runtime: runtime, module: module, alloc: alloc}, nil
+}
+
+// call copies input into the module's memory, runs export on it and copies its
+// output out
+func (m *wazeroModule) call(ctx context.Context, export string, input []byte) ([]byte, bool, error) {
+	fn := m.module.ExportedFunction(export)
+	if fn == nil {
+		return nil, false, nil
+	}
+
+	results, err := m.alloc.Call(ctx, uint64(len(input)))
+	if err != nil {
+		return nil, true, fmt.Errorf("gismo_alloc: %w", err)
+	}
+	ptr := uint32(results[0])
+	if !m.module.Memory().Write(ptr, input) {
+		return nil, true, fmt.Errorf("gismo_alloc returned %d, outside the module's memory", ptr)
+	}
+
+	results, err = fn.Call(ctx, uint64(ptr), uint64(len(input)))
+	if err != nil {
+		return nil, true, err
+	}
+	if results[0] == 0 {
+		return nil, true, nil
+	}
+	outPtr, outLen := uint32(results[0]>>32), uint32(results[0])
+	output, ok := m.module.Memory().Read(outPtr, outLen)
+	if !ok {
+		return nil, true, fmt.Errorf("%s returned %d bytes at %d, outside the module's memory", export, outLen, outPtr)
+	}
+	// The view into memory is only valid until the next call
+	return append([]byte(nil), output...), true, nil
+}
+
+// close releases the runtime and its compiled code
+func (m *wazeroModule) close(ctx context.Context) error {
+	return m.runtime.Close(ctx)
+}
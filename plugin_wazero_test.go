@@ -0,0 +1,128 @@
+//go:build wasmplugins
+
+package gismo
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// uleb128 and sleb128 encode integers as in the WebAssembly binary format
+func uleb128(v uint64) []byte {
+	var out []byte
+	for {
+		b := byte(v & 0x7f)
+		v >>= 7
+		if v != 0 {
+			b |= 0x80
+		}
+		out = append(out, b)
+		if v == 0 {
+			return out
+		}
+	}
+}
+
+func sleb128(v int64) []byte {
+	var out []byte
+	for {
+		b := byte(v & 0x7f)
+		v >>= 7
+		if (v == 0 && b&0x40 == 0) || (v == -1 && b&0x40 != 0) {
+			return append(out, b)
+		}
+		out = append(out, b|0x80)
+	}
+}
+
+// wasmVec prefixes items with their length
+func wasmVec(items ...byte) []byte {
+	return append(uleb128(uint64(len(items))), items...)
+}
+
+// wasmSection encodes a section with its id and size
+func wasmSection(id byte, content ...byte) []byte {
+	return append(append([]byte{id}, uleb128(uint64(len(content)))...), content...)
+}
+
+// blockingPluginModule builds a plugin whose gismo_pre_tool_use always returns
+// response, kept in a data segment at offset 16. It exports no other hooks.
+func blockingPluginModule(response string) []byte {
+	const offset = 16
+	var module []byte
+	module = append(module, 0x00, 'a', 's', 'm', 0x01, 0x00, 0x00, 0x00)
+	// Types: (i32) -> i32 for gismo_alloc and (i32, i32) -> i64 for hooks
+	module = append(module, wasmSection(1, 0x02,
+		0x60, 0x01, 0x7f, 0x01, 0x7f,
+		0x60, 0x02, 0x7f, 0x7f, 0x01, 0x7e)...)
+	module = append(module, wasmSection(3, 0x02, 0x00, 0x01)...)
+	// One page of memory
+	module = append(module, wasmSection(5, 0x01, 0x00, 0x01)...)
+
+	var exports []byte
+	exports = append(exports, 0x03)
+	exports = append(append(exports, wasmVec([]byte("memory")...)...), 0x02, 0x00)
+	exports = append(append(exports, wasmVec([]byte("gismo_alloc")...)...), 0x00, 0x00)
+	exports = append(append(exports, wasmVec([]byte("gismo_pre_tool_use")...)...), 0x00, 0x01)
+	module = append(module, wasmSection(7, exports...)...)
+
+	// gismo_alloc hands out one buffer at 1024; gismo_pre_tool_use returns the
+	// response's pointer and length
+	alloc := append([]byte{0x00, 0x41}, sleb128(1024)...)
+	alloc = append(alloc, 0x0b)
+	hook := append([]byte{0x00, 0x42}, sleb128(int64(offset)<<32|int64(len(response)))...)
+	hook = append(hook, 0x0b)
+	code := []byte{0x02}
+	code = append(append(code, uleb128(uint64(len(alloc)))...), alloc...)
+	code = append(append(code, uleb128(uint64(len(hook)))...), hook...)
+	module = append(module, wasmSection(10, code...)...)
+
+	data := append([]byte{0x01, 0x00, 0x41}, sleb128(offset)...)
+	data = append(data, 0x0b)
+	data = append(data, wasmVec([]byte(response)...)...)
+	return append(module, wasmSection(11, data...)...)
+}
+
+func TestPluginRuleEngine_Wazero(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.wasm")
+	if err := os.WriteFile(path, blockingPluginModule(`{"decision":"block","reason":"blocked by plugin"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	plugins, err := LoadPlugins(ctx, []PluginConfig{{Path: path}})
+	if err != nil {
+		t.Fatalf("LoadPlugins() error = %v", err)
+	}
+	plugin := plugins[0]
+	defer plugin.Close(ctx)
+	if plugin.Name() != "policy" {
+		t.Errorf("Name() = %q, want policy", plugin.Name())
+	}
+
+	response, err := plugin.EvaluatePreToolUse(ctx, &PreToolUseMessage{ToolName: "Bash"})
+	if err != nil {
+		t.Fatalf("EvaluatePreToolUse() error = %v", err)
+	}
+	if response == nil || response.Decision != "block" || response.Reason != "blocked by plugin" {
+		t.Errorf("EvaluatePreToolUse() = %+v, want the plugin's block", response)
+	}
+
+	// Hooks the module doesn't export get no response
+	response, err = plugin.EvaluatePostToolUse(ctx, &PostToolUseMessage{ToolName: "Bash"})
+	if err != nil || response != nil {
+		t.Errorf("EvaluatePostToolUse() = %+v, %v, want no response", response, err)
+	}
+}
+
+func TestPluginRuleEngine_WazeroInvalidModule(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "broken.wasm")
+	if err := os.WriteFile(path, []byte("not wasm"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewPluginRuleEngine(context.Background(), path); err == nil {
+		t.Error("NewPluginRuleEngine() error = nil, want a compile error")
+	}
+}
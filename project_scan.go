@@ -0,0 +1,363 @@
+package gismo
+
+import (
+	"context"
+	"errors"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jrossi/gismo/linters"
+	"github.com/jrossi/gismo/types"
+)
+
+// DefaultProjectScanBudget is how long a project scan may run by default
+const DefaultProjectScanBudget = 10 * time.Second
+
+// maxProjectScanFileSize skips files too large for a cheap check
+const maxProjectScanFileSize = 1 << 20
+
+// maxProjectScanListed bounds the files listed for each direction of the delta
+const maxProjectScanListed = 10
+
+// projectScanSkipDirs are directories a project scan doesn't descend into;
+// hidden directories are skipped too
+var projectScanSkipDirs = map[string]bool{
+	"node_modules": true,
+	"vendor":       true,
+}
+
+// StopHookConfig controls what gismo does when the agent stops
+type StopHookConfig struct {
+	// ProjectScan scans the repository and reports how its health changed
+	// since the session started
+	ProjectScan *ProjectScanConfig `json:"projectScan,omitempty"`
+}
+
+// ProjectScanConfig controls the whole-repository scan run on Stop. The scan
+// runs only syntax checks and the linters built into gismo, never external tools.
+type ProjectScanConfig struct {
+	// Enabled turns on the scan, default false
+	Enabled *bool `json:"enabled,omitempty"`
+	// Budget is the hard time limit of one scan, default 10s. Files not reached
+	// within it are left out of the comparison.
+	Budget *types.Duration `json:"budget,omitempty"`
+	// Ignore lists glob patterns of files and directories not scanned, matched
+	// against the path relative to the repository root or its base name
+	Ignore []string `json:"ignore,omitempty"`
+}
+
+// IsProjectScanEnabled checks if the Stop hook scans the repository
+func (c *AppConfig) IsProjectScanEnabled() bool {
+	if c == nil || c.StopHook == nil || c.StopHook.ProjectScan == nil || c.StopHook.ProjectScan.Enabled == nil {
+		return false
+	}
+	return *c.StopHook.ProjectScan.Enabled
+}
+
+// GetProjectScan returns the project scan's time budget and ignore patterns
+func (c *AppConfig) GetProjectScan() (time.Duration, []string) {
+	if c == nil || c.StopHook == nil || c.StopHook.ProjectScan == nil {
+		return DefaultProjectScanBudget, nil
+	}
+	budget := DefaultProjectScanBudget
+	if c.StopHook.ProjectScan.Budget != nil {
+		budget = c.StopHook.ProjectScan.Budget.Duration
+	}
+	return budget, c.StopHook.ProjectScan.Ignore
+}
+
+// ProjectHealth is the result of a project scan
+type ProjectHealth struct {
+	Errors   int `json:"errors"`
+	Warnings int `json:"warnings"`
+	// Files holds the counts of each file with issues, keyed by its slash
+	// separated path relative to the repository root
+	Files map[string]FileHealth `json:"files,omitempty"`
+	// Scanned and Total count the files checked and the files to check
+	Scanned int `json:"scanned"`
+	Total   int `json:"total"`
+	// Through is the last file checked when the budget ran out; files are
+	// checked in path order. It is empty for a complete scan.
+	Through string    `json:"through,omitempty"`
+	At      time.Time `json:"at"`
+}
+
+// FileHealth counts the issues found in one file
+type FileHealth struct {
+	Errors   int `json:"errors"`
+	Warnings int `json:"warnings"`
+}
+
+// Complete reports whether the scan checked every file
+func (h *ProjectHealth) Complete() bool {
+	return h.Through == ""
+}
+
+// covers reports whether the scan checked the file at rel
+func (h *ProjectHealth) covers(rel string) bool {
+	return h.Complete() || rel <= h.Through
+}
+
+// scanProject checks every file in the project within the configured budget.
+// Only cheap checks run: Go syntax and the linters that need no external tool.
+func (e *LintingRuleEngine) scanProject(ctx context.Context) *ProjectHealth {
+	budget, ignore := e.config.GetProjectScan()
+	ctx, cancel := context.WithTimeout(linters.WithStaticOnly(ctx), budget)
+	defer cancel()
+
+	root := e.projectRoot()
+	health := &ProjectHealth{Files: make(map[string]FileHealth), At: time.Now()}
+	files := projectScanFiles(root, ignore)
+	health.Total = len(files)
+
+	for _, rel := range files {
+		if ctx.Err() != nil {
+			break
+		}
+		file, ok := e.scanFile(ctx, filepath.Join(root, filepath.FromSlash(rel)))
+		if !ok {
+			break
+		}
+		health.Scanned++
+		if file.Errors > 0 || file.Warnings > 0 {
+			health.Files[rel] = file
+			health.Errors += file.Errors
+			health.Warnings += file.Warnings
+		}
+	}
+	if health.Scanned < health.Total {
+		// An empty Through would claim a complete scan
+		health.Through = "\x00"
+		if health.Scanned > 0 {
+			health.Through = files[health.Scanned-1]
+		}
+	}
+	return health
+}
+
+// projectScanFiles returns the files under root a project scan checks, as
+// sorted slash separated paths relative to root
+func projectScanFiles(root string, ignore []string) []string {
+	var files []string
+	ignored := func(rel string) bool {
+		for _, pattern := range ignore {
+			if matchRulePattern(pattern, rel) {
+				return true
+			}
+		}
+		return false
+	}
+	_ = filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if entry != nil && entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if path == root {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+		if entry.IsDir() {
+			name := entry.Name()
+			if projectScanSkipDirs[name] || strings.HasPrefix(name, ".") || ignored(rel) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.Type().IsRegular() && !ignored(rel) {
+			files = append(files, rel)
+		}
+		return nil
+	})
+	sort.Strings(files)
+	return files
+}
+
+// scanFile runs the cheap checks on one file. ok is false if the budget ran
+// out before the file was checked completely.
+func (e *LintingRuleEngine) scanFile(ctx context.Context, path string) (FileHealth, bool) {
+	var health FileHealth
+
+	// Files no cheap check handles aren't read unless a rule may route them
+	isGo := filepath.Ext(path) == ".go"
+	if !isGo && !e.config.hasRoutes() && !slices.ContainsFunc(e.lintersFor(path), func(linter linters.Linter) bool {
+		return isCheapLinter(linter) && linter.CanHandle(path)
+	}) {
+		return health, true
+	}
+
+	info, err := os.Stat(path)
+	if err != nil || info.Size() > maxProjectScanFileSize {
+		return health, true
+	}
+	content, err := os.ReadFile(path) // #nosec G304 - files under the project root
+	if err != nil {
+		return health, true
+	}
+
+	var cheap []linters.Linter
+	for _, linter := range e.routedLinters(path, content) {
+		if linter.CanHandle(path) && isCheapLinter(linter) {
+			cheap = append(cheap, linter)
+		}
+	}
+
+	if isGo {
+		_, err := parser.ParseFile(token.NewFileSet(), path, content, parser.AllErrors|parser.SkipObjectResolution)
+		var list scanner.ErrorList
+		if errors.As(err, &list) {
+			health.Errors += len(list)
+		} else if err != nil {
+			health.Errors++
+		}
+	}
+
+	if len(cheap) > 0 {
+		e.applyOverrides(path, content, nil)
+		results := e.executeLinters(ctx, cheap, path, content)
+		if ctx.Err() != nil {
+			return health, false
+		}
+		aggregated, _ := linters.AggregateResultsWithPolicy(results, e.severityPolicy())
+		for _, issue := range linters.DeduplicateIssues(aggregated.Issues) {
+			switch issue.Severity {
+			case "error":
+				health.Errors++
+			case "warning":
+				health.Warnings++
+			}
+		}
+	}
+	return health, true
+}
+
+// isCheapLinter reports whether a linter runs without external tools
+func isCheapLinter(linter linters.Linter) bool {
+	if routed, ok := linter.(routedLinter); ok {
+		linter = routed.Linter
+	}
+	describer, ok := linter.(linters.CapabilityDescriber)
+	return ok && len(describer.Capabilities().Tools) == 0
+}
+
+// captureProjectBaseline records the project's health at the start of a
+// session, before its first edit, for the Stop hook to compare against
+func (e *LintingRuleEngine) captureProjectBaseline(ctx context.Context, sessionID string) {
+	if !e.config.IsProjectScanEnabled() || e.sessions == nil || sessionID == "" {
+		return
+	}
+	if state, err := e.sessions.Load(sessionID); err != nil || state.ProjectBaseline != nil {
+		return
+	}
+	// The scan runs outside the session lock, which other hooks may wait on
+	baseline := e.scanProject(ctx)
+	_ = e.sessions.Update(sessionID, func(state *SessionState) error {
+		if state.ProjectBaseline != nil {
+			return errNoSessionChange
+		}
+		state.ProjectBaseline = baseline
+		return nil
+	})
+}
+
+// projectHealthDelta scans the project and describes how its health changed
+// since the session's baseline. A session without one gets the scan as its
+// baseline and no report.
+func (e *LintingRuleEngine) projectHealthDelta(ctx context.Context, sessionID string) string {
+	if !e.config.IsProjectScanEnabled() || e.sessions == nil || sessionID == "" {
+		return ""
+	}
+	current := e.scanProject(ctx)
+
+	var baseline *ProjectHealth
+	_ = e.sessions.Update(sessionID, func(state *SessionState) error {
+		if state.ProjectBaseline != nil {
+			baseline = state.ProjectBaseline
+			return errNoSessionChange
+		}
+		state.ProjectBaseline = current
+		return nil
+	})
+	if baseline == nil {
+		return ""
+	}
+	budget, _ := e.config.GetProjectScan()
+	return e.formatHealthDelta(baseline, current, budget)
+}
+
+// formatHealthDelta describes the change from before to after, comparing only
+// the files both scans checked. It returns "" when nothing changed.
+func (e *LintingRuleEngine) formatHealthDelta(before, after *ProjectHealth, budget time.Duration) string {
+	covered := func(rel string) bool {
+		return before.covers(rel) && after.covers(rel)
+	}
+	var beforeTotal, afterTotal FileHealth
+	paths := make(map[string]bool)
+	for rel, file := range before.Files {
+		if covered(rel) {
+			paths[rel] = true
+			beforeTotal.Errors += file.Errors
+			beforeTotal.Warnings += file.Warnings
+		}
+	}
+	for rel, file := range after.Files {
+		if covered(rel) {
+			paths[rel] = true
+			afterTotal.Errors += file.Errors
+			afterTotal.Warnings += file.Warnings
+		}
+	}
+
+	var worse, better []string
+	sorted := make([]string, 0, len(paths))
+	for rel := range paths {
+		sorted = append(sorted, rel)
+	}
+	sort.Strings(sorted)
+	for _, rel := range sorted {
+		errorsDelta := after.Files[rel].Errors - before.Files[rel].Errors
+		warningsDelta := after.Files[rel].Warnings - before.Files[rel].Warnings
+		line := e.messages.Sprintf("projectscan.file", rel, errorsDelta, warningsDelta)
+		switch {
+		case errorsDelta > 0 || errorsDelta == 0 && warningsDelta > 0:
+			worse = append(worse, line)
+		case errorsDelta < 0 || warningsDelta < 0:
+			better = append(better, line)
+		}
+	}
+	if len(worse) == 0 && len(better) == 0 {
+		return ""
+	}
+
+	lines := []string{e.messages.Sprintf("projectscan.summary", beforeTotal.Errors, afterTotal.Errors, beforeTotal.Warnings, afterTotal.Warnings)}
+	for _, group := range []struct {
+		key   string
+		files []string
+	}{{"projectscan.worse", worse}, {"projectscan.better", better}} {
+		if len(group.files) == 0 {
+			continue
+		}
+		lines = append(lines, e.messages.Sprintf(group.key))
+		lines = append(lines, group.files[:min(len(group.files), maxProjectScanListed)]...)
+		if len(group.files) > maxProjectScanListed {
+			lines = append(lines, e.messages.Sprintf("projectscan.more", len(group.files)-maxProjectScanListed))
+		}
+	}
+	if !before.Complete() || !after.Complete() {
+		lines = append(lines, e.messages.Sprintf("projectscan.partial", budget, min(before.Scanned, after.Scanned), max(before.Total, after.Total)))
+	}
+	return strings.Join(lines, "\n")
+}
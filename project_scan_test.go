@@ -0,0 +1,195 @@
+package gismo
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jrossi/gismo/types"
+)
+
+func writeProjectFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestProjectScanFiles(t *testing.T) {
+	root := t.TempDir()
+	writeProjectFiles(t, root, map[string]string{
+		"main.go":                 "package main\n",
+		"b/data.json":             "{}",
+		"a/config.json":           "{}",
+		"gen/out.json":            "{}",
+		"schema.generated.json":   "{}",
+		".git/config":             "",
+		"node_modules/pkg/x.json": "{}",
+		"vendor/dep/dep.go":       "package dep\n",
+	})
+
+	got := projectScanFiles(root, []string{"gen", "*.generated.json"})
+	want := []string{"a/config.json", "b/data.json", "main.go"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("projectScanFiles() = %v, want %v", got, want)
+	}
+}
+
+func TestLintingRuleEngine_ProjectScan(t *testing.T) {
+	root := t.TempDir()
+	writeProjectFiles(t, root, map[string]string{
+		"broken.go":     "package main\n\nfunc main() {\n",
+		"config.json":   `{"name": "gismo"}`,
+		"ignored.json":  `{"name": `,
+		"docs/note.txt": "notes",
+	})
+
+	enabled := true
+	engine := NewLintingRuleEngineWithConfig(LintingConfig{
+		SessionStore: NewSessionStore(t.TempDir()),
+		ProjectRoot:  root,
+	})
+	engine.SetAppConfig(&AppConfig{StopHook: &StopHookConfig{ProjectScan: &ProjectScanConfig{
+		Enabled: &enabled,
+		Ignore:  []string{"ignored.json"},
+	}}})
+	var feedback strings.Builder
+	engine.SetFeedbackWriter(&feedback)
+	ctx := context.Background()
+
+	// A session stopping before any edit gets no report
+	stop := &StopMessage{BaseHookMessage: BaseHookMessage{SessionID: "scan-session"}}
+	if response, err := engine.EvaluateStop(ctx, stop); err != nil || response != nil {
+		t.Fatalf("EvaluateStop() without edits = %+v, %v, want nil", response, err)
+	}
+
+	// Reset the baseline so it's taken by the first edit instead
+	if err := engine.sessions.Update("scan-session", func(state *SessionState) error {
+		state.ProjectBaseline = nil
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	write := &PreToolUseMessage{
+		BaseHookMessage: BaseHookMessage{SessionID: "scan-session"},
+		ToolName:        "Write",
+		ToolInput: map[string]json.RawMessage{
+			"file_path": json.RawMessage(`"` + filepath.ToSlash(filepath.Join(root, "docs", "note.txt")) + `"`),
+			"content":   json.RawMessage(`"more notes"`),
+		},
+	}
+	if _, err := engine.EvaluatePreToolUse(ctx, write); err != nil {
+		t.Fatal(err)
+	}
+	state, err := engine.sessions.Load("scan-session")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.ProjectBaseline == nil || state.ProjectBaseline.Files["broken.go"].Errors == 0 {
+		t.Fatalf("baseline = %+v, want broken.go syntax errors", state.ProjectBaseline)
+	}
+	if _, ok := state.ProjectBaseline.Files["ignored.json"]; ok {
+		t.Error("baseline includes an ignored file")
+	}
+
+	// Fix the Go file and break the JSON file
+	writeProjectFiles(t, root, map[string]string{
+		"broken.go":   "package main\n\nfunc main() {}\n",
+		"config.json": `{"name": `,
+	})
+	response, err := engine.EvaluateStop(ctx, stop)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response == nil {
+		t.Fatal("EvaluateStop() = nil, want a health delta")
+	}
+	if response.Decision != "" {
+		t.Errorf("Decision = %q, the report must not block stopping", response.Decision)
+	}
+	for _, want := range []string{"Project health since the session started", "New or increased issues:", "config.json: +", "Fixed or reduced issues:", "broken.go: -"} {
+		if !strings.Contains(response.Message, want) {
+			t.Errorf("Message missing %q:\n%s", want, response.Message)
+		}
+	}
+	if !strings.Contains(feedback.String(), response.Message) {
+		t.Error("delta not written to the feedback writer")
+	}
+}
+
+func TestFormatHealthDelta(t *testing.T) {
+	engine := NewLintingRuleEngine()
+	before := &ProjectHealth{
+		Files: map[string]FileHealth{
+			"a.json": {Errors: 1},
+			"z.json": {Errors: 1},
+		},
+		Scanned: 3,
+		Total:   3,
+	}
+
+	t.Run("unchanged", func(t *testing.T) {
+		if got := engine.formatHealthDelta(before, before, time.Second); got != "" {
+			t.Errorf("formatHealthDelta() = %q, want empty", got)
+		}
+	})
+
+	t.Run("partial scan compares only files both scanned", func(t *testing.T) {
+		after := &ProjectHealth{
+			Files:   map[string]FileHealth{"a.json": {Errors: 2}},
+			Scanned: 1,
+			Total:   3,
+			Through: "m.json",
+		}
+		got := engine.formatHealthDelta(before, after, 5*time.Second)
+		if !strings.Contains(got, "1 → 2 error(s)") {
+			t.Errorf("totals should leave out z.json, which the second scan didn't reach:\n%s", got)
+		}
+		if strings.Contains(got, "z.json") {
+			t.Errorf("z.json reported as fixed though it wasn't scanned:\n%s", got)
+		}
+		if !strings.Contains(got, "5s budget, so only the first 1 of 3 file(s)") {
+			t.Errorf("missing partial scan note:\n%s", got)
+		}
+	})
+}
+
+func TestAppConfig_MergeStopHook(t *testing.T) {
+	enabled := true
+	base := &AppConfig{StopHook: &StopHookConfig{ProjectScan: &ProjectScanConfig{Ignore: []string{"dist"}}}}
+	base.Merge(&AppConfig{StopHook: &StopHookConfig{ProjectScan: &ProjectScanConfig{
+		Enabled: &enabled,
+		Budget:  &types.Duration{Duration: 3 * time.Second},
+		Ignore:  []string{"dist", "*.min.js"},
+	}}})
+
+	if !base.IsProjectScanEnabled() {
+		t.Error("IsProjectScanEnabled() = false after merge")
+	}
+	budget, ignore := base.GetProjectScan()
+	if budget != 3*time.Second {
+		t.Errorf("budget = %v, want 3s", budget)
+	}
+	if !reflect.DeepEqual(ignore, []string{"dist", "*.min.js"}) {
+		t.Errorf("ignore = %v", ignore)
+	}
+
+	var empty *AppConfig
+	if empty.IsProjectScanEnabled() {
+		t.Error("nil config enables the scan")
+	}
+	if budget, _ := empty.GetProjectScan(); budget != DefaultProjectScanBudget {
+		t.Errorf("default budget = %v", budget)
+	}
+}
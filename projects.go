@@ -0,0 +1,186 @@
+package gismo
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jrossi/gismo/linters"
+	"github.com/jrossi/gismo/toolcache"
+)
+
+// projectLinterTypes maps language linters to the project type they belong to.
+// Linters for files without a project type (markdown, json, protobuf) are never scoped.
+var projectLinterTypes = map[string]string{
+	"go":         "go",
+	"javascript": "javascript",
+	"python":     "python",
+	"rust":       "rust",
+}
+
+// ProjectsConfig controls monorepo sub-project detection
+type ProjectsConfig struct {
+	// Detect discovers sub-projects from go.mod, package.json, pyproject.toml, setup.py and Cargo.toml
+	Detect *bool `json:"detect,omitempty"`
+	// ScopeLinters runs language linters only inside sub-projects of their language, default true
+	ScopeLinters *bool `json:"scopeLinters,omitempty"`
+	// Linters configures linters per sub-project directory, keyed by path relative to the repository root
+	Linters map[string]map[string]LinterConfig `json:"linters,omitempty"`
+}
+
+// IsProjectDetectionEnabled checks if monorepo sub-project detection is enabled
+func (c *AppConfig) IsProjectDetectionEnabled() bool {
+	if c == nil || c.Projects == nil || c.Projects.Detect == nil {
+		return false
+	}
+	return *c.Projects.Detect
+}
+
+// IsLinterScopingEnabled checks if language linters are scoped to matching sub-projects
+func (c *AppConfig) IsLinterScopingEnabled() bool {
+	if !c.IsProjectDetectionEnabled() {
+		return false
+	}
+	if c.Projects.ScopeLinters == nil {
+		return true
+	}
+	return *c.Projects.ScopeLinters
+}
+
+// projectLinterConfig returns the sub-project settings for a linter that apply to
+// relPath, from the outermost to the innermost matching directory
+func (c *AppConfig) projectLinterConfig(relPath, linterName string) []LinterConfig {
+	if c == nil || c.Projects == nil {
+		return nil
+	}
+	var dirs []string
+	for dir := range c.Projects.Linters {
+		clean := strings.Trim(filepath.ToSlash(filepath.Clean(dir)), "/")
+		if clean == "." || relPath == clean || strings.HasPrefix(relPath, clean+"/") {
+			dirs = append(dirs, dir)
+		}
+	}
+	// Shorter paths are outer directories, so inner settings are applied last
+	sort.SliceStable(dirs, func(i, j int) bool { return len(dirs[i]) < len(dirs[j]) })
+
+	var configs []LinterConfig
+	for _, dir := range dirs {
+		if config, ok := c.Projects.Linters[dir][linterName]; ok {
+			configs = append(configs, config)
+		}
+	}
+	return configs
+}
+
+// projectRelPath returns filePath relative to the project root with forward slashes
+func (e *LintingRuleEngine) projectRelPath(filePath string) (string, bool) {
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return "", false
+	}
+	rel, err := filepath.Rel(e.projectRoot(), absPath)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return filepath.ToSlash(rel), true
+}
+
+// projectRoot returns the repository root sub-projects are discovered from
+func (e *LintingRuleEngine) projectRoot() string {
+	if e.root != "" {
+		return e.root
+	}
+	if wd, err := os.Getwd(); err == nil {
+		return wd
+	}
+	return "."
+}
+
+// discoverProjects returns the sub-projects of the repository, discovering them
+// once per engine through the on-disk project cache
+func (e *LintingRuleEngine) discoverProjects() map[string]toolcache.ProjectConfig {
+	e.projectsOnce.Do(func() {
+		root := e.projectRoot()
+		if manager, err := toolcache.NewCacheManager(root); err == nil {
+			e.projects, _ = manager.Projects(root)
+		}
+		if e.projects == nil {
+			e.projects, _ = toolcache.DiscoverProjects(root)
+		}
+	})
+	return e.projects
+}
+
+// lintersFor returns the enabled linters for filePath. With project detection,
+// language linters are limited to sub-projects of their language and
+// per-project enablement is applied.
+func (e *LintingRuleEngine) lintersFor(filePath string) []linters.Linter {
+	if e.config == nil {
+		return e.linters
+	}
+
+	var rel string
+	var project toolcache.ProjectConfig
+	var inProject, inRoot bool
+	if e.config.IsProjectDetectionEnabled() {
+		if rel, inRoot = e.projectRelPath(filePath); inRoot {
+			_, project, inProject = toolcache.FindProject(e.discoverProjects(), rel)
+		}
+	}
+
+	active := make([]linters.Linter, 0, len(e.linters))
+	for _, linter := range e.linters {
+		name := linter.Name()
+		enabled := e.config.IsLinterEnabled(name)
+		if inRoot {
+			for _, config := range e.config.projectLinterConfig(rel, name) {
+				if config.Enabled != nil {
+					enabled = *config.Enabled
+				}
+			}
+		}
+		if !enabled {
+			continue
+		}
+		if inProject && e.config.IsLinterScopingEnabled() {
+			if projectType, scoped := projectLinterTypes[name]; scoped && !hasProjectType(project, projectType) {
+				continue
+			}
+		}
+		active = append(active, linter)
+	}
+	return active
+}
+
+// hasProjectType reports whether a project is of the given type
+func hasProjectType(project toolcache.ProjectConfig, projectType string) bool {
+	for _, t := range project.ProjectType {
+		if t == projectType {
+			return true
+		}
+	}
+	return false
+}
+
+// ActiveLinterNames returns the names of the linters enabled for filePath after
+// per-project enablement and scoping, without checking which files they handle
+func (e *LintingRuleEngine) ActiveLinterNames(filePath string) []string {
+	var names []string
+	for _, linter := range e.lintersFor(filePath) {
+		names = append(names, linter.Name())
+	}
+	return names
+}
+
+// HandlingLinterNames returns the names of the enabled linters that check
+// filePath, judged by its name alone
+func (e *LintingRuleEngine) HandlingLinterNames(filePath string) []string {
+	var names []string
+	for _, linter := range e.lintersFor(filePath) {
+		if linter.CanHandle(filePath) {
+			names = append(names, linter.Name())
+		}
+	}
+	return names
+}
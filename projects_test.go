@@ -0,0 +1,118 @@
+package gismo
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+// configRecordingLinter records the last configuration it was given
+type configRecordingLinter struct {
+	MockLinter
+	config string
+}
+
+func (l *configRecordingLinter) SetConfig(config json.RawMessage) error {
+	l.config = string(config)
+	return nil
+}
+
+func TestLintingRuleEngine_ProjectScoping(t *testing.T) {
+	root := t.TempDir()
+	for _, file := range []string{".claude/.keep", "services/api/go.mod", "web/package.json", "docs/guide.md"} {
+		path := filepath.Join(root, filepath.FromSlash(file))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	detect, disabled := true, false
+	config := NewAppConfig()
+	config.Projects = &ProjectsConfig{
+		Detect: &detect,
+		Linters: map[string]map[string]LinterConfig{
+			"web": {"markdown": {Enabled: &disabled}},
+		},
+	}
+
+	engine := NewLintingRuleEngineWithConfig(LintingConfig{ProjectRoot: root})
+	engine.linters = nil
+	for _, name := range []string{"go", "javascript", "python", "markdown"} {
+		engine.linters = append(engine.linters, &MockLinter{name: name, canHandle: true})
+	}
+	engine.SetAppConfig(config)
+
+	tests := []struct {
+		file string
+		want string
+	}{
+		{"services/api/main.go", "go,markdown"},
+		{"web/src/app.ts", "javascript"},
+		{"docs/guide.md", "go,javascript,markdown,python"},
+	}
+	for _, tt := range tests {
+		var names []string
+		for _, linter := range engine.lintersFor(filepath.Join(root, tt.file)) {
+			names = append(names, linter.Name())
+		}
+		sort.Strings(names)
+		if got := strings.Join(names, ","); got != tt.want {
+			t.Errorf("lintersFor(%s) = %s, want %s", tt.file, got, tt.want)
+		}
+	}
+
+	// Scoping can be turned off while keeping per-project settings
+	config.Projects.ScopeLinters = &disabled
+	var names []string
+	for _, linter := range engine.lintersFor(filepath.Join(root, "web/README.md")) {
+		names = append(names, linter.Name())
+	}
+	if got := strings.Join(names, ","); got != "go,javascript,python" {
+		t.Errorf("lintersFor without scoping = %s, want go,javascript,python", got)
+	}
+}
+
+func TestLintingRuleEngine_DisabledLinterSkipped(t *testing.T) {
+	engine := NewLintingRuleEngineWithConfig(LintingConfig{FileSystem: linters.NewMemFileSystem()})
+	engine.linters = []linters.Linter{&syntaxLinter{MockLinter{name: "syntax", canHandle: true}}}
+
+	disabled := false
+	config := NewAppConfig()
+	config.Linters["syntax"] = LinterConfig{Enabled: &disabled}
+	engine.SetAppConfig(config)
+
+	msg := writeMessage("", map[string]interface{}{"file_path": "/proj/file.txt", "content": "BROKEN"})
+	resp, err := engine.EvaluatePreToolUse(context.Background(), msg)
+	if err != nil || resp.Decision != "approve" {
+		t.Errorf("EvaluatePreToolUse() = %+v, %v; want approve with the linter disabled", resp, err)
+	}
+}
+
+func TestLintingRuleEngine_ProjectLinterConfig(t *testing.T) {
+	root := t.TempDir()
+	linter := &configRecordingLinter{MockLinter: MockLinter{name: "go", canHandle: true}}
+	engine := NewLintingRuleEngineWithConfig(LintingConfig{ProjectRoot: root})
+	engine.linters = []linters.Linter{linter}
+
+	config := NewAppConfig()
+	config.Projects = &ProjectsConfig{Linters: map[string]map[string]LinterConfig{
+		".":            {"go": {Config: json.RawMessage(`{"testTimeout": "1m", "verbose": false}`)}},
+		"services/api": {"go": {Config: json.RawMessage(`{"testTimeout": "5m"}`)}},
+	}}
+	config.Rules = []RuleOverride{{Pattern: "*_test.go", Linter: "go", Rules: json.RawMessage(`{"verbose": true}`)}}
+	engine.SetAppConfig(config)
+
+	engine.applyRuleOverrides(filepath.Join(root, "services/api/handler_test.go"))
+	if linter.config != `{"testTimeout":"5m","verbose":true}` {
+		t.Errorf("applied config = %s, want inner project then rule settings", linter.config)
+	}
+}
@@ -0,0 +1,130 @@
+package gismo
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+// Refactor guard actions for oversized edits
+const (
+	// RefactorGuardWarn approves oversized edits with a warning
+	RefactorGuardWarn = "warn"
+	// RefactorGuardBlock blocks an oversized edit until it is retried unchanged
+	RefactorGuardBlock = "block"
+)
+
+// Default refactor guard limits
+const (
+	DefaultMaxChangedLines = 300
+	DefaultMaxEdits        = 20
+)
+
+// RefactorGuardConfig flags single Write, Edit and MultiEdit operations too large
+// to review, so the agent splits massive rewrites into steps
+type RefactorGuardConfig struct {
+	// Enabled turns on the guard, default false
+	Enabled *bool `json:"enabled,omitempty"`
+	// MaxChangedLines is the most lines one operation may add and remove
+	// together, default 300 (0 disables the limit)
+	MaxChangedLines *int `json:"maxChangedLines,omitempty"`
+	// MaxEdits is the most edits one MultiEdit may make, default 20 (0 disables the limit)
+	MaxEdits *int `json:"maxEdits,omitempty"`
+	// Action is "warn" (default) or "block". A blocked edit goes through when
+	// it is retried unchanged.
+	Action *string `json:"action,omitempty"`
+}
+
+// IsRefactorGuardEnabled checks if oversized edits are flagged
+func (c *AppConfig) IsRefactorGuardEnabled() bool {
+	return c != nil && c.RefactorGuard != nil && c.RefactorGuard.Enabled != nil && *c.RefactorGuard.Enabled
+}
+
+// GetRefactorGuardLimits returns the changed-line and MultiEdit edit limits
+func (c *AppConfig) GetRefactorGuardLimits() (maxChangedLines, maxEdits int) {
+	maxChangedLines, maxEdits = DefaultMaxChangedLines, DefaultMaxEdits
+	if c == nil || c.RefactorGuard == nil {
+		return maxChangedLines, maxEdits
+	}
+	if c.RefactorGuard.MaxChangedLines != nil {
+		maxChangedLines = *c.RefactorGuard.MaxChangedLines
+	}
+	if c.RefactorGuard.MaxEdits != nil {
+		maxEdits = *c.RefactorGuard.MaxEdits
+	}
+	return maxChangedLines, maxEdits
+}
+
+// GetRefactorGuardAction returns the action for oversized edits
+func (c *AppConfig) GetRefactorGuardAction() string {
+	if c == nil || c.RefactorGuard == nil || c.RefactorGuard.Action == nil {
+		return RefactorGuardWarn
+	}
+	return *c.RefactorGuard.Action
+}
+
+// checkEditSize applies the refactor guard to an edit that passed linting.
+// content is the file as the edit leaves it. It returns a blocking response
+// when the edit is oversized and not yet acknowledged, or a warning to include
+// in the approval.
+func (e *LintingRuleEngine) checkEditSize(msg *PreToolUseMessage, filePath, content string) (*HookResponse, string) {
+	if !e.config.IsRefactorGuardEnabled() {
+		return nil, ""
+	}
+	maxChangedLines, maxEdits := e.config.GetRefactorGuardLimits()
+
+	var size string
+	if input, err := ParseToolInput(msg.ToolName, msg.ToolInput); err == nil {
+		if multi, ok := input.(MultiEditToolInput); ok && maxEdits > 0 && len(multi.Edits) > maxEdits {
+			size = e.messages.Sprintf("refactor.edits", len(multi.Edits), maxEdits)
+		}
+	}
+	if size == "" && maxChangedLines > 0 {
+		current, err := e.fs.ReadFile(filePath)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, ""
+		}
+		added, removed := linters.ChangedLines(current, []byte(content))
+		if added+removed > maxChangedLines {
+			size = e.messages.Sprintf("refactor.lines", added, removed, maxChangedLines)
+		}
+	}
+	if size == "" {
+		return nil, ""
+	}
+
+	if e.config.GetRefactorGuardAction() == RefactorGuardBlock && !e.acknowledgeLargeEdit(msg.SessionID, filePath, content) {
+		return &HookResponse{
+			Decision: "block",
+			Reason:   e.messages.Sprintf("refactor.block", filePath, size),
+			NoCache:  true,
+		}, ""
+	}
+	return nil, e.messages.Sprintf("refactor.warn", filePath, size)
+}
+
+// acknowledgeLargeEdit records an oversized edit in session state and reports
+// whether the same edit was already blocked once. Without session state every
+// edit counts as acknowledged, since a retry couldn't be recognized.
+func (e *LintingRuleEngine) acknowledgeLargeEdit(sessionID, filePath, content string) bool {
+	if e.sessions == nil || sessionID == "" {
+		return true
+	}
+	sum := sha256.Sum256([]byte(filePath + "\x00" + content))
+	key := hex.EncodeToString(sum[:])
+	acknowledged := true
+	_ = e.sessions.Update(sessionID, func(state *SessionState) error {
+		if state.AcknowledgedEdits[key] {
+			return errNoSessionChange
+		}
+		if state.AcknowledgedEdits == nil {
+			state.AcknowledgedEdits = make(map[string]bool)
+		}
+		state.AcknowledgedEdits[key] = true
+		acknowledged = false
+		return nil
+	})
+	return acknowledged
+}
@@ -0,0 +1,84 @@
+package gismo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+func TestLintingRuleEngine_RefactorGuard(t *testing.T) {
+	var before, after strings.Builder
+	for i := range 10 {
+		fmt.Fprintf(&before, "line %d\n", i)
+		fmt.Fprintf(&after, "changed %d\n", i)
+	}
+	manyEdits := make([]map[string]string, 4)
+	for i := range manyEdits {
+		manyEdits[i] = map[string]string{"old_string": fmt.Sprintf("line %d\n", i), "new_string": fmt.Sprintf("edited %d\n", i)}
+	}
+
+	tests := []struct {
+		name         string
+		action       string
+		tool         string
+		input        map[string]interface{}
+		wantDecision []string
+		wantText     string
+	}{
+		{"small edit passes", RefactorGuardBlock, "Edit", map[string]interface{}{"old_string": "line 1\n", "new_string": "line one\n"}, []string{"approve"}, ""},
+		{"rewrite warns", RefactorGuardWarn, "Write", map[string]interface{}{"content": after.String()}, []string{"approve"}, "adds 10 and removes 10 lines"},
+		{"rewrite blocks until retried", RefactorGuardBlock, "Write", map[string]interface{}{"content": after.String()}, []string{"block", "approve"}, "Large edit"},
+		{"many edits block", RefactorGuardBlock, "MultiEdit", map[string]interface{}{"edits": manyEdits}, []string{"block"}, "4 edits in one MultiEdit"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsys := linters.NewMemFileSystem()
+			if err := fsys.WriteFile("/proj/notes.txt", []byte(before.String()), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			engine := NewLintingRuleEngineWithConfig(LintingConfig{FileSystem: fsys, SessionStore: NewSessionStore(t.TempDir())})
+			engine.linters = []linters.Linter{&MockLinter{canHandle: true, result: &linters.LintResult{Success: true}}}
+
+			enabled, maxLines, maxEdits, action := true, 5, 3, tt.action
+			config := NewAppConfig()
+			config.RefactorGuard = &RefactorGuardConfig{Enabled: &enabled, MaxChangedLines: &maxLines, MaxEdits: &maxEdits, Action: &action}
+			engine.SetAppConfig(config)
+
+			input := map[string]interface{}{"file_path": "/proj/notes.txt"}
+			for key, value := range tt.input {
+				input[key] = value
+			}
+			var last *HookResponse
+			for i, want := range tt.wantDecision {
+				msg := &PreToolUseMessage{
+					BaseHookMessage: BaseHookMessage{SessionID: "session", HookEventName: PreToolUseEvent},
+					ToolName:        tt.tool,
+					ToolInput:       testConvertToRawMessage(input),
+				}
+				resp, err := engine.EvaluatePreToolUse(context.Background(), msg)
+				if err != nil {
+					t.Fatalf("EvaluatePreToolUse() error = %v", err)
+				}
+				if resp.Decision != want {
+					t.Errorf("attempt %d decision = %q, want %q (%s)", i+1, resp.Decision, want, resp.Reason)
+				}
+				if resp.Decision == "block" && !resp.NoCache {
+					t.Error("refactor guard block must not be cached, so a retry can acknowledge it")
+				}
+				last = resp
+			}
+
+			text := last.Reason + last.Message
+			if tt.wantText == "" && text != "" {
+				t.Errorf("expected no refactor guard feedback, got %q", text)
+			}
+			if !strings.Contains(text, tt.wantText) {
+				t.Errorf("expected %q in feedback, got %q", tt.wantText, text)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+//go:build release
+
+package gismo
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jrossi/gismo/linters"
+	"github.com/jrossi/gismo/toolcache"
+)
+
+// releaseSample is a file each linter's embedded checks must flag
+type releaseSample struct {
+	file    string
+	content string
+	// rule is the embedded rule expected, empty for linters without embedded checks
+	rule string
+	// config and stubs set up checks that need them
+	config string
+	stubs  []string
+}
+
+// releaseSamples has one entry per registered linter, so a new linter fails the
+// release check until it is covered
+var releaseSamples = map[string]releaseSample{
+	"conflicts":  {file: "main.go", content: "<<<<<<< HEAD\na\n=======\nb\n>>>>>>> main\n", rule: "conflict-marker"},
+	"css":        {file: "site.css", content: "a {\n  color: red;\n", rule: "syntax"},
+	"dockerfile": {file: "Dockerfile", content: "FROM ubuntu\n", rule: "pin-image-tag"},
+	"go":         {file: "main.go", content: "package main\nfunc main(){}\n", rule: "gofmt"},
+	"javascript": {file: "app.js", content: "function f() {\n", rule: "basic-syntax"},
+	"json":       {file: "data.json", content: "{\"a\": }\n", rule: "syntax"},
+	"markdown":   {file: "README.md", content: "# Notes\n*  item\n", rule: "formatting"},
+	"paths":      {file: "aux.ts", content: "export {}\n", rule: "reserved-name"},
+	"protobuf": {
+		file:    "api.proto",
+		content: "syntax = \"proto3\";\npackage api;\n",
+		rule:    "generated-code",
+		config:  `{"checkGenerated": true}`,
+		stubs:   []string{"api.pb.go"},
+	},
+	"python":   {file: "app.py", content: "def f(:\n", rule: "basic-syntax"},
+	"rust":     {file: "main.rs", content: "fn main() {\n", rule: "basic-syntax"},
+	"secrets":  {file: "settings.py", content: "KEY = \"AKIA" + "IOSFODNN7EXAMPLE\"\n", rule: "aws-access-key"},
+	"security": {file: "client.go", content: "var c = tls.Config{InsecureSkipVerify: true}\n", rule: "tls-verify-disabled"},
+	"shell":    {file: "run.sh", content: "#!/bin/sh\necho hi\n"},
+	"toml":     {file: "Cargo.toml", content: "[dependencies]\nserde = \"1\"\n", rule: "cargo-manifest"},
+	"unicode":  {file: "main.c", content: "int access\u202e = 1;\n", rule: "bidi-control"},
+	"yaml":     {file: "config.yaml", content: "a: 1\na: 2\n", rule: "duplicate-key"},
+}
+
+// TestReleaseEmbeddedChecks runs every registered linter with no external tools,
+// as on a machine with only the gismo binary, and checks that each one runs and
+// that its embedded checks report findings
+func TestReleaseEmbeddedChecks(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	dir := t.TempDir()
+
+	engine := NewLintingRuleEngineWithConfig(LintingConfig{ToolCache: toolcache.NewMemoryCache()})
+	capabilities := engine.LinterCapabilities()
+
+	for _, linter := range engine.linters {
+		t.Run(linter.Name(), func(t *testing.T) {
+			sample, ok := releaseSamples[linter.Name()]
+			if !ok {
+				t.Fatalf("no release sample for linter %q", linter.Name())
+			}
+			caps, ok := capabilities[linter.Name()]
+			if !ok {
+				t.Fatalf("linter %q doesn't report its capabilities", linter.Name())
+			}
+			if len(caps.Embedded) > 0 && sample.rule == "" {
+				t.Fatalf("release sample for %q checks none of its embedded checks", linter.Name())
+			}
+
+			filePath := filepath.Join(dir, linter.Name(), sample.file)
+			if err := os.MkdirAll(filepath.Dir(filePath), 0750); err != nil {
+				t.Fatal(err)
+			}
+			for _, stub := range sample.stubs {
+				if err := os.WriteFile(filepath.Join(filepath.Dir(filePath), stub), []byte("package api\n"), 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if sample.config != "" {
+				configurable, ok := linter.(ConfigurableLinter)
+				if !ok {
+					t.Fatalf("linter %q isn't configurable", linter.Name())
+				}
+				if err := configurable.SetConfig(json.RawMessage(sample.config)); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if !linter.CanHandle(filePath) {
+				t.Fatalf("CanHandle(%q) = false", filePath)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			result, err := linter.Lint(ctx, filePath, []byte(sample.content))
+			if err != nil {
+				t.Fatalf("Lint() error = %v", err)
+			}
+			if sample.rule != "" && !hasRule(result.Issues, sample.rule) {
+				t.Errorf("Lint() issues = %+v, want rule %q", result.Issues, sample.rule)
+			}
+		})
+	}
+}
+
+// hasRule reports whether issues include one for rule
+func hasRule(issues []linters.Issue, rule string) bool {
+	for _, issue := range issues {
+		if issue.Rule == rule {
+			return true
+		}
+	}
+	return false
+}
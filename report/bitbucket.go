@@ -0,0 +1,116 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+// maxBitbucketAnnotations is the most annotations Bitbucket accepts per report
+const maxBitbucketAnnotations = 1000
+
+// BitbucketReporter writes a Bitbucket Code Insights report with its annotations.
+// A pipeline step PUTs "report" to the reports endpoint and POSTs "annotations"
+// to the report's annotations endpoint.
+type BitbucketReporter struct {
+	Root string
+}
+
+// bitbucketDocument holds a Code Insights report and its annotations
+type bitbucketDocument struct {
+	Report      bitbucketReport       `json:"report"`
+	Annotations []bitbucketAnnotation `json:"annotations"`
+}
+
+type bitbucketReport struct {
+	Title      string          `json:"title"`
+	Details    string          `json:"details"`
+	ReportType string          `json:"report_type"`
+	Reporter   string          `json:"reporter"`
+	Result     string          `json:"result"`
+	Data       []bitbucketData `json:"data"`
+}
+
+type bitbucketData struct {
+	Title string `json:"title"`
+	Type  string `json:"type"`
+	Value int    `json:"value"`
+}
+
+type bitbucketAnnotation struct {
+	ExternalID     string `json:"external_id"`
+	AnnotationType string `json:"annotation_type"`
+	Summary        string `json:"summary"`
+	Severity       string `json:"severity"`
+	Path           string `json:"path"`
+	Line           int    `json:"line"`
+}
+
+// Report writes the report document. The report fails when any issue is an error.
+// Annotations beyond Bitbucket's limit are dropped but still counted in the report data.
+func (r *BitbucketReporter) Report(w io.Writer, issues []linters.Issue) error {
+	sorted := sortedIssues(issues)
+	prints := fingerprints(r.Root, sorted)
+
+	errors, warnings := 0, 0
+	annotations := make([]bitbucketAnnotation, 0, min(len(sorted), maxBitbucketAnnotations))
+	for i, issue := range sorted {
+		if issue.Severity == "error" {
+			errors++
+		} else {
+			warnings++
+		}
+		if len(annotations) == maxBitbucketAnnotations {
+			continue
+		}
+		summary := issue.Message
+		if issue.Rule != "" {
+			summary = fmt.Sprintf("[%s] %s", issue.Rule, issue.Message)
+		}
+		annotations = append(annotations, bitbucketAnnotation{
+			ExternalID:     prints[i],
+			AnnotationType: "CODE_SMELL",
+			Summary:        summary,
+			Severity:       bitbucketSeverity(issue.Severity),
+			Path:           relPath(r.Root, issue.File),
+			Line:           max(issue.Line, 1),
+		})
+	}
+
+	result := "PASSED"
+	if errors > 0 {
+		result = "FAILED"
+	}
+	document := bitbucketDocument{
+		Report: bitbucketReport{
+			Title:      "gismo",
+			Details:    fmt.Sprintf("gismo found %d error(s) and %d warning(s)", errors, warnings),
+			ReportType: "BUG",
+			Reporter:   "gismo",
+			Result:     result,
+			Data: []bitbucketData{
+				{Title: "Errors", Type: "NUMBER", Value: errors},
+				{Title: "Warnings", Type: "NUMBER", Value: warnings},
+			},
+		},
+		Annotations: annotations,
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(document)
+}
+
+// bitbucketSeverity maps gismo severities to Code Insights severities
+func bitbucketSeverity(severity string) string {
+	switch severity {
+	case "error":
+		return "HIGH"
+	case "warning":
+		return "MEDIUM"
+	default:
+		return "LOW"
+	}
+}
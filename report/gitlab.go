@@ -0,0 +1,72 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+// GitLabReporter writes a GitLab Code Quality report, for use as a
+// `artifacts:reports:codequality` artifact
+type GitLabReporter struct {
+	Root string
+}
+
+// gitlabIssue is a single entry of a GitLab Code Quality report
+type gitlabIssue struct {
+	Description string         `json:"description"`
+	CheckName   string         `json:"check_name"`
+	Fingerprint string         `json:"fingerprint"`
+	Severity    string         `json:"severity"`
+	Location    gitlabLocation `json:"location"`
+}
+
+type gitlabLocation struct {
+	Path  string      `json:"path"`
+	Lines gitlabLines `json:"lines"`
+}
+
+type gitlabLines struct {
+	Begin int `json:"begin"`
+}
+
+// Report writes issues as a Code Quality JSON array; an empty report is "[]"
+func (r *GitLabReporter) Report(w io.Writer, issues []linters.Issue) error {
+	sorted := sortedIssues(issues)
+	prints := fingerprints(r.Root, sorted)
+
+	entries := make([]gitlabIssue, 0, len(sorted))
+	for i, issue := range sorted {
+		checkName := issue.Rule
+		if checkName == "" {
+			checkName = "gismo"
+		}
+		entries = append(entries, gitlabIssue{
+			Description: issue.Message,
+			CheckName:   checkName,
+			Fingerprint: prints[i],
+			Severity:    gitlabSeverity(issue.Severity),
+			Location: gitlabLocation{
+				Path:  relPath(r.Root, issue.File),
+				Lines: gitlabLines{Begin: max(issue.Line, 1)},
+			},
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(entries)
+}
+
+// gitlabSeverity maps gismo severities to Code Quality severities
+func gitlabSeverity(severity string) string {
+	switch severity {
+	case "error":
+		return "major"
+	case "warning":
+		return "minor"
+	default:
+		return "info"
+	}
+}
@@ -0,0 +1,29 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+// JSONReporter writes issues as a JSON array in gismo's own issue format
+type JSONReporter struct {
+	Root string
+}
+
+// Report writes issues sorted by file and position, with repository-relative
+// paths; an empty report is "[]"
+func (r *JSONReporter) Report(w io.Writer, issues []linters.Issue) error {
+	sorted := sortedIssues(issues)
+	if sorted == nil {
+		sorted = []linters.Issue{}
+	}
+	for i := range sorted {
+		sorted[i].File = relPath(r.Root, sorted[i].File)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sorted)
+}
@@ -0,0 +1,129 @@
+// Package report renders lint issues in formats understood by CI systems
+package report
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+// Report formats supported by New
+const (
+	FormatText      = "text"
+	FormatJSON      = "json"
+	FormatSARIF     = "sarif"
+	FormatGitLab    = "gitlab"
+	FormatBitbucket = "bitbucket"
+)
+
+// Reporter writes lint issues to w in a specific format
+type Reporter interface {
+	Report(w io.Writer, issues []linters.Issue) error
+}
+
+// Formats returns the supported report format names
+func Formats() []string {
+	return []string{FormatText, FormatJSON, FormatSARIF, FormatGitLab, FormatBitbucket}
+}
+
+// New returns the reporter for format. Issue paths are reported relative to root
+// when they are inside it, as CI systems expect repository-relative paths.
+func New(format, root string) (Reporter, error) {
+	switch format {
+	case FormatText, "":
+		return &TextReporter{Root: root}, nil
+	case FormatJSON:
+		return &JSONReporter{Root: root}, nil
+	case FormatSARIF:
+		return &SARIFReporter{Root: root}, nil
+	case FormatGitLab:
+		return &GitLabReporter{Root: root}, nil
+	case FormatBitbucket:
+		return &BitbucketReporter{Root: root}, nil
+	default:
+		return nil, fmt.Errorf("unknown report format %q (supported: %s)", format, strings.Join(Formats(), ", "))
+	}
+}
+
+// TextReporter writes one issue per line in the file:line:col form editors understand
+type TextReporter struct {
+	Root string
+}
+
+// Report writes issues sorted by file and position, each followed by its
+// source lines when it has them
+func (r *TextReporter) Report(w io.Writer, issues []linters.Issue) error {
+	for _, issue := range sortedIssues(issues) {
+		line := fmt.Sprintf("%s:%d:%d: %s: %s", relPath(r.Root, issue.File), issue.Line, issue.Column, issue.Severity, issue.Message)
+		if issue.Rule != "" {
+			line += fmt.Sprintf(" [%s]", issue.Rule)
+		}
+		for i, source := range issue.SourceLines {
+			line += fmt.Sprintf("\n  %5d | %s", issue.SourceStart+i, source)
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sortedIssues returns a copy of issues ordered by file, line and column
+func sortedIssues(issues []linters.Issue) []linters.Issue {
+	sorted := append([]linters.Issue(nil), issues...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].File != sorted[j].File {
+			return sorted[i].File < sorted[j].File
+		}
+		if sorted[i].Line != sorted[j].Line {
+			return sorted[i].Line < sorted[j].Line
+		}
+		return sorted[i].Column < sorted[j].Column
+	})
+	return sorted
+}
+
+// relPath returns path relative to root with forward slashes, or path unchanged
+// if it is outside root
+func relPath(root, path string) string {
+	if root == "" || !filepath.IsAbs(path) {
+		return filepath.ToSlash(path)
+	}
+	rel, err := filepath.Rel(root, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return filepath.ToSlash(path)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// fingerprint identifies an issue across runs. Line numbers are left out so the
+// fingerprint survives edits elsewhere in the file; identical issues in the same
+// file are told apart by occurrence.
+func fingerprint(path string, issue linters.Issue, occurrence int) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s\x00%s\x00%s\x00%d", path, issue.Rule, issue.Message, occurrence)))
+	return hex.EncodeToString(sum[:16])
+}
+
+// fingerprints returns a fingerprint for each of the sorted issues, keeping the
+// one the engine computed from the issue's code context when it is set
+func fingerprints(root string, issues []linters.Issue) []string {
+	seen := make(map[string]int)
+	prints := make([]string, len(issues))
+	for i, issue := range issues {
+		if issue.Fingerprint != "" {
+			prints[i] = issue.Fingerprint
+			continue
+		}
+		path := relPath(root, issue.File)
+		key := path + "\x00" + issue.Rule + "\x00" + issue.Message
+		prints[i] = fingerprint(path, issue, seen[key])
+		seen[key]++
+	}
+	return prints
+}
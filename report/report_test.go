@@ -0,0 +1,230 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+var testIssues = []linters.Issue{
+	{File: "/repo/pkg/b.go", Line: 7, Column: 2, Severity: "warning", Message: "line too long", Rule: "lll"},
+	{File: "/repo/a.go", Line: 3, Column: 1, Severity: "error", Message: "undefined: x", Rule: "typecheck"},
+	{File: "/repo/a.go", Line: 0, Column: 0, Severity: "info", Message: "file note"},
+}
+
+func TestNew(t *testing.T) {
+	for _, format := range append(Formats(), "") {
+		if _, err := New(format, "/repo"); err != nil {
+			t.Errorf("New(%q) error = %v", format, err)
+		}
+	}
+	if _, err := New("xml", ""); err == nil || !strings.Contains(err.Error(), "gitlab") {
+		t.Errorf("New(unknown) error = %v, want error listing formats", err)
+	}
+}
+
+func TestTextReporter(t *testing.T) {
+	var out bytes.Buffer
+	if err := (&TextReporter{Root: "/repo"}).Report(&out, testIssues); err != nil {
+		t.Fatal(err)
+	}
+	want := "a.go:0:0: info: file note\n" +
+		"a.go:3:1: error: undefined: x [typecheck]\n" +
+		"pkg/b.go:7:2: warning: line too long [lll]\n"
+	if out.String() != want {
+		t.Errorf("Report() =\n%s\nwant\n%s", out.String(), want)
+	}
+}
+
+func TestGitLabReporter(t *testing.T) {
+	var out bytes.Buffer
+	if err := (&GitLabReporter{Root: "/repo"}).Report(&out, testIssues); err != nil {
+		t.Fatal(err)
+	}
+
+	var entries []gitlabIssue
+	if err := json.Unmarshal(out.Bytes(), &entries); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out.String())
+	}
+	if len(entries) != 3 {
+		t.Fatalf("got %d entries, want 3", len(entries))
+	}
+
+	tests := []struct {
+		check, severity, path string
+		line                  int
+	}{
+		{"gismo", "info", "a.go", 1},
+		{"typecheck", "major", "a.go", 3},
+		{"lll", "minor", "pkg/b.go", 7},
+	}
+	seen := make(map[string]bool)
+	for i, tt := range tests {
+		entry := entries[i]
+		if entry.CheckName != tt.check || entry.Severity != tt.severity || entry.Location.Path != tt.path || entry.Location.Lines.Begin != tt.line {
+			t.Errorf("entry %d = %+v, want %+v", i, entry, tt)
+		}
+		if entry.Fingerprint == "" || seen[entry.Fingerprint] {
+			t.Errorf("entry %d fingerprint %q is empty or duplicated", i, entry.Fingerprint)
+		}
+		seen[entry.Fingerprint] = true
+	}
+
+	// Fingerprints must not depend on line numbers
+	moved := append([]linters.Issue(nil), testIssues...)
+	moved[0].Line += 10
+	var movedOut bytes.Buffer
+	_ = (&GitLabReporter{Root: "/repo"}).Report(&movedOut, moved)
+	var movedEntries []gitlabIssue
+	_ = json.Unmarshal(movedOut.Bytes(), &movedEntries)
+	if movedEntries[2].Fingerprint != entries[2].Fingerprint {
+		t.Error("fingerprint changed when the issue moved")
+	}
+
+	// Fingerprints set by the engine are kept
+	fingerprinted := append([]linters.Issue(nil), testIssues...)
+	fingerprinted[1].Fingerprint = "0123456789abcdef"
+	var fingerprintedOut bytes.Buffer
+	_ = (&GitLabReporter{Root: "/repo"}).Report(&fingerprintedOut, fingerprinted)
+	var fingerprintedEntries []gitlabIssue
+	_ = json.Unmarshal(fingerprintedOut.Bytes(), &fingerprintedEntries)
+	if got := fingerprintedEntries[1].Fingerprint; got != "0123456789abcdef" {
+		t.Errorf("fingerprint = %q, want the issue's own", got)
+	}
+
+	out.Reset()
+	_ = (&GitLabReporter{}).Report(&out, nil)
+	if strings.TrimSpace(out.String()) != "[]" {
+		t.Errorf("empty report = %q, want []", out.String())
+	}
+}
+
+func TestBitbucketReporter(t *testing.T) {
+	var out bytes.Buffer
+	if err := (&BitbucketReporter{Root: "/repo"}).Report(&out, testIssues); err != nil {
+		t.Fatal(err)
+	}
+
+	var document bitbucketDocument
+	if err := json.Unmarshal(out.Bytes(), &document); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out.String())
+	}
+	if document.Report.Result != "FAILED" || document.Report.Data[0].Value != 1 || document.Report.Data[1].Value != 2 {
+		t.Errorf("report = %+v, want FAILED with 1 error and 2 warnings", document.Report)
+	}
+	if len(document.Annotations) != 3 {
+		t.Fatalf("got %d annotations, want 3", len(document.Annotations))
+	}
+	if a := document.Annotations[1]; a.Severity != "HIGH" || a.Path != "a.go" || a.Summary != "[typecheck] undefined: x" {
+		t.Errorf("annotation = %+v", a)
+	}
+
+	out.Reset()
+	warnings := []linters.Issue{{File: "x.go", Line: 1, Severity: "warning", Message: "w"}}
+	_ = (&BitbucketReporter{}).Report(&out, warnings)
+	document = bitbucketDocument{}
+	_ = json.Unmarshal(out.Bytes(), &document)
+	if document.Report.Result != "PASSED" {
+		t.Errorf("warnings-only result = %s, want PASSED", document.Report.Result)
+	}
+}
+
+func TestJSONReporter(t *testing.T) {
+	var out bytes.Buffer
+	if err := (&JSONReporter{Root: "/repo"}).Report(&out, testIssues); err != nil {
+		t.Fatal(err)
+	}
+
+	var issues []linters.Issue
+	if err := json.Unmarshal(out.Bytes(), &issues); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out.String())
+	}
+	if len(issues) != 3 || issues[1].File != "a.go" || issues[1].Rule != "typecheck" || issues[2].File != "pkg/b.go" {
+		t.Errorf("issues = %+v, want sorted issues with relative paths", issues)
+	}
+	if testIssues[1].File != "/repo/a.go" {
+		t.Error("Report() modified the caller's issues")
+	}
+
+	out.Reset()
+	_ = (&JSONReporter{}).Report(&out, nil)
+	if strings.TrimSpace(out.String()) != "[]" {
+		t.Errorf("empty report = %q, want []", out.String())
+	}
+}
+
+func TestSARIFReporter(t *testing.T) {
+	var out bytes.Buffer
+	if err := (&SARIFReporter{Root: "/repo"}).Report(&out, testIssues); err != nil {
+		t.Fatal(err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(out.Bytes(), &log); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out.String())
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("log = %+v, want one SARIF 2.1.0 run", log)
+	}
+	run := log.Runs[0]
+	if run.Tool.Driver.Name != "gismo" || len(run.Tool.Driver.Rules) != 2 || run.Tool.Driver.Rules[0].ID != "lll" {
+		t.Errorf("driver = %+v, want gismo with rules lll and typecheck", run.Tool.Driver)
+	}
+	if len(run.Results) != 3 {
+		t.Fatalf("got %d results, want 3", len(run.Results))
+	}
+
+	tests := []struct {
+		level, uri   string
+		line, column int
+	}{
+		{"note", "a.go", 1, 0},
+		{"error", "a.go", 3, 1},
+		{"warning", "pkg/b.go", 7, 2},
+	}
+	for i, tt := range tests {
+		result := run.Results[i]
+		location := result.Locations[0].PhysicalLocation
+		if result.Level != tt.level || location.ArtifactLocation.URI != tt.uri ||
+			location.Region.StartLine != tt.line || location.Region.StartColumn != tt.column {
+			t.Errorf("result %d = %+v, want %s at %s:%d:%d", i, result, tt.level, tt.uri, tt.line, tt.column)
+		}
+		if result.PartialFingerprints["gismo/v1"] == "" {
+			t.Errorf("result %d has no fingerprint", i)
+		}
+	}
+}
+
+func TestReporters_SourceLines(t *testing.T) {
+	issues := []linters.Issue{{
+		File: "/repo/a.go", Line: 4, Column: 2, Severity: "error", Message: "undefined: x", Rule: "typecheck",
+		SourceStart: 3, SourceLines: []string{"func f() {", "\tx()", "}"},
+	}}
+
+	var out bytes.Buffer
+	if err := (&TextReporter{Root: "/repo"}).Report(&out, issues); err != nil {
+		t.Fatal(err)
+	}
+	if want := "a.go:4:2: error: undefined: x [typecheck]\n      3 | func f() {\n      4 | \tx()\n      5 | }\n"; out.String() != want {
+		t.Errorf("text report =\n%q\nwant\n%q", out.String(), want)
+	}
+
+	out.Reset()
+	if err := (&SARIFReporter{Root: "/repo"}).Report(&out, issues); err != nil {
+		t.Fatal(err)
+	}
+	var log sarifLog
+	if err := json.Unmarshal(out.Bytes(), &log); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out.String())
+	}
+	location := log.Runs[0].Results[0].Locations[0].PhysicalLocation
+	if location.Region.Snippet == nil || location.Region.Snippet.Text != "\tx()" {
+		t.Errorf("region snippet = %+v, want the issue line", location.Region.Snippet)
+	}
+	if c := location.ContextRegion; c == nil || c.StartLine != 3 || c.EndLine != 5 || c.Snippet.Text != "func f() {\n\tx()\n}" {
+		t.Errorf("context region = %+v, want lines 3-5", c)
+	}
+}
@@ -0,0 +1,155 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+// SARIF identifies the SARIF version and schema written by SARIFReporter
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+// SARIFReporter writes a SARIF 2.1.0 log, as read by GitHub code scanning and
+// most editors and security dashboards
+type SARIFReporter struct {
+	Root string
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules,omitempty"`
+}
+
+type sarifRule struct {
+	ID string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleID              string            `json:"ruleId,omitempty"`
+	Level               string            `json:"level"`
+	Message             sarifMessage      `json:"message"`
+	Locations           []sarifLocation   `json:"locations"`
+	PartialFingerprints map[string]string `json:"partialFingerprints"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+	ContextRegion    *sarifRegion          `json:"contextRegion,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int           `json:"startLine"`
+	StartColumn int           `json:"startColumn,omitempty"`
+	EndLine     int           `json:"endLine,omitempty"`
+	Snippet     *sarifSnippet `json:"snippet,omitempty"`
+}
+
+type sarifSnippet struct {
+	Text string `json:"text"`
+}
+
+// Report writes issues as a single SARIF run; an empty report has no results
+func (r *SARIFReporter) Report(w io.Writer, issues []linters.Issue) error {
+	sorted := sortedIssues(issues)
+	prints := fingerprints(r.Root, sorted)
+
+	ruleIDs := make(map[string]bool)
+	results := make([]sarifResult, 0, len(sorted))
+	for i, issue := range sorted {
+		if issue.Rule != "" {
+			ruleIDs[issue.Rule] = true
+		}
+		location := sarifPhysicalLocation{
+			ArtifactLocation: sarifArtifactLocation{URI: relPath(r.Root, issue.File)},
+			// SARIF lines start at 1; issues about the whole file use line 0
+			Region: sarifRegion{StartLine: max(issue.Line, 1), StartColumn: issue.Column},
+		}
+		if len(issue.SourceLines) > 0 {
+			if line := issue.Line - issue.SourceStart; line >= 0 && line < len(issue.SourceLines) {
+				location.Region.Snippet = &sarifSnippet{Text: issue.SourceLines[line]}
+			}
+			location.ContextRegion = &sarifRegion{
+				StartLine: issue.SourceStart,
+				EndLine:   issue.SourceStart + len(issue.SourceLines) - 1,
+				Snippet:   &sarifSnippet{Text: strings.Join(issue.SourceLines, "\n")},
+			}
+		}
+		results = append(results, sarifResult{
+			RuleID:              issue.Rule,
+			Level:               sarifLevel(issue.Severity),
+			Message:             sarifMessage{Text: issue.Message},
+			Locations:           []sarifLocation{{PhysicalLocation: location}},
+			PartialFingerprints: map[string]string{"gismo/v1": prints[i]},
+		})
+	}
+
+	rules := make([]sarifRule, 0, len(ruleIDs))
+	for id := range ruleIDs {
+		rules = append(rules, sarifRule{ID: id})
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].ID < rules[j].ID })
+
+	log := sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           "gismo",
+				InformationURI: "https://github.com/jrossi/gismo",
+				Rules:          rules,
+			}},
+			Results: results,
+		}},
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(log)
+}
+
+// sarifLevel maps gismo severities to SARIF result levels
+func sarifLevel(severity string) string {
+	switch severity {
+	case "error":
+		return "error"
+	case "warning":
+		return "warning"
+	default:
+		return "note"
+	}
+}
@@ -0,0 +1,82 @@
+package gismo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jrossi/gismo/linters"
+	"github.com/jrossi/gismo/types"
+)
+
+// ResourcesConfig limits the CPU, I/O and memory of spawned linter processes
+type ResourcesConfig struct {
+	// Nice is the niceness increment for linter processes, e.g. 10
+	Nice *int `json:"nice,omitempty"`
+	// IOClass is the ionice scheduling class, "idle" or "best-effort" (Linux only)
+	IOClass *string `json:"ioClass,omitempty"`
+	// MaxMemory caps each process's address space, e.g. "2G" (Linux only)
+	MaxMemory *string `json:"maxMemory,omitempty"`
+	// MaxCPUTime caps each process's CPU time, e.g. "2m" (Linux only)
+	MaxCPUTime *types.Duration `json:"maxCpuTime,omitempty"`
+}
+
+// GetResourceLimits returns the resource limits for spawned linter processes
+func (c *AppConfig) GetResourceLimits() (linters.ResourceLimits, error) {
+	var limits linters.ResourceLimits
+	if c == nil || c.Resources == nil {
+		return limits, nil
+	}
+	r := c.Resources
+	if r.Nice != nil {
+		limits.Nice = *r.Nice
+	}
+	if r.IOClass != nil {
+		switch *r.IOClass {
+		case linters.IOClassIdle, linters.IOClassBestEffort:
+			limits.IOClass = *r.IOClass
+		default:
+			return limits, fmt.Errorf("invalid ioClass %q: use %q or %q", *r.IOClass, linters.IOClassIdle, linters.IOClassBestEffort)
+		}
+	}
+	if r.MaxMemory != nil {
+		size, err := parseByteSize(*r.MaxMemory)
+		if err != nil {
+			return limits, fmt.Errorf("invalid maxMemory: %w", err)
+		}
+		limits.MaxMemory = size
+	}
+	if r.MaxCPUTime != nil {
+		limits.MaxCPUTime = r.MaxCPUTime.Duration
+	}
+	return limits, nil
+}
+
+// parseByteSize parses sizes like "512M", "2G" or "1048576" using binary units
+func parseByteSize(s string) (uint64, error) {
+	value := strings.ToUpper(strings.TrimSpace(s))
+	value = strings.TrimSuffix(strings.TrimSuffix(value, "B"), "I")
+
+	multiplier := uint64(1)
+	if n := len(value); n > 0 {
+		switch value[n-1] {
+		case 'K':
+			multiplier = 1 << 10
+		case 'M':
+			multiplier = 1 << 20
+		case 'G':
+			multiplier = 1 << 30
+		case 'T':
+			multiplier = 1 << 40
+		}
+		if multiplier > 1 {
+			value = value[:n-1]
+		}
+	}
+
+	n, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a size like 512M or 2G", s)
+	}
+	return n * multiplier, nil
+}
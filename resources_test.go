@@ -0,0 +1,66 @@
+package gismo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+func TestParseByteSize(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    uint64
+		wantErr bool
+	}{
+		{"1048576", 1 << 20, false},
+		{"512K", 512 << 10, false},
+		{"512M", 512 << 20, false},
+		{"2G", 2 << 30, false},
+		{"2GiB", 2 << 30, false},
+		{"1gb", 1 << 30, false},
+		{"", 0, true},
+		{"lots", 0, true},
+		{"-1G", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parseByteSize(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseByteSize(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseByteSize(%q) = %d, want %d", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAppConfig_GetResourceLimits(t *testing.T) {
+	nice, class, memory := 10, "idle", "2G"
+	config := &AppConfig{Resources: &ResourcesConfig{
+		Nice:       &nice,
+		IOClass:    &class,
+		MaxMemory:  &memory,
+		MaxCPUTime: &Duration{Duration: time.Minute},
+	}}
+	got, err := config.GetResourceLimits()
+	if err != nil {
+		t.Fatalf("GetResourceLimits() error = %v", err)
+	}
+	want := linters.ResourceLimits{Nice: 10, IOClass: "idle", MaxMemory: 2 << 30, MaxCPUTime: time.Minute}
+	if got != want {
+		t.Errorf("GetResourceLimits() = %+v, want %+v", got, want)
+	}
+
+	var empty *AppConfig
+	if got, err := empty.GetResourceLimits(); err != nil || got != (linters.ResourceLimits{}) {
+		t.Errorf("nil config limits = %+v, %v", got, err)
+	}
+
+	bad := "realtime"
+	config.Resources.IOClass = &bad
+	if _, err := config.GetResourceLimits(); err == nil {
+		t.Error("expected error for unsupported ioClass")
+	}
+}
@@ -0,0 +1,205 @@
+package gismo
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/jrossi/gismo/internal/statedir"
+	"github.com/jrossi/gismo/linters"
+	"github.com/jrossi/gismo/types"
+)
+
+// DefaultResultCacheTTL is how long a lint result is reused by default
+const DefaultResultCacheTTL = 10 * time.Minute
+
+// ResultCacheConfig controls reuse of lint results for unchanged file content
+type ResultCacheConfig struct {
+	Enabled *bool           `json:"enabled,omitempty"` // default true
+	TTL     *types.Duration `json:"ttl,omitempty"`     // default 10m
+}
+
+// ResultCache stores each linter's result for a file, keyed by the file path,
+// a SHA-256 of its content and the linter's config. The PostToolUse hook after a
+// write then reuses what the PreToolUse hook found in the same content, and
+// external tools run once per version of a file. Results are kept as one file
+// per key, so concurrent hooks never contend for a lock.
+type ResultCache struct {
+	dir string
+	ttl time.Duration
+	now func() time.Time
+	// private is set once dir is known to belong to the current user only
+	private atomic.Bool
+}
+
+// cachedResult is a stored lint result
+type cachedResult struct {
+	Result    linters.LintResult `json:"result"`
+	CreatedAt time.Time          `json:"createdAt"`
+}
+
+// NewResultCache creates a result cache in dir keeping results for ttl
+func NewResultCache(dir string, ttl time.Duration) *ResultCache {
+	return &ResultCache{dir: dir, ttl: ttl, now: time.Now}
+}
+
+// DefaultResultCacheDir returns the directory used for cached results by
+// default. A cached result skips linting, so each user keeps theirs in their
+// cache directory.
+func DefaultResultCacheDir() string {
+	return statedir.CacheDir("results")
+}
+
+// trusted reports whether results can be read from the cache directory: it
+// must exist and belong to the current user only
+func (c *ResultCache) trusted() bool {
+	if c.private.Load() {
+		return true
+	}
+	if statedir.Check(c.dir) != nil {
+		return false
+	}
+	c.private.Store(true)
+	return true
+}
+
+// path returns the file holding the result for key
+func (c *ResultCache) path(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
+
+// Get returns the result stored for key, if it hasn't expired
+func (c *ResultCache) Get(key string) (*linters.LintResult, bool) {
+	if !c.trusted() {
+		return nil, false
+	}
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+	var cached cachedResult
+	if err := json.Unmarshal(data, &cached); err != nil || c.now().Sub(cached.CreatedAt) > c.ttl {
+		return nil, false
+	}
+	return &cached.Result, true
+}
+
+// Put stores result for key. The file is written under a temporary name and
+// renamed, so a concurrent Get never reads a partial result.
+func (c *ResultCache) Put(key string, result *linters.LintResult) error {
+	if !c.private.Load() {
+		if err := statedir.Ensure(c.dir); err != nil {
+			return err
+		}
+		c.private.Store(true)
+	}
+	data, err := json.Marshal(cachedResult{Result: *result, CreatedAt: c.now()})
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(c.dir, key+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path(key))
+}
+
+// Prune removes results older than the TTL, and temporary files left behind by
+// interrupted writes
+func (c *ResultCache) Prune() error {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	now := c.now()
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasSuffix(name, ".json") && !strings.HasSuffix(name, ".tmp") {
+			continue
+		}
+		if info, err := entry.Info(); err == nil && now.Sub(info.ModTime()) > c.ttl {
+			_ = os.Remove(filepath.Join(c.dir, name))
+		}
+	}
+	return nil
+}
+
+// resultKey identifies a linter's result for content at filePath under the
+// config the linter currently has. Static-only runs skip checks such as tests,
+// so they are cached apart from full runs.
+func (e *LintingRuleEngine) resultKey(ctx context.Context, linter linters.Linter, filePath string, content []byte) string {
+	config, ok := e.applied[linter.Name()]
+	if !ok {
+		config, _ = e.config.GetLinterConfig(linter.Name())
+	}
+
+	// A custom linter's command and output format change its results too
+	var definition []byte
+	if custom, ok := e.config.GetCustomLinters()[linter.Name()]; ok {
+		definition, _ = json.Marshal(custom)
+	}
+
+	h := sha256.New()
+	for _, part := range []string{linter.Name(), filePath, string(config), string(definition)} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	if linters.IsStaticOnly(ctx) {
+		h.Write([]byte("static"))
+	}
+	h.Write([]byte{0})
+	contentSum := sha256.Sum256(content)
+	h.Write(contentSum[:])
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// executeLinters runs the linters that handle filePath on content, reusing
+// cached results where the cache has one. Results that ran tests aren't cached,
+// as they depend on more than the file, nor are partial ones.
+func (e *LintingRuleEngine) executeLinters(ctx context.Context, active []linters.Linter, filePath string, content []byte) []linters.LintTaskResult {
+	if e.results == nil {
+		return e.executor.ExecuteLinters(ctx, active, filePath, content)
+	}
+
+	var results []linters.LintTaskResult
+	var misses []linters.Linter
+	keys := make(map[string]string)
+	for _, linter := range active {
+		if !linter.CanHandle(filePath) {
+			continue
+		}
+		key := e.resultKey(ctx, linter, filePath, content)
+		if result, ok := e.results.Get(key); ok {
+			results = append(results, linters.LintTaskResult{LinterName: linter.Name(), Result: result})
+			continue
+		}
+		keys[linter.Name()] = key
+		misses = append(misses, linter)
+	}
+
+	for _, result := range e.executor.ExecuteLinters(ctx, misses, filePath, content) {
+		if result.Error == nil && result.Result != nil && result.Result.TestOutput == "" && !result.Result.Partial && ctx.Err() == nil {
+			_ = e.results.Put(keys[result.LinterName], result.Result)
+		}
+		results = append(results, result)
+	}
+	return results
+}
@@ -0,0 +1,117 @@
+package gismo
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+func TestResultCache(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	cache := NewResultCache(dir, time.Minute)
+	cache.now = func() time.Time { return now }
+
+	if _, ok := cache.Get("missing"); ok {
+		t.Error("Get() of an unknown key should miss")
+	}
+
+	result := &linters.LintResult{Issues: []linters.Issue{{Line: 3, Severity: "error", Message: "bad", Rule: "syntax"}}, Formatted: []byte("fixed\n")}
+	if err := cache.Put("key", result); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	got, ok := cache.Get("key")
+	if !ok || len(got.Issues) != 1 || got.Issues[0].Rule != "syntax" || string(got.Formatted) != "fixed\n" {
+		t.Fatalf("Get() = %+v, %v, want the stored result", got, ok)
+	}
+
+	// Expired results are neither returned nor kept by Prune
+	now = now.Add(2 * time.Minute)
+	if _, ok := cache.Get("key"); ok {
+		t.Error("Get() returned an expired result")
+	}
+	old := now.Add(-2 * time.Minute)
+	if err := os.Chtimes(filepath.Join(dir, "key.json"), old, old); err != nil {
+		t.Fatal(err)
+	}
+	if err := cache.Prune(); err != nil {
+		t.Fatalf("Prune() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "key.json")); !os.IsNotExist(err) {
+		t.Errorf("Prune() kept an expired result: %v", err)
+	}
+
+	if err := NewResultCache(filepath.Join(dir, "missing"), time.Minute).Prune(); err != nil {
+		t.Errorf("Prune() of a missing directory error = %v", err)
+	}
+}
+
+// countingLinter counts its runs
+type countingLinter struct {
+	configRecordingLinter
+	runs int
+}
+
+func (l *countingLinter) Lint(ctx context.Context, filePath string, content []byte) (*linters.LintResult, error) {
+	l.runs++
+	return l.result, l.err
+}
+
+func (l *countingLinter) ConfigSchema() json.RawMessage {
+	return json.RawMessage(`{"type": "object", "properties": {"maxLineLength": {"type": "integer"}}}`)
+}
+
+func TestLintingRuleEngine_ResultCache(t *testing.T) {
+	linter := &countingLinter{configRecordingLinter: configRecordingLinter{MockLinter: MockLinter{
+		name:      "markdown",
+		canHandle: true,
+		result:    &linters.LintResult{Issues: []linters.Issue{{Line: 1, Severity: "warning", Message: "long line", Rule: "MD013"}}},
+	}}}
+	engine := NewLintingRuleEngineWithConfig(LintingConfig{ResultCache: NewResultCache(t.TempDir(), time.Minute)})
+	engine.linters = []linters.Linter{linter}
+
+	lint := func(path, content string) []Diagnostic {
+		t.Helper()
+		diagnostics, err := engine.LintFile(context.Background(), path, []byte(content))
+		if err != nil {
+			t.Fatalf("LintFile() error = %v", err)
+		}
+		return diagnostics
+	}
+
+	steps := []struct {
+		name     string
+		path     string
+		content  string
+		wantRuns int
+	}{
+		{name: "first lint", path: "a.md", content: "# A\n", wantRuns: 1},
+		{name: "same content", path: "a.md", content: "# A\n", wantRuns: 1},
+		{name: "changed content", path: "a.md", content: "# B\n", wantRuns: 2},
+		{name: "other file", path: "b.md", content: "# B\n", wantRuns: 3},
+		{name: "inline config changes the key", path: "b.md", content: "<!-- gismo:config maxLineLength=200 -->\n# B\n", wantRuns: 4},
+		{name: "same inline config", path: "b.md", content: "<!-- gismo:config maxLineLength=200 -->\n# B\n", wantRuns: 4},
+	}
+	for _, step := range steps {
+		diagnostics := lint(step.path, step.content)
+		if linter.runs != step.wantRuns {
+			t.Errorf("%s: linter ran %d times, want %d", step.name, linter.runs, step.wantRuns)
+		}
+		if len(diagnostics) != 1 || diagnostics[0].Rule != "MD013" {
+			t.Errorf("%s: diagnostics = %+v", step.name, diagnostics)
+		}
+	}
+
+	// Results of test runs depend on other files and are never reused
+	linter.result = &linters.LintResult{Success: true, TestOutput: "ok"}
+	lint("c.md", "# C\n")
+	lint("c.md", "# C\n")
+	if linter.runs != 6 {
+		t.Errorf("linter ran %d times, want test results rerun", linter.runs)
+	}
+}
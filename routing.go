@@ -0,0 +1,35 @@
+package gismo
+
+import "github.com/jrossi/gismo/linters"
+
+// routedLinter runs a linter on a file a rule routed to it, which the linter's
+// own file type check would skip
+type routedLinter struct {
+	linters.Linter
+}
+
+// CanHandle accepts the routed file
+func (routedLinter) CanHandle(string) bool {
+	return true
+}
+
+// routedLinters returns the enabled linters for filePath like lintersFor, with
+// the linters rules route the file's content to accepting the file
+func (e *LintingRuleEngine) routedLinters(filePath string, content []byte) []linters.Linter {
+	active := e.lintersFor(filePath)
+	var routed []linters.Linter
+	for i, linter := range active {
+		if linter.CanHandle(filePath) || !e.config.IsRouted(filePath, content, linter.Name()) {
+			continue
+		}
+		if routed == nil {
+			// lintersFor may return the engine's own slice
+			routed = append([]linters.Linter(nil), active...)
+		}
+		routed[i] = routedLinter{linter}
+	}
+	if routed == nil {
+		return active
+	}
+	return routed
+}
@@ -0,0 +1,202 @@
+package gismo
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Kinds of rule problems reported by AnalyzeRules
+const (
+	// RuleInvalidPattern is a pattern filepath.Match rejects, or a firstLine that
+	// isn't a regular expression; the rule never applies
+	RuleInvalidPattern = "invalid-pattern"
+	// RuleInvalidSettings is a rules value that is not a JSON object; the rule is ignored
+	RuleInvalidSettings = "invalid-settings"
+	// RuleUnknownLinter names a linter that doesn't exist; the rule never applies
+	RuleUnknownLinter = "unknown-linter"
+	// RuleDuplicate repeats an earlier pattern and linter with different settings
+	RuleDuplicate = "duplicate"
+	// RuleRedundant repeats an earlier pattern and linter with identical settings
+	RuleRedundant = "redundant"
+	// RuleShadowed has settings that a later, broader rule always overrides
+	RuleShadowed = "shadowed"
+)
+
+// RuleProblem describes a contradictory, shadowed or invalid entry in the rules list
+type RuleProblem struct {
+	Kind     string   `json:"kind"`
+	Severity string   `json:"severity"` // "error" for rules that never apply, otherwise "warning"
+	Index    int      `json:"index"`    // index of the affected rule
+	Other    int      `json:"other"`    // index of the conflicting rule, or -1
+	Keys     []string `json:"keys,omitempty"`
+	Message  string   `json:"message"`
+}
+
+// AnalyzeRules checks the rules list for problems. Rules are applied in order and
+// later matching rules override earlier ones key by key, so a later rule matching
+// a superset of files overrides every setting it shares with an earlier rule.
+// When knownLinters is non-empty, rules naming other linters are reported.
+func (c *AppConfig) AnalyzeRules(knownLinters []string) []RuleProblem {
+	if c == nil || len(c.Rules) == 0 {
+		return nil
+	}
+
+	known := make(map[string]bool, len(knownLinters))
+	for _, name := range knownLinters {
+		known[name] = true
+	}
+
+	var problems []RuleProblem
+	settings := make([]map[string]interface{}, len(c.Rules))
+	valid := make([]bool, len(c.Rules))
+
+	for i, rule := range c.Rules {
+		if _, err := filepath.Match(rule.Pattern, ""); err != nil {
+			problems = append(problems, RuleProblem{
+				Kind: RuleInvalidPattern, Severity: "error", Index: i, Other: -1,
+				Message: fmt.Sprintf("rules[%d]: invalid pattern %q: %v", i, rule.Pattern, err),
+			})
+			continue
+		}
+		if _, err := compilePattern(rule.FirstLine); rule.FirstLine != "" && err != nil {
+			problems = append(problems, RuleProblem{
+				Kind: RuleInvalidPattern, Severity: "error", Index: i, Other: -1,
+				Message: fmt.Sprintf("rules[%d]: invalid firstLine %q: %v", i, rule.FirstLine, err),
+			})
+			continue
+		}
+		if rule.Route && len(rule.Rules) == 0 {
+			// A rule that only routes has no settings to compare
+			settings[i] = map[string]interface{}{}
+		} else if err := json.Unmarshal(rule.Rules, &settings[i]); err != nil || settings[i] == nil {
+			problems = append(problems, RuleProblem{
+				Kind: RuleInvalidSettings, Severity: "error", Index: i, Other: -1,
+				Message: fmt.Sprintf("rules[%d]: rules must be a JSON object of linter settings", i),
+			})
+			continue
+		}
+		if len(known) > 0 && rule.Linter != "*" && !known[rule.Linter] {
+			problems = append(problems, RuleProblem{
+				Kind: RuleUnknownLinter, Severity: "error", Index: i, Other: -1,
+				Message: fmt.Sprintf("rules[%d]: unknown linter %q, the rule never applies (known: %s)",
+					i, rule.Linter, strings.Join(knownLinters, ", ")),
+			})
+			continue
+		}
+		valid[i] = true
+	}
+
+	for i, earlier := range c.Rules {
+		if !valid[i] {
+			continue
+		}
+		for j := i + 1; j < len(c.Rules); j++ {
+			later := c.Rules[j]
+			if !valid[j] || (later.Linter != earlier.Linter && later.Linter != "*") {
+				continue
+			}
+
+			same, different := compareSettings(settings[i], settings[j])
+			if earlier.Pattern == later.Pattern && sameContentPredicates(earlier, later) && later.Linter == earlier.Linter {
+				if len(different) == 0 && len(same) == len(settings[i]) && len(same) == len(settings[j]) && earlier.Route == later.Route {
+					problems = append(problems, RuleProblem{
+						Kind: RuleRedundant, Severity: "warning", Index: j, Other: i,
+						Message: fmt.Sprintf("rules[%d] repeats rules[%d] (pattern %q, linter %q) with identical settings",
+							j, i, later.Pattern, later.Linter),
+					})
+					continue
+				}
+				if len(different) > 0 {
+					problems = append(problems, RuleProblem{
+						Kind: RuleDuplicate, Severity: "warning", Index: j, Other: i, Keys: different,
+						Message: fmt.Sprintf("rules[%d] repeats pattern %q for linter %q from rules[%d] with different %s; rules[%d] wins",
+							j, later.Pattern, later.Linter, i, strings.Join(different, ", "), j),
+					})
+				}
+				continue
+			}
+
+			// A later rule matching on content may skip files the earlier one matches
+			if len(different) > 0 && !later.hasContentPredicate() && patternCovers(later.Pattern, earlier.Pattern) {
+				problems = append(problems, RuleProblem{
+					Kind: RuleShadowed, Severity: "warning", Index: i, Other: j, Keys: different,
+					Message: fmt.Sprintf("rules[%d] (pattern %q) is shadowed by the broader rules[%d] (pattern %q), which always overrides %s; move the broader rule first",
+						i, earlier.Pattern, j, later.Pattern, strings.Join(different, ", ")),
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(problems, func(a, b int) bool { return problems[a].Index < problems[b].Index })
+	return problems
+}
+
+// compareSettings returns the keys two rule settings share, split into those with
+// equal and different values
+func compareSettings(a, b map[string]interface{}) (same, different []string) {
+	for key, value := range a {
+		other, ok := b[key]
+		if !ok {
+			continue
+		}
+		if reflect.DeepEqual(value, other) {
+			same = append(same, key)
+		} else {
+			different = append(different, key)
+		}
+	}
+	sort.Strings(same)
+	sort.Strings(different)
+	return same, different
+}
+
+// sameContentPredicates reports whether two rules match the same content
+func sameContentPredicates(a, b RuleOverride) bool {
+	return a.FirstLine == b.FirstLine && a.Contains == b.Contains
+}
+
+// patternCovers reports whether every file matching inner also matches outer.
+// Patterns match either the full path or the file name, so outer covers inner when
+// it matches inner itself or inner's final element, treating inner's wildcards as
+// literal text (a "*" in outer matches a "*" in inner).
+func patternCovers(outer, inner string) bool {
+	if matched, err := filepath.Match(outer, inner); err == nil && matched {
+		return true
+	}
+	matched, err := filepath.Match(outer, filepath.Base(inner))
+	return err == nil && matched
+}
+
+// SortRulesBySpecificity returns the rules ordered from broadest to most specific,
+// so specific rules are applied last and are not shadowed. Rules of equal
+// specificity keep their relative order.
+func SortRulesBySpecificity(rules []RuleOverride) []RuleOverride {
+	sorted := append([]RuleOverride(nil), rules...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return patternSpecificity(sorted[i]) < patternSpecificity(sorted[j])
+	})
+	return sorted
+}
+
+// patternSpecificity scores a rule by its literal pattern characters and path
+// depth; rules for a single linter are more specific than rules for all linters
+func patternSpecificity(rule RuleOverride) int {
+	score := 0
+	for _, r := range rule.Pattern {
+		switch r {
+		case '*', '?', '[', ']':
+		case '/':
+			score += 10
+		default:
+			score++
+		}
+	}
+	if rule.Linter != "*" {
+		score++
+	}
+	return score
+}
@@ -0,0 +1,137 @@
+package gismo
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestAppConfig_AnalyzeRules(t *testing.T) {
+	rule := func(pattern, linter, rules string) RuleOverride {
+		return RuleOverride{Pattern: pattern, Linter: linter, Rules: json.RawMessage(rules)}
+	}
+	known := []string{"go", "python", "markdown"}
+
+	tests := []struct {
+		name  string
+		rules []RuleOverride
+		want  []string // kind@index for each problem, in order
+	}{
+		{
+			name: "specific after broad is fine",
+			rules: []RuleOverride{
+				rule("*", "*", `{"maxLineLength": 120}`),
+				rule("*_test.go", "go", `{"maxLineLength": 200}`),
+			},
+		},
+		{
+			name: "broad wildcard after specific shadows it",
+			rules: []RuleOverride{
+				rule("*_test.go", "go", `{"maxLineLength": 200}`),
+				rule("*", "*", `{"maxLineLength": 120}`),
+			},
+			want: []string{"shadowed@0"},
+		},
+		{
+			name: "broad rule with other keys does not shadow",
+			rules: []RuleOverride{
+				rule("*_test.go", "go", `{"maxLineLength": 200}`),
+				rule("*", "*", `{"verbose": true}`),
+			},
+		},
+		{
+			name: "later rule for another linter does not shadow",
+			rules: []RuleOverride{
+				rule("*_test.go", "go", `{"maxLineLength": 200}`),
+				rule("*", "python", `{"maxLineLength": 120}`),
+			},
+		},
+		{
+			name: "duplicate pattern with different settings",
+			rules: []RuleOverride{
+				rule("*.md", "markdown", `{"maxLineLength": 80}`),
+				rule("*.md", "markdown", `{"maxLineLength": 120}`),
+			},
+			want: []string{"duplicate@1"},
+		},
+		{
+			name: "identical duplicate is redundant",
+			rules: []RuleOverride{
+				rule("*.md", "markdown", `{"maxLineLength": 80}`),
+				rule("*.md", "markdown", `{"maxLineLength": 80}`),
+			},
+			want: []string{"redundant@1"},
+		},
+		{
+			name: "same pattern matching other content is not a duplicate",
+			rules: []RuleOverride{
+				rule("*.yaml", "go", `{"maxLineLength": 80}`),
+				{Pattern: "*.yaml", Contains: "apiVersion:", Linter: "go", Rules: json.RawMessage(`{"maxLineLength": 120}`)},
+			},
+		},
+		{
+			name: "later content rule does not shadow",
+			rules: []RuleOverride{
+				rule("*_test.go", "go", `{"maxLineLength": 200}`),
+				{Pattern: "*", FirstLine: "^package", Linter: "go", Rules: json.RawMessage(`{"maxLineLength": 120}`)},
+			},
+		},
+		{
+			name: "route without settings",
+			rules: []RuleOverride{
+				{FirstLine: "^#!/usr/bin/env bash", Linter: "python", Route: true},
+				{FirstLine: "^#!/usr/bin/env (", Linter: "python", Route: true},
+			},
+			want: []string{"invalid-pattern@1"},
+		},
+		{
+			name: "invalid entries",
+			rules: []RuleOverride{
+				rule("[", "go", `{}`),
+				rule("*.go", "go", `[1]`),
+				rule("*.rb", "ruby", `{}`),
+			},
+			want: []string{"invalid-pattern@0", "invalid-settings@1", "unknown-linter@2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &AppConfig{Rules: tt.rules}
+			var got []string
+			for _, problem := range config.AnalyzeRules(known) {
+				got = append(got, fmt.Sprintf("%s@%d", problem.Kind, problem.Index))
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("AnalyzeRules() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortRulesBySpecificity(t *testing.T) {
+	rules := []RuleOverride{
+		{Pattern: "internal/*_test.go", Linter: "go"},
+		{Pattern: "*_test.go", Linter: "go"},
+		{Pattern: "*", Linter: "*"},
+		{Pattern: "*.go", Linter: "go"},
+	}
+
+	var got []string
+	for _, rule := range SortRulesBySpecificity(rules) {
+		got = append(got, rule.Pattern)
+	}
+	want := "*,*.go,*_test.go,internal/*_test.go"
+	if strings.Join(got, ",") != want {
+		t.Errorf("SortRulesBySpecificity() = %v, want %s", got, want)
+	}
+
+	sorted := &AppConfig{Rules: SortRulesBySpecificity([]RuleOverride{
+		{Pattern: "*_test.go", Linter: "go", Rules: json.RawMessage(`{"maxLineLength": 200}`)},
+		{Pattern: "*", Linter: "*", Rules: json.RawMessage(`{"maxLineLength": 120}`)},
+	})}
+	if problems := sorted.AnalyzeRules(nil); len(problems) != 0 {
+		t.Errorf("sorted rules still have problems: %+v", problems)
+	}
+}
@@ -0,0 +1,224 @@
+package gismo
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+
+	"github.com/goccy/go-json"
+)
+
+// RulePackManifest is the file name of a rule pack's manifest, at the top of an
+// archive or one directory down
+const RulePackManifest = "gismo-pack.json"
+
+// RulePackDir is the directory next to a config file that installed packs live in
+const RulePackDir = "gismo-packs"
+
+// maxRulePackBytes bounds the size of a rule pack and of its manifest
+const maxRulePackBytes = 10 << 20
+
+// rulePackName is the form of pack names, which double as file names
+var rulePackName = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
+
+// RulePack is a shareable bundle of linter settings and pattern rules, such as
+// markdown frontmatter schemas and per-path overrides, distributed as a JSON
+// manifest on its own or inside a .tar.gz or .zip archive
+type RulePack struct {
+	Name        string                  `json:"name"`
+	Version     string                  `json:"version"`
+	Description string                  `json:"description,omitempty"`
+	Source      string                  `json:"source,omitempty"` // where the pack was installed from
+	Linters     map[string]LinterConfig `json:"linters,omitempty"`
+	Rules       []RuleOverride          `json:"rules,omitempty"`
+}
+
+// ReadRulePack reads a rule pack from a manifest or an archive containing one.
+// Unknown manifest fields are rejected, so policy a pack carries is never dropped silently.
+func ReadRulePack(data []byte) (*RulePack, error) {
+	manifest, err := rulePackManifest(data)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(manifest))
+	decoder.DisallowUnknownFields()
+	var pack RulePack
+	if err := decoder.Decode(&pack); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", RulePackManifest, err)
+	}
+	if !rulePackName.MatchString(pack.Name) {
+		return nil, fmt.Errorf("invalid rule pack name %q: use lowercase letters, digits, '.', '_' and '-'", pack.Name)
+	}
+	if pack.Version == "" {
+		return nil, fmt.Errorf("rule pack %s has no version", pack.Name)
+	}
+	return &pack, nil
+}
+
+// rulePackManifest returns the manifest in data, which is a gzipped tar archive,
+// a zip archive or the manifest itself
+func rulePackManifest(data []byte) ([]byte, error) {
+	switch {
+	case bytes.HasPrefix(data, []byte{0x1f, 0x8b}):
+		gz, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("invalid rule pack archive: %w", err)
+		}
+		defer gz.Close()
+		archive := tar.NewReader(gz)
+		for {
+			header, err := archive.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("invalid rule pack archive: %w", err)
+			}
+			if header.Typeflag == tar.TypeReg && isManifestPath(header.Name) {
+				return io.ReadAll(io.LimitReader(archive, maxRulePackBytes))
+			}
+		}
+	case bytes.HasPrefix(data, []byte("PK")):
+		archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return nil, fmt.Errorf("invalid rule pack archive: %w", err)
+		}
+		for _, file := range archive.File {
+			if !isManifestPath(file.Name) {
+				continue
+			}
+			rc, err := file.Open()
+			if err != nil {
+				return nil, fmt.Errorf("invalid rule pack archive: %w", err)
+			}
+			defer rc.Close()
+			return io.ReadAll(io.LimitReader(rc, maxRulePackBytes))
+		}
+	default:
+		return data, nil
+	}
+	return nil, fmt.Errorf("rule pack archive has no %s", RulePackManifest)
+}
+
+// isManifestPath reports whether an archive entry is the pack manifest, either at
+// the top level or in the single directory archives are often wrapped in
+func isManifestPath(name string) bool {
+	name = path.Clean(name)
+	dir, file := path.Split(name)
+	return file == RulePackManifest && (dir == "" || path.Dir(path.Clean(dir)) == ".")
+}
+
+// Config returns the pack's settings as a config layer
+func (p *RulePack) Config() *AppConfig {
+	config := NewAppConfig()
+	for name, linter := range p.Linters {
+		config.Linters[name] = linter
+	}
+	config.Rules = append(config.Rules, p.Rules...)
+	config.normalizeLinterNames()
+	return config
+}
+
+// InstallRulePack stores pack in the gismo-packs directory next to configPath
+// and adds it to the config file's packs, creating the file if needed. An
+// installed pack of the same name is replaced; its version is returned.
+func InstallRulePack(configPath string, pack *RulePack) (previous string, err error) {
+	packPath := filepath.Join(filepath.Dir(configPath), RulePackDir, pack.Name+".json")
+	if existing, err := loadRulePack(packPath); err == nil {
+		previous = existing.Version
+	}
+
+	data, err := json.MarshalIndent(pack, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(packPath), 0750); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", filepath.Dir(packPath), err)
+	}
+	if err := os.WriteFile(packPath, append(data, '\n'), 0600); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", packPath, err)
+	}
+
+	// Edit the config file as raw JSON so settings gismo doesn't model survive
+	config := make(map[string]json.RawMessage)
+	if existing, err := os.ReadFile(configPath); err == nil { // #nosec G304 - project config path
+		if err := json.Unmarshal(existing, &config); err != nil {
+			return "", fmt.Errorf("failed to parse config file %s: %w", configPath, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return "", fmt.Errorf("failed to read config file %s: %w", configPath, err)
+	}
+
+	var packs []string
+	if raw, ok := config["packs"]; ok {
+		if err := json.Unmarshal(raw, &packs); err != nil {
+			return "", fmt.Errorf("config file %s: packs must be a list of names: %w", configPath, err)
+		}
+	}
+	for _, name := range packs {
+		if name == pack.Name {
+			return previous, nil
+		}
+	}
+	if config["packs"], err = json.Marshal(append(packs, pack.Name)); err != nil {
+		return "", err
+	}
+
+	data, err = json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(configPath), 0750); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", filepath.Dir(configPath), err)
+	}
+	if err := os.WriteFile(configPath, append(data, '\n'), 0600); err != nil {
+		return "", fmt.Errorf("failed to write config file %s: %w", configPath, err)
+	}
+	return previous, nil
+}
+
+// InstalledRulePacks returns the packs installed next to configPath, keyed by name
+func InstalledRulePacks(configPath string) (map[string]*RulePack, error) {
+	dir := filepath.Join(filepath.Dir(configPath), RulePackDir)
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return map[string]*RulePack{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	packs := make(map[string]*RulePack)
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		pack, err := loadRulePack(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		packs[pack.Name] = pack
+	}
+	return packs, nil
+}
+
+// loadRulePack reads an installed pack
+func loadRulePack(packPath string) (*RulePack, error) {
+	data, err := os.ReadFile(packPath) // #nosec G304 - pack path derived from the config file
+	if err != nil {
+		return nil, err
+	}
+	pack, err := ReadRulePack(data)
+	if err != nil {
+		return nil, fmt.Errorf("rule pack %s: %w", packPath, err)
+	}
+	return pack, nil
+}
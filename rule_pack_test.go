@@ -0,0 +1,164 @@
+package gismo
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testPackManifest = `{
+  "name": "acme-docs",
+  "version": "1.0.0",
+  "description": "ACME documentation policy",
+  "linters": {"markdown": {"config": {"maxLineLength": 100, "requireFrontmatter": true}}},
+  "rules": [{"pattern": "CHANGELOG.md", "linter": "markdown", "rules": {"maxLineLength": 200}}]
+}`
+
+// tarGzPack wraps the manifest in a gzipped tar archive under dir
+func tarGzPack(t *testing.T, dir string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	archive := tar.NewWriter(gz)
+	for name, body := range map[string]string{dir + "README.md": "# Pack\n", dir + RulePackManifest: testPackManifest} {
+		if err := archive.WriteHeader(&tar.Header{Name: name, Mode: 0600, Size: int64(len(body)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := archive.Write([]byte(body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// zipPack wraps the manifest in a zip archive
+func zipPack(t *testing.T) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	file, err := archive.Create(RulePackManifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := file.Write([]byte(testPackManifest)); err != nil {
+		t.Fatal(err)
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestReadRulePack(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr string
+	}{
+		{name: "manifest", data: []byte(testPackManifest)},
+		{name: "tar.gz", data: tarGzPack(t, "")},
+		{name: "tar.gz in a directory", data: tarGzPack(t, "acme-docs-1.0.0/")},
+		{name: "zip", data: zipPack(t)},
+		{name: "nested too deep", data: tarGzPack(t, "a/b/"), wantErr: "has no gismo-pack.json"},
+		{name: "unknown field", data: []byte(`{"name": "p", "version": "1", "plugins": ["x"]}`), wantErr: "unknown field"},
+		{name: "bad name", data: []byte(`{"name": "../p", "version": "1"}`), wantErr: "invalid rule pack name"},
+		{name: "no version", data: []byte(`{"name": "p"}`), wantErr: "has no version"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pack, err := ReadRulePack(tt.data)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ReadRulePack() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReadRulePack() error = %v", err)
+			}
+			if pack.Name != "acme-docs" || pack.Version != "1.0.0" || len(pack.Rules) != 1 || pack.Linters["markdown"].Config == nil {
+				t.Errorf("ReadRulePack() = %+v", pack)
+			}
+		})
+	}
+}
+
+func TestInstallRulePack(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, ".claude", "gismo.json")
+	if err := os.MkdirAll(filepath.Dir(configPath), 0750); err != nil {
+		t.Fatal(err)
+	}
+	// The project's own settings win over the pack's, and unmodeled keys survive
+	project := `{"linters": {"markdown": {"config": {"maxLineLength": 120}}}, "custom": {"kept": true}}`
+	if err := os.WriteFile(configPath, []byte(project), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	pack, err := ReadRulePack([]byte(testPackManifest))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if previous, err := InstallRulePack(configPath, pack); err != nil || previous != "" {
+		t.Fatalf("InstallRulePack() = %q, %v", previous, err)
+	}
+	pack.Version = "1.1.0"
+	if previous, err := InstallRulePack(configPath, pack); err != nil || previous != "1.0.0" {
+		t.Fatalf("InstallRulePack() upgrade = %q, %v, want previous 1.0.0", previous, err)
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Count(string(data), "acme-docs") != 1 || !strings.Contains(string(data), `"kept": true`) {
+		t.Errorf("config file after install:\n%s", data)
+	}
+
+	loader := &ConfigLoader{projectDir: dir, homeDir: t.TempDir()}
+	config, err := loader.LoadConfigWithPaths([]string{configPath})
+	if err != nil {
+		t.Fatalf("LoadConfigWithPaths() error = %v", err)
+	}
+	var markdown map[string]interface{}
+	if err := json.Unmarshal(config.Linters["markdown"].Config, &markdown); err != nil {
+		t.Fatal(err)
+	}
+	if markdown["maxLineLength"] != float64(120) || markdown["requireFrontmatter"] != true {
+		t.Errorf("markdown config = %v, want the pack's settings under the project's", markdown)
+	}
+	if len(config.Rules) != 1 || len(config.Packs) != 1 {
+		t.Errorf("rules = %v, packs = %v", config.Rules, config.Packs)
+	}
+
+	// Each pack is a layer of its own, before the file that lists it
+	layers, err := loader.LoadConfigLayers([]string{configPath})
+	if err != nil || len(layers) != 2 || layers[0].Source != configPath+" (pack acme-docs)" || layers[1].Source != configPath {
+		t.Errorf("LoadConfigLayers() = %+v, %v", layers, err)
+	}
+
+	installed, err := InstalledRulePacks(configPath)
+	if err != nil || installed["acme-docs"] == nil || installed["acme-docs"].Version != "1.1.0" {
+		t.Errorf("InstalledRulePacks() = %v, %v", installed, err)
+	}
+
+	// A listed pack that isn't installed is an error, not silently missing policy
+	if err := os.Remove(filepath.Join(dir, ".claude", RulePackDir, "acme-docs.json")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loader.LoadConfigWithPaths([]string{configPath}); err == nil || !strings.Contains(err.Error(), "not installed") {
+		t.Errorf("LoadConfigWithPaths() with a missing pack error = %v", err)
+	}
+}
@@ -0,0 +1,218 @@
+package gismo
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+// Rule overrides live in the project's .claude directory, next to the log of
+// every override created and every block it relaxed
+const (
+	relaxationFile = "gismo-override.json"
+	relaxationLog  = "gismo-override.log"
+)
+
+// Actions recorded in the rule override log
+const (
+	RelaxationCreated = "created"
+	RelaxationApplied = "relaxed"
+)
+
+// RuleRelaxation temporarily turns the errors of some rules into warnings, for
+// when a person decides a block is a false positive in the middle of a session
+type RuleRelaxation struct {
+	// Rules are the rules relaxed, such as "errcheck" or "MD013"
+	Rules []string `json:"rules"`
+	// Paths limit the override to matching files, relative to the project root
+	// and following gitignore rules; empty means every file
+	Paths []string `json:"paths,omitempty"`
+	// Session limits the override to one Claude session; empty means any session
+	Session string `json:"session,omitempty"`
+	// Expires is when the override stops applying
+	Expires time.Time `json:"expires"`
+	// Reason and By record why and by whom the override was made
+	Reason string `json:"reason,omitempty"`
+	By     string `json:"by,omitempty"`
+	// Created is when the override was made
+	Created time.Time `json:"created"`
+}
+
+// RuleRelaxations is the content of .claude/gismo-override.json
+type RuleRelaxations struct {
+	Overrides []RuleRelaxation `json:"overrides"`
+}
+
+// RelaxationRecord is one line of the rule override log
+type RelaxationRecord struct {
+	Time    time.Time `json:"time"`
+	Action  string    `json:"action"`
+	Session string    `json:"session,omitempty"`
+	Rules   []string  `json:"rules"`
+	// File and Message describe the error a relaxed block reported
+	File    string    `json:"file,omitempty"`
+	Message string    `json:"message,omitempty"`
+	Expires time.Time `json:"expires"`
+	Reason  string    `json:"reason,omitempty"`
+	By      string    `json:"by,omitempty"`
+}
+
+// RelaxationPath returns the rule override file of the project at root
+func RelaxationPath(root string) string {
+	return filepath.Join(root, ".claude", relaxationFile)
+}
+
+// RelaxationLogPath returns the rule override log of the project at root
+func RelaxationLogPath(root string) string {
+	return filepath.Join(root, ".claude", relaxationLog)
+}
+
+// LoadRuleRelaxations reads the rule overrides of the project at root. A missing
+// file has no overrides.
+func LoadRuleRelaxations(root string) (*RuleRelaxations, error) {
+	data, err := os.ReadFile(RelaxationPath(root)) // #nosec G304 - the project's override file
+	if os.IsNotExist(err) {
+		return &RuleRelaxations{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rule overrides: %w", err)
+	}
+	var overrides RuleRelaxations
+	if err := json.Unmarshal(data, &overrides); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", RelaxationPath(root), err)
+	}
+	return &overrides, nil
+}
+
+// Active returns the overrides that haven't expired at now
+func (o *RuleRelaxations) Active(now time.Time) []RuleRelaxation {
+	var active []RuleRelaxation
+	for _, override := range o.Overrides {
+		if now.Before(override.Expires) {
+			active = append(active, override)
+		}
+	}
+	return active
+}
+
+// AddRuleRelaxation adds override to the project at root, dropping expired
+// overrides from the file, and records it in the override log
+func AddRuleRelaxation(root string, override RuleRelaxation) error {
+	overrides, err := LoadRuleRelaxations(root)
+	if err != nil {
+		return err
+	}
+	if override.Created.IsZero() {
+		override.Created = time.Now()
+	}
+	overrides.Overrides = append(overrides.Active(override.Created), override)
+
+	data, err := json.MarshalIndent(overrides, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode rule overrides: %w", err)
+	}
+	path := RelaxationPath(root)
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return fmt.Errorf("failed to create .claude directory: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("failed to write rule overrides: %w", err)
+	}
+	return appendRelaxationRecord(root, RelaxationRecord{
+		Time:    override.Created,
+		Action:  RelaxationCreated,
+		Session: override.Session,
+		Rules:   override.Rules,
+		Expires: override.Expires,
+		Reason:  override.Reason,
+		By:      override.By,
+	})
+}
+
+// appendRelaxationRecord appends record to the override log of the project at root
+func appendRelaxationRecord(root string, record RelaxationRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	file, err := os.OpenFile(RelaxationLogPath(root), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600) // #nosec G304 - the project's override log
+	if err != nil {
+		return fmt.Errorf("failed to open rule override log: %w", err)
+	}
+	defer file.Close()
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write rule override log: %w", err)
+	}
+	return nil
+}
+
+// relaxes reports whether the override relaxes rule for relPath in sessionID
+func (o RuleRelaxation) relaxes(sessionID, relPath, rule string) bool {
+	if rule == "" || !slices.Contains(o.Rules, rule) {
+		return false
+	}
+	if o.Session != "" && o.Session != sessionID {
+		return false
+	}
+	if len(o.Paths) == 0 {
+		return true
+	}
+	matched, err := matchesPathPattern(relPath, o.Paths)
+	return err == nil && matched
+}
+
+// relaxRules turns errors whose rule a live override relaxes into
+// warnings, logging each one, and returns a note for the feedback. Overrides
+// are read on every hook, so ones added mid-session apply at once.
+func (e *LintingRuleEngine) relaxRules(sessionID, filePath string, errorIssues []linters.Issue) (blocking, relaxed []linters.Issue, note string) {
+	if len(errorIssues) == 0 {
+		return errorIssues, nil, ""
+	}
+	root := e.projectRoot()
+	overrides, err := LoadRuleRelaxations(root)
+	if err != nil {
+		return errorIssues, nil, e.messages.Sprintf("relax.invalid", err)
+	}
+	now := time.Now()
+	active := overrides.Active(now)
+	if len(active) == 0 {
+		return errorIssues, nil, ""
+	}
+	rel, _ := e.projectRelPath(filePath)
+
+	var rules []string
+	for _, issue := range errorIssues {
+		i := slices.IndexFunc(active, func(o RuleRelaxation) bool { return o.relaxes(sessionID, rel, issue.Rule) })
+		if i < 0 {
+			blocking = append(blocking, issue)
+			continue
+		}
+		override := active[i]
+		_ = appendRelaxationRecord(root, RelaxationRecord{
+			Time:    now,
+			Action:  RelaxationApplied,
+			Session: sessionID,
+			Rules:   []string{issue.Rule},
+			File:    rel,
+			Message: issue.Message,
+			Expires: override.Expires,
+			Reason:  override.Reason,
+			By:      override.By,
+		})
+		issue.Severity = "warning"
+		relaxed = append(relaxed, issue)
+		if !slices.Contains(rules, issue.Rule) {
+			rules = append(rules, issue.Rule)
+		}
+	}
+	if len(relaxed) > 0 {
+		note = e.messages.Sprintf("relax.applied", len(relaxed), strings.Join(rules, ", "))
+	}
+	return blocking, relaxed, note
+}
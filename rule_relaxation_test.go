@@ -0,0 +1,117 @@
+package gismo
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+func TestRuleRelaxations_Active(t *testing.T) {
+	now := time.Now()
+	relaxations := &RuleRelaxations{Overrides: []RuleRelaxation{
+		{Rules: []string{"expired"}, Expires: now.Add(-time.Minute)},
+		{Rules: []string{"live"}, Expires: now.Add(time.Minute)},
+	}}
+	active := relaxations.Active(now)
+	if len(active) != 1 || active[0].Rules[0] != "live" {
+		t.Errorf("Active() = %+v", active)
+	}
+
+	relaxation := RuleRelaxation{Rules: []string{"errcheck"}, Paths: []string{"/internal/"}, Session: "s1"}
+	tests := []struct {
+		session, path, rule string
+		want                bool
+	}{
+		{"s1", "internal/db.go", "errcheck", true},
+		{"s2", "internal/db.go", "errcheck", false},
+		{"s1", "cmd/main.go", "errcheck", false},
+		{"s1", "internal/db.go", "govet", false},
+		{"s1", "internal/db.go", "", false},
+	}
+	for _, tt := range tests {
+		if got := relaxation.relaxes(tt.session, tt.path, tt.rule); got != tt.want {
+			t.Errorf("relaxes(%q, %q, %q) = %v, want %v", tt.session, tt.path, tt.rule, got, tt.want)
+		}
+	}
+}
+
+func TestAddRuleRelaxation(t *testing.T) {
+	root := t.TempDir()
+	now := time.Now()
+	if err := AddRuleRelaxation(root, RuleRelaxation{Rules: []string{"old"}, Expires: now.Add(-time.Hour), Created: now.Add(-2 * time.Hour)}); err != nil {
+		t.Fatal(err)
+	}
+	if err := AddRuleRelaxation(root, RuleRelaxation{Rules: []string{"MD013"}, Expires: now.Add(time.Hour), Reason: "long URLs", By: "alex"}); err != nil {
+		t.Fatal(err)
+	}
+
+	relaxations, err := LoadRuleRelaxations(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(relaxations.Overrides) != 1 || relaxations.Overrides[0].Rules[0] != "MD013" || relaxations.Overrides[0].Created.IsZero() {
+		t.Errorf("expired overrides should be dropped when adding one: %+v", relaxations.Overrides)
+	}
+	log, _ := os.ReadFile(RelaxationLogPath(root))
+	if lines := strings.Split(strings.TrimSpace(string(log)), "\n"); len(lines) != 2 || !strings.Contains(lines[1], `"action":"created"`) || !strings.Contains(lines[1], `"reason":"long URLs"`) {
+		t.Errorf("override log:\n%s", log)
+	}
+
+	if relaxations, err := LoadRuleRelaxations(t.TempDir()); err != nil || len(relaxations.Overrides) != 0 {
+		t.Errorf("missing override file: %+v, %v", relaxations, err)
+	}
+}
+
+func TestLintingRuleEngine_RelaxedRules(t *testing.T) {
+	root := t.TempDir()
+	engine := NewLintingRuleEngineWithConfig(LintingConfig{ProjectRoot: root})
+	engine.SetFeedbackWriter(io.Discard)
+	engine.linters = []linters.Linter{&MockLinter{canHandle: true, result: &linters.LintResult{
+		Success: false,
+		Issues: []linters.Issue{
+			{File: "main.go", Line: 3, Column: 1, Severity: "error", Message: "error return value not checked", Rule: "errcheck"},
+		},
+	}}}
+
+	evaluate := func(sessionID string) *HookResponse {
+		t.Helper()
+		response, err := engine.EvaluatePreToolUse(context.Background(), &PreToolUseMessage{
+			BaseHookMessage: BaseHookMessage{SessionID: sessionID},
+			ToolName:        "Write",
+			ToolInput: testConvertToRawMessage(map[string]interface{}{
+				"file_path": filepath.Join(root, "main.go"),
+				"content":   "package main\n",
+			}),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return response
+	}
+
+	if response := evaluate("s1"); response.Decision != "block" {
+		t.Fatalf("without an override: %+v", response)
+	}
+
+	if err := AddRuleRelaxation(root, RuleRelaxation{Rules: []string{"errcheck"}, Session: "s1", Expires: time.Now().Add(time.Hour), Reason: "false positive"}); err != nil {
+		t.Fatal(err)
+	}
+	response := evaluate("s1")
+	if response.Decision != "approve" || !strings.Contains(response.Message, "1 error(s) from errcheck didn't block") {
+		t.Errorf("relaxed rule: %+v", response)
+	}
+	if response := evaluate("s2"); response.Decision != "block" {
+		t.Errorf("other session: %+v", response)
+	}
+
+	log, _ := os.ReadFile(RelaxationLogPath(root))
+	if !strings.Contains(string(log), `"action":"relaxed","session":"s1","rules":["errcheck"],"file":"main.go"`) {
+		t.Errorf("relaxed block not logged:\n%s", log)
+	}
+}
@@ -2,6 +2,9 @@ package gismo
 
 import (
 	"context"
+	"io"
+
+	"github.com/jrossi/gismo/linters"
 )
 
 // RuleEngine defines the interface for evaluating hook messages
@@ -71,7 +74,17 @@ func (e *BaseRuleEngine) EvaluatePreCompact(ctx context.Context, msg *PreCompact
 	return nil, nil
 }
 
-// CompositeRuleEngine combines multiple rule engines
+// CompositeRuleEngine evaluates hooks with several rule engines in order and
+// merges their responses:
+//
+//   - a block wins over an approval, and the first block ends the evaluation
+//   - a response that stops Claude (continue false) ends the evaluation, and its
+//     stop reason is kept
+//   - messages and reasons of the responses evaluated are joined in order
+//   - output is suppressed if any engine suppresses it
+//
+// Engines that return nil have no opinion. An error from any engine fails the
+// evaluation.
 type CompositeRuleEngine struct {
 	engines []RuleEngine
 }
@@ -88,86 +101,137 @@ func (c *CompositeRuleEngine) AddEngine(engine RuleEngine) {
 	c.engines = append(c.engines, engine)
 }
 
-// EvaluatePreToolUse runs all engines and returns the first blocking response
-func (c *CompositeRuleEngine) EvaluatePreToolUse(ctx context.Context, msg *PreToolUseMessage) (*HookResponse, error) {
+// SetFeedbackWriter redirects the feedback of the engines that write any
+func (c *CompositeRuleEngine) SetFeedbackWriter(w io.Writer) {
 	for _, engine := range c.engines {
-		response, err := engine.EvaluatePreToolUse(ctx, msg)
-		if err != nil {
-			return nil, err
-		}
-		if response != nil && response.Decision == "block" {
-			return response, nil
+		if aware, ok := engine.(FeedbackAware); ok {
+			aware.SetFeedbackWriter(w)
 		}
 	}
-	return &HookResponse{Decision: "approve"}, nil
 }
 
-// EvaluatePostToolUse runs all engines and returns the first non-nil response
-func (c *CompositeRuleEngine) EvaluatePostToolUse(ctx context.Context, msg *PostToolUseMessage) (*HookResponse, error) {
+// TrackBlock passes a block replayed from the decision cache to the engines that
+// track blocks, so escalation applies as if the engines had evaluated it again
+func (c *CompositeRuleEngine) TrackBlock(sessionID, filePath string, errorIssues []linters.Issue, response *HookResponse) *HookResponse {
 	for _, engine := range c.engines {
-		response, err := engine.EvaluatePostToolUse(ctx, msg)
-		if err != nil {
-			return nil, err
-		}
-		if response != nil {
-			return response, nil
+		if tracker, ok := engine.(BlockTracker); ok {
+			response = tracker.TrackBlock(sessionID, filePath, errorIssues, response)
 		}
 	}
-	return nil, nil
+	return response
 }
 
-// EvaluateNotification runs all engines and returns the first non-nil response
-func (c *CompositeRuleEngine) EvaluateNotification(ctx context.Context, msg *NotificationMessage) (*HookResponse, error) {
+// evaluate runs evaluate on each engine until one blocks or stops, and merges
+// the responses
+func (c *CompositeRuleEngine) evaluate(evaluate func(RuleEngine) (*HookResponse, error)) (*HookResponse, error) {
+	var merged *HookResponse
 	for _, engine := range c.engines {
-		response, err := engine.EvaluateNotification(ctx, msg)
+		response, err := evaluate(engine)
 		if err != nil {
 			return nil, err
 		}
-		if response != nil {
-			return response, nil
+		if response == nil {
+			continue
+		}
+		merged = mergeHookResponses(merged, response)
+		if merged.Decision == "block" || (merged.Continue != nil && !*merged.Continue) {
+			break
 		}
 	}
-	return nil, nil
+	return merged, nil
 }
 
-// EvaluateStop runs all engines and returns the first non-nil response
-func (c *CompositeRuleEngine) EvaluateStop(ctx context.Context, msg *StopMessage) (*HookResponse, error) {
-	for _, engine := range c.engines {
-		response, err := engine.EvaluateStop(ctx, msg)
-		if err != nil {
-			return nil, err
+// mergeHookResponses merges next into the responses merged so far
+func mergeHookResponses(merged, next *HookResponse) *HookResponse {
+	if merged == nil {
+		copied := *next
+		return &copied
+	}
+	if next.Decision == "block" {
+		// A block's reason explains the block; earlier approvals' reasons don't
+		if merged.Decision != "block" {
+			merged.Reason = ""
 		}
-		if response != nil {
-			return response, nil
+		merged.Decision = "block"
+		merged.Reason = joinFeedback(merged.Reason, next.Reason)
+	} else if merged.Decision != "block" {
+		if merged.Decision == "" {
+			merged.Decision = next.Decision
 		}
+		merged.Reason = joinFeedback(merged.Reason, next.Reason)
 	}
-	return nil, nil
+	merged.Message = joinFeedback(merged.Message, next.Message)
+	if next.Continue != nil && (merged.Continue == nil || *merged.Continue) {
+		merged.Continue = next.Continue
+		merged.StopReason = next.StopReason
+	}
+	if next.SuppressOutput != nil && (merged.SuppressOutput == nil || *next.SuppressOutput) {
+		merged.SuppressOutput = next.SuppressOutput
+	}
+	merged.NoCache = merged.NoCache || next.NoCache
+	return merged
 }
 
-// EvaluateSubagentStop runs all engines and returns the first non-nil response
-func (c *CompositeRuleEngine) EvaluateSubagentStop(ctx context.Context, msg *SubagentStopMessage) (*HookResponse, error) {
-	for _, engine := range c.engines {
-		response, err := engine.EvaluateSubagentStop(ctx, msg)
-		if err != nil {
-			return nil, err
-		}
-		if response != nil {
-			return response, nil
-		}
+// joinFeedback joins two messages or reasons with a blank line, skipping empty ones
+func joinFeedback(a, b string) string {
+	switch {
+	case a == "":
+		return b
+	case b == "":
+		return a
 	}
-	return nil, nil
+	return a + "\n\n" + b
 }
 
-// EvaluatePreCompact runs all engines and returns the first non-nil response
-func (c *CompositeRuleEngine) EvaluatePreCompact(ctx context.Context, msg *PreCompactMessage) (*HookResponse, error) {
-	for _, engine := range c.engines {
-		response, err := engine.EvaluatePreCompact(ctx, msg)
-		if err != nil {
-			return nil, err
-		}
-		if response != nil {
-			return response, nil
-		}
+// EvaluatePreToolUse runs the engines and merges their responses, approving if
+// none responds
+func (c *CompositeRuleEngine) EvaluatePreToolUse(ctx context.Context, msg *PreToolUseMessage) (*HookResponse, error) {
+	response, err := c.evaluate(func(engine RuleEngine) (*HookResponse, error) {
+		return engine.EvaluatePreToolUse(ctx, msg)
+	})
+	if err != nil {
+		return nil, err
 	}
-	return nil, nil
+	if response == nil {
+		return &HookResponse{Decision: "approve"}, nil
+	}
+	if response.Decision == "" {
+		response.Decision = "approve"
+	}
+	return response, nil
+}
+
+// EvaluatePostToolUse runs the engines and merges their responses
+func (c *CompositeRuleEngine) EvaluatePostToolUse(ctx context.Context, msg *PostToolUseMessage) (*HookResponse, error) {
+	return c.evaluate(func(engine RuleEngine) (*HookResponse, error) {
+		return engine.EvaluatePostToolUse(ctx, msg)
+	})
+}
+
+// EvaluateNotification runs the engines and merges their responses
+func (c *CompositeRuleEngine) EvaluateNotification(ctx context.Context, msg *NotificationMessage) (*HookResponse, error) {
+	return c.evaluate(func(engine RuleEngine) (*HookResponse, error) {
+		return engine.EvaluateNotification(ctx, msg)
+	})
+}
+
+// EvaluateStop runs the engines and merges their responses
+func (c *CompositeRuleEngine) EvaluateStop(ctx context.Context, msg *StopMessage) (*HookResponse, error) {
+	return c.evaluate(func(engine RuleEngine) (*HookResponse, error) {
+		return engine.EvaluateStop(ctx, msg)
+	})
+}
+
+// EvaluateSubagentStop runs the engines and merges their responses
+func (c *CompositeRuleEngine) EvaluateSubagentStop(ctx context.Context, msg *SubagentStopMessage) (*HookResponse, error) {
+	return c.evaluate(func(engine RuleEngine) (*HookResponse, error) {
+		return engine.EvaluateSubagentStop(ctx, msg)
+	})
+}
+
+// EvaluatePreCompact runs the engines and merges their responses
+func (c *CompositeRuleEngine) EvaluatePreCompact(ctx context.Context, msg *PreCompactMessage) (*HookResponse, error) {
+	return c.evaluate(func(engine RuleEngine) (*HookResponse, error) {
+		return engine.EvaluatePreCompact(ctx, msg)
+	})
 }
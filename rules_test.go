@@ -132,7 +132,7 @@ func TestCompositeRuleEngine(t *testing.T) {
 		}
 	})
 
-	t.Run("PostToolUse first non-nil wins", func(t *testing.T) {
+	t.Run("PostToolUse messages joined in order", func(t *testing.T) {
 		engine1 := &MockRuleEngine{
 			postToolUseResponse: nil,
 		}
@@ -161,9 +161,9 @@ func TestCompositeRuleEngine(t *testing.T) {
 			t.Fatalf("EvaluatePostToolUse() error = %v", err)
 		}
 
-		// Should return engine2's response (first non-nil)
-		if resp.Message != "processed by engine2" {
-			t.Errorf("expected engine2's response, got %v", resp.Message)
+		// engine1 has no opinion; the others' messages are both kept
+		if resp.Message != "processed by engine2\n\nprocessed by engine3" {
+			t.Errorf("expected both messages in order, got %q", resp.Message)
 		}
 	})
 
@@ -202,6 +202,57 @@ func TestCompositeRuleEngine(t *testing.T) {
 	})
 }
 
+func TestCompositeRuleEngine_Merge(t *testing.T) {
+	ctx := context.Background()
+	stop := false
+
+	t.Run("block wins and ends the evaluation", func(t *testing.T) {
+		last := &MockRuleEngine{preToolUseResponse: &HookResponse{Decision: "block", Reason: "too late"}}
+		composite := NewCompositeRuleEngine(
+			&MockRuleEngine{preToolUseResponse: &HookResponse{Decision: "approve", Reason: "looks fine", Message: "formatted"}},
+			&MockRuleEngine{preToolUseResponse: &HookResponse{Decision: "block", Reason: "secret in file"}},
+			last,
+		)
+		resp, err := composite.EvaluatePreToolUse(ctx, &PreToolUseMessage{ToolName: "Write"})
+		if err != nil {
+			t.Fatalf("EvaluatePreToolUse() error = %v", err)
+		}
+		if resp.Decision != "block" || resp.Reason != "secret in file" || resp.Message != "formatted" {
+			t.Errorf("EvaluatePreToolUse() = %+v, want the block's reason and the earlier message", resp)
+		}
+		if last.preToolUseCalled {
+			t.Error("engine after the block was called")
+		}
+	})
+
+	t.Run("first stop wins", func(t *testing.T) {
+		last := &MockRuleEngine{stopResponse: &HookResponse{Continue: &stop, StopReason: "second"}}
+		composite := NewCompositeRuleEngine(
+			&MockRuleEngine{stopResponse: &HookResponse{Message: "3 files changed"}},
+			&MockRuleEngine{stopResponse: &HookResponse{Continue: &stop, StopReason: "tests fail"}},
+			last,
+		)
+		resp, err := composite.EvaluateStop(ctx, &StopMessage{})
+		if err != nil {
+			t.Fatalf("EvaluateStop() error = %v", err)
+		}
+		if resp.Continue == nil || *resp.Continue || resp.StopReason != "tests fail" || resp.Message != "3 files changed" {
+			t.Errorf("EvaluateStop() = %+v, want the first stop and the earlier message", resp)
+		}
+		if last.stopCalled {
+			t.Error("engine after the stop was called")
+		}
+	})
+
+	t.Run("no responses", func(t *testing.T) {
+		composite := NewCompositeRuleEngine(&MockRuleEngine{}, &MockRuleEngine{})
+		resp, err := composite.EvaluatePostToolUse(ctx, &PostToolUseMessage{})
+		if err != nil || resp != nil {
+			t.Errorf("EvaluatePostToolUse() = %+v, %v, want no response", resp, err)
+		}
+	})
+}
+
 func TestCompositeRuleEngine_AllMethods(t *testing.T) {
 	// Test that all methods properly aggregate responses
 	ctx := context.Background()
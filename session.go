@@ -0,0 +1,283 @@
+package gismo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jrossi/gismo/internal/statedir"
+	"github.com/jrossi/gismo/linters"
+)
+
+// SessionState holds state that must survive between hook invocations in the same
+// Claude Code session. Each hook runs as a separate process, so it is persisted.
+type SessionState struct {
+	// Decisions caches PreToolUse decisions keyed by a hash of the tool input
+	Decisions map[string]*CachedDecision `json:"decisions,omitempty"`
+	// BlockStreaks counts consecutive PreToolUse blocks keyed by file and rule
+	BlockStreaks map[string]int `json:"blockStreaks,omitempty"`
+	// Blocks records recent PreToolUse blocks so policy changes can be replayed by gismo tune
+	Blocks []BlockRecord `json:"blocks,omitempty"`
+	// Acknowledged records files whose cross-team edit was acknowledged, keyed by path
+	Acknowledged map[string]bool `json:"acknowledged,omitempty"`
+	// AcknowledgedEdits records oversized edits blocked once by the refactor
+	// guard, keyed by a hash of the file and its new content
+	AcknowledgedEdits map[string]bool `json:"acknowledgedEdits,omitempty"`
+	// ProjectBaseline is the project scan taken before the session's first edit
+	ProjectBaseline *ProjectHealth `json:"projectBaseline,omitempty"`
+	UpdatedAt       time.Time      `json:"updatedAt"`
+}
+
+// maxBlockHistory bounds the blocks kept per session
+const maxBlockHistory = 200
+
+// BlockRecord is a PreToolUse block and the errors that caused it
+type BlockRecord struct {
+	File   string          `json:"file"`
+	Issues []linters.Issue `json:"issues"`
+	At     time.Time       `json:"at"`
+}
+
+// recordBlockHistory appends a block to the session history, dropping the oldest
+// entries beyond maxBlockHistory
+func recordBlockHistory(state *SessionState, filePath string, errorIssues []linters.Issue) {
+	state.Blocks = append(state.Blocks, BlockRecord{File: filePath, Issues: errorIssues, At: time.Now()})
+	if len(state.Blocks) > maxBlockHistory {
+		state.Blocks = append([]BlockRecord(nil), state.Blocks[len(state.Blocks)-maxBlockHistory:]...)
+	}
+}
+
+// CachedDecision is a previously computed block for an identical tool input
+type CachedDecision struct {
+	Response  HookResponse `json:"response"`
+	CreatedAt time.Time    `json:"createdAt"`
+	Hits      int          `json:"hits"` // times the cached response was reused
+	// File and Issues are the block that produced the response, replayed for
+	// escalation tracking when the decision is reused
+	File   string          `json:"file,omitempty"`
+	Issues []linters.Issue `json:"issues,omitempty"`
+}
+
+// DefaultSessionMaxAge is how long an idle session's state is kept before pruning
+const DefaultSessionMaxAge = 24 * time.Hour
+
+// Session locks are retried for up to sessionLockTimeout, and a lock older than
+// staleSessionLock is assumed to be left behind by a crashed hook
+const (
+	sessionLockTimeout = 5 * time.Second
+	sessionLockRetry   = 10 * time.Millisecond
+	staleSessionLock   = 30 * time.Second
+)
+
+// SessionStore persists SessionState as one JSON file per session
+type SessionStore struct {
+	dir string
+}
+
+// unsafeSessionChars matches characters not allowed in session file names
+var unsafeSessionChars = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+// NewSessionStore creates a session store rooted at dir
+func NewSessionStore(dir string) *SessionStore {
+	return &SessionStore{dir: dir}
+}
+
+// DefaultSessionDir returns the directory used for session state by default.
+// Hooks trust session state, so each user keeps theirs in their cache directory.
+func DefaultSessionDir() string {
+	return statedir.CacheDir("sessions")
+}
+
+// path returns the state file for a session
+func (s *SessionStore) path(sessionID string) string {
+	return filepath.Join(s.dir, unsafeSessionChars.ReplaceAllString(sessionID, "_")+".json")
+}
+
+// lock takes an exclusive lock on a session's state file so that concurrent hooks
+// don't overwrite each other's updates. The returned function releases it.
+func (s *SessionStore) lock(sessionID string) (func(), error) {
+	if err := statedir.Ensure(s.dir); err != nil {
+		return nil, fmt.Errorf("failed to create session directory: %w", err)
+	}
+
+	lockPath := s.path(sessionID) + ".lock"
+	deadline := time.Now().Add(sessionLockTimeout)
+	for {
+		f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err == nil {
+			_ = f.Close()
+			return func() { _ = os.Remove(lockPath) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("failed to lock session state: %w", err)
+		}
+		if info, statErr := os.Stat(lockPath); statErr == nil && time.Since(info.ModTime()) > staleSessionLock {
+			_ = os.Remove(lockPath)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out waiting for session lock %s", lockPath)
+		}
+		time.Sleep(sessionLockRetry)
+	}
+}
+
+// errNoSessionChange is returned from an Update callback to skip saving unchanged state
+var errNoSessionChange = errors.New("session state unchanged")
+
+// Update loads the state for a session, applies fn and saves the result while
+// holding the session lock. State is not saved when fn returns an error.
+func (s *SessionStore) Update(sessionID string, fn func(state *SessionState) error) error {
+	if sessionID == "" {
+		state, _ := s.Load(sessionID)
+		return fn(state)
+	}
+
+	unlock, err := s.lock(sessionID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	state, err := s.Load(sessionID)
+	if err != nil {
+		return err
+	}
+	if err := fn(state); err != nil {
+		return err
+	}
+	return s.Save(sessionID, state)
+}
+
+// Prune removes session files, leftover temp files and stale locks not modified
+// within maxAge. A missing directory is not an error.
+func (s *SessionStore) Prune(maxAge time.Duration) error {
+	entries, err := os.ReadDir(s.dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read session directory: %w", err)
+	}
+
+	cutoff := time.Now().Add(-maxAge)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || info.ModTime().After(cutoff) {
+			continue
+		}
+		_ = os.Remove(filepath.Join(s.dir, entry.Name()))
+	}
+	return nil
+}
+
+// Load returns the state for a session, or empty state if none has been saved
+func (s *SessionStore) Load(sessionID string) (*SessionState, error) {
+	state := &SessionState{Decisions: make(map[string]*CachedDecision)}
+	if sessionID == "" {
+		return state, nil
+	}
+
+	// State in a directory another user can write to can't be trusted
+	if err := statedir.Check(s.dir); os.IsNotExist(err) {
+		return state, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read session state: %w", err)
+	}
+	data, err := os.ReadFile(s.path(sessionID))
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read session state: %w", err)
+	}
+
+	if err := json.Unmarshal(data, state); err != nil {
+		// Corrupt state is discarded rather than failing the hook
+		return &SessionState{Decisions: make(map[string]*CachedDecision)}, nil
+	}
+	if state.Decisions == nil {
+		state.Decisions = make(map[string]*CachedDecision)
+	}
+	return state, nil
+}
+
+// Save writes the state for a session atomically
+func (s *SessionStore) Save(sessionID string, state *SessionState) error {
+	if sessionID == "" {
+		return nil
+	}
+
+	if err := statedir.Ensure(s.dir); err != nil {
+		return fmt.Errorf("failed to create session directory: %w", err)
+	}
+
+	state.UpdatedAt = time.Now()
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to encode session state: %w", err)
+	}
+
+	// Write to a temp file and rename so concurrent hooks never read partial state
+	tmp, err := os.CreateTemp(s.dir, ".session-*")
+	if err != nil {
+		return fmt.Errorf("failed to write session state: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write session state: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write session state: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path(sessionID)); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write session state: %w", err)
+	}
+	return nil
+}
+
+// LoadAll returns the state of every saved session keyed by file name, skipping
+// unreadable or corrupt files. A missing directory yields no sessions.
+func (s *SessionStore) LoadAll() (map[string]*SessionState, error) {
+	if err := statedir.Check(s.dir); os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read session directory: %w", err)
+	}
+	entries, err := os.ReadDir(s.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read session directory: %w", err)
+	}
+
+	states := make(map[string]*SessionState)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || filepath.Ext(name) != ".json" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(s.dir, name))
+		if err != nil {
+			continue
+		}
+		var state SessionState
+		if err := json.Unmarshal(data, &state); err != nil {
+			continue
+		}
+		states[strings.TrimSuffix(name, ".json")] = &state
+	}
+	return states, nil
+}
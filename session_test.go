@@ -0,0 +1,183 @@
+package gismo
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+func TestSessionStore_LoadSave(t *testing.T) {
+	store := NewSessionStore(t.TempDir())
+
+	state, err := store.Load("session-1")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(state.Decisions) != 0 {
+		t.Fatalf("expected empty state for new session, got %+v", state)
+	}
+
+	state.Decisions["key"] = &CachedDecision{
+		Response:  HookResponse{Decision: "block", Reason: "bad"},
+		CreatedAt: time.Now(),
+	}
+	if err := store.Save("session-1", state); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := store.Load("session-1")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := loaded.Decisions["key"]; got == nil || got.Response.Reason != "bad" {
+		t.Errorf("Load() decision = %+v, want saved decision", got)
+	}
+
+	// Sessions are isolated
+	other, _ := store.Load("session-2")
+	if len(other.Decisions) != 0 {
+		t.Errorf("expected session-2 to be empty, got %+v", other.Decisions)
+	}
+}
+
+func TestSessionStore_UnsafeIDAndCorruptState(t *testing.T) {
+	dir := t.TempDir()
+	store := NewSessionStore(dir)
+
+	if err := store.Save("../escape/id", &SessionState{}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 || filepath.Dir(store.path("../escape/id")) != dir {
+		t.Errorf("expected session file inside store directory, got %v", entries)
+	}
+
+	if err := os.WriteFile(store.path("corrupt"), []byte("{not json"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	state, err := store.Load("corrupt")
+	if err != nil || state == nil || state.Decisions == nil {
+		t.Errorf("expected corrupt state to be discarded, got %+v, %v", state, err)
+	}
+}
+
+func TestSessionStore_EmptySessionID(t *testing.T) {
+	dir := t.TempDir()
+	store := NewSessionStore(dir)
+
+	if err := store.Save("", &SessionState{}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 0 {
+		t.Errorf("expected nothing persisted without a session ID, got %v", entries)
+	}
+}
+
+func TestSessionStore_LoadAll(t *testing.T) {
+	dir := t.TempDir()
+	store := NewSessionStore(dir)
+
+	states, err := store.LoadAll()
+	if err != nil || len(states) != 0 {
+		t.Fatalf("LoadAll() on empty store = %v, %v", states, err)
+	}
+
+	for _, id := range []string{"a", "b"} {
+		state, _ := store.Load(id)
+		recordBlockHistory(state, id+".go", []linters.Issue{{Severity: "error", Rule: "r"}})
+		if err := store.Save(id, state); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "corrupt.json"), []byte("{"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	states, err = store.LoadAll()
+	if err != nil {
+		t.Fatalf("LoadAll() error = %v", err)
+	}
+	if len(states) != 2 || len(states["a"].Blocks) != 1 || states["b"].Blocks[0].File != "b.go" {
+		t.Errorf("LoadAll() = %+v, want sessions a and b with one block each", states)
+	}
+}
+
+func TestRecordBlockHistory_Bounded(t *testing.T) {
+	state := &SessionState{}
+	for i := 0; i < maxBlockHistory+5; i++ {
+		recordBlockHistory(state, fmt.Sprintf("%d.go", i), nil)
+	}
+	if len(state.Blocks) != maxBlockHistory {
+		t.Fatalf("len(Blocks) = %d, want %d", len(state.Blocks), maxBlockHistory)
+	}
+	if state.Blocks[0].File != "5.go" {
+		t.Errorf("oldest block = %s, want 5.go", state.Blocks[0].File)
+	}
+}
+
+func TestSessionStore_UpdateConcurrent(t *testing.T) {
+	store := NewSessionStore(t.TempDir())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = store.Update("s1", func(state *SessionState) error {
+				if state.BlockStreaks == nil {
+					state.BlockStreaks = make(map[string]int)
+				}
+				state.BlockStreaks["main.go"]++
+				return nil
+			})
+		}()
+	}
+	wg.Wait()
+
+	state, err := store.Load("s1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := state.BlockStreaks["main.go"]; got != 20 {
+		t.Errorf("streak = %d, want 20 with no lost updates", got)
+	}
+	if _, err := os.Stat(store.path("s1") + ".lock"); !os.IsNotExist(err) {
+		t.Errorf("expected lock to be released, stat error = %v", err)
+	}
+}
+
+func TestSessionStore_Prune(t *testing.T) {
+	dir := t.TempDir()
+	store := NewSessionStore(dir)
+	if err := store.Save("old", &SessionState{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save("new", &SessionState{}); err != nil {
+		t.Fatal(err)
+	}
+	past := time.Now().Add(-2 * DefaultSessionMaxAge)
+	if err := os.Chtimes(store.path("old"), past, past); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.Prune(DefaultSessionMaxAge); err != nil {
+		t.Fatalf("Prune() error = %v", err)
+	}
+	if _, err := os.Stat(store.path("old")); !os.IsNotExist(err) {
+		t.Errorf("expected old session to be pruned, stat error = %v", err)
+	}
+	if _, err := os.Stat(store.path("new")); err != nil {
+		t.Errorf("expected new session to be kept, stat error = %v", err)
+	}
+
+	// A missing directory is not an error
+	if err := NewSessionStore(filepath.Join(dir, "missing")).Prune(time.Hour); err != nil {
+		t.Errorf("Prune() on missing dir error = %v", err)
+	}
+}
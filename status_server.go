@@ -0,0 +1,213 @@
+package gismo
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+// maxRecentDecisions bounds the decisions kept for /status
+const maxRecentDecisions = 50
+
+// StatusServer collects lifecycle events and serves the current diagnostics and
+// recent hook decisions as JSON, for editor integrations such as a VS Code extension.
+// It implements EventSink, so it can be fed directly or from event stream connections.
+type StatusServer struct {
+	mu        sync.Mutex
+	version   string
+	started   time.Time
+	files     map[string]*FileDiagnostics
+	pending   map[string][]Diagnostic
+	decisions []Event
+	now       func() time.Time
+}
+
+// Diagnostic is an issue attributed to the linter that reported it
+type Diagnostic struct {
+	Linter string `json:"linter"`
+	linters.Issue
+}
+
+// FileDiagnostics is the latest lint result for a file, as served by /diagnostics
+type FileDiagnostics struct {
+	File        string       `json:"file"`
+	Hook        string       `json:"hook,omitempty"`
+	Decision    string       `json:"decision,omitempty"`
+	Reason      string       `json:"reason,omitempty"`
+	UpdatedAt   time.Time    `json:"updatedAt"`
+	Diagnostics []Diagnostic `json:"diagnostics"`
+}
+
+// ServerStatus is the response of /status
+type ServerStatus struct {
+	Version         string    `json:"version"`
+	StartedAt       time.Time `json:"startedAt"`
+	UptimeSeconds   int64     `json:"uptimeSeconds"`
+	Files           int       `json:"files"`
+	Errors          int       `json:"errors"`
+	Warnings        int       `json:"warnings"`
+	RecentDecisions []Event   `json:"recentDecisions"`
+}
+
+// NewStatusServer creates a status server reporting the given gismo version
+func NewStatusServer(version string) *StatusServer {
+	return &StatusServer{
+		version: version,
+		started: time.Now(),
+		files:   make(map[string]*FileDiagnostics),
+		pending: make(map[string][]Diagnostic),
+		now:     time.Now,
+	}
+}
+
+// Emit records an event. Diagnostics for a file are replaced when a lint run
+// for it ends, so a file always shows the result of its latest run.
+func (s *StatusServer) Emit(event Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file := filepath.Clean(event.File)
+	switch event.Type {
+	case EventLintStart:
+		s.pending[file] = []Diagnostic{}
+	case EventIssue:
+		if event.Issue != nil {
+			s.pending[file] = append(s.pending[file], Diagnostic{Linter: event.Linter, Issue: *event.Issue})
+		}
+	case EventLintEnd:
+		diagnostics := s.pending[file]
+		delete(s.pending, file)
+		if diagnostics == nil {
+			diagnostics = []Diagnostic{}
+		}
+		s.files[file] = &FileDiagnostics{File: file, Hook: event.Hook, UpdatedAt: s.eventTime(event), Diagnostics: diagnostics}
+	case EventDecision:
+		s.decisions = append(s.decisions, event)
+		if len(s.decisions) > maxRecentDecisions {
+			s.decisions = s.decisions[len(s.decisions)-maxRecentDecisions:]
+		}
+		if entry, ok := s.files[file]; ok {
+			entry.Decision, entry.Reason = event.Decision, event.Reason
+		}
+	}
+}
+
+// eventTime returns the event time, or now for events without one
+func (s *StatusServer) eventTime(event Event) time.Time {
+	if event.Time.IsZero() {
+		return s.now()
+	}
+	return event.Time
+}
+
+// Status returns a snapshot of the server status
+func (s *StatusServer) Status() ServerStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	status := ServerStatus{
+		Version:         s.version,
+		StartedAt:       s.started,
+		UptimeSeconds:   int64(s.now().Sub(s.started).Seconds()),
+		Files:           len(s.files),
+		RecentDecisions: append([]Event{}, s.decisions...),
+	}
+	for _, entry := range s.files {
+		for _, diagnostic := range entry.Diagnostics {
+			if diagnostic.Severity == "error" {
+				status.Errors++
+			} else {
+				status.Warnings++
+			}
+		}
+	}
+	return status
+}
+
+// Diagnostics returns the latest diagnostics for a file, or for every file sorted
+// by path when file is empty
+func (s *StatusServer) Diagnostics(file string) []FileDiagnostics {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if file != "" {
+		file = filepath.Clean(file)
+		if entry, ok := s.files[file]; ok {
+			return []FileDiagnostics{copyFileDiagnostics(entry)}
+		}
+		return []FileDiagnostics{{File: file, Diagnostics: []Diagnostic{}}}
+	}
+
+	all := make([]FileDiagnostics, 0, len(s.files))
+	for _, entry := range s.files {
+		all = append(all, copyFileDiagnostics(entry))
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].File < all[j].File })
+	return all
+}
+
+// copyFileDiagnostics copies an entry so it can be encoded outside the lock
+func copyFileDiagnostics(entry *FileDiagnostics) FileDiagnostics {
+	copied := *entry
+	copied.Diagnostics = append([]Diagnostic{}, entry.Diagnostics...)
+	return copied
+}
+
+// Handler returns the HTTP handler serving /status and /diagnostics?file=
+func (s *StatusServer) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/status", func(w http.ResponseWriter, r *http.Request) {
+		writeStatusJSON(w, s.Status())
+	})
+	mux.HandleFunc("/diagnostics", func(w http.ResponseWriter, r *http.Request) {
+		if file := r.URL.Query().Get("file"); file != "" {
+			writeStatusJSON(w, s.Diagnostics(file)[0])
+			return
+		}
+		writeStatusJSON(w, s.Diagnostics(""))
+	})
+	return mux
+}
+
+// writeStatusJSON writes v as a JSON response
+func writeStatusJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// ServeEvents accepts event stream connections on listener, such as hooks run
+// with -event-stream unix:<socket>, and records their events until the listener closes
+func (s *StatusServer) ServeEvents(listener net.Listener) error {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		go s.readEvents(conn)
+	}
+}
+
+// readEvents records JSONL events from a connection, skipping malformed lines
+func (s *StatusServer) readEvents(conn net.Conn) {
+	defer conn.Close()
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		var event Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			continue
+		}
+		s.Emit(event)
+	}
+}
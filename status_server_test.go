@@ -0,0 +1,130 @@
+package gismo
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+// emitLintRun feeds a complete lint run for file to sink
+func emitLintRun(sink EventSink, file string, issues ...linters.Issue) {
+	sink.Emit(Event{Type: EventLintStart, File: file, Hook: "PreToolUse"})
+	for i := range issues {
+		sink.Emit(Event{Type: EventIssue, File: file, Linter: "go", Issue: &issues[i]})
+	}
+	sink.Emit(Event{Type: EventLintEnd, File: file, Hook: "PreToolUse"})
+}
+
+func TestStatusServer_Emit(t *testing.T) {
+	server := NewStatusServer("test")
+
+	emitLintRun(server, "/proj/a.go",
+		linters.Issue{File: "/proj/a.go", Line: 1, Severity: "error", Message: "bad"},
+		linters.Issue{File: "/proj/a.go", Line: 2, Severity: "warning", Message: "meh"})
+	server.Emit(Event{Type: EventDecision, File: "/proj/a.go", Decision: "block", Reason: "Found 1 error(s)"})
+	emitLintRun(server, "/proj/b.go")
+
+	status := server.Status()
+	if status.Version != "test" || status.Files != 2 || status.Errors != 1 || status.Warnings != 1 || len(status.RecentDecisions) != 1 {
+		t.Errorf("Status() = %+v", status)
+	}
+
+	a := server.Diagnostics("/proj/./a.go")[0]
+	if a.Decision != "block" || len(a.Diagnostics) != 2 || a.Diagnostics[0].Linter != "go" {
+		t.Errorf("Diagnostics(a.go) = %+v", a)
+	}
+
+	// A later run replaces earlier diagnostics
+	emitLintRun(server, "/proj/a.go")
+	if got := server.Diagnostics("/proj/a.go")[0]; len(got.Diagnostics) != 0 {
+		t.Errorf("diagnostics after clean run = %+v, want none", got.Diagnostics)
+	}
+
+	if unknown := server.Diagnostics("/proj/none.go")[0]; unknown.Diagnostics == nil {
+		t.Error("unknown file should report an empty diagnostics list, not null")
+	}
+	if all := server.Diagnostics(""); len(all) != 2 || all[0].File != "/proj/a.go" {
+		t.Errorf("Diagnostics(\"\") = %+v", all)
+	}
+}
+
+func TestStatusServer_Handler(t *testing.T) {
+	server := NewStatusServer("test")
+	emitLintRun(server, "/proj/a.go", linters.Issue{File: "/proj/a.go", Line: 3, Column: 4, Severity: "error", Message: "bad", Rule: "typecheck"})
+
+	tests := []struct {
+		path  string
+		check func(t *testing.T, body map[string]interface{})
+	}{
+		{"/status", func(t *testing.T, body map[string]interface{}) {
+			if body["files"] != float64(1) || body["errors"] != float64(1) {
+				t.Errorf("/status = %v", body)
+			}
+		}},
+		{"/diagnostics?file=/proj/a.go", func(t *testing.T, body map[string]interface{}) {
+			diagnostics := body["diagnostics"].([]interface{})
+			first := diagnostics[0].(map[string]interface{})
+			if first["linter"] != "go" || first["line"] != float64(3) || first["rule"] != "typecheck" {
+				t.Errorf("diagnostic = %v", first)
+			}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			server.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if recorder.Code != http.StatusOK || recorder.Header().Get("Content-Type") != "application/json" {
+				t.Fatalf("status %d, content type %q", recorder.Code, recorder.Header().Get("Content-Type"))
+			}
+			var body map[string]interface{}
+			if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+				t.Fatalf("invalid JSON: %v", err)
+			}
+			tt.check(t, body)
+		})
+	}
+}
+
+func TestStatusServer_ServeEvents(t *testing.T) {
+	dir, err := os.MkdirTemp("", "gismo-st")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	socket := filepath.Join(dir, "s.sock")
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Skipf("unix sockets unavailable: %v", err)
+	}
+
+	server := NewStatusServer("test")
+	done := make(chan error, 1)
+	go func() { done <- server.ServeEvents(listener) }()
+
+	sink, err := OpenEventStream("unix:" + socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	emitLintRun(sink, "/proj/a.go", linters.Issue{Severity: "error", Message: "bad"})
+	_ = sink.Close()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for server.Status().Files == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if status := server.Status(); status.Files != 1 || status.Errors != 1 {
+		t.Errorf("Status() after streamed events = %+v", status)
+	}
+
+	_ = listener.Close()
+	if err := <-done; err != nil {
+		t.Errorf("ServeEvents() after close = %v, want nil", err)
+	}
+}
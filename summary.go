@@ -0,0 +1,151 @@
+package gismo
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jrossi/gismo/i18n"
+	"github.com/jrossi/gismo/linters"
+)
+
+// DefaultMaxIssuesPerFile caps how many issues a feedback summary shows per file
+const DefaultMaxIssuesPerFile = 10
+
+// FeedbackSummary consolidates lint issues from several files into a single report
+// ranked by severity, so multi-file feedback stays short enough to act on
+type FeedbackSummary struct {
+	Files    []FileFeedback `json:"files"`
+	Errors   int            `json:"errors"`
+	Warnings int            `json:"warnings"`
+}
+
+// FileFeedback holds the ranked issues reported for one file
+type FileFeedback struct {
+	File     string          `json:"file"`
+	Errors   int             `json:"errors"`
+	Warnings int             `json:"warnings"`
+	Issues   []linters.Issue `json:"issues"`
+	Omitted  int             `json:"omitted,omitempty"` // issues dropped by the per-file cap
+}
+
+// NewFeedbackSummary builds a summary from issues grouped by file. Files with errors
+// come first, then files are ordered by path; issues within a file are ordered by
+// severity and position. At most maxPerFile issues are kept per file (0 means no cap).
+func NewFeedbackSummary(issuesByFile map[string][]linters.Issue, maxPerFile int) *FeedbackSummary {
+	summary := &FeedbackSummary{Files: []FileFeedback{}}
+
+	for file, issues := range issuesByFile {
+		if len(issues) == 0 {
+			continue
+		}
+
+		sorted := append([]linters.Issue(nil), issues...)
+		sort.SliceStable(sorted, func(i, j int) bool {
+			if ri, rj := severityRank(sorted[i].Severity), severityRank(sorted[j].Severity); ri != rj {
+				return ri < rj
+			}
+			if sorted[i].Line != sorted[j].Line {
+				return sorted[i].Line < sorted[j].Line
+			}
+			return sorted[i].Column < sorted[j].Column
+		})
+
+		feedback := FileFeedback{File: file}
+		for _, issue := range sorted {
+			if issue.Severity == "error" {
+				feedback.Errors++
+			} else {
+				feedback.Warnings++
+			}
+		}
+		if maxPerFile > 0 && len(sorted) > maxPerFile {
+			feedback.Omitted = len(sorted) - maxPerFile
+			sorted = sorted[:maxPerFile]
+		}
+		feedback.Issues = sorted
+
+		summary.Errors += feedback.Errors
+		summary.Warnings += feedback.Warnings
+		summary.Files = append(summary.Files, feedback)
+	}
+
+	sort.Slice(summary.Files, func(i, j int) bool {
+		ei, ej := summary.Files[i].Errors > 0, summary.Files[j].Errors > 0
+		if ei != ej {
+			return ei
+		}
+		return summary.Files[i].File < summary.Files[j].File
+	})
+
+	return summary
+}
+
+// severityRank orders severities from most to least important
+func severityRank(severity string) int {
+	switch severity {
+	case "error":
+		return 0
+	case "warning":
+		return 1
+	default:
+		return 2
+	}
+}
+
+// IsBlocking reports whether any file in the summary has errors
+func (s *FeedbackSummary) IsBlocking() bool {
+	return s.Errors > 0
+}
+
+// Format renders the summary in the smart-lint.sh style used for single files,
+// followed by a machine-readable JSON attachment
+func (s *FeedbackSummary) Format() string {
+	return s.FormatIn(nil)
+}
+
+// FormatIn renders the summary like Format, with messages from catalog
+func (s *FeedbackSummary) FormatIn(catalog *i18n.Catalog) string {
+	var output strings.Builder
+
+	for i, file := range s.Files {
+		if i > 0 {
+			output.WriteString("\n")
+		}
+		output.WriteString(fmt.Sprintf("- [ccfeedback:%s]: ", file.File))
+		for j, issue := range file.Issues {
+			if j > 0 {
+				output.WriteString("\n  ")
+			}
+			if issue.Line > 0 && issue.Column > 0 {
+				output.WriteString(fmt.Sprintf("%s:%d:%d: %s", file.File, issue.Line, issue.Column, issue.Message))
+			} else {
+				output.WriteString(issue.Message)
+			}
+			if issue.Rule != "" {
+				output.WriteString(fmt.Sprintf(" (%s)", issue.Rule))
+			}
+		}
+		if file.Omitted > 0 {
+			output.WriteString("\n  " + catalog.Sprintf("summary.more_issues", file.Omitted))
+		}
+	}
+	output.WriteString("\n")
+
+	if s.IsBlocking() {
+		output.WriteString("\n" + catalog.Sprintf("summary.blocking_count", s.Errors, s.Warnings, len(s.Files)) + "\n")
+		output.WriteString(catalog.Sprintf("output.blocking"))
+	} else {
+		output.WriteString("\n" + catalog.Sprintf("summary.warning_count", s.Warnings, len(s.Files)) + "\n")
+		output.WriteString(catalog.Sprintf("output.non_blocking"))
+	}
+
+	if data, err := json.Marshal(s); err == nil {
+		output.WriteString("\n\n" + catalog.Sprintf("summary.machine_readable") + "\n```json\n")
+		output.Write(data)
+		output.WriteString("\n```")
+	}
+
+	return output.String()
+}
@@ -0,0 +1,118 @@
+package gismo
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+func TestNewFeedbackSummary_Ranking(t *testing.T) {
+	summary := NewFeedbackSummary(map[string][]linters.Issue{
+		"b.go": {
+			{File: "b.go", Line: 9, Column: 1, Severity: "warning", Message: "late warning"},
+			{File: "b.go", Line: 5, Column: 1, Severity: "error", Message: "error"},
+			{File: "b.go", Line: 1, Column: 1, Severity: "warning", Message: "early warning"},
+		},
+		"a.go": {
+			{File: "a.go", Line: 1, Column: 1, Severity: "warning", Message: "warning only"},
+		},
+		"c.go":     {{File: "c.go", Line: 1, Column: 1, Severity: "error", Message: "error"}},
+		"empty.go": nil,
+	}, 0)
+
+	var files []string
+	for _, f := range summary.Files {
+		files = append(files, f.File)
+	}
+	if got := strings.Join(files, ","); got != "b.go,c.go,a.go" {
+		t.Errorf("file order = %s, want files with errors first then by path", got)
+	}
+
+	var messages []string
+	for _, issue := range summary.Files[0].Issues {
+		messages = append(messages, issue.Message)
+	}
+	if got := strings.Join(messages, ","); got != "error,early warning,late warning" {
+		t.Errorf("issue order = %s, want severity then line", got)
+	}
+
+	if summary.Errors != 2 || summary.Warnings != 3 {
+		t.Errorf("totals = %d errors, %d warnings, want 2 and 3", summary.Errors, summary.Warnings)
+	}
+	if !summary.IsBlocking() {
+		t.Error("expected summary with errors to be blocking")
+	}
+}
+
+func TestNewFeedbackSummary_PerFileCap(t *testing.T) {
+	var issues []linters.Issue
+	for i := 1; i <= 5; i++ {
+		issues = append(issues, linters.Issue{File: "a.go", Line: i, Column: 1, Severity: "warning", Message: "w"})
+	}
+
+	summary := NewFeedbackSummary(map[string][]linters.Issue{"a.go": issues}, 2)
+	file := summary.Files[0]
+	if len(file.Issues) != 2 || file.Omitted != 3 {
+		t.Errorf("got %d issues and %d omitted, want 2 and 3", len(file.Issues), file.Omitted)
+	}
+	// Counts reflect all issues, not only the ones shown
+	if file.Warnings != 5 || summary.Warnings != 5 {
+		t.Errorf("warning counts = %d/%d, want 5", file.Warnings, summary.Warnings)
+	}
+	if !strings.Contains(summary.Format(), "... 3 more issue(s) in this file") {
+		t.Error("expected formatted output to mention omitted issues")
+	}
+}
+
+func TestFeedbackSummary_Format(t *testing.T) {
+	summary := NewFeedbackSummary(map[string][]linters.Issue{
+		"main.go":      {{File: "main.go", Line: 3, Column: 2, Severity: "error", Message: "undefined: x", Rule: "typecheck"}},
+		"main_test.go": {{File: "main_test.go", Line: 1, Column: 1, Severity: "warning", Message: "not formatted", Rule: "gofmt"}},
+	}, DefaultMaxIssuesPerFile)
+
+	output := summary.Format()
+	for _, want := range []string{
+		"- [ccfeedback:main.go]: main.go:3:2: undefined: x (typecheck)",
+		"- [ccfeedback:main_test.go]: main_test.go:1:1: not formatted (gofmt)",
+		"Found 1 blocking issue(s) and 1 warning(s) across 2 file(s)",
+		"BLOCKING",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Format() missing %q in:\n%s", want, output)
+		}
+	}
+
+	// The JSON attachment round-trips
+	start := strings.Index(output, "```json\n")
+	end := strings.LastIndex(output, "\n```")
+	if start < 0 || end < start {
+		t.Fatalf("Format() missing JSON attachment:\n%s", output)
+	}
+	var decoded FeedbackSummary
+	if err := json.Unmarshal([]byte(output[start+len("```json\n"):end]), &decoded); err != nil {
+		t.Fatalf("invalid JSON attachment: %v", err)
+	}
+	if decoded.Errors != 1 || len(decoded.Files) != 2 || decoded.Files[0].Issues[0].Rule != "typecheck" {
+		t.Errorf("unexpected decoded summary: %+v", decoded)
+	}
+}
+
+func TestAppConfig_GetMaxIssuesPerFile(t *testing.T) {
+	config := NewAppConfig()
+	if got := config.GetMaxIssuesPerFile(); got != DefaultMaxIssuesPerFile {
+		t.Errorf("default = %d, want %d", got, DefaultMaxIssuesPerFile)
+	}
+
+	limit := 3
+	config.Merge(&AppConfig{Feedback: &FeedbackConfig{MaxIssuesPerFile: &limit}})
+	if got := config.GetMaxIssuesPerFile(); got != 3 {
+		t.Errorf("merged = %d, want 3", got)
+	}
+
+	var nilConfig *AppConfig
+	if got := nilConfig.GetMaxIssuesPerFile(); got != DefaultMaxIssuesPerFile {
+		t.Errorf("nil config = %d, want default", got)
+	}
+}
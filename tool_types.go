@@ -1,6 +1,10 @@
 package gismo
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
 
 // ToolInput represents the base interface for all tool inputs
 type ToolInput interface {
@@ -45,6 +49,53 @@ type EditOperation struct {
 
 func (m MultiEditToolInput) ToolName() string { return "MultiEdit" }
 
+// Apply applies the edit to content using the Edit tool's semantics: old_string
+// must occur exactly once unless replace_all is set. An empty old_string creates
+// a new file and is only valid when content is empty.
+func (e EditOperation) Apply(content string) (string, error) {
+	if e.OldString == "" {
+		if content != "" {
+			return "", fmt.Errorf("old_string is empty but file is not")
+		}
+		return e.NewString, nil
+	}
+	if e.OldString == e.NewString {
+		return "", fmt.Errorf("old_string and new_string are identical")
+	}
+
+	count := strings.Count(content, e.OldString)
+	switch {
+	case count == 0:
+		return "", fmt.Errorf("old_string not found")
+	case count > 1 && !e.ReplaceAll:
+		return "", fmt.Errorf("old_string found %d times but replace_all is not set", count)
+	case e.ReplaceAll:
+		return strings.ReplaceAll(content, e.OldString, e.NewString), nil
+	default:
+		return strings.Replace(content, e.OldString, e.NewString, 1), nil
+	}
+}
+
+// Apply applies the edit to content
+func (e EditToolInput) Apply(content string) (string, error) {
+	return EditOperation{
+		OldString:  e.OldString,
+		NewString:  e.NewString,
+		ReplaceAll: e.ReplaceAll,
+	}.Apply(content)
+}
+
+// Apply applies all edits in order, each to the result of the previous one
+func (m MultiEditToolInput) Apply(content string) (string, error) {
+	for i, edit := range m.Edits {
+		var err error
+		if content, err = edit.Apply(content); err != nil {
+			return "", fmt.Errorf("edit %d: %w", i+1, err)
+		}
+	}
+	return content, nil
+}
+
 // BashToolInput represents input for the Bash tool
 type BashToolInput struct {
 	Command     string `json:"command"`
@@ -0,0 +1,93 @@
+package gismo
+
+import (
+	"testing"
+)
+
+func TestEditOperation_Apply(t *testing.T) {
+	tests := []struct {
+		name    string
+		edit    EditOperation
+		content string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "single replacement",
+			edit:    EditOperation{OldString: "foo", NewString: "bar"},
+			content: "a foo b",
+			want:    "a bar b",
+		},
+		{
+			name:    "replace all",
+			edit:    EditOperation{OldString: "foo", NewString: "bar", ReplaceAll: true},
+			content: "foo foo",
+			want:    "bar bar",
+		},
+		{
+			name:    "ambiguous without replace all",
+			edit:    EditOperation{OldString: "foo", NewString: "bar"},
+			content: "foo foo",
+			wantErr: true,
+		},
+		{
+			name:    "not found",
+			edit:    EditOperation{OldString: "missing", NewString: "bar"},
+			content: "foo",
+			wantErr: true,
+		},
+		{
+			name:    "identical strings",
+			edit:    EditOperation{OldString: "foo", NewString: "foo"},
+			content: "foo",
+			wantErr: true,
+		},
+		{
+			name:    "create new file",
+			edit:    EditOperation{OldString: "", NewString: "package main\n"},
+			content: "",
+			want:    "package main\n",
+		},
+		{
+			name:    "empty old string on existing content",
+			edit:    EditOperation{OldString: "", NewString: "x"},
+			content: "existing",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.edit.Apply(tt.content)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Apply() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Apply() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMultiEditToolInput_Apply(t *testing.T) {
+	input := MultiEditToolInput{
+		FilePath: "main.go",
+		Edits: []EditOperation{
+			{OldString: "one", NewString: "two"},
+			{OldString: "two", NewString: "three"}, // applies to the result of the first edit
+		},
+	}
+
+	got, err := input.Apply("one")
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if got != "three" {
+		t.Errorf("Apply() = %q, want %q", got, "three")
+	}
+
+	input.Edits = append(input.Edits, EditOperation{OldString: "missing", NewString: "x"})
+	if _, err := input.Apply("one"); err == nil {
+		t.Error("expected error when a later edit does not apply")
+	}
+}
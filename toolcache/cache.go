@@ -1,6 +1,7 @@
 package toolcache
 
 import (
+	"bytes"
 	"crypto/sha256"
 	"encoding/json"
 	"fmt"
@@ -12,6 +13,8 @@ import (
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/jrossi/gismo/linters"
 )
 
 // UniversalToolCache represents the complete tool cache for a project
@@ -36,6 +39,10 @@ type AllToolsCache struct {
 	Python     PythonToolsCache     `json:"python"`
 	JSON       JSONToolsCache       `json:"json"`
 	Markdown   MarkdownToolsCache   `json:"markdown"`
+	YAML       YAMLToolsCache       `json:"yaml"`
+	Shell      ShellToolsCache      `json:"shell"`
+	Dockerfile DockerfileToolsCache `json:"dockerfile"`
+	CSS        CSSToolsCache        `json:"css"`
 
 	// System tools used across linters
 	System  SystemToolsCache  `json:"system"`
@@ -52,6 +59,7 @@ type GoToolsCache struct {
 	GoMod        *ToolInfo `json:"gomod,omitempty"`
 	GoVet        *ToolInfo `json:"govet,omitempty"`
 	StaticCheck  *ToolInfo `json:"staticcheck,omitempty"`
+	Govulncheck  *ToolInfo `json:"govulncheck,omitempty"`
 }
 
 // JavaScript/TypeScript ecosystem tools
@@ -84,12 +92,14 @@ type PythonToolsCache struct {
 	UV      *ToolInfo `json:"uv,omitempty"`
 
 	// Linting and formatting tools
-	Ruff   *ToolInfo `json:"ruff,omitempty"`
-	Black  *ToolInfo `json:"black,omitempty"`
-	Isort  *ToolInfo `json:"isort,omitempty"`
-	Pylint *ToolInfo `json:"pylint,omitempty"`
-	Flake8 *ToolInfo `json:"flake8,omitempty"`
-	Mypy   *ToolInfo `json:"mypy,omitempty"`
+	Ruff    *ToolInfo `json:"ruff,omitempty"`
+	Black   *ToolInfo `json:"black,omitempty"`
+	Isort   *ToolInfo `json:"isort,omitempty"`
+	Pylint  *ToolInfo `json:"pylint,omitempty"`
+	Flake8  *ToolInfo `json:"flake8,omitempty"`
+	Mypy    *ToolInfo `json:"mypy,omitempty"`
+	Pyright *ToolInfo `json:"pyright,omitempty"`
+	Bandit  *ToolInfo `json:"bandit,omitempty"`
 
 	// Testing tools
 	Pytest *ToolInfo `json:"pytest,omitempty"`
@@ -110,6 +120,28 @@ type MarkdownToolsCache struct {
 	Vale         *ToolInfo `json:"vale,omitempty"`
 }
 
+// YAML ecosystem tools
+type YAMLToolsCache struct {
+	Yamllint *ToolInfo `json:"yamllint,omitempty"`
+}
+
+// Shell script tools
+type ShellToolsCache struct {
+	Shellcheck *ToolInfo `json:"shellcheck,omitempty"`
+	Bash       *ToolInfo `json:"bash,omitempty"`
+	Zsh        *ToolInfo `json:"zsh,omitempty"`
+}
+
+// Dockerfile tools
+type DockerfileToolsCache struct {
+	Hadolint *ToolInfo `json:"hadolint,omitempty"`
+}
+
+// Stylesheet tools
+type CSSToolsCache struct {
+	Stylelint *ToolInfo `json:"stylelint,omitempty"`
+}
+
 // System tools used across multiple linters
 type SystemToolsCache struct {
 	Grep    *ToolInfo `json:"grep,omitempty"`
@@ -213,6 +245,23 @@ type SystemMetrics struct {
 	Shell        string `json:"shell"`
 }
 
+// ToolCache is the interface linters use to discover external tools.
+// The disk-backed CacheManager is the default implementation; MemoryCache
+// provides a hermetic implementation for tests.
+type ToolCache interface {
+	// GetTool returns cached tool information, or nil if the tool is unknown
+	GetTool(category, toolName string) *ToolInfo
+
+	// UpdateTool stores tool information in the cache
+	UpdateTool(category, toolName string, info *ToolInfo) error
+
+	// DiscoverTool returns fresh tool information, discovering it if needed
+	DiscoverTool(category, toolName string) (*ToolInfo, error)
+}
+
+// Ensure CacheManager implements ToolCache
+var _ ToolCache = (*CacheManager)(nil)
+
 // CacheManager manages the universal tool cache
 type CacheManager struct {
 	gitRoot     string
@@ -222,38 +271,32 @@ type CacheManager struct {
 	initialized bool
 }
 
-// Global cache manager instance
-var globalCacheManager *CacheManager
-var cacheManagerOnce sync.Once
-
-// GetCacheManager returns the global cache manager for the current project
-func GetCacheManager(currentPath string) (*CacheManager, error) {
+// NewCacheManager creates a cache manager for the project containing currentPath.
+// Each call returns an independent manager backed by .claude/gismo-tools.json.
+func NewCacheManager(currentPath string) (*CacheManager, error) {
 	// Find .claude directory using existing config pattern
 	claudeDir, err := findClaudeDir(currentPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find .claude directory: %w", err)
 	}
 
-	cacheManagerOnce.Do(func() {
-		globalCacheManager = &CacheManager{
-			gitRoot:   claudeDir,
-			cachePath: filepath.Join(claudeDir, "gismo-tools.json"),
-		}
-	})
-
-	if globalCacheManager.gitRoot != claudeDir {
-		// Different .claude directory, create new cache manager
-		return &CacheManager{
-			gitRoot:   claudeDir,
-			cachePath: filepath.Join(claudeDir, "gismo-tools.json"),
-		}, nil
+	manager := &CacheManager{
+		gitRoot:   claudeDir,
+		cachePath: filepath.Join(claudeDir, "gismo-tools.json"),
 	}
 
-	if err := globalCacheManager.ensureInitialized(); err != nil {
+	if err := manager.ensureInitialized(); err != nil {
 		return nil, fmt.Errorf("failed to initialize cache: %w", err)
 	}
 
-	return globalCacheManager, nil
+	return manager, nil
+}
+
+// GetCacheManager returns a cache manager for the current project.
+//
+// Deprecated: use NewCacheManager and pass the result to linters explicitly.
+func GetCacheManager(currentPath string) (*CacheManager, error) {
+	return NewCacheManager(currentPath)
 }
 
 // ensureInitialized loads or creates the cache file
@@ -388,6 +431,14 @@ func (c *CacheManager) getToolByPath(category, toolName string) *ToolInfo {
 		return c.getJSONTool(tools.JSON, toolName)
 	case "markdown":
 		return c.getMarkdownTool(tools.Markdown, toolName)
+	case "yaml":
+		return c.getYAMLTool(tools.YAML, toolName)
+	case "shell":
+		return c.getShellTool(tools.Shell, toolName)
+	case "dockerfile":
+		return c.getDockerfileTool(tools.Dockerfile, toolName)
+	case "css":
+		return c.getCSSTool(tools.CSS, toolName)
 	case "system":
 		return c.getSystemTool(tools.System, toolName)
 	case "git":
@@ -416,6 +467,8 @@ func (c *CacheManager) getGoTool(tools GoToolsCache, toolName string) *ToolInfo
 		return tools.GoVet
 	case "staticcheck":
 		return tools.StaticCheck
+	case "govulncheck":
+		return tools.Govulncheck
 	}
 	return nil
 }
@@ -472,6 +525,10 @@ func (c *CacheManager) getPythonTool(tools PythonToolsCache, toolName string) *T
 		return tools.Flake8
 	case "mypy":
 		return tools.Mypy
+	case "pyright":
+		return tools.Pyright
+	case "bandit":
+		return tools.Bandit
 	case "pytest":
 		return tools.Pytest
 	}
@@ -504,6 +561,39 @@ func (c *CacheManager) getMarkdownTool(tools MarkdownToolsCache, toolName string
 	return nil
 }
 
+func (c *CacheManager) getYAMLTool(tools YAMLToolsCache, toolName string) *ToolInfo {
+	if toolName == "yamllint" {
+		return tools.Yamllint
+	}
+	return nil
+}
+
+func (c *CacheManager) getShellTool(tools ShellToolsCache, toolName string) *ToolInfo {
+	switch toolName {
+	case "shellcheck":
+		return tools.Shellcheck
+	case "bash":
+		return tools.Bash
+	case "zsh":
+		return tools.Zsh
+	}
+	return nil
+}
+
+func (c *CacheManager) getDockerfileTool(tools DockerfileToolsCache, toolName string) *ToolInfo {
+	if toolName == "hadolint" {
+		return tools.Hadolint
+	}
+	return nil
+}
+
+func (c *CacheManager) getCSSTool(tools CSSToolsCache, toolName string) *ToolInfo {
+	if toolName == "stylelint" {
+		return tools.Stylelint
+	}
+	return nil
+}
+
 func (c *CacheManager) getSystemTool(tools SystemToolsCache, toolName string) *ToolInfo {
 	switch toolName {
 	case "grep":
@@ -592,6 +682,14 @@ func (c *CacheManager) setToolByPath(category, toolName string, info *ToolInfo)
 		c.setJSONTool(&tools.JSON, toolName, info)
 	case "markdown":
 		c.setMarkdownTool(&tools.Markdown, toolName, info)
+	case "yaml":
+		c.setYAMLTool(&tools.YAML, toolName, info)
+	case "shell":
+		c.setShellTool(&tools.Shell, toolName, info)
+	case "dockerfile":
+		c.setDockerfileTool(&tools.Dockerfile, toolName, info)
+	case "css":
+		c.setCSSTool(&tools.CSS, toolName, info)
 	case "system":
 		c.setSystemTool(&tools.System, toolName, info)
 	case "git":
@@ -618,6 +716,8 @@ func (c *CacheManager) setGoTool(tools *GoToolsCache, toolName string, info *Too
 		tools.GoVet = info
 	case "staticcheck":
 		tools.StaticCheck = info
+	case "govulncheck":
+		tools.Govulncheck = info
 	}
 }
 
@@ -672,6 +772,10 @@ func (c *CacheManager) setPythonTool(tools *PythonToolsCache, toolName string, i
 		tools.Flake8 = info
 	case "mypy":
 		tools.Mypy = info
+	case "pyright":
+		tools.Pyright = info
+	case "bandit":
+		tools.Bandit = info
 	case "pytest":
 		tools.Pytest = info
 	}
@@ -701,6 +805,35 @@ func (c *CacheManager) setMarkdownTool(tools *MarkdownToolsCache, toolName strin
 	}
 }
 
+func (c *CacheManager) setYAMLTool(tools *YAMLToolsCache, toolName string, info *ToolInfo) {
+	if toolName == "yamllint" {
+		tools.Yamllint = info
+	}
+}
+
+func (c *CacheManager) setShellTool(tools *ShellToolsCache, toolName string, info *ToolInfo) {
+	switch toolName {
+	case "shellcheck":
+		tools.Shellcheck = info
+	case "bash":
+		tools.Bash = info
+	case "zsh":
+		tools.Zsh = info
+	}
+}
+
+func (c *CacheManager) setDockerfileTool(tools *DockerfileToolsCache, toolName string, info *ToolInfo) {
+	if toolName == "hadolint" {
+		tools.Hadolint = info
+	}
+}
+
+func (c *CacheManager) setCSSTool(tools *CSSToolsCache, toolName string, info *ToolInfo) {
+	if toolName == "stylelint" {
+		tools.Stylelint = info
+	}
+}
+
 func (c *CacheManager) setSystemTool(tools *SystemToolsCache, toolName string, info *ToolInfo) {
 	switch toolName {
 	case "grep":
@@ -849,11 +982,18 @@ func (c *CacheManager) getBinaryHash(path string) string {
 
 // getToolVersion attempts to get the version of a tool
 func (c *CacheManager) getToolVersion(toolName, path string) string {
+	return DetectVersion(path)
+}
+
+// DetectVersion returns the first line of a tool's version output, trying the
+// common version flags in turn, or "" if none works. linters.ParseToolVersion
+// extracts the version number.
+func DetectVersion(path string) string {
 	// Common version flags to try
 	versionFlags := []string{"--version", "-V", "-v", "version"}
 
 	for _, flag := range versionFlags {
-		if version := c.tryGetVersion(path, flag); version != "" {
+		if version := tryGetVersion(path, flag); version != "" {
 			return version
 		}
 	}
@@ -862,15 +1002,16 @@ func (c *CacheManager) getToolVersion(toolName, path string) string {
 }
 
 // tryGetVersion attempts to get version using a specific flag
-func (c *CacheManager) tryGetVersion(path, flag string) string {
+func tryGetVersion(path, flag string) string {
 	cmd := exec.Command(path, flag)
-	output, err := cmd.Output()
-	if err != nil {
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	if err := linters.Run(cmd); err != nil {
 		return ""
 	}
 
 	// Extract version from output (simple approach)
-	versionStr := strings.TrimSpace(string(output))
+	versionStr := strings.TrimSpace(output.String())
 	lines := strings.Split(versionStr, "\n")
 	if len(lines) > 0 {
 		return strings.TrimSpace(lines[0])
@@ -0,0 +1,64 @@
+package toolcache
+
+import (
+	"sync"
+	"time"
+)
+
+// MemoryCache is an in-memory ToolCache that never touches the filesystem or PATH.
+// It is intended for tests that need deterministic tool discovery.
+type MemoryCache struct {
+	mu    sync.RWMutex
+	tools map[string]*ToolInfo
+}
+
+// Ensure MemoryCache implements ToolCache
+var _ ToolCache = (*MemoryCache)(nil)
+
+// NewMemoryCache creates an empty in-memory tool cache
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{
+		tools: make(map[string]*ToolInfo),
+	}
+}
+
+// memoryKey builds the map key for a category/tool pair
+func memoryKey(category, toolName string) string {
+	return category + "/" + toolName
+}
+
+// GetTool retrieves cached tool information
+func (m *MemoryCache) GetTool(category, toolName string) *ToolInfo {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.tools[memoryKey(category, toolName)]
+}
+
+// UpdateTool stores tool information in memory
+func (m *MemoryCache) UpdateTool(category, toolName string, info *ToolInfo) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tools[memoryKey(category, toolName)] = info
+	return nil
+}
+
+// DiscoverTool returns the registered tool, or an unavailable entry if none was registered
+func (m *MemoryCache) DiscoverTool(category, toolName string) (*ToolInfo, error) {
+	if tool := m.GetTool(category, toolName); tool != nil {
+		return tool, nil
+	}
+	return &ToolInfo{
+		Available: false,
+		LastCheck: time.Now(),
+	}, nil
+}
+
+// AddTool registers an available tool at the given path
+func (m *MemoryCache) AddTool(category, toolName, path string) {
+	_ = m.UpdateTool(category, toolName, &ToolInfo{
+		Path:      path,
+		Available: true,
+		LastCheck: time.Now(),
+		Source:    "memory",
+	})
+}
@@ -0,0 +1,48 @@
+package toolcache
+
+import (
+	"testing"
+)
+
+func TestMemoryCache_DiscoverTool(t *testing.T) {
+	cache := NewMemoryCache()
+
+	tool, err := cache.DiscoverTool("go", "gofmt")
+	if err != nil {
+		t.Fatalf("DiscoverTool failed: %v", err)
+	}
+	if tool.Available {
+		t.Error("Expected unregistered tool to be unavailable")
+	}
+	if cache.GetTool("go", "gofmt") != nil {
+		t.Error("DiscoverTool should not store unregistered tools")
+	}
+
+	cache.AddTool("go", "gofmt", "/fake/gofmt")
+	tool, err = cache.DiscoverTool("go", "gofmt")
+	if err != nil {
+		t.Fatalf("DiscoverTool failed: %v", err)
+	}
+	if !tool.Available || tool.Path != "/fake/gofmt" {
+		t.Errorf("Expected registered tool, got %+v", tool)
+	}
+
+	// Categories are independent
+	if cache.GetTool("python", "gofmt") != nil {
+		t.Error("Expected tool lookup to be scoped by category")
+	}
+}
+
+func TestMemoryCache_UpdateTool(t *testing.T) {
+	var cache ToolCache = NewMemoryCache()
+
+	info := &ToolInfo{Path: "/fake/ruff", Available: true, Version: "0.5.0"}
+	if err := cache.UpdateTool("python", "ruff", info); err != nil {
+		t.Fatalf("UpdateTool failed: %v", err)
+	}
+
+	got := cache.GetTool("python", "ruff")
+	if got == nil || got.Version != "0.5.0" {
+		t.Errorf("Expected updated tool info, got %+v", got)
+	}
+}
@@ -0,0 +1,223 @@
+package toolcache
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// projectCacheTTL is how long discovered project layouts are reused
+const projectCacheTTL = time.Hour
+
+// projectManifests maps package manifest files to the project type they indicate
+var projectManifests = map[string]string{
+	"go.mod":         "go",
+	"package.json":   "javascript",
+	"pyproject.toml": "python",
+	"setup.py":       "python",
+	"Cargo.toml":     "rust",
+}
+
+// toolConfigFiles maps tool configuration files to the tool that reads them
+var toolConfigFiles = map[string]string{
+	".golangci.yml":      "golangci-lint",
+	".golangci.yaml":     "golangci-lint",
+	".golangci.toml":     "golangci-lint",
+	"biome.json":         "biome",
+	"biome.jsonc":        "biome",
+	".eslintrc.json":     "eslint",
+	".eslintrc.js":       "eslint",
+	".eslintrc.cjs":      "eslint",
+	"eslint.config.js":   "eslint",
+	"eslint.config.mjs":  "eslint",
+	".oxlintrc.json":     "oxlint",
+	"tsconfig.json":      "tsc",
+	"ruff.toml":          "ruff",
+	".ruff.toml":         "ruff",
+	"mypy.ini":           "mypy",
+	".markdownlint.json": "markdownlint",
+	".markdownlint.yaml": "markdownlint",
+	"rustfmt.toml":       "rustfmt",
+	".rustfmt.toml":      "rustfmt",
+	"clippy.toml":        "clippy",
+	"buf.yaml":           "buf",
+	".protolint.yaml":    "protolint",
+}
+
+// skippedProjectDirs are never searched for projects: dependencies, build output
+// and virtual environments contain manifests that aren't part of the repository
+var skippedProjectDirs = map[string]bool{
+	".git": true, "node_modules": true, "vendor": true, "target": true,
+	".venv": true, "venv": true, "__pycache__": true, "dist": true, "build": true,
+}
+
+// DiscoverProjects scans root for package manifests (go.mod, package.json,
+// pyproject.toml, setup.py, Cargo.toml) and returns a ProjectConfig for every
+// directory containing one, keyed by slash-separated path relative to root ("."
+// for root itself). When nested projects are found, root is recorded as their
+// WorkspaceRoot and lists them in SubProjects.
+func DiscoverProjects(root string) (map[string]ProjectConfig, error) {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get absolute path: %w", err)
+	}
+
+	now := time.Now()
+	projects := make(map[string]ProjectConfig)
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// Unreadable directories are skipped rather than failing discovery
+			if d != nil && d.IsDir() && path != root {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if path != root && (skippedProjectDirs[d.Name()] || strings.HasPrefix(d.Name(), ".")) {
+			return filepath.SkipDir
+		}
+
+		project, ok := inspectProjectDir(root, path)
+		if !ok {
+			return nil
+		}
+		project.LastDiscovered = now
+		rel, _ := filepath.Rel(root, path)
+		projects[filepath.ToSlash(rel)] = project
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover projects: %w", err)
+	}
+
+	var subProjects []string
+	for rel := range projects {
+		if rel != "." {
+			subProjects = append(subProjects, rel)
+		}
+	}
+	if len(subProjects) == 0 {
+		return projects, nil
+	}
+	sort.Strings(subProjects)
+
+	// Record the monorepo root, even when it has no manifest of its own
+	workspace, ok := projects["."]
+	if !ok {
+		workspace = ProjectConfig{ProjectType: []string{"mixed"}, LastDiscovered: now}
+	}
+	workspace.SubProjects = subProjects
+	projects["."] = workspace
+	for _, rel := range subProjects {
+		project := projects[rel]
+		project.WorkspaceRoot = "."
+		projects[rel] = project
+	}
+	return projects, nil
+}
+
+// inspectProjectDir returns the project rooted at dir, if dir contains a manifest
+func inspectProjectDir(root, dir string) (ProjectConfig, bool) {
+	entries, err := readDirNames(dir)
+	if err != nil {
+		return ProjectConfig{}, false
+	}
+
+	project := ProjectConfig{
+		ConfigFiles:  make(map[string]string),
+		PackageFiles: make(map[string]string),
+	}
+	types := make(map[string]bool)
+	for _, name := range entries {
+		rel, _ := filepath.Rel(root, filepath.Join(dir, name))
+		if projectType, ok := projectManifests[name]; ok {
+			project.PackageFiles[name] = filepath.ToSlash(rel)
+			types[projectType] = true
+		}
+		if tool, ok := toolConfigFiles[name]; ok {
+			if _, seen := project.ConfigFiles[tool]; !seen {
+				project.ConfigFiles[tool] = filepath.ToSlash(rel)
+			}
+		}
+	}
+	if len(types) == 0 {
+		return ProjectConfig{}, false
+	}
+
+	for projectType := range types {
+		project.ProjectType = append(project.ProjectType, projectType)
+	}
+	sort.Strings(project.ProjectType)
+	return project, true
+}
+
+// readDirNames returns the sorted names of the entries in dir
+func readDirNames(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// FindProject returns the innermost project containing relPath, a slash-separated
+// path relative to the discovery root
+func FindProject(projects map[string]ProjectConfig, relPath string) (string, ProjectConfig, bool) {
+	dir := filepath.ToSlash(filepath.Dir(filepath.FromSlash(relPath)))
+	for {
+		if project, ok := projects[dir]; ok && !isWorkspaceOnly(project) {
+			return dir, project, true
+		}
+		if dir == "." || dir == "/" || dir == "" {
+			return "", ProjectConfig{}, false
+		}
+		dir = filepath.ToSlash(filepath.Dir(filepath.FromSlash(dir)))
+	}
+}
+
+// isWorkspaceOnly reports whether project is a synthesized monorepo root without a manifest
+func isWorkspaceOnly(project ProjectConfig) bool {
+	return len(project.PackageFiles) == 0
+}
+
+// Projects returns the projects under root, rediscovering them when the cached
+// layout is missing or older than an hour
+func (c *CacheManager) Projects(root string) (map[string]ProjectConfig, error) {
+	c.mu.RLock()
+	cached := c.cache.Projects.Configs
+	fresh := len(cached) > 0
+	for _, project := range cached {
+		if time.Since(project.LastDiscovered) > projectCacheTTL {
+			fresh = false
+			break
+		}
+	}
+	c.mu.RUnlock()
+	if fresh {
+		return cached, nil
+	}
+
+	projects, err := DiscoverProjects(root)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cache.Projects.Configs = projects
+	if err := c.save(); err != nil {
+		return projects, err
+	}
+	return projects, nil
+}
@@ -0,0 +1,152 @@
+package toolcache
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// writeTree creates files (slash-separated paths) under root
+func writeTree(t *testing.T, root string, files ...string) {
+	t.Helper()
+	for _, file := range files {
+		path := filepath.Join(root, filepath.FromSlash(file))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestDiscoverProjects(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root,
+		"services/api/go.mod",
+		"services/api/.golangci.yml",
+		"web/package.json",
+		"web/biome.json",
+		"web/node_modules/dep/package.json",
+		"tools/gen/pyproject.toml",
+		"tools/gen/package.json",
+		".hidden/go.mod",
+		"README.md",
+	)
+
+	projects, err := DiscoverProjects(root)
+	if err != nil {
+		t.Fatalf("DiscoverProjects() error = %v", err)
+	}
+
+	workspace, ok := projects["."]
+	if !ok || !reflect.DeepEqual(workspace.ProjectType, []string{"mixed"}) {
+		t.Fatalf("workspace root = %+v, want mixed root", workspace)
+	}
+	if want := []string{"services/api", "tools/gen", "web"}; !reflect.DeepEqual(workspace.SubProjects, want) {
+		t.Errorf("SubProjects = %v, want %v", workspace.SubProjects, want)
+	}
+
+	tests := []struct {
+		dir         string
+		types       []string
+		packageFile string
+		tool        string
+	}{
+		{"services/api", []string{"go"}, "go.mod", "golangci-lint"},
+		{"web", []string{"javascript"}, "package.json", "biome"},
+		{"tools/gen", []string{"javascript", "python"}, "pyproject.toml", ""},
+	}
+	for _, tt := range tests {
+		project, ok := projects[tt.dir]
+		if !ok {
+			t.Errorf("project %s not found", tt.dir)
+			continue
+		}
+		if !reflect.DeepEqual(project.ProjectType, tt.types) || project.WorkspaceRoot != "." {
+			t.Errorf("%s = %+v, want types %v in workspace .", tt.dir, project, tt.types)
+		}
+		if project.PackageFiles[tt.packageFile] != tt.dir+"/"+tt.packageFile {
+			t.Errorf("%s package files = %v", tt.dir, project.PackageFiles)
+		}
+		if tt.tool != "" && project.ConfigFiles[tt.tool] == "" {
+			t.Errorf("%s config files = %v, want %s", tt.dir, project.ConfigFiles, tt.tool)
+		}
+	}
+
+	if len(projects) != 4 {
+		t.Errorf("got %d projects, want 4 (dependencies and hidden directories skipped): %v", len(projects), projects)
+	}
+}
+
+func TestDiscoverProjects_SingleProject(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root, "go.mod", "internal/x.go")
+
+	projects, err := DiscoverProjects(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(projects) != 1 || len(projects["."].SubProjects) != 0 || projects["."].ProjectType[0] != "go" {
+		t.Errorf("DiscoverProjects() = %+v, want single go project", projects)
+	}
+}
+
+func TestFindProject(t *testing.T) {
+	projects := map[string]ProjectConfig{
+		".":            {ProjectType: []string{"mixed"}, SubProjects: []string{"services/api"}},
+		"services/api": {ProjectType: []string{"go"}, PackageFiles: map[string]string{"go.mod": "services/api/go.mod"}},
+	}
+
+	tests := []struct {
+		path   string
+		want   string
+		wantOK bool
+	}{
+		{"services/api/internal/handler.go", "services/api", true},
+		{"services/api/main.go", "services/api", true},
+		{"services/other/main.go", "", false},
+		{"README.md", "", false},
+	}
+	for _, tt := range tests {
+		got, _, ok := FindProject(projects, tt.path)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("FindProject(%q) = %q, %v; want %q, %v", tt.path, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestCacheManager_Projects(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".claude"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeTree(t, root, "a/go.mod")
+
+	manager, err := NewCacheManager(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	projects, err := manager.Projects(root)
+	if err != nil || projects["a"].ProjectType[0] != "go" {
+		t.Fatalf("Projects() = %v, %v", projects, err)
+	}
+
+	// Fresh layouts are served from the cache file without rescanning
+	writeTree(t, root, "b/Cargo.toml")
+	reloaded, err := NewCacheManager(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	projects, err = reloaded.Projects(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := projects["b"]; ok {
+		t.Error("expected cached layout, got rediscovered projects")
+	}
+	if _, ok := projects["a"]; !ok {
+		t.Errorf("cached layout missing project a: %v", projects)
+	}
+}
@@ -0,0 +1,50 @@
+package gismo
+
+import (
+	"path/filepath"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+// DefaultToolCacheDir is where tool-internal caches live, relative to the project root
+const DefaultToolCacheDir = ".claude/gismo-cache"
+
+// ToolCachesConfig pins golangci-lint, ruff, ESLint and optionally cargo caches
+// to stable locations so they survive across hook invocations
+type ToolCachesConfig struct {
+	// Enabled pins tool caches, default true
+	Enabled *bool `json:"enabled,omitempty"`
+	// Dir is the cache root, relative to the project root unless absolute
+	Dir *string `json:"dir,omitempty"`
+	// CargoTargetDir also points CARGO_TARGET_DIR into the cache root, default false
+	CargoTargetDir *bool `json:"cargoTargetDir,omitempty"`
+}
+
+// IsToolCachesEnabled checks if tool caches are pinned under the cache root
+func (c *AppConfig) IsToolCachesEnabled() bool {
+	if c == nil || c.ToolCaches == nil || c.ToolCaches.Enabled == nil {
+		return true // default to enabled
+	}
+	return *c.ToolCaches.Enabled
+}
+
+// GetCacheDirs returns the tool cache locations for a project root
+func (c *AppConfig) GetCacheDirs(projectRoot string) linters.CacheDirs {
+	if !c.IsToolCachesEnabled() {
+		return linters.CacheDirs{}
+	}
+	dir := DefaultToolCacheDir
+	var cargoTarget bool
+	if c != nil && c.ToolCaches != nil {
+		if c.ToolCaches.Dir != nil {
+			dir = *c.ToolCaches.Dir
+		}
+		if c.ToolCaches.CargoTargetDir != nil {
+			cargoTarget = *c.ToolCaches.CargoTargetDir
+		}
+	}
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(projectRoot, filepath.FromSlash(dir))
+	}
+	return linters.CacheDirs{Root: dir, CargoTarget: cargoTarget}
+}
@@ -0,0 +1,32 @@
+package gismo
+
+import (
+	"testing"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+func TestAppConfig_GetCacheDirs(t *testing.T) {
+	disabled, enabled, absDir, relDir := false, true, "/var/cache/gismo", "build/cache"
+	tests := []struct {
+		name   string
+		config *AppConfig
+		want   linters.CacheDirs
+	}{
+		{"default", nil, linters.CacheDirs{Root: "/repo/.claude/gismo-cache"}},
+		{"disabled", &AppConfig{ToolCaches: &ToolCachesConfig{Enabled: &disabled}}, linters.CacheDirs{}},
+		{"relative dir", &AppConfig{ToolCaches: &ToolCachesConfig{Dir: &relDir}}, linters.CacheDirs{Root: "/repo/build/cache"}},
+		{
+			"absolute dir with cargo",
+			&AppConfig{ToolCaches: &ToolCachesConfig{Dir: &absDir, CargoTargetDir: &enabled}},
+			linters.CacheDirs{Root: "/var/cache/gismo", CargoTarget: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.config.GetCacheDirs("/repo"); got != tt.want {
+				t.Errorf("GetCacheDirs() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package gismo
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+// TuneProposal is a candidate policy change replayed against recorded blocks
+type TuneProposal struct {
+	// DisableRules drops issues for these rules
+	DisableRules []string
+	// Severities changes the severity of issues by rule; only "error" blocks
+	Severities map[string]string
+	// IgnorePatterns skips files matching these rule patterns
+	IgnorePatterns []string
+}
+
+// RuleCount is the number of blocks a rule caused
+type RuleCount struct {
+	Rule   string
+	Blocks int
+}
+
+// TuneResult summarizes a replay of recorded blocks under a proposal
+type TuneResult struct {
+	Sessions int
+	Blocks   int
+	Avoided  int
+	// Remaining lists the rules that would still block, most frequent first
+	Remaining []RuleCount
+}
+
+// ReplayBlocks replays the blocks recorded in session state since the given time
+// (zero for all) and counts how many would not have happened under the proposal.
+// A block is avoided when none of its error issues still blocks.
+func ReplayBlocks(states map[string]*SessionState, since time.Time, proposal TuneProposal) TuneResult {
+	disabled := make(map[string]bool, len(proposal.DisableRules))
+	for _, rule := range proposal.DisableRules {
+		disabled[rule] = true
+	}
+
+	var result TuneResult
+	remaining := make(map[string]int)
+	for _, state := range states {
+		counted := false
+		for _, block := range state.Blocks {
+			if block.At.Before(since) {
+				continue
+			}
+			if !counted {
+				result.Sessions++
+				counted = true
+			}
+			result.Blocks++
+
+			rules := blockingRules(block, disabled, proposal)
+			if len(rules) == 0 {
+				result.Avoided++
+				continue
+			}
+			for _, rule := range rules {
+				remaining[rule]++
+			}
+		}
+	}
+
+	for rule, blocks := range remaining {
+		result.Remaining = append(result.Remaining, RuleCount{Rule: rule, Blocks: blocks})
+	}
+	sort.Slice(result.Remaining, func(i, j int) bool {
+		if result.Remaining[i].Blocks != result.Remaining[j].Blocks {
+			return result.Remaining[i].Blocks > result.Remaining[j].Blocks
+		}
+		return result.Remaining[i].Rule < result.Remaining[j].Rule
+	})
+	return result
+}
+
+// blockingRules returns the distinct rules of a block that still block under the proposal
+func blockingRules(block BlockRecord, disabled map[string]bool, proposal TuneProposal) []string {
+	for _, pattern := range proposal.IgnorePatterns {
+		if matchRulePattern(pattern, block.File) {
+			return nil
+		}
+	}
+
+	seen := make(map[string]bool)
+	var rules []string
+	for _, issue := range block.Issues {
+		if !stillBlocks(issue, disabled, proposal.Severities) || seen[issue.Rule] {
+			continue
+		}
+		seen[issue.Rule] = true
+		rules = append(rules, issue.Rule)
+	}
+	return rules
+}
+
+// stillBlocks reports whether an issue remains an error under the proposal
+func stillBlocks(issue linters.Issue, disabled map[string]bool, severities map[string]string) bool {
+	if disabled[issue.Rule] {
+		return false
+	}
+	severity := issue.Severity
+	if override, ok := severities[issue.Rule]; ok {
+		severity = strings.ToLower(override)
+	}
+	return severity == "error"
+}
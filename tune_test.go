@@ -0,0 +1,66 @@
+package gismo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jrossi/gismo/linters"
+)
+
+func TestReplayBlocks(t *testing.T) {
+	now := time.Now()
+	states := map[string]*SessionState{
+		"s1": {Blocks: []BlockRecord{
+			{File: "main.go", At: now, Issues: []linters.Issue{{Severity: "error", Rule: "errcheck"}}},
+			{File: "main.go", At: now, Issues: []linters.Issue{
+				{Severity: "error", Rule: "errcheck"},
+				{Severity: "error", Rule: "govet"},
+			}},
+		}},
+		"s2": {Blocks: []BlockRecord{
+			{File: "README.md", At: now, Issues: []linters.Issue{{Severity: "error", Rule: "MD013"}}},
+			{File: "old.md", At: now.Add(-48 * time.Hour), Issues: []linters.Issue{{Severity: "error", Rule: "MD013"}}},
+		}},
+	}
+
+	tests := []struct {
+		name     string
+		since    time.Time
+		proposal TuneProposal
+		sessions int
+		blocks   int
+		avoided  int
+		top      string
+	}{
+		{name: "baseline", blocks: 4, sessions: 2, top: "MD013"},
+		{name: "since excludes old blocks", since: now.Add(-time.Hour), blocks: 3, sessions: 2, top: "errcheck"},
+		{
+			name:     "disable rule avoids only blocks it fully caused",
+			proposal: TuneProposal{DisableRules: []string{"errcheck"}},
+			blocks:   4, sessions: 2, avoided: 1, top: "MD013",
+		},
+		{
+			name:     "severity downgrade",
+			proposal: TuneProposal{Severities: map[string]string{"MD013": "warning"}},
+			blocks:   4, sessions: 2, avoided: 2, top: "errcheck",
+		},
+		{
+			name:     "ignore pattern",
+			proposal: TuneProposal{IgnorePatterns: []string{"*.go"}},
+			blocks:   4, sessions: 2, avoided: 2, top: "MD013",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ReplayBlocks(states, tt.since, tt.proposal)
+			if result.Sessions != tt.sessions || result.Blocks != tt.blocks || result.Avoided != tt.avoided {
+				t.Errorf("ReplayBlocks() = %d sessions, %d blocks, %d avoided; want %d, %d, %d",
+					result.Sessions, result.Blocks, result.Avoided, tt.sessions, tt.blocks, tt.avoided)
+			}
+			if len(result.Remaining) == 0 || result.Remaining[0].Rule != tt.top {
+				t.Errorf("top remaining rule = %+v, want %s", result.Remaining, tt.top)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+// Package types holds value types shared by the gismo configuration and the
+// linter configurations
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+)
+
+// legacyNanosecondThreshold separates integer milliseconds from the bare
+// nanosecond counts older configs used. A billion milliseconds is over eleven
+// days and a billion nanoseconds is one second, so neither side is a sensible
+// timeout in the other unit.
+const legacyNanosecondThreshold = 1e9
+
+// Duration is a time.Duration that reads from JSON as a Go duration string such
+// as "90s" or "2m", or as an integer number of milliseconds. Integers of a
+// billion or more are read as nanoseconds, the legacy numeric format. It is
+// always written as a duration string.
+type Duration struct {
+	time.Duration
+}
+
+// NewDuration returns a pointer to a Duration of d, for optional config fields
+func NewDuration(d time.Duration) *Duration {
+	return &Duration{Duration: d}
+}
+
+// UnmarshalJSON implements json.Unmarshaler for Duration
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	switch value := v.(type) {
+	case string:
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		d.Duration = duration
+		return nil
+	case float64:
+		if value != math.Trunc(value) {
+			return fmt.Errorf("invalid duration %v: numbers must be whole milliseconds", value)
+		}
+		if math.Abs(value) >= legacyNanosecondThreshold {
+			d.Duration = time.Duration(value)
+		} else {
+			d.Duration = time.Duration(value) * time.Millisecond
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid duration %s: use a string such as \"90s\" or integer milliseconds", b)
+	}
+}
+
+// MarshalJSON implements json.Marshaler for Duration
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.Duration.String())
+}
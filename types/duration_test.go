@@ -0,0 +1,62 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestDuration_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{name: "seconds", input: `"90s"`, want: 90 * time.Second},
+		{name: "minutes", input: `"2m"`, want: 2 * time.Minute},
+		{name: "complex", input: `"1h30m45s"`, want: time.Hour + 30*time.Minute + 45*time.Second},
+		{name: "integer milliseconds", input: `1500`, want: 1500 * time.Millisecond},
+		{name: "zero", input: `0`, want: 0},
+		{name: "legacy nanoseconds", input: `120000000000`, want: 2 * time.Minute},
+		{name: "fractional milliseconds", input: `1.5`, wantErr: true},
+		{name: "invalid string", input: `"soon"`, wantErr: true},
+		{name: "string without unit", input: `"90"`, wantErr: true},
+		{name: "boolean", input: `true`, wantErr: true},
+		{name: "object", input: `{"Duration": 5}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d Duration
+			err := json.Unmarshal([]byte(tt.input), &d)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UnmarshalJSON(%s) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr && d.Duration != tt.want {
+				t.Errorf("UnmarshalJSON(%s) = %v, want %v", tt.input, d.Duration, tt.want)
+			}
+		})
+	}
+}
+
+func TestDuration_MarshalJSON(t *testing.T) {
+	config := struct {
+		Timeout *Duration `json:"timeout"`
+	}{Timeout: NewDuration(90 * time.Second)}
+
+	got, err := json.Marshal(config)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if string(got) != `{"timeout":"1m30s"}` {
+		t.Errorf("Marshal() = %s", got)
+	}
+
+	var back struct {
+		Timeout *Duration `json:"timeout"`
+	}
+	if err := json.Unmarshal(got, &back); err != nil || back.Timeout.Duration != 90*time.Second {
+		t.Errorf("round trip = %v, %v", back.Timeout, err)
+	}
+}
@@ -0,0 +1,38 @@
+package gismo
+
+import (
+	"time"
+
+	"github.com/jrossi/gismo/types"
+)
+
+// DefaultUpdateCheckAfter is how long a newer release must have been out before
+// hooks warn about it by default
+const DefaultUpdateCheckAfter = 30 * 24 * time.Hour
+
+// UpdateCheckConfig controls the warning hooks add when gismo is out of date
+type UpdateCheckConfig struct {
+	// Enabled turns on the warning, default false. The latest release is looked
+	// up at most once a day.
+	Enabled *bool `json:"enabled,omitempty"`
+	// After is how long a newer release must have been out before the warning,
+	// default 30 days, so installs aren't nagged right after every release
+	After *types.Duration `json:"after,omitempty"`
+}
+
+// IsUpdateCheckEnabled checks if hooks warn when gismo is out of date
+func (c *AppConfig) IsUpdateCheckEnabled() bool {
+	if c == nil || c.UpdateCheck == nil || c.UpdateCheck.Enabled == nil {
+		return false
+	}
+	return *c.UpdateCheck.Enabled
+}
+
+// GetUpdateCheckAfter returns how long a newer release must have been out
+// before hooks warn about it
+func (c *AppConfig) GetUpdateCheckAfter() time.Duration {
+	if c == nil || c.UpdateCheck == nil || c.UpdateCheck.After == nil {
+		return DefaultUpdateCheckAfter
+	}
+	return c.UpdateCheck.After.Duration
+}